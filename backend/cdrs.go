@@ -2,6 +2,23 @@ package backend
 
 import "time"
 
+// CdrIssue discrepancy found when CDR is verified
+type CdrIssue struct {
+	Severity string `json:"severity"` // Severity warning or error
+	Code     string `json:"code"`     // Code kind of discrepancy (energy, time, parking-time, cost, session)
+	Message  string `json:"message"`  // Message human readable details
+}
+
+// CdrIssueReport CDR discrepancies of a partner
+type CdrIssueReport struct {
+	PlatformId  string   `json:"platformId"`            // PlatformId partner's platform
+	PartyId     string   `json:"partyId,omitempty"`     // PartyId should be unique within country
+	CountryCode string   `json:"countryCode,omitempty"` // CountryCode alfa-2 code
+	Warnings    int      `json:"warnings"`              // Warnings number of CDRs with warnings only
+	Errors      int      `json:"errors"`                // Errors number of CDRs with errors
+	Cdrs        []string `json:"cdrs,omitempty"`        // Cdrs ids of CDRs with discrepancies
+}
+
 type Cdr struct {
 	Id                       string            `json:"id"`                             // Id uniquely identifies the session
 	StartDateTime            time.Time         `json:"startDateTime"`                  // StartDateTime timestamp of the charging cdr
@@ -25,6 +42,10 @@ type Cdr struct {
 	Credit                   bool              `json:"credit"`                         // Credit when set to true, this is a Credit CDR, and the field credit_reference_id needs to be set as wel
 	CreditReferenceId        string            `json:"creditReferenceId,omitempty"`    // CreditReferenceId to be set for a Credit CDR
 	HomeChargingCompensation bool              `json:"homeChargingCompensation"`       // HomeChargingCompensation when set to true, this CDR is for a charging cdr using the home charge
+	TaxAmounts               []*TaxAmount      `json:"taxAmounts,omitempty"`           // TaxAmounts breakdown of the taxes applied to the total cost
+	Issues                   []*CdrIssue       `json:"issues,omitempty"`               // Issues discrepancies found by verification of CDRs received from remote platforms
+	Credited                 *Price            `json:"credited,omitempty"`             // Credited total amount credited by credit CDRs referencing this CDR (read only)
+	CreditCdrIds             []string          `json:"creditCdrIds,omitempty"`         // CreditCdrIds credit CDRs issued against this CDR (read only)
 	LastUpdated              time.Time         `json:"lastUpdated"`                    // LastUpdated when this Tariff was last updated
 	PlatformId               string            `json:"platformId"`                     // PlatformId rel to platform
 	RefId                    string            `json:"refId"`                          // RefId any external relation
@@ -32,6 +53,13 @@ type Cdr struct {
 	CountryCode              string            `json:"countryCode,omitempty"`          // CountryCode alfa-2 code
 }
 
+// CdrCreditRequest request to issue a credit CDR
+type CdrCreditRequest struct {
+	Id     string `json:"id,omitempty"`     // Id of the credit CDR, generated if empty
+	Amount *Price `json:"amount,omitempty"` // Amount to credit (positive), the whole remaining creditable amount if empty
+	Remark string `json:"remark,omitempty"` // Remark reason of the credit
+}
+
 type CdrSearchResponse struct {
 	PageInfo *PageResponse `json:"pageInfo,omitempty"`
 	Items    []*Cdr        `json:"items,omitempty"`
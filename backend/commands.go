@@ -63,12 +63,14 @@ type CancelReservationRequest struct {
 }
 
 type StartSession struct {
-	Token                  *Token   `json:"token"`                 // Token object the Charge Point has to use to start a new session
-	LocationId             string   `json:"locationId"`            // LocationId on which a session is to be started
-	EvseId                 string   `json:"evseId,omitempty"`      // EvseId of the EVSE of this Location on which a session is to be started
-	ConnectorId            string   `json:"connectorId,omitempty"` // ConnectorId  of the Connector of the EVSE on which a session 	is to be started
-	AuthorizationReference string   `json:"authRef,omitempty"`     // AuthorizationReference reference to the authorization given by the eMSP
-	KwhLimit               *float64 `json:"kwhLimit,omitempty"`    // KwhLimit allows setting limit on maximum Kwh (Yandex extension, isn't a part of OCPI protocol)
+	Token                  *Token   `json:"token"`                       // Token object the Charge Point has to use to start a new session
+	LocationId             string   `json:"locationId"`                  // LocationId on which a session is to be started
+	EvseId                 string   `json:"evseId,omitempty"`            // EvseId of the EVSE of this Location on which a session is to be started
+	ConnectorId            string   `json:"connectorId,omitempty"`       // ConnectorId  of the Connector of the EVSE on which a session 	is to be started
+	AuthorizationReference string   `json:"authRef,omitempty"`           // AuthorizationReference reference to the authorization given by the eMSP
+	KwhLimit               *float64 `json:"kwhLimit,omitempty"`          // KwhLimit allows setting limit on maximum Kwh (Yandex extension, isn't a part of OCPI protocol)
+	CostLimit              *float64 `json:"costLimit,omitempty"`         // CostLimit max total cost of the session, enforced by the platform and isn't sent to CPO
+	CostLimitCurrency      string   `json:"costLimitCurrency,omitempty"` // CostLimitCurrency ISO-4217 currency code of the cost limit
 }
 
 type StopSession struct {
@@ -115,16 +117,18 @@ type CmdTokenRq struct {
 }
 
 type StartSessionRequest struct {
-	Id                     string   `json:"id"`                    // Id unique identifier
-	LocationId             string   `json:"locationId"`            // LocationId on which a session is to be started
-	EvseId                 string   `json:"evseUid"`               // EvseId of the EVSE of this Location on which a session is to be started
-	ConnectorId            string   `json:"connectorId"`           // ConnectorId  of the Connector of the EVSE on which a session 	is to be started
-	AuthorizationReference string   `json:"authRef,omitempty"`     // AuthorizationReference reference to the authorization given by the eMSP
-	Token                  Token    `json:"token"`                 // Token token info
-	PartyId                string   `json:"partyId,omitempty"`     // PartyId should be unique within country
-	CountryCode            string   `json:"countryCode,omitempty"` // CountryCode alfa-2 code
-	RefId                  string   `json:"refId,omitempty"`       // RefId any external relation
-	KwhLimit               *float64 `json:"kwhLimit,omitempty"`    // KwhLimit allows setting limit on maximum Kwh (Yandex extension, isn't a part of OCPI protocol)
+	Id                     string   `json:"id"`                          // Id unique identifier
+	LocationId             string   `json:"locationId"`                  // LocationId on which a session is to be started
+	EvseId                 string   `json:"evseUid"`                     // EvseId of the EVSE of this Location on which a session is to be started, available EVSE is selected if empty
+	ConnectorId            string   `json:"connectorId"`                 // ConnectorId  of the Connector of the EVSE on which a session 	is to be started, required if EVSE requires connector
+	AuthorizationReference string   `json:"authRef,omitempty"`           // AuthorizationReference reference to the authorization given by the eMSP
+	Token                  Token    `json:"token"`                       // Token token info
+	PartyId                string   `json:"partyId,omitempty"`           // PartyId should be unique within country
+	CountryCode            string   `json:"countryCode,omitempty"`       // CountryCode alfa-2 code
+	RefId                  string   `json:"refId,omitempty"`             // RefId any external relation
+	KwhLimit               *float64 `json:"kwhLimit,omitempty"`          // KwhLimit allows setting limit on maximum Kwh (Yandex extension, isn't a part of OCPI protocol)
+	CostLimit              *float64 `json:"costLimit,omitempty"`         // CostLimit max total cost of the session, enforced by the platform and isn't sent to CPO
+	CostLimitCurrency      string   `json:"costLimitCurrency,omitempty"` // CostLimitCurrency ISO-4217 currency code of the cost limit
 }
 
 type StopSessionRequest struct {
@@ -20,8 +20,9 @@ type BusinessDetails struct {
 }
 
 type PageResponse struct {
-	Total *int `json:"total,omitempty"` // Total number of items available by request
-	Limit *int `json:"limit,omitempty"` // Limit number of retrieved items
+	Total  *int    `json:"total,omitempty"`  // Total number of items available by request
+	Limit  *int    `json:"limit,omitempty"`  // Limit number of retrieved items
+	Cursor *string `json:"cursor,omitempty"` // Cursor to request the next page, empty if it's the last page
 }
 
 type DisplayText struct {
@@ -33,3 +34,18 @@ type PullRequest struct {
 	From *time.Time `json:"from"`
 	To   *time.Time `json:"to"`
 }
+
+type BulkItemError struct {
+	Code    string `json:"code,omitempty"`    // Code error code
+	Message string `json:"message,omitempty"` // Message error message
+}
+
+type BulkItemResult struct {
+	Id      string         `json:"id,omitempty"`    // Id item id
+	Applied bool           `json:"applied"`         // Applied true if changes are applied, false if item is rejected or later changes found
+	Error   *BulkItemError `json:"error,omitempty"` // Error populated if item is rejected
+}
+
+type BulkResponse struct {
+	Items []*BulkItemResult `json:"items,omitempty"` // Items results in order of request items
+}
@@ -0,0 +1,69 @@
+package backend
+
+import (
+	"time"
+)
+
+const (
+	DisputeStatusOpen         = "OPEN"
+	DisputeStatusAcknowledged = "ACKNOWLEDGED"
+	DisputeStatusResolved     = "RESOLVED"
+	DisputeStatusRejected     = "REJECTED"
+
+	DisputeEvidenceSession         = "session"
+	DisputeEvidenceChargingPeriods = "charging-periods"
+	DisputeEvidenceLog             = "log"
+	DisputeEvidenceDocument        = "document"
+)
+
+type DisputeEvidence struct {
+	Type        string    `json:"type"`                  // Type of evidence (session, charging-periods, log, document)
+	RefId       string    `json:"refId,omitempty"`       // RefId session id (CDR session if empty), log request id or document url
+	Description string    `json:"description,omitempty"` // Description human readable description
+	Data        any       `json:"data,omitempty"`        // Data snapshot of the session, charging periods or log messages taken when evidence is attached
+	AddedAt     time.Time `json:"addedAt,omitempty"`     // AddedAt when the evidence was attached
+}
+
+type Dispute struct {
+	Id           string             `json:"id"`                     // Id dispute id
+	Status       string             `json:"status"`                 // Status dispute status
+	CdrId        string             `json:"cdrId"`                  // CdrId disputed CDR
+	SessionId    string             `json:"sessionId,omitempty"`    // SessionId session of the disputed CDR
+	Reason       string             `json:"reason"`                 // Reason why CDR is disputed
+	Amount       Price              `json:"amount"`                 // Amount in question
+	Currency     string             `json:"currency"`               // Currency of the amount, CDR currency if empty
+	Evidence     []*DisputeEvidence `json:"evidence,omitempty"`     // Evidence attached to the dispute
+	Comment      string             `json:"comment,omitempty"`      // Comment given when status is changed
+	CreditCdrId  string             `json:"creditCdrId,omitempty"`  // CreditCdrId credit CDR the dispute is resolved by
+	Credited     *Price             `json:"credited,omitempty"`     // Credited total amount of credit CDRs received against the disputed CDR (read only)
+	CreditCdrIds []string           `json:"creditCdrIds,omitempty"` // CreditCdrIds credit CDRs received against the disputed CDR (read only)
+	ClosedAt     *time.Time         `json:"closedAt,omitempty"`     // ClosedAt when the dispute was resolved or rejected
+	LastUpdated  time.Time          `json:"lastUpdated"`            // LastUpdated when this dispute was last updated
+	PlatformId   string             `json:"platformId"`             // PlatformId platform of the disputed CDR
+	RefId        string             `json:"refId,omitempty"`        // RefId any external relation
+	PartyId      string             `json:"partyId,omitempty"`      // PartyId party of the disputed CDR
+	CountryCode  string             `json:"countryCode,omitempty"`  // CountryCode alfa-2 code
+}
+
+type DisputeStatusRequest struct {
+	Status      string `json:"status"`                // Status new status (ACKNOWLEDGED, RESOLVED, REJECTED)
+	Comment     string `json:"comment,omitempty"`     // Comment any comment
+	CreditCdrId string `json:"creditCdrId,omitempty"` // CreditCdrId credit CDR, required when dispute is resolved
+}
+
+// DisputeSummary disputes of a partner
+type DisputeSummary struct {
+	PlatformId   string             `json:"platformId"`            // PlatformId partner's platform
+	PartyId      string             `json:"partyId,omitempty"`     // PartyId should be unique within country
+	CountryCode  string             `json:"countryCode,omitempty"` // CountryCode alfa-2 code
+	Open         int                `json:"open"`                  // Open number of open disputes
+	Acknowledged int                `json:"acknowledged"`          // Acknowledged number of acknowledged disputes
+	Resolved     int                `json:"resolved"`              // Resolved number of disputes resolved by credit CDR
+	Rejected     int                `json:"rejected"`              // Rejected number of rejected disputes
+	Amounts      map[string]float64 `json:"amounts,omitempty"`     // Amounts disputed amounts excl. VAT of open and acknowledged disputes by currency
+}
+
+type DisputeSearchResponse struct {
+	PageInfo *PageResponse `json:"pageInfo,omitempty"`
+	Items    []*Dispute    `json:"items,omitempty"`
+}
@@ -105,3 +105,58 @@ func (w *webhookCall) callAsync(ctx context.Context, event string, payload any)
 func (w *webhookCall) getByEvent(ctx context.Context, event string) ([]*backend.Webhook, error) {
 	return w.webhook.Search(ctx, &backend.SearchWebhookCriteria{Event: event})
 }
+
+func (w *webhookCall) OnTerminalChanged(ctx context.Context, t *backend.Terminal) error {
+	w.l().C(ctx).Mth("on-terminal").Dbg()
+	return w.callAsync(ctx, backend.WhEventTerminalChanged, t)
+}
+
+func (w *webhookCall) OnFinancialAdviceChanged(ctx context.Context, fa *backend.FinancialAdvice) error {
+	w.l().C(ctx).Mth("on-fin-advice").Dbg()
+	return w.callAsync(ctx, backend.WhEventFinAdviceChanged, fa)
+}
+
+func (w *webhookCall) OnPlatformDisconnected(ctx context.Context, p *backend.Platform) error {
+	w.l().C(ctx).Mth("on-platform-disconnected").Dbg()
+	return w.callAsync(ctx, backend.WhEventPlatformDisconnected, p)
+}
+
+func (w *webhookCall) OnTokenRotationFailed(ctx context.Context, r *backend.TokenRotation) error {
+	w.l().C(ctx).Mth("on-token-rotation-failed").Dbg()
+	return w.callAsync(ctx, backend.WhEventTokenRotationFailed, r)
+}
+
+func (w *webhookCall) OnPlatformStatusChanged(ctx context.Context, p *backend.Platform) error {
+	w.l().C(ctx).Mth("on-platform-status-changed").Dbg()
+	return w.callAsync(ctx, backend.WhEventPlatformStatusChanged, p)
+}
+
+func (w *webhookCall) OnReservationChanged(ctx context.Context, r *backend.ChargeReservation) error {
+	w.l().C(ctx).Mth("on-reservation").Dbg()
+	return w.callAsync(ctx, backend.WhEventReservationChanged, r)
+}
+
+func (w *webhookCall) OnSessionLimitExceeded(ctx context.Context, sess *backend.Session) error {
+	w.l().C(ctx).Mth("on-sess-limit").Dbg()
+	return w.callAsync(ctx, backend.WhEventSessionLimitExceeded, sess)
+}
+
+func (w *webhookCall) OnTokenBlocked(ctx context.Context, tb *backend.TokenBlocked) error {
+	w.l().C(ctx).Mth("on-token-blocked").Dbg()
+	return w.callAsync(ctx, backend.WhEventTokenBlocked, tb)
+}
+
+func (w *webhookCall) OnSessionStale(ctx context.Context, sess *backend.Session) error {
+	w.l().C(ctx).Mth("on-sess-stale").Dbg()
+	return w.callAsync(ctx, backend.WhEventSessionStale, sess)
+}
+
+func (w *webhookCall) OnCdrMissing(ctx context.Context, sess *backend.Session) error {
+	w.l().C(ctx).Mth("on-cdr-missing").Dbg()
+	return w.callAsync(ctx, backend.WhEventCdrMissing, sess)
+}
+
+func (w *webhookCall) OnDisputeChanged(ctx context.Context, d *backend.Dispute) error {
+	w.l().C(ctx).Mth("on-dispute").Dbg()
+	return w.callAsync(ctx, backend.WhEventDisputeChanged, d)
+}
@@ -13,6 +13,7 @@ const (
 	ModuleIdSessions      = "sessions"
 	ModuleIdTariffs       = "tariffs"
 	ModuleIdTokens        = "tokens"
+	ModuleIdPayments      = "payments"
 
 	ConnectionStatusConnected = "CONNECTED"
 	ConnectionStatusOffLine   = "OFFLINE"
@@ -185,6 +186,32 @@ type Hours struct {
 	ExceptionalClosings []*ExceptionalPeriod `json:"exceptionalClosings,omitempty"` // ExceptionalClosings for specified calendar dates, time-range based
 }
 
+type Parking struct {
+	Id                    string   `json:"id"`                              // Id uniquely identifies the parking place within the location
+	PhysicalReference     string   `json:"physicalReference,omitempty"`     // PhysicalReference number/string printed on the parking place
+	VehicleTypes          []string `json:"vehicleTypes,omitempty"`          // VehicleTypes types of vehicles the parking place is intended for
+	MaxVehicleWeight      *float64 `json:"maxVehicleWeight,omitempty"`      // MaxVehicleWeight maximum vehicle weight in kg
+	MaxVehicleHeight      *float64 `json:"maxVehicleHeight,omitempty"`      // MaxVehicleHeight maximum vehicle height in cm
+	MaxVehicleLength      *float64 `json:"maxVehicleLength,omitempty"`      // MaxVehicleLength maximum vehicle length in cm
+	MaxVehicleWidth       *float64 `json:"maxVehicleWidth,omitempty"`       // MaxVehicleWidth maximum vehicle width in cm
+	ParkingSpaceLength    *float64 `json:"parkingSpaceLength,omitempty"`    // ParkingSpaceLength length of the parking space in cm
+	ParkingSpaceWidth     *float64 `json:"parkingSpaceWidth,omitempty"`     // ParkingSpaceWidth width of the parking space in cm
+	DangerousGoodsAllowed *bool    `json:"dangerousGoodsAllowed,omitempty"` // DangerousGoodsAllowed if vehicles with dangerous goods are allowed
+	Direction             string   `json:"direction,omitempty"`             // Direction of the parking place relative to the driving direction
+	DriveThrough          *bool    `json:"driveThrough,omitempty"`          // DriveThrough if the parking place is a drive-through one
+	RestrictedToType      *bool    `json:"restrictedToType,omitempty"`      // RestrictedToType if only the vehicle types listed may park
+	ReservationRequired   *bool    `json:"reservationRequired,omitempty"`   // ReservationRequired if the parking place must be reserved
+	TimeLimit             *float64 `json:"timeLimit,omitempty"`             // TimeLimit maximum parking time in minutes
+	Roofed                *bool    `json:"roofed,omitempty"`                // Roofed if the parking place is roofed
+	Lighting              *bool    `json:"lighting,omitempty"`              // Lighting if the parking place is lit
+	RefrigerationOutlet   *bool    `json:"refrigerationOutlet,omitempty"`   // RefrigerationOutlet if a refrigeration outlet is available
+}
+
+type EvseParking struct {
+	ParkingId    string `json:"parkingId"`              // ParkingId reference to the parking place of the location
+	EvsePosition string `json:"evsePosition,omitempty"` // EvsePosition position of the EVSE relative to the parking place
+}
+
 type Connector struct {
 	Id                 string    `json:"id,omitempty"`                 // Id identifier of the Connector
 	LocationId         string    `json:"locationId,omitempty"`         // LocationId link to location id
@@ -197,6 +224,7 @@ type Connector struct {
 	MaxElectricPower   *float64  `json:"maxElectricPower,omitempty"`   // MaxElectricPower maximum electric power in W
 	TariffIds          []string  `json:"tariffIds,omitempty"`          // TariffIds charging tariffs
 	TermsAndConditions string    `json:"termsAndConditions,omitempty"` // TermsAndConditions url of operator’s terms and conditions
+	Capabilities       []string  `json:"capabilities,omitempty"`       // Capabilities list of functionalities that the connector is capable of
 	PartyId            string    `json:"partyId,omitempty"`            // PartyId should be unique within country
 	CountryCode        string    `json:"countryCode,omitempty"`        // CountryCode alfa-2 code
 	RefId              string    `json:"refId,omitempty"`              // RefId any external relation
@@ -216,6 +244,7 @@ type Evse struct {
 	Directions          []*DisplayText    `json:"directions,omitempty"`          // Directions human-readable directions
 	ParkingRestrictions []string          `json:"parkingRestrictions,omitempty"` // ParkingRestrictions restrictions that apply to the parking spot
 	Images              []*Image          `json:"images,omitempty"`              // Images related to the EVSE
+	Parking             []*EvseParking    `json:"parking,omitempty"`             // Parking references to the parking places the EVSE can be reached from
 	Connectors          []*Connector      `json:"connectors,omitempty"`          // Connectors related connectors
 	PartyId             string            `json:"partyId,omitempty"`             // PartyId should be unique within country
 	CountryCode         string            `json:"countryCode,omitempty"`         // CountryCode alfa-2 code
@@ -246,6 +275,8 @@ type Location struct {
 	ChargingWhenClosed *bool                    `json:"chargingWhenClosed,omitempty"` // ChargingWhenClosed if the EVSEs are still charging outside the opening hours of the location
 	Images             []*Image                 `json:"images,omitempty"`             // Images links to images related to the location
 	EnergyMix          *EnergyMix               `json:"energyMix,omitempty"`          // EnergyMix energy supplied at this location
+	HelpPhone          string                   `json:"helpPhone,omitempty"`          // HelpPhone telephone number of the helpdesk of the operator
+	ParkingPlaces      []*Parking               `json:"parkingPlaces,omitempty"`      // ParkingPlaces parking places at the location
 	Evses              []*Evse                  `json:"evses,omitempty"`              // Evses list of evses
 	PartyId            string                   `json:"partyId,omitempty"`            // PartyId should be unique within country
 	CountryCode        string                   `json:"countryCode,omitempty"`        // CountryCode alfa-2 code
@@ -253,6 +284,20 @@ type Location struct {
 	LastUpdated        time.Time                `json:"lastUpdated"`                  // LastUpdated last updated
 }
 
+type LocationBulkRequest struct {
+	Items []*Location `json:"items,omitempty"` // Items locations with evses and connectors
+}
+
+type EvseStatus struct {
+	LocationId string `json:"locationId,omitempty"` // LocationId location id
+	EvseId     string `json:"evseId,omitempty"`     // EvseId evse id
+	Status     string `json:"status,omitempty"`     // Status new status of the EVSE
+}
+
+type EvseStatusBulkRequest struct {
+	Items []*EvseStatus `json:"items,omitempty"` // Items evse statuses
+}
+
 type LocationSearchResponse struct {
 	PageInfo *PageResponse `json:"pageInfo,omitempty"`
 	Items    []*Location   `json:"items,omitempty"`
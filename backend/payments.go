@@ -0,0 +1,50 @@
+package backend
+
+import (
+	"time"
+)
+
+const (
+	InvoiceCreatorCpo = "CPO"
+	InvoiceCreatorPtp = "PTP"
+
+	CaptureStatusSuccess        = "SUCCESS"
+	CaptureStatusPartialSuccess = "PARTIAL_SUCCESS"
+	CaptureStatusFailed         = "FAILED"
+)
+
+type Terminal struct {
+	Id                string       `json:"id"`                          // Id uniquely identifies the terminal
+	CustomerReference string       `json:"customerReference,omitempty"` // CustomerReference reference of the customer the terminal is installed for
+	Address           string       `json:"address,omitempty"`           // Address street/block name and house number
+	City              string       `json:"city,omitempty"`              // City or town
+	PostalCode        string       `json:"postalCode,omitempty"`        // PostalCode of the terminal
+	State             string       `json:"state,omitempty"`             // State or province of the terminal
+	Country           string       `json:"country,omitempty"`           // Country alpha-3 code for the country
+	Coordinates       *GeoLocation `json:"coordinates,omitempty"`       // Coordinates of the terminal
+	InvoiceBaseUrl    string       `json:"invoiceBaseUrl,omitempty"`    // InvoiceBaseUrl base url to download invoices
+	InvoiceCreator    string       `json:"invoiceCreator,omitempty"`    // InvoiceCreator party creating invoices
+	Reference         string       `json:"reference,omitempty"`         // Reference of the terminal given by the PTP
+	LocationIds       []string     `json:"locationIds,omitempty"`       // LocationIds locations the terminal is assigned to
+	EvseUids          []string     `json:"evseUids,omitempty"`          // EvseUids EVSEs the terminal is assigned to
+	LastUpdated       time.Time    `json:"lastUpdated"`                 // LastUpdated when this terminal was last updated
+	PlatformId        string       `json:"platformId"`                  // PlatformId rel to platform
+	RefId             string       `json:"refId"`                       // RefId any external relation
+	PartyId           string       `json:"partyId,omitempty"`           // PartyId should be unique within country
+	CountryCode       string       `json:"countryCode,omitempty"`       // CountryCode alfa-2 code
+}
+
+type FinancialAdvice struct {
+	Id                   string    `json:"id"`                             // Id uniquely identifies the financial advice confirmation
+	AuthRef              string    `json:"authRef"`                        // AuthRef authorization reference of the session or cdr
+	TotalCosts           Price     `json:"totalCosts"`                     // TotalCosts captured amount
+	Currency             string    `json:"currency"`                       // Currency ISO-4217 code
+	EftData              []string  `json:"eftData,omitempty"`              // EftData electronic funds transfer data received from the terminal
+	CaptureStatusCode    string    `json:"captureStatusCode"`              // CaptureStatusCode status of the capture
+	CaptureStatusMessage string    `json:"captureStatusMessage,omitempty"` // CaptureStatusMessage message of the capture status
+	LastUpdated          time.Time `json:"lastUpdated"`                    // LastUpdated when this confirmation was last updated
+	PlatformId           string    `json:"platformId"`                     // PlatformId rel to platform
+	RefId                string    `json:"refId"`                          // RefId any external relation
+	PartyId              string    `json:"partyId,omitempty"`              // PartyId should be unique within country
+	CountryCode          string    `json:"countryCode,omitempty"`          // CountryCode alfa-2 code
+}
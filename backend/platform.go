@@ -1,5 +1,7 @@
 package backend
 
+import "time"
+
 // PushSupport specifies if platform supports pushing for the particular module
 type PushSupport struct {
 	Credentials   bool `json:"credentials"`   // Credentials pushing supported
@@ -13,17 +15,114 @@ type PushSupport struct {
 }
 
 type ProtocolDetails struct {
-	PushSupport PushSupport `json:"pushSupport"` // PushSupport specifies pushing support by module
+	PushSupport PushSupport `json:"pushSupport"`          // PushSupport specifies pushing support by module
+	Validation  string      `json:"validation,omitempty"` // Validation how strictly lifecycle changes of objects are validated (strict, lenient), strict if empty
+}
+
+// TokenRotationPolicy specifies how often credentials tokens are rotated
+type TokenRotationPolicy struct {
+	Interval    int `json:"interval,omitempty"`    // Interval rotation interval in days, rotation is disabled if not specified
+	GracePeriod int `json:"gracePeriod,omitempty"` // GracePeriod period in hours when the previous token is still accepted
+}
+
+// TokenRotation token rotation history item
+type TokenRotation struct {
+	Id         string    `json:"id"`              // Id unique ID
+	PlatformId string    `json:"platformId"`      // PlatformId platform which tokens were rotated
+	Status     string    `json:"status"`          // Status rotation status (success, failed)
+	Error      string    `json:"error,omitempty"` // Error rotation error if failed
+	CreatedAt  time.Time `json:"createdAt"`       // CreatedAt when rotation happened
+}
+
+// OnboardingTokenRequest request to issue a new onboarding token (token A)
+type OnboardingTokenRequest struct {
+	Role      string `json:"role,omitempty"`      // Role intended role of the remote platform, platform's role is used if empty
+	SingleUse bool   `json:"singleUse,omitempty"` // SingleUse if true, token can be used only for a single handshake
+	Ttl       int    `json:"ttl,omitempty"`       // Ttl token time to live in hours, 72 hours if empty
+}
+
+// OnboardingToken onboarding token (token A) lifecycle
+type OnboardingToken struct {
+	PlatformId string     `json:"platformId"`           // PlatformId platform the token is issued for
+	Token      string     `json:"token,omitempty"`      // Token token A, populated only when token is issued
+	Role       string     `json:"role,omitempty"`       // Role intended role of the remote platform
+	SingleUse  bool       `json:"singleUse,omitempty"`  // SingleUse if true, token can be used only for a single handshake
+	Status     string     `json:"status"`               // Status token status (active, expired, consumed, revoked)
+	CreatedAt  time.Time  `json:"createdAt"`            // CreatedAt when token was issued
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`  // ExpiresAt when token expires
+	ConsumedAt *time.Time `json:"consumedAt,omitempty"` // ConsumedAt when token was used for handshake
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`  // RevokedAt when token was revoked
+}
+
+// PlatformHealth health state of the remote platform
+type PlatformHealth struct {
+	CheckedAt    *time.Time `json:"checkedAt,omitempty"`    // CheckedAt when the last check happened
+	LatencyMs    int64      `json:"latencyMs,omitempty"`    // LatencyMs latency of the last check
+	Failures     int        `json:"failures,omitempty"`     // Failures number of consecutive failed checks
+	Successes    int        `json:"successes,omitempty"`    // Successes number of consecutive successful checks
+	LastError    string     `json:"lastError,omitempty"`    // LastError error of the last failed check
+	OfflineSince *time.Time `json:"offlineSince,omitempty"` // OfflineSince when platform was moved offline by health checks
+}
+
+// PlatformDataCleanup result of handling platform's data on disconnection
+type PlatformDataCleanup struct {
+	Policy    string    `json:"policy"`          // Policy data policy applied (archive, purge)
+	Status    string    `json:"status"`          // Status cleanup status (success, failed)
+	Error     string    `json:"error,omitempty"` // Error cleanup error if failed
+	UpdatedAt time.Time `json:"updatedAt"`       // UpdatedAt when cleanup happened
+}
+
+// RateLimit token bucket rate limit of requests
+type RateLimit struct {
+	Rate  float64 `json:"rate,omitempty"`  // Rate requests per second
+	Burst int     `json:"burst,omitempty"` // Burst max number of requests sent at once
+}
+
+// ClientSettings specifies how OCPI requests are sent to the remote platform, empty values mean defaults
+type ClientSettings struct {
+	Timeout          int                   `json:"timeout,omitempty"`          // Timeout request timeout in seconds
+	MaxRetries       *int                  `json:"maxRetries,omitempty"`       // MaxRetries max number of retries of idempotent requests (GET, PUT), 0 disables retries
+	RetryBackoff     int                   `json:"retryBackoff,omitempty"`     // RetryBackoff initial retry backoff in milliseconds
+	BreakerThreshold int                   `json:"breakerThreshold,omitempty"` // BreakerThreshold number of consecutive failures opening circuit breaker
+	BreakerCooldown  int                   `json:"breakerCooldown,omitempty"`  // BreakerCooldown period in seconds circuit breaker stays open
+	MaxConcurrency   int                   `json:"maxConcurrency,omitempty"`   // MaxConcurrency max number of concurrent requests, unlimited if empty
+	RateLimit        *RateLimit            `json:"rateLimit,omitempty"`        // RateLimit limits all requests to the remote platform, unlimited if empty
+	ModuleRateLimits map[string]*RateLimit `json:"moduleRateLimits,omitempty"` // ModuleRateLimits limits requests to the particular modules (locations, sessions etc.)
+}
+
+// ClientState runtime state of the client to the remote platform
+type ClientState struct {
+	Breaker  string     `json:"breaker"`            // Breaker circuit breaker state (closed, open, half-open)
+	Failures int        `json:"failures,omitempty"` // Failures number of consecutive failures
+	OpenedAt *time.Time `json:"openedAt,omitempty"` // OpenedAt when circuit breaker was opened
+	InFlight int        `json:"inFlight,omitempty"` // InFlight number of requests in progress
+	Queued   int        `json:"queued,omitempty"`   // Queued number of requests waiting for rate limiters
+}
+
+// RouteLimits limits requests coming to the local OCPI endpoints
+type RouteLimits struct {
+	RateLimit  *RateLimit `json:"rateLimit,omitempty"`  // RateLimit token bucket rate limit, unlimited if empty
+	DailyQuota int        `json:"dailyQuota,omitempty"` // DailyQuota max number of requests per day (UTC), unlimited if empty
+}
+
+// InboundLimits limits requests of the remote platform to the local OCPI endpoints
+type InboundLimits struct {
+	RateLimit  *RateLimit              `json:"rateLimit,omitempty"`  // RateLimit token bucket rate limit of all requests, unlimited if empty
+	DailyQuota int                     `json:"dailyQuota,omitempty"` // DailyQuota max number of all requests per day (UTC), unlimited if empty
+	Routes     map[string]*RouteLimits `json:"routes,omitempty"`     // Routes limits of the particular routes, key is <module>.<method> (e.g. locations.get)
 }
 
 type PlatformRequest struct {
-	Id           string           `json:"id,omitempty"`           // Id platform id
-	TokenA       string           `json:"tokenA,omitempty"`       // TokenA platform token A
-	Name         string           `json:"name,omitempty"`         // Name platform name
-	Role         string           `json:"role,omitempty"`         // Role platform role
-	GetVersionEp string           `json:"getVersionEp,omitempty"` // GetVersionEp versions endpoint
-	TokenBase64  *bool            `json:"tokenBase64,omitempty"`  // TokenBase64 if true, token is base64 encoded
-	Protocol     *ProtocolDetails `json:"protocol,omitempty"`     // Protocol details
+	Id            string               `json:"id,omitempty"`            // Id platform id
+	TokenA        string               `json:"tokenA,omitempty"`        // TokenA platform token A
+	Name          string               `json:"name,omitempty"`          // Name platform name
+	Role          string               `json:"role,omitempty"`          // Role platform role
+	GetVersionEp  string               `json:"getVersionEp,omitempty"`  // GetVersionEp versions endpoint
+	TokenBase64   *bool                `json:"tokenBase64,omitempty"`   // TokenBase64 if true, token is base64 encoded
+	Protocol      *ProtocolDetails     `json:"protocol,omitempty"`      // Protocol details
+	TokenRotation *TokenRotationPolicy `json:"tokenRotation,omitempty"` // TokenRotation token rotation policy
+	Client        *ClientSettings      `json:"client,omitempty"`        // Client settings of the client to the remote platform
+	Inbound       *InboundLimits       `json:"inbound,omitempty"`       // Inbound limits of requests coming from the remote platform
 }
 
 type VersionInfo struct {
@@ -37,16 +136,24 @@ type RoleEndpoint struct {
 }
 
 type Platform struct {
-	Id          string                   `json:"id,omitempty"`          // Id of platform
-	TokenA      string                   `json:"tokenA,omitempty"`      // TokenA platform token A
-	TokenB      string                   `json:"tokenB,omitempty"`      // TokenB platform token B
-	TokenC      string                   `json:"tokenC,omitempty"`      // TokenC platform token C
-	Name        string                   `json:"name,omitempty"`        // Name platform name
-	Role        string                   `json:"role,omitempty"`        // Role platform role
-	VersionInfo *VersionInfo             `json:"versionInfo,omitempty"` // VersionInfo platform versions
-	Endpoints   map[string]*RoleEndpoint `json:"endpoints,omitempty"`   // Endpoints platform endpoints
-	Status      string                   `json:"status,omitempty"`      // Status platform status
-	Remote      bool                     `json:"remote,omitempty"`      // Remote if platform remote
-	TokenBase64 *bool                    `json:"tokenBase64,omitempty"` // TokenBase64 if true, token is base64 encoded
-	Protocol    *ProtocolDetails         `json:"protocol,omitempty"`    // Protocol details
+	Id            string                   `json:"id,omitempty"`            // Id of platform
+	TokenA        string                   `json:"tokenA,omitempty"`        // TokenA platform token A
+	TokenB        string                   `json:"tokenB,omitempty"`        // TokenB platform token B
+	TokenC        string                   `json:"tokenC,omitempty"`        // TokenC platform token C
+	Name          string                   `json:"name,omitempty"`          // Name platform name
+	Role          string                   `json:"role,omitempty"`          // Role platform role
+	VersionInfo   *VersionInfo             `json:"versionInfo,omitempty"`   // VersionInfo platform versions
+	Endpoints     map[string]*RoleEndpoint `json:"endpoints,omitempty"`     // Endpoints platform endpoints
+	Status        string                   `json:"status,omitempty"`        // Status platform status
+	Remote        bool                     `json:"remote,omitempty"`        // Remote if platform remote
+	TokenBase64   *bool                    `json:"tokenBase64,omitempty"`   // TokenBase64 if true, token is base64 encoded
+	Protocol      *ProtocolDetails         `json:"protocol,omitempty"`      // Protocol details
+	TokenRotation *TokenRotationPolicy     `json:"tokenRotation,omitempty"` // TokenRotation token rotation policy
+	TokenIssuedAt *time.Time               `json:"tokenIssuedAt,omitempty"` // TokenIssuedAt when token B was issued
+	PrevTokenBExp *time.Time               `json:"prevTokenBExp,omitempty"` // PrevTokenBExp when previous token B expires
+	Health        *PlatformHealth          `json:"health,omitempty"`        // Health health state of the remote platform
+	Client        *ClientSettings          `json:"client,omitempty"`        // Client settings of the client to the remote platform
+	ClientState   *ClientState             `json:"clientState,omitempty"`   // ClientState runtime state of the client to the remote platform
+	Inbound       *InboundLimits           `json:"inbound,omitempty"`       // Inbound limits of requests coming from the remote platform
+	DataCleanup   *PlatformDataCleanup     `json:"dataCleanup,omitempty"`   // DataCleanup result of data cleanup on disconnection
 }
@@ -0,0 +1,36 @@
+package backend
+
+import (
+	"time"
+)
+
+const (
+	ReservationStatusActive    = "ACTIVE"
+	ReservationStatusCancelled = "CANCELLED"
+	ReservationStatusExpired   = "EXPIRED"
+	ReservationStatusUsed      = "USED"
+)
+
+type ChargeReservation struct {
+	Id            string    `json:"id"`                    // Id internal reservation id
+	ReservationId string    `json:"reservationId"`         // ReservationId reservation id given by eMSP
+	Status        string    `json:"status"`                // Status reservation status
+	TokenId       string    `json:"tokenId"`               // TokenId token the reservation is made for
+	LocationId    string    `json:"locationId"`            // LocationId reserved location
+	EvseId        string    `json:"evseId,omitempty"`      // EvseId reserved EVSE
+	ConnectorId   string    `json:"connectorId,omitempty"` // ConnectorId reserved connector
+	ExpireDate    time.Time `json:"expireDate"`            // ExpireDate when the reservation ends
+	AuthRef       string    `json:"authRef,omitempty"`     // AuthRef authorization reference
+	CommandId     string    `json:"commandId"`             // CommandId RESERVE_NOW command the reservation is created by
+	SessionId     string    `json:"sessionId,omitempty"`   // SessionId session the reservation is used by
+	LastUpdated   time.Time `json:"lastUpdated"`           // LastUpdated when this reservation was last updated
+	PlatformId    string    `json:"platformId"`            // PlatformId rel to platform
+	RefId         string    `json:"refId,omitempty"`       // RefId any external relation
+	PartyId       string    `json:"partyId,omitempty"`     // PartyId should be unique within country
+	CountryCode   string    `json:"countryCode,omitempty"` // CountryCode alfa-2 code
+}
+
+type ReservationSearchResponse struct {
+	PageInfo *PageResponse        `json:"pageInfo,omitempty"`
+	Items    []*ChargeReservation `json:"items,omitempty"`
+}
@@ -13,6 +13,9 @@ const (
 	SessionStatusPending     = "PENDING"
 	SessionStatusReservation = "RESERVATION"
 
+	SessionLimitKwh  = "KWH"  // SessionLimitKwh limit of charged energy
+	SessionLimitCost = "COST" // SessionLimitCost limit of the session total cost
+
 	DimensionTypeCurrent         = "CURRENT"
 	DimensionTypeEnergy          = "ENERGY"
 	DimensionTypeEnergyExport    = "ENERGY_EXPORT"
@@ -58,27 +61,46 @@ type ChargingPeriod struct {
 	Url                   string          `json:"url,omitempty"`                   // Url that can be shown to an EV driver
 }
 
+// SessionStopReason why the session was stopped by the platform
+type SessionStopReason struct {
+	Limit     string    `json:"limit"`               // Limit reached limit
+	Value     float64   `json:"value"`               // Value of the limit
+	Actual    float64   `json:"actual"`              // Actual value reached the limit
+	CommandId string    `json:"commandId,omitempty"` // CommandId STOP_SESSION command issued to stop the session
+	At        time.Time `json:"at"`                  // At when the limit was detected to be reached
+}
+
 type Session struct {
-	Id              string            `json:"id"`                        // Id uniquely identifies the session
-	StartDateTime   *time.Time        `json:"startDateTime"`             // StartDateTime timestamp when the session became ACTIVE
-	EndDateTime     *time.Time        `json:"endDateTime"`               // EndDateTime timestamp when the session was completed/finished
-	Kwh             *float64          `json:"kwh"`                       // Kwh how many kWh were charged
-	CdrToken        *CdrToken         `json:"cdrToken"`                  // CdrToken token used to start this charging session
-	AuthMethod      string            `json:"authMethod"`                // AuthMethod method used for authentication
-	AuthRef         string            `json:"authRef,omitempty"`         // AuthRef reference to the authorization given by the eMSP
-	LocationId      string            `json:"locationId"`                // LocationId id of the location obj
-	EvseId          string            `json:"evseId"`                    // EvseId id of the evse obj
-	ConnectorId     string            `json:"connectorId"`               // ConnectorId id of the connector
-	MeterId         string            `json:"meterId,omitempty"`         // MeterId id of the kWh meter
-	Currency        string            `json:"currency"`                  // Currency ISO-4217 currency code
-	ChargingPeriods []*ChargingPeriod `json:"chargingPeriods,omitempty"` // ChargingPeriods  list of Charging Periods that can be used to calculate and verify the total cost
-	TotalCost       *Price            `json:"totalCost,omitempty"`       // TotalCost total cost of the session in the specified currency
-	Status          string            `json:"status"`                    // Status session status
-	LastUpdated     time.Time         `json:"lastUpdated"`               // LastUpdated when this Tariff was last updated
-	PlatformId      string            `json:"platformId"`                // PlatformId rel to platform
-	RefId           string            `json:"refId"`                     // RefId any external relation
-	PartyId         string            `json:"partyId,omitempty"`         // PartyId should be unique within country
-	CountryCode     string            `json:"countryCode,omitempty"`     // CountryCode alfa-2 code
+	Id              string             `json:"id"`                        // Id uniquely identifies the session
+	StartDateTime   *time.Time         `json:"startDateTime"`             // StartDateTime timestamp when the session became ACTIVE
+	EndDateTime     *time.Time         `json:"endDateTime"`               // EndDateTime timestamp when the session was completed/finished
+	Kwh             *float64           `json:"kwh"`                       // Kwh how many kWh were charged
+	CdrToken        *CdrToken          `json:"cdrToken"`                  // CdrToken token used to start this charging session
+	AuthMethod      string             `json:"authMethod"`                // AuthMethod method used for authentication
+	AuthRef         string             `json:"authRef,omitempty"`         // AuthRef reference to the authorization given by the eMSP
+	LocationId      string             `json:"locationId"`                // LocationId id of the location obj
+	EvseId          string             `json:"evseId"`                    // EvseId id of the evse obj
+	ConnectorId     string             `json:"connectorId"`               // ConnectorId id of the connector
+	MeterId         string             `json:"meterId,omitempty"`         // MeterId id of the kWh meter
+	Currency        string             `json:"currency"`                  // Currency ISO-4217 currency code
+	ChargingPeriods []*ChargingPeriod  `json:"chargingPeriods,omitempty"` // ChargingPeriods  list of Charging Periods that can be used to calculate and verify the total cost
+	TotalCost       *Price             `json:"totalCost,omitempty"`       // TotalCost total cost of the session in the specified currency
+	Status          string             `json:"status"`                    // Status session status
+	StopReason      *SessionStopReason `json:"stopReason,omitempty"`      // StopReason set if the session was stopped by the platform
+	LastUpdated     time.Time          `json:"lastUpdated"`               // LastUpdated when this Tariff was last updated
+	PlatformId      string             `json:"platformId"`                // PlatformId rel to platform
+	RefId           string             `json:"refId"`                     // RefId any external relation
+	PartyId         string             `json:"partyId,omitempty"`         // PartyId should be unique within country
+	CountryCode     string             `json:"countryCode,omitempty"`     // CountryCode alfa-2 code
+}
+
+// SessionIssueReport sessions of a partner requiring attention
+type SessionIssueReport struct {
+	PlatformId  string   `json:"platformId"`            // PlatformId partner's platform
+	PartyId     string   `json:"partyId,omitempty"`     // PartyId should be unique within country
+	CountryCode string   `json:"countryCode,omitempty"` // CountryCode alfa-2 code
+	Stale       []string `json:"stale,omitempty"`       // Stale ids of ACTIVE/PENDING sessions without updates for too long
+	MissingCdr  []string `json:"missingCdr,omitempty"`  // MissingCdr ids of COMPLETED sessions without CDR for too long
 }
 
 type SessionSearchResponse struct {
@@ -31,6 +31,13 @@ type Price struct {
 	InclVat *float64 `json:"inclVat,omitempty"` // InclVat Price/Cost including VAT
 }
 
+type TaxAmount struct {
+	Name          string   `json:"name"`                    // Name of the tax
+	AccountNumber string   `json:"accountNumber,omitempty"` // AccountNumber tax account number of the party
+	Percentage    *float64 `json:"percentage,omitempty"`    // Percentage of the tax
+	Amount        float64  `json:"amount"`                  // Amount of the tax
+}
+
 type TariffRestrictions struct {
 	StartTime   string     `json:"startTime,omitempty"`   // StartTime time of day in local time
 	EndTime     string     `json:"endTime,omitempty"`     // EndTime time of day in local time
@@ -61,22 +68,24 @@ type TariffElement struct {
 }
 
 type Tariff struct {
-	Id            string           `json:"id"`                      // Id uniquely identifies the tariff
-	Currency      string           `json:"currency"`                // Currency ISO-4217 code
-	Type          string           `json:"type,omitempty"`          // Type of the tariff
-	TariffAltText []*DisplayText   `json:"tariffAltText,omitempty"` // TariffAltText list of multi-language alternative tariff info texts
-	TariffAltUrl  string           `json:"tariffAltUrl,omitempty"`  // TariffAltUrl web page that contains an explanation of the tariff
-	MinPrice      *Price           `json:"minPrice,omitempty"`      // MinPrice minimum possible price
-	MaxPrice      *Price           `json:"maxPrice,omitempty"`      // MaxPrice maximum possible price
-	Elements      []*TariffElement `json:"elements"`                // Elements list of tariff elements
-	StartDateTime *time.Time       `json:"startDateTime,omitempty"` // StartDateTime when this tariff becomes active in UTC
-	EndDateTime   *time.Time       `json:"endDateTime,omitempty"`   // EndDateTime when this tariff no longer valid in UTC
-	EnergyMix     *EnergyMix       `json:"energyMix,omitempty"`     // EnergyMix details on the energy supplied with this tariff
-	LastUpdated   time.Time        `json:"lastUpdated"`             // LastUpdated when this Tariff was last updated
-	PlatformId    string           `json:"platformId"`              // PlatformId rel to platform
-	RefId         string           `json:"refId"`                   // RefId any external relation
-	PartyId       string           `json:"partyId,omitempty"`       // PartyId should be unique within country
-	CountryCode   string           `json:"countryCode,omitempty"`   // CountryCode alfa-2 code
+	Id                 string           `json:"id"`                           // Id uniquely identifies the tariff
+	Currency           string           `json:"currency"`                     // Currency ISO-4217 code
+	Type               string           `json:"type,omitempty"`               // Type of the tariff
+	TariffAltText      []*DisplayText   `json:"tariffAltText,omitempty"`      // TariffAltText list of multi-language alternative tariff info texts
+	TariffAltUrl       string           `json:"tariffAltUrl,omitempty"`       // TariffAltUrl web page that contains an explanation of the tariff
+	MinPrice           *Price           `json:"minPrice,omitempty"`           // MinPrice minimum possible price
+	MaxPrice           *Price           `json:"maxPrice,omitempty"`           // MaxPrice maximum possible price
+	Elements           []*TariffElement `json:"elements"`                     // Elements list of tariff elements
+	StartDateTime      *time.Time       `json:"startDateTime,omitempty"`      // StartDateTime when this tariff becomes active in UTC
+	EndDateTime        *time.Time       `json:"endDateTime,omitempty"`        // EndDateTime when this tariff no longer valid in UTC
+	EnergyMix          *EnergyMix       `json:"energyMix,omitempty"`          // EnergyMix details on the energy supplied with this tariff
+	PreauthorizeAmount *Price           `json:"preauthorizeAmount,omitempty"` // PreauthorizeAmount amount to be pre-authorized on a payment terminal for ad hoc payment
+	TaxIncluded        string           `json:"taxIncluded,omitempty"`        // TaxIncluded whether taxes are included in the prices of the tariff
+	LastUpdated        time.Time        `json:"lastUpdated"`                  // LastUpdated when this Tariff was last updated
+	PlatformId         string           `json:"platformId"`                   // PlatformId rel to platform
+	RefId              string           `json:"refId"`                        // RefId any external relation
+	PartyId            string           `json:"partyId,omitempty"`            // PartyId should be unique within country
+	CountryCode        string           `json:"countryCode,omitempty"`        // CountryCode alfa-2 code
 }
 
 type TariffSearchResponse struct {
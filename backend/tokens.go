@@ -19,6 +19,13 @@ type EnergyContract struct {
 	ContractId   string `json:"contractId,omitempty"` // ContractId at the energy supplier, that belongs to the owner of this token
 }
 
+// SessionLimits limits of a charging session, session is stopped as soon as any of them is reached
+type SessionLimits struct {
+	Kwh      *float64 `json:"kwh,omitempty"`      // Kwh max charged energy in kWh
+	Cost     *float64 `json:"cost,omitempty"`     // Cost max total cost, including VAT if it's given
+	Currency string   `json:"currency,omitempty"` // Currency ISO-4217 currency code of the cost limit, it's applied only to sessions in this currency
+}
+
 type Token struct {
 	Id                 string          `json:"id"`                           // Id unique ID by which this Token can be identified
 	Type               string          `json:"type"`                         // Type of the token
@@ -31,6 +38,7 @@ type Token struct {
 	Lang               string          `json:"language,omitempty"`           // Lang code ISO 639-1
 	DefaultProfileType string          `json:"defaultProfileType,omitempty"` // DefaultProfileType default Charging Preference
 	EnergyContract     *EnergyContract `json:"energyContract,omitempty"`     // EnergyContract energy supplier/contract
+	SessionLimits      *SessionLimits  `json:"sessionLimits,omitempty"`      // SessionLimits limits applied to every session of the token, enforced by the platform
 	LastUpdated        time.Time       `json:"lastUpdated"`                  // LastUpdated when this Tariff was last updated
 	PlatformId         string          `json:"platformId"`                   // PlatformId rel to platform
 	RefId              string          `json:"refId"`                        // RefId any external relation
@@ -38,6 +46,13 @@ type Token struct {
 	CountryCode        string          `json:"countryCode,omitempty"`        // CountryCode alfa-2 code
 }
 
+// TokenBlocked active sessions of the token blocked by eMSP
+type TokenBlocked struct {
+	Token    *Token     `json:"token"`    // Token blocked token
+	Sessions []*Session `json:"sessions"` // Sessions active sessions started with the token
+	Stop     bool       `json:"stop"`     // Stop if true, the sessions are requested to be stopped
+}
+
 type TokenSearchResponse struct {
 	PageInfo *PageResponse `json:"pageInfo,omitempty"`
 	Items    []*Token      `json:"items,omitempty"`
@@ -5,19 +5,30 @@ import (
 )
 
 const (
-	WhEventPartyChanged      = "party.changed"
-	WhEventLocationChanged   = "location.changed"
-	WhEventEvseChanged       = "evse.changed"
-	WhEventConnectorChanged  = "connector.changed"
-	WhEventTariffChanged     = "tariff.changed"
-	WhEventTokenChanged      = "token.changed"
-	WhEventSessionChanged    = "session.changed"
-	WhEventCommandResponse   = "command.response"
-	WhEventStartSession      = "command.start-session"
-	WhEventStopSession       = "command.stop-session"
-	WhEventCdrChanged        = "cdr.changed"
-	WhEventReservation       = "command.reservation"
-	WhEventCancelReservation = "command.reservation-cancel"
+	WhEventPartyChanged          = "party.changed"
+	WhEventLocationChanged       = "location.changed"
+	WhEventEvseChanged           = "evse.changed"
+	WhEventConnectorChanged      = "connector.changed"
+	WhEventTariffChanged         = "tariff.changed"
+	WhEventTokenChanged          = "token.changed"
+	WhEventSessionChanged        = "session.changed"
+	WhEventCommandResponse       = "command.response"
+	WhEventStartSession          = "command.start-session"
+	WhEventStopSession           = "command.stop-session"
+	WhEventCdrChanged            = "cdr.changed"
+	WhEventReservation           = "command.reservation"
+	WhEventCancelReservation     = "command.reservation-cancel"
+	WhEventTerminalChanged       = "terminal.changed"
+	WhEventFinAdviceChanged      = "financial-advice.changed"
+	WhEventPlatformDisconnected  = "platform.disconnected"
+	WhEventTokenRotationFailed   = "platform.token-rotation-failed"
+	WhEventPlatformStatusChanged = "platform.status-changed"
+	WhEventReservationChanged    = "reservation.changed"
+	WhEventSessionLimitExceeded  = "session.limit-exceeded"
+	WhEventTokenBlocked          = "token.blocked"
+	WhEventSessionStale          = "session.stale"
+	WhEventCdrMissing            = "cdr.missing"
+	WhEventDisputeChanged        = "dispute.changed"
 )
 
 type Webhook struct {
@@ -73,6 +84,28 @@ type WebhookCallService interface {
 	OnReserveNow(ctx context.Context, cmd *Command) error
 	// OnCancelReservation makes a webhook call when a cancel reservation requested
 	OnCancelReservation(ctx context.Context, cmd *Command) error
+	// OnTerminalChanged makes a webhook call when a terminal changed
+	OnTerminalChanged(ctx context.Context, t *Terminal) error
+	// OnFinancialAdviceChanged makes a webhook call when a financial advice confirmation received
+	OnFinancialAdviceChanged(ctx context.Context, fa *FinancialAdvice) error
+	// OnPlatformDisconnected makes a webhook call when a platform disconnected
+	OnPlatformDisconnected(ctx context.Context, p *Platform) error
+	// OnTokenRotationFailed makes a webhook call when a platform token rotation failed
+	OnTokenRotationFailed(ctx context.Context, r *TokenRotation) error
+	// OnPlatformStatusChanged makes a webhook call when platform status changed automatically
+	OnPlatformStatusChanged(ctx context.Context, p *Platform) error
+	// OnReservationChanged makes a webhook call when reservation status changed
+	OnReservationChanged(ctx context.Context, r *ChargeReservation) error
+	// OnSessionLimitExceeded makes a webhook call when session exceeded its limit and is being stopped
+	OnSessionLimitExceeded(ctx context.Context, sess *Session) error
+	// OnTokenBlocked makes a webhook call when a token with active sessions is blocked by eMSP
+	OnTokenBlocked(ctx context.Context, tb *TokenBlocked) error
+	// OnSessionStale makes a webhook call when ACTIVE/PENDING session hasn't been updated for too long
+	OnSessionStale(ctx context.Context, sess *Session) error
+	// OnCdrMissing makes a webhook call when COMPLETED session hasn't got CDR for too long
+	OnCdrMissing(ctx context.Context, sess *Session) error
+	// OnDisputeChanged makes a webhook call when CDR dispute is opened, changed or resolved by credit CDR
+	OnDisputeChanged(ctx context.Context, d *Dispute) error
 }
 
 type WebhookRepository interface {
@@ -20,16 +20,25 @@ import (
 	"github.com/mikhailbolshakov/ocpi/transport/http"
 	bkndCdrs "github.com/mikhailbolshakov/ocpi/transport/http/backend/cdrs"
 	bkndCmd "github.com/mikhailbolshakov/ocpi/transport/http/backend/commands"
+	bkndDisp "github.com/mikhailbolshakov/ocpi/transport/http/backend/disputes"
 	bkndLoc "github.com/mikhailbolshakov/ocpi/transport/http/backend/locations"
 	bkndMnt "github.com/mikhailbolshakov/ocpi/transport/http/backend/maintenance"
 	bkndParty "github.com/mikhailbolshakov/ocpi/transport/http/backend/party"
 	bkndPlatform "github.com/mikhailbolshakov/ocpi/transport/http/backend/platform"
+	bkndRes "github.com/mikhailbolshakov/ocpi/transport/http/backend/reservations"
 	bkndSess "github.com/mikhailbolshakov/ocpi/transport/http/backend/sessions"
 	bkndSwg "github.com/mikhailbolshakov/ocpi/transport/http/backend/swagger"
 	bkndTrf "github.com/mikhailbolshakov/ocpi/transport/http/backend/tariffs"
 	bkndTkn "github.com/mikhailbolshakov/ocpi/transport/http/backend/tokens"
 	bkndWebhook "github.com/mikhailbolshakov/ocpi/transport/http/backend/webhook"
 	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi/platform"
+	cdrsV211 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v211/cdrs"
+	commandsV211 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v211/commands"
+	credentialsV211 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v211/credentials"
+	locationsV211 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v211/locations"
+	sessionsV211 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v211/sessions"
+	tariffsV211 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v211/tariffs"
+	tokensV211 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v211/tokens"
 	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v221/cdrs"
 	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v221/commands"
 	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v221/credentials"
@@ -38,6 +47,15 @@ import (
 	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v221/sessions"
 	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v221/tariffs"
 	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v221/tokens"
+	cdrsV230 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v230/cdrs"
+	commandsV230 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v230/commands"
+	credentialsV230 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v230/credentials"
+	hubV230 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v230/hub"
+	locationsV230 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v230/locations"
+	paymentsV230 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v230/payments"
+	sessionsV230 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v230/sessions"
+	tariffsV230 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v230/tariffs"
+	tokensV230 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v230/tokens"
 	"github.com/mikhailbolshakov/ocpi/usecase"
 	impl2 "github.com/mikhailbolshakov/ocpi/usecase/impl"
 )
@@ -79,10 +97,20 @@ type ServiceImpl struct {
 	cdrService           domain.CdrService
 	cdrUc                usecase.CdrUc
 	cdrConverter         usecase.CdrConverter
+	paymentService       domain.PaymentService
+	paymentUc            usecase.PaymentUc
+	paymentConverter     usecase.PaymentConverter
+	resService           domain.ReservationService
+	resUc                usecase.ReservationUc
+	resConverter         usecase.ReservationConverter
+	disputeService       domain.DisputeService
+	disputeUc            usecase.DisputeUc
+	disputeConverter     usecase.DisputeConverter
 	webhookService       backend.WebhookService
 	webhookCallService   backend.WebhookCallService
 	webhookAdapter       webhook.Adapter
 	maintenanceUc        usecase.MaintenanceUc
+	healthUc             usecase.HealthUc
 	cronManager          cron.Manager
 }
 
@@ -107,7 +135,6 @@ func New() cluster.Service {
 	s.platformService = impl.NewPlatformService(s.storageAdapter, s.tokenGen, s.partyService)
 	s.localPlatformService = impl.NewLocalPlatformService(s.platformService, s.partyService)
 	s.hubUc = impl2.NewHubUc(s.platformService, s.ocpiAdapter, s.partyService, s.webhookCallService, s.localPlatformService, s.tokenGen)
-	s.credentialsUc = impl2.NewCredentialsUc(s.platformService, s.localPlatformService, s.tokenGen, s.ocpiAdapter, s.partyService, s.webhookCallService, s.hubUc)
 	s.locationService = impl.NewLocationService(s.storageAdapter)
 	s.locationUc = impl2.NewLocationUc(s.platformService, s.locationService, s.ocpiAdapter, s.partyService, s.webhookCallService, s.localPlatformService, s.tokenGen)
 	s.trfConverter = impl2.NewTariffConverter()
@@ -117,21 +144,54 @@ func New() cluster.Service {
 	s.tknService = impl.NewTokenService(s.storageAdapter)
 	s.tknUc = impl2.NewTokenUc(s.platformService, s.tknService, s.ocpiAdapter, s.partyService, s.webhookCallService, s.localPlatformService, s.tokenGen)
 	s.cmdService = impl.NewCmdService(s.tknService, s.storageAdapter)
+	s.resConverter = impl2.NewReservationConverter()
+	s.resService = impl.NewReservationService(s.storageAdapter)
+	s.resUc = impl2.NewReservationUc(s.resService, s.locationService, s.locationUc, s.localPlatformService, s.webhookCallService)
 	s.sessConverter = impl2.NewSessionConverter()
-	s.sessService = impl.NewSessionService(s.storageAdapter)
-	s.sessUc = impl2.NewSessionUc(s.platformService, s.sessService, s.ocpiAdapter, s.partyService, s.webhookCallService, s.cmdService, s.localPlatformService, s.tknService, s.tokenGen)
+	s.sessService = impl.NewSessionService(s.storageAdapter, s.platformService)
+	s.cmdConverter = impl2.NewCommandConverter(s.tknConverter)
+	s.cmdUc = impl2.NewCommandUc(s.platformService, s.cmdService, s.ocpiAdapter, s.partyService, s.locationService, s.webhookCallService,
+		s.localPlatformService, s.tknUc, s.tknService, s.sessService, s.resUc, s.storageAdapter, s.tokenGen)
+	s.sessUc = impl2.NewSessionUc(s.platformService, s.sessService, s.ocpiAdapter, s.partyService, s.webhookCallService, s.cmdService, s.localPlatformService, s.tknService, s.resUc, s.cmdUc, s.tokenGen)
+	s.tknUc.SetSessionUc(s.sessUc)
 	s.cdrConverter = impl2.NewCdrConverter(s.trfConverter)
 	s.cdrService = impl.NewCdrService(s.storageAdapter, s.trfService)
+	s.credentialsUc = impl2.NewCredentialsUc(s.platformService, s.localPlatformService, s.tokenGen, s.ocpiAdapter, s.partyService, s.webhookCallService, s.hubUc,
+		s.locationService, s.trfService, s.tknService, s.sessService, s.cdrService)
+	s.disputeConverter = impl2.NewDisputeConverter()
+	s.disputeService = impl.NewDisputeService(s.storageAdapter)
+	s.disputeUc = impl2.NewDisputeUc(s.disputeService, s.cdrService, s.sessService, s.logService, s.localPlatformService, s.webhookCallService)
 	s.cdrUc = impl2.NewCdrUc(s.platformService, s.cdrService, s.ocpiAdapter, s.partyService, s.webhookCallService,
-		s.sessService, s.localPlatformService, s.locationService, s.trfService, s.tknService, s.tokenGen)
-	s.cmdConverter = impl2.NewCommandConverter(s.tknConverter)
-	s.cmdUc = impl2.NewCommandUc(s.platformService, s.cmdService, s.ocpiAdapter, s.partyService, s.locationService, s.webhookCallService,
-		s.localPlatformService, s.tknUc, s.tknService, s.sessService, s.tokenGen)
+		s.sessService, s.localPlatformService, s.locationService, s.trfService, s.tknService, s.disputeUc, s.tokenGen)
+	s.paymentConverter = impl2.NewPaymentConverter()
+	s.paymentService = impl.NewPaymentService(s.storageAdapter)
+	s.paymentUc = impl2.NewPaymentUc(s.platformService, s.paymentService, s.partyService, s.webhookCallService, s.localPlatformService, s.tokenGen)
 	s.maintenanceUc = impl2.NewMaintenanceUc(s.platformService, s.localPlatformService, s.partyService, s.locationService, s.cmdService,
 		s.sessService, s.cdrService, s.trfService, s.tknService, s.tokenGen)
+	s.healthUc = impl2.NewHealthUc(s.platformService, s.localPlatformService, s.ocpiAdapter, s.partyService, s.webhookCallService, s.tokenGen,
+		s.locationUc, s.trfUc, s.tknUc, s.sessUc, s.cdrUc)
 	return s
 }
 
+// EncryptTokens admin command which encrypts plain platform tokens and re-encrypts tokens with the current key
+// the service converts tokens on startup as well, the command allows to do it before rollout
+func EncryptTokens(ctx context.Context) (int, error) {
+	cfg, err := ocpi.LoadConfig()
+	if err != nil {
+		return 0, err
+	}
+	ocpi.Logger.Init(cfg.Log)
+
+	// only storage is initialized, so that it works before the service is able to start
+	storageAdapter := storage.NewAdapter()
+	if err := storageAdapter.Init(ctx, cfg.Storages); err != nil {
+		return 0, err
+	}
+	defer func() { _ = storageAdapter.Close(ctx) }()
+
+	return storageAdapter.EncryptTokens(ctx)
+}
+
 func (s *ServiceImpl) SetConfigLoadFn(fn func() (*ocpi.Config, error)) {
 	s.loadCfgFn = fn
 }
@@ -160,6 +220,26 @@ func (s *ServiceImpl) initHttpServer(ctx context.Context) error {
 	routeBuilder.SetRoutes(cdrs.GetRoutes(cdrs.NewController(s.cdrService, s.localPlatformService, s.cdrConverter, s.cdrUc, s.cfg.Ocpi)))
 	routeBuilder.SetRoutes(commands.GetRoutes(commands.NewController(s.cmdUc)))
 
+	// ocpi 2.1.1 routing
+	routeBuilder.SetRoutes(credentialsV211.GetRoutes(credentialsV211.NewController(s.credentialsUc, s.platformService, s.localPlatformService)))
+	routeBuilder.SetRoutes(locationsV211.GetRoutes(locationsV211.NewController(s.locationUc, s.locationService, s.localPlatformService, s.locConverter, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(tariffsV211.GetRoutes(tariffsV211.NewController(s.trfService, s.localPlatformService, s.trfConverter, s.trfUc, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(tokensV211.GetRoutes(tokensV211.NewController(s.tknService, s.localPlatformService, s.tknConverter, s.tknUc, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(sessionsV211.GetRoutes(sessionsV211.NewController(s.sessService, s.localPlatformService, s.sessConverter, s.sessUc, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(cdrsV211.GetRoutes(cdrsV211.NewController(s.cdrService, s.partyService, s.localPlatformService, s.cdrConverter, s.cdrUc, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(commandsV211.GetRoutes(commandsV211.NewController(s.cmdUc, s.partyService)))
+
+	// ocpi 2.3.0 routing
+	routeBuilder.SetRoutes(credentialsV230.GetRoutes(credentialsV230.NewController(s.credentialsUc, s.platformService, s.localPlatformService)))
+	routeBuilder.SetRoutes(hubV230.GetRoutes(hubV230.NewController(s.partyService, s.localPlatformService, s.hubUc, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(locationsV230.GetRoutes(locationsV230.NewController(s.locationUc, s.locationService, s.localPlatformService, s.locConverter, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(tariffsV230.GetRoutes(tariffsV230.NewController(s.trfService, s.localPlatformService, s.trfConverter, s.trfUc, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(tokensV230.GetRoutes(tokensV230.NewController(s.tknService, s.localPlatformService, s.tknConverter, s.tknUc, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(sessionsV230.GetRoutes(sessionsV230.NewController(s.sessService, s.localPlatformService, s.sessConverter, s.sessUc, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(cdrsV230.GetRoutes(cdrsV230.NewController(s.cdrService, s.localPlatformService, s.cdrConverter, s.cdrUc, s.cfg.Ocpi)))
+	routeBuilder.SetRoutes(commandsV230.GetRoutes(commandsV230.NewController(s.cmdUc)))
+	routeBuilder.SetRoutes(paymentsV230.GetRoutes(paymentsV230.NewController(s.paymentService, s.localPlatformService, s.paymentConverter, s.paymentUc, s.cfg.Ocpi)))
+
 	// backend routing
 	routeBuilder.SetRoutes(bkndPlatform.GetRoutes(bkndPlatform.NewController(s.platformService, s.credentialsUc, s.credConverter, s.tokenGen)))
 	routeBuilder.SetRoutes(bkndWebhook.GetRoutes(bkndWebhook.NewController(s.webhookService)))
@@ -170,6 +250,8 @@ func (s *ServiceImpl) initHttpServer(ctx context.Context) error {
 	routeBuilder.SetRoutes(bkndSess.GetRoutes(bkndSess.NewController(s.sessUc, s.sessConverter, s.localPlatformService, s.sessService)))
 	routeBuilder.SetRoutes(bkndCdrs.GetRoutes(bkndCdrs.NewController(s.cdrUc, s.cdrConverter, s.localPlatformService, s.cdrService)))
 	routeBuilder.SetRoutes(bkndCmd.GetRoutes(bkndCmd.NewController(s.cmdUc, s.cmdConverter, s.localPlatformService, s.cmdService)))
+	routeBuilder.SetRoutes(bkndRes.GetRoutes(bkndRes.NewController(s.resConverter, s.resService)))
+	routeBuilder.SetRoutes(bkndDisp.GetRoutes(bkndDisp.NewController(s.disputeUc, s.disputeConverter, s.disputeService)))
 	routeBuilder.SetRoutes(bkndMnt.GetRoutes(bkndMnt.NewController(s.maintenanceUc, s.logService)))
 	routeBuilder.SetRoutes(bkndSwg.GetRoutes())
 
@@ -209,6 +291,21 @@ func (s *ServiceImpl) Init(ctx context.Context) error {
 	if err := s.localPlatformService.Init(ctx, s.cfg.Ocpi); err != nil {
 		return err
 	}
+	if err := s.locationUc.Init(ctx, s.cfg.Ocpi); err != nil {
+		return err
+	}
+	if err := s.healthUc.Init(ctx, s.cfg.Ocpi); err != nil {
+		return err
+	}
+	if err := s.cmdUc.Init(ctx, s.cfg.Ocpi); err != nil {
+		return err
+	}
+	if err := s.sessUc.Init(ctx, s.cfg.Ocpi); err != nil {
+		return err
+	}
+	if err := s.cdrUc.Init(ctx, s.cfg.Ocpi); err != nil {
+		return err
+	}
 
 	// init http server
 	if err := s.initHttpServer(ctx); err != nil {
@@ -227,7 +324,7 @@ func (s *ServiceImpl) Init(ctx context.Context) error {
 		errMonitor := monitoring.NewErrorMonitoring()
 		ocpi.Logger.SetErrorHook(errMonitor)
 		// register metrics collectors
-		if err := s.monitoring.Init(s.cfg.Monitoring, errMonitor); err != nil {
+		if err := s.monitoring.Init(s.cfg.Monitoring, errMonitor, s.ocpiAdapter.Metrics(), s.storageAdapter.Metrics()); err != nil {
 			return err
 		}
 	}
@@ -241,7 +338,7 @@ func (s *ServiceImpl) Init(ctx context.Context) error {
 	}
 
 	// register cron
-	ocpiCron.NewCron(s.cronManager, s.cmdUc).Register(ctx)
+	ocpiCron.NewCron(s.cronManager, s.cmdUc, s.credentialsUc, s.healthUc, s.resUc, s.sessUc).Register(ctx)
 
 	return nil
 }
@@ -276,6 +373,8 @@ func (s *ServiceImpl) Start(ctx context.Context) error {
 
 func (s *ServiceImpl) Close(ctx context.Context) {
 	s.cronManager.Stop(ctx)
+	// push evse statuses waiting in buffer before adapters are closed
+	s.locationUc.Close(ctx)
 	_ = s.storageAdapter.Close(ctx)
 	_ = s.ocpiAdapter.Close(ctx)
 	_ = s.webhookAdapter.Close(ctx)
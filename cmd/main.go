@@ -24,6 +24,17 @@ func main() {
 
 	l := ocpi.L().Mth("main").Inf("created")
 
+	// admin command encrypting platform tokens, the service isn't started
+	if len(os.Args) > 1 && os.Args[1] == "encrypt-tokens" {
+		n, err := bootstrap.EncryptTokens(ctx)
+		if err != nil {
+			l.E(err).St().Err("tokens encryption")
+			os.Exit(1)
+		}
+		l.F(kit.KV{"platforms": n}).Inf("tokens encrypted")
+		os.Exit(0)
+	}
+
 	// init service
 	if err := s.Init(ctx); err != nil {
 		l.E(err).St().Err("initialization")
@@ -31,8 +31,25 @@ func L() kit.CLogger {
 	return LF()()
 }
 
+type CfgCryptoKey struct {
+	Key  string // Key base64 encoded 32-bytes key
+	File string // File path to a local keyfile with base64 encoded key, used if Key is empty
+}
+
+type CfgCrypto struct {
+	Current string                   // Current key id used to encrypt and hash tokens
+	Keys    map[string]*CfgCryptoKey // Keys all available keys by id, previous keys are kept to support rotation
+}
+
+type CfgCache struct {
+	Enabled bool // Enabled if platforms and parties are cached in-process
+	Ttl     int  // Ttl time to live of cached items in seconds
+}
+
 type CfgStorages struct {
 	Database *pg.DbClusterConfig
+	Crypto   *CfgCrypto
+	Cache    *CfgCache
 }
 
 type CfgAdapter struct {
@@ -68,17 +85,56 @@ type CfgWebHook struct {
 	Timeout *int
 }
 
+type CfgTokenBlock struct {
+	Policy string // Policy how active sessions of a blocked token are handled (none, notify, stop)
+}
+
 type CfgOcpiLocal struct {
-	Url      string
-	ApiKey   string `config:"api-key"`
-	Platform *CfgOcpiPlatform
-	Party    *CfgOcpiParty
-	Webhook  *CfgWebHook
+	Url        string
+	ApiKey     string `config:"api-key"`
+	Platform   *CfgOcpiPlatform
+	Party      *CfgOcpiParty
+	Webhook    *CfgWebHook
+	TokenBlock *CfgTokenBlock `config:"token-block"`
+}
+
+type CfgHealth struct {
+	Enabled           bool // Enabled if true, remote platforms are checked periodically
+	FailureThreshold  int  `config:"failure-threshold"`  // FailureThreshold number of consecutive failed checks to move platform offline
+	RecoveryThreshold int  `config:"recovery-threshold"` // RecoveryThreshold number of consecutive successful checks to move platform back online
+}
+
+type CfgPush struct {
+	EvseStatusWindowMs int `config:"evse-status-window-ms"` // EvseStatusWindowMs window in milliseconds evse status changes are coalesced within, only the latest status is pushed
+}
+
+type CfgSessionMonitor struct {
+	Enabled    bool // Enabled if true, stale sessions and missing CDRs are detected periodically
+	StaleHours int  `config:"stale-hours"` // StaleHours ACTIVE/PENDING session without updates for longer is considered stale
+	CdrHours   int  `config:"cdr-hours"`   // CdrHours COMPLETED session without CDR for longer is considered missing CDR
+}
+
+type CfgCdrValidation struct {
+	Strict bool // Strict if true, CDRs with inconsistencies of error severity are rejected, otherwise they are stored with the findings
+}
+
+type CfgConnectorSelection struct {
+	Strategy  string // Strategy how an EVSE is selected when START_SESSION is requested for a location (power, standard)
+	Standards string // Standards comma separated connector standards in order of preference, used by standard strategy
+}
+
+type CfgCommands struct {
+	ConnectorSelection *CfgConnectorSelection `config:"connector-selection"`
 }
 
 type CfgOcpiRemote struct {
-	Mock    bool
-	Timeout *int
+	Mock           bool
+	Timeout        *int
+	Health         *CfgHealth
+	Push           *CfgPush
+	Commands       *CfgCommands
+	SessionMonitor *CfgSessionMonitor `config:"session-monitor"`
+	CdrValidation  *CfgCdrValidation  `config:"cdr-validation"`
 }
 
 type CfgOcpiConfig struct {
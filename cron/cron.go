@@ -11,14 +11,23 @@ import (
 )
 
 type cronImpl struct {
-	cronManager cron.Manager
-	commandUc   usecase.CommandUc
+	cronManager   cron.Manager
+	commandUc     usecase.CommandUc
+	credentialsUc usecase.CredentialsUc
+	healthUc      usecase.HealthUc
+	reservationUc usecase.ReservationUc
+	sessionUc     usecase.SessionUc
 }
 
-func NewCron(cronManager cron.Manager, commandUc usecase.CommandUc) cron.CronHandler {
+func NewCron(cronManager cron.Manager, commandUc usecase.CommandUc, credentialsUc usecase.CredentialsUc, healthUc usecase.HealthUc,
+	reservationUc usecase.ReservationUc, sessionUc usecase.SessionUc) cron.CronHandler {
 	return &cronImpl{
-		cronManager: cronManager,
-		commandUc:   commandUc,
+		cronManager:   cronManager,
+		commandUc:     commandUc,
+		credentialsUc: credentialsUc,
+		healthUc:      healthUc,
+		reservationUc: reservationUc,
+		sessionUc:     sessionUc,
 	}
 }
 
@@ -33,6 +42,18 @@ func (c *cronImpl) Register(ctx context.Context) {
 	c.cronManager.Add(ctx, "remote-cmd-deadline").
 		Every(time.Minute).
 		Action(c.remoteCmdDeadlineAsync())
+	c.cronManager.Add(ctx, "token-rotation").
+		Every(time.Hour).
+		Action(c.tokenRotationAsync())
+	c.cronManager.Add(ctx, "platform-health").
+		Every(time.Minute).
+		Action(c.platformHealthAsync())
+	c.cronManager.Add(ctx, "reservation-expiry").
+		Every(time.Minute).
+		Action(c.reservationExpiryAsync())
+	c.cronManager.Add(ctx, "session-monitor").
+		Every(time.Hour).
+		Action(c.sessionMonitorAsync())
 }
 
 func (c *cronImpl) localCmdDeadlineAsync() cron.Action {
@@ -56,3 +77,47 @@ func (c *cronImpl) remoteCmdDeadlineAsync() cron.Action {
 			})
 	}
 }
+
+func (c *cronImpl) tokenRotationAsync() cron.Action {
+	return func(ctxFn func() context.Context) {
+		ctx := ctxFn()
+		goroutine.New().
+			WithLogger(c.l().C(ctx).Mth("token-rotation")).
+			Go(ctx, func() {
+				c.credentialsUc.TokenRotationCronHandler(ctx)
+			})
+	}
+}
+
+func (c *cronImpl) platformHealthAsync() cron.Action {
+	return func(ctxFn func() context.Context) {
+		ctx := ctxFn()
+		goroutine.New().
+			WithLogger(c.l().C(ctx).Mth("platform-health")).
+			Go(ctx, func() {
+				c.healthUc.HealthCheckCronHandler(ctx)
+			})
+	}
+}
+
+func (c *cronImpl) reservationExpiryAsync() cron.Action {
+	return func(ctxFn func() context.Context) {
+		ctx := ctxFn()
+		goroutine.New().
+			WithLogger(c.l().C(ctx).Mth("reservation-expiry")).
+			Go(ctx, func() {
+				c.reservationUc.ExpireReservationsCronHandler(ctx)
+			})
+	}
+}
+
+func (c *cronImpl) sessionMonitorAsync() cron.Action {
+	return func(ctxFn func() context.Context) {
+		ctx := ctxFn()
+		goroutine.New().
+			WithLogger(c.l().C(ctx).Mth("session-monitor")).
+			Go(ctx, func() {
+				c.sessionUc.SessionMonitorCronHandler(ctx)
+			})
+	}
+}
@@ -0,0 +1,46 @@
+-- +goose Up
+
+create table terminals
+(
+    id           varchar primary key,
+    platform_id  varchar   not null,
+    party_id     varchar   not null,
+    country_code varchar   not null,
+    ref_id       varchar,
+    details      jsonb,
+    last_updated timestamp not null,
+    last_sent    timestamp,
+    created_at   timestamp not null default now(),
+    updated_at   timestamp not null default now(),
+    deleted_at   timestamp
+);
+
+create index idx_term_platform on terminals (platform_id);
+create index idx_term_party on terminals (party_id, country_code);
+create index idx_term_ref on terminals (ref_id);
+create index idx_term_last_upd on terminals (last_updated);
+
+create table financial_advices
+(
+    id           varchar primary key,
+    platform_id  varchar   not null,
+    party_id     varchar   not null,
+    country_code varchar   not null,
+    ref_id       varchar,
+    details      jsonb,
+    auth_ref     varchar GENERATED ALWAYS as (details ->> 'authRef') stored,
+    last_updated timestamp not null,
+    last_sent    timestamp,
+    created_at   timestamp not null default now(),
+    updated_at   timestamp not null default now(),
+    deleted_at   timestamp
+);
+
+create index idx_fa_platform on financial_advices (platform_id);
+create index idx_fa_party on financial_advices (party_id, country_code);
+create index idx_fa_auth_ref on financial_advices (auth_ref);
+create index idx_fa_last_upd on financial_advices (last_updated);
+
+-- +goose Down
+drop table terminals;
+drop table financial_advices;
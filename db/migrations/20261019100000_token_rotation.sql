@@ -0,0 +1,24 @@
+-- +goose Up
+
+alter table platforms add token_b_prev varchar;
+alter table platforms add token_b_prev_exp timestamp;
+
+create index idx_platforms_token_b_prev on platforms (token_b_prev) where token_b_prev is not null;
+
+create table platform_token_rotations
+(
+    id          uuid primary key,
+    platform_id varchar   not null,
+    status      varchar   not null,
+    error       varchar,
+    created_at  timestamp not null default now(),
+    updated_at  timestamp not null default now(),
+    deleted_at  timestamp
+);
+
+create index idx_ptr_platform on platform_token_rotations (platform_id, created_at);
+
+-- +goose Down
+drop table platform_token_rotations;
+alter table platforms drop column token_b_prev_exp;
+alter table platforms drop column token_b_prev;
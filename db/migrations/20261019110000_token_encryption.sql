@@ -0,0 +1,46 @@
+-- +goose Up
+
+-- tokens are kept encrypted in token_* columns and looked up by keyed hashes
+-- existing plain tokens (rows with empty token_a_hash) are encrypted and hashed by the service on startup with the configured key
+-- until then such rows are still looked up by plain tokens
+alter table platforms add token_a_hash varchar;
+alter table platforms add token_b_hash varchar;
+alter table platforms add token_c_hash varchar;
+alter table platforms add token_b_prev_hash varchar;
+
+drop index idx_platforms_token_a;
+drop index idx_platforms_token_b;
+drop index idx_platforms_token_c;
+drop index idx_platforms_token_b_prev;
+
+create index idx_platforms_token_a_hash on platforms (token_a_hash);
+create index idx_platforms_token_b_hash on platforms (token_b_hash) where token_b_hash is not null;
+create index idx_platforms_token_c_hash on platforms (token_c_hash) where token_c_hash is not null;
+create index idx_platforms_token_b_prev_hash on platforms (token_b_prev_hash) where token_b_prev_hash is not null;
+
+-- mask tokens already written to logs
+update logs set token = case when length(token) > 8 then left(token, 4) || '****' else '****' end where token is not null;
+update logs set details = jsonb_set(details, '{hdr,Authorization}', '["****"]') where details #> '{hdr,Authorization}' is not null;
+update logs set details = jsonb_set(details, '{hdr,authorization}', '"****"') where details #> '{hdr,authorization}' is not null;
+update logs set details = jsonb_set(details, '{rq,token}', '"****"') where event like 'credentials.%' and details #> '{rq,token}' is not null;
+update logs set details = jsonb_set(details, '{rq,data,token}', '"****"') where event like 'credentials.%' and details #> '{rq,data,token}' is not null;
+update logs set details = jsonb_set(details, '{rs,data,token}', '"****"') where event like 'credentials.%' and details #> '{rs,data,token}' is not null;
+
+-- +goose Down
+
+-- the migration can't be rolled back once the service has started: tokens are encrypted by the application key and can't be decrypted here
+-- a version storing plain tokens doesn't recognize encrypted ones, so every connected platform has to be re-registered after rollback
+drop index idx_platforms_token_b_prev_hash;
+drop index idx_platforms_token_c_hash;
+drop index idx_platforms_token_b_hash;
+drop index idx_platforms_token_a_hash;
+
+alter table platforms drop column token_b_prev_hash;
+alter table platforms drop column token_c_hash;
+alter table platforms drop column token_b_hash;
+alter table platforms drop column token_a_hash;
+
+create index idx_platforms_token_a on platforms (token_a);
+create index idx_platforms_token_b on platforms (token_b) where token_b is not null;
+create index idx_platforms_token_c on platforms (token_c) where token_c is not null;
+create index idx_platforms_token_b_prev on platforms (token_b_prev) where token_b_prev is not null;
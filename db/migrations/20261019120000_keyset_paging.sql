@@ -0,0 +1,47 @@
+-- +goose Up
+
+-- search results are ordered by (last_updated, id) and paged by keyset
+drop index idx_loc_last_upd;
+drop index idx_evse_last_upd;
+drop index idx_con_last_upd;
+drop index idx_trf_last_upd;
+drop index idx_tkn_last_upd;
+drop index idx_sess_last_upd;
+drop index idx_cdr_last_upd;
+drop index idx_parties_last_upd;
+drop index idx_term_last_upd;
+drop index idx_fa_last_upd;
+
+create index idx_loc_last_upd on locations (last_updated, id);
+create index idx_evse_last_upd on evses (last_updated, id);
+create index idx_con_last_upd on connectors (last_updated, id);
+create index idx_trf_last_upd on tariffs (last_updated, id);
+create index idx_tkn_last_upd on tokens (last_updated, id);
+create index idx_sess_last_upd on sessions (last_updated, id);
+create index idx_cdr_last_upd on cdrs (last_updated, id);
+create index idx_parties_last_upd on parties (last_updated, id);
+create index idx_term_last_upd on terminals (last_updated, id);
+create index idx_fa_last_upd on financial_advices (last_updated, id);
+
+-- +goose Down
+drop index idx_fa_last_upd;
+drop index idx_term_last_upd;
+drop index idx_parties_last_upd;
+drop index idx_cdr_last_upd;
+drop index idx_sess_last_upd;
+drop index idx_tkn_last_upd;
+drop index idx_trf_last_upd;
+drop index idx_con_last_upd;
+drop index idx_evse_last_upd;
+drop index idx_loc_last_upd;
+
+create index idx_loc_last_upd on locations (last_updated);
+create index idx_evse_last_upd on evses (last_updated);
+create index idx_con_last_upd on connectors (last_updated);
+create index idx_trf_last_upd on tariffs (last_updated);
+create index idx_tkn_last_upd on tokens (last_updated);
+create index idx_sess_last_upd on sessions (last_updated);
+create index idx_cdr_last_upd on cdrs (last_updated);
+create index idx_parties_last_upd on parties (last_updated);
+create index idx_term_last_upd on terminals (last_updated);
+create index idx_fa_last_upd on financial_advices (last_updated);
@@ -0,0 +1,36 @@
+-- +goose Up
+
+create table reservations
+(
+    id             uuid primary key,
+    reservation_id varchar   not null,
+    platform_id    varchar   not null,
+    party_id       varchar   not null,
+    country_code   varchar   not null,
+    ref_id         varchar,
+    status         varchar   not null,
+    expire_date    timestamp not null,
+    details        jsonb,
+    token_id       varchar GENERATED ALWAYS as (details ->> 'tokenId') stored,
+    location_id    varchar GENERATED ALWAYS as (details ->> 'locationId') stored,
+    evse_id        varchar GENERATED ALWAYS as (details ->> 'evseId') stored,
+    session_id     varchar GENERATED ALWAYS as (details ->> 'sessionId') stored,
+    last_updated   timestamp not null,
+    last_sent      timestamp,
+    created_at     timestamp not null default now(),
+    updated_at     timestamp not null default now(),
+    deleted_at     timestamp
+);
+
+-- reservation id is given by eMSP, so it's unique only within the party of the platform
+create unique index idx_res_ext on reservations (platform_id, country_code, party_id, reservation_id);
+create index idx_res_party on reservations (party_id, country_code);
+create index idx_res_ref on reservations (ref_id);
+create index idx_res_status_exp on reservations (status, expire_date);
+create index idx_res_token on reservations (token_id, status);
+create index idx_res_loc on reservations (location_id, evse_id);
+create index idx_res_sess on reservations (session_id);
+create index idx_res_last_upd on reservations (last_updated, id);
+
+-- +goose Down
+drop table reservations;
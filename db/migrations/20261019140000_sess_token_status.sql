@@ -0,0 +1,8 @@
+-- +goose Up
+alter table sessions add token_id varchar GENERATED ALWAYS as (details -> 'cdrToken' ->> 'uid') stored;
+alter table sessions add status varchar GENERATED ALWAYS as (details ->> 'status') stored;
+create index idx_sess_token on sessions(token_id, status);
+
+-- +goose Down
+alter table sessions drop token_id;
+alter table sessions drop status;
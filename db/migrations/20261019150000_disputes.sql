@@ -0,0 +1,27 @@
+-- +goose Up
+
+create table disputes
+(
+    id           varchar primary key,
+    platform_id  varchar   not null,
+    party_id     varchar   not null,
+    country_code varchar   not null,
+    ref_id       varchar,
+    status       varchar   not null,
+    details      jsonb,
+    cdr_id       varchar GENERATED ALWAYS as (details ->> 'cdrId') stored,
+    last_updated timestamp not null,
+    last_sent    timestamp,
+    created_at   timestamp not null default now(),
+    updated_at   timestamp not null default now(),
+    deleted_at   timestamp
+);
+
+create index idx_disp_platform on disputes (platform_id);
+create index idx_disp_party on disputes (party_id, country_code);
+create index idx_disp_cdr on disputes (cdr_id, status);
+create index idx_disp_status on disputes (status);
+create index idx_disp_last_upd on disputes (last_updated, id);
+
+-- +goose Down
+drop table disputes;
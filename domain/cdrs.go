@@ -5,6 +5,33 @@ import (
 	"time"
 )
 
+const (
+	CdrIssueSeverityWarning = "warning" // CdrIssueSeverityWarning CDR is accepted, but needs attention
+	CdrIssueSeverityError   = "error"   // CdrIssueSeverityError CDR is inconsistent, it's rejected in strict mode
+
+	CdrIssueEnergy      = "energy"       // CdrIssueEnergy total energy doesn't match charging periods or session
+	CdrIssueTime        = "time"         // CdrIssueTime total time doesn't match start/end
+	CdrIssueParkingTime = "parking-time" // CdrIssueParkingTime parking time exceeds total time
+	CdrIssueCost        = "cost"         // CdrIssueCost total cost is inconsistent with cost components or tariffs
+	CdrIssueSession     = "session"      // CdrIssueSession CDR doesn't match the related session
+)
+
+// CdrIssue discrepancy found when CDR is verified
+type CdrIssue struct {
+	Severity string `json:"severity"` // Severity warning or error
+	Code     string `json:"code"`     // Code kind of discrepancy
+	Message  string `json:"message"`  // Message human readable details
+}
+
+// CdrIssueReport discrepancies of CDRs grouped by partner
+type CdrIssueReport struct {
+	PartyExtId
+	PlatformId string   // PlatformId remote platform
+	Warnings   int      // Warnings number of CDRs with warnings only
+	Errors     int      // Errors number of CDRs with errors
+	Cdrs       []string // Cdrs ids of CDRs with discrepancies
+}
+
 type CdrLocation struct {
 	Id                 string      `json:"id"`                   // Id Uniquely identifies the location within the CPO’s platform
 	Name               string      `json:"name,omitempty"`       // Name of the location
@@ -57,6 +84,10 @@ type CdrDetails struct {
 	Credit                   bool              `json:"credit"`                         // Credit when set to true, this is a Credit CDR, and the field credit_reference_id needs to be set as wel
 	CreditReferenceId        string            `json:"creditReferenceId,omitempty"`    // CreditReferenceId to be set for a Credit CDR
 	HomeChargingCompensation bool              `json:"homeChargingCompensation"`       // HomeChargingCompensation when set to true, this CDR is for a charging cdr using the home charge
+	TaxAmounts               []*TaxAmount      `json:"taxAmounts,omitempty"`           // TaxAmounts breakdown of the taxes applied to the total cost
+	Issues                   []*CdrIssue       `json:"issues,omitempty"`               // Issues discrepancies found by verification
+	Credited                 *Price            `json:"credited,omitempty"`             // Credited total amount credited by credit CDRs referencing this CDR
+	CreditCdrIds             []string          `json:"creditCdrIds,omitempty"`         // CreditCdrIds credit CDRs issued against this CDR
 
 }
 
@@ -66,6 +97,17 @@ type Cdr struct {
 	Details CdrDetails `json:"details"` // Details cdr details
 }
 
+// CdrCredit request to issue a credit CDR against the original one
+type CdrCredit struct {
+	Id     string // Id of the credit CDR, generated if empty. A repeated credit with the same id returns the stored credit
+	Amount *Price // Amount to credit, the whole remaining creditable amount if empty
+	Remark string // Remark reason of the credit
+}
+
+// CreditBuilder builds credit CDR against the original one and applies the credited amount to the original
+// existing is the stored CDR with the id of the credit, if the builder returns it, the request is repeated and nothing is stored
+type CreditBuilder func(original, existing *Cdr) (*Cdr, error)
+
 type CdrSearchCriteria struct {
 	PageRequest
 	ExtId        *PartyExtId // ExtId by party ext ID
@@ -73,6 +115,7 @@ type CdrSearchCriteria struct {
 	IncPlatforms []string    // IncPlatforms includes platform Ids
 	ExcPlatforms []string    // ExcPlatforms exclude platform Ids
 	Ids          []string    // Ids by list Ids
+	WithIssues   bool        // WithIssues retrieves only cdrs with discrepancies
 }
 
 type CdrSearchResponse struct {
@@ -83,23 +126,43 @@ type CdrSearchResponse struct {
 type CdrService interface {
 	// PutCdr creates or updates cdr
 	PutCdr(ctx context.Context, sess *Cdr) (*Cdr, error)
+	// PutCdrs creates or updates cdrs in a single transaction
+	// rejected items are reported in results, the rest are stored, returns stored cdrs and results in order of request
+	PutCdrs(ctx context.Context, cdrs []*Cdr) ([]*Cdr, []*BulkItemResult, error)
 	// GetCdr retrieves cdr by ID
 	GetCdr(ctx context.Context, sessId string) (*Cdr, error)
 	// DeleteCdrsByExtId deletes all cdrs by party ext id
 	DeleteCdrsByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteCdrsByPlatform deletes all cdrs of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteCdrsByPlatform(ctx context.Context, platformId string, archive bool) error
 	// SearchCdrs searches cdrs
 	SearchCdrs(ctx context.Context, cr *CdrSearchCriteria) (*CdrSearchResponse, error)
+	// Verify cross-checks cdr totals against charging periods, tariffs and the related session
+	Verify(ctx context.Context, cdr *Cdr, sess *Session) []*CdrIssue
+	// Credit issues a credit CDR mirroring the original one with negated costs
+	// the credited amount is tracked on the original CDR and cannot exceed its total cost
+	// returns the credit CDR and the updated original one
+	Credit(ctx context.Context, cdrId string, rq *CdrCredit) (*Cdr, *Cdr, error)
 }
 
 type CdrStorage interface {
 	// MergeCdr creates or updates cdr
 	MergeCdr(ctx context.Context, sess *Cdr) error
+	// MergeCdrs creates or updates cdrs in a single transaction
+	// rows are upserted in batches, stored rows are updated only if they aren't later than merged ones
+	MergeCdrs(ctx context.Context, cdrs []*Cdr) error
 	// UpdateCdr updates cdr
 	UpdateCdr(ctx context.Context, sess *Cdr) error
+	// CreateCreditCdr locks the original cdr, builds credit cdr against it, creates the credit and updates the original in a single transaction
+	// concurrent credits of the same cdr are serialized, so that the builder always gets the latest credited amount
+	// returns the credit cdr and the updated original one
+	CreateCreditCdr(ctx context.Context, originalId, creditId string, build CreditBuilder) (*Cdr, *Cdr, error)
 	// GetCdr retrieves cdr by ID
 	GetCdr(ctx context.Context, sessId string) (*Cdr, error)
 	// DeleteCdrsByExtId deletes all cdrs by party ext id
 	DeleteCdrsByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteCdrsByPlatform deletes all cdrs of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteCdrsByPlatform(ctx context.Context, platformId string, archive bool) error
 	// SearchCdrs searches cdrs
 	SearchCdrs(ctx context.Context, cr *CdrSearchCriteria) (*CdrSearchResponse, error)
 }
@@ -48,11 +48,13 @@ type CancelReservation struct {
 }
 
 type StartSession struct {
-	Token       *Token   `json:"token"`                 // Token object the Charge Point has to use to start a new session
-	LocationId  string   `json:"locationId"`            // LocationId on which a session is to be started
-	EvseId      string   `json:"evseId,omitempty"`      // EvseId of the EVSE of this Location on which a session is to be started
-	ConnectorId string   `json:"connectorId,omitempty"` // ConnectorId  of the Connector of the EVSE on which a session 	is to be started
-	KwhLimit    *float64 `json:"kwhLimit,omitempty"`    // KwhLimit allows setting limit on maximum Kwh (Yandex extension, isn't a part of OCPI protocol)
+	Token             *Token   `json:"token"`                       // Token object the Charge Point has to use to start a new session
+	LocationId        string   `json:"locationId"`                  // LocationId on which a session is to be started
+	EvseId            string   `json:"evseId,omitempty"`            // EvseId of the EVSE of this Location on which a session is to be started
+	ConnectorId       string   `json:"connectorId,omitempty"`       // ConnectorId  of the Connector of the EVSE on which a session 	is to be started
+	KwhLimit          *float64 `json:"kwhLimit,omitempty"`          // KwhLimit allows setting limit on maximum Kwh (Yandex extension, isn't a part of OCPI protocol)
+	CostLimit         *float64 `json:"costLimit,omitempty"`         // CostLimit max total cost of the session, enforced by the platform and isn't sent to CPO
+	CostLimitCurrency string   `json:"costLimitCurrency,omitempty"` // CostLimitCurrency ISO-4217 currency code of the cost limit
 }
 
 type StopSession struct {
@@ -141,3 +143,11 @@ type CommandStorage interface {
 	// SearchCommands searches commands
 	SearchCommands(ctx context.Context, cr *CommandSearchCriteria) (*CommandSearchResponse, error)
 }
+
+// CommandResultNotifier notifies all the service instances that the command result is received
+type CommandResultNotifier interface {
+	// NotifyCommandResult notifies waiters of the command
+	NotifyCommandResult(ctx context.Context, cmdId string)
+	// SubscribeCommandResult subscribes on the command result, the returned func must be called to unsubscribe
+	SubscribeCommandResult(ctx context.Context, cmdId string) (<-chan struct{}, func())
+}
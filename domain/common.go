@@ -5,11 +5,13 @@ import "time"
 const (
 	PageSizeMaxLimit = 100
 	PageSizeDefault  = 20
+	BulkMaxSize      = 1000
 )
 
 type PageRequest struct {
 	Offset   *int       // Offset paging offset
 	Limit    *int       // Limit paging limit
+	Cursor   *string    // Cursor opaque position of the page returned with the previous page, offset is ignored if specified
 	DateFrom *time.Time // DateFrom updating period
 	DateTo   *time.Time // DateTo updating period
 }
@@ -20,6 +22,14 @@ type PageResponse struct {
 	NextPage *PageRequest // NextPage paging criteria to request the next page
 }
 
+// NextCursor returns cursor of the next page, nil if it's the last page or paging by offset
+func (p *PageResponse) NextCursor() *string {
+	if p.NextPage == nil {
+		return nil
+	}
+	return p.NextPage.Cursor
+}
+
 type DisplayText struct {
 	Language string
 	Text     string
@@ -37,3 +47,10 @@ type OcpiItem struct {
 	LastUpdated time.Time  `json:"lastUpdated"` // LastUpdated last updated
 	LastSent    *time.Time `json:"lastSent"`    // LastSent last sent
 }
+
+// BulkItemResult result of processing an item of the bulk request
+type BulkItemResult struct {
+	Id      string // Id item id
+	Applied bool   // Applied true if changes are applied, false if item is rejected or later changes found
+	Err     error  // Err if item is rejected
+}
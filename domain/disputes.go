@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DisputeStatusOpen         = "OPEN"         // DisputeStatusOpen dispute is opened against CDR
+	DisputeStatusAcknowledged = "ACKNOWLEDGED" // DisputeStatusAcknowledged partner has acknowledged the dispute
+	DisputeStatusResolved     = "RESOLVED"     // DisputeStatusResolved dispute is resolved by a credit CDR
+	DisputeStatusRejected     = "REJECTED"     // DisputeStatusRejected partner has rejected the dispute
+
+	DisputeEvidenceSession         = "session"          // DisputeEvidenceSession snapshot of the session with charging periods
+	DisputeEvidenceChargingPeriods = "charging-periods" // DisputeEvidenceChargingPeriods snapshot of CDR charging periods
+	DisputeEvidenceLog             = "log"              // DisputeEvidenceLog OCPI log messages by request id
+	DisputeEvidenceDocument        = "document"         // DisputeEvidenceDocument any document provided by the backend
+)
+
+// DisputeEvidence evidence attached to a dispute
+type DisputeEvidence struct {
+	Type        string    `json:"type"`                  // Type of evidence
+	RefId       string    `json:"refId,omitempty"`       // RefId reference of the evidence (session id, log request id, document url)
+	Description string    `json:"description,omitempty"` // Description human readable description
+	Data        any       `json:"data,omitempty"`        // Data snapshot of the evidence taken when it's attached
+	AddedAt     time.Time `json:"addedAt"`               // AddedAt when the evidence was attached
+}
+
+type DisputeDetails struct {
+	CdrId        string             `json:"cdrId"`                  // CdrId disputed CDR
+	SessionId    string             `json:"sessionId,omitempty"`    // SessionId session of the disputed CDR
+	Reason       string             `json:"reason"`                 // Reason why CDR is disputed
+	Amount       Price              `json:"amount"`                 // Amount in question
+	Currency     string             `json:"currency"`               // Currency of the amount
+	Evidence     []*DisputeEvidence `json:"evidence,omitempty"`     // Evidence attached to the dispute
+	Comment      string             `json:"comment,omitempty"`      // Comment given when status is changed
+	CreditCdrId  string             `json:"creditCdrId,omitempty"`  // CreditCdrId credit CDR the dispute is resolved by
+	Credited     *Price             `json:"credited,omitempty"`     // Credited total amount of credit CDRs linked to the dispute
+	CreditCdrIds []string           `json:"creditCdrIds,omitempty"` // CreditCdrIds credit CDRs linked to the dispute
+	ClosedAt     *time.Time         `json:"closedAt,omitempty"`     // ClosedAt when the dispute was resolved or rejected
+}
+
+// Dispute dispute of a CDR received from a partner
+// party attributes are of the partner owning the disputed CDR
+type Dispute struct {
+	OcpiItem
+	Id      string         `json:"id"`      // Id dispute id
+	Status  string         `json:"status"`  // Status dispute status
+	Details DisputeDetails `json:"details"` // Details dispute details
+}
+
+// DisputeSummary disputes of a partner
+type DisputeSummary struct {
+	PartyExtId
+	PlatformId   string             // PlatformId partner's platform
+	Open         int                // Open number of open disputes
+	Acknowledged int                // Acknowledged number of acknowledged disputes
+	Resolved     int                // Resolved number of disputes resolved by credit CDR
+	Rejected     int                // Rejected number of rejected disputes
+	Amounts      map[string]float64 // Amounts disputed amounts excl. VAT of open and acknowledged disputes by currency
+}
+
+type DisputeSearchCriteria struct {
+	PageRequest
+	ExtId        *PartyExtId // ExtId by party ext ID
+	RefId        string      // RefId by ref id
+	IncPlatforms []string    // IncPlatforms includes platform Ids
+	ExcPlatforms []string    // ExcPlatforms exclude platform Ids
+	Ids          []string    // Ids by list of Ids
+	Statuses     []string    // Statuses by list of statuses
+	CdrId        string      // CdrId by disputed CDR
+}
+
+type DisputeSearchResponse struct {
+	PageResponse
+	Items []*Dispute
+}
+
+type DisputeService interface {
+	// Create opens a dispute
+	Create(ctx context.Context, d *Dispute) (*Dispute, error)
+	// SetStatus changes status of the dispute, creditCdrId is required when dispute is resolved
+	SetStatus(ctx context.Context, id, status, comment, creditCdrId string) (*Dispute, error)
+	// AddEvidence attaches evidence to the dispute which isn't closed
+	AddEvidence(ctx context.Context, id string, ev *DisputeEvidence) (*Dispute, error)
+	// AddCredit links credit CDR to the dispute which isn't closed and adds its amount to the credited total
+	// credit CDR already linked is ignored
+	AddCredit(ctx context.Context, id, creditCdrId string, amount *Price) (*Dispute, error)
+	// Get retrieves dispute by ID
+	Get(ctx context.Context, id string) (*Dispute, error)
+	// Search searches disputes
+	Search(ctx context.Context, cr *DisputeSearchCriteria) (*DisputeSearchResponse, error)
+}
+
+type DisputeStorage interface {
+	// MergeDispute creates or updates dispute
+	MergeDispute(ctx context.Context, d *Dispute) error
+	// UpdateDispute updates dispute
+	UpdateDispute(ctx context.Context, d *Dispute) error
+	// GetDispute retrieves dispute by ID
+	GetDispute(ctx context.Context, id string) (*Dispute, error)
+	// SearchDisputes searches disputes
+	SearchDisputes(ctx context.Context, cr *DisputeSearchCriteria) (*DisputeSearchResponse, error)
+}
@@ -57,6 +57,16 @@ func (b *base) validateLastUpdated(ctx context.Context, lastUpdated time.Time) e
 	return nil
 }
 
+func (b *base) validateBulk(ctx context.Context, size int) error {
+	if size == 0 {
+		return errors.ErrBulkEmpty(ctx)
+	}
+	if size > domain.BulkMaxSize {
+		return errors.ErrBulkTooLarge(ctx, domain.BulkMaxSize)
+	}
+	return nil
+}
+
 func (b *base) validateOcpiItem(ctx context.Context, i *domain.OcpiItem) error {
 	if i.PlatformId == "" {
 		return errors.ErrPlatformIdEmpty(ctx)
@@ -220,3 +230,52 @@ func (b *base) validateAmount(ctx context.Context, entity, attr string, v *float
 	}
 	return nil
 }
+
+// putBulk prepares items of the bulk one by one, duplicated and rejected items are reported in results
+// prepare returns false if the item isn't applied because of later changes
+// returns applied items and results in order of the bulk
+func putBulk[T any](ctx context.Context, items []T, id func(T) string, prepare func(T) (bool, error)) ([]T, []*domain.BulkItemResult) {
+	var applied []T
+	results := make([]*domain.BulkItemResult, 0, len(items))
+	ids := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		itemId := id(item)
+		rs := &domain.BulkItemResult{Id: itemId}
+		results = append(results, rs)
+		if _, ok := ids[itemId]; ok && itemId != "" {
+			rs.Err = errors.ErrBulkDuplicateItem(ctx, itemId)
+			continue
+		}
+		ids[itemId] = struct{}{}
+		ok, err := prepare(item)
+		if err != nil {
+			rs.Err = err
+			continue
+		}
+		if !ok {
+			continue
+		}
+		rs.Applied = true
+		applied = append(applied, item)
+	}
+	return applied, results
+}
+
+// searchByIds retrieves stored items by ids
+// ids are split into chunks, so that each chunk fits a single page
+func searchByIds[T any](ids []string, id func(T) string, search func(ids []string) ([]T, error)) (map[string]T, error) {
+	rs := make(map[string]T, len(ids))
+	ids = kit.Filter(ids, func(v string) bool { return v != "" })
+	for len(ids) > 0 {
+		n := min(len(ids), domain.PageSizeMaxLimit)
+		items, err := search(ids[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			rs[id(item)] = item
+		}
+		ids = ids[n:]
+	}
+	return rs, nil
+}
@@ -119,6 +119,43 @@ func (s *baseTestSuite) Test_ValidateImage() {
 
 }
 
+func (s *baseTestSuite) Test_PutBulk() {
+	applied, rs := putBulk(s.Ctx, []string{"1", "2", "1", "3", "4"}, func(v string) string { return v },
+		func(v string) (bool, error) {
+			switch v {
+			case "3":
+				return false, nil
+			case "4":
+				return false, errors.ErrLocIdEmpty(s.Ctx)
+			}
+			return true, nil
+		})
+	s.Equal([]string{"1", "2"}, applied)
+	s.Len(rs, 5)
+	s.True(rs[0].Applied)
+	s.True(rs[1].Applied)
+	s.AssertAppErr(rs[2].Err, errors.ErrCodeBulkDuplicateItem)
+	s.False(rs[3].Applied)
+	s.Nil(rs[3].Err)
+	s.AssertAppErr(rs[4].Err, errors.ErrCodeLocIdEmpty)
+}
+
+func (s *baseTestSuite) Test_SearchByIds_Chunks() {
+	var ids []string
+	for i := 0; i < domain.PageSizeMaxLimit*2+1; i++ {
+		ids = append(ids, kit.NewRandString())
+	}
+	ids = append(ids, "")
+	var calls []int
+	rs, err := searchByIds(ids, func(v string) string { return v }, func(chunk []string) ([]string, error) {
+		calls = append(calls, len(chunk))
+		return chunk, nil
+	})
+	s.NoError(err)
+	s.Equal([]int{domain.PageSizeMaxLimit, domain.PageSizeMaxLimit, 1}, calls)
+	s.Len(rs, len(ids)-1)
+}
+
 func (s *baseTestSuite) ocpiItem() *domain.OcpiItem {
 	return &domain.OcpiItem{
 		ExtId: domain.PartyExtId{
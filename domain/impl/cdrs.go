@@ -38,24 +38,85 @@ func (s *cdrService) PutCdr(ctx context.Context, cdr *domain.Cdr) (*domain.Cdr,
 		return nil, err
 	}
 
-	// check last_updated
-	if stored != nil && cdr.LastUpdated.Before(stored.LastUpdated) {
+	ok, err := s.preparePutCdr(ctx, cdr, stored)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
 		l.Warn("later changes found")
 		return nil, nil
 	}
 
-	// validate
-	err = s.validateAndPopulatePut(ctx, cdr, stored)
+	err = s.storage.MergeCdr(ctx, cdr)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.storage.MergeCdr(ctx, cdr)
+	return cdr, nil
+}
+
+func (s *cdrService) PutCdrs(ctx context.Context, cdrs []*domain.Cdr) ([]*domain.Cdr, []*domain.BulkItemResult, error) {
+	l := s.l().C(ctx).Mth("put-cdrs").F(kit.KV{"count": len(cdrs)}).Dbg()
+
+	if err := s.validateBulk(ctx, len(cdrs)); err != nil {
+		return nil, nil, err
+	}
+
+	// retrieve stored cdrs at once
+	stored, err := searchByIds(kit.Select(cdrs, func(cdr *domain.Cdr) string { return cdr.Id }),
+		func(cdr *domain.Cdr) string { return cdr.Id },
+		func(ids []string) ([]*domain.Cdr, error) {
+			rs, err := s.storage.SearchCdrs(ctx, &domain.CdrSearchCriteria{
+				PageRequest: domain.PageRequest{Limit: kit.IntPtr(len(ids))},
+				Ids:         ids,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return rs.Items, nil
+		})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return cdr, nil
+	applied, results := putBulk(ctx, cdrs, func(cdr *domain.Cdr) string { return cdr.Id }, func(cdr *domain.Cdr) (bool, error) {
+		ok, err := s.preparePutCdr(ctx, cdr, stored[cdr.Id])
+		if err == nil && !ok {
+			l.F(kit.KV{"cdrId": cdr.Id}).Warn("later changes found")
+		}
+		return ok, err
+	})
+
+	if len(applied) == 0 {
+		return nil, results, nil
+	}
+
+	// all the applied cdrs are stored in a single transaction
+	if err := s.storage.MergeCdrs(ctx, applied); err != nil {
+		return nil, nil, err
+	}
+
+	return applied, results, nil
+}
+
+// preparePutCdr validates and populates cdr with stored attributes
+// returns false if later changes found
+func (s *cdrService) preparePutCdr(ctx context.Context, cdr, stored *domain.Cdr) (bool, error) {
+	if cdr.Id == "" {
+		return false, errors.ErrCdrIdEmpty(ctx)
+	}
+
+	// check last_updated
+	if stored != nil && cdr.LastUpdated.Before(stored.LastUpdated) {
+		return false, nil
+	}
+
+	// validate
+	if err := s.validateAndPopulatePut(ctx, cdr, stored); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (s *cdrService) GetCdr(ctx context.Context, cdrId string) (*domain.Cdr, error) {
@@ -82,6 +143,14 @@ func (s *cdrService) DeleteCdrsByExtId(ctx context.Context, extId domain.PartyEx
 	return s.storage.DeleteCdrsByExtId(ctx, extId)
 }
 
+func (s *cdrService) DeleteCdrsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("del-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return errors.ErrPlatformIdEmpty(ctx)
+	}
+	return s.storage.DeleteCdrsByPlatform(ctx, platformId, archive)
+}
+
 func (s *cdrService) validateCdrLocation(ctx context.Context, loc *domain.CdrLocation) error {
 
 	// name
@@ -238,22 +307,22 @@ func (s *cdrService) validate(ctx context.Context, cdr *domain.Cdr) error {
 	}
 
 	// cost
-	if err := s.validatePrice(ctx, "cdr", "total_cost", &cdr.Details.TotalCost); err != nil {
+	if err := s.validateCost(ctx, cdr, "total_cost", &cdr.Details.TotalCost); err != nil {
 		return err
 	}
-	if err := s.validatePrice(ctx, "cdr", "total_fixed_cost", cdr.Details.TotalFixedCost); err != nil {
+	if err := s.validateCost(ctx, cdr, "total_fixed_cost", cdr.Details.TotalFixedCost); err != nil {
 		return err
 	}
-	if err := s.validatePrice(ctx, "cdr", "total_energy_cost", cdr.Details.TotalEnergyCost); err != nil {
+	if err := s.validateCost(ctx, cdr, "total_energy_cost", cdr.Details.TotalEnergyCost); err != nil {
 		return err
 	}
-	if err := s.validatePrice(ctx, "cdr", "total_time_cost", cdr.Details.TotalTimeCost); err != nil {
+	if err := s.validateCost(ctx, cdr, "total_time_cost", cdr.Details.TotalTimeCost); err != nil {
 		return err
 	}
-	if err := s.validatePrice(ctx, "cdr", "total_parking_cost", cdr.Details.TotalParkingCost); err != nil {
+	if err := s.validateCost(ctx, cdr, "total_parking_cost", cdr.Details.TotalParkingCost); err != nil {
 		return err
 	}
-	if err := s.validatePrice(ctx, "cdr", "total_reservation_cost", cdr.Details.TotalReservationCost); err != nil {
+	if err := s.validateCost(ctx, cdr, "total_reservation_cost", cdr.Details.TotalReservationCost); err != nil {
 		return err
 	}
 
@@ -279,6 +348,9 @@ func (s *cdrService) validate(ctx context.Context, cdr *domain.Cdr) error {
 	}
 
 	// credit reference
+	if cdr.Details.Credit && cdr.Details.CreditReferenceId == "" {
+		return errors.ErrCdrEmptyAttr(ctx, "cdr", "credit_reference_id")
+	}
 	if err := s.validateMaxLen(ctx, cdr.Details.CreditReferenceId, 39, "cdr.credit_reference_id"); err != nil {
 		return err
 	}
@@ -305,6 +377,14 @@ func (s *cdrService) validate(ctx context.Context, cdr *domain.Cdr) error {
 	return nil
 }
 
+// validateCost validates cost of the cdr, costs of a credit cdr are negative
+func (s *cdrService) validateCost(ctx context.Context, cdr *domain.Cdr, attr string, price *domain.Price) error {
+	if cdr.Details.Credit {
+		price = scalePrice(price, -1)
+	}
+	return s.validatePrice(ctx, "cdr", attr, price)
+}
+
 func (s *cdrService) validateAndPopulatePut(ctx context.Context, cdr, stored *domain.Cdr) error {
 
 	if stored != nil {
@@ -318,6 +398,9 @@ func (s *cdrService) validateAndPopulatePut(ctx context.Context, cdr, stored *do
 		if cdr.ExtId.PartyId == "" || cdr.ExtId.CountryCode == "" {
 			cdr.ExtId = stored.ExtId
 		}
+		// credited balance is managed by credit cdrs only
+		cdr.Details.Credited = stored.Details.Credited
+		cdr.Details.CreditCdrIds = stored.Details.CreditCdrIds
 	}
 
 	return s.validate(ctx, cdr)
@@ -0,0 +1,194 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"math"
+)
+
+const (
+	cdrCostPrecision = 10000.0 // cdrCostPrecision calculated costs are rounded to 4 decimals
+)
+
+func (s *cdrService) Credit(ctx context.Context, cdrId string, rq *domain.CdrCredit) (*domain.Cdr, *domain.Cdr, error) {
+	l := s.l().C(ctx).Mth("credit").F(kit.KV{"cdrId": cdrId}).Dbg()
+
+	if cdrId == "" {
+		return nil, nil, errors.ErrCdrIdEmpty(ctx)
+	}
+	if rq == nil {
+		return nil, nil, errors.ErrCdrEmptyAttr(ctx, "cdr", "credit")
+	}
+	if err := s.validateMaxLen(ctx, rq.Remark, 255, "cdr.remark"); err != nil {
+		return nil, nil, err
+	}
+
+	creditId := rq.Id
+	if creditId == "" {
+		creditId = kit.NewId()
+	}
+
+	// credit is built against the locked original cdr, so that concurrent credits see the latest credited amount
+	var amount *domain.Price
+	credit, orig, err := s.storage.CreateCreditCdr(ctx, cdrId, creditId, func(orig, existing *domain.Cdr) (*domain.Cdr, error) {
+		if orig == nil {
+			return nil, errors.ErrCdrCreditNotFound(ctx, cdrId)
+		}
+		if orig.Details.Credit {
+			return nil, errors.ErrCdrCreditOfCredit(ctx)
+		}
+
+		// repeated credit of the same cdr is returned as is, the credited amount isn't changed
+		if existing != nil {
+			if existing.Details.Credit && existing.Details.CreditReferenceId == orig.Id {
+				return existing, nil
+			}
+			return nil, errors.ErrCdrCreditIdExists(ctx, creditId)
+		}
+
+		// check amount against the remaining creditable amount
+		var err error
+		amount, err = s.creditAmount(ctx, orig, rq.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		// build credit cdr
+		credit := s.buildCredit(orig, creditId, rq, amount)
+		if err := s.validate(ctx, credit); err != nil {
+			return nil, err
+		}
+
+		// track credited balance on the original cdr
+		orig.Details.Credited = addPrice(orig.Details.Credited, amount)
+		orig.Details.CreditCdrIds = append(orig.Details.CreditCdrIds, credit.Id)
+
+		return credit, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if amount == nil {
+		l.F(kit.KV{"creditId": credit.Id}).Inf("already credited")
+		return credit, orig, nil
+	}
+
+	l.F(kit.KV{"creditId": credit.Id, "amount": amount.ExclVat}).Inf("credited")
+
+	return credit, orig, nil
+}
+
+// creditAmount checks the requested amount against the remaining creditable amount
+// if amount isn't requested, the whole remaining amount is credited
+func (s *cdrService) creditAmount(ctx context.Context, orig *domain.Cdr, requested *domain.Price) (*domain.Price, error) {
+
+	remaining := addPrice(&orig.Details.TotalCost, scalePrice(orig.Details.Credited, -1))
+
+	amount := remaining
+	if requested != nil {
+		if requested.ExclVat <= 0 {
+			return nil, errors.ErrCdrInvalidAttr(ctx, "credit", "amount")
+		}
+		if err := s.validatePrice(ctx, "credit", "amount", requested); err != nil {
+			return nil, err
+		}
+		amount = &domain.Price{ExclVat: requested.ExclVat, InclVat: requested.InclVat}
+	}
+
+	if remaining.ExclVat < cdrCostTolerance || amount.ExclVat > remaining.ExclVat+cdrCostTolerance {
+		return nil, errors.ErrCdrCreditExceeded(ctx, amount.ExclVat, remaining.ExclVat)
+	}
+
+	// amount incl. VAT is proportional to the remaining one if not requested
+	if remaining.InclVat != nil {
+		if amount.InclVat == nil {
+			amount.InclVat = kit.Float64Ptr(roundCost(*remaining.InclVat * amount.ExclVat / remaining.ExclVat))
+		}
+		if *amount.InclVat > *remaining.InclVat+cdrCostTolerance {
+			return nil, errors.ErrCdrCreditExceeded(ctx, *amount.InclVat, *remaining.InclVat)
+		}
+	}
+
+	return amount, nil
+}
+
+// buildCredit builds credit cdr mirroring the original one
+// total cost is the negated amount, cost components are negated in proportion to the amount
+func (s *cdrService) buildCredit(orig *domain.Cdr, creditId string, rq *domain.CdrCredit, amount *domain.Price) *domain.Cdr {
+	credit := &domain.Cdr{
+		OcpiItem: domain.OcpiItem{
+			ExtId:       orig.ExtId,
+			PlatformId:  orig.PlatformId,
+			RefId:       orig.RefId,
+			LastUpdated: kit.Now(),
+		},
+		Id:      creditId,
+		Details: orig.Details,
+	}
+
+	k := -amount.ExclVat / orig.Details.TotalCost.ExclVat
+	d := &credit.Details
+	d.Credit = true
+	d.CreditReferenceId = orig.Id
+	d.Remark = rq.Remark
+	d.TotalCost = *scalePrice(amount, -1)
+	d.TotalFixedCost = roundPrice(scalePrice(orig.Details.TotalFixedCost, k))
+	d.TotalEnergyCost = roundPrice(scalePrice(orig.Details.TotalEnergyCost, k))
+	d.TotalTimeCost = roundPrice(scalePrice(orig.Details.TotalTimeCost, k))
+	d.TotalParkingCost = roundPrice(scalePrice(orig.Details.TotalParkingCost, k))
+	d.TotalReservationCost = roundPrice(scalePrice(orig.Details.TotalReservationCost, k))
+	d.TaxAmounts = kit.Select(orig.Details.TaxAmounts, func(t *domain.TaxAmount) *domain.TaxAmount {
+		return &domain.TaxAmount{Name: t.Name, AccountNumber: t.AccountNumber, Percentage: t.Percentage, Amount: roundCost(t.Amount * k)}
+	})
+	d.Issues = nil
+	d.Credited = nil
+	d.CreditCdrIds = nil
+
+	return credit
+}
+
+// scalePrice multiplies price by k
+func scalePrice(p *domain.Price, k float64) *domain.Price {
+	if p == nil {
+		return nil
+	}
+	rs := &domain.Price{ExclVat: p.ExclVat * k}
+	if p.InclVat != nil {
+		rs.InclVat = kit.Float64Ptr(*p.InclVat * k)
+	}
+	return rs
+}
+
+// addPrice sums prices, incl. VAT is summed if the first price has it
+func addPrice(a, b *domain.Price) *domain.Price {
+	if a == nil {
+		return scalePrice(b, 1)
+	}
+	rs := scalePrice(a, 1)
+	if b == nil {
+		return rs
+	}
+	rs.ExclVat += b.ExclVat
+	if rs.InclVat != nil && b.InclVat != nil {
+		*rs.InclVat += *b.InclVat
+	}
+	return rs
+}
+
+func roundPrice(p *domain.Price) *domain.Price {
+	if p == nil {
+		return nil
+	}
+	p.ExclVat = roundCost(p.ExclVat)
+	if p.InclVat != nil {
+		p.InclVat = kit.Float64Ptr(roundCost(*p.InclVat))
+	}
+	return p
+}
+
+func roundCost(v float64) float64 {
+	return math.Round(v*cdrCostPrecision) / cdrCostPrecision
+}
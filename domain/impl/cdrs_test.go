@@ -1,10 +1,18 @@
 package impl
 
 import (
+	"context"
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/atomic"
+	"sync"
 	"testing"
+	"time"
 )
 
 type cdrTestSuite struct {
@@ -23,3 +31,269 @@ func (s *cdrTestSuite) TearDownSuite() {}
 func TestCdrSuite(t *testing.T) {
 	suite.Run(t, new(cdrTestSuite))
 }
+
+func (s *cdrTestSuite) consistentCdr() *domain.Cdr {
+	start := kit.Now().Add(-time.Hour * 2)
+	return &domain.Cdr{
+		Id: kit.NewId(),
+		Details: domain.CdrDetails{
+			StartDateTime: start,
+			EndDateTime:   start.Add(time.Hour * 2),
+			Currency:      "EUR",
+			CdrLocation:   domain.CdrLocation{Id: "loc", EvseId: "evse", ConnectorId: "con"},
+			ChargingPeriods: []*domain.ChargingPeriod{
+				{StartDateTime: start, Dimensions: []*domain.CdrDimension{{Type: domain.DimensionTypeEnergy, Volume: 10}, {Type: domain.DimensionTypeTime, Volume: 1}}},
+				{StartDateTime: start.Add(time.Hour), Dimensions: []*domain.CdrDimension{{Type: domain.DimensionTypeEnergy, Volume: 5}}},
+			},
+			TotalCost:       domain.Price{ExclVat: 10, InclVat: kit.Float64Ptr(12)},
+			TotalEnergy:     15,
+			TotalEnergyCost: &domain.Price{ExclVat: 8},
+			TotalTimeCost:   &domain.Price{ExclVat: 2},
+			TotalTime:       2,
+		},
+	}
+}
+
+func (s *cdrTestSuite) Test_Verify_Consistent() {
+	svc := NewCdrService(nil, nil)
+	cdr := s.consistentCdr()
+	sess := &domain.Session{Details: domain.SessionDetails{
+		StartDateTime: kit.TimePtr(cdr.Details.StartDateTime),
+		Kwh:           kit.Float64Ptr(15),
+		LocationId:    "loc",
+		EvseId:        "evse",
+		ConnectorId:   "con",
+		Currency:      "EUR",
+	}}
+	s.Empty(svc.Verify(s.Ctx, cdr, sess))
+}
+
+func (s *cdrTestSuite) Test_Verify_Inconsistent() {
+	svc := NewCdrService(nil, nil)
+	tests := []struct {
+		name     string
+		modify   func(cdr *domain.Cdr, sess *domain.Session)
+		code     string
+		severity string
+	}{
+		{"energy", func(cdr *domain.Cdr, sess *domain.Session) { cdr.Details.TotalEnergy = 20 }, domain.CdrIssueEnergy, domain.CdrIssueSeverityError},
+		{"time", func(cdr *domain.Cdr, sess *domain.Session) { cdr.Details.TotalTime = 3 }, domain.CdrIssueTime, domain.CdrIssueSeverityError},
+		{"end before start", func(cdr *domain.Cdr, sess *domain.Session) {
+			cdr.Details.EndDateTime = cdr.Details.StartDateTime.Add(-time.Hour)
+		}, domain.CdrIssueTime, domain.CdrIssueSeverityError},
+		{"parking", func(cdr *domain.Cdr, sess *domain.Session) { cdr.Details.TotalParkingTime = kit.Float64Ptr(3) }, domain.CdrIssueParkingTime, domain.CdrIssueSeverityError},
+		{"components", func(cdr *domain.Cdr, sess *domain.Session) { cdr.Details.TotalFixedCost = &domain.Price{ExclVat: 5} }, domain.CdrIssueCost, domain.CdrIssueSeverityError},
+		{"vat", func(cdr *domain.Cdr, sess *domain.Session) { cdr.Details.TotalCost.InclVat = kit.Float64Ptr(5) }, domain.CdrIssueCost, domain.CdrIssueSeverityError},
+		{"tariff currency", func(cdr *domain.Cdr, sess *domain.Session) {
+			cdr.Details.Tariffs = []*domain.Tariff{{Id: "trf", Details: domain.TariffDetails{Currency: "RSD"}}}
+		}, domain.CdrIssueCost, domain.CdrIssueSeverityError},
+		{"tariff max price", func(cdr *domain.Cdr, sess *domain.Session) {
+			cdr.Details.Tariffs = []*domain.Tariff{{Id: "trf", Details: domain.TariffDetails{Currency: "EUR", MaxPrice: &domain.Price{ExclVat: 5}}}}
+		}, domain.CdrIssueCost, domain.CdrIssueSeverityWarning},
+		{"session location", func(cdr *domain.Cdr, sess *domain.Session) { sess.Details.EvseId = "other" }, domain.CdrIssueSession, domain.CdrIssueSeverityError},
+		{"session currency", func(cdr *domain.Cdr, sess *domain.Session) { sess.Details.Currency = "RSD" }, domain.CdrIssueSession, domain.CdrIssueSeverityError},
+		{"session energy", func(cdr *domain.Cdr, sess *domain.Session) { sess.Details.Kwh = kit.Float64Ptr(12) }, domain.CdrIssueEnergy, domain.CdrIssueSeverityWarning},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			cdr := s.consistentCdr()
+			sess := &domain.Session{Details: domain.SessionDetails{LocationId: "loc", EvseId: "evse", ConnectorId: "con", Currency: "EUR"}}
+			tt.modify(cdr, sess)
+			issues := svc.Verify(s.Ctx, cdr, sess)
+			s.Len(issues, 1)
+			s.Equal(tt.code, issues[0].Code)
+			s.Equal(tt.severity, issues[0].Severity)
+		})
+	}
+}
+
+// creditableCdr valid local cdr to be credited
+func (s *cdrTestSuite) creditableCdr() *domain.Cdr {
+	cdr := s.consistentCdr()
+	cdr.ExtId = domain.PartyExtId{PartyId: "TST", CountryCode: "RS"}
+	cdr.PlatformId = "local"
+	cdr.LastUpdated = kit.Now()
+	cdr.Details.SessionId = kit.NewId()
+	cdr.Details.CdrToken = &domain.CdrToken{
+		PartyExtId: domain.PartyExtId{PartyId: "EMS", CountryCode: "RS"},
+		Id:         kit.NewId(),
+		Type:       domain.TokenTypeAppUser,
+		ContractId: kit.NewId(),
+	}
+	cdr.Details.AuthMethod = domain.AuthMethodCommand
+	cdr.Details.CdrLocation = domain.CdrLocation{
+		Id:                 "loc",
+		Address:            "address",
+		City:               "city",
+		Country:            "SRB",
+		Coordinates:        domain.GeoLocation{Latitude: "44.787197", Longitude: "20.457273"},
+		EvseId:             "evse",
+		ConnectorId:        "con",
+		ConnectorStandard:  domain.ConnectorTypeChademo,
+		ConnectorFormat:    domain.FormatCable,
+		ConnectorPowerType: domain.PowerTypeAc1Phase,
+	}
+	cdr.Details.TaxAmounts = []*domain.TaxAmount{{Name: "VAT", Amount: 2}}
+	return cdr
+}
+
+func (s *cdrTestSuite) creditService(orig *domain.Cdr) (domain.CdrService, *mocks.CdrStorage) {
+	storage := &mocks.CdrStorage{}
+	// credits are serialized as the original row is locked by storage
+	var mu sync.Mutex
+	credits := map[string]*domain.Cdr{}
+	storage.On("CreateCreditCdr", s.Ctx, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, id, creditId string, build domain.CreditBuilder) (*domain.Cdr, *domain.Cdr, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			var stored *domain.Cdr
+			if id == orig.Id {
+				stored = orig
+			}
+			existing := credits[creditId]
+			if creditId == orig.Id {
+				existing = orig
+			}
+			credit, err := build(stored, existing)
+			if err != nil {
+				return nil, nil, err
+			}
+			credits[credit.Id] = credit
+			return credit, stored, nil
+		}, nil, nil)
+	return NewCdrService(storage, &mocks.TariffService{}), storage
+}
+
+func (s *cdrTestSuite) Test_Credit_Full() {
+	orig := s.creditableCdr()
+	svc, storage := s.creditService(orig)
+
+	credit, updated, err := svc.Credit(s.Ctx, orig.Id, &domain.CdrCredit{Remark: "wrong tariff"})
+	s.NoError(err)
+	s.NotEmpty(credit.Id)
+	s.True(credit.Details.Credit)
+	s.Equal(orig.Id, credit.Details.CreditReferenceId)
+	s.Equal("wrong tariff", credit.Details.Remark)
+	s.Equal(-10.0, credit.Details.TotalCost.ExclVat)
+	s.Equal(-12.0, *credit.Details.TotalCost.InclVat)
+	s.Equal(-8.0, credit.Details.TotalEnergyCost.ExclVat)
+	s.Equal(-2.0, credit.Details.TotalTimeCost.ExclVat)
+	s.Equal(-2.0, credit.Details.TaxAmounts[0].Amount)
+	s.Equal(orig.Details.TotalEnergy, credit.Details.TotalEnergy)
+	s.Empty(credit.Details.CreditCdrIds)
+
+	s.Equal(10.0, updated.Details.Credited.ExclVat)
+	s.Equal(12.0, *updated.Details.Credited.InclVat)
+	s.Equal([]string{credit.Id}, updated.Details.CreditCdrIds)
+	storage.AssertCalled(s.T(), "CreateCreditCdr", s.Ctx, orig.Id, credit.Id, mock.Anything)
+
+	// nothing left to credit
+	_, _, err = svc.Credit(s.Ctx, orig.Id, &domain.CdrCredit{})
+	s.AssertAppErr(err, errors.ErrCodeCdrCreditExceeded)
+}
+
+func (s *cdrTestSuite) Test_Credit_Partial() {
+	orig := s.creditableCdr()
+	svc, _ := s.creditService(orig)
+
+	credit, updated, err := svc.Credit(s.Ctx, orig.Id, &domain.CdrCredit{Amount: &domain.Price{ExclVat: 4}})
+	s.NoError(err)
+	s.Equal(-4.0, credit.Details.TotalCost.ExclVat)
+	s.Equal(-4.8, *credit.Details.TotalCost.InclVat)
+	s.Equal(-3.2, credit.Details.TotalEnergyCost.ExclVat)
+	s.Equal(-0.8, credit.Details.TotalTimeCost.ExclVat)
+	s.Equal(4.0, updated.Details.Credited.ExclVat)
+
+	// exceeds remaining
+	_, _, err = svc.Credit(s.Ctx, orig.Id, &domain.CdrCredit{Amount: &domain.Price{ExclVat: 6.5}})
+	s.AssertAppErr(err, errors.ErrCodeCdrCreditExceeded)
+
+	// the rest
+	credit, updated, err = svc.Credit(s.Ctx, orig.Id, &domain.CdrCredit{})
+	s.NoError(err)
+	s.Equal(-6.0, credit.Details.TotalCost.ExclVat)
+	s.Equal(10.0, updated.Details.Credited.ExclVat)
+	s.Len(updated.Details.CreditCdrIds, 2)
+}
+
+func (s *cdrTestSuite) Test_Credit_RepeatedId() {
+	orig := s.creditableCdr()
+	svc, _ := s.creditService(orig)
+
+	rq := &domain.CdrCredit{Id: kit.NewId(), Amount: &domain.Price{ExclVat: 4}}
+	credit, _, err := svc.Credit(s.Ctx, orig.Id, rq)
+	s.NoError(err)
+	s.Equal(rq.Id, credit.Id)
+
+	// repeated credit returns the stored one and doesn't credit again
+	repeated, updated, err := svc.Credit(s.Ctx, orig.Id, rq)
+	s.NoError(err)
+	s.Equal(credit, repeated)
+	s.Equal(4.0, updated.Details.Credited.ExclVat)
+	s.Equal([]string{credit.Id}, updated.Details.CreditCdrIds)
+
+	// id of another cdr
+	_, _, err = svc.Credit(s.Ctx, orig.Id, &domain.CdrCredit{Id: orig.Id})
+	s.AssertAppErr(err, errors.ErrCodeCdrCreditIdExists)
+}
+
+func (s *cdrTestSuite) Test_Credit_Invalid() {
+	orig := s.creditableCdr()
+	svc, _ := s.creditService(orig)
+
+	// not found
+	_, _, err := svc.Credit(s.Ctx, kit.NewId(), &domain.CdrCredit{})
+	s.AssertAppErr(err, errors.ErrCodeCdrCreditNotFound)
+
+	// no request
+	_, _, err = svc.Credit(s.Ctx, orig.Id, nil)
+	s.AssertAppErr(err, errors.ErrCodeCdrEmptyAttr)
+
+	// negative amount
+	_, _, err = svc.Credit(s.Ctx, orig.Id, &domain.CdrCredit{Amount: &domain.Price{ExclVat: -1}})
+	s.AssertAppErr(err, errors.ErrCodeCdrInvalidAttr)
+
+	// credit of credit
+	orig.Details.Credit = true
+	orig.Details.CreditReferenceId = kit.NewId()
+	_, _, err = svc.Credit(s.Ctx, orig.Id, &domain.CdrCredit{})
+	s.AssertAppErr(err, errors.ErrCodeCdrCreditOfCredit)
+}
+
+func (s *cdrTestSuite) Test_Credit_Concurrent() {
+	orig := s.creditableCdr()
+	svc, _ := s.creditService(orig)
+
+	// 10 credits of 2 against total cost 10, only 5 of them fit
+	var wg sync.WaitGroup
+	credited, exceeded := atomic.NewInt32(0), atomic.NewInt32(0)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := svc.Credit(s.Ctx, orig.Id, &domain.CdrCredit{Amount: &domain.Price{ExclVat: 2}})
+			if err == nil {
+				credited.Inc()
+				return
+			}
+			s.AssertAppErr(err, errors.ErrCodeCdrCreditExceeded)
+			exceeded.Inc()
+		}()
+	}
+	wg.Wait()
+	s.Equal(int32(5), credited.Load())
+	s.Equal(int32(5), exceeded.Load())
+	s.Equal(10.0, orig.Details.Credited.ExclVat)
+	s.Len(orig.Details.CreditCdrIds, 5)
+}
+
+func (s *cdrTestSuite) Test_Verify_Credit() {
+	svc := NewCdrService(nil, nil)
+	cdr := s.consistentCdr()
+	cdr.Details.Credit = true
+	cdr.Details.CreditReferenceId = kit.NewId()
+	cdr.Details.TotalCost = domain.Price{ExclVat: -10, InclVat: kit.Float64Ptr(-12)}
+	cdr.Details.TotalEnergyCost = &domain.Price{ExclVat: -8}
+	cdr.Details.TotalTimeCost = &domain.Price{ExclVat: -2}
+	s.Empty(svc.Verify(s.Ctx, cdr, nil))
+}
@@ -0,0 +1,151 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"math"
+)
+
+const (
+	cdrEnergyTolerance = 0.01        // cdrEnergyTolerance absolute tolerance of energy in kWh
+	cdrEnergyRelTol    = 0.01        // cdrEnergyRelTol relative tolerance of energy
+	cdrTimeTolerance   = 1.0 / 60    // cdrTimeTolerance tolerance of time in hours
+	cdrCostTolerance   = 0.01        // cdrCostTolerance tolerance of cost in currency units
+	cdrSessionTimeTol  = 15.0 / 60.0 // cdrSessionTimeTol tolerance of session start in hours
+)
+
+// cdrVerifier collects discrepancies of a CDR
+type cdrVerifier struct {
+	cdr    *domain.Cdr
+	issues []*domain.CdrIssue
+}
+
+func (v *cdrVerifier) add(severity, code, msg string, args ...any) {
+	v.issues = append(v.issues, &domain.CdrIssue{Severity: severity, Code: code, Message: fmt.Sprintf(msg, args...)})
+}
+
+func (s *cdrService) Verify(ctx context.Context, cdr *domain.Cdr, sess *domain.Session) []*domain.CdrIssue {
+	s.l().C(ctx).Mth("verify").F(kit.KV{"cdrId": cdr.Id}).Dbg()
+
+	v := &cdrVerifier{cdr: cdr}
+	v.verifyEnergy()
+	v.verifyTime()
+	v.verifyCost()
+	v.verifyTariffs()
+	if sess != nil {
+		v.verifySession(sess)
+	}
+	return v.issues
+}
+
+// verifyEnergy checks total energy against ENERGY dimensions of charging periods
+func (v *cdrVerifier) verifyEnergy() {
+	var sum float64
+	found := false
+	for _, p := range v.cdr.Details.ChargingPeriods {
+		for _, d := range p.Dimensions {
+			if d.Type == domain.DimensionTypeEnergy {
+				sum += d.Volume
+				found = true
+			}
+		}
+	}
+	if found && !energyEqual(sum, v.cdr.Details.TotalEnergy) {
+		v.add(domain.CdrIssueSeverityError, domain.CdrIssueEnergy, "total energy %.3f kWh doesn't match charging periods %.3f kWh", v.cdr.Details.TotalEnergy, sum)
+	}
+}
+
+// verifyTime checks total and parking time against start/end
+func (v *cdrVerifier) verifyTime() {
+	d := v.cdr.Details
+	if d.EndDateTime.Before(d.StartDateTime) {
+		v.add(domain.CdrIssueSeverityError, domain.CdrIssueTime, "end %s before start %s", d.EndDateTime, d.StartDateTime)
+		return
+	}
+	duration := d.EndDateTime.Sub(d.StartDateTime).Hours()
+	if math.Abs(duration-d.TotalTime) > cdrTimeTolerance {
+		v.add(domain.CdrIssueSeverityError, domain.CdrIssueTime, "total time %.3f h doesn't match start/end %.3f h", d.TotalTime, duration)
+	}
+	if d.TotalParkingTime != nil && *d.TotalParkingTime > d.TotalTime+cdrTimeTolerance {
+		v.add(domain.CdrIssueSeverityError, domain.CdrIssueParkingTime, "parking time %.3f h exceeds total time %.3f h", *d.TotalParkingTime, d.TotalTime)
+	}
+}
+
+// costs returns details with costs mirrored for a credit cdr, so they're verified as positive
+func (v *cdrVerifier) costs() domain.CdrDetails {
+	d := v.cdr.Details
+	if d.Credit {
+		d.TotalCost = *scalePrice(&d.TotalCost, -1)
+		d.TotalFixedCost = scalePrice(d.TotalFixedCost, -1)
+		d.TotalEnergyCost = scalePrice(d.TotalEnergyCost, -1)
+		d.TotalTimeCost = scalePrice(d.TotalTimeCost, -1)
+		d.TotalParkingCost = scalePrice(d.TotalParkingCost, -1)
+		d.TotalReservationCost = scalePrice(d.TotalReservationCost, -1)
+	}
+	return d
+}
+
+// verifyCost checks total cost against cost components
+func (v *cdrVerifier) verifyCost() {
+	d := v.costs()
+	if d.TotalCost.InclVat != nil && *d.TotalCost.InclVat < d.TotalCost.ExclVat-cdrCostTolerance {
+		v.add(domain.CdrIssueSeverityError, domain.CdrIssueCost, "total cost incl. VAT %.2f less than excl. VAT %.2f", *d.TotalCost.InclVat, d.TotalCost.ExclVat)
+	}
+	var components float64
+	for _, p := range []*domain.Price{d.TotalFixedCost, d.TotalEnergyCost, d.TotalTimeCost, d.TotalParkingCost, d.TotalReservationCost} {
+		if p != nil {
+			components += p.ExclVat
+		}
+	}
+	if components > d.TotalCost.ExclVat+cdrCostTolerance {
+		v.add(domain.CdrIssueSeverityError, domain.CdrIssueCost, "sum of cost components %.2f exceeds total cost %.2f", components, d.TotalCost.ExclVat)
+	}
+}
+
+// verifyTariffs checks total cost against tariffs attached to the CDR
+// there is no pricing calculation, so only currency and price bounds are checked
+func (v *cdrVerifier) verifyTariffs() {
+	d := v.costs()
+	for _, t := range d.Tariffs {
+		if t.Details.Currency != "" && t.Details.Currency != d.Currency {
+			v.add(domain.CdrIssueSeverityError, domain.CdrIssueCost, "tariff %s currency %s differs from %s", t.Id, t.Details.Currency, d.Currency)
+		}
+	}
+	// bounds are applicable only if the whole CDR is priced by a single tariff
+	if len(d.Tariffs) != 1 {
+		return
+	}
+	t := d.Tariffs[0]
+	if t.Details.MinPrice != nil && d.TotalCost.ExclVat < t.Details.MinPrice.ExclVat-cdrCostTolerance {
+		v.add(domain.CdrIssueSeverityWarning, domain.CdrIssueCost, "total cost %.2f less than tariff min price %.2f", d.TotalCost.ExclVat, t.Details.MinPrice.ExclVat)
+	}
+	if t.Details.MaxPrice != nil && d.TotalCost.ExclVat > t.Details.MaxPrice.ExclVat+cdrCostTolerance {
+		v.add(domain.CdrIssueSeverityWarning, domain.CdrIssueCost, "total cost %.2f exceeds tariff max price %.2f", d.TotalCost.ExclVat, t.Details.MaxPrice.ExclVat)
+	}
+}
+
+// verifySession checks CDR against the related session
+// session might be not up-to-date, so its values are compared leniently
+func (v *cdrVerifier) verifySession(sess *domain.Session) {
+	d, sd := v.cdr.Details, sess.Details
+	loc := d.CdrLocation
+	if (sd.LocationId != "" && sd.LocationId != loc.Id) || (sd.EvseId != "" && sd.EvseId != loc.EvseId) || (sd.ConnectorId != "" && sd.ConnectorId != loc.ConnectorId) {
+		v.add(domain.CdrIssueSeverityError, domain.CdrIssueSession, "location %s/%s/%s differs from session %s/%s/%s", loc.Id, loc.EvseId, loc.ConnectorId, sd.LocationId, sd.EvseId, sd.ConnectorId)
+	}
+	if sd.Currency != "" && sd.Currency != d.Currency {
+		v.add(domain.CdrIssueSeverityError, domain.CdrIssueSession, "currency %s differs from session %s", d.Currency, sd.Currency)
+	}
+	if sd.Kwh != nil && !energyEqual(*sd.Kwh, d.TotalEnergy) {
+		v.add(domain.CdrIssueSeverityWarning, domain.CdrIssueEnergy, "total energy %.3f kWh differs from session %.3f kWh", d.TotalEnergy, *sd.Kwh)
+	}
+	if sd.StartDateTime != nil && math.Abs(sd.StartDateTime.Sub(d.StartDateTime).Hours()) > cdrSessionTimeTol {
+		v.add(domain.CdrIssueSeverityWarning, domain.CdrIssueSession, "start %s differs from session %s", d.StartDateTime, *sd.StartDateTime)
+	}
+}
+
+// energyEqual compares energy values with tolerance
+func energyEqual(a, b float64) bool {
+	return math.Abs(a-b) <= math.Max(cdrEnergyTolerance, cdrEnergyRelTol*math.Max(a, b))
+}
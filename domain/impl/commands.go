@@ -234,9 +234,7 @@ func (s *cmdService) validateStartSession(ctx context.Context, cmd *domain.Comma
 	if cmd.Details.StartSession.EvseId == "" {
 		return errors.ErrCmdEmptyAttr(ctx, "start_session", "evse_id")
 	}
-	if cmd.Details.StartSession.ConnectorId == "" {
-		return errors.ErrCmdEmptyAttr(ctx, "start_session", "connector_id")
-	}
+	// connector is optional unless the EVSE requires it
 	if err := s.validateId(ctx, cmd.Details.StartSession.EvseId, "evse_id"); err != nil {
 		return err
 	}
@@ -252,6 +250,17 @@ func (s *cmdService) validateStartSession(ctx context.Context, cmd *domain.Comma
 	if cmd.Details.StartSession.KwhLimit != nil && *cmd.Details.StartSession.KwhLimit < 0 {
 		return errors.ErrCmdInvalidAttr(ctx, "start_session", "kwh_limit")
 	}
+	if cmd.Details.StartSession.CostLimit != nil && *cmd.Details.StartSession.CostLimit < 0 {
+		return errors.ErrCmdInvalidAttr(ctx, "start_session", "cost_limit")
+	}
+	if cmd.Details.StartSession.CostLimit != nil {
+		if cmd.Details.StartSession.CostLimitCurrency == "" {
+			return errors.ErrCmdEmptyAttr(ctx, "start_session", "cost_limit_currency")
+		}
+		if !kit.CurrencyValid(cmd.Details.StartSession.CostLimitCurrency) {
+			return errors.ErrCmdInvalidAttr(ctx, "start_session", "cost_limit_currency")
+		}
+	}
 	return s.tokenService.ValidateToken(ctx, cmd.Details.StartSession.Token)
 }
 
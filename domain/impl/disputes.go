@@ -0,0 +1,274 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"slices"
+)
+
+type disputeService struct {
+	base
+	storage domain.DisputeStorage
+}
+
+func NewDisputeService(storage domain.DisputeStorage) domain.DisputeService {
+	return &disputeService{
+		storage: storage,
+	}
+}
+
+func (s *disputeService) l() kit.CLogger {
+	return ocpi.L().Cmp("disp-svc")
+}
+
+var (
+	// disputeTransitionMap statuses a dispute can be moved to from the given status
+	disputeTransitionMap = map[string]map[string]struct{}{
+		domain.DisputeStatusOpen: {
+			domain.DisputeStatusAcknowledged: {},
+			domain.DisputeStatusResolved:     {},
+			domain.DisputeStatusRejected:     {},
+		},
+		domain.DisputeStatusAcknowledged: {
+			domain.DisputeStatusResolved: {},
+			domain.DisputeStatusRejected: {},
+		},
+	}
+	// disputeEvidenceTypeMap supported evidence types
+	disputeEvidenceTypeMap = map[string]struct{}{
+		domain.DisputeEvidenceSession:         {},
+		domain.DisputeEvidenceChargingPeriods: {},
+		domain.DisputeEvidenceLog:             {},
+		domain.DisputeEvidenceDocument:        {},
+	}
+)
+
+func (s *disputeService) Create(ctx context.Context, d *domain.Dispute) (*domain.Dispute, error) {
+	s.l().C(ctx).Mth("create").F(kit.KV{"dispId": d.Id}).Dbg()
+
+	if d.Id == "" {
+		return nil, errors.ErrDisputeIdEmpty(ctx)
+	}
+
+	now := kit.Now()
+	d.Status = domain.DisputeStatusOpen
+	d.Details.Comment = ""
+	d.Details.CreditCdrId = ""
+	d.Details.Credited = nil
+	d.Details.CreditCdrIds = nil
+	d.Details.ClosedAt = nil
+	if d.LastUpdated.IsZero() {
+		d.LastUpdated = now
+	}
+	for _, ev := range d.Details.Evidence {
+		if ev.AddedAt.IsZero() {
+			ev.AddedAt = now
+		}
+	}
+
+	// validate
+	err := s.validateDispute(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storage.MergeDispute(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (s *disputeService) SetStatus(ctx context.Context, id, status, comment, creditCdrId string) (*domain.Dispute, error) {
+	s.l().C(ctx).Mth("set-status").F(kit.KV{"dispId": id, "status": status}).Dbg()
+
+	stored, err := s.mustGet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := disputeTransitionMap[stored.Status][status]; !ok {
+		return nil, errors.ErrDisputeStatusInvalid(ctx, stored.Status, status)
+	}
+	if status == domain.DisputeStatusResolved && creditCdrId == "" {
+		return nil, errors.ErrDisputeEmptyAttr(ctx, "dispute", "credit_cdr_id")
+	}
+	if err := s.validateMaxLen(ctx, comment, 255, "dispute.comment"); err != nil {
+		return nil, err
+	}
+
+	stored.Status = status
+	stored.Details.Comment = comment
+	stored.LastUpdated = kit.Now()
+	if status == domain.DisputeStatusResolved {
+		stored.Details.CreditCdrId = creditCdrId
+	}
+	if status == domain.DisputeStatusResolved || status == domain.DisputeStatusRejected {
+		stored.Details.ClosedAt = kit.TimePtr(stored.LastUpdated)
+	}
+
+	err = s.storage.UpdateDispute(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (s *disputeService) AddEvidence(ctx context.Context, id string, ev *domain.DisputeEvidence) (*domain.Dispute, error) {
+	s.l().C(ctx).Mth("add-evidence").F(kit.KV{"dispId": id}).Dbg()
+
+	stored, err := s.mustGet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// evidence can be attached only until the dispute is closed
+	if _, ok := disputeTransitionMap[stored.Status]; !ok {
+		return nil, errors.ErrDisputeStatusInvalid(ctx, stored.Status, stored.Status)
+	}
+
+	if ev.AddedAt.IsZero() {
+		ev.AddedAt = kit.Now()
+	}
+	if err := s.validateEvidence(ctx, ev); err != nil {
+		return nil, err
+	}
+
+	stored.Details.Evidence = append(stored.Details.Evidence, ev)
+	stored.LastUpdated = kit.Now()
+
+	err = s.storage.UpdateDispute(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (s *disputeService) AddCredit(ctx context.Context, id, creditCdrId string, amount *domain.Price) (*domain.Dispute, error) {
+	s.l().C(ctx).Mth("add-credit").F(kit.KV{"dispId": id, "creditCdrId": creditCdrId}).Dbg()
+
+	if creditCdrId == "" {
+		return nil, errors.ErrDisputeEmptyAttr(ctx, "dispute", "credit_cdr_id")
+	}
+	if amount == nil {
+		return nil, errors.ErrDisputeEmptyAttr(ctx, "credit", "amount")
+	}
+
+	stored, err := s.mustGet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// credit can be linked only until the dispute is closed
+	if _, ok := disputeTransitionMap[stored.Status]; !ok {
+		return nil, errors.ErrDisputeStatusInvalid(ctx, stored.Status, stored.Status)
+	}
+
+	// credit CDR is linked once, so that its updates aren't counted twice
+	if slices.Contains(stored.Details.CreditCdrIds, creditCdrId) {
+		return stored, nil
+	}
+
+	stored.Details.Credited = addPrice(stored.Details.Credited, amount)
+	stored.Details.CreditCdrIds = append(stored.Details.CreditCdrIds, creditCdrId)
+	stored.LastUpdated = kit.Now()
+
+	err = s.storage.UpdateDispute(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (s *disputeService) Get(ctx context.Context, id string) (*domain.Dispute, error) {
+	s.l().C(ctx).Mth("get").Dbg()
+	if id == "" {
+		return nil, errors.ErrDisputeIdEmpty(ctx)
+	}
+	return s.storage.GetDispute(ctx, id)
+}
+
+func (s *disputeService) Search(ctx context.Context, cr *domain.DisputeSearchCriteria) (*domain.DisputeSearchResponse, error) {
+	s.l().C(ctx).Mth("search").Dbg()
+	if cr.Limit == nil {
+		cr.Limit = kit.IntPtr(20)
+	}
+	return s.storage.SearchDisputes(ctx, cr)
+}
+
+func (s *disputeService) mustGet(ctx context.Context, id string) (*domain.Dispute, error) {
+	if id == "" {
+		return nil, errors.ErrDisputeIdEmpty(ctx)
+	}
+	stored, err := s.storage.GetDispute(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, errors.ErrDisputeNotFound(ctx)
+	}
+	return stored, nil
+}
+
+func (s *disputeService) validateDispute(ctx context.Context, d *domain.Dispute) error {
+
+	if err := s.validateOcpiItem(ctx, &d.OcpiItem); err != nil {
+		return err
+	}
+	if err := s.validateId(ctx, d.Id, "dispute_id"); err != nil {
+		return err
+	}
+
+	// cdr
+	if d.Details.CdrId == "" {
+		return errors.ErrDisputeEmptyAttr(ctx, "dispute", "cdr_id")
+	}
+
+	// reason
+	if d.Details.Reason == "" {
+		return errors.ErrDisputeEmptyAttr(ctx, "dispute", "reason")
+	}
+	if err := s.validateMaxLen(ctx, d.Details.Reason, 255, "dispute.reason"); err != nil {
+		return err
+	}
+
+	// amount
+	if err := s.validatePrice(ctx, "dispute", "amount", &d.Details.Amount); err != nil {
+		return err
+	}
+	if d.Details.Currency == "" {
+		return errors.ErrDisputeEmptyAttr(ctx, "dispute", "currency")
+	}
+	if !kit.CurrencyValid(d.Details.Currency) {
+		return errors.ErrDisputeInvalidAttr(ctx, "dispute", "currency")
+	}
+
+	// evidence
+	for _, ev := range d.Details.Evidence {
+		if err := s.validateEvidence(ctx, ev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *disputeService) validateEvidence(ctx context.Context, ev *domain.DisputeEvidence) error {
+	if ev.Type == "" {
+		return errors.ErrDisputeEmptyAttr(ctx, "evidence", "type")
+	}
+	if _, ok := disputeEvidenceTypeMap[ev.Type]; !ok {
+		return errors.ErrDisputeInvalidAttr(ctx, "evidence", "type")
+	}
+	if err := s.validateMaxLen(ctx, ev.Description, 255, "evidence.description"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type disputeTestSuite struct {
+	kit.Suite
+	svc     domain.DisputeService
+	storage *mocks.DisputeStorage
+}
+
+func (s *disputeTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *disputeTestSuite) SetupTest() {
+	s.storage = &mocks.DisputeStorage{}
+	s.svc = NewDisputeService(s.storage)
+}
+
+func TestDisputeSuite(t *testing.T) {
+	suite.Run(t, new(disputeTestSuite))
+}
+
+func (s *disputeTestSuite) dispute(status string) *domain.Dispute {
+	return &domain.Dispute{
+		OcpiItem: domain.OcpiItem{
+			ExtId:       domain.PartyExtId{PartyId: "TST", CountryCode: "RS"},
+			PlatformId:  kit.NewRandString(),
+			LastUpdated: kit.Now(),
+		},
+		Id:     kit.NewId(),
+		Status: status,
+		Details: domain.DisputeDetails{
+			CdrId:    kit.NewId(),
+			Reason:   "energy overcharged",
+			Amount:   domain.Price{ExclVat: 10},
+			Currency: "EUR",
+		},
+	}
+}
+
+func (s *disputeTestSuite) Test_Create() {
+	d := s.dispute("")
+	d.Details.CreditCdrId = kit.NewId()
+	d.Details.Evidence = []*domain.DisputeEvidence{{Type: domain.DisputeEvidenceDocument, RefId: "https://docs"}}
+	s.storage.On("MergeDispute", s.Ctx, d).Return(nil)
+	act, err := s.svc.Create(s.Ctx, d)
+	s.NoError(err)
+	s.Equal(domain.DisputeStatusOpen, act.Status)
+	s.Empty(act.Details.CreditCdrId)
+	s.NotEmpty(act.Details.Evidence[0].AddedAt)
+}
+
+func (s *disputeTestSuite) Test_Create_Invalid() {
+	d := s.dispute("")
+	d.Details.Reason = ""
+	_, err := s.svc.Create(s.Ctx, d)
+	s.AssertAppErr(err, errors.ErrCodeDisputeEmptyAttr)
+
+	d = s.dispute("")
+	d.Details.Currency = "XXXX"
+	_, err = s.svc.Create(s.Ctx, d)
+	s.AssertAppErr(err, errors.ErrCodeDisputeInvalidAttr)
+
+	d = s.dispute("")
+	d.Details.Evidence = []*domain.DisputeEvidence{{Type: "unknown"}}
+	_, err = s.svc.Create(s.Ctx, d)
+	s.AssertAppErr(err, errors.ErrCodeDisputeInvalidAttr)
+	s.storage.AssertNotCalled(s.T(), "MergeDispute", mock.Anything, mock.Anything)
+}
+
+func (s *disputeTestSuite) Test_SetStatus() {
+	d := s.dispute(domain.DisputeStatusOpen)
+	s.storage.On("GetDispute", s.Ctx, d.Id).Return(d, nil)
+	s.storage.On("UpdateDispute", s.Ctx, d).Return(nil)
+
+	act, err := s.svc.SetStatus(s.Ctx, d.Id, domain.DisputeStatusAcknowledged, "checking", "")
+	s.NoError(err)
+	s.Equal(domain.DisputeStatusAcknowledged, act.Status)
+	s.Nil(act.Details.ClosedAt)
+
+	// credit CDR is mandatory for resolved dispute
+	_, err = s.svc.SetStatus(s.Ctx, d.Id, domain.DisputeStatusResolved, "", "")
+	s.AssertAppErr(err, errors.ErrCodeDisputeEmptyAttr)
+
+	creditId := kit.NewId()
+	act, err = s.svc.SetStatus(s.Ctx, d.Id, domain.DisputeStatusResolved, "", creditId)
+	s.NoError(err)
+	s.Equal(domain.DisputeStatusResolved, act.Status)
+	s.Equal(creditId, act.Details.CreditCdrId)
+	s.NotNil(act.Details.ClosedAt)
+
+	// closed dispute cannot be changed
+	_, err = s.svc.SetStatus(s.Ctx, d.Id, domain.DisputeStatusRejected, "", "")
+	s.AssertAppErr(err, errors.ErrCodeDisputeStatusInvalid)
+	_, err = s.svc.AddEvidence(s.Ctx, d.Id, &domain.DisputeEvidence{Type: domain.DisputeEvidenceDocument})
+	s.AssertAppErr(err, errors.ErrCodeDisputeStatusInvalid)
+}
+
+func (s *disputeTestSuite) Test_SetStatus_Invalid() {
+	d := s.dispute(domain.DisputeStatusAcknowledged)
+	s.storage.On("GetDispute", s.Ctx, d.Id).Return(d, nil)
+	_, err := s.svc.SetStatus(s.Ctx, d.Id, domain.DisputeStatusOpen, "", "")
+	s.AssertAppErr(err, errors.ErrCodeDisputeStatusInvalid)
+
+	s.storage.On("GetDispute", s.Ctx, mock.Anything).Return(nil, nil)
+	_, err = s.svc.SetStatus(s.Ctx, kit.NewId(), domain.DisputeStatusRejected, "", "")
+	s.AssertAppErr(err, errors.ErrCodeDisputeNotFound)
+}
+
+func (s *disputeTestSuite) Test_AddEvidence() {
+	d := s.dispute(domain.DisputeStatusOpen)
+	s.storage.On("GetDispute", s.Ctx, d.Id).Return(d, nil)
+	s.storage.On("UpdateDispute", s.Ctx, d).Return(nil)
+
+	act, err := s.svc.AddEvidence(s.Ctx, d.Id, &domain.DisputeEvidence{Type: domain.DisputeEvidenceLog, RefId: kit.NewId()})
+	s.NoError(err)
+	s.Len(act.Details.Evidence, 1)
+	s.NotEmpty(act.Details.Evidence[0].AddedAt)
+
+	_, err = s.svc.AddEvidence(s.Ctx, d.Id, &domain.DisputeEvidence{})
+	s.AssertAppErr(err, errors.ErrCodeDisputeEmptyAttr)
+}
+
+func (s *disputeTestSuite) Test_AddCredit() {
+	d := s.dispute(domain.DisputeStatusOpen)
+	s.storage.On("GetDispute", s.Ctx, d.Id).Return(d, nil)
+	s.storage.On("UpdateDispute", s.Ctx, d).Return(nil)
+
+	creditId := kit.NewId()
+	act, err := s.svc.AddCredit(s.Ctx, d.Id, creditId, &domain.Price{ExclVat: 4, InclVat: kit.Float64Ptr(4.8)})
+	s.NoError(err)
+	s.Equal(domain.DisputeStatusOpen, act.Status)
+	s.Equal([]string{creditId}, act.Details.CreditCdrIds)
+	s.Equal(4.0, act.Details.Credited.ExclVat)
+
+	// the same credit isn't counted twice
+	act, err = s.svc.AddCredit(s.Ctx, d.Id, creditId, &domain.Price{ExclVat: 4})
+	s.NoError(err)
+	s.Equal(4.0, act.Details.Credited.ExclVat)
+
+	act, err = s.svc.AddCredit(s.Ctx, d.Id, kit.NewId(), &domain.Price{ExclVat: 6, InclVat: kit.Float64Ptr(7.2)})
+	s.NoError(err)
+	s.Len(act.Details.CreditCdrIds, 2)
+	s.Equal(10.0, act.Details.Credited.ExclVat)
+	s.InDelta(12.0, *act.Details.Credited.InclVat, 0.0001)
+	s.storage.AssertNumberOfCalls(s.T(), "UpdateDispute", 2)
+}
+
+func (s *disputeTestSuite) Test_AddCredit_Closed() {
+	d := s.dispute(domain.DisputeStatusRejected)
+	s.storage.On("GetDispute", s.Ctx, d.Id).Return(d, nil)
+	_, err := s.svc.AddCredit(s.Ctx, d.Id, kit.NewId(), &domain.Price{ExclVat: 4})
+	s.AssertAppErr(err, errors.ErrCodeDisputeStatusInvalid)
+	_, err = s.svc.AddCredit(s.Ctx, d.Id, "", &domain.Price{ExclVat: 4})
+	s.AssertAppErr(err, errors.ErrCodeDisputeEmptyAttr)
+}
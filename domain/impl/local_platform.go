@@ -33,6 +33,7 @@ var (
 		SenderOnly   bool
 		ReceiverOnly bool
 		NotSupported bool
+		Versions     []string // Versions the module is supported in, empty means all versions
 	}{
 		domain.ModuleIdCredentials:   {SenderOnly: true},
 		domain.ModuleIdCdrs:          {},
@@ -42,6 +43,7 @@ var (
 		domain.ModuleIdSessions:      {},
 		domain.ModuleIdTariffs:       {},
 		domain.ModuleIdTokens:        {},
+		domain.ModuleIdPayments:      {Versions: []string{model.OcpiVersion230}},
 	}
 )
 
@@ -133,6 +135,18 @@ func (p *localPlatformService) GetPlatformId(ctx context.Context) string {
 	return p.cfg.Local.Platform.Id
 }
 
+func (p *localPlatformService) moduleVersionSupported(versions []string, version string) bool {
+	if len(versions) == 0 {
+		return true
+	}
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *localPlatformService) GetEndpoints(ctx context.Context, version string) domain.ModuleEndpoints {
 	p.l().C(ctx).Mth("get-endpoints").Dbg()
 	r := make(domain.ModuleEndpoints)
@@ -140,6 +154,9 @@ func (p *localPlatformService) GetEndpoints(ctx context.Context, version string)
 		if d.NotSupported {
 			continue
 		}
+		if !p.moduleVersionSupported(d.Versions, version) {
+			continue
+		}
 		if d.SenderOnly {
 			r[moduleId] = domain.RoleEndpoint{
 				model.OcpiSender: domain.Endpoint(fmt.Sprintf("%s/ocpi/%s/%s", p.cfg.Local.Url, version, moduleId)),
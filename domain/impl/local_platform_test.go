@@ -3,12 +3,15 @@ package impl
 import (
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/model"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
 
 type localPlatformTestSuite struct {
 	kit.Suite
+	svc *localPlatformService
 }
 
 func (s *localPlatformTestSuite) SetupSuite() {
@@ -16,6 +19,8 @@ func (s *localPlatformTestSuite) SetupSuite() {
 }
 
 func (s *localPlatformTestSuite) SetupTest() {
+	s.svc = NewLocalPlatformService(nil, nil).(*localPlatformService)
+	s.svc.cfg = &ocpi.CfgOcpiConfig{Local: &ocpi.CfgOcpiLocal{Url: "http://local"}}
 }
 
 func (s *localPlatformTestSuite) TearDownSuite() {}
@@ -23,3 +28,14 @@ func (s *localPlatformTestSuite) TearDownSuite() {}
 func TestLocalPlatformSuite(t *testing.T) {
 	suite.Run(t, new(localPlatformTestSuite))
 }
+
+func (s *localPlatformTestSuite) Test_GetEndpoints_PaymentsVersionGated() {
+	eps := s.svc.GetEndpoints(s.Ctx, model.OcpiVersion221)
+	s.NotEmpty(eps[domain.ModuleIdTokens])
+	s.Empty(eps[domain.ModuleIdPayments])
+
+	eps = s.svc.GetEndpoints(s.Ctx, model.OcpiVersion230)
+	s.NotEmpty(eps[domain.ModuleIdTokens])
+	s.Equal(domain.Endpoint("http://local/ocpi/2.3.0/receiver/payments"), eps[domain.ModuleIdPayments][model.OcpiReceiver])
+	s.Equal(domain.Endpoint("http://local/ocpi/2.3.0/sender/payments"), eps[domain.ModuleIdPayments][model.OcpiSender])
+}
@@ -172,24 +172,85 @@ func (s *locationService) PutLocation(ctx context.Context, loc *domain.Location)
 		return nil, err
 	}
 
-	// check last_updated
-	if stored != nil && loc.LastUpdated.Before(stored.LastUpdated) {
+	ok, err := s.preparePutLocation(ctx, loc, stored)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
 		l.Warn("later changes found")
 		return nil, nil
 	}
 
-	// validate
-	err = s.validateAndPopulatePutLocation(ctx, loc, stored)
+	err = s.storage.MergeLocation(ctx, loc)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.storage.MergeLocation(ctx, loc)
+	return loc, nil
+}
+
+func (s *locationService) PutLocations(ctx context.Context, locs []*domain.Location) ([]*domain.Location, []*domain.BulkItemResult, error) {
+	l := s.l().C(ctx).Mth("put-locs").F(kit.KV{"count": len(locs)}).Dbg()
+
+	if err := s.validateBulk(ctx, len(locs)); err != nil {
+		return nil, nil, err
+	}
+
+	// retrieve stored locations at once
+	stored, err := searchByIds(kit.Select(locs, func(loc *domain.Location) string { return loc.Id }),
+		func(loc *domain.Location) string { return loc.Id },
+		func(ids []string) ([]*domain.Location, error) {
+			rs, err := s.storage.SearchLocations(ctx, &domain.LocationSearchCriteria{
+				PageRequest: domain.PageRequest{Limit: kit.IntPtr(len(ids))},
+				Ids:         ids,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return rs.Items, nil
+		})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return loc, nil
+	applied, results := putBulk(ctx, locs, func(loc *domain.Location) string { return loc.Id }, func(loc *domain.Location) (bool, error) {
+		ok, err := s.preparePutLocation(ctx, loc, stored[loc.Id])
+		if err == nil && !ok {
+			l.F(kit.KV{"locId": loc.Id}).Warn("later changes found")
+		}
+		return ok, err
+	})
+
+	if len(applied) == 0 {
+		return nil, results, nil
+	}
+
+	// all the applied locations are stored in a single transaction
+	if err := s.storage.MergeLocations(ctx, applied); err != nil {
+		return nil, nil, err
+	}
+
+	return applied, results, nil
+}
+
+// preparePutLocation validates and populates location with stored attributes
+// returns false if later changes found
+func (s *locationService) preparePutLocation(ctx context.Context, loc, stored *domain.Location) (bool, error) {
+	if loc.Id == "" {
+		return false, errors.ErrLocIdEmpty(ctx)
+	}
+
+	// check last_updated
+	if stored != nil && loc.LastUpdated.Before(stored.LastUpdated) {
+		return false, nil
+	}
+
+	// validate
+	if err := s.validateAndPopulatePutLocation(ctx, loc, stored); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (s *locationService) GetLocation(ctx context.Context, locId string, withEvse bool) (*domain.Location, error) {
@@ -255,6 +316,14 @@ func (s *locationService) DeleteLocationsByExtId(ctx context.Context, extId doma
 	return s.storage.DeleteLocationsByExtId(ctx, extId)
 }
 
+func (s *locationService) DeleteLocationsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("del-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return errors.ErrPlatformIdEmpty(ctx)
+	}
+	return s.storage.DeleteLocationsByPlatform(ctx, platformId, archive)
+}
+
 func (s *locationService) PutEvse(ctx context.Context, evse *domain.Evse) (*domain.Evse, error) {
 	l := s.l().C(ctx).Mth("put-evse").F(kit.KV{"evseId": evse.Id}).Dbg()
 
@@ -305,6 +374,70 @@ func (s *locationService) GetEvse(ctx context.Context, locId, evseId string, wit
 func (s *locationService) MergeEvse(ctx context.Context, evse *domain.Evse) (*domain.Evse, error) {
 	l := s.l().C(ctx).Mth("merge-evse").F(kit.KV{"evseId": evse.Id}).Dbg()
 
+	stored, err := s.prepareMergeEvse(ctx, evse)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		l.Warn("later changes found")
+		return nil, nil
+	}
+
+	err = s.storage.MergeEvse(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (s *locationService) MergeEvses(ctx context.Context, evses []*domain.Evse) ([]*domain.Evse, []*domain.BulkItemResult, error) {
+	l := s.l().C(ctx).Mth("merge-evses").F(kit.KV{"count": len(evses)}).Dbg()
+
+	if err := s.validateBulk(ctx, len(evses)); err != nil {
+		return nil, nil, err
+	}
+
+	var applied []*domain.Evse
+	results := make([]*domain.BulkItemResult, 0, len(evses))
+	keys := make(map[string]struct{}, len(evses))
+	for _, evse := range evses {
+		rs := &domain.BulkItemResult{Id: evse.Id}
+		results = append(results, rs)
+		key := evse.LocationId + "/" + evse.Id
+		if _, ok := keys[key]; ok && evse.Id != "" {
+			rs.Err = errors.ErrBulkDuplicateItem(ctx, key)
+			continue
+		}
+		keys[key] = struct{}{}
+		stored, err := s.prepareMergeEvse(ctx, evse)
+		if err != nil {
+			rs.Err = err
+			continue
+		}
+		if stored == nil {
+			l.F(kit.KV{"evseId": evse.Id}).Warn("later changes found")
+			continue
+		}
+		rs.Applied = true
+		applied = append(applied, stored)
+	}
+
+	if len(applied) == 0 {
+		return nil, results, nil
+	}
+
+	// all the applied evses are stored in a single transaction
+	if err := s.storage.MergeEvses(ctx, applied); err != nil {
+		return nil, nil, err
+	}
+
+	return applied, results, nil
+}
+
+// prepareMergeEvse validates evse and merges it into the stored one
+// returns nil if later changes found
+func (s *locationService) prepareMergeEvse(ctx context.Context, evse *domain.Evse) (*domain.Evse, error) {
 	if evse.Id == "" {
 		return nil, errors.ErrEvseIdEmpty(ctx)
 	}
@@ -326,7 +459,6 @@ func (s *locationService) MergeEvse(ctx context.Context, evse *domain.Evse) (*do
 
 	// check last_updated
 	if evse.LastUpdated.Before(stored.LastUpdated) {
-		l.Warn("later changes found")
 		return nil, nil
 	}
 
@@ -336,11 +468,6 @@ func (s *locationService) MergeEvse(ctx context.Context, evse *domain.Evse) (*do
 		return nil, err
 	}
 
-	err = s.storage.MergeEvse(ctx, stored)
-	if err != nil {
-		return nil, err
-	}
-
 	return stored, nil
 }
 
@@ -622,6 +749,18 @@ func (s *locationService) validateLocation(ctx context.Context, loc *domain.Loca
 		}
 	}
 
+	if err := s.validateMaxLen(ctx, loc.Details.HelpPhone, 255, "help_phone"); err != nil {
+		return err
+	}
+	for _, p := range loc.Details.ParkingPlaces {
+		if p.Id == "" {
+			return errors.ErrLocDetailsEmptyAttr(ctx, "parking_places.id")
+		}
+		if err := s.validateId(ctx, p.Id, "parking_places.id"); err != nil {
+			return err
+		}
+	}
+
 	//TODO: Energy Mix is currently not validated and not used
 	return nil
 }
@@ -741,6 +880,12 @@ func (s *locationService) validateAndPopulateMergeLocation(ctx context.Context,
 	if loc.Details.EnergyMix != nil {
 		stored.Details.EnergyMix = loc.Details.EnergyMix
 	}
+	if loc.Details.HelpPhone != "" {
+		stored.Details.HelpPhone = loc.Details.HelpPhone
+	}
+	if len(loc.Details.ParkingPlaces) > 0 {
+		stored.Details.ParkingPlaces = loc.Details.ParkingPlaces
+	}
 
 	return s.validateLocation(ctx, stored)
 }
@@ -899,6 +1044,9 @@ func (s *locationService) validateAndPopulateMergeEvse(ctx context.Context, evse
 	if len(evse.Details.Images) > 0 {
 		stored.Details.Images = evse.Details.Images
 	}
+	if len(evse.Details.Parking) > 0 {
+		stored.Details.Parking = evse.Details.Parking
+	}
 	return s.validateEvse(ctx, stored)
 }
 
@@ -1033,5 +1181,8 @@ func (s *locationService) validateAndPopulateMergeConnector(ctx context.Context,
 	if con.Details.TermsAndConditions != "" {
 		stored.Details.TermsAndConditions = con.Details.TermsAndConditions
 	}
+	if len(con.Details.Capabilities) > 0 {
+		stored.Details.Capabilities = con.Details.Capabilities
+	}
 	return s.validateConnector(ctx, stored)
 }
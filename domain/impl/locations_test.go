@@ -4,7 +4,9 @@ import (
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
 	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"testing"
 	"time"
@@ -52,6 +54,56 @@ func (s *locationTestSuite) Test_PutLocation_Ok() {
 	s.AssertCalled(&s.storage.Mock, "MergeLocation", s.Ctx, loc)
 }
 
+func (s *locationTestSuite) Test_PutLocations_Results() {
+	ok, later, dup := s.location(), s.location(), s.location()
+	stored := s.location()
+	stored.Id = later.Id
+	stored.OcpiItem = later.OcpiItem
+	later.LastUpdated = kit.Now().Add(-time.Hour)
+	dup.Id = ok.Id
+	invalid := s.location()
+	invalid.Details.Name = ""
+	invalid.Details.Address = ""
+	s.storage.On("SearchLocations", s.Ctx, mock.Anything).
+		Return(&domain.LocationSearchResponse{Items: []*domain.Location{stored}}, nil)
+	s.storage.On("MergeLocations", s.Ctx, []*domain.Location{ok}).Return(nil)
+	applied, rs, err := s.svc.PutLocations(s.Ctx, []*domain.Location{ok, later, dup, invalid})
+	s.NoError(err)
+	s.Equal([]*domain.Location{ok}, applied)
+	s.Len(rs, 4)
+	s.True(rs[0].Applied)
+	s.False(rs[1].Applied)
+	s.Nil(rs[1].Err)
+	s.AssertAppErr(rs[2].Err, errors.ErrCodeBulkDuplicateItem)
+	s.Error(rs[3].Err)
+	s.AssertNumberOfCalls(&s.storage.Mock, "MergeLocations", 1)
+	// stored locations are retrieved with a single request
+	s.AssertNumberOfCalls(&s.storage.Mock, "SearchLocations", 1)
+	s.storage.AssertNotCalled(s.T(), "GetLocation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *locationTestSuite) Test_PutLocations_WhenNothingApplied_NotStored() {
+	loc := s.location()
+	stored := s.location()
+	stored.Id = loc.Id
+	stored.OcpiItem = loc.OcpiItem
+	loc.LastUpdated = kit.Now().Add(-time.Hour)
+	s.storage.On("SearchLocations", s.Ctx, &domain.LocationSearchCriteria{
+		PageRequest: domain.PageRequest{Limit: kit.IntPtr(1)},
+		Ids:         []string{loc.Id},
+	}).Return(&domain.LocationSearchResponse{Items: []*domain.Location{stored}}, nil)
+	applied, rs, err := s.svc.PutLocations(s.Ctx, []*domain.Location{loc})
+	s.NoError(err)
+	s.Empty(applied)
+	s.Len(rs, 1)
+	s.storage.AssertNotCalled(s.T(), "MergeLocations", s.Ctx, []*domain.Location{loc})
+}
+
+func (s *locationTestSuite) Test_PutLocations_WhenEmpty_Fail() {
+	_, _, err := s.svc.PutLocations(s.Ctx, nil)
+	s.AssertAppErr(err, errors.ErrCodeBulkEmpty)
+}
+
 func (s *locationTestSuite) Test_MergeLocation_WhenLastUpdatedLater_Skip() {
 	stored := s.location()
 	s.storage.On("GetLocation", s.Ctx, stored.Id, false).Return(stored, nil)
@@ -317,6 +369,26 @@ func (s *locationTestSuite) Test_MergeEvse_WhenChanged() {
 	s.Equal(evse.Details.Coordinates.Latitude, r.Details.Coordinates.Latitude)
 }
 
+func (s *locationTestSuite) Test_MergeEvses_Results() {
+	stored := s.evse()
+	stored.LastUpdated = kit.Now().Add(-time.Hour)
+	s.storage.On("GetEvse", s.Ctx, stored.LocationId, stored.Id, false).Return(stored, nil)
+	s.storage.On("GetEvse", s.Ctx, stored.LocationId, "unknown", false).Return(nil, nil)
+	s.storage.On("MergeEvses", s.Ctx, []*domain.Evse{stored}).Return(nil)
+	evse := &domain.Evse{Id: stored.Id, LocationId: stored.LocationId, Status: domain.EvseStatusCharging}
+	evse.LastUpdated = kit.Now()
+	unknown := &domain.Evse{Id: "unknown", LocationId: stored.LocationId, Status: domain.EvseStatusCharging}
+	unknown.LastUpdated = kit.Now()
+	applied, rs, err := s.svc.MergeEvses(s.Ctx, []*domain.Evse{evse, unknown})
+	s.NoError(err)
+	s.Len(applied, 1)
+	s.Equal(domain.EvseStatusCharging, applied[0].Status)
+	s.Equal(stored.ExtId, applied[0].ExtId)
+	s.Len(rs, 2)
+	s.True(rs[0].Applied)
+	s.AssertAppErr(rs[1].Err, errors.ErrCodeEvseNotFound)
+}
+
 func (s *locationTestSuite) Test_ValidateConnector() {
 	// valid
 	con := s.connector()
@@ -2,7 +2,17 @@ package impl
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"net/http"
+	"strings"
+)
+
+const (
+	tokenMask          = "****"
+	tokenMaskPrefixLen = 4
+	credentialsEvents  = "credentials."
 )
 
 type ocpiLogImpl struct {
@@ -19,6 +29,7 @@ func (l *ocpiLogImpl) Init(severity string) {
 }
 
 func (l *ocpiLogImpl) Log(ctx context.Context, msg *domain.LogMessage) {
+	l.mask(msg)
 	l.storage.Save(ctx, msg)
 }
 
@@ -28,3 +39,87 @@ func (l *ocpiLogImpl) Search(ctx context.Context, criteria *domain.SearchLogCrit
 	}
 	return l.storage.SearchLog(ctx, criteria)
 }
+
+// mask hides tokens, so that they never get into logs in clear
+func (l *ocpiLogImpl) mask(msg *domain.LogMessage) {
+	msg.Token = l.maskToken(msg.Token)
+	msg.Headers = l.maskHeaders(msg.Headers)
+	// credentials carry partner's tokens in request and response bodies
+	if strings.HasPrefix(msg.Event, credentialsEvents) {
+		msg.RequestBody = l.maskBody(msg.RequestBody)
+		msg.ResponseBody = l.maskBody(msg.ResponseBody)
+	}
+}
+
+// maskToken keeps a short prefix of the token to make it recognizable
+func (l *ocpiLogImpl) maskToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	if len(token) <= tokenMaskPrefixLen*2 {
+		return tokenMask
+	}
+	return token[:tokenMaskPrefixLen] + tokenMask
+}
+
+func (l *ocpiLogImpl) maskHeaders(headers any) any {
+	switch h := headers.(type) {
+	case http.Header:
+		if h.Get(model.OcpiHeaderAuth) == "" {
+			return h
+		}
+		r := h.Clone()
+		r.Set(model.OcpiHeaderAuth, tokenMask)
+		return r
+	case map[string]string:
+		r := make(map[string]string, len(h))
+		for k, v := range h {
+			if strings.EqualFold(k, model.OcpiHeaderAuth) {
+				v = tokenMask
+			}
+			r[k] = v
+		}
+		return r
+	}
+	return headers
+}
+
+// maskBody converts body to a generic form and masks token attributes on any level
+func (l *ocpiLogImpl) maskBody(body any) any {
+	if body == nil {
+		return nil
+	}
+	var generic any
+	if s, ok := body.(string); ok {
+		if err := json.Unmarshal([]byte(s), &generic); err != nil {
+			return body
+		}
+	} else {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return body
+		}
+		if err := json.Unmarshal(b, &generic); err != nil {
+			return body
+		}
+	}
+	l.maskTokenAttrs(generic)
+	return generic
+}
+
+func (l *ocpiLogImpl) maskTokenAttrs(v any) {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			if s, ok := item.(string); ok && k == "token" {
+				val[k] = l.maskToken(s)
+				continue
+			}
+			l.maskTokenAttrs(item)
+		}
+	case []any:
+		for _, item := range val {
+			l.maskTokenAttrs(item)
+		}
+	}
+}
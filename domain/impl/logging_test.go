@@ -1 +1,85 @@
 package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"testing"
+)
+
+type loggingTestSuite struct {
+	kit.Suite
+	storage *mocks.OcpiLogStorage
+	svc     domain.OcpiLogService
+}
+
+func (s *loggingTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *loggingTestSuite) SetupTest() {
+	s.storage = &mocks.OcpiLogStorage{}
+	s.storage.On("Save", s.Ctx, mock.Anything)
+	s.svc = NewOcpiLogService(s.storage)
+}
+
+func TestLoggingSuite(t *testing.T) {
+	suite.Run(t, new(loggingTestSuite))
+}
+
+func (s *loggingTestSuite) Test_Incoming_TokenMasked() {
+	token := kit.NewRandString()
+	hdr := http.Header{}
+	hdr.Set(model.OcpiHeaderAuth, "Token "+token)
+	msg := &domain.LogMessage{
+		Event:   "credentials.post",
+		Token:   token,
+		Headers: hdr,
+		RequestBody: map[string]interface{}{
+			"token": token,
+			"url":   "https://test.dev/versions",
+		},
+	}
+	s.svc.Log(s.Ctx, msg)
+	s.Equal(token[:4]+"****", msg.Token)
+	s.Equal("****", msg.Headers.(http.Header).Get(model.OcpiHeaderAuth))
+	// source headers aren't modified
+	s.Equal("Token "+token, hdr.Get(model.OcpiHeaderAuth))
+	s.Equal(token[:4]+"****", msg.RequestBody.(map[string]interface{})["token"])
+	s.Equal("https://test.dev/versions", msg.RequestBody.(map[string]interface{})["url"])
+}
+
+func (s *loggingTestSuite) Test_Outgoing_TokenMasked() {
+	token := kit.NewRandString()
+	msg := &domain.LogMessage{
+		Event:   domain.LogEventPostCredentials,
+		Token:   "short",
+		Headers: map[string]string{model.OcpiHeaderAuth: "Token " + token, model.OcpiHeaderRequestId: "rq"},
+		RequestBody: &model.OcpiCredentials{
+			Token: token,
+			Url:   "https://test.dev/versions",
+		},
+		ResponseBody: `{"status_code": 1000, "data": {"token": "` + token + `"}}`,
+	}
+	s.svc.Log(s.Ctx, msg)
+	s.Equal("****", msg.Token)
+	s.Equal("****", msg.Headers.(map[string]string)[model.OcpiHeaderAuth])
+	s.Equal("rq", msg.Headers.(map[string]string)[model.OcpiHeaderRequestId])
+	s.Equal(token[:4]+"****", msg.RequestBody.(map[string]any)["token"])
+	s.Equal(token[:4]+"****", msg.ResponseBody.(map[string]any)["data"].(map[string]any)["token"])
+}
+
+func (s *loggingTestSuite) Test_NotCredentials_BodyNotChanged() {
+	body := map[string]interface{}{"token": "value"}
+	msg := &domain.LogMessage{
+		Event:       "tokens.put",
+		RequestBody: body,
+	}
+	s.svc.Log(s.Ctx, msg)
+	s.Equal("value", msg.RequestBody.(map[string]interface{})["token"])
+}
@@ -0,0 +1,361 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+)
+
+type paymentService struct {
+	base
+	storage domain.PaymentStorage
+}
+
+func NewPaymentService(storage domain.PaymentStorage) domain.PaymentService {
+	return &paymentService{
+		storage: storage,
+	}
+}
+
+func (s *paymentService) l() kit.CLogger {
+	return ocpi.L().Cmp("pay-svc")
+}
+
+var (
+	invoiceCreatorMap = map[string]struct{}{
+		domain.InvoiceCreatorCpo: {},
+		domain.InvoiceCreatorPtp: {},
+	}
+
+	captureStatusMap = map[string]struct{}{
+		domain.CaptureStatusSuccess:        {},
+		domain.CaptureStatusPartialSuccess: {},
+		domain.CaptureStatusFailed:         {},
+	}
+)
+
+func (s *paymentService) PutTerminal(ctx context.Context, t *domain.Terminal) (*domain.Terminal, error) {
+	l := s.l().C(ctx).Mth("put-terminal").F(kit.KV{"terminalId": t.Id}).Dbg()
+
+	if t.Id == "" {
+		return nil, errors.ErrTerminalIdEmpty(ctx)
+	}
+
+	// search by id
+	stored, err := s.storage.GetTerminal(ctx, t.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// check last_updated
+	if stored != nil && t.LastUpdated.Before(stored.LastUpdated) {
+		l.Warn("later changes found")
+		return nil, nil
+	}
+
+	// validate
+	err = s.validateAndPopulatePutTerminal(ctx, t, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storage.MergeTerminal(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *paymentService) MergeTerminal(ctx context.Context, t *domain.Terminal) (*domain.Terminal, error) {
+	l := s.l().C(ctx).Mth("merge-terminal").F(kit.KV{"terminalId": t.Id}).Dbg()
+
+	if t.Id == "" {
+		return nil, errors.ErrTerminalIdEmpty(ctx)
+	}
+
+	// search by id
+	stored, err := s.storage.GetTerminal(ctx, t.Id)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, errors.ErrTerminalNotFound(ctx)
+	}
+
+	// check last_updated
+	if t.LastUpdated.Before(stored.LastUpdated) {
+		l.Warn("later changes found")
+		return nil, nil
+	}
+
+	// validate
+	err = s.validateAndPopulateMergeTerminal(ctx, t, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storage.UpdateTerminal(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (s *paymentService) GetTerminal(ctx context.Context, id string) (*domain.Terminal, error) {
+	s.l().C(ctx).Mth("get-terminal").Dbg()
+	if id == "" {
+		return nil, errors.ErrTerminalIdEmpty(ctx)
+	}
+	return s.storage.GetTerminal(ctx, id)
+}
+
+func (s *paymentService) SearchTerminals(ctx context.Context, cr *domain.TerminalSearchCriteria) (*domain.TerminalSearchResponse, error) {
+	s.l().C(ctx).Mth("search-terminals").Dbg()
+	if cr.Limit == nil {
+		cr.Limit = kit.IntPtr(20)
+	}
+	return s.storage.SearchTerminals(ctx, cr)
+}
+
+func (s *paymentService) PutFinancialAdvice(ctx context.Context, fa *domain.FinancialAdvice) (*domain.FinancialAdvice, error) {
+	l := s.l().C(ctx).Mth("put-fin-advice").F(kit.KV{"faId": fa.Id}).Dbg()
+
+	if fa.Id == "" {
+		return nil, errors.ErrFinAdviceIdEmpty(ctx)
+	}
+
+	// search by id
+	stored, err := s.storage.GetFinancialAdvice(ctx, fa.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// check last_updated
+	if stored != nil && fa.LastUpdated.Before(stored.LastUpdated) {
+		l.Warn("later changes found")
+		return nil, nil
+	}
+
+	if stored != nil {
+		fa.LastSent = stored.LastSent
+		if fa.PlatformId == "" {
+			fa.PlatformId = stored.PlatformId
+		}
+		if fa.RefId == "" {
+			fa.RefId = stored.RefId
+		}
+		if fa.ExtId.PartyId == "" || fa.ExtId.CountryCode == "" {
+			fa.ExtId = stored.ExtId
+		}
+	}
+
+	// validate
+	err = s.validateFinancialAdvice(ctx, fa)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storage.MergeFinancialAdvice(ctx, fa)
+	if err != nil {
+		return nil, err
+	}
+
+	return fa, nil
+}
+
+func (s *paymentService) GetFinancialAdvice(ctx context.Context, id string) (*domain.FinancialAdvice, error) {
+	s.l().C(ctx).Mth("get-fin-advice").Dbg()
+	if id == "" {
+		return nil, errors.ErrFinAdviceIdEmpty(ctx)
+	}
+	return s.storage.GetFinancialAdvice(ctx, id)
+}
+
+func (s *paymentService) SearchFinancialAdvices(ctx context.Context, cr *domain.FinancialAdviceSearchCriteria) (*domain.FinancialAdviceSearchResponse, error) {
+	s.l().C(ctx).Mth("search-fin-advices").Dbg()
+	if cr.Limit == nil {
+		cr.Limit = kit.IntPtr(20)
+	}
+	return s.storage.SearchFinancialAdvices(ctx, cr)
+}
+
+func (s *paymentService) validateTerminal(ctx context.Context, t *domain.Terminal) error {
+
+	if err := s.validateOcpiItem(ctx, &t.OcpiItem); err != nil {
+		return err
+	}
+	if t.Id == "" {
+		return errors.ErrTerminalIdEmpty(ctx)
+	}
+	if err := s.validateId(ctx, t.Id, "terminal_id"); err != nil {
+		return err
+	}
+
+	// customer reference
+	if err := s.validateMaxLen(ctx, t.Details.CustomerReference, 36, "customer_reference"); err != nil {
+		return err
+	}
+
+	// address
+	if err := s.validateMaxLen(ctx, t.Details.Address, 45, "address"); err != nil {
+		return err
+	}
+	if err := s.validateMaxLen(ctx, t.Details.City, 45, "city"); err != nil {
+		return err
+	}
+	if err := s.validateMaxLen(ctx, t.Details.PostalCode, 10, "postal_code"); err != nil {
+		return err
+	}
+	if err := s.validateMaxLen(ctx, t.Details.State, 20, "state"); err != nil {
+		return err
+	}
+	if t.Details.Country != "" && kit.GetCountryByAlfa3(t.Details.Country) == nil {
+		return errors.ErrTerminalInvalidAttr(ctx, "terminal", "country")
+	}
+
+	// invoice
+	if t.Details.InvoiceBaseUrl != "" && !kit.IsUrlValid(t.Details.InvoiceBaseUrl) {
+		return errors.ErrTerminalInvalidAttr(ctx, "terminal", "invoice_base_url")
+	}
+	if t.Details.InvoiceCreator != "" {
+		if _, ok := invoiceCreatorMap[t.Details.InvoiceCreator]; !ok {
+			return errors.ErrTerminalInvalidAttr(ctx, "terminal", "invoice_creator")
+		}
+	}
+	if err := s.validateMaxLen(ctx, t.Details.Reference, 36, "reference"); err != nil {
+		return err
+	}
+
+	// assignments
+	for _, locId := range t.Details.LocationIds {
+		if locId == "" {
+			return errors.ErrTerminalEmptyAttr(ctx, "terminal", "location_ids")
+		}
+	}
+	for _, evseUid := range t.Details.EvseUids {
+		if evseUid == "" {
+			return errors.ErrTerminalEmptyAttr(ctx, "terminal", "evse_uids")
+		}
+	}
+
+	return nil
+}
+
+func (s *paymentService) validateFinancialAdvice(ctx context.Context, fa *domain.FinancialAdvice) error {
+
+	if err := s.validateOcpiItem(ctx, &fa.OcpiItem); err != nil {
+		return err
+	}
+	if fa.Id == "" {
+		return errors.ErrFinAdviceIdEmpty(ctx)
+	}
+	if err := s.validateId(ctx, fa.Id, "id"); err != nil {
+		return err
+	}
+
+	// authorization reference
+	if fa.Details.AuthRef == "" {
+		return errors.ErrFinAdviceEmptyAttr(ctx, "financial_advice_confirmation", "authorization_reference")
+	}
+	if err := s.validateId(ctx, fa.Details.AuthRef, "authorization_reference"); err != nil {
+		return err
+	}
+
+	// costs
+	if err := s.validatePrice(ctx, "financial_advice_confirmation", "total_costs", &fa.Details.TotalCosts); err != nil {
+		return err
+	}
+	if fa.Details.Currency == "" {
+		return errors.ErrFinAdviceEmptyAttr(ctx, "financial_advice_confirmation", "currency")
+	}
+	if !kit.CurrencyValid(fa.Details.Currency) {
+		return errors.ErrFinAdviceInvalidAttr(ctx, "financial_advice_confirmation", "currency")
+	}
+
+	// capture status
+	if fa.Details.CaptureStatusCode == "" {
+		return errors.ErrFinAdviceEmptyAttr(ctx, "financial_advice_confirmation", "capture_status_code")
+	}
+	if _, ok := captureStatusMap[fa.Details.CaptureStatusCode]; !ok {
+		return errors.ErrFinAdviceInvalidAttr(ctx, "financial_advice_confirmation", "capture_status_code")
+	}
+	if err := s.validateMaxLen(ctx, fa.Details.CaptureStatusMessage, 255, "capture_status_message"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *paymentService) validateAndPopulatePutTerminal(ctx context.Context, t, stored *domain.Terminal) error {
+
+	if stored != nil {
+		t.LastSent = stored.LastSent
+		if t.PlatformId == "" {
+			t.PlatformId = stored.PlatformId
+		}
+		if t.RefId == "" {
+			t.RefId = stored.RefId
+		}
+		if t.ExtId.PartyId == "" || t.ExtId.CountryCode == "" {
+			t.ExtId = stored.ExtId
+		}
+	}
+
+	return s.validateTerminal(ctx, t)
+}
+
+func (s *paymentService) validateAndPopulateMergeTerminal(ctx context.Context, t, stored *domain.Terminal) error {
+
+	stored.LastUpdated = t.LastUpdated
+
+	if t.PlatformId != "" {
+		stored.PlatformId = t.PlatformId
+	}
+	if t.RefId != "" {
+		stored.RefId = t.RefId
+	}
+	if t.ExtId.PartyId != "" && t.ExtId.CountryCode != "" {
+		stored.ExtId = t.ExtId
+	}
+	if t.Details.CustomerReference != "" {
+		stored.Details.CustomerReference = t.Details.CustomerReference
+	}
+	if t.Details.Address != "" {
+		stored.Details.Address = t.Details.Address
+	}
+	if t.Details.City != "" {
+		stored.Details.City = t.Details.City
+	}
+	if t.Details.PostalCode != "" {
+		stored.Details.PostalCode = t.Details.PostalCode
+	}
+	if t.Details.State != "" {
+		stored.Details.State = t.Details.State
+	}
+	if t.Details.Country != "" {
+		stored.Details.Country = t.Details.Country
+	}
+	if t.Details.Coordinates != nil {
+		stored.Details.Coordinates = t.Details.Coordinates
+	}
+	if t.Details.InvoiceBaseUrl != "" {
+		stored.Details.InvoiceBaseUrl = t.Details.InvoiceBaseUrl
+	}
+	if t.Details.InvoiceCreator != "" {
+		stored.Details.InvoiceCreator = t.Details.InvoiceCreator
+	}
+	if t.Details.Reference != "" {
+		stored.Details.Reference = t.Details.Reference
+	}
+	if len(t.Details.LocationIds) > 0 {
+		stored.Details.LocationIds = t.Details.LocationIds
+	}
+	if len(t.Details.EvseUids) > 0 {
+		stored.Details.EvseUids = t.Details.EvseUids
+	}
+	return s.validateTerminal(ctx, stored)
+}
@@ -0,0 +1,179 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type paymentTestSuite struct {
+	kit.Suite
+	svc     *paymentService
+	storage *mocks.PaymentStorage
+}
+
+func (s *paymentTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *paymentTestSuite) SetupTest() {
+	s.storage = &mocks.PaymentStorage{}
+	s.svc = NewPaymentService(s.storage).(*paymentService)
+}
+
+func (s *paymentTestSuite) TearDownSuite() {}
+
+func TestPaymentSuite(t *testing.T) {
+	suite.Run(t, new(paymentTestSuite))
+}
+
+func (s *paymentTestSuite) Test_PutTerminal_WhenLaterExists_Skip() {
+	t := s.terminal()
+	stored := s.terminal()
+	stored.Id = t.Id
+	t.LastUpdated = kit.Now().Add(-time.Hour)
+	s.storage.On("GetTerminal", s.Ctx, t.Id).Return(stored, nil)
+	r, err := s.svc.PutTerminal(s.Ctx, t)
+	s.NoError(err)
+	s.Nil(r)
+}
+
+func (s *paymentTestSuite) Test_PutTerminal_Ok() {
+	t := s.terminal()
+	s.storage.On("GetTerminal", s.Ctx, t.Id).Return(nil, nil)
+	s.storage.On("MergeTerminal", s.Ctx, t).Return(nil)
+	_, err := s.svc.PutTerminal(s.Ctx, t)
+	s.NoError(err)
+	s.AssertCalled(&s.storage.Mock, "MergeTerminal", s.Ctx, t)
+}
+
+func (s *paymentTestSuite) Test_MergeTerminal_WhenNotFound_Fail() {
+	t := s.terminal()
+	s.storage.On("GetTerminal", s.Ctx, t.Id).Return(nil, nil)
+	_, err := s.svc.MergeTerminal(s.Ctx, t)
+	s.AssertAppErr(err, errors.ErrCodeTerminalNotFound)
+}
+
+func (s *paymentTestSuite) Test_MergeTerminal_WhenChanged() {
+	stored := s.terminal()
+	stored.LastUpdated = kit.Now().Add(-time.Hour)
+	s.storage.On("GetTerminal", s.Ctx, stored.Id).Return(stored, nil)
+	s.storage.On("UpdateTerminal", s.Ctx, stored).Return(nil)
+	t := &domain.Terminal{Id: stored.Id}
+	t.LastUpdated = kit.Now()
+	t.Details.LocationIds = []string{"loc-2"}
+	r, err := s.svc.MergeTerminal(s.Ctx, t)
+	s.NoError(err)
+	s.NotEmpty(r)
+	s.Equal(stored.ExtId, r.ExtId)
+	s.Equal([]string{"loc-2"}, r.Details.LocationIds)
+}
+
+func (s *paymentTestSuite) Test_ValidateTerminal() {
+	// valid
+	t := s.terminal()
+	s.NoError(s.svc.validateTerminal(s.Ctx, t))
+
+	// invalid country
+	t = s.terminal()
+	t.Details.Country = "invalid"
+	s.Error(s.svc.validateTerminal(s.Ctx, t))
+
+	// invalid invoice creator
+	t = s.terminal()
+	t.Details.InvoiceCreator = "invalid"
+	s.Error(s.svc.validateTerminal(s.Ctx, t))
+
+	// empty location id
+	t = s.terminal()
+	t.Details.LocationIds = []string{""}
+	s.Error(s.svc.validateTerminal(s.Ctx, t))
+}
+
+func (s *paymentTestSuite) Test_PutFinancialAdvice_Ok() {
+	fa := s.financialAdvice()
+	s.storage.On("GetFinancialAdvice", s.Ctx, fa.Id).Return(nil, nil)
+	s.storage.On("MergeFinancialAdvice", s.Ctx, fa).Return(nil)
+	_, err := s.svc.PutFinancialAdvice(s.Ctx, fa)
+	s.NoError(err)
+	s.AssertCalled(&s.storage.Mock, "MergeFinancialAdvice", s.Ctx, fa)
+}
+
+func (s *paymentTestSuite) Test_ValidateFinancialAdvice() {
+	// valid
+	fa := s.financialAdvice()
+	s.NoError(s.svc.validateFinancialAdvice(s.Ctx, fa))
+
+	// empty auth ref
+	fa = s.financialAdvice()
+	fa.Details.AuthRef = ""
+	s.Error(s.svc.validateFinancialAdvice(s.Ctx, fa))
+
+	// invalid currency
+	fa = s.financialAdvice()
+	fa.Details.Currency = "invalid"
+	s.Error(s.svc.validateFinancialAdvice(s.Ctx, fa))
+
+	// invalid capture status
+	fa = s.financialAdvice()
+	fa.Details.CaptureStatusCode = "invalid"
+	s.Error(s.svc.validateFinancialAdvice(s.Ctx, fa))
+
+	// invalid costs
+	fa = s.financialAdvice()
+	fa.Details.TotalCosts.ExclVat = -1
+	s.Error(s.svc.validateFinancialAdvice(s.Ctx, fa))
+}
+
+func (s *paymentTestSuite) terminal() *domain.Terminal {
+	return &domain.Terminal{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     "PPP",
+				CountryCode: "RS",
+			},
+			PlatformId:  kit.NewRandString(),
+			RefId:       kit.NewRandString(),
+			LastUpdated: kit.Now(),
+		},
+		Id: kit.NewRandString(),
+		Details: domain.TerminalDetails{
+			CustomerReference: "customer",
+			Address:           "address",
+			City:              "Belgrade",
+			PostalCode:        "11000",
+			Country:           "SRB",
+			InvoiceBaseUrl:    "https://example.com/invoices",
+			InvoiceCreator:    domain.InvoiceCreatorCpo,
+			LocationIds:       []string{"loc-1"},
+			EvseUids:          []string{"evse-1"},
+		},
+	}
+}
+
+func (s *paymentTestSuite) financialAdvice() *domain.FinancialAdvice {
+	return &domain.FinancialAdvice{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     "PPP",
+				CountryCode: "RS",
+			},
+			PlatformId:  kit.NewRandString(),
+			RefId:       kit.NewRandString(),
+			LastUpdated: kit.Now(),
+		},
+		Id: kit.NewRandString(),
+		Details: domain.FinancialAdviceDetails{
+			AuthRef:           kit.NewRandString(),
+			TotalCosts:        domain.Price{ExclVat: 100.0, InclVat: kit.Float64Ptr(120.0)},
+			Currency:          "EUR",
+			EftData:           []string{"eft"},
+			CaptureStatusCode: domain.CaptureStatusSuccess,
+		},
+	}
+}
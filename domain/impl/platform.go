@@ -8,6 +8,11 @@ import (
 	"github.com/mikhailbolshakov/ocpi/errors"
 )
 
+var validationMap = map[string]struct{}{
+	domain.ValidationStrict:  {},
+	domain.ValidationLenient: {},
+}
+
 type platformService struct {
 	storage      domain.PlatformStorage
 	partyService domain.PartyService
@@ -89,6 +94,29 @@ func (p *platformService) Merge(ctx context.Context, platform *domain.Platform)
 		if platform.Protocol == nil {
 			platform.Protocol = stored.Protocol
 		}
+		if platform.TokenRotation == nil {
+			platform.TokenRotation = stored.TokenRotation
+		}
+		if platform.Client == nil {
+			platform.Client = stored.Client
+		}
+		if platform.Inbound == nil {
+			platform.Inbound = stored.Inbound
+		}
+		if platform.TokenIssuedAt == nil {
+			platform.TokenIssuedAt = stored.TokenIssuedAt
+		}
+		if platform.PrevTokenB == "" {
+			platform.PrevTokenB = stored.PrevTokenB
+			platform.PrevTokenBExp = stored.PrevTokenBExp
+		}
+		if platform.Health == nil {
+			platform.Health = stored.Health
+		}
+		// onboarding lifecycle relates to the stored token A only
+		if platform.Onboarding == nil && platform.TokenA == stored.TokenA {
+			platform.Onboarding = stored.Onboarding
+		}
 	}
 
 	if platform.Protocol == nil {
@@ -156,6 +184,30 @@ func (p *platformService) SetStatus(ctx context.Context, platformId string, stat
 	return platform, nil
 }
 
+func (p *platformService) Disconnect(ctx context.Context, platformId string) (*domain.Platform, error) {
+	p.l().C(ctx).Mth("disconnect").F(kit.KV{"platformId": platformId}).Dbg()
+
+	// get stored
+	platform, err := p.mustGet(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+
+	platform.TokenB = ""
+	platform.TokenC = ""
+	platform.PrevTokenB = ""
+	platform.PrevTokenBExp = nil
+	platform.Status = domain.ConnectionStatusSuspended
+
+	// put to storage
+	err = p.storage.UpdatePlatform(ctx, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	return platform, nil
+}
+
 func (p *platformService) Get(ctx context.Context, platformId string) (*domain.Platform, error) {
 	p.l().C(ctx).Mth("get").Dbg()
 	if platformId == "" {
@@ -177,7 +229,12 @@ func (p *platformService) GetByTokenB(ctx context.Context, token domain.Platform
 	if token == "" {
 		return nil, errors.ErrPlatformTokenEmpty(ctx)
 	}
-	return p.storage.GetPlatformByTokenB(ctx, p.tryBase64Token(token))
+	platform, err := p.storage.GetPlatformByTokenB(ctx, p.tryBase64Token(token))
+	if err != nil || platform != nil {
+		return platform, err
+	}
+	// previous token is still accepted during grace period after rotation
+	return p.storage.GetPlatformByPrevTokenB(ctx, p.tryBase64Token(token))
 }
 
 func (p *platformService) GetByTokenC(ctx context.Context, token domain.PlatformToken) (*domain.Platform, error) {
@@ -208,6 +265,176 @@ func (p *platformService) RoleEndpoint(ctx context.Context, platform *domain.Pla
 	return ep
 }
 
+func (p *platformService) AddTokenRotation(ctx context.Context, rotation *domain.TokenRotation) error {
+	p.l().C(ctx).Mth("add-token-rotation").Dbg()
+	if rotation.PlatformId == "" {
+		return errors.ErrPlatformIdEmpty(ctx)
+	}
+	rotation.Id = kit.NewId()
+	if rotation.CreatedAt.IsZero() {
+		rotation.CreatedAt = kit.Now()
+	}
+	return p.storage.CreateTokenRotation(ctx, rotation)
+}
+
+func (p *platformService) GetTokenRotations(ctx context.Context, platformId string) ([]*domain.TokenRotation, error) {
+	p.l().C(ctx).Mth("get-token-rotations").Dbg()
+	if platformId == "" {
+		return nil, errors.ErrPlatformIdEmpty(ctx)
+	}
+	return p.storage.GetTokenRotations(ctx, platformId)
+}
+
+func (p *platformService) IssueOnboardingToken(ctx context.Context, platformId string, token *domain.OnboardingToken) (*domain.Platform, error) {
+	p.l().C(ctx).Mth("issue-onboarding-token").F(kit.KV{"platformId": platformId}).Dbg()
+
+	// get stored
+	platform, err := p.mustGet(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+
+	// generate a new token A
+	platform.TokenA, err = p.tokenGen.Generate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	onboarding := &domain.OnboardingToken{
+		Role:      platform.Role,
+		CreatedAt: kit.Now(),
+		ExpiresAt: kit.TimePtr(kit.Now().Add(domain.OnboardingTokenTtlDefault)),
+	}
+	if token != nil {
+		onboarding.SingleUse = token.SingleUse
+		if token.Role != "" {
+			onboarding.Role = token.Role
+		}
+		if token.ExpiresAt != nil {
+			onboarding.ExpiresAt = token.ExpiresAt
+		}
+	}
+	platform.Onboarding = onboarding
+
+	// validation
+	err = p.validatePlatform(ctx, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	// put to storage
+	err = p.storage.UpdatePlatform(ctx, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	return platform, nil
+}
+
+func (p *platformService) RevokeOnboardingToken(ctx context.Context, platformId string) (*domain.Platform, error) {
+	p.l().C(ctx).Mth("revoke-onboarding-token").F(kit.KV{"platformId": platformId}).Dbg()
+
+	// get stored
+	platform, err := p.mustGet(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+	if platform.Onboarding == nil {
+		return nil, errors.ErrOnboardingTokenNotFound(ctx)
+	}
+
+	if platform.Onboarding.RevokedAt == nil {
+		platform.Onboarding.RevokedAt = kit.NowPtr()
+		err = p.storage.UpdatePlatform(ctx, platform)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return platform, nil
+}
+
+func (p *platformService) ConsumeOnboardingToken(ctx context.Context, platformId string) (*domain.Platform, error) {
+	p.l().C(ctx).Mth("consume-onboarding-token").F(kit.KV{"platformId": platformId}).Dbg()
+
+	// get stored
+	platform, err := p.mustGet(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+
+	// nothing to consume
+	if platform.Onboarding == nil {
+		return platform, nil
+	}
+
+	// consumed by conditional update, if concurrent handshakes use the same single use token, only one of them succeeds
+	consumed, err := p.storage.ConsumeOnboardingToken(ctx, platformId, kit.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !consumed && platform.Onboarding.SingleUse {
+		return nil, errors.ErrOnboardingTokenConsumed(ctx)
+	}
+
+	return p.mustGet(ctx, platformId)
+}
+
+func (p *platformService) SetHealth(ctx context.Context, platformId string, health *domain.PlatformHealth) (*domain.Platform, error) {
+	p.l().C(ctx).Mth("set-health").F(kit.KV{"platformId": platformId}).Dbg()
+
+	// get stored
+	platform, err := p.mustGet(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+
+	platform.Health = health
+
+	// put to storage
+	err = p.storage.UpdatePlatform(ctx, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	return platform, nil
+}
+
+func (p *platformService) SetDataCleanup(ctx context.Context, platformId string, cleanup *domain.PlatformDataCleanup) (*domain.Platform, error) {
+	p.l().C(ctx).Mth("set-data-cleanup").F(kit.KV{"platformId": platformId}).Dbg()
+
+	// get stored
+	platform, err := p.mustGet(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+
+	platform.DataCleanup = cleanup
+
+	// put to storage
+	err = p.storage.UpdatePlatform(ctx, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	return platform, nil
+}
+
+func (p *platformService) OnboardingTokenStatus(token *domain.OnboardingToken) string {
+	if token == nil {
+		return ""
+	}
+	switch {
+	case token.RevokedAt != nil:
+		return domain.OnboardingTokenRevoked
+	case token.SingleUse && token.ConsumedAt != nil:
+		return domain.OnboardingTokenConsumed
+	case token.ExpiresAt != nil && !kit.Now().Before(*token.ExpiresAt):
+		return domain.OnboardingTokenExpired
+	}
+	return domain.OnboardingTokenActive
+}
+
 // tryBase64Token tries to decode from base64, otherwise returns a source token
 func (p *platformService) tryBase64Token(token domain.PlatformToken) domain.PlatformToken {
 	decoded, ok := p.tokenGen.TryBase64Decode(token)
@@ -217,6 +444,14 @@ func (p *platformService) tryBase64Token(token domain.PlatformToken) domain.Plat
 	return token
 }
 
+func (p *platformService) validRateLimit(rl *domain.RateLimit) bool {
+	return rl == nil || (rl.Rate >= 0 && rl.Burst >= 0)
+}
+
+func (p *platformService) validRouteLimits(rl *domain.RouteLimits) bool {
+	return rl == nil || (p.validRateLimit(rl.RateLimit) && rl.DailyQuota >= 0)
+}
+
 func (p *platformService) validatePlatform(ctx context.Context, platform *domain.Platform) error {
 
 	if platform == nil {
@@ -246,6 +481,59 @@ func (p *platformService) validatePlatform(ctx context.Context, platform *domain
 		return errors.ErrPlatformRoleNotSupported(ctx)
 	}
 
+	// onboarding token role
+	if platform.Onboarding != nil && platform.Onboarding.Role != "" {
+		if _, ok := domain.RoleMap[platform.Onboarding.Role]; !ok {
+			return errors.ErrOnboardingTokenRoleInvalid(ctx, platform.Onboarding.Role)
+		}
+	}
+
+	// token rotation policy
+	if platform.TokenRotation != nil {
+		if platform.TokenRotation.Interval < 0 || platform.TokenRotation.GracePeriod < 0 {
+			return errors.ErrPlatformTokenRotationInvalid(ctx)
+		}
+		// grace period must be less than interval
+		if platform.TokenRotation.Interval > 0 && platform.TokenRotation.GracePeriod >= platform.TokenRotation.Interval*24 {
+			return errors.ErrPlatformTokenRotationInvalid(ctx)
+		}
+	}
+
+	// client settings
+	if cl := platform.Client; cl != nil {
+		if cl.Timeout < 0 || (cl.MaxRetries != nil && *cl.MaxRetries < 0) || cl.RetryBackoff < 0 ||
+			cl.BreakerThreshold < 0 || cl.BreakerCooldown < 0 || cl.MaxConcurrency < 0 {
+			return errors.ErrPlatformClientSettingsInvalid(ctx)
+		}
+		if !p.validRateLimit(cl.RateLimit) {
+			return errors.ErrPlatformClientSettingsInvalid(ctx)
+		}
+		for module, rl := range cl.ModuleRateLimits {
+			if _, ok := domain.ModuleIds[module]; !ok || !p.validRateLimit(rl) {
+				return errors.ErrPlatformClientSettingsInvalid(ctx)
+			}
+		}
+	}
+
+	// validation mode
+	if platform.Protocol != nil && platform.Protocol.Validation != "" {
+		if _, ok := validationMap[platform.Protocol.Validation]; !ok {
+			return errors.ErrPlatformValidationInvalid(ctx)
+		}
+	}
+
+	// inbound limits
+	if in := platform.Inbound; in != nil {
+		if !p.validRouteLimits(&domain.RouteLimits{RateLimit: in.RateLimit, DailyQuota: in.DailyQuota}) {
+			return errors.ErrPlatformInboundLimitsInvalid(ctx)
+		}
+		for route, rl := range in.Routes {
+			if !domain.ValidRouteKey(route) || !p.validRouteLimits(rl) {
+				return errors.ErrPlatformInboundLimitsInvalid(ctx)
+			}
+		}
+	}
+
 	// if connected status
 	if platform.Status == domain.ConnectionStatusConnected {
 		// for remote platform tokens must be populated
@@ -3,12 +3,19 @@ package impl
 import (
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type platformTestSuite struct {
 	kit.Suite
+	storage *mocks.PlatformStorage
+	svc     domain.PlatformService
 }
 
 func (s *platformTestSuite) SetupSuite() {
@@ -16,6 +23,8 @@ func (s *platformTestSuite) SetupSuite() {
 }
 
 func (s *platformTestSuite) SetupTest() {
+	s.storage = &mocks.PlatformStorage{}
+	s.svc = NewPlatformService(s.storage, &mocks.TokenGenerator{}, &mocks.PartyService{})
 }
 
 func (s *platformTestSuite) TearDownSuite() {}
@@ -23,3 +32,235 @@ func (s *platformTestSuite) TearDownSuite() {}
 func TestPlatformSuite(t *testing.T) {
 	suite.Run(t, new(platformTestSuite))
 }
+
+func (s *platformTestSuite) Test_Disconnect() {
+	p := &domain.Platform{
+		Id:     kit.NewRandString(),
+		TokenA: domain.PlatformToken(kit.NewRandString()),
+		TokenB: domain.PlatformToken(kit.NewRandString()),
+		TokenC: domain.PlatformToken(kit.NewRandString()),
+		Status: domain.ConnectionStatusConnected,
+		Remote: true,
+	}
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(p, nil)
+	s.storage.On("UpdatePlatform", s.Ctx, mock.Anything).Return(nil)
+
+	rs, err := s.svc.Disconnect(s.Ctx, p.Id)
+	s.NoError(err)
+	s.Empty(rs.TokenB)
+	s.Empty(rs.TokenC)
+	s.NotEmpty(rs.TokenA)
+	s.Equal(domain.ConnectionStatusSuspended, rs.Status)
+	s.storage.AssertCalled(s.T(), "UpdatePlatform", s.Ctx, rs)
+}
+
+func (s *platformTestSuite) Test_Disconnect_NotFound() {
+	s.storage.On("GetPlatform", s.Ctx, mock.Anything).Return(nil, nil)
+	_, err := s.svc.Disconnect(s.Ctx, kit.NewRandString())
+	s.AssertAppErr(err, errors.ErrCodePlatformNotFound)
+}
+
+func (s *platformTestSuite) Test_GetByTokenB_PrevTokenAccepted() {
+	p := &domain.Platform{Id: kit.NewRandString()}
+	token := domain.PlatformToken(kit.NewRandString())
+	tokenGen := &mocks.TokenGenerator{}
+	tokenGen.On("TryBase64Decode", token).Return(token, false)
+	s.svc = NewPlatformService(s.storage, tokenGen, &mocks.PartyService{})
+	s.storage.On("GetPlatformByTokenB", s.Ctx, token).Return(nil, nil)
+	s.storage.On("GetPlatformByPrevTokenB", s.Ctx, token).Return(p, nil)
+
+	rs, err := s.svc.GetByTokenB(s.Ctx, token)
+	s.NoError(err)
+	s.Equal(p, rs)
+}
+
+func (s *platformTestSuite) Test_Merge_InvalidTokenRotation() {
+	p := &domain.Platform{
+		Id:          kit.NewRandString(),
+		Name:        "name",
+		TokenA:      domain.PlatformToken(kit.NewRandString()),
+		Role:        domain.RoleCPO,
+		VersionInfo: domain.VersionInfo{VersionEp: "http://test.com/versions"},
+		// grace period exceeds interval
+		TokenRotation: &domain.TokenRotationPolicy{Interval: 1, GracePeriod: 48},
+	}
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(nil, nil)
+	_, err := s.svc.Merge(s.Ctx, p)
+	s.AssertAppErr(err, errors.ErrCodePlatformTokenRotationInvalid)
+}
+
+func (s *platformTestSuite) onboardingPlatform() *domain.Platform {
+	return &domain.Platform{
+		Id:     kit.NewRandString(),
+		Name:   "test",
+		TokenA: domain.PlatformToken(kit.NewRandString()),
+		Role:   domain.RoleCPO,
+		VersionInfo: domain.VersionInfo{
+			VersionEp: "https://test.dev/ocpi/versions",
+		},
+		Status: domain.ConnectionStatusPlanned,
+		Remote: true,
+	}
+}
+
+func (s *platformTestSuite) Test_Merge_InvalidClientSettings() {
+	retries := -1
+	p := &domain.Platform{
+		Id:          kit.NewRandString(),
+		Name:        "name",
+		TokenA:      domain.PlatformToken(kit.NewRandString()),
+		Role:        domain.RoleCPO,
+		VersionInfo: domain.VersionInfo{VersionEp: "http://test.com/versions"},
+		Client:      &domain.ClientSettings{MaxRetries: &retries},
+	}
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(nil, nil)
+	_, err := s.svc.Merge(s.Ctx, p)
+	s.AssertAppErr(err, errors.ErrCodePlatformClientSettingsInvalid)
+}
+
+func (s *platformTestSuite) Test_Merge_InvalidModuleRateLimit() {
+	p := &domain.Platform{
+		Id:          kit.NewRandString(),
+		Name:        "name",
+		TokenA:      domain.PlatformToken(kit.NewRandString()),
+		Role:        domain.RoleCPO,
+		VersionInfo: domain.VersionInfo{VersionEp: "http://test.com/versions"},
+		Client: &domain.ClientSettings{
+			ModuleRateLimits: map[string]*domain.RateLimit{"unknown": {Rate: 1, Burst: 1}},
+		},
+	}
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(nil, nil)
+	_, err := s.svc.Merge(s.Ctx, p)
+	s.AssertAppErr(err, errors.ErrCodePlatformClientSettingsInvalid)
+}
+
+func (s *platformTestSuite) Test_Merge_InvalidInboundLimits() {
+	for _, route := range []string{"unknown.get", "locations.head", "locations"} {
+		p := &domain.Platform{
+			Id:          kit.NewRandString(),
+			Name:        "name",
+			TokenA:      domain.PlatformToken(kit.NewRandString()),
+			Role:        domain.RoleCPO,
+			VersionInfo: domain.VersionInfo{VersionEp: "http://test.com/versions"},
+			Inbound: &domain.InboundLimits{
+				Routes: map[string]*domain.RouteLimits{route: {DailyQuota: 100}},
+			},
+		}
+		s.storage.On("GetPlatform", s.Ctx, p.Id).Return(nil, nil)
+		_, err := s.svc.Merge(s.Ctx, p)
+		s.AssertAppErr(err, errors.ErrCodePlatformInboundLimitsInvalid)
+	}
+}
+
+func (s *platformTestSuite) Test_IssueOnboardingToken() {
+	p := s.onboardingPlatform()
+	prevToken := p.TokenA
+	tokenGen := &mocks.TokenGenerator{}
+	tokenGen.On("Generate", s.Ctx).Return(domain.PlatformToken(kit.NewRandString()), nil)
+	s.svc = NewPlatformService(s.storage, tokenGen, &mocks.PartyService{})
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(p, nil)
+	s.storage.On("UpdatePlatform", s.Ctx, mock.Anything).Return(nil)
+
+	rs, err := s.svc.IssueOnboardingToken(s.Ctx, p.Id, &domain.OnboardingToken{SingleUse: true})
+	s.NoError(err)
+	s.NotEqual(prevToken, rs.TokenA)
+	s.NotEmpty(rs.Onboarding)
+	s.True(rs.Onboarding.SingleUse)
+	s.Equal(domain.RoleCPO, rs.Onboarding.Role)
+	s.NotEmpty(rs.Onboarding.ExpiresAt)
+	s.Equal(domain.OnboardingTokenActive, s.svc.OnboardingTokenStatus(rs.Onboarding))
+}
+
+func (s *platformTestSuite) Test_IssueOnboardingToken_InvalidRole() {
+	p := s.onboardingPlatform()
+	tokenGen := &mocks.TokenGenerator{}
+	tokenGen.On("Generate", s.Ctx).Return(domain.PlatformToken(kit.NewRandString()), nil)
+	s.svc = NewPlatformService(s.storage, tokenGen, &mocks.PartyService{})
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(p, nil)
+
+	_, err := s.svc.IssueOnboardingToken(s.Ctx, p.Id, &domain.OnboardingToken{Role: "invalid"})
+	s.AssertAppErr(err, errors.ErrCodeOnboardingTokenRoleInvalid)
+}
+
+func (s *platformTestSuite) Test_RevokeOnboardingToken() {
+	p := s.onboardingPlatform()
+	p.Onboarding = &domain.OnboardingToken{CreatedAt: kit.Now()}
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(p, nil)
+	s.storage.On("UpdatePlatform", s.Ctx, mock.Anything).Return(nil)
+
+	rs, err := s.svc.RevokeOnboardingToken(s.Ctx, p.Id)
+	s.NoError(err)
+	s.NotEmpty(rs.Onboarding.RevokedAt)
+	s.Equal(domain.OnboardingTokenRevoked, s.svc.OnboardingTokenStatus(rs.Onboarding))
+}
+
+func (s *platformTestSuite) Test_RevokeOnboardingToken_NotFound() {
+	p := s.onboardingPlatform()
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(p, nil)
+	_, err := s.svc.RevokeOnboardingToken(s.Ctx, p.Id)
+	s.AssertAppErr(err, errors.ErrCodeOnboardingTokenNotFound)
+}
+
+func (s *platformTestSuite) Test_ConsumeOnboardingToken() {
+	p := s.onboardingPlatform()
+	p.Onboarding = &domain.OnboardingToken{SingleUse: true, CreatedAt: kit.Now()}
+	consumed := *p
+	consumed.Onboarding = &domain.OnboardingToken{SingleUse: true, CreatedAt: p.Onboarding.CreatedAt, ConsumedAt: kit.NowPtr()}
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(p, nil).Once()
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(&consumed, nil).Once()
+	s.storage.On("ConsumeOnboardingToken", s.Ctx, p.Id, mock.Anything).Return(true, nil)
+
+	rs, err := s.svc.ConsumeOnboardingToken(s.Ctx, p.Id)
+	s.NoError(err)
+	s.NotEmpty(rs.Onboarding.ConsumedAt)
+	s.Equal(domain.OnboardingTokenConsumed, s.svc.OnboardingTokenStatus(rs.Onboarding))
+	s.storage.AssertNotCalled(s.T(), "UpdatePlatform", mock.Anything, mock.Anything)
+}
+
+func (s *platformTestSuite) Test_ConsumeOnboardingToken_ConsumedConcurrently() {
+	p := s.onboardingPlatform()
+	// the token is read as active, but another handshake has consumed it in the meantime
+	p.Onboarding = &domain.OnboardingToken{SingleUse: true, CreatedAt: kit.Now()}
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(p, nil)
+	s.storage.On("ConsumeOnboardingToken", s.Ctx, p.Id, mock.Anything).Return(false, nil)
+
+	_, err := s.svc.ConsumeOnboardingToken(s.Ctx, p.Id)
+	s.AssertAppErr(err, errors.ErrCodeOnboardingTokenConsumed)
+}
+
+func (s *platformTestSuite) Test_ConsumeOnboardingToken_MultiUse() {
+	p := s.onboardingPlatform()
+	p.Onboarding = &domain.OnboardingToken{CreatedAt: kit.Now(), ConsumedAt: kit.NowPtr()}
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(p, nil)
+	s.storage.On("ConsumeOnboardingToken", s.Ctx, p.Id, mock.Anything).Return(false, nil)
+
+	rs, err := s.svc.ConsumeOnboardingToken(s.Ctx, p.Id)
+	s.NoError(err)
+	s.Equal(domain.OnboardingTokenActive, s.svc.OnboardingTokenStatus(rs.Onboarding))
+}
+
+func (s *platformTestSuite) Test_ConsumeOnboardingToken_NoOnboarding() {
+	p := s.onboardingPlatform()
+	s.storage.On("GetPlatform", s.Ctx, p.Id).Return(p, nil)
+
+	_, err := s.svc.ConsumeOnboardingToken(s.Ctx, p.Id)
+	s.NoError(err)
+	s.storage.AssertNotCalled(s.T(), "ConsumeOnboardingToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *platformTestSuite) Test_OnboardingTokenStatus() {
+	for _, cs := range []struct {
+		token  *domain.OnboardingToken
+		status string
+	}{
+		{nil, ""},
+		{&domain.OnboardingToken{}, domain.OnboardingTokenActive},
+		{&domain.OnboardingToken{ExpiresAt: kit.TimePtr(kit.Now().Add(time.Hour))}, domain.OnboardingTokenActive},
+		{&domain.OnboardingToken{ExpiresAt: kit.TimePtr(kit.Now().Add(-time.Hour))}, domain.OnboardingTokenExpired},
+		{&domain.OnboardingToken{ConsumedAt: kit.NowPtr()}, domain.OnboardingTokenActive},
+		{&domain.OnboardingToken{SingleUse: true, ConsumedAt: kit.NowPtr()}, domain.OnboardingTokenConsumed},
+		{&domain.OnboardingToken{RevokedAt: kit.NowPtr(), ExpiresAt: kit.TimePtr(kit.Now().Add(-time.Hour))}, domain.OnboardingTokenRevoked},
+	} {
+		s.Equal(cs.status, s.svc.OnboardingTokenStatus(cs.token))
+	}
+}
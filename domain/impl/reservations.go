@@ -0,0 +1,175 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+)
+
+type reservationService struct {
+	base
+	storage domain.ReservationStorage
+}
+
+func NewReservationService(storage domain.ReservationStorage) domain.ReservationService {
+	return &reservationService{
+		storage: storage,
+	}
+}
+
+func (s *reservationService) l() kit.CLogger {
+	return ocpi.L().Cmp("res-svc")
+}
+
+var (
+	// reservationFinalStatusMap statuses an active reservation can be moved to
+	reservationFinalStatusMap = map[string]struct{}{
+		domain.ReservationStatusCancelled: {},
+		domain.ReservationStatusExpired:   {},
+		domain.ReservationStatusUsed:      {},
+	}
+)
+
+func (s *reservationService) Create(ctx context.Context, r *domain.ChargeReservation) (*domain.ChargeReservation, error) {
+	s.l().C(ctx).Mth("create").F(kit.KV{"resId": r.ReservationId}).Dbg()
+
+	if r.ReservationId == "" {
+		return nil, errors.ErrReservationIdEmpty(ctx)
+	}
+
+	r.Status = domain.ReservationStatusActive
+	r.Details.SessionId = ""
+	if r.LastUpdated.IsZero() {
+		r.LastUpdated = kit.Now()
+	}
+
+	// validate
+	err := s.validateReservation(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// reservation with the same reservation id of the party is replaced by the newer one
+	stored, err := s.storage.GetReservationByExtId(ctx, r.PlatformId, r.ExtId, r.ReservationId)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		r.Id = stored.Id
+	} else {
+		r.Id = kit.NewId()
+	}
+
+	err = s.storage.MergeReservation(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *reservationService) SetStatus(ctx context.Context, id, status, sessionId string) (*domain.ChargeReservation, error) {
+	s.l().C(ctx).Mth("set-status").F(kit.KV{"resId": id, "status": status}).Dbg()
+
+	if id == "" {
+		return nil, errors.ErrReservationIdEmpty(ctx)
+	}
+
+	stored, err := s.storage.GetReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, errors.ErrReservationNotFound(ctx)
+	}
+
+	// only active reservation can be finished
+	if _, ok := reservationFinalStatusMap[status]; !ok || stored.Status != domain.ReservationStatusActive {
+		return nil, errors.ErrReservationStatusInvalid(ctx, stored.Status, status)
+	}
+	if status == domain.ReservationStatusUsed && sessionId == "" {
+		return nil, errors.ErrReservationEmptyAttr(ctx, "reservation", "session_id")
+	}
+
+	stored.Status = status
+	stored.Details.SessionId = sessionId
+	stored.LastUpdated = kit.Now()
+
+	err = s.storage.UpdateReservation(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (s *reservationService) Get(ctx context.Context, id string) (*domain.ChargeReservation, error) {
+	s.l().C(ctx).Mth("get").Dbg()
+	if id == "" {
+		return nil, errors.ErrReservationIdEmpty(ctx)
+	}
+	return s.storage.GetReservation(ctx, id)
+}
+
+func (s *reservationService) GetByReservationId(ctx context.Context, platformId string, extId domain.PartyExtId, reservationId string) (*domain.ChargeReservation, error) {
+	s.l().C(ctx).Mth("get-by-res-id").Dbg()
+	if reservationId == "" {
+		return nil, errors.ErrReservationIdEmpty(ctx)
+	}
+	return s.storage.GetReservationByExtId(ctx, platformId, extId, reservationId)
+}
+
+func (s *reservationService) Search(ctx context.Context, cr *domain.ReservationSearchCriteria) (*domain.ReservationSearchResponse, error) {
+	s.l().C(ctx).Mth("search").Dbg()
+	if cr.Limit == nil {
+		cr.Limit = kit.IntPtr(20)
+	}
+	return s.storage.SearchReservations(ctx, cr)
+}
+
+func (s *reservationService) validateReservation(ctx context.Context, r *domain.ChargeReservation) error {
+
+	if err := s.validateOcpiItem(ctx, &r.OcpiItem); err != nil {
+		return err
+	}
+	if err := s.validateId(ctx, r.ReservationId, "reservation_id"); err != nil {
+		return err
+	}
+
+	// token
+	if r.Details.TokenId == "" {
+		return errors.ErrReservationEmptyAttr(ctx, "reservation", "token")
+	}
+
+	// location
+	if r.Details.LocationId == "" {
+		return errors.ErrReservationEmptyAttr(ctx, "reservation", "location_id")
+	}
+	if err := s.validateId(ctx, r.Details.LocationId, "location_id"); err != nil {
+		return err
+	}
+	if r.Details.EvseId != "" {
+		if err := s.validateId(ctx, r.Details.EvseId, "evse_uid"); err != nil {
+			return err
+		}
+	}
+	if r.Details.ConnectorId != "" {
+		if err := s.validateId(ctx, r.Details.ConnectorId, "connector_id"); err != nil {
+			return err
+		}
+	}
+
+	// expiry
+	if r.Details.ExpireDate.IsZero() {
+		return errors.ErrReservationEmptyAttr(ctx, "reservation", "expiry_date")
+	}
+
+	// command
+	if r.Details.CommandId == "" {
+		return errors.ErrReservationEmptyAttr(ctx, "reservation", "command_id")
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type reservationTestSuite struct {
+	kit.Suite
+	svc     domain.ReservationService
+	storage *mocks.ReservationStorage
+}
+
+func (s *reservationTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *reservationTestSuite) SetupTest() {
+	s.storage = &mocks.ReservationStorage{}
+	s.svc = NewReservationService(s.storage)
+}
+
+func TestReservationSuite(t *testing.T) {
+	suite.Run(t, new(reservationTestSuite))
+}
+
+func (s *reservationTestSuite) reservation(status string) *domain.ChargeReservation {
+	return &domain.ChargeReservation{
+		OcpiItem: domain.OcpiItem{
+			ExtId:       domain.PartyExtId{PartyId: "TST", CountryCode: "RS"},
+			PlatformId:  kit.NewRandString(),
+			LastUpdated: kit.Now(),
+		},
+		Id:            kit.NewId(),
+		ReservationId: kit.NewId(),
+		Status:        status,
+		Details: domain.ReservationDetails{
+			TokenId:    kit.NewId(),
+			LocationId: kit.NewId(),
+			EvseId:     kit.NewId(),
+			ExpireDate: kit.Now().Add(time.Hour),
+			CommandId:  kit.NewId(),
+		},
+	}
+}
+
+func (s *reservationTestSuite) Test_Create() {
+	r := s.reservation("")
+	r.Id = ""
+	r.Details.SessionId = kit.NewId()
+	s.storage.On("GetReservationByExtId", s.Ctx, r.PlatformId, r.ExtId, r.ReservationId).Return(nil, nil)
+	s.storage.On("MergeReservation", s.Ctx, r).Return(nil)
+	act, err := s.svc.Create(s.Ctx, r)
+	s.NoError(err)
+	s.NotEmpty(act.Id)
+	s.Equal(domain.ReservationStatusActive, act.Status)
+	s.Empty(act.Details.SessionId)
+}
+
+func (s *reservationTestSuite) Test_Create_SameReservationIdReplaced() {
+	stored := s.reservation(domain.ReservationStatusCancelled)
+	r := s.reservation("")
+	r.Id, r.PlatformId, r.ReservationId = "", stored.PlatformId, stored.ReservationId
+	s.storage.On("GetReservationByExtId", s.Ctx, r.PlatformId, r.ExtId, r.ReservationId).Return(stored, nil)
+	s.storage.On("MergeReservation", s.Ctx, r).Return(nil)
+	act, err := s.svc.Create(s.Ctx, r)
+	s.NoError(err)
+	s.Equal(stored.Id, act.Id)
+	s.Equal(domain.ReservationStatusActive, act.Status)
+}
+
+func (s *reservationTestSuite) Test_Create_Invalid() {
+	r := s.reservation("")
+	r.Details.TokenId = ""
+	_, err := s.svc.Create(s.Ctx, r)
+	s.AssertAppErr(err, errors.ErrCodeReservationEmptyAttr)
+
+	r = s.reservation("")
+	r.Details.ExpireDate = time.Time{}
+	_, err = s.svc.Create(s.Ctx, r)
+	s.AssertAppErr(err, errors.ErrCodeReservationEmptyAttr)
+	s.storage.AssertNotCalled(s.T(), "MergeReservation", mock.Anything, mock.Anything)
+}
+
+func (s *reservationTestSuite) Test_SetStatus() {
+	r := s.reservation(domain.ReservationStatusActive)
+	s.storage.On("GetReservation", s.Ctx, r.Id).Return(r, nil)
+	s.storage.On("UpdateReservation", s.Ctx, r).Return(nil)
+
+	// session is mandatory for used reservation
+	_, err := s.svc.SetStatus(s.Ctx, r.Id, domain.ReservationStatusUsed, "")
+	s.AssertAppErr(err, errors.ErrCodeReservationEmptyAttr)
+
+	sessId := kit.NewId()
+	act, err := s.svc.SetStatus(s.Ctx, r.Id, domain.ReservationStatusUsed, sessId)
+	s.NoError(err)
+	s.Equal(domain.ReservationStatusUsed, act.Status)
+	s.Equal(sessId, act.Details.SessionId)
+
+	// final status cannot be changed
+	_, err = s.svc.SetStatus(s.Ctx, r.Id, domain.ReservationStatusExpired, "")
+	s.AssertAppErr(err, errors.ErrCodeReservationStatusInvalid)
+}
+
+func (s *reservationTestSuite) Test_SetStatus_Invalid() {
+	r := s.reservation(domain.ReservationStatusActive)
+	s.storage.On("GetReservation", s.Ctx, r.Id).Return(r, nil)
+	_, err := s.svc.SetStatus(s.Ctx, r.Id, domain.ReservationStatusActive, "")
+	s.AssertAppErr(err, errors.ErrCodeReservationStatusInvalid)
+
+	s.storage.On("GetReservation", s.Ctx, mock.Anything).Return(nil, nil)
+	_, err = s.svc.SetStatus(s.Ctx, kit.NewId(), domain.ReservationStatusCancelled, "")
+	s.AssertAppErr(err, errors.ErrCodeReservationNotFound)
+}
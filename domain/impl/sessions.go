@@ -10,12 +10,14 @@ import (
 
 type sessionService struct {
 	base
-	storage domain.SessionStorage
+	storage         domain.SessionStorage
+	platformService domain.PlatformService
 }
 
-func NewSessionService(storage domain.SessionStorage) domain.SessionService {
+func NewSessionService(storage domain.SessionStorage, platformService domain.PlatformService) domain.SessionService {
 	return &sessionService{
-		storage: storage,
+		storage:         storage,
+		platformService: platformService,
 	}
 }
 
@@ -32,6 +34,28 @@ var (
 		domain.SessionStatusReservation: {},
 	}
 
+	// sessionTransitions allowed transitions of session status
+	// staying in the same status is always allowed, COMPLETED and INVALID are final
+	sessionTransitions = map[string]map[string]struct{}{
+		domain.SessionStatusReservation: {
+			domain.SessionStatusPending:   {},
+			domain.SessionStatusActive:    {},
+			domain.SessionStatusCompleted: {},
+			domain.SessionStatusInvalid:   {},
+		},
+		domain.SessionStatusPending: {
+			domain.SessionStatusActive:    {},
+			domain.SessionStatusCompleted: {},
+			domain.SessionStatusInvalid:   {},
+		},
+		domain.SessionStatusActive: {
+			domain.SessionStatusCompleted: {},
+			domain.SessionStatusInvalid:   {},
+		},
+		domain.SessionStatusCompleted: {},
+		domain.SessionStatusInvalid:   {},
+	}
+
 	dimensionMap = map[string]struct{}{
 		domain.DimensionTypeCurrent:         {},
 		domain.DimensionTypeEnergy:          {},
@@ -62,17 +86,14 @@ func (s *sessionService) PutSession(ctx context.Context, sess *domain.Session) (
 		return nil, err
 	}
 
-	// check last_updated
-	if stored != nil && sess.LastUpdated.Before(stored.LastUpdated) {
-		l.Warn("later changes found")
-		return nil, nil
-	}
-
-	// validate
-	err = s.validateAndPopulatePut(ctx, sess, stored)
+	ok, err := s.preparePutSession(ctx, sess, stored)
 	if err != nil {
 		return nil, err
 	}
+	if !ok {
+		l.Warn("later changes found")
+		return nil, nil
+	}
 
 	// update storage
 	err = s.storage.MergeSession(ctx, sess)
@@ -89,6 +110,79 @@ func (s *sessionService) PutSession(ctx context.Context, sess *domain.Session) (
 	return sess, nil
 }
 
+func (s *sessionService) PutSessions(ctx context.Context, sessions []*domain.Session) ([]*domain.Session, []*domain.BulkItemResult, error) {
+	l := s.l().C(ctx).Mth("put-sessions").F(kit.KV{"count": len(sessions)}).Dbg()
+
+	if err := s.validateBulk(ctx, len(sessions)); err != nil {
+		return nil, nil, err
+	}
+
+	// retrieve stored sessions at once
+	stored, err := searchByIds(kit.Select(sessions, func(sess *domain.Session) string { return sess.Id }),
+		func(sess *domain.Session) string { return sess.Id },
+		func(ids []string) ([]*domain.Session, error) {
+			rs, err := s.storage.SearchSessions(ctx, &domain.SessionSearchCriteria{
+				PageRequest: domain.PageRequest{Limit: kit.IntPtr(len(ids))},
+				Ids:         ids,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return rs.Items, nil
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied, results := putBulk(ctx, sessions, func(sess *domain.Session) string { return sess.Id }, func(sess *domain.Session) (bool, error) {
+		ok, err := s.preparePutSession(ctx, sess, stored[sess.Id])
+		if err == nil && !ok {
+			l.F(kit.KV{"sessId": sess.Id}).Warn("later changes found")
+		}
+		return ok, err
+	})
+
+	if len(applied) == 0 {
+		return nil, results, nil
+	}
+
+	// all the applied sessions are stored with charging periods in a single transaction
+	if err := s.storage.MergeSessions(ctx, applied); err != nil {
+		return nil, nil, err
+	}
+
+	return applied, results, nil
+}
+
+// preparePutSession validates and populates session with stored attributes
+// returns false if later changes found
+func (s *sessionService) preparePutSession(ctx context.Context, sess, stored *domain.Session) (bool, error) {
+	if sess.Id == "" {
+		return false, errors.ErrSessIdEmpty(ctx)
+	}
+
+	// check last_updated
+	if stored != nil && sess.LastUpdated.Before(stored.LastUpdated) {
+		return false, nil
+	}
+
+	// validate
+	if err := s.validateAndPopulatePut(ctx, sess, stored); err != nil {
+		return false, err
+	}
+
+	// validate status transition
+	prevStatus := ""
+	if stored != nil {
+		prevStatus = stored.Details.Status
+	}
+	if err := s.validateLifecycle(ctx, sess, prevStatus); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (s *sessionService) MergeSession(ctx context.Context, sess *domain.Session) (*domain.Session, error) {
 	l := s.l().C(ctx).Mth("merge-sess").F(kit.KV{"sessId": sess.Id}).Dbg()
 
@@ -112,11 +206,18 @@ func (s *sessionService) MergeSession(ctx context.Context, sess *domain.Session)
 	}
 
 	// validate
+	prevStatus := stored.Details.Status
 	err = s.validateAndPopulateMerge(ctx, sess, stored)
 	if err != nil {
 		return nil, err
 	}
 
+	// validate status transition
+	err = s.validateLifecycle(ctx, stored, prevStatus)
+	if err != nil {
+		return nil, err
+	}
+
 	// update session
 	err = s.storage.UpdateSession(ctx, stored)
 	if err != nil {
@@ -150,6 +251,30 @@ func (s *sessionService) GetSessionWithPeriods(ctx context.Context, sessId strin
 	return s.storage.GetSession(ctx, sessId, true)
 }
 
+func (s *sessionService) SetStopReason(ctx context.Context, sessId string, reason *domain.SessionStopReason) (*domain.Session, error) {
+	s.l().C(ctx).Mth("set-stop-reason").F(kit.KV{"sessId": sessId}).Dbg()
+
+	if sessId == "" {
+		return nil, errors.ErrSessIdEmpty(ctx)
+	}
+
+	stored, err := s.storage.GetSession(ctx, sessId, false)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, errors.ErrSessNotFound(ctx)
+	}
+
+	stored.Details.StopReason = reason
+	err = s.storage.UpdateSession(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
 func (s *sessionService) SearchSessions(ctx context.Context, cr *domain.SessionSearchCriteria) (*domain.SessionSearchResponse, error) {
 	s.l().C(ctx).Mth("search-sess").Dbg()
 	if cr.Limit == nil {
@@ -167,6 +292,14 @@ func (s *sessionService) DeleteSessionsByExtId(ctx context.Context, extId domain
 	return s.storage.DeleteSessionsByExtId(ctx, extId)
 }
 
+func (s *sessionService) DeleteSessionsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("del-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return errors.ErrPlatformIdEmpty(ctx)
+	}
+	return s.storage.DeleteSessionsByPlatform(ctx, platformId, archive)
+}
+
 func (s *sessionService) validate(ctx context.Context, sess *domain.Session) error {
 
 	if err := s.validateOcpiItem(ctx, &sess.OcpiItem); err != nil {
@@ -263,6 +396,48 @@ func (s *sessionService) validate(ctx context.Context, sess *domain.Session) err
 	return nil
 }
 
+// validateLifecycle validates transition from the previous status and attributes mandatory in the new status
+// if the platform the session belongs to is lenient, violations are logged and the change is applied
+func (s *sessionService) validateLifecycle(ctx context.Context, sess *domain.Session, prevStatus string) error {
+	err := s.validateTransition(ctx, sess, prevStatus)
+	if err == nil || s.strict(ctx, sess.PlatformId) {
+		return err
+	}
+	s.l().C(ctx).Mth("lifecycle").F(kit.KV{"sessId": sess.Id, "from": prevStatus, "to": sess.Details.Status}).E(err).Warn("violation accepted")
+	return nil
+}
+
+func (s *sessionService) validateTransition(ctx context.Context, sess *domain.Session, prevStatus string) error {
+	status := sess.Details.Status
+	if prevStatus != "" && prevStatus != status {
+		if _, ok := sessionTransitions[prevStatus][status]; !ok {
+			return errors.ErrSessStatusTransitionInvalid(ctx, prevStatus, status)
+		}
+	}
+	if status == domain.SessionStatusCompleted {
+		if sess.Details.EndDateTime == nil {
+			return errors.ErrSessStatusAttrEmpty(ctx, status, "end_date_time")
+		}
+		if sess.Details.EndDateTime.Before(*sess.Details.StartDateTime) {
+			return errors.ErrSessInvalidAttr(ctx, "session", "end_date_time")
+		}
+	}
+	return nil
+}
+
+// strict checks if lifecycle of the platform's sessions is validated strictly
+func (s *sessionService) strict(ctx context.Context, platformId string) bool {
+	if platformId == "" {
+		return true
+	}
+	platform, err := s.platformService.Get(ctx, platformId)
+	if err != nil {
+		s.l().C(ctx).Mth("strict").F(kit.KV{"platformId": platformId}).E(err).Err()
+		return true
+	}
+	return platform == nil || platform.Protocol == nil || platform.Protocol.Validation != domain.ValidationLenient
+}
+
 func (s *sessionService) validateAndPopulatePut(ctx context.Context, sess, stored *domain.Session) error {
 
 	if stored != nil {
@@ -276,6 +451,10 @@ func (s *sessionService) validateAndPopulatePut(ctx context.Context, sess, store
 		if sess.ExtId.PartyId == "" || sess.ExtId.CountryCode == "" {
 			sess.ExtId = stored.ExtId
 		}
+		// stop reason is set by the platform, so the session owner doesn't send it
+		if sess.Details.StopReason == nil {
+			sess.Details.StopReason = stored.Details.StopReason
+		}
 	}
 
 	return s.validate(ctx, sess)
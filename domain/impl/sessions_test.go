@@ -3,12 +3,20 @@ package impl
 import (
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type sessionTestSuite struct {
 	kit.Suite
+	svc             *sessionService
+	storage         *mocks.SessionStorage
+	platformService *mocks.PlatformService
 }
 
 func (s *sessionTestSuite) SetupSuite() {
@@ -16,6 +24,9 @@ func (s *sessionTestSuite) SetupSuite() {
 }
 
 func (s *sessionTestSuite) SetupTest() {
+	s.storage = &mocks.SessionStorage{}
+	s.platformService = &mocks.PlatformService{}
+	s.svc = NewSessionService(s.storage, s.platformService).(*sessionService)
 }
 
 func (s *sessionTestSuite) TearDownSuite() {}
@@ -23,3 +34,134 @@ func (s *sessionTestSuite) TearDownSuite() {}
 func TestSessionSuite(t *testing.T) {
 	suite.Run(t, new(sessionTestSuite))
 }
+
+func (s *sessionTestSuite) session(status string) *domain.Session {
+	sess := &domain.Session{
+		OcpiItem: domain.OcpiItem{
+			ExtId:       domain.PartyExtId{PartyId: "TST", CountryCode: "RS"},
+			PlatformId:  kit.NewRandString(),
+			LastUpdated: kit.Now(),
+		},
+		Id: kit.NewId(),
+		Details: domain.SessionDetails{
+			StartDateTime: kit.TimePtr(kit.Now().Add(-time.Hour)),
+			Kwh:           kit.Float64Ptr(10),
+			CdrToken: &domain.CdrToken{
+				PartyExtId: domain.PartyExtId{PartyId: "TST", CountryCode: "RS"},
+				Id:         kit.NewId(),
+				Type:       domain.TokenTypeRfid,
+				ContractId: kit.NewId(),
+			},
+			AuthMethod:  domain.AuthMethodWhitelist,
+			LocationId:  kit.NewId(),
+			EvseId:      kit.NewId(),
+			ConnectorId: kit.NewId(),
+			Currency:    "EUR",
+			Status:      status,
+		},
+	}
+	if status == domain.SessionStatusCompleted {
+		sess.Details.EndDateTime = kit.NowPtr()
+	}
+	return sess
+}
+
+func (s *sessionTestSuite) platform(validation string) *domain.Platform {
+	return &domain.Platform{Id: kit.NewRandString(), Protocol: &domain.ProtocolDetails{Validation: validation}}
+}
+
+func (s *sessionTestSuite) Test_Transitions() {
+	tests := []struct {
+		from, to string
+		valid    bool
+	}{
+		{"", domain.SessionStatusCompleted, true},
+		{domain.SessionStatusReservation, domain.SessionStatusPending, true},
+		{domain.SessionStatusPending, domain.SessionStatusActive, true},
+		{domain.SessionStatusActive, domain.SessionStatusActive, true},
+		{domain.SessionStatusActive, domain.SessionStatusCompleted, true},
+		{domain.SessionStatusActive, domain.SessionStatusInvalid, true},
+		{domain.SessionStatusActive, domain.SessionStatusPending, false},
+		{domain.SessionStatusCompleted, domain.SessionStatusActive, false},
+		{domain.SessionStatusCompleted, domain.SessionStatusInvalid, false},
+		{domain.SessionStatusInvalid, domain.SessionStatusCompleted, false},
+	}
+	for _, t := range tests {
+		err := s.svc.validateTransition(s.Ctx, s.session(t.to), t.from)
+		if t.valid {
+			s.NoError(err, t.from+"->"+t.to)
+		} else {
+			s.AssertAppErr(err, errors.ErrCodeSessStatusTransitionInvalid)
+		}
+	}
+}
+
+func (s *sessionTestSuite) Test_Completed_EndDateTime() {
+	sess := s.session(domain.SessionStatusCompleted)
+	sess.Details.EndDateTime = nil
+	s.AssertAppErr(s.svc.validateTransition(s.Ctx, sess, domain.SessionStatusActive), errors.ErrCodeSessStatusAttrEmpty)
+	sess.Details.EndDateTime = kit.TimePtr(sess.Details.StartDateTime.Add(-time.Minute))
+	s.AssertAppErr(s.svc.validateTransition(s.Ctx, sess, domain.SessionStatusActive), errors.ErrCodeSessInvalidAttr)
+}
+
+func (s *sessionTestSuite) Test_PutSession_InvalidTransition_Strict() {
+	sess := s.session(domain.SessionStatusActive)
+	stored := s.session(domain.SessionStatusCompleted)
+	stored.Id, stored.OcpiItem = sess.Id, sess.OcpiItem
+	stored.LastUpdated = sess.LastUpdated.Add(-time.Minute)
+	s.storage.On("GetSession", s.Ctx, sess.Id, false).Return(stored, nil)
+	s.platformService.On("Get", s.Ctx, sess.PlatformId).Return(s.platform(""), nil)
+	_, err := s.svc.PutSession(s.Ctx, sess)
+	s.AssertAppErr(err, errors.ErrCodeSessStatusTransitionInvalid)
+	s.storage.AssertNotCalled(s.T(), "MergeSession", mock.Anything, mock.Anything)
+}
+
+func (s *sessionTestSuite) Test_PutSession_InvalidTransition_Lenient() {
+	sess := s.session(domain.SessionStatusActive)
+	stored := s.session(domain.SessionStatusCompleted)
+	stored.Id, stored.OcpiItem = sess.Id, sess.OcpiItem
+	stored.LastUpdated = sess.LastUpdated.Add(-time.Minute)
+	s.storage.On("GetSession", s.Ctx, sess.Id, false).Return(stored, nil)
+	s.storage.On("MergeSession", s.Ctx, sess).Return(nil)
+	s.storage.On("UpdateChargingPeriods", s.Ctx, sess, mock.Anything).Return(nil)
+	s.platformService.On("Get", s.Ctx, sess.PlatformId).Return(s.platform(domain.ValidationLenient), nil)
+	r, err := s.svc.PutSession(s.Ctx, sess)
+	s.NoError(err)
+	s.NotNil(r)
+	s.storage.AssertCalled(s.T(), "MergeSession", s.Ctx, sess)
+}
+
+func (s *sessionTestSuite) Test_PutSession_OutOfOrder_Skip() {
+	sess := s.session(domain.SessionStatusActive)
+	stored := s.session(domain.SessionStatusCompleted)
+	stored.Id, stored.OcpiItem = sess.Id, sess.OcpiItem
+	stored.LastUpdated = sess.LastUpdated.Add(time.Minute)
+	s.storage.On("GetSession", s.Ctx, sess.Id, false).Return(stored, nil)
+	r, err := s.svc.PutSession(s.Ctx, sess)
+	s.NoError(err)
+	s.Nil(r)
+	s.storage.AssertNotCalled(s.T(), "MergeSession", mock.Anything, mock.Anything)
+}
+
+func (s *sessionTestSuite) Test_MergeSession_Completed() {
+	stored := s.session(domain.SessionStatusActive)
+	patch := &domain.Session{
+		OcpiItem: domain.OcpiItem{LastUpdated: kit.Now()},
+		Id:       stored.Id,
+		Details:  domain.SessionDetails{Status: domain.SessionStatusCompleted},
+	}
+	stored.LastUpdated = patch.LastUpdated.Add(-time.Minute)
+	s.storage.On("GetSession", s.Ctx, stored.Id, false).Return(stored, nil)
+	s.platformService.On("Get", s.Ctx, stored.PlatformId).Return(s.platform(domain.ValidationStrict), nil)
+
+	// end_date_time is mandatory
+	_, err := s.svc.MergeSession(s.Ctx, patch)
+	s.AssertAppErr(err, errors.ErrCodeSessStatusAttrEmpty)
+
+	stored.Details.Status = domain.SessionStatusActive
+	patch.Details.EndDateTime = kit.NowPtr()
+	s.storage.On("UpdateSession", s.Ctx, stored).Return(nil)
+	r, err := s.svc.MergeSession(s.Ctx, patch)
+	s.NoError(err)
+	s.Equal(domain.SessionStatusCompleted, r.Details.Status)
+}
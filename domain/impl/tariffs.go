@@ -32,6 +32,12 @@ var (
 		domain.TariffTypeReg:          {},
 	}
 
+	taxIncludedMap = map[string]struct{}{
+		domain.TaxIncludedYes:           {},
+		domain.TaxIncludedNo:            {},
+		domain.TaxIncludedNotApplicable: {},
+	}
+
 	tariffDimType = map[string]struct{}{
 		domain.TariffDimParkingType: {},
 		domain.TariffDimFlat:        {},
@@ -63,24 +69,85 @@ func (s *tariffService) PutTariff(ctx context.Context, trf *domain.Tariff) (*dom
 		return nil, err
 	}
 
-	// check last_updated
-	if stored != nil && trf.LastUpdated.Before(stored.LastUpdated) {
+	ok, err := s.preparePutTariff(ctx, trf, stored)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
 		l.Warn("later changes found")
 		return nil, nil
 	}
 
-	// validate
-	err = s.validateAndPopulatePut(ctx, trf, stored)
+	err = s.storage.MergeTariff(ctx, trf)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.storage.MergeTariff(ctx, trf)
+	return trf, nil
+}
+
+func (s *tariffService) PutTariffs(ctx context.Context, trfs []*domain.Tariff) ([]*domain.Tariff, []*domain.BulkItemResult, error) {
+	l := s.l().C(ctx).Mth("put-trfs").F(kit.KV{"count": len(trfs)}).Dbg()
+
+	if err := s.validateBulk(ctx, len(trfs)); err != nil {
+		return nil, nil, err
+	}
+
+	// retrieve stored tariffs at once
+	stored, err := searchByIds(kit.Select(trfs, func(trf *domain.Tariff) string { return trf.Id }),
+		func(trf *domain.Tariff) string { return trf.Id },
+		func(ids []string) ([]*domain.Tariff, error) {
+			rs, err := s.storage.SearchTariffs(ctx, &domain.TariffSearchCriteria{
+				PageRequest: domain.PageRequest{Limit: kit.IntPtr(len(ids))},
+				Ids:         ids,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return rs.Items, nil
+		})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return trf, nil
+	applied, results := putBulk(ctx, trfs, func(trf *domain.Tariff) string { return trf.Id }, func(trf *domain.Tariff) (bool, error) {
+		ok, err := s.preparePutTariff(ctx, trf, stored[trf.Id])
+		if err == nil && !ok {
+			l.F(kit.KV{"trfId": trf.Id}).Warn("later changes found")
+		}
+		return ok, err
+	})
+
+	if len(applied) == 0 {
+		return nil, results, nil
+	}
+
+	// all the applied tariffs are stored in a single transaction
+	if err := s.storage.MergeTariffs(ctx, applied); err != nil {
+		return nil, nil, err
+	}
+
+	return applied, results, nil
+}
+
+// preparePutTariff validates and populates tariff with stored attributes
+// returns false if later changes found
+func (s *tariffService) preparePutTariff(ctx context.Context, trf, stored *domain.Tariff) (bool, error) {
+	if trf.Id == "" {
+		return false, errors.ErrTrfIdEmpty(ctx)
+	}
+
+	// check last_updated
+	if stored != nil && trf.LastUpdated.Before(stored.LastUpdated) {
+		return false, nil
+	}
+
+	// validate
+	if err := s.validateAndPopulatePut(ctx, trf, stored); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (s *tariffService) MergeTariff(ctx context.Context, trf *domain.Tariff) (*domain.Tariff, error) {
@@ -146,6 +213,14 @@ func (s *tariffService) DeleteTariffsByExtId(ctx context.Context, extId domain.P
 	return s.storage.DeleteTariffsByExtId(ctx, extId)
 }
 
+func (s *tariffService) DeleteTariffsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("del-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return errors.ErrPlatformIdEmpty(ctx)
+	}
+	return s.storage.DeleteTariffsByPlatform(ctx, platformId, archive)
+}
+
 func (s *tariffService) Validate(ctx context.Context, trf *domain.Tariff) error {
 
 	if err := s.validateOcpiItem(ctx, &trf.OcpiItem); err != nil {
@@ -191,6 +266,16 @@ func (s *tariffService) Validate(ctx context.Context, trf *domain.Tariff) error
 		return err
 	}
 
+	// ad hoc payment
+	if err := s.validatePrice(ctx, "tariff", "preauthorize_amount", trf.Details.PreauthorizeAmount); err != nil {
+		return err
+	}
+	if trf.Details.TaxIncluded != "" {
+		if _, ok := taxIncludedMap[trf.Details.TaxIncluded]; !ok {
+			return errors.ErrTrfInvalidAttr(ctx, "tariff", "tax_included")
+		}
+	}
+
 	// elements
 	if err := s.validateElements(ctx, &trf.Details); err != nil {
 		return err
@@ -356,5 +441,11 @@ func (s *tariffService) validateAndPopulateMerge(ctx context.Context, trf, store
 	if trf.Details.EnergyMix != nil {
 		stored.Details.EnergyMix = trf.Details.EnergyMix
 	}
+	if trf.Details.PreauthorizeAmount != nil {
+		stored.Details.PreauthorizeAmount = trf.Details.PreauthorizeAmount
+	}
+	if trf.Details.TaxIncluded != "" {
+		stored.Details.TaxIncluded = trf.Details.TaxIncluded
+	}
 	return s.Validate(ctx, stored)
 }
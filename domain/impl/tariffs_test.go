@@ -52,6 +52,29 @@ func (s *tariffTestSuite) Test_PutTariff_Ok() {
 	s.AssertCalled(&s.storage.Mock, "MergeTariff", s.Ctx, trf)
 }
 
+func (s *tariffTestSuite) Test_PutTariffs_Results() {
+	ok, later, invalid := s.tariff(), s.tariff(), s.tariff()
+	ok.Id, later.Id, invalid.Id = kit.NewRandString(), kit.NewRandString(), kit.NewRandString()
+	stored := s.tariff()
+	stored.Id = later.Id
+	later.LastUpdated = kit.Now().Add(-time.Hour)
+	invalid.Details.Currency = ""
+	s.storage.On("SearchTariffs", s.Ctx, &domain.TariffSearchCriteria{
+		PageRequest: domain.PageRequest{Limit: kit.IntPtr(3)},
+		Ids:         []string{ok.Id, later.Id, invalid.Id},
+	}).Return(&domain.TariffSearchResponse{Items: []*domain.Tariff{stored}}, nil)
+	s.storage.On("MergeTariffs", s.Ctx, []*domain.Tariff{ok}).Return(nil)
+	applied, rs, err := s.svc.PutTariffs(s.Ctx, []*domain.Tariff{ok, later, invalid})
+	s.NoError(err)
+	s.Equal([]*domain.Tariff{ok}, applied)
+	s.Len(rs, 3)
+	s.True(rs[0].Applied)
+	s.False(rs[1].Applied)
+	s.Nil(rs[1].Err)
+	s.Error(rs[2].Err)
+	s.storage.AssertNotCalled(s.T(), "GetTariff", s.Ctx, ok.Id)
+}
+
 func (s *tariffTestSuite) Test_MergeTariff_WhenLastUpdatedLater_Skip() {
 	stored := s.tariff()
 	s.storage.On("GetTariff", s.Ctx, stored.Id).Return(stored, nil)
@@ -199,6 +222,23 @@ func (s *tariffTestSuite) Test_Validate() {
 	s.Error(s.svc.Validate(s.Ctx, trf))
 }
 
+func (s *tariffTestSuite) Test_Validate_TaxAndPreauthorize() {
+	// valid
+	trf := s.tariff()
+	trf.Details.TaxIncluded = domain.TaxIncludedNotApplicable
+	trf.Details.PreauthorizeAmount = &domain.Price{ExclVat: 50.0}
+	s.NoError(s.svc.Validate(s.Ctx, trf))
+
+	// invalid tax included
+	trf.Details.TaxIncluded = "invalid"
+	s.Error(s.svc.Validate(s.Ctx, trf))
+
+	// invalid preauthorize amount
+	trf = s.tariff()
+	trf.Details.PreauthorizeAmount = &domain.Price{ExclVat: -1}
+	s.Error(s.svc.Validate(s.Ctx, trf))
+}
+
 func (s *tariffTestSuite) tariff() *domain.Tariff {
 	return &domain.Tariff{
 		OcpiItem: domain.OcpiItem{
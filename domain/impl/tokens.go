@@ -59,24 +59,85 @@ func (s *tokenService) PutToken(ctx context.Context, tkn *domain.Token) (*domain
 		return nil, err
 	}
 
-	// check last_updated
-	if stored != nil && tkn.LastUpdated.Before(stored.LastUpdated) {
+	ok, err := s.preparePutToken(ctx, tkn, stored)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
 		l.Warn("later changes found")
 		return nil, nil
 	}
 
-	// validate
-	err = s.validateAndPopulatePut(ctx, tkn, stored)
+	err = s.storage.MergeToken(ctx, tkn)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.storage.MergeToken(ctx, tkn)
+	return tkn, nil
+}
+
+func (s *tokenService) PutTokens(ctx context.Context, tkns []*domain.Token) ([]*domain.Token, []*domain.BulkItemResult, error) {
+	l := s.l().C(ctx).Mth("put-tkns").F(kit.KV{"count": len(tkns)}).Dbg()
+
+	if err := s.validateBulk(ctx, len(tkns)); err != nil {
+		return nil, nil, err
+	}
+
+	// retrieve stored tokens at once
+	stored, err := searchByIds(kit.Select(tkns, func(tkn *domain.Token) string { return tkn.Id }),
+		func(tkn *domain.Token) string { return tkn.Id },
+		func(ids []string) ([]*domain.Token, error) {
+			rs, err := s.storage.SearchTokens(ctx, &domain.TokenSearchCriteria{
+				PageRequest: domain.PageRequest{Limit: kit.IntPtr(len(ids))},
+				Ids:         ids,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return rs.Items, nil
+		})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return tkn, nil
+	applied, results := putBulk(ctx, tkns, func(tkn *domain.Token) string { return tkn.Id }, func(tkn *domain.Token) (bool, error) {
+		ok, err := s.preparePutToken(ctx, tkn, stored[tkn.Id])
+		if err == nil && !ok {
+			l.F(kit.KV{"tknId": tkn.Id}).Warn("later changes found")
+		}
+		return ok, err
+	})
+
+	if len(applied) == 0 {
+		return nil, results, nil
+	}
+
+	// all the applied tokens are stored in a single transaction
+	if err := s.storage.MergeTokens(ctx, applied); err != nil {
+		return nil, nil, err
+	}
+
+	return applied, results, nil
+}
+
+// preparePutToken validates and populates token with stored attributes
+// returns false if later changes found
+func (s *tokenService) preparePutToken(ctx context.Context, tkn, stored *domain.Token) (bool, error) {
+	if tkn.Id == "" {
+		return false, errors.ErrTknIdEmpty(ctx)
+	}
+
+	// check last_updated
+	if stored != nil && tkn.LastUpdated.Before(stored.LastUpdated) {
+		return false, nil
+	}
+
+	// validate
+	if err := s.validateAndPopulatePut(ctx, tkn, stored); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (s *tokenService) MergeToken(ctx context.Context, tkn *domain.Token) (*domain.Token, error) {
@@ -139,6 +200,14 @@ func (s *tokenService) DeleteTokensByExtId(ctx context.Context, extId domain.Par
 	return s.storage.DeleteTokensByExtId(ctx, extId)
 }
 
+func (s *tokenService) DeleteTokensByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("del-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return errors.ErrPlatformIdEmpty(ctx)
+	}
+	return s.storage.DeleteTokensByPlatform(ctx, platformId, archive)
+}
+
 func (s *tokenService) ValidateToken(ctx context.Context, tkn *domain.Token) error {
 
 	if err := s.validateOcpiItem(ctx, &tkn.OcpiItem); err != nil {
@@ -225,6 +294,24 @@ func (s *tokenService) ValidateToken(ctx context.Context, tkn *domain.Token) err
 		}
 	}
 
+	// session limits
+	if tkn.Details.SessionLimits != nil {
+		if tkn.Details.SessionLimits.Kwh != nil && *tkn.Details.SessionLimits.Kwh <= 0 {
+			return errors.ErrTknInvalidAttr(ctx, "session_limits", "kwh")
+		}
+		if tkn.Details.SessionLimits.Cost != nil && *tkn.Details.SessionLimits.Cost <= 0 {
+			return errors.ErrTknInvalidAttr(ctx, "session_limits", "cost")
+		}
+		if tkn.Details.SessionLimits.Cost != nil {
+			if tkn.Details.SessionLimits.Currency == "" {
+				return errors.ErrTknEmptyAttr(ctx, "session_limits", "currency")
+			}
+			if !kit.CurrencyValid(tkn.Details.SessionLimits.Currency) {
+				return errors.ErrTknInvalidAttr(ctx, "session_limits", "currency")
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -241,6 +328,10 @@ func (s *tokenService) validateAndPopulatePut(ctx context.Context, tkn, stored *
 		if tkn.ExtId.PartyId == "" || tkn.ExtId.CountryCode == "" {
 			tkn.ExtId = stored.ExtId
 		}
+		// limits aren't a part of OCPI token, so they are kept unless specified
+		if tkn.Details.SessionLimits == nil {
+			tkn.Details.SessionLimits = stored.Details.SessionLimits
+		}
 	}
 
 	return s.ValidateToken(ctx, tkn)
@@ -289,5 +380,8 @@ func (s *tokenService) validateAndPopulateMerge(ctx context.Context, tkn, stored
 	if tkn.Details.EnergyContract != nil {
 		stored.Details.EnergyContract = tkn.Details.EnergyContract
 	}
+	if tkn.Details.SessionLimits != nil {
+		stored.Details.SessionLimits = tkn.Details.SessionLimits
+	}
 	return s.ValidateToken(ctx, stored)
 }
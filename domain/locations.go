@@ -192,6 +192,7 @@ type ConnectorDetails struct {
 	MaxElectricPower   *float64 `json:"maxElectricPower,omitempty"`   // MaxElectricPower maximum electric power in W
 	TariffIds          []string `json:"tariffIds,omitempty"`          // TariffIds charging tariffs
 	TermsAndConditions string   `json:"termsAndConditions,omitempty"` // TermsAndConditions url of operator’s terms and conditions
+	Capabilities       []string `json:"capabilities,omitempty"`       // Capabilities list of functionalities that the connector is capable of
 }
 
 type Connector struct {
@@ -202,6 +203,32 @@ type Connector struct {
 	Details    ConnectorDetails `json:"details"`          // Details connector details
 }
 
+type Parking struct {
+	Id                    string   `json:"id"`                              // Id uniquely identifies the parking place within the location
+	PhysicalReference     string   `json:"physicalReference,omitempty"`     // PhysicalReference number/string printed on the parking place
+	VehicleTypes          []string `json:"vehicleTypes,omitempty"`          // VehicleTypes types of vehicles the parking place is intended for
+	MaxVehicleWeight      *float64 `json:"maxVehicleWeight,omitempty"`      // MaxVehicleWeight maximum vehicle weight in kg
+	MaxVehicleHeight      *float64 `json:"maxVehicleHeight,omitempty"`      // MaxVehicleHeight maximum vehicle height in cm
+	MaxVehicleLength      *float64 `json:"maxVehicleLength,omitempty"`      // MaxVehicleLength maximum vehicle length in cm
+	MaxVehicleWidth       *float64 `json:"maxVehicleWidth,omitempty"`       // MaxVehicleWidth maximum vehicle width in cm
+	ParkingSpaceLength    *float64 `json:"parkingSpaceLength,omitempty"`    // ParkingSpaceLength length of the parking space in cm
+	ParkingSpaceWidth     *float64 `json:"parkingSpaceWidth,omitempty"`     // ParkingSpaceWidth width of the parking space in cm
+	DangerousGoodsAllowed *bool    `json:"dangerousGoodsAllowed,omitempty"` // DangerousGoodsAllowed if vehicles with dangerous goods are allowed
+	Direction             string   `json:"direction,omitempty"`             // Direction of the parking place relative to the driving direction
+	DriveThrough          *bool    `json:"driveThrough,omitempty"`          // DriveThrough if the parking place is a drive-through one
+	RestrictedToType      *bool    `json:"restrictedToType,omitempty"`      // RestrictedToType if only the vehicle types listed may park
+	ReservationRequired   *bool    `json:"reservationRequired,omitempty"`   // ReservationRequired if the parking place must be reserved
+	TimeLimit             *float64 `json:"timeLimit,omitempty"`             // TimeLimit maximum parking time in minutes
+	Roofed                *bool    `json:"roofed,omitempty"`                // Roofed if the parking place is roofed
+	Lighting              *bool    `json:"lighting,omitempty"`              // Lighting if the parking place is lit
+	RefrigerationOutlet   *bool    `json:"refrigerationOutlet,omitempty"`   // RefrigerationOutlet if a refrigeration outlet is available
+}
+
+type EvseParking struct {
+	ParkingId    string `json:"parkingId"`              // ParkingId reference to the parking place of the location
+	EvsePosition string `json:"evsePosition,omitempty"` // EvsePosition position of the EVSE relative to the parking place
+}
+
 type EvseDetails struct {
 	EvseId              string            `json:"evseId,omitempty"`              // EvseId following specification for EVSE ID from "eMI3 standard version V1.0". Can be reused
 	StatusSchedule      []*StatusSchedule `json:"statusSchedule,omitempty"`      // StatusSchedule indicates a planned status update of the EVSE
@@ -212,6 +239,7 @@ type EvseDetails struct {
 	Directions          []*DisplayText    `json:"directions,omitempty"`          // Directions human-readable directions
 	ParkingRestrictions []string          `json:"parkingRestrictions,omitempty"` // ParkingRestrictions restrictions that apply to the parking spot
 	Images              []*Image          `json:"images,omitempty"`              // Images related to the EVSE
+	Parking             []*EvseParking    `json:"parking,omitempty"`             // Parking references to the parking places the EVSE can be reached from
 }
 
 type Evse struct {
@@ -245,6 +273,8 @@ type LocationDetails struct {
 	ChargingWhenClosed *bool                    `json:"chargingWhenClosed,omitempty"` // ChargingWhenClosed if the EVSEs are still charging outside the opening hours of the location
 	Images             []*Image                 `json:"images,omitempty"`             // Images links to images related to the location
 	EnergyMix          *EnergyMix               `json:"energyMix,omitempty"`          // EnergyMix energy supplied at this location
+	HelpPhone          string                   `json:"helpPhone,omitempty"`          // HelpPhone telephone number of the helpdesk of the operator
+	ParkingPlaces      []*Parking               `json:"parkingPlaces,omitempty"`      // ParkingPlaces parking places at the location
 }
 
 type Location struct {
@@ -295,16 +325,24 @@ type LocationService interface {
 	PutLocation(ctx context.Context, loc *Location) (*Location, error)
 	// MergeLocation merges location
 	MergeLocation(ctx context.Context, loc *Location) (*Location, error)
+	// PutLocations creates or updates locations in a single transaction
+	// rejected items are reported in results, the rest are stored, returns stored locations and results in order of request
+	PutLocations(ctx context.Context, locs []*Location) ([]*Location, []*BulkItemResult, error)
 	// GetLocation retrieves location by ID
 	GetLocation(ctx context.Context, locId string, withEvse bool) (*Location, error)
 	// SearchLocations searches locations
 	SearchLocations(ctx context.Context, cr *LocationSearchCriteria) (*LocationSearchResponse, error)
 	// DeleteLocationsByExtId deletes locations (evse + connectors) by party ext id
 	DeleteLocationsByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteLocationsByPlatform deletes all locations (evse + connectors) of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteLocationsByPlatform(ctx context.Context, platformId string, archive bool) error
 	// PutEvse creates or updates evse
 	PutEvse(ctx context.Context, evse *Evse) (*Evse, error)
 	// MergeEvse merges evse
 	MergeEvse(ctx context.Context, evse *Evse) (*Evse, error)
+	// MergeEvses merges evses in a single transaction
+	// rejected items are reported in results, the rest are stored, returns stored evses and results in order of request
+	MergeEvses(ctx context.Context, evses []*Evse) ([]*Evse, []*BulkItemResult, error)
 	// GetEvse retrieves evse by ID
 	GetEvse(ctx context.Context, locationId, evseId string, withConnectors bool) (*Evse, error)
 	// SearchEvses searches evses
@@ -324,16 +362,23 @@ type LocationStorage interface {
 	GetLocation(ctx context.Context, id string, withEvse bool) (*Location, error)
 	// MergeLocation merges location
 	MergeLocation(ctx context.Context, loc *Location) error
+	// MergeLocations merges locations with evses and connectors in a single transaction
+	// rows are upserted in batches, stored rows are updated only if they aren't later than merged ones
+	MergeLocations(ctx context.Context, locs []*Location) error
 	// UpdateLocation updates location
 	UpdateLocation(ctx context.Context, loc *Location) error
 	// DeleteLocationsByExtId deletes locations (evse + connectors) by party ext id
 	DeleteLocationsByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteLocationsByPlatform deletes all locations (evse + connectors) of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteLocationsByPlatform(ctx context.Context, platformId string, archive bool) error
 	// SearchLocations searches locations
 	SearchLocations(ctx context.Context, cr *LocationSearchCriteria) (*LocationSearchResponse, error)
 	// GetEvse retrieves evse by id
 	GetEvse(ctx context.Context, locId, evseId string, withConnectors bool) (*Evse, error)
 	// MergeEvse merges evse
 	MergeEvse(ctx context.Context, evse *Evse) error
+	// MergeEvses merges evses with connectors in a single transaction
+	MergeEvses(ctx context.Context, evses []*Evse) error
 	// UpdateEvse update evse
 	UpdateEvse(ctx context.Context, evse *Evse) error
 	// SearchEvses searches evses
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+)
+
+const (
+	InvoiceCreatorCpo = "CPO"
+	InvoiceCreatorPtp = "PTP"
+
+	CaptureStatusSuccess        = "SUCCESS"
+	CaptureStatusPartialSuccess = "PARTIAL_SUCCESS"
+	CaptureStatusFailed         = "FAILED"
+)
+
+type TerminalDetails struct {
+	CustomerReference string       `json:"customerReference,omitempty"` // CustomerReference reference of the customer the terminal is installed for
+	Address           string       `json:"address,omitempty"`           // Address street/block name and house number
+	City              string       `json:"city,omitempty"`              // City or town
+	PostalCode        string       `json:"postalCode,omitempty"`        // PostalCode of the terminal
+	State             string       `json:"state,omitempty"`             // State or province of the terminal
+	Country           string       `json:"country,omitempty"`           // Country alpha-3 code for the country
+	Coordinates       *GeoLocation `json:"coordinates,omitempty"`       // Coordinates of the terminal
+	InvoiceBaseUrl    string       `json:"invoiceBaseUrl,omitempty"`    // InvoiceBaseUrl base url to download invoices
+	InvoiceCreator    string       `json:"invoiceCreator,omitempty"`    // InvoiceCreator party creating invoices
+	Reference         string       `json:"reference,omitempty"`         // Reference of the terminal given by the PTP
+	LocationIds       []string     `json:"locationIds,omitempty"`       // LocationIds locations the terminal is assigned to
+	EvseUids          []string     `json:"evseUids,omitempty"`          // EvseUids EVSEs the terminal is assigned to
+}
+
+type Terminal struct {
+	OcpiItem
+	Id      string          `json:"id"`      // Id uniquely identifies the terminal
+	Details TerminalDetails `json:"details"` // Details terminal details
+}
+
+type FinancialAdviceDetails struct {
+	AuthRef              string   `json:"authRef"`                        // AuthRef authorization reference of the session or cdr the advice relates to
+	TotalCosts           Price    `json:"totalCosts"`                     // TotalCosts captured amount
+	Currency             string   `json:"currency"`                       // Currency ISO-4217 code
+	EftData              []string `json:"eftData,omitempty"`              // EftData electronic funds transfer data received from the terminal
+	CaptureStatusCode    string   `json:"captureStatusCode"`              // CaptureStatusCode status of the capture
+	CaptureStatusMessage string   `json:"captureStatusMessage,omitempty"` // CaptureStatusMessage message of the capture status
+}
+
+// FinancialAdvice financial advice confirmation of the ad hoc payment captured by a terminal
+type FinancialAdvice struct {
+	OcpiItem
+	Id      string                 `json:"id"`      // Id uniquely identifies the financial advice confirmation
+	Details FinancialAdviceDetails `json:"details"` // Details financial advice confirmation details
+}
+
+type TerminalSearchCriteria struct {
+	PageRequest
+	ExtId        *PartyExtId // ExtId by party ext ID
+	RefId        string      // RefId by ref id
+	IncPlatforms []string    // IncPlatforms includes platform Ids
+	ExcPlatforms []string    // ExcPlatforms exclude platform Ids
+	Ids          []string    // Ids by list of Ids
+}
+
+type TerminalSearchResponse struct {
+	PageResponse
+	Items []*Terminal
+}
+
+type FinancialAdviceSearchCriteria struct {
+	PageRequest
+	ExtId        *PartyExtId // ExtId by party ext ID
+	AuthRef      string      // AuthRef by authorization reference
+	IncPlatforms []string    // IncPlatforms includes platform Ids
+	ExcPlatforms []string    // ExcPlatforms exclude platform Ids
+	Ids          []string    // Ids by list of Ids
+}
+
+type FinancialAdviceSearchResponse struct {
+	PageResponse
+	Items []*FinancialAdvice
+}
+
+type PaymentService interface {
+	// PutTerminal creates or updates terminal
+	PutTerminal(ctx context.Context, t *Terminal) (*Terminal, error)
+	// MergeTerminal merges terminal
+	MergeTerminal(ctx context.Context, t *Terminal) (*Terminal, error)
+	// GetTerminal retrieves terminal by ID
+	GetTerminal(ctx context.Context, id string) (*Terminal, error)
+	// SearchTerminals searches terminals
+	SearchTerminals(ctx context.Context, cr *TerminalSearchCriteria) (*TerminalSearchResponse, error)
+	// PutFinancialAdvice creates or updates financial advice confirmation
+	PutFinancialAdvice(ctx context.Context, fa *FinancialAdvice) (*FinancialAdvice, error)
+	// GetFinancialAdvice retrieves financial advice confirmation by ID
+	GetFinancialAdvice(ctx context.Context, id string) (*FinancialAdvice, error)
+	// SearchFinancialAdvices searches financial advice confirmations
+	SearchFinancialAdvices(ctx context.Context, cr *FinancialAdviceSearchCriteria) (*FinancialAdviceSearchResponse, error)
+}
+
+type PaymentStorage interface {
+	// MergeTerminal creates or updates terminal
+	MergeTerminal(ctx context.Context, t *Terminal) error
+	// UpdateTerminal updates terminal
+	UpdateTerminal(ctx context.Context, t *Terminal) error
+	// GetTerminal retrieves terminal by ID
+	GetTerminal(ctx context.Context, id string) (*Terminal, error)
+	// SearchTerminals searches terminals
+	SearchTerminals(ctx context.Context, cr *TerminalSearchCriteria) (*TerminalSearchResponse, error)
+	// MergeFinancialAdvice creates or updates financial advice confirmation
+	MergeFinancialAdvice(ctx context.Context, fa *FinancialAdvice) error
+	// GetFinancialAdvice retrieves financial advice confirmation by ID
+	GetFinancialAdvice(ctx context.Context, id string) (*FinancialAdvice, error)
+	// SearchFinancialAdvices searches financial advice confirmations
+	SearchFinancialAdvices(ctx context.Context, cr *FinancialAdviceSearchCriteria) (*FinancialAdviceSearchResponse, error)
+}
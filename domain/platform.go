@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 	"github.com/mikhailbolshakov/ocpi"
+	"strings"
+	"time"
 )
 
 const (
@@ -14,6 +16,7 @@ const (
 	ModuleIdSessions      = "sessions"
 	ModuleIdTariffs       = "tariffs"
 	ModuleIdTokens        = "tokens"
+	ModuleIdPayments      = "payments"
 
 	ConnectionStatusConnected = "CONNECTED"
 	ConnectionStatusOffLine   = "OFFLINE"
@@ -27,8 +30,72 @@ const (
 	RoleNSP   = "NSP"
 	RoleOTHER = "OTHER"
 	RoleSCSP  = "SCSP"
+
+	PlatformDataKeep    = "keep"    // PlatformDataKeep platform's data remains untouched on disconnection
+	PlatformDataArchive = "archive" // PlatformDataArchive platform's data is marked as deleted on disconnection
+	PlatformDataPurge   = "purge"   // PlatformDataPurge platform's data is removed on disconnection
+
+	DataCleanupSuccess = "success" // DataCleanupSuccess platform's data was archived or purged
+	DataCleanupFailed  = "failed"  // DataCleanupFailed platform's data wasn't handled, disconnection can be repeated to retry
+
+	TokenRotationSuccess = "success"
+	TokenRotationFailed  = "failed"
+
+	OnboardingTokenActive   = "active"   // OnboardingTokenActive token can be used to start credentials handshake
+	OnboardingTokenExpired  = "expired"  // OnboardingTokenExpired token is expired
+	OnboardingTokenConsumed = "consumed" // OnboardingTokenConsumed single use token has been already used for handshake
+	OnboardingTokenRevoked  = "revoked"  // OnboardingTokenRevoked token is revoked
+
+	OnboardingTokenTtlDefault = time.Hour * 72 // OnboardingTokenTtlDefault onboarding token TTL if expiration isn't specified
+
+	BreakerClosed   = "closed"    // BreakerClosed requests to the remote platform are allowed
+	BreakerOpen     = "open"      // BreakerOpen requests to the remote platform are rejected until cooldown expires
+	BreakerHalfOpen = "half-open" // BreakerHalfOpen a trial request is allowed to check if the remote platform is back
+
+	ValidationStrict  = "strict"  // ValidationStrict invalid lifecycle changes of objects are rejected
+	ValidationLenient = "lenient" // ValidationLenient invalid lifecycle changes of objects are logged and applied
 )
 
+// ModuleIds list of supported modules
+var ModuleIds = map[string]struct{}{
+	ModuleIdCredentials:   {},
+	ModuleIdCdrs:          {},
+	ModuleIdCommands:      {},
+	ModuleIdHubClientInfo: {},
+	ModuleIdLocations:     {},
+	ModuleIdSessions:      {},
+	ModuleIdTariffs:       {},
+	ModuleIdTokens:        {},
+	ModuleIdPayments:      {},
+}
+
+// routeMethods HTTP methods of the inbound routes
+var routeMethods = map[string]struct{}{
+	"get":    {},
+	"post":   {},
+	"put":    {},
+	"patch":  {},
+	"delete": {},
+}
+
+// RouteKey builds a key of the inbound route as <module>.<method> (e.g. locations.get)
+func RouteKey(module, method string) string {
+	return strings.ToLower(module + "." + method)
+}
+
+// ValidRouteKey checks if the key of the inbound route is valid
+func ValidRouteKey(key string) bool {
+	parts := strings.Split(key, ".")
+	if len(parts) != 2 {
+		return false
+	}
+	if _, ok := ModuleIds[parts[0]]; !ok && parts[0] != "versions" && parts[0] != "version-details" {
+		return false
+	}
+	_, ok := routeMethods[parts[1]]
+	return ok
+}
+
 var RoleMap = map[string]bool{
 	RoleHUB:   true,
 	RoleCPO:   true,
@@ -74,32 +141,129 @@ type PushSupport struct {
 }
 
 type ProtocolDetails struct {
-	PushSupport PushSupport `json:"pushSupport"` // PushSupport specifies pushing support by module
+	PushSupport PushSupport `json:"pushSupport"`          // PushSupport specifies pushing support by module
+	Validation  string      `json:"validation,omitempty"` // Validation how strictly lifecycle changes of objects are validated, strict if empty
+}
+
+// TokenRotationPolicy specifies how often credentials tokens are rotated
+type TokenRotationPolicy struct {
+	Interval    int `json:"interval,omitempty"`    // Interval rotation interval in days, rotation is disabled if not specified
+	GracePeriod int `json:"gracePeriod,omitempty"` // GracePeriod period in hours when the previous token is still accepted
+}
+
+// TokenRotation token rotation history item
+type TokenRotation struct {
+	Id         string    // Id unique ID
+	PlatformId string    // PlatformId platform which tokens were rotated
+	Status     string    // Status rotation status
+	Error      string    // Error rotation error if failed
+	CreatedAt  time.Time // CreatedAt when rotation happened
+}
+
+// PlatformHealth health state of the remote platform tracked by periodic checks
+type PlatformHealth struct {
+	CheckedAt    *time.Time `json:"checkedAt,omitempty"`    // CheckedAt when the last check happened
+	LatencyMs    int64      `json:"latencyMs,omitempty"`    // LatencyMs latency of the last check
+	Failures     int        `json:"failures,omitempty"`     // Failures number of consecutive failed checks
+	Successes    int        `json:"successes,omitempty"`    // Successes number of consecutive successful checks
+	LastError    string     `json:"lastError,omitempty"`    // LastError error of the last failed check
+	OfflineSince *time.Time `json:"offlineSince,omitempty"` // OfflineSince when platform was moved offline by health checks
+}
+
+// RateLimit token bucket rate limit of requests
+type RateLimit struct {
+	Rate  float64 `json:"rate,omitempty"`  // Rate requests per second
+	Burst int     `json:"burst,omitempty"` // Burst max number of requests sent at once
+}
+
+// ClientSettings specifies how OCPI requests are sent to the remote platform
+// empty values mean defaults are used
+type ClientSettings struct {
+	Timeout          int                   `json:"timeout,omitempty"`          // Timeout request timeout in seconds
+	MaxRetries       *int                  `json:"maxRetries,omitempty"`       // MaxRetries max number of retries of idempotent requests (GET, PUT), 0 disables retries
+	RetryBackoff     int                   `json:"retryBackoff,omitempty"`     // RetryBackoff initial retry backoff in milliseconds, it's doubled with every retry and jittered
+	BreakerThreshold int                   `json:"breakerThreshold,omitempty"` // BreakerThreshold number of consecutive failures opening circuit breaker
+	BreakerCooldown  int                   `json:"breakerCooldown,omitempty"`  // BreakerCooldown period in seconds circuit breaker stays open
+	MaxConcurrency   int                   `json:"maxConcurrency,omitempty"`   // MaxConcurrency max number of concurrent requests, unlimited if empty
+	RateLimit        *RateLimit            `json:"rateLimit,omitempty"`        // RateLimit limits all requests to the remote platform, unlimited if empty
+	ModuleRateLimits map[string]*RateLimit `json:"moduleRateLimits,omitempty"` // ModuleRateLimits limits requests to the particular modules
+}
+
+// ClientState runtime state of the client to the remote platform
+type ClientState struct {
+	Breaker  string     // Breaker circuit breaker state
+	Failures int        // Failures number of consecutive failures
+	OpenedAt *time.Time // OpenedAt when circuit breaker was opened
+	InFlight int        // InFlight number of requests in progress
+	Queued   int        // Queued number of requests waiting for rate limiters
+}
+
+// RouteLimits limits requests coming to the local OCPI endpoints
+type RouteLimits struct {
+	RateLimit  *RateLimit `json:"rateLimit,omitempty"`  // RateLimit token bucket rate limit, unlimited if empty
+	DailyQuota int        `json:"dailyQuota,omitempty"` // DailyQuota max number of requests per day (UTC), unlimited if empty
+}
+
+// InboundLimits limits requests of the remote platform to the local OCPI endpoints
+// platform limits are applied to all the requests, route limits are applied in addition to the particular routes
+type InboundLimits struct {
+	RateLimit  *RateLimit              `json:"rateLimit,omitempty"`  // RateLimit token bucket rate limit of all requests, unlimited if empty
+	DailyQuota int                     `json:"dailyQuota,omitempty"` // DailyQuota max number of all requests per day (UTC), unlimited if empty
+	Routes     map[string]*RouteLimits `json:"routes,omitempty"`     // Routes limits of the particular routes, key is <module>.<method> (e.g. locations.get)
+}
+
+// OnboardingToken token A lifecycle. Token A is handed out to a remote platform to start credentials handshake
+type OnboardingToken struct {
+	Role       string     `json:"role,omitempty"`       // Role intended role of the remote platform
+	SingleUse  bool       `json:"singleUse,omitempty"`  // SingleUse if true, token is consumed by the first successful handshake
+	CreatedAt  time.Time  `json:"createdAt"`            // CreatedAt when token was issued
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`  // ExpiresAt when token expires
+	ConsumedAt *time.Time `json:"consumedAt,omitempty"` // ConsumedAt when token was used for handshake
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`  // RevokedAt when token was revoked
+}
+
+// PlatformDataCleanup result of handling platform's data according to the policy on disconnection
+type PlatformDataCleanup struct {
+	Policy    string    `json:"policy"`          // Policy data policy applied
+	Status    string    `json:"status"`          // Status cleanup status
+	Error     string    `json:"error,omitempty"` // Error cleanup error if failed
+	UpdatedAt time.Time `json:"updatedAt"`       // UpdatedAt when cleanup happened
 }
 
 // Platform OCPI platform (either local or remote)
 type Platform struct {
-	Id          string           `json:"id"`                    // Id unique ID
-	TokenA      PlatformToken    `json:"tokenA"`                // TokenA is an initial token used for handshake
-	TokenB      PlatformToken    `json:"tokenB,omitempty"`      // TokenB token received from sender
-	TokenC      PlatformToken    `json:"tokenC,omitempty"`      // TokenC token received from receiver
-	TokenBase64 *bool            `json:"tokenBase64,omitempty"` // TokenBase64 if true, token is base64 encoded
-	Name        string           `json:"name,omitempty"`        // Name platform name
-	Role        string           `json:"role,omitempty"`        // Role platform role
-	VersionInfo VersionInfo      `json:"versionInfo"`           // VersionInfo platform version info
-	Endpoints   ModuleEndpoints  `json:"endpoints,omitempty"`   // Endpoints list of available endpoints
-	Status      string           `json:"status,omitempty"`      // Status platform status
-	Remote      bool             `json:"remote"`                // Remote is true, if it's a remote platform (not local)
-	Protocol    *ProtocolDetails `json:"protocol,omitempty"`    // Protocol specifies protocol details
+	Id            string               `json:"id"`                      // Id unique ID
+	TokenA        PlatformToken        `json:"tokenA"`                  // TokenA is an initial token used for handshake
+	TokenB        PlatformToken        `json:"tokenB,omitempty"`        // TokenB token received from sender
+	TokenC        PlatformToken        `json:"tokenC,omitempty"`        // TokenC token received from receiver
+	TokenBase64   *bool                `json:"tokenBase64,omitempty"`   // TokenBase64 if true, token is base64 encoded
+	Name          string               `json:"name,omitempty"`          // Name platform name
+	Role          string               `json:"role,omitempty"`          // Role platform role
+	VersionInfo   VersionInfo          `json:"versionInfo"`             // VersionInfo platform version info
+	Endpoints     ModuleEndpoints      `json:"endpoints,omitempty"`     // Endpoints list of available endpoints
+	Status        string               `json:"status,omitempty"`        // Status platform status
+	Remote        bool                 `json:"remote"`                  // Remote is true, if it's a remote platform (not local)
+	Protocol      *ProtocolDetails     `json:"protocol,omitempty"`      // Protocol specifies protocol details
+	TokenRotation *TokenRotationPolicy `json:"tokenRotation,omitempty"` // TokenRotation specifies token rotation policy
+	TokenIssuedAt *time.Time           `json:"tokenIssuedAt,omitempty"` // TokenIssuedAt when token B was issued
+	PrevTokenB    PlatformToken        `json:"prevTokenB,omitempty"`    // PrevTokenB previous token B which is still accepted until PrevTokenBExp
+	PrevTokenBExp *time.Time           `json:"prevTokenBExp,omitempty"` // PrevTokenBExp when previous token B expires
+	Onboarding    *OnboardingToken     `json:"onboarding,omitempty"`    // Onboarding token A lifecycle, if empty token A isn't restricted
+	Health        *PlatformHealth      `json:"health,omitempty"`        // Health health state of the remote platform
+	Client        *ClientSettings      `json:"client,omitempty"`        // Client settings of the client sending requests to the remote platform
+	ClientState   *ClientState         `json:"-"`                       // ClientState runtime state of the client, it isn't stored
+	Inbound       *InboundLimits       `json:"inbound,omitempty"`       // Inbound limits of requests coming from the remote platform
+	DataCleanup   *PlatformDataCleanup `json:"dataCleanup,omitempty"`   // DataCleanup result of data cleanup on disconnection
 }
 
 type PlatformSearchCriteria struct {
-	Roles    []string // Roles roles
-	ExcRoles []string // ExcRoles exclude roles
-	Statuses []string // Statuses search by statuses
-	IncIds   []string // IncIds include IDs
-	ExcIds   []string // ExcIds exclude IDs
-	Remote   *bool    // Remote search by remote flag
+	Roles      []string // Roles roles
+	ExcRoles   []string // ExcRoles exclude roles
+	Statuses   []string // Statuses search by statuses
+	IncIds     []string // IncIds include IDs
+	ExcIds     []string // ExcIds exclude IDs
+	Remote     *bool    // Remote search by remote flag
+	Onboarding bool     // Onboarding search platforms with onboarding token issued
 }
 
 // TokenGenerator responsible for token generation
@@ -119,6 +283,8 @@ type PlatformService interface {
 	Merge(ctx context.Context, platform *Platform) (*Platform, error)
 	// SetStatus sets status
 	SetStatus(ctx context.Context, platformId string, status string) (*Platform, error)
+	// Disconnect wipes connection tokens (B, C) and suspends platform
+	Disconnect(ctx context.Context, platformId string) (*Platform, error)
 	// Get retrieves platform by ID
 	Get(ctx context.Context, platformId string) (*Platform, error)
 	// GetByTokenA retrieves platform by token A
@@ -131,6 +297,23 @@ type PlatformService interface {
 	Search(ctx context.Context, cr *PlatformSearchCriteria) ([]*Platform, error)
 	// RoleEndpoint returns endpoint of the requested module and role. If roles isn't supported, empty string is returned
 	RoleEndpoint(ctx context.Context, platform *Platform, module, role string) Endpoint
+	// AddTokenRotation adds token rotation history item
+	AddTokenRotation(ctx context.Context, rotation *TokenRotation) error
+	// GetTokenRotations retrieves token rotation history of the platform
+	GetTokenRotations(ctx context.Context, platformId string) ([]*TokenRotation, error)
+	// IssueOnboardingToken generates a new token A, the previous token A stops working
+	IssueOnboardingToken(ctx context.Context, platformId string, token *OnboardingToken) (*Platform, error)
+	// RevokeOnboardingToken revokes token A
+	RevokeOnboardingToken(ctx context.Context, platformId string) (*Platform, error)
+	// ConsumeOnboardingToken marks token A as used for handshake, it must be called before handshake
+	// single use token is consumed atomically, so that only one handshake succeeds
+	ConsumeOnboardingToken(ctx context.Context, platformId string) (*Platform, error)
+	// OnboardingTokenStatus calculates status of the onboarding token
+	OnboardingTokenStatus(token *OnboardingToken) string
+	// SetHealth sets health state of the platform
+	SetHealth(ctx context.Context, platformId string, health *PlatformHealth) (*Platform, error)
+	// SetDataCleanup sets result of data cleanup on disconnection
+	SetDataCleanup(ctx context.Context, platformId string, cleanup *PlatformDataCleanup) (*Platform, error)
 }
 
 type LocalPlatformService interface {
@@ -163,4 +346,14 @@ type PlatformStorage interface {
 	GetPlatformByTokenC(ctx context.Context, token PlatformToken) (*Platform, error)
 	// SearchPlatforms searches platforms by criteria
 	SearchPlatforms(ctx context.Context, cr *PlatformSearchCriteria) ([]*Platform, error)
+	// GetPlatformByPrevTokenB retrieves platform by previous token B which hasn't expired yet
+	GetPlatformByPrevTokenB(ctx context.Context, token PlatformToken) (*Platform, error)
+	// CreateTokenRotation creates token rotation history item
+	CreateTokenRotation(ctx context.Context, rotation *TokenRotation) error
+	// GetTokenRotations retrieves token rotation history of the platform
+	GetTokenRotations(ctx context.Context, platformId string) ([]*TokenRotation, error)
+	// EncryptTokens encrypts plain tokens and re-encrypts tokens with the current key, returns number of updated platforms
+	EncryptTokens(ctx context.Context) (int, error)
+	// ConsumeOnboardingToken marks token A as consumed if it hasn't been consumed yet, returns false if it's already consumed
+	ConsumeOnboardingToken(ctx context.Context, platformId string, consumedAt time.Time) (bool, error)
 }
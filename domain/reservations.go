@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ReservationStatusActive    = "ACTIVE"    // ReservationStatusActive reservation is accepted by the charge point
+	ReservationStatusCancelled = "CANCELLED" // ReservationStatusCancelled reservation is cancelled before it's used
+	ReservationStatusExpired   = "EXPIRED"   // ReservationStatusExpired no session started until the expiry date
+	ReservationStatusUsed      = "USED"      // ReservationStatusUsed session started with the reserved token
+)
+
+type ReservationDetails struct {
+	TokenId     string    `json:"tokenId"`               // TokenId token the reservation is made for
+	LocationId  string    `json:"locationId"`            // LocationId reserved location
+	EvseId      string    `json:"evseId,omitempty"`      // EvseId reserved EVSE, empty if any EVSE of the location can be used
+	ConnectorId string    `json:"connectorId,omitempty"` // ConnectorId reserved connector
+	ExpireDate  time.Time `json:"expireDate"`            // ExpireDate when the reservation ends
+	AuthRef     string    `json:"authRef,omitempty"`     // AuthRef authorization reference
+	CommandId   string    `json:"commandId"`             // CommandId RESERVE_NOW command the reservation is created by
+	SessionId   string    `json:"sessionId,omitempty"`   // SessionId session the reservation is used by
+}
+
+// ChargeReservation reservation of a location or EVSE made by RESERVE_NOW command
+type ChargeReservation struct {
+	OcpiItem
+	Id            string             `json:"id"`            // Id internal reservation id
+	ReservationId string             `json:"reservationId"` // ReservationId reservation id given by eMSP, unique within the party of the platform
+	Status        string             `json:"status"`        // Status reservation status
+	Details       ReservationDetails `json:"details"`       // Details reservation details
+}
+
+type ReservationSearchCriteria struct {
+	PageRequest
+	ExtId         *PartyExtId // ExtId by party ext ID
+	RefId         string      // RefId by ref id
+	IncPlatforms  []string    // IncPlatforms includes platform Ids
+	ExcPlatforms  []string    // ExcPlatforms exclude platform Ids
+	Ids           []string    // Ids by list of Ids
+	ReservationId string      // ReservationId by reservation id given by eMSP
+	Statuses      []string    // Statuses by list of statuses
+	TokenId       string      // TokenId by token
+	LocationId    string      // LocationId by location
+	EvseId        string      // EvseId by EVSE
+	SessionId     string      // SessionId by session
+	ExpireDateLE  *time.Time  // ExpireDateLE expire date less or equal
+}
+
+type ReservationSearchResponse struct {
+	PageResponse
+	Items []*ChargeReservation
+}
+
+type ReservationService interface {
+	// Create creates an active reservation, reservation with the same reservation id of the party is replaced
+	Create(ctx context.Context, r *ChargeReservation) (*ChargeReservation, error)
+	// SetStatus changes status of the active reservation, sessionId is required when reservation is used
+	SetStatus(ctx context.Context, id, status, sessionId string) (*ChargeReservation, error)
+	// Get retrieves reservation by ID
+	Get(ctx context.Context, id string) (*ChargeReservation, error)
+	// GetByReservationId retrieves reservation by reservation id given by the party of the platform
+	GetByReservationId(ctx context.Context, platformId string, extId PartyExtId, reservationId string) (*ChargeReservation, error)
+	// Search searches reservations
+	Search(ctx context.Context, cr *ReservationSearchCriteria) (*ReservationSearchResponse, error)
+}
+
+type ReservationStorage interface {
+	// MergeReservation creates or updates reservation
+	MergeReservation(ctx context.Context, r *ChargeReservation) error
+	// UpdateReservation updates reservation
+	UpdateReservation(ctx context.Context, r *ChargeReservation) error
+	// GetReservation retrieves reservation by ID
+	GetReservation(ctx context.Context, id string) (*ChargeReservation, error)
+	// GetReservationByExtId retrieves reservation by reservation id given by the party of the platform
+	GetReservationByExtId(ctx context.Context, platformId string, extId PartyExtId, reservationId string) (*ChargeReservation, error)
+	// SearchReservations searches reservations
+	SearchReservations(ctx context.Context, cr *ReservationSearchCriteria) (*ReservationSearchResponse, error)
+}
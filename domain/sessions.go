@@ -16,6 +16,9 @@ const (
 	SessionStatusPending     = "PENDING"
 	SessionStatusReservation = "RESERVATION"
 
+	SessionLimitKwh  = "KWH"  // SessionLimitKwh limit of charged energy
+	SessionLimitCost = "COST" // SessionLimitCost limit of the session total cost
+
 	DimensionTypeCurrent         = "CURRENT"
 	DimensionTypeEnergy          = "ENERGY"
 	DimensionTypeEnergyExport    = "ENERGY_EXPORT"
@@ -60,20 +63,37 @@ type ChargingPeriod struct {
 	Url                   string          `json:"url,omitempty"`                   // Url that can be shown to an EV driver
 }
 
+// SessionLimits limits of a charging session, session is stopped as soon as any of them is reached
+type SessionLimits struct {
+	Kwh      *float64 `json:"kwh,omitempty"`      // Kwh max charged energy in kWh
+	Cost     *float64 `json:"cost,omitempty"`     // Cost max total cost, including VAT if it's given
+	Currency string   `json:"currency,omitempty"` // Currency ISO-4217 currency code of the cost limit, it's applied only to sessions in this currency
+}
+
+// SessionStopReason why the session was stopped by the platform
+type SessionStopReason struct {
+	Limit     string    `json:"limit"`               // Limit reached limit
+	Value     float64   `json:"value"`               // Value of the limit
+	Actual    float64   `json:"actual"`              // Actual value reached the limit
+	CommandId string    `json:"commandId,omitempty"` // CommandId STOP_SESSION command issued to stop the session
+	At        time.Time `json:"at"`                  // At when the limit was detected to be reached
+}
+
 type SessionDetails struct {
-	StartDateTime *time.Time `json:"startDateTime"`       // StartDateTime timestamp when the session became ACTIVE
-	EndDateTime   *time.Time `json:"endDateTime"`         // EndDateTime timestamp when the session was completed/finished
-	Kwh           *float64   `json:"kwh"`                 // Kwh how many kWh were charged
-	CdrToken      *CdrToken  `json:"cdrToken"`            // CdrToken token used to start this charging session
-	AuthMethod    string     `json:"authMethod"`          // AuthMethod method used for authentication
-	AuthRef       string     `json:"authRef,omitempty"`   // AuthRef reference to the authorization given by the eMSP
-	LocationId    string     `json:"locationId"`          // LocationId id of the location obj
-	EvseId        string     `json:"evseId"`              // EvseId id of the evse obj
-	ConnectorId   string     `json:"connectorId"`         // ConnectorId id of the connector
-	MeterId       string     `json:"meterId,omitempty"`   // MeterId id of the kWh meter
-	Currency      string     `json:"currency"`            // Currency ISO-4217 currency code
-	TotalCost     *Price     `json:"totalCost,omitempty"` // TotalCost total cost of the session in the specified currency
-	Status        string     `json:"status"`              // Status session status
+	StartDateTime *time.Time         `json:"startDateTime"`        // StartDateTime timestamp when the session became ACTIVE
+	EndDateTime   *time.Time         `json:"endDateTime"`          // EndDateTime timestamp when the session was completed/finished
+	Kwh           *float64           `json:"kwh"`                  // Kwh how many kWh were charged
+	CdrToken      *CdrToken          `json:"cdrToken"`             // CdrToken token used to start this charging session
+	AuthMethod    string             `json:"authMethod"`           // AuthMethod method used for authentication
+	AuthRef       string             `json:"authRef,omitempty"`    // AuthRef reference to the authorization given by the eMSP
+	LocationId    string             `json:"locationId"`           // LocationId id of the location obj
+	EvseId        string             `json:"evseId"`               // EvseId id of the evse obj
+	ConnectorId   string             `json:"connectorId"`          // ConnectorId id of the connector
+	MeterId       string             `json:"meterId,omitempty"`    // MeterId id of the kWh meter
+	Currency      string             `json:"currency"`             // Currency ISO-4217 currency code
+	TotalCost     *Price             `json:"totalCost,omitempty"`  // TotalCost total cost of the session in the specified currency
+	Status        string             `json:"status"`               // Status session status
+	StopReason    *SessionStopReason `json:"stopReason,omitempty"` // StopReason set if the session was stopped by the platform
 }
 
 type Session struct {
@@ -91,6 +111,10 @@ type SessionSearchCriteria struct {
 	ExcPlatforms        []string    // ExcPlatforms exclude platform Ids
 	Ids                 []string    // Ids by list of Ids
 	AuthRef             string      // AuthRef by auth ref
+	TokenId             string      // TokenId by id of the token used to start the session
+	TokenExtId          *PartyExtId // TokenExtId by party ext ID of the token used to start the session
+	Statuses            []string    // Statuses by session statuses
+	WithoutCdr          bool        // WithoutCdr retrieves only sessions without CDR
 	WithChargingPeriods bool        // WithChargingPeriods if true, retrieve charging periods for ech item
 }
 
@@ -99,17 +123,32 @@ type SessionSearchResponse struct {
 	Items []*Session
 }
 
+// SessionIssueReport sessions of a partner requiring attention
+type SessionIssueReport struct {
+	PartyExtId
+	PlatformId string   // PlatformId partner's platform
+	Stale      []string // Stale ids of ACTIVE/PENDING sessions without updates for too long
+	MissingCdr []string // MissingCdr ids of COMPLETED sessions without CDR for too long
+}
+
 type SessionService interface {
 	// PutSession creates or updates session
 	PutSession(ctx context.Context, sess *Session) (*Session, error)
+	// PutSessions creates or updates sessions with charging periods in a single transaction
+	// rejected items are reported in results, the rest are stored, returns stored sessions and results in order of request
+	PutSessions(ctx context.Context, sessions []*Session) ([]*Session, []*BulkItemResult, error)
 	// MergeSession merges session
 	MergeSession(ctx context.Context, sess *Session) (*Session, error)
 	// GetSession retrieves session by ID
 	GetSession(ctx context.Context, sessId string) (*Session, error)
 	// GetSessionWithPeriods retrieves session by ID with periods
 	GetSessionWithPeriods(ctx context.Context, sessId string) (*Session, error)
+	// SetStopReason records why the session is stopped by the platform, last_updated isn't changed as the session isn't changed by its owner
+	SetStopReason(ctx context.Context, sessId string, reason *SessionStopReason) (*Session, error)
 	// DeleteSessionsByExtId deletes all sessions by party ext id
 	DeleteSessionsByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteSessionsByPlatform deletes all sessions of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteSessionsByPlatform(ctx context.Context, platformId string, archive bool) error
 	// SearchSessions searches sessions
 	SearchSessions(ctx context.Context, cr *SessionSearchCriteria) (*SessionSearchResponse, error)
 }
@@ -117,12 +156,17 @@ type SessionService interface {
 type SessionStorage interface {
 	// MergeSession creates or updates session
 	MergeSession(ctx context.Context, sess *Session) error
+	// MergeSessions creates or updates sessions and replaces their charging periods in a single transaction
+	// rows are upserted in batches, stored rows are updated only if they aren't later than merged ones
+	MergeSessions(ctx context.Context, sessions []*Session) error
 	// UpdateSession updates session
 	UpdateSession(ctx context.Context, sess *Session) error
 	// GetSession retrieves session by ID
 	GetSession(ctx context.Context, sessId string, withChargingPeriods bool) (*Session, error)
 	// DeleteSessionsByExtId deletes all sessions by party ext id
 	DeleteSessionsByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteSessionsByPlatform deletes all sessions of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteSessionsByPlatform(ctx context.Context, platformId string, archive bool) error
 	// SearchSessions searches sessions
 	SearchSessions(ctx context.Context, cr *SessionSearchCriteria) (*SessionSearchResponse, error)
 	// CreateChargingPeriods creates new entries for charging periods
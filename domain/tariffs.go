@@ -12,6 +12,10 @@ const (
 	TariffTypeProfileGreen = "PROFILE_GREEN"
 	TariffTypeReg          = "REGULAR"
 
+	TaxIncludedYes           = "YES"
+	TaxIncludedNo            = "NO"
+	TaxIncludedNotApplicable = "N/A"
+
 	TariffDimEnergy      = "ENERGY"
 	TariffDimFlat        = "FLAT"
 	TariffDimParkingType = "PARKING_TIME"
@@ -34,6 +38,13 @@ type Price struct {
 	InclVat *float64 `json:"incl_vat,omitempty"` // InclVat Price/Cost including VAT
 }
 
+type TaxAmount struct {
+	Name          string   `json:"name"`                    // Name of the tax
+	AccountNumber string   `json:"accountNumber,omitempty"` // AccountNumber tax account number of the party
+	Percentage    *float64 `json:"percentage,omitempty"`    // Percentage of the tax
+	Amount        float64  `json:"amount"`                  // Amount of the tax
+}
+
 type TariffRestrictions struct {
 	StartTime   string     `json:"startTime,omitempty"`   // StartTime time of day in local time
 	EndTime     string     `json:"endTime,omitempty"`     // EndTime time of day in local time
@@ -64,16 +75,18 @@ type TariffElement struct {
 }
 
 type TariffDetails struct {
-	Currency      string           `json:"currency"`                // Currency ISO-4217 code
-	Type          string           `json:"type,omitempty"`          // Type of the tariff
-	TariffAltText []*DisplayText   `json:"tariffAltText,omitempty"` // TariffAltText list of multi-language alternative tariff info texts
-	TariffAltUrl  string           `json:"tariffAltUrl,omitempty"`  // TariffAltUrl web page that contains an explanation of the tariff
-	MinPrice      *Price           `json:"minPrice,omitempty"`      // MinPrice minimum possible price
-	MaxPrice      *Price           `json:"maxPrice,omitempty"`      // MaxPrice maximum possible price
-	Elements      []*TariffElement `json:"elements"`                // Elements list of tariff elements
-	StartDateTime *time.Time       `json:"startDateTime,omitempty"` // StartDateTime when this tariff becomes active in UTC
-	EndDateTime   *time.Time       `json:"endDateTime,omitempty"`   // EndDateTime when this tariff no longer valid in UTC
-	EnergyMix     *EnergyMix       `json:"energyMix,omitempty"`     // EnergyMix details on the energy supplied with this tariff
+	Currency           string           `json:"currency"`                     // Currency ISO-4217 code
+	Type               string           `json:"type,omitempty"`               // Type of the tariff
+	TariffAltText      []*DisplayText   `json:"tariffAltText,omitempty"`      // TariffAltText list of multi-language alternative tariff info texts
+	TariffAltUrl       string           `json:"tariffAltUrl,omitempty"`       // TariffAltUrl web page that contains an explanation of the tariff
+	MinPrice           *Price           `json:"minPrice,omitempty"`           // MinPrice minimum possible price
+	MaxPrice           *Price           `json:"maxPrice,omitempty"`           // MaxPrice maximum possible price
+	Elements           []*TariffElement `json:"elements"`                     // Elements list of tariff elements
+	StartDateTime      *time.Time       `json:"startDateTime,omitempty"`      // StartDateTime when this tariff becomes active in UTC
+	EndDateTime        *time.Time       `json:"endDateTime,omitempty"`        // EndDateTime when this tariff no longer valid in UTC
+	EnergyMix          *EnergyMix       `json:"energyMix,omitempty"`          // EnergyMix details on the energy supplied with this tariff
+	PreauthorizeAmount *Price           `json:"preauthorizeAmount,omitempty"` // PreauthorizeAmount amount to be pre-authorized on a payment terminal for ad hoc payment
+	TaxIncluded        string           `json:"taxIncluded,omitempty"`        // TaxIncluded whether taxes are included in the prices of the tariff
 }
 
 type Tariff struct {
@@ -99,12 +112,17 @@ type TariffSearchResponse struct {
 type TariffService interface {
 	// PutTariff creates or updates tariff
 	PutTariff(ctx context.Context, trf *Tariff) (*Tariff, error)
+	// PutTariffs creates or updates tariffs in a single transaction
+	// rejected items are reported in results, the rest are stored, returns stored tariffs and results in order of request
+	PutTariffs(ctx context.Context, trfs []*Tariff) ([]*Tariff, []*BulkItemResult, error)
 	// MergeTariff merges tariff
 	MergeTariff(ctx context.Context, trf *Tariff) (*Tariff, error)
 	// GetTariff retrieves tariff by ID
 	GetTariff(ctx context.Context, trfId string) (*Tariff, error)
 	// DeleteTariffsByExtId deletes all tariffs by party ext id
 	DeleteTariffsByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteTariffsByPlatform deletes all tariffs of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteTariffsByPlatform(ctx context.Context, platformId string, archive bool) error
 	// SearchTariffs searches tariffs
 	SearchTariffs(ctx context.Context, cr *TariffSearchCriteria) (*TariffSearchResponse, error)
 	// Validate validates tariffs
@@ -114,12 +132,17 @@ type TariffService interface {
 type TariffStorage interface {
 	// MergeTariff creates or updates tariff
 	MergeTariff(ctx context.Context, trf *Tariff) error
+	// MergeTariffs creates or updates tariffs in a single transaction
+	// rows are upserted in batches, stored rows are updated only if they aren't later than merged ones
+	MergeTariffs(ctx context.Context, trfs []*Tariff) error
 	// UpdateTariff updates tariff
 	UpdateTariff(ctx context.Context, trf *Tariff) error
 	// GetTariff retrieves tariff by ID
 	GetTariff(ctx context.Context, trfId string) (*Tariff, error)
 	// DeleteTariffsByExtId deletes all tariffs by party ext id
 	DeleteTariffsByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteTariffsByPlatform deletes all tariffs of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteTariffsByPlatform(ctx context.Context, platformId string, archive bool) error
 	// SearchTariffs searches tariffs
 	SearchTariffs(ctx context.Context, cr *TariffSearchCriteria) (*TariffSearchResponse, error)
 }
@@ -35,6 +35,7 @@ type TokenDetails struct {
 	Lang               string          `json:"language,omitempty"`           // Lang code ISO 639-1
 	DefaultProfileType string          `json:"defaultProfileType,omitempty"` // DefaultProfileType default Charging Preference
 	EnergyContract     *EnergyContract `json:"energyContract,omitempty"`     // EnergyContract energy supplier/contract
+	SessionLimits      *SessionLimits  `json:"sessionLimits,omitempty"`      // SessionLimits limits applied to every session of the token, enforced by the platform
 }
 
 type Token struct {
@@ -60,6 +61,9 @@ type TokenSearchResponse struct {
 type TokenService interface {
 	// PutToken creates or updates Token
 	PutToken(ctx context.Context, tkn *Token) (*Token, error)
+	// PutTokens creates or updates tokens in a single transaction
+	// rejected items are reported in results, the rest are stored, returns stored tokens and results in order of request
+	PutTokens(ctx context.Context, tkns []*Token) ([]*Token, []*BulkItemResult, error)
 	// MergeToken merges Token
 	MergeToken(ctx context.Context, tkn *Token) (*Token, error)
 	// GetToken retrieves Token by ID
@@ -68,6 +72,8 @@ type TokenService interface {
 	SearchTokens(ctx context.Context, cr *TokenSearchCriteria) (*TokenSearchResponse, error)
 	// DeleteTokensByExtId deletes all tokens by party ext id
 	DeleteTokensByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteTokensByPlatform deletes all tokens of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteTokensByPlatform(ctx context.Context, platformId string, archive bool) error
 	// ValidateToken validate token
 	ValidateToken(ctx context.Context, tkn *Token) error
 }
@@ -75,12 +81,17 @@ type TokenService interface {
 type TokenStorage interface {
 	// MergeToken creates or updates Token
 	MergeToken(ctx context.Context, tkn *Token) error
+	// MergeTokens creates or updates tokens in a single transaction
+	// rows are upserted in batches, stored rows are updated only if they aren't later than merged ones
+	MergeTokens(ctx context.Context, tkns []*Token) error
 	// UpdateToken updates Token
 	UpdateToken(ctx context.Context, tkn *Token) error
 	// GetToken retrieves Token by ID
 	GetToken(ctx context.Context, tknId string) (*Token, error)
 	// DeleteTokensByExtId deletes all tokens by party ext id
 	DeleteTokensByExtId(ctx context.Context, extId PartyExtId) error
+	// DeleteTokensByPlatform deletes all tokens of the platform. If archive, items are marked as deleted and kept in storage
+	DeleteTokensByPlatform(ctx context.Context, platformId string, archive bool) error
 	// SearchTokens searches Tokens
 	SearchTokens(ctx context.Context, cr *TokenSearchCriteria) (*TokenSearchResponse, error)
 }
@@ -181,4 +181,75 @@ const (
 	ErrCodeCmdCancelReservationNotFound        = "OCPI-193"
 	ErrCodeCmdCancelReservationInvalidPlatform = "OCPI-194"
 	ErrCodeCmdReservationIdAlreadyExists       = "OCPI-195"
+	ErrCodeVersionNotSupported                 = "OCPI-196"
+	ErrCodePlatformPartyNotFound               = "OCPI-197"
+	ErrCodeTerminalIdEmpty                     = "OCPI-198"
+	ErrCodeTerminalNotFound                    = "OCPI-199"
+	ErrCodeTerminalEmptyAttr                   = "OCPI-200"
+	ErrCodeTerminalInvalidAttr                 = "OCPI-201"
+	ErrCodeTerminalNotBelongRemotePlatform     = "OCPI-202"
+	ErrCodeFinAdviceIdEmpty                    = "OCPI-203"
+	ErrCodeFinAdviceNotFound                   = "OCPI-204"
+	ErrCodeFinAdviceEmptyAttr                  = "OCPI-205"
+	ErrCodeFinAdviceInvalidAttr                = "OCPI-206"
+	ErrCodePaymentStorageGet                   = "OCPI-207"
+	ErrCodePaymentStorageMerge                 = "OCPI-208"
+	ErrCodePaymentStorageUpdate                = "OCPI-209"
+	ErrCodeTknStorageDelete                    = "OCPI-210"
+	ErrCodePlatformDataPolicyInvalid           = "OCPI-211"
+	ErrCodePlatformTokenRotationInvalid        = "OCPI-212"
+	ErrCodeCryptoKeyInvalid                    = "OCPI-213"
+	ErrCodeCryptoCurrentKeyNotFound            = "OCPI-214"
+	ErrCodeCryptoEncrypt                       = "OCPI-215"
+	ErrCodeCryptoDecrypt                       = "OCPI-216"
+	ErrCodeOnboardingTokenNotFound             = "OCPI-217"
+	ErrCodeOnboardingTokenRoleMismatch         = "OCPI-218"
+	ErrCodeOnboardingTokenRoleInvalid          = "OCPI-219"
+	ErrCodePlatformClientSettingsInvalid       = "OCPI-220"
+	ErrCodeOcpiRestCircuitOpen                 = "OCPI-221"
+	ErrCodeOcpiRestConcurrencyLimit            = "OCPI-222"
+	ErrCodePlatformInboundLimitsInvalid        = "OCPI-223"
+	ErrCodeRateLimitExceeded                   = "OCPI-224"
+	ErrCodeDailyQuotaExceeded                  = "OCPI-225"
+	ErrCodeBulkEmpty                           = "OCPI-226"
+	ErrCodeBulkTooLarge                        = "OCPI-227"
+	ErrCodeBulkDuplicateItem                   = "OCPI-228"
+	ErrCodePageCursorInvalid                   = "OCPI-229"
+	ErrCodeSessStatusTransitionInvalid         = "OCPI-230"
+	ErrCodeSessStatusAttrEmpty                 = "OCPI-231"
+	ErrCodePlatformValidationInvalid           = "OCPI-232"
+	ErrCodeReservationNotFound                 = "OCPI-233"
+	ErrCodeReservationStatusInvalid            = "OCPI-234"
+	ErrCodeReservationEmptyAttr                = "OCPI-235"
+	ErrCodeReservationInvalidAttr              = "OCPI-236"
+	ErrCodeReservationStorageGet               = "OCPI-237"
+	ErrCodeReservationStorageMerge             = "OCPI-238"
+	ErrCodeReservationStorageUpdate            = "OCPI-239"
+	ErrCodeCmdEvseNotFound                     = "OCPI-240"
+	ErrCodeCmdEvseNotCapable                   = "OCPI-241"
+	ErrCodeCmdEvseNotAvailable                 = "OCPI-242"
+	ErrCodeCmdConnectorRequired                = "OCPI-243"
+	ErrCodeCmdNoAvailableEvse                  = "OCPI-244"
+	ErrCodeCmdSelectionStrategyInvalid         = "OCPI-245"
+	ErrCodeSessTokenBlockPolicyInvalid         = "OCPI-246"
+	ErrCodeCdrInconsistent                     = "OCPI-247"
+	ErrCodeDisputeIdEmpty                      = "OCPI-248"
+	ErrCodeDisputeNotFound                     = "OCPI-249"
+	ErrCodeDisputeStatusInvalid                = "OCPI-250"
+	ErrCodeDisputeEmptyAttr                    = "OCPI-251"
+	ErrCodeDisputeInvalidAttr                  = "OCPI-252"
+	ErrCodeDisputeAlreadyOpen                  = "OCPI-253"
+	ErrCodeDisputeCdrInvalidPlatform           = "OCPI-254"
+	ErrCodeDisputeStorageGet                   = "OCPI-255"
+	ErrCodeDisputeStorageMerge                 = "OCPI-256"
+	ErrCodeDisputeStorageUpdate                = "OCPI-257"
+	ErrCodeDisputeCdrNotFound                  = "OCPI-258"
+	ErrCodeCdrCreditNotFound                   = "OCPI-259"
+	ErrCodeCdrCreditOfCredit                   = "OCPI-260"
+	ErrCodeCdrCreditExceeded                   = "OCPI-261"
+	ErrCodeCdrCreditInvalidPlatform            = "OCPI-262"
+	ErrCodeCryptoTokenNotEncrypted             = "OCPI-263"
+	ErrCodeOnboardingTokenConsumed             = "OCPI-264"
+	ErrCodeSessChargingPrefNotSupported        = "OCPI-265"
+	ErrCodeCdrCreditIdExists                   = "OCPI-266"
 )
@@ -551,4 +551,217 @@ var (
 	ErrCmdReservationIdAlreadyExists = func(ctx context.Context, reservationId string) error {
 		return kit.NewAppErrBuilder(ErrCodeCmdReservationIdAlreadyExists, "reservation: already exists reservation_id (%s)", reservationId).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Business().C(ctx).Err()
 	}
+	ErrVersionNotSupported = func(ctx context.Context, version, feature string) error {
+		return kit.NewAppErrBuilder(ErrCodeVersionNotSupported, "%s isn't supported by version %s", feature, version).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusUnsupportedVersionError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPlatformPartyNotFound = func(ctx context.Context, platformId string) error {
+		return kit.NewAppErrBuilder(ErrCodePlatformPartyNotFound, "no party found for platform (%s)", platformId).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrTerminalIdEmpty = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeTerminalIdEmpty, "terminal id is empty").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrTerminalNotFound = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeTerminalNotFound, "terminal not found").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusNotFound).Err()
+	}
+	ErrTerminalEmptyAttr = func(ctx context.Context, entity, attr string) error {
+		return kit.NewAppErrBuilder(ErrCodeTerminalEmptyAttr, "empty terminal attribute: %s.%s", entity, attr).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrTerminalInvalidAttr = func(ctx context.Context, entity, attr string) error {
+		return kit.NewAppErrBuilder(ErrCodeTerminalInvalidAttr, "invalid terminal attribute: %s.%s", entity, attr).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrTerminalNotBelongRemotePlatform = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeTerminalNotBelongRemotePlatform, "terminal doesn't belong to remote platform").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrFinAdviceIdEmpty = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeFinAdviceIdEmpty, "financial advice confirmation id is empty").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrFinAdviceNotFound = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeFinAdviceNotFound, "financial advice confirmation not found").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusNotFound).Err()
+	}
+	ErrFinAdviceEmptyAttr = func(ctx context.Context, entity, attr string) error {
+		return kit.NewAppErrBuilder(ErrCodeFinAdviceEmptyAttr, "empty financial advice confirmation attribute: %s.%s", entity, attr).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrFinAdviceInvalidAttr = func(ctx context.Context, entity, attr string) error {
+		return kit.NewAppErrBuilder(ErrCodeFinAdviceInvalidAttr, "invalid financial advice confirmation attribute: %s.%s", entity, attr).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPaymentStorageGet = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodePaymentStorageGet, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPaymentStorageMerge = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodePaymentStorageMerge, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPaymentStorageUpdate = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodePaymentStorageUpdate, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrTknStorageDelete = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodeTknStorageDelete, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPlatformDataPolicyInvalid = func(ctx context.Context, policy string) error {
+		return kit.NewAppErrBuilder(ErrCodePlatformDataPolicyInvalid, "invalid platform data policy: %s", policy).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPlatformTokenRotationInvalid = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodePlatformTokenRotationInvalid, "invalid token rotation policy").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCryptoKeyInvalid = func(ctx context.Context, keyId string) error {
+		return kit.NewAppErrBuilder(ErrCodeCryptoKeyInvalid, "invalid crypto key: %s", keyId).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCryptoCurrentKeyNotFound = func(ctx context.Context, keyId string) error {
+		return kit.NewAppErrBuilder(ErrCodeCryptoCurrentKeyNotFound, "current crypto key not found: %s (platform tokens are encrypted at rest, set OCPI_CRYPTO_KEY or OCPI_CRYPTO_KEY_FILE)", keyId).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCryptoEncrypt = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodeCryptoEncrypt, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCryptoDecrypt = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodeCryptoDecrypt, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCryptoTokenNotEncrypted = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeCryptoTokenNotEncrypted, "token isn't encrypted with any of configured keys").C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrOnboardingTokenNotFound = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeOnboardingTokenNotFound, "onboarding token not found").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusNotFound).Err()
+	}
+	ErrOnboardingTokenRoleMismatch = func(ctx context.Context, role string) error {
+		return kit.NewAppErrBuilder(ErrCodeOnboardingTokenRoleMismatch, "onboarding token is issued for another role: %s", role).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrOnboardingTokenConsumed = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeOnboardingTokenConsumed, "onboarding token has already been used").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrOnboardingTokenRoleInvalid = func(ctx context.Context, role string) error {
+		return kit.NewAppErrBuilder(ErrCodeOnboardingTokenRoleInvalid, "invalid onboarding token role: %s", role).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPlatformClientSettingsInvalid = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodePlatformClientSettingsInvalid, "invalid client settings").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrOcpiRestCircuitOpen = func(ctx context.Context, platformId string) error {
+		return kit.NewAppErrBuilder(ErrCodeOcpiRestCircuitOpen, "circuit breaker is open").C(ctx).F(kit.KV{"platformId": platformId}).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrOcpiRestConcurrencyLimit = func(ctx context.Context, platformId string) error {
+		return kit.NewAppErrBuilder(ErrCodeOcpiRestConcurrencyLimit, "concurrency limit reached").C(ctx).F(kit.KV{"platformId": platformId}).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPlatformInboundLimitsInvalid = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodePlatformInboundLimitsInvalid, "invalid inbound limits").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrRateLimitExceeded = func(ctx context.Context, route string) error {
+		return kit.NewAppErrBuilder(ErrCodeRateLimitExceeded, "rate limit exceeded").Business().C(ctx).F(kit.KV{"route": route}).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusTooManyRequests).Err()
+	}
+	ErrDailyQuotaExceeded = func(ctx context.Context, route string) error {
+		return kit.NewAppErrBuilder(ErrCodeDailyQuotaExceeded, "daily quota exceeded").Business().C(ctx).F(kit.KV{"route": route}).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusTooManyRequests).Err()
+	}
+	ErrBulkEmpty = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeBulkEmpty, "bulk request is empty").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrBulkTooLarge = func(ctx context.Context, max int) error {
+		return kit.NewAppErrBuilder(ErrCodeBulkTooLarge, "bulk request exceeds %d items", max).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrBulkDuplicateItem = func(ctx context.Context, id string) error {
+		return kit.NewAppErrBuilder(ErrCodeBulkDuplicateItem, "duplicate item in bulk request: %s", id).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPageCursorInvalid = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodePageCursorInvalid, "invalid page cursor").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrSessStatusTransitionInvalid = func(ctx context.Context, from, to string) error {
+		return kit.NewAppErrBuilder(ErrCodeSessStatusTransitionInvalid, "session status cannot be changed from %s to %s", from, to).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrSessStatusAttrEmpty = func(ctx context.Context, status, attr string) error {
+		return kit.NewAppErrBuilder(ErrCodeSessStatusAttrEmpty, "session attribute %s is mandatory in status %s", attr, status).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrPlatformValidationInvalid = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodePlatformValidationInvalid, "invalid validation mode").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrReservationNotFound = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeReservationNotFound, "reservation not found").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusNotFound).Err()
+	}
+	ErrReservationStatusInvalid = func(ctx context.Context, from, to string) error {
+		return kit.NewAppErrBuilder(ErrCodeReservationStatusInvalid, "reservation status cannot be changed from %s to %s", from, to).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrReservationEmptyAttr = func(ctx context.Context, entity, attr string) error {
+		return kit.NewAppErrBuilder(ErrCodeReservationEmptyAttr, "empty reservation attribute: %s.%s", entity, attr).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrReservationInvalidAttr = func(ctx context.Context, entity, attr string) error {
+		return kit.NewAppErrBuilder(ErrCodeReservationInvalidAttr, "invalid reservation attribute: %s.%s", entity, attr).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrReservationStorageGet = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodeReservationStorageGet, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrReservationStorageMerge = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodeReservationStorageMerge, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrReservationStorageUpdate = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodeReservationStorageUpdate, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCmdEvseNotFound = func(ctx context.Context, evseId string) error {
+		return kit.NewAppErrBuilder(ErrCodeCmdEvseNotFound, "evse not found (%s)", evseId).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusUnknownLocationError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCmdEvseNotCapable = func(ctx context.Context, evseId, capability string) error {
+		return kit.NewAppErrBuilder(ErrCodeCmdEvseNotCapable, "evse (%s) doesn't support %s", evseId, capability).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCmdEvseNotAvailable = func(ctx context.Context, evseId, status string) error {
+		return kit.NewAppErrBuilder(ErrCodeCmdEvseNotAvailable, "evse (%s) isn't available in status %s", evseId, status).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCmdConnectorRequired = func(ctx context.Context, evseId string) error {
+		return kit.NewAppErrBuilder(ErrCodeCmdConnectorRequired, "evse (%s) requires connector to start session", evseId).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCmdNoAvailableEvse = func(ctx context.Context, locationId string) error {
+		return kit.NewAppErrBuilder(ErrCodeCmdNoAvailableEvse, "no available evse found on location (%s)", locationId).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCmdSelectionStrategyInvalid = func(ctx context.Context, strategy string) error {
+		return kit.NewAppErrBuilder(ErrCodeCmdSelectionStrategyInvalid, "invalid connector selection strategy: %s", strategy).C(ctx).Err()
+	}
+	ErrSessTokenBlockPolicyInvalid = func(ctx context.Context, policy string) error {
+		return kit.NewAppErrBuilder(ErrCodeSessTokenBlockPolicyInvalid, "invalid token block policy: %s", policy).C(ctx).Err()
+	}
+	ErrSessChargingPrefNotSupported = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeSessChargingPrefNotSupported, "charging preferences aren't supported").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenClientError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCdrInconsistent = func(ctx context.Context, issue string) error {
+		return kit.NewAppErrBuilder(ErrCodeCdrInconsistent, "cdr inconsistent: %s", issue).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCdrCreditNotFound = func(ctx context.Context, cdrId string) error {
+		return kit.NewAppErrBuilder(ErrCodeCdrCreditNotFound, "credited cdr not found: %s", cdrId).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusNotFound).Err()
+	}
+	ErrCdrCreditOfCredit = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeCdrCreditOfCredit, "credit cdr cannot be credited").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCdrCreditExceeded = func(ctx context.Context, amount, remaining float64) error {
+		return kit.NewAppErrBuilder(ErrCodeCdrCreditExceeded, "credit amount %.2f exceeds remaining creditable amount %.2f", amount, remaining).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCdrCreditInvalidPlatform = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeCdrCreditInvalidPlatform, "only cdr of the local platform can be credited").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrCdrCreditIdExists = func(ctx context.Context, creditId string) error {
+		return kit.NewAppErrBuilder(ErrCodeCdrCreditIdExists, "cdr with credit id already exists: %s", creditId).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrDisputeIdEmpty = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeIdEmpty, "dispute id empty").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrDisputeNotFound = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeNotFound, "dispute not found").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusNotFound).Err()
+	}
+	ErrDisputeStatusInvalid = func(ctx context.Context, from, to string) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeStatusInvalid, "dispute status cannot be changed from %s to %s", from, to).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrDisputeEmptyAttr = func(ctx context.Context, entity, attr string) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeEmptyAttr, "empty dispute attribute: %s.%s", entity, attr).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrDisputeInvalidAttr = func(ctx context.Context, entity, attr string) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeInvalidAttr, "invalid dispute attribute: %s.%s", entity, attr).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrDisputeAlreadyOpen = func(ctx context.Context, cdrId string) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeAlreadyOpen, "cdr already disputed: %s", cdrId).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrDisputeCdrInvalidPlatform = func(ctx context.Context) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeCdrInvalidPlatform, "only cdr of a remote platform can be disputed").Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrDisputeCdrNotFound = func(ctx context.Context, cdrId string) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeCdrNotFound, "disputed cdr not found: %s", cdrId).Business().C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusInvalidParamError}).HttpSt(http.StatusNotFound).Err()
+	}
+	ErrDisputeStorageGet = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeStorageGet, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrDisputeStorageMerge = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeStorageMerge, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
+	ErrDisputeStorageUpdate = func(ctx context.Context, err error) error {
+		return kit.NewAppErrBuilder(ErrCodeDisputeStorageUpdate, "").Wrap(err).C(ctx).F(kit.KV{model.OcpiStatusField: model.OcpiStatusGenServerError}).HttpSt(http.StatusOK).Err()
+	}
 )
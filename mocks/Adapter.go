@@ -1376,6 +1376,152 @@ func (_m *Adapter) UpdateWebhook(ctx context.Context, wh *backend.Webhook) error
 	return r0
 }
 
+// GetFinancialAdvice provides a mock function with given fields: ctx, id
+func (_m *Adapter) GetFinancialAdvice(ctx context.Context, id string) (*domain.FinancialAdvice, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.FinancialAdvice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.FinancialAdvice, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.FinancialAdvice); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.FinancialAdvice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTerminal provides a mock function with given fields: ctx, id
+func (_m *Adapter) GetTerminal(ctx context.Context, id string) (*domain.Terminal, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Terminal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Terminal, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Terminal); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Terminal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeFinancialAdvice provides a mock function with given fields: ctx, fa
+func (_m *Adapter) MergeFinancialAdvice(ctx context.Context, fa *domain.FinancialAdvice) error {
+	ret := _m.Called(ctx, fa)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.FinancialAdvice) error); ok {
+		r0 = rf(ctx, fa)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeTerminal provides a mock function with given fields: ctx, t
+func (_m *Adapter) MergeTerminal(ctx context.Context, t *domain.Terminal) error {
+	ret := _m.Called(ctx, t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Terminal) error); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchFinancialAdvices provides a mock function with given fields: ctx, cr
+func (_m *Adapter) SearchFinancialAdvices(ctx context.Context, cr *domain.FinancialAdviceSearchCriteria) (*domain.FinancialAdviceSearchResponse, error) {
+	ret := _m.Called(ctx, cr)
+
+	var r0 *domain.FinancialAdviceSearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.FinancialAdviceSearchCriteria) (*domain.FinancialAdviceSearchResponse, error)); ok {
+		return rf(ctx, cr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.FinancialAdviceSearchCriteria) *domain.FinancialAdviceSearchResponse); ok {
+		r0 = rf(ctx, cr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.FinancialAdviceSearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.FinancialAdviceSearchCriteria) error); ok {
+		r1 = rf(ctx, cr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchTerminals provides a mock function with given fields: ctx, cr
+func (_m *Adapter) SearchTerminals(ctx context.Context, cr *domain.TerminalSearchCriteria) (*domain.TerminalSearchResponse, error) {
+	ret := _m.Called(ctx, cr)
+
+	var r0 *domain.TerminalSearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TerminalSearchCriteria) (*domain.TerminalSearchResponse, error)); ok {
+		return rf(ctx, cr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TerminalSearchCriteria) *domain.TerminalSearchResponse); ok {
+		r0 = rf(ctx, cr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TerminalSearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TerminalSearchCriteria) error); ok {
+		r1 = rf(ctx, cr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTerminal provides a mock function with given fields: ctx, t
+func (_m *Adapter) UpdateTerminal(ctx context.Context, t *domain.Terminal) error {
+	ret := _m.Called(ctx, t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Terminal) error); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAdapter creates a new instance of Adapter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAdapter(t interface {
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	backend "github.com/mikhailbolshakov/ocpi/backend"
+
 	domain "github.com/mikhailbolshakov/ocpi/domain"
 
 	mock "github.com/stretchr/testify/mock"
@@ -32,6 +33,22 @@ func (_m *CdrConverter) CdrBackendToDomain(cdr *backend.Cdr, sess *domain.Sessio
 	return r0
 }
 
+// CdrCreditBackendToDomain provides a mock function with given fields: rq
+func (_m *CdrConverter) CdrCreditBackendToDomain(rq *backend.CdrCreditRequest) *domain.CdrCredit {
+	ret := _m.Called(rq)
+
+	var r0 *domain.CdrCredit
+	if rf, ok := ret.Get(0).(func(*backend.CdrCreditRequest) *domain.CdrCredit); ok {
+		r0 = rf(rq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CdrCredit)
+		}
+	}
+
+	return r0
+}
+
 // CdrDomainToBackend provides a mock function with given fields: cdr
 func (_m *CdrConverter) CdrDomainToBackend(cdr *domain.Cdr) *backend.Cdr {
 	ret := _m.Called(cdr)
@@ -64,6 +81,22 @@ func (_m *CdrConverter) CdrDomainToModel(cdr *domain.Cdr) *model.OcpiCdr {
 	return r0
 }
 
+// CdrIssueReportsDomainToBackend provides a mock function with given fields: rs
+func (_m *CdrConverter) CdrIssueReportsDomainToBackend(rs []*domain.CdrIssueReport) []*backend.CdrIssueReport {
+	ret := _m.Called(rs)
+
+	var r0 []*backend.CdrIssueReport
+	if rf, ok := ret.Get(0).(func([]*domain.CdrIssueReport) []*backend.CdrIssueReport); ok {
+		r0 = rf(rs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*backend.CdrIssueReport)
+		}
+	}
+
+	return r0
+}
+
 // CdrModelToDomain provides a mock function with given fields: cdr, platformId
 func (_m *CdrConverter) CdrModelToDomain(cdr *model.OcpiCdr, platformId string) *domain.Cdr {
 	ret := _m.Called(cdr, platformId)
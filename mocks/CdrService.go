@@ -5,8 +5,9 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
 	domain "github.com/mikhailbolshakov/ocpi/domain"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
 // CdrService is an autogenerated mock type for the CdrService type
@@ -14,6 +15,41 @@ type CdrService struct {
 	mock.Mock
 }
 
+// Credit provides a mock function with given fields: ctx, cdrId, rq
+func (_m *CdrService) Credit(ctx context.Context, cdrId string, rq *domain.CdrCredit) (*domain.Cdr, *domain.Cdr, error) {
+	ret := _m.Called(ctx, cdrId, rq)
+
+	var r0 *domain.Cdr
+	var r1 *domain.Cdr
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.CdrCredit) (*domain.Cdr, *domain.Cdr, error)); ok {
+		return rf(ctx, cdrId, rq)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.CdrCredit) *domain.Cdr); ok {
+		r0 = rf(ctx, cdrId, rq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Cdr)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.CdrCredit) *domain.Cdr); ok {
+		r1 = rf(ctx, cdrId, rq)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Cdr)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *domain.CdrCredit) error); ok {
+		r2 = rf(ctx, cdrId, rq)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // DeleteCdrsByExtId provides a mock function with given fields: ctx, extId
 func (_m *CdrService) DeleteCdrsByExtId(ctx context.Context, extId domain.PartyExtId) error {
 	ret := _m.Called(ctx, extId)
@@ -28,6 +64,20 @@ func (_m *CdrService) DeleteCdrsByExtId(ctx context.Context, extId domain.PartyE
 	return r0
 }
 
+// DeleteCdrsByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *CdrService) DeleteCdrsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetCdr provides a mock function with given fields: ctx, sessId
 func (_m *CdrService) GetCdr(ctx context.Context, sessId string) (*domain.Cdr, error) {
 	ret := _m.Called(ctx, sessId)
@@ -80,6 +130,41 @@ func (_m *CdrService) PutCdr(ctx context.Context, sess *domain.Cdr) (*domain.Cdr
 	return r0, r1
 }
 
+// PutCdrs provides a mock function with given fields: ctx, cdrs
+func (_m *CdrService) PutCdrs(ctx context.Context, cdrs []*domain.Cdr) ([]*domain.Cdr, []*domain.BulkItemResult, error) {
+	ret := _m.Called(ctx, cdrs)
+
+	var r0 []*domain.Cdr
+	var r1 []*domain.BulkItemResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Cdr) ([]*domain.Cdr, []*domain.BulkItemResult, error)); ok {
+		return rf(ctx, cdrs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Cdr) []*domain.Cdr); ok {
+		r0 = rf(ctx, cdrs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Cdr)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Cdr) []*domain.BulkItemResult); ok {
+		r1 = rf(ctx, cdrs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*domain.BulkItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []*domain.Cdr) error); ok {
+		r2 = rf(ctx, cdrs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SearchCdrs provides a mock function with given fields: ctx, cr
 func (_m *CdrService) SearchCdrs(ctx context.Context, cr *domain.CdrSearchCriteria) (*domain.CdrSearchResponse, error) {
 	ret := _m.Called(ctx, cr)
@@ -106,6 +191,22 @@ func (_m *CdrService) SearchCdrs(ctx context.Context, cr *domain.CdrSearchCriter
 	return r0, r1
 }
 
+// Verify provides a mock function with given fields: ctx, cdr, sess
+func (_m *CdrService) Verify(ctx context.Context, cdr *domain.Cdr, sess *domain.Session) []*domain.CdrIssue {
+	ret := _m.Called(ctx, cdr, sess)
+
+	var r0 []*domain.CdrIssue
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Cdr, *domain.Session) []*domain.CdrIssue); ok {
+		r0 = rf(ctx, cdr, sess)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CdrIssue)
+		}
+	}
+
+	return r0
+}
+
 // NewCdrService creates a new instance of CdrService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCdrService(t interface {
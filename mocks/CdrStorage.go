@@ -5,8 +5,9 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
 	domain "github.com/mikhailbolshakov/ocpi/domain"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
 // CdrStorage is an autogenerated mock type for the CdrStorage type
@@ -14,6 +15,41 @@ type CdrStorage struct {
 	mock.Mock
 }
 
+// CreateCreditCdr provides a mock function with given fields: ctx, originalId, creditId, build
+func (_m *CdrStorage) CreateCreditCdr(ctx context.Context, originalId string, creditId string, build domain.CreditBuilder) (*domain.Cdr, *domain.Cdr, error) {
+	ret := _m.Called(ctx, originalId, creditId, build)
+
+	var r0 *domain.Cdr
+	var r1 *domain.Cdr
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.CreditBuilder) (*domain.Cdr, *domain.Cdr, error)); ok {
+		return rf(ctx, originalId, creditId, build)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.CreditBuilder) *domain.Cdr); ok {
+		r0 = rf(ctx, originalId, creditId, build)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Cdr)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, domain.CreditBuilder) *domain.Cdr); ok {
+		r1 = rf(ctx, originalId, creditId, build)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.Cdr)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, domain.CreditBuilder) error); ok {
+		r2 = rf(ctx, originalId, creditId, build)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // DeleteCdrsByExtId provides a mock function with given fields: ctx, extId
 func (_m *CdrStorage) DeleteCdrsByExtId(ctx context.Context, extId domain.PartyExtId) error {
 	ret := _m.Called(ctx, extId)
@@ -28,6 +64,20 @@ func (_m *CdrStorage) DeleteCdrsByExtId(ctx context.Context, extId domain.PartyE
 	return r0
 }
 
+// DeleteCdrsByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *CdrStorage) DeleteCdrsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetCdr provides a mock function with given fields: ctx, sessId
 func (_m *CdrStorage) GetCdr(ctx context.Context, sessId string) (*domain.Cdr, error) {
 	ret := _m.Called(ctx, sessId)
@@ -68,6 +118,20 @@ func (_m *CdrStorage) MergeCdr(ctx context.Context, sess *domain.Cdr) error {
 	return r0
 }
 
+// MergeCdrs provides a mock function with given fields: ctx, cdrs
+func (_m *CdrStorage) MergeCdrs(ctx context.Context, cdrs []*domain.Cdr) error {
+	ret := _m.Called(ctx, cdrs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Cdr) error); ok {
+		r0 = rf(ctx, cdrs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SearchCdrs provides a mock function with given fields: ctx, cr
 func (_m *CdrStorage) SearchCdrs(ctx context.Context, cr *domain.CdrSearchCriteria) (*domain.CdrSearchResponse, error) {
 	ret := _m.Called(ctx, cr)
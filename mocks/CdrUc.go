@@ -7,10 +7,14 @@ import (
 
 	backend "github.com/mikhailbolshakov/ocpi/backend"
 
+	domain "github.com/mikhailbolshakov/ocpi/domain"
+
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/mikhailbolshakov/ocpi/model"
 
+	ocpi "github.com/mikhailbolshakov/ocpi"
+
 	time "time"
 )
 
@@ -19,6 +23,72 @@ type CdrUc struct {
 	mock.Mock
 }
 
+// CreditCdr provides a mock function with given fields: ctx, cdrId, rq
+func (_m *CdrUc) CreditCdr(ctx context.Context, cdrId string, rq *domain.CdrCredit) (*domain.Cdr, error) {
+	ret := _m.Called(ctx, cdrId, rq)
+
+	var r0 *domain.Cdr
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.CdrCredit) (*domain.Cdr, error)); ok {
+		return rf(ctx, cdrId, rq)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.CdrCredit) *domain.Cdr); ok {
+		r0 = rf(ctx, cdrId, rq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Cdr)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.CdrCredit) error); ok {
+		r1 = rf(ctx, cdrId, rq)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCdrIssues provides a mock function with given fields: ctx
+func (_m *CdrUc) GetCdrIssues(ctx context.Context) ([]*domain.CdrIssueReport, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*domain.CdrIssueReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.CdrIssueReport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.CdrIssueReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.CdrIssueReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Init provides a mock function with given fields: ctx, cfg
+func (_m *CdrUc) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
+	ret := _m.Called(ctx, cfg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ocpi.CfgOcpiConfig) error); ok {
+		r0 = rf(ctx, cfg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnLocalCdrChanged provides a mock function with given fields: ctx, cdr
 func (_m *CdrUc) OnLocalCdrChanged(ctx context.Context, cdr *backend.Cdr) error {
 	ret := _m.Called(ctx, cdr)
@@ -75,6 +145,20 @@ func (_m *CdrUc) OnRemoteCdrsPullWhenPushNotSupported(ctx context.Context, from
 	return r0
 }
 
+// OnRemotePlatformPull provides a mock function with given fields: ctx, platformId, from, to
+func (_m *CdrUc) OnRemotePlatformPull(ctx context.Context, platformId string, from *time.Time, to *time.Time) error {
+	ret := _m.Called(ctx, platformId, from, to)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) error); ok {
+		r0 = rf(ctx, platformId, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCdrUc creates a new instance of CdrUc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCdrUc(t interface {
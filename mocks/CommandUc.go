@@ -5,10 +5,15 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
 	domain "github.com/mikhailbolshakov/ocpi/domain"
 
+	mock "github.com/stretchr/testify/mock"
+
 	model "github.com/mikhailbolshakov/ocpi/model"
+
+	ocpi "github.com/mikhailbolshakov/ocpi"
+
+	time "time"
 )
 
 // CommandUc is an autogenerated mock type for the CommandUc type
@@ -16,6 +21,20 @@ type CommandUc struct {
 	mock.Mock
 }
 
+// Init provides a mock function with given fields: ctx, cfg
+func (_m *CommandUc) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
+	ret := _m.Called(ctx, cfg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ocpi.CfgOcpiConfig) error); ok {
+		r0 = rf(ctx, cfg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // LocalCommandsDeadlineCronHandler provides a mock function with given fields: ctx
 func (_m *CommandUc) LocalCommandsDeadlineCronHandler(ctx context.Context) {
 	_m.Called(ctx)
@@ -254,6 +273,32 @@ func (_m *CommandUc) RemoteCommandsDeadlineCronHandler(ctx context.Context) {
 	_m.Called(ctx)
 }
 
+// WaitCommandResult provides a mock function with given fields: ctx, cmdId, timeout
+func (_m *CommandUc) WaitCommandResult(ctx context.Context, cmdId string, timeout time.Duration) (*domain.Command, error) {
+	ret := _m.Called(ctx, cmdId, timeout)
+
+	var r0 *domain.Command
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (*domain.Command, error)); ok {
+		return rf(ctx, cmdId, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) *domain.Command); ok {
+		r0 = rf(ctx, cmdId, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Command)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, cmdId, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCommandUc creates a new instance of CommandUc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCommandUc(t interface {
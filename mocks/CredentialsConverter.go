@@ -141,6 +141,70 @@ func (_m *CredentialsConverter) PlatformDomainToBackend(p *domain.Platform) *bac
 	return r0
 }
 
+// TokenRotationDomainToBackend provides a mock function with given fields: r
+func (_m *CredentialsConverter) TokenRotationDomainToBackend(r *domain.TokenRotation) *backend.TokenRotation {
+	ret := _m.Called(r)
+
+	var r0 *backend.TokenRotation
+	if rf, ok := ret.Get(0).(func(*domain.TokenRotation) *backend.TokenRotation); ok {
+		r0 = rf(r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*backend.TokenRotation)
+		}
+	}
+
+	return r0
+}
+
+// TokenRotationsDomainToBackend provides a mock function with given fields: rs
+func (_m *CredentialsConverter) TokenRotationsDomainToBackend(rs []*domain.TokenRotation) []*backend.TokenRotation {
+	ret := _m.Called(rs)
+
+	var r0 []*backend.TokenRotation
+	if rf, ok := ret.Get(0).(func([]*domain.TokenRotation) []*backend.TokenRotation); ok {
+		r0 = rf(rs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*backend.TokenRotation)
+		}
+	}
+
+	return r0
+}
+
+// OnboardingTokenBackendToDomain provides a mock function with given fields: rq
+func (_m *CredentialsConverter) OnboardingTokenBackendToDomain(rq *backend.OnboardingTokenRequest) *domain.OnboardingToken {
+	ret := _m.Called(rq)
+
+	var r0 *domain.OnboardingToken
+	if rf, ok := ret.Get(0).(func(*backend.OnboardingTokenRequest) *domain.OnboardingToken); ok {
+		r0 = rf(rq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OnboardingToken)
+		}
+	}
+
+	return r0
+}
+
+// OnboardingTokenDomainToBackend provides a mock function with given fields: p, status
+func (_m *CredentialsConverter) OnboardingTokenDomainToBackend(p *domain.Platform, status string) *backend.OnboardingToken {
+	ret := _m.Called(p, status)
+
+	var r0 *backend.OnboardingToken
+	if rf, ok := ret.Get(0).(func(*domain.Platform, string) *backend.OnboardingToken); ok {
+		r0 = rf(p, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*backend.OnboardingToken)
+		}
+	}
+
+	return r0
+}
+
 // NewCredentialsConverter creates a new instance of CredentialsConverter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCredentialsConverter(t interface {
@@ -162,6 +162,89 @@ func (_m *CredentialsUc) UpdateConnection(ctx context.Context, receiverPlatformI
 	return r0, r1
 }
 
+// DisconnectPlatform provides a mock function with given fields: ctx, platformId, dataPolicy
+func (_m *CredentialsUc) DisconnectPlatform(ctx context.Context, platformId string, dataPolicy string) (*domain.Platform, error) {
+	ret := _m.Called(ctx, platformId, dataPolicy)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Platform, error)); ok {
+		return rf(ctx, platformId, dataPolicy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Platform); ok {
+		r0 = rf(ctx, platformId, dataPolicy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, platformId, dataPolicy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateToken provides a mock function with given fields: ctx, platformId
+func (_m *CredentialsUc) RotateToken(ctx context.Context, platformId string) (*domain.Platform, error) {
+	ret := _m.Called(ctx, platformId)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Platform, error)); ok {
+		return rf(ctx, platformId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Platform); ok {
+		r0 = rf(ctx, platformId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, platformId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenRotationCronHandler provides a mock function with given fields: ctx
+func (_m *CredentialsUc) TokenRotationCronHandler(ctx context.Context)  {
+	_m.Called(ctx)
+}
+
+// GetPlatform provides a mock function with given fields: ctx, platformId
+func (_m *CredentialsUc) GetPlatform(ctx context.Context, platformId string) (*domain.Platform, error) {
+	ret := _m.Called(ctx, platformId)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Platform, error)); ok {
+		return rf(ctx, platformId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Platform); ok {
+		r0 = rf(ctx, platformId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, platformId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCredentialsUc creates a new instance of CredentialsUc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCredentialsUc(t interface {
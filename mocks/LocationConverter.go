@@ -304,6 +304,54 @@ func (_m *LocationConverter) LocationsDomainToModel(locs []*domain.Location) []*
 	return r0
 }
 
+// BulkResultsDomainToBackend provides a mock function with given fields: rs
+func (_m *LocationConverter) BulkResultsDomainToBackend(rs []*domain.BulkItemResult) *backend.BulkResponse {
+	ret := _m.Called(rs)
+
+	var r0 *backend.BulkResponse
+	if rf, ok := ret.Get(0).(func([]*domain.BulkItemResult) *backend.BulkResponse); ok {
+		r0 = rf(rs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*backend.BulkResponse)
+		}
+	}
+
+	return r0
+}
+
+// EvseStatusesBackendToDomain provides a mock function with given fields: statuses
+func (_m *LocationConverter) EvseStatusesBackendToDomain(statuses []*backend.EvseStatus) []*domain.Evse {
+	ret := _m.Called(statuses)
+
+	var r0 []*domain.Evse
+	if rf, ok := ret.Get(0).(func([]*backend.EvseStatus) []*domain.Evse); ok {
+		r0 = rf(statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Evse)
+		}
+	}
+
+	return r0
+}
+
+// LocationsBackendToDomain provides a mock function with given fields: locs, platformId
+func (_m *LocationConverter) LocationsBackendToDomain(locs []*backend.Location, platformId string) []*domain.Location {
+	ret := _m.Called(locs, platformId)
+
+	var r0 []*domain.Location
+	if rf, ok := ret.Get(0).(func([]*backend.Location, string) []*domain.Location); ok {
+		r0 = rf(locs, platformId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Location)
+		}
+	}
+
+	return r0
+}
+
 // NewLocationConverter creates a new instance of LocationConverter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLocationConverter(t interface {
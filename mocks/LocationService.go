@@ -340,6 +340,90 @@ func (_m *LocationService) SearchLocations(ctx context.Context, cr *domain.Locat
 	return r0, r1
 }
 
+// DeleteLocationsByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *LocationService) DeleteLocationsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeEvses provides a mock function with given fields: ctx, evses
+func (_m *LocationService) MergeEvses(ctx context.Context, evses []*domain.Evse) ([]*domain.Evse, []*domain.BulkItemResult, error) {
+	ret := _m.Called(ctx, evses)
+
+	var r0 []*domain.Evse
+	var r1 []*domain.BulkItemResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Evse) ([]*domain.Evse, []*domain.BulkItemResult, error)); ok {
+		return rf(ctx, evses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Evse) []*domain.Evse); ok {
+		r0 = rf(ctx, evses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Evse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Evse) []*domain.BulkItemResult); ok {
+		r1 = rf(ctx, evses)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*domain.BulkItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []*domain.Evse) error); ok {
+		r2 = rf(ctx, evses)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PutLocations provides a mock function with given fields: ctx, locs
+func (_m *LocationService) PutLocations(ctx context.Context, locs []*domain.Location) ([]*domain.Location, []*domain.BulkItemResult, error) {
+	ret := _m.Called(ctx, locs)
+
+	var r0 []*domain.Location
+	var r1 []*domain.BulkItemResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Location) ([]*domain.Location, []*domain.BulkItemResult, error)); ok {
+		return rf(ctx, locs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Location) []*domain.Location); ok {
+		r0 = rf(ctx, locs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Location)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Location) []*domain.BulkItemResult); ok {
+		r1 = rf(ctx, locs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*domain.BulkItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []*domain.Location) error); ok {
+		r2 = rf(ctx, locs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewLocationService creates a new instance of LocationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLocationService(t interface {
@@ -268,6 +268,48 @@ func (_m *LocationStorage) UpdateLocation(ctx context.Context, loc *domain.Locat
 	return r0
 }
 
+// DeleteLocationsByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *LocationStorage) DeleteLocationsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeEvses provides a mock function with given fields: ctx, evses
+func (_m *LocationStorage) MergeEvses(ctx context.Context, evses []*domain.Evse) error {
+	ret := _m.Called(ctx, evses)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Evse) error); ok {
+		r0 = rf(ctx, evses)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeLocations provides a mock function with given fields: ctx, locs
+func (_m *LocationStorage) MergeLocations(ctx context.Context, locs []*domain.Location) error {
+	ret := _m.Called(ctx, locs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Location) error); ok {
+		r0 = rf(ctx, locs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewLocationStorage creates a new instance of LocationStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLocationStorage(t interface {
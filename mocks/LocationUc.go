@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	ocpi "github.com/mikhailbolshakov/ocpi"
 	domain "github.com/mikhailbolshakov/ocpi/domain"
 
 	model "github.com/mikhailbolshakov/ocpi/model"
@@ -186,6 +187,91 @@ func (_m *LocationUc) OnRemoteLocationsPullWhenPushNotSupported(ctx context.Cont
 	return r0
 }
 
+// OnRemotePlatformPull provides a mock function with given fields: ctx, platformId, from, to
+func (_m *LocationUc) OnRemotePlatformPull(ctx context.Context, platformId string, from *time.Time, to *time.Time) error {
+	ret := _m.Called(ctx, platformId, from, to)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) error); ok {
+		r0 = rf(ctx, platformId, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Close provides a mock function with given fields: ctx
+func (_m *LocationUc) Close(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// Init provides a mock function with given fields: ctx, cfg
+func (_m *LocationUc) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
+	ret := _m.Called(ctx, cfg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ocpi.CfgOcpiConfig) error); ok {
+		r0 = rf(ctx, cfg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnLocalEvseStatusesChanged provides a mock function with given fields: ctx, evses
+func (_m *LocationUc) OnLocalEvseStatusesChanged(ctx context.Context, evses []*domain.Evse) ([]*domain.BulkItemResult, error) {
+	ret := _m.Called(ctx, evses)
+
+	var r0 []*domain.BulkItemResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Evse) ([]*domain.BulkItemResult, error)); ok {
+		return rf(ctx, evses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Evse) []*domain.BulkItemResult); ok {
+		r0 = rf(ctx, evses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.BulkItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Evse) error); ok {
+		r1 = rf(ctx, evses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OnLocalLocationsChanged provides a mock function with given fields: ctx, locs
+func (_m *LocationUc) OnLocalLocationsChanged(ctx context.Context, locs []*domain.Location) ([]*domain.BulkItemResult, error) {
+	ret := _m.Called(ctx, locs)
+
+	var r0 []*domain.BulkItemResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Location) ([]*domain.BulkItemResult, error)); ok {
+		return rf(ctx, locs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Location) []*domain.BulkItemResult); ok {
+		r0 = rf(ctx, locs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.BulkItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Location) error); ok {
+		r1 = rf(ctx, locs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewLocationUc creates a new instance of LocationUc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLocationUc(t interface {
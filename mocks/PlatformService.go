@@ -5,9 +5,10 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
 	domain "github.com/mikhailbolshakov/ocpi/domain"
 
+	mock "github.com/stretchr/testify/mock"
+
 	ocpi "github.com/mikhailbolshakov/ocpi"
 )
 
@@ -16,6 +17,72 @@ type PlatformService struct {
 	mock.Mock
 }
 
+// AddTokenRotation provides a mock function with given fields: ctx, rotation
+func (_m *PlatformService) AddTokenRotation(ctx context.Context, rotation *domain.TokenRotation) error {
+	ret := _m.Called(ctx, rotation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TokenRotation) error); ok {
+		r0 = rf(ctx, rotation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsumeOnboardingToken provides a mock function with given fields: ctx, platformId
+func (_m *PlatformService) ConsumeOnboardingToken(ctx context.Context, platformId string) (*domain.Platform, error) {
+	ret := _m.Called(ctx, platformId)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Platform, error)); ok {
+		return rf(ctx, platformId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Platform); ok {
+		r0 = rf(ctx, platformId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, platformId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Disconnect provides a mock function with given fields: ctx, platformId
+func (_m *PlatformService) Disconnect(ctx context.Context, platformId string) (*domain.Platform, error) {
+	ret := _m.Called(ctx, platformId)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Platform, error)); ok {
+		return rf(ctx, platformId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Platform); ok {
+		r0 = rf(ctx, platformId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, platformId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, platformId
 func (_m *PlatformService) Get(ctx context.Context, platformId string) (*domain.Platform, error) {
 	ret := _m.Called(ctx, platformId)
@@ -120,6 +187,32 @@ func (_m *PlatformService) GetByTokenC(ctx context.Context, token domain.Platfor
 	return r0, r1
 }
 
+// GetTokenRotations provides a mock function with given fields: ctx, platformId
+func (_m *PlatformService) GetTokenRotations(ctx context.Context, platformId string) ([]*domain.TokenRotation, error) {
+	ret := _m.Called(ctx, platformId)
+
+	var r0 []*domain.TokenRotation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.TokenRotation, error)); ok {
+		return rf(ctx, platformId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.TokenRotation); ok {
+		r0 = rf(ctx, platformId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.TokenRotation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, platformId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Init provides a mock function with given fields: ctx, cfg
 func (_m *PlatformService) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
 	ret := _m.Called(ctx, cfg)
@@ -134,6 +227,32 @@ func (_m *PlatformService) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) er
 	return r0
 }
 
+// IssueOnboardingToken provides a mock function with given fields: ctx, platformId, token
+func (_m *PlatformService) IssueOnboardingToken(ctx context.Context, platformId string, token *domain.OnboardingToken) (*domain.Platform, error) {
+	ret := _m.Called(ctx, platformId, token)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.OnboardingToken) (*domain.Platform, error)); ok {
+		return rf(ctx, platformId, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.OnboardingToken) *domain.Platform); ok {
+		r0 = rf(ctx, platformId, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.OnboardingToken) error); ok {
+		r1 = rf(ctx, platformId, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Merge provides a mock function with given fields: ctx, platform
 func (_m *PlatformService) Merge(ctx context.Context, platform *domain.Platform) (*domain.Platform, error) {
 	ret := _m.Called(ctx, platform)
@@ -160,6 +279,46 @@ func (_m *PlatformService) Merge(ctx context.Context, platform *domain.Platform)
 	return r0, r1
 }
 
+// OnboardingTokenStatus provides a mock function with given fields: token
+func (_m *PlatformService) OnboardingTokenStatus(token *domain.OnboardingToken) string {
+	ret := _m.Called(token)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*domain.OnboardingToken) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// RevokeOnboardingToken provides a mock function with given fields: ctx, platformId
+func (_m *PlatformService) RevokeOnboardingToken(ctx context.Context, platformId string) (*domain.Platform, error) {
+	ret := _m.Called(ctx, platformId)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Platform, error)); ok {
+		return rf(ctx, platformId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Platform); ok {
+		r0 = rf(ctx, platformId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, platformId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RoleEndpoint provides a mock function with given fields: ctx, platform, module, role
 func (_m *PlatformService) RoleEndpoint(ctx context.Context, platform *domain.Platform, module string, role string) domain.Endpoint {
 	ret := _m.Called(ctx, platform, module, role)
@@ -200,6 +359,58 @@ func (_m *PlatformService) Search(ctx context.Context, cr *domain.PlatformSearch
 	return r0, r1
 }
 
+// SetDataCleanup provides a mock function with given fields: ctx, platformId, cleanup
+func (_m *PlatformService) SetDataCleanup(ctx context.Context, platformId string, cleanup *domain.PlatformDataCleanup) (*domain.Platform, error) {
+	ret := _m.Called(ctx, platformId, cleanup)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.PlatformDataCleanup) (*domain.Platform, error)); ok {
+		return rf(ctx, platformId, cleanup)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.PlatformDataCleanup) *domain.Platform); ok {
+		r0 = rf(ctx, platformId, cleanup)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.PlatformDataCleanup) error); ok {
+		r1 = rf(ctx, platformId, cleanup)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetHealth provides a mock function with given fields: ctx, platformId, health
+func (_m *PlatformService) SetHealth(ctx context.Context, platformId string, health *domain.PlatformHealth) (*domain.Platform, error) {
+	ret := _m.Called(ctx, platformId, health)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.PlatformHealth) (*domain.Platform, error)); ok {
+		return rf(ctx, platformId, health)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.PlatformHealth) *domain.Platform); ok {
+		r0 = rf(ctx, platformId, health)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.PlatformHealth) error); ok {
+		r1 = rf(ctx, platformId, health)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetStatus provides a mock function with given fields: ctx, platformId, status
 func (_m *PlatformService) SetStatus(ctx context.Context, platformId string, status string) (*domain.Platform, error) {
 	ret := _m.Called(ctx, platformId, status)
@@ -5,8 +5,11 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
 	domain "github.com/mikhailbolshakov/ocpi/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // PlatformStorage is an autogenerated mock type for the PlatformStorage type
@@ -14,6 +17,30 @@ type PlatformStorage struct {
 	mock.Mock
 }
 
+// ConsumeOnboardingToken provides a mock function with given fields: ctx, platformId, consumedAt
+func (_m *PlatformStorage) ConsumeOnboardingToken(ctx context.Context, platformId string, consumedAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, platformId, consumedAt)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return rf(ctx, platformId, consumedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = rf(ctx, platformId, consumedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, platformId, consumedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePlatform provides a mock function with given fields: ctx, p
 func (_m *PlatformStorage) CreatePlatform(ctx context.Context, p *domain.Platform) error {
 	ret := _m.Called(ctx, p)
@@ -28,6 +55,20 @@ func (_m *PlatformStorage) CreatePlatform(ctx context.Context, p *domain.Platfor
 	return r0
 }
 
+// CreateTokenRotation provides a mock function with given fields: ctx, rotation
+func (_m *PlatformStorage) CreateTokenRotation(ctx context.Context, rotation *domain.TokenRotation) error {
+	ret := _m.Called(ctx, rotation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TokenRotation) error); ok {
+		r0 = rf(ctx, rotation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeletePlatform provides a mock function with given fields: ctx, p
 func (_m *PlatformStorage) DeletePlatform(ctx context.Context, p *domain.Platform) error {
 	ret := _m.Called(ctx, p)
@@ -42,6 +83,30 @@ func (_m *PlatformStorage) DeletePlatform(ctx context.Context, p *domain.Platfor
 	return r0
 }
 
+// EncryptTokens provides a mock function with given fields: ctx
+func (_m *PlatformStorage) EncryptTokens(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPlatform provides a mock function with given fields: ctx, id
 func (_m *PlatformStorage) GetPlatform(ctx context.Context, id string) (*domain.Platform, error) {
 	ret := _m.Called(ctx, id)
@@ -68,6 +133,32 @@ func (_m *PlatformStorage) GetPlatform(ctx context.Context, id string) (*domain.
 	return r0, r1
 }
 
+// GetPlatformByPrevTokenB provides a mock function with given fields: ctx, token
+func (_m *PlatformStorage) GetPlatformByPrevTokenB(ctx context.Context, token domain.PlatformToken) (*domain.Platform, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *domain.Platform
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PlatformToken) (*domain.Platform, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PlatformToken) *domain.Platform); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Platform)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PlatformToken) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPlatformByTokenA provides a mock function with given fields: ctx, tokenA
 func (_m *PlatformStorage) GetPlatformByTokenA(ctx context.Context, tokenA domain.PlatformToken) (*domain.Platform, error) {
 	ret := _m.Called(ctx, tokenA)
@@ -146,6 +237,32 @@ func (_m *PlatformStorage) GetPlatformByTokenC(ctx context.Context, token domain
 	return r0, r1
 }
 
+// GetTokenRotations provides a mock function with given fields: ctx, platformId
+func (_m *PlatformStorage) GetTokenRotations(ctx context.Context, platformId string) ([]*domain.TokenRotation, error) {
+	ret := _m.Called(ctx, platformId)
+
+	var r0 []*domain.TokenRotation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.TokenRotation, error)); ok {
+		return rf(ctx, platformId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.TokenRotation); ok {
+		r0 = rf(ctx, platformId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.TokenRotation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, platformId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SearchPlatforms provides a mock function with given fields: ctx, cr
 func (_m *PlatformStorage) SearchPlatforms(ctx context.Context, cr *domain.PlatformSearchCriteria) ([]*domain.Platform, error) {
 	ret := _m.Called(ctx, cr)
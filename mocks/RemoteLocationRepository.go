@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
 	model "github.com/mikhailbolshakov/ocpi/model"
 
 	usecase "github.com/mikhailbolshakov/ocpi/usecase"
@@ -125,6 +126,20 @@ func (_m *RemoteLocationRepository) PatchConnectorAsync(ctx context.Context, rq
 	_m.Called(ctx, rq, party, evseId, locId)
 }
 
+// PatchEvse provides a mock function with given fields: ctx, rq, party, locId
+func (_m *RemoteLocationRepository) PatchEvse(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) error {
+	ret := _m.Called(ctx, rq, party, locId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.OcpiRepositoryRequestG[*model.OcpiEvse], *model.OcpiPartyId, string) error); ok {
+		r0 = rf(ctx, rq, party, locId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PatchEvseAsync provides a mock function with given fields: ctx, rq, party, locId
 func (_m *RemoteLocationRepository) PatchEvseAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) {
 	_m.Called(ctx, rq, party, locId)
@@ -135,16 +150,58 @@ func (_m *RemoteLocationRepository) PatchLocationAsync(ctx context.Context, rq *
 	_m.Called(ctx, rq)
 }
 
+// PutConnector provides a mock function with given fields: ctx, rq, party, locId, evseId
+func (_m *RemoteLocationRepository) PutConnector(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiConnector], party *model.OcpiPartyId, locId string, evseId string) error {
+	ret := _m.Called(ctx, rq, party, locId, evseId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.OcpiRepositoryRequestG[*model.OcpiConnector], *model.OcpiPartyId, string, string) error); ok {
+		r0 = rf(ctx, rq, party, locId, evseId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PutConnectorAsync provides a mock function with given fields: ctx, rq, party, evseId, locId
 func (_m *RemoteLocationRepository) PutConnectorAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiConnector], party *model.OcpiPartyId, evseId string, locId string) {
 	_m.Called(ctx, rq, party, evseId, locId)
 }
 
+// PutEvse provides a mock function with given fields: ctx, rq, party, locId
+func (_m *RemoteLocationRepository) PutEvse(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) error {
+	ret := _m.Called(ctx, rq, party, locId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.OcpiRepositoryRequestG[*model.OcpiEvse], *model.OcpiPartyId, string) error); ok {
+		r0 = rf(ctx, rq, party, locId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PutEvseAsync provides a mock function with given fields: ctx, rq, party, locId
 func (_m *RemoteLocationRepository) PutEvseAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) {
 	_m.Called(ctx, rq, party, locId)
 }
 
+// PutLocation provides a mock function with given fields: ctx, rq
+func (_m *RemoteLocationRepository) PutLocation(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiLocation]) error {
+	ret := _m.Called(ctx, rq)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.OcpiRepositoryRequestG[*model.OcpiLocation]) error); ok {
+		r0 = rf(ctx, rq)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PutLocationAsync provides a mock function with given fields: ctx, rq
 func (_m *RemoteLocationRepository) PutLocationAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiLocation]) {
 	_m.Called(ctx, rq)
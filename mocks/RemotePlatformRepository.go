@@ -162,6 +162,22 @@ func (_m *RemotePlatformRepository) PutCredentials(ctx context.Context, rq *usec
 	return r0, r1
 }
 
+// GetClientState provides a mock function with given fields: ctx, platformId
+func (_m *RemotePlatformRepository) GetClientState(ctx context.Context, platformId string) *domain.ClientState {
+	ret := _m.Called(ctx, platformId)
+
+	var r0 *domain.ClientState
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ClientState); ok {
+		r0 = rf(ctx, platformId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ClientState)
+		}
+	}
+
+	return r0
+}
+
 // NewRemotePlatformRepository creates a new instance of RemotePlatformRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRemotePlatformRepository(t interface {
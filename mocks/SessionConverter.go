@@ -4,6 +4,7 @@ package mocks
 
 import (
 	backend "github.com/mikhailbolshakov/ocpi/backend"
+
 	domain "github.com/mikhailbolshakov/ocpi/domain"
 
 	mock "github.com/stretchr/testify/mock"
@@ -64,6 +65,22 @@ func (_m *SessionConverter) SessionDomainToModel(sess *domain.Session) *model.Oc
 	return r0
 }
 
+// SessionIssueReportsDomainToBackend provides a mock function with given fields: rs
+func (_m *SessionConverter) SessionIssueReportsDomainToBackend(rs []*domain.SessionIssueReport) []*backend.SessionIssueReport {
+	ret := _m.Called(rs)
+
+	var r0 []*backend.SessionIssueReport
+	if rf, ok := ret.Get(0).(func([]*domain.SessionIssueReport) []*backend.SessionIssueReport); ok {
+		r0 = rf(rs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*backend.SessionIssueReport)
+		}
+	}
+
+	return r0
+}
+
 // SessionModelToDomain provides a mock function with given fields: sess, platformId
 func (_m *SessionConverter) SessionModelToDomain(sess *model.OcpiSession, platformId string) *domain.Session {
 	ret := _m.Called(sess, platformId)
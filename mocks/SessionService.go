@@ -5,8 +5,9 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
 	domain "github.com/mikhailbolshakov/ocpi/domain"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
 // SessionService is an autogenerated mock type for the SessionService type
@@ -28,6 +29,20 @@ func (_m *SessionService) DeleteSessionsByExtId(ctx context.Context, extId domai
 	return r0
 }
 
+// DeleteSessionsByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *SessionService) DeleteSessionsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetSession provides a mock function with given fields: ctx, sessId
 func (_m *SessionService) GetSession(ctx context.Context, sessId string) (*domain.Session, error) {
 	ret := _m.Called(ctx, sessId)
@@ -132,6 +147,41 @@ func (_m *SessionService) PutSession(ctx context.Context, sess *domain.Session)
 	return r0, r1
 }
 
+// PutSessions provides a mock function with given fields: ctx, sessions
+func (_m *SessionService) PutSessions(ctx context.Context, sessions []*domain.Session) ([]*domain.Session, []*domain.BulkItemResult, error) {
+	ret := _m.Called(ctx, sessions)
+
+	var r0 []*domain.Session
+	var r1 []*domain.BulkItemResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Session) ([]*domain.Session, []*domain.BulkItemResult, error)); ok {
+		return rf(ctx, sessions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Session) []*domain.Session); ok {
+		r0 = rf(ctx, sessions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Session) []*domain.BulkItemResult); ok {
+		r1 = rf(ctx, sessions)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*domain.BulkItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []*domain.Session) error); ok {
+		r2 = rf(ctx, sessions)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SearchSessions provides a mock function with given fields: ctx, cr
 func (_m *SessionService) SearchSessions(ctx context.Context, cr *domain.SessionSearchCriteria) (*domain.SessionSearchResponse, error) {
 	ret := _m.Called(ctx, cr)
@@ -158,6 +208,32 @@ func (_m *SessionService) SearchSessions(ctx context.Context, cr *domain.Session
 	return r0, r1
 }
 
+// SetStopReason provides a mock function with given fields: ctx, sessId, reason
+func (_m *SessionService) SetStopReason(ctx context.Context, sessId string, reason *domain.SessionStopReason) (*domain.Session, error) {
+	ret := _m.Called(ctx, sessId, reason)
+
+	var r0 *domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.SessionStopReason) (*domain.Session, error)); ok {
+		return rf(ctx, sessId, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.SessionStopReason) *domain.Session); ok {
+		r0 = rf(ctx, sessId, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.SessionStopReason) error); ok {
+		r1 = rf(ctx, sessId, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewSessionService creates a new instance of SessionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSessionService(t interface {
@@ -169,6 +169,34 @@ func (_m *SessionStorage) UpdateSession(ctx context.Context, sess *domain.Sessio
 	return r0
 }
 
+// DeleteSessionsByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *SessionStorage) DeleteSessionsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeSessions provides a mock function with given fields: ctx, sessions
+func (_m *SessionStorage) MergeSessions(ctx context.Context, sessions []*domain.Session) error {
+	ret := _m.Called(ctx, sessions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Session) error); ok {
+		r0 = rf(ctx, sessions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewSessionStorage creates a new instance of SessionStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSessionStorage(t interface {
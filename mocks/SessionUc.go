@@ -5,11 +5,14 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
 	domain "github.com/mikhailbolshakov/ocpi/domain"
 
+	mock "github.com/stretchr/testify/mock"
+
 	model "github.com/mikhailbolshakov/ocpi/model"
 
+	ocpi "github.com/mikhailbolshakov/ocpi"
+
 	time "time"
 )
 
@@ -18,6 +21,46 @@ type SessionUc struct {
 	mock.Mock
 }
 
+// GetSessionIssues provides a mock function with given fields: ctx
+func (_m *SessionUc) GetSessionIssues(ctx context.Context) ([]*domain.SessionIssueReport, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*domain.SessionIssueReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.SessionIssueReport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.SessionIssueReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.SessionIssueReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Init provides a mock function with given fields: ctx, cfg
+func (_m *SessionUc) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
+	ret := _m.Called(ctx, cfg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *ocpi.CfgOcpiConfig) error); ok {
+		r0 = rf(ctx, cfg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnLocalSessionChanged provides a mock function with given fields: ctx, sess
 func (_m *SessionUc) OnLocalSessionChanged(ctx context.Context, sess *domain.Session) error {
 	ret := _m.Called(ctx, sess)
@@ -46,6 +89,20 @@ func (_m *SessionUc) OnLocalSessionPatched(ctx context.Context, sess *domain.Ses
 	return r0
 }
 
+// OnRemotePlatformPull provides a mock function with given fields: ctx, platformId, from, to
+func (_m *SessionUc) OnRemotePlatformPull(ctx context.Context, platformId string, from *time.Time, to *time.Time) error {
+	ret := _m.Called(ctx, platformId, from, to)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) error); ok {
+		r0 = rf(ctx, platformId, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnRemoteSessionPatch provides a mock function with given fields: ctx, platformId, sess
 func (_m *SessionUc) OnRemoteSessionPatch(ctx context.Context, platformId string, sess *model.OcpiSession) error {
 	ret := _m.Called(ctx, platformId, sess)
@@ -102,6 +159,25 @@ func (_m *SessionUc) OnRemoteSessionsPullWhenPushNotSupported(ctx context.Contex
 	return r0
 }
 
+// OnTokenBlocked provides a mock function with given fields: ctx, tkn
+func (_m *SessionUc) OnTokenBlocked(ctx context.Context, tkn *domain.Token) error {
+	ret := _m.Called(ctx, tkn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Token) error); ok {
+		r0 = rf(ctx, tkn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionMonitorCronHandler provides a mock function with given fields: ctx
+func (_m *SessionUc) SessionMonitorCronHandler(ctx context.Context) {
+	_m.Called(ctx)
+}
+
 // NewSessionUc creates a new instance of SessionUc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSessionUc(t interface {
@@ -146,6 +146,55 @@ func (_m *TariffService) Validate(ctx context.Context, trf *domain.Tariff) error
 	return r0
 }
 
+// DeleteTariffsByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *TariffService) DeleteTariffsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PutTariffs provides a mock function with given fields: ctx, trfs
+func (_m *TariffService) PutTariffs(ctx context.Context, trfs []*domain.Tariff) ([]*domain.Tariff, []*domain.BulkItemResult, error) {
+	ret := _m.Called(ctx, trfs)
+
+	var r0 []*domain.Tariff
+	var r1 []*domain.BulkItemResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Tariff) ([]*domain.Tariff, []*domain.BulkItemResult, error)); ok {
+		return rf(ctx, trfs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Tariff) []*domain.Tariff); ok {
+		r0 = rf(ctx, trfs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Tariff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Tariff) []*domain.BulkItemResult); ok {
+		r1 = rf(ctx, trfs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*domain.BulkItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []*domain.Tariff) error); ok {
+		r2 = rf(ctx, trfs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewTariffService creates a new instance of TariffService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTariffService(t interface {
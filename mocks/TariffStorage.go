@@ -108,6 +108,34 @@ func (_m *TariffStorage) UpdateTariff(ctx context.Context, trf *domain.Tariff) e
 	return r0
 }
 
+// DeleteTariffsByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *TariffStorage) DeleteTariffsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeTariffs provides a mock function with given fields: ctx, trfs
+func (_m *TariffStorage) MergeTariffs(ctx context.Context, trfs []*domain.Tariff) error {
+	ret := _m.Called(ctx, trfs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Tariff) error); ok {
+		r0 = rf(ctx, trfs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTariffStorage creates a new instance of TariffStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTariffStorage(t interface {
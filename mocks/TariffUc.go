@@ -88,6 +88,20 @@ func (_m *TariffUc) OnRemoteTariffsPullWhenPushNotSupported(ctx context.Context,
 	return r0
 }
 
+// OnRemotePlatformPull provides a mock function with given fields: ctx, platformId, from, to
+func (_m *TariffUc) OnRemotePlatformPull(ctx context.Context, platformId string, from *time.Time, to *time.Time) error {
+	ret := _m.Called(ctx, platformId, from, to)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) error); ok {
+		r0 = rf(ctx, platformId, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTariffUc creates a new instance of TariffUc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTariffUc(t interface {
@@ -146,6 +146,55 @@ func (_m *TokenService) ValidateToken(ctx context.Context, tkn *domain.Token) er
 	return r0
 }
 
+// DeleteTokensByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *TokenService) DeleteTokensByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PutTokens provides a mock function with given fields: ctx, tkns
+func (_m *TokenService) PutTokens(ctx context.Context, tkns []*domain.Token) ([]*domain.Token, []*domain.BulkItemResult, error) {
+	ret := _m.Called(ctx, tkns)
+
+	var r0 []*domain.Token
+	var r1 []*domain.BulkItemResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Token) ([]*domain.Token, []*domain.BulkItemResult, error)); ok {
+		return rf(ctx, tkns)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Token) []*domain.Token); ok {
+		r0 = rf(ctx, tkns)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Token) []*domain.BulkItemResult); ok {
+		r1 = rf(ctx, tkns)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*domain.BulkItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []*domain.Token) error); ok {
+		r2 = rf(ctx, tkns)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewTokenService creates a new instance of TokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenService(t interface {
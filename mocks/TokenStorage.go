@@ -108,6 +108,34 @@ func (_m *TokenStorage) UpdateToken(ctx context.Context, tkn *domain.Token) erro
 	return r0
 }
 
+// DeleteTokensByPlatform provides a mock function with given fields: ctx, platformId, archive
+func (_m *TokenStorage) DeleteTokensByPlatform(ctx context.Context, platformId string, archive bool) error {
+	ret := _m.Called(ctx, platformId, archive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, platformId, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeTokens provides a mock function with given fields: ctx, tkns
+func (_m *TokenStorage) MergeTokens(ctx context.Context, tkns []*domain.Token) error {
+	ret := _m.Called(ctx, tkns)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Token) error); ok {
+		r0 = rf(ctx, tkns)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTokenStorage creates a new instance of TokenStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenStorage(t interface {
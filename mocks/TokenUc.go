@@ -5,12 +5,15 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
 	domain "github.com/mikhailbolshakov/ocpi/domain"
 
+	mock "github.com/stretchr/testify/mock"
+
 	model "github.com/mikhailbolshakov/ocpi/model"
 
 	time "time"
+
+	usecase "github.com/mikhailbolshakov/ocpi/usecase"
 )
 
 // TokenUc is an autogenerated mock type for the TokenUc type
@@ -58,6 +61,20 @@ func (_m *TokenUc) OnLocalTokenChanged(ctx context.Context, tkn *domain.Token) e
 	return r0
 }
 
+// OnRemotePlatformPull provides a mock function with given fields: ctx, platformId, from, to
+func (_m *TokenUc) OnRemotePlatformPull(ctx context.Context, platformId string, from *time.Time, to *time.Time) error {
+	ret := _m.Called(ctx, platformId, from, to)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) error); ok {
+		r0 = rf(ctx, platformId, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnRemoteTokenPatch provides a mock function with given fields: ctx, platformId, tkn
 func (_m *TokenUc) OnRemoteTokenPatch(ctx context.Context, platformId string, tkn *model.OcpiToken) error {
 	ret := _m.Called(ctx, platformId, tkn)
@@ -114,6 +131,11 @@ func (_m *TokenUc) OnRemoteTokensPullWhenPushNotSupported(ctx context.Context, f
 	return r0
 }
 
+// SetSessionUc provides a mock function with given fields: sessionUc
+func (_m *TokenUc) SetSessionUc(sessionUc usecase.SessionUc) {
+	_m.Called(sessionUc)
+}
+
 // NewTokenUc creates a new instance of TokenUc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenUc(t interface {
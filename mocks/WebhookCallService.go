@@ -43,6 +43,20 @@ func (_m *WebhookCallService) OnCdrChanged(ctx context.Context, cdr *backend.Cdr
 	return r0
 }
 
+// OnCdrMissing provides a mock function with given fields: ctx, sess
+func (_m *WebhookCallService) OnCdrMissing(ctx context.Context, sess *backend.Session) error {
+	ret := _m.Called(ctx, sess)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.Session) error); ok {
+		r0 = rf(ctx, sess)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnCommandResponse provides a mock function with given fields: ctx, cmd
 func (_m *WebhookCallService) OnCommandResponse(ctx context.Context, cmd *backend.Command) error {
 	ret := _m.Called(ctx, cmd)
@@ -78,6 +92,20 @@ func (_m *WebhookCallService) OnConnectorChanged(ctx context.Context, cons ...*b
 	return r0
 }
 
+// OnDisputeChanged provides a mock function with given fields: ctx, d
+func (_m *WebhookCallService) OnDisputeChanged(ctx context.Context, d *backend.Dispute) error {
+	ret := _m.Called(ctx, d)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.Dispute) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnEvseChanged provides a mock function with given fields: ctx, evses
 func (_m *WebhookCallService) OnEvseChanged(ctx context.Context, evses ...*backend.Evse) error {
 	_va := make([]interface{}, len(evses))
@@ -99,6 +127,20 @@ func (_m *WebhookCallService) OnEvseChanged(ctx context.Context, evses ...*backe
 	return r0
 }
 
+// OnFinancialAdviceChanged provides a mock function with given fields: ctx, fa
+func (_m *WebhookCallService) OnFinancialAdviceChanged(ctx context.Context, fa *backend.FinancialAdvice) error {
+	ret := _m.Called(ctx, fa)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.FinancialAdvice) error); ok {
+		r0 = rf(ctx, fa)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnLocationsChanged provides a mock function with given fields: ctx, locs
 func (_m *WebhookCallService) OnLocationsChanged(ctx context.Context, locs ...*backend.Location) error {
 	_va := make([]interface{}, len(locs))
@@ -141,6 +183,48 @@ func (_m *WebhookCallService) OnPartiesChanged(ctx context.Context, parties ...*
 	return r0
 }
 
+// OnPlatformDisconnected provides a mock function with given fields: ctx, p
+func (_m *WebhookCallService) OnPlatformDisconnected(ctx context.Context, p *backend.Platform) error {
+	ret := _m.Called(ctx, p)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.Platform) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnPlatformStatusChanged provides a mock function with given fields: ctx, p
+func (_m *WebhookCallService) OnPlatformStatusChanged(ctx context.Context, p *backend.Platform) error {
+	ret := _m.Called(ctx, p)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.Platform) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnReservationChanged provides a mock function with given fields: ctx, r
+func (_m *WebhookCallService) OnReservationChanged(ctx context.Context, r *backend.ChargeReservation) error {
+	ret := _m.Called(ctx, r)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.ChargeReservation) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnReserveNow provides a mock function with given fields: ctx, cmd
 func (_m *WebhookCallService) OnReserveNow(ctx context.Context, cmd *backend.Command) error {
 	ret := _m.Called(ctx, cmd)
@@ -155,6 +239,34 @@ func (_m *WebhookCallService) OnReserveNow(ctx context.Context, cmd *backend.Com
 	return r0
 }
 
+// OnSessionLimitExceeded provides a mock function with given fields: ctx, sess
+func (_m *WebhookCallService) OnSessionLimitExceeded(ctx context.Context, sess *backend.Session) error {
+	ret := _m.Called(ctx, sess)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.Session) error); ok {
+		r0 = rf(ctx, sess)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnSessionStale provides a mock function with given fields: ctx, sess
+func (_m *WebhookCallService) OnSessionStale(ctx context.Context, sess *backend.Session) error {
+	ret := _m.Called(ctx, sess)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.Session) error); ok {
+		r0 = rf(ctx, sess)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnSessionsChanged provides a mock function with given fields: ctx, sessions
 func (_m *WebhookCallService) OnSessionsChanged(ctx context.Context, sessions ...*backend.Session) error {
 	_va := make([]interface{}, len(sessions))
@@ -225,6 +337,48 @@ func (_m *WebhookCallService) OnTariffsChanged(ctx context.Context, tariffs ...*
 	return r0
 }
 
+// OnTerminalChanged provides a mock function with given fields: ctx, t
+func (_m *WebhookCallService) OnTerminalChanged(ctx context.Context, t *backend.Terminal) error {
+	ret := _m.Called(ctx, t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.Terminal) error); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnTokenBlocked provides a mock function with given fields: ctx, tb
+func (_m *WebhookCallService) OnTokenBlocked(ctx context.Context, tb *backend.TokenBlocked) error {
+	ret := _m.Called(ctx, tb)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.TokenBlocked) error); ok {
+		r0 = rf(ctx, tb)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnTokenRotationFailed provides a mock function with given fields: ctx, r
+func (_m *WebhookCallService) OnTokenRotationFailed(ctx context.Context, r *backend.TokenRotation) error {
+	ret := _m.Called(ctx, r)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *backend.TokenRotation) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnTokensChanged provides a mock function with given fields: ctx, tokens
 func (_m *WebhookCallService) OnTokensChanged(ctx context.Context, tokens ...*backend.Token) error {
 	_va := make([]interface{}, len(tokens))
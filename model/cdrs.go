@@ -56,6 +56,7 @@ type OcpiCdr struct {
 	Credit                   bool                  `json:"credit"`                            // Credit when set to true, this is a Credit CDR, and the field credit_reference_id needs to be set as wel
 	CreditReferenceId        string                `json:"credit_reference_id,omitempty"`     // CreditReferenceId to be set for a Credit CDR
 	HomeChargingCompensation bool                  `json:"home_charging_compensation"`        // HomeChargingCompensation when set to true, this CDR is for a charging session using the home charge
+	TaxAmounts               []*OcpiTaxAmount      `json:"tax_amounts,omitempty"`             // TaxAmounts breakdown of the taxes applied to the total cost (2.3.0)
 	LastUpdated              time.Time             `json:"last_updated"`                      // LastUpdated when updated or created
 }
 
@@ -9,6 +9,11 @@ const (
 	ModuleIdSessions      = "sessions"
 	ModuleIdTariffs       = "tariffs"
 	ModuleIdTokens        = "tokens"
+	ModuleIdPayments      = "payments"
+
+	OcpiVersion211 = "2.1.1"
+	OcpiVersion221 = "2.2.1"
+	OcpiVersion230 = "2.3.0"
 
 	OcpiStatusCodeOk                   = 1000
 	OcpiStatusGenClientError           = 2000
@@ -48,11 +53,13 @@ const (
 	OcpiCtxToCountryCode   = "ocpi-to-cc"
 	OcpiCtxToParty         = "ocpi-to-party"
 	OcpiCtxPlatform        = "ocpi-platform"
+	OcpiCtxThrottled       = "ocpi-throttled"
 
 	OcpiQueryParamDateFrom = "date_from"
 	OcpiQueryParamDateTo   = "date_to"
 	OcpiQueryParamOffset   = "offset"
 	OcpiQueryParamLimit    = "limit"
+	OcpiQueryParamCursor   = "cursor"
 
 	OcpiQueryParamPartyId     = "party_id"
 	OcpiQueryParamCountryCode = "country_code"
@@ -98,5 +105,6 @@ var (
 		ModuleIdSessions:      {},
 		ModuleIdTariffs:       {},
 		ModuleIdTokens:        {},
+		ModuleIdPayments:      {},
 	}
 )
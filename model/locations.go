@@ -54,9 +54,36 @@ type OcpiConnector struct {
 	MaxElectricPower   *float64  `json:"max_electric_power,omitempty"`   // MaxElectricPower maximum electric power in W
 	TariffIds          []string  `json:"tariff_ids,omitempty"`           // TariffIds charging tariffs
 	TermsAndConditions string    `json:"terms_and_conditions,omitempty"` // TermsAndConditions url of operator’s terms and conditions
+	Capabilities       []string  `json:"capabilities,omitempty"`         // Capabilities list of functionalities that the connector is capable of (2.3.0)
 	LastUpdated        time.Time `json:"last_updated"`                   // LastUpdated when updated or created
 }
 
+type OcpiParking struct {
+	Id                    string   `json:"id"`                                // Id uniquely identifies the parking place within the location
+	PhysicalReference     string   `json:"physical_reference,omitempty"`      // PhysicalReference number/string printed on the parking place
+	VehicleTypes          []string `json:"vehicle_types,omitempty"`           // VehicleTypes types of vehicles the parking place is intended for
+	MaxVehicleWeight      *float64 `json:"max_vehicle_weight,omitempty"`      // MaxVehicleWeight maximum vehicle weight in kg
+	MaxVehicleHeight      *float64 `json:"max_vehicle_height,omitempty"`      // MaxVehicleHeight maximum vehicle height in cm
+	MaxVehicleLength      *float64 `json:"max_vehicle_length,omitempty"`      // MaxVehicleLength maximum vehicle length in cm
+	MaxVehicleWidth       *float64 `json:"max_vehicle_width,omitempty"`       // MaxVehicleWidth maximum vehicle width in cm
+	ParkingSpaceLength    *float64 `json:"parking_space_length,omitempty"`    // ParkingSpaceLength length of the parking space in cm
+	ParkingSpaceWidth     *float64 `json:"parking_space_width,omitempty"`     // ParkingSpaceWidth width of the parking space in cm
+	DangerousGoodsAllowed *bool    `json:"dangerous_goods_allowed,omitempty"` // DangerousGoodsAllowed if vehicles with dangerous goods are allowed
+	Direction             string   `json:"direction,omitempty"`               // Direction of the parking place relative to the driving direction
+	DriveThrough          *bool    `json:"drive_through,omitempty"`           // DriveThrough if the parking place is a drive-through one
+	RestrictedToType      *bool    `json:"restricted_to_type,omitempty"`      // RestrictedToType if only the vehicle types listed may park
+	ReservationRequired   *bool    `json:"reservation_required,omitempty"`    // ReservationRequired if the parking place must be reserved
+	TimeLimit             *float64 `json:"time_limit,omitempty"`              // TimeLimit maximum parking time in minutes
+	Roofed                *bool    `json:"roofed,omitempty"`                  // Roofed if the parking place is roofed
+	Lighting              *bool    `json:"lighting,omitempty"`                // Lighting if the parking place is lit
+	RefrigerationOutlet   *bool    `json:"refrigeration_outlet,omitempty"`    // RefrigerationOutlet if a refrigeration outlet is available
+}
+
+type OcpiEvseParking struct {
+	ParkingId    string `json:"parking_id"`              // ParkingId reference to the parking place of the location
+	EvsePosition string `json:"evse_position,omitempty"` // EvsePosition position of the EVSE relative to the parking place
+}
+
 type OcpiEvse struct {
 	Uid                 string                `json:"uid"`                            // Uid identifies the EVSE within the CPOs platform
 	EvseId              string                `json:"evse_id,omitempty"`              // EvseId following specification for EVSE ID from "eMI3 standard version V1.0". Can be reused
@@ -70,6 +97,7 @@ type OcpiEvse struct {
 	Directions          []*OcpiDisplayText    `json:"directions,omitempty"`           // Directions human-readable directions
 	ParkingRestrictions []string              `json:"parking_restrictions,omitempty"` // ParkingRestrictions restrictions that apply to the parking spot
 	Images              []*OcpiImage          `json:"images,omitempty"`               // Images related to the EVSE
+	Parking             []*OcpiEvseParking    `json:"parking,omitempty"`              // Parking references to the parking places the EVSE can be reached from (2.3.0)
 	LastUpdated         time.Time             `json:"last_updated"`                   // LastUpdated when updated or created
 }
 
@@ -98,6 +126,8 @@ type OcpiLocation struct {
 	ChargingWhenClosed *bool                        `json:"charging_when_closed,omitempty"` // ChargingWhenClosed if the EVSEs are still charging outside the opening hours of the location
 	Images             []*OcpiImage                 `json:"images,omitempty"`               // Images links to images related to the location
 	EnergyMix          *OcpiEnergyMix               `json:"energy_mix,omitempty"`           // EnergyMix energy supplied at this location
+	HelpPhone          string                       `json:"help_phone,omitempty"`           // HelpPhone telephone number of the helpdesk of the operator (2.3.0)
+	ParkingPlaces      []*OcpiParking               `json:"parking_places,omitempty"`       // ParkingPlaces parking places at the location (2.3.0)
 	LastUpdated        time.Time                    `json:"last_updated"`                   // LastUpdated when updated or created
 }
 
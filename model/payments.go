@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+type OcpiTerminal struct {
+	OcpiPartyId
+	Id                string           `json:"terminal_id"`                  // Id uniquely identifies the terminal
+	CustomerReference string           `json:"customer_reference,omitempty"` // CustomerReference reference of the customer the terminal is installed for
+	Address           string           `json:"address,omitempty"`            // Address street/block name and house number
+	City              string           `json:"city,omitempty"`               // City or town
+	PostalCode        string           `json:"postal_code,omitempty"`        // PostalCode of the terminal
+	State             string           `json:"state,omitempty"`              // State or province of the terminal
+	Country           string           `json:"country,omitempty"`            // Country alpha-3 code for the country
+	Coordinates       *OcpiGeoLocation `json:"coordinates,omitempty"`        // Coordinates of the terminal
+	InvoiceBaseUrl    string           `json:"invoice_base_url,omitempty"`   // InvoiceBaseUrl base url to download invoices
+	InvoiceCreator    string           `json:"invoice_creator,omitempty"`    // InvoiceCreator party creating invoices
+	Reference         string           `json:"reference,omitempty"`          // Reference of the terminal given by the PTP
+	LocationIds       []string         `json:"location_ids,omitempty"`       // LocationIds locations the terminal is assigned to
+	EvseUids          []string         `json:"evse_uids,omitempty"`          // EvseUids EVSEs the terminal is assigned to
+	LastUpdated       time.Time        `json:"last_updated"`                 // LastUpdated timestamp when this terminal was last updated
+}
+
+type OcpiFinancialAdviceConfirmation struct {
+	OcpiPartyId
+	Id                   string    `json:"id"`                               // Id uniquely identifies the financial advice confirmation
+	AuthRef              string    `json:"authorization_reference"`          // AuthRef reference to the authorization of the session or cdr
+	TotalCosts           OcpiPrice `json:"total_costs"`                      // TotalCosts captured amount
+	Currency             string    `json:"currency"`                         // Currency ISO-4217 code
+	EftData              []string  `json:"eft_data,omitempty"`               // EftData electronic funds transfer data received from the terminal
+	CaptureStatusCode    string    `json:"capture_status_code"`              // CaptureStatusCode status of the capture
+	CaptureStatusMessage string    `json:"capture_status_message,omitempty"` // CaptureStatusMessage message of the capture status
+	LastUpdated          time.Time `json:"last_updated"`                     // LastUpdated timestamp when this confirmation was last updated
+}
+
+type OcpiTerminalsResponse struct {
+	OcpiResponse
+	Data []*OcpiTerminal `json:"data"`
+}
+
+type OcpiTerminalResponse struct {
+	OcpiResponse
+	Data *OcpiTerminal `json:"data,omitempty"`
+}
+
+type OcpiFinancialAdviceConfirmationsResponse struct {
+	OcpiResponse
+	Data []*OcpiFinancialAdviceConfirmation `json:"data"`
+}
@@ -7,6 +7,13 @@ type OcpiPrice struct {
 	InclVat *float64 `json:"incl_vat,omitempty"` // InclVat Price/Cost including VAT
 }
 
+type OcpiTaxAmount struct {
+	Name          string   `json:"name"`                     // Name of the tax
+	AccountNumber string   `json:"account_number,omitempty"` // AccountNumber tax account number of the party
+	Percentage    *float64 `json:"percentage,omitempty"`     // Percentage of the tax
+	Amount        float64  `json:"amount"`                   // Amount of the tax
+}
+
 type OcpiTariffRestrictions struct {
 	StartTime   string     `json:"start_time,omitempty"`   // StartTime time of day in local time
 	EndTime     string     `json:"end_time,omitempty"`     // EndTime time of day in local time
@@ -38,18 +45,20 @@ type OcpiTariffElement struct {
 
 type OcpiTariff struct {
 	OcpiPartyId
-	Id            string               `json:"id"`                        // Id uniquely identifies the tariff
-	Currency      string               `json:"currency"`                  // Currency ISO-4217 code
-	Type          string               `json:"type,omitempty"`            // Type of the tariff
-	TariffAltText []*OcpiDisplayText   `json:"tariff_alt_text,omitempty"` // TariffAltText list of multi-language alternative tariff info texts
-	TariffAltUrl  string               `json:"tariff_alt_url,omitempty"`  // TariffAltUrl web page that contains an explanation of the tariff
-	MinPrice      *OcpiPrice           `json:"min_price,omitempty"`       // MinPrice minimum possible price
-	MaxPrice      *OcpiPrice           `json:"max_price,omitempty"`       // MaxPrice maximum possible price
-	Elements      []*OcpiTariffElement `json:"elements"`                  // Elements list of tariff elements
-	StartDateTime *time.Time           `json:"start_date_time,omitempty"` // StartDateTime when this tariff becomes active in UTC
-	EndDateTime   *time.Time           `json:"end_date_time,omitempty"`   // EndDateTime when this tariff no longer valid in UTC
-	EnergyMix     *OcpiEnergyMix       `json:"energy_mix,omitempty"`      // EnergyMix details on the energy supplied with this tariff
-	LastUpdated   time.Time            `json:"last_updated"`              // LastUpdated when this Tariff was last updated
+	Id                 string               `json:"id"`                            // Id uniquely identifies the tariff
+	Currency           string               `json:"currency"`                      // Currency ISO-4217 code
+	Type               string               `json:"type,omitempty"`                // Type of the tariff
+	TariffAltText      []*OcpiDisplayText   `json:"tariff_alt_text,omitempty"`     // TariffAltText list of multi-language alternative tariff info texts
+	TariffAltUrl       string               `json:"tariff_alt_url,omitempty"`      // TariffAltUrl web page that contains an explanation of the tariff
+	MinPrice           *OcpiPrice           `json:"min_price,omitempty"`           // MinPrice minimum possible price
+	MaxPrice           *OcpiPrice           `json:"max_price,omitempty"`           // MaxPrice maximum possible price
+	Elements           []*OcpiTariffElement `json:"elements"`                      // Elements list of tariff elements
+	StartDateTime      *time.Time           `json:"start_date_time,omitempty"`     // StartDateTime when this tariff becomes active in UTC
+	EndDateTime        *time.Time           `json:"end_date_time,omitempty"`       // EndDateTime when this tariff no longer valid in UTC
+	EnergyMix          *OcpiEnergyMix       `json:"energy_mix,omitempty"`          // EnergyMix details on the energy supplied with this tariff
+	PreauthorizeAmount *OcpiPrice           `json:"preauthorize_amount,omitempty"` // PreauthorizeAmount amount to be pre-authorized on a payment terminal for ad hoc payment (2.3.0)
+	TaxIncluded        string               `json:"tax_included,omitempty"`        // TaxIncluded whether taxes are included in the prices of the tariff (2.3.0)
+	LastUpdated        time.Time            `json:"last_updated"`                  // LastUpdated when this Tariff was last updated
 }
 
 type OcpiTariffsResponse struct {
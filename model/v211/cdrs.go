@@ -0,0 +1,35 @@
+package v211
+
+import (
+	"github.com/mikhailbolshakov/ocpi/model"
+	"time"
+)
+
+type OcpiCdr struct {
+	Id               string                `json:"id"`                           // Id unique id that identifies the cdr
+	StartDateTime    time.Time             `json:"start_date_time"`              // StartDateTime timestamp of the charging session
+	StopDateTime     time.Time             `json:"stop_date_time"`               // StopDateTime timestamp when the session was completed/finished
+	AuthId           string                `json:"auth_id"`                      // AuthId reference to a token, identified by the auth_id field of the Token
+	AuthMethod       string                `json:"auth_method"`                  // AuthMethod method used for authentication
+	Location         *OcpiLocation         `json:"location"`                     // Location where the charging session took place, contains only the EVSE and connector used
+	MeterId          string                `json:"meter_id,omitempty"`           // MeterId identification of the Meter inside the Charge Point
+	Currency         string                `json:"currency"`                     // Currency of the CDR in ISO 4217 Code
+	Tariffs          []*OcpiTariff         `json:"tariffs,omitempty"`            // Tariffs list of relevant tariffs
+	ChargingPeriods  []*OcpiChargingPeriod `json:"charging_periods"`             // ChargingPeriods list of Charging Periods that make up this charging session
+	TotalCost        float64               `json:"total_cost"`                   // TotalCost total cost (excluding VAT) of this transaction
+	TotalEnergy      float64               `json:"total_energy"`                 // TotalEnergy charged, in kWh
+	TotalTime        float64               `json:"total_time"`                   // TotalTime total duration of the charging session in hours
+	TotalParkingTime *float64              `json:"total_parking_time,omitempty"` // TotalParkingTime total duration of the charging session where the EV was not charging in hours
+	Remark           string                `json:"remark,omitempty"`             // Remark can be used to provide additional human readable information
+	LastUpdated      time.Time             `json:"last_updated"`                 // LastUpdated when updated or created
+}
+
+type OcpiCdrsResponse struct {
+	model.OcpiResponse
+	Data []*OcpiCdr `json:"data"`
+}
+
+type OcpiCdrResponse struct {
+	model.OcpiResponse
+	Data *OcpiCdr `json:"data"`
+}
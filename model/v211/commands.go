@@ -0,0 +1,48 @@
+package v211
+
+import (
+	"github.com/mikhailbolshakov/ocpi/model"
+	"time"
+)
+
+const (
+	CommandResponseNotSupported   = "NOT_SUPPORTED"
+	CommandResponseRejected       = "REJECTED"
+	CommandResponseAccepted       = "ACCEPTED"
+	CommandResponseTimeout        = "TIMEOUT"
+	CommandResponseUnknownSession = "UNKNOWN_SESSION"
+)
+
+type OcpiReserveNow struct {
+	ResponseUrl   string     `json:"response_url"`       // ResponseUrl URL that the CommandResponse POST should be sent to
+	Token         *OcpiToken `json:"token"`              // Token object the Charge Point has to use to start a new session
+	ExpiryDate    time.Time  `json:"expiry_date"`        // ExpiryDate when this reservation ends, in UTC
+	ReservationId int        `json:"reservation_id"`     // ReservationId unique for this reservation
+	LocationId    string     `json:"location_id"`        // LocationId on which a session is to be started
+	EvseId        string     `json:"evse_uid,omitempty"` // EvseId of the EVSE of this Location on which a session is to be started
+}
+
+type OcpiStartSession struct {
+	ResponseUrl string     `json:"response_url"`       // ResponseUrl URL that the CommandResponse POST should be sent to
+	Token       *OcpiToken `json:"token"`              // Token object the Charge Point has to use to start a new session
+	LocationId  string     `json:"location_id"`        // LocationId on which a session is to be started
+	EvseId      string     `json:"evse_uid,omitempty"` // EvseId of the EVSE of this Location on which a session is to be started
+}
+
+type OcpiStopSession struct {
+	ResponseUrl string `json:"response_url"` // ResponseUrl URL that the CommandResponse POST should be sent to
+	SessionId   string `json:"session_id"`   // SessionId of the Session that is requested to be stopped
+}
+
+type OcpiUnlockConnector struct {
+	ResponseUrl string `json:"response_url"` // ResponseUrl URL that the CommandResponse POST should be sent to
+	LocationId  string `json:"location_id"`  // LocationId of the Location of the Connector
+	EvseId      string `json:"evse_uid"`     // EvseId of the EVSE of this Location of the Connector
+	ConnectorId string `json:"connector_id"` // ConnectorId of the Connector of the EVSE to be unlocked
+}
+
+// OcpiCommandResponse 2.1.1 uses the same object both as a synchronous response and as an asynchronous result
+type OcpiCommandResponse struct {
+	Result  string                   `json:"result"`            // Result of the command request
+	Message []*model.OcpiDisplayText `json:"message,omitempty"` // Message human-readable description of the result
+}
@@ -0,0 +1,877 @@
+package v211
+
+import (
+	"github.com/mikhailbolshakov/ocpi/model"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// 2.1.1 doesn't distinguish sender and receiver interfaces in version details,
+// the role of a module endpoint is defined by the role of the platform exposing it
+var moduleRoles = map[string]map[string]string{
+	model.OcpiRoleCPO: {
+		model.ModuleIdCredentials: model.OcpiSender,
+		model.ModuleIdLocations:   model.OcpiSender,
+		model.ModuleIdSessions:    model.OcpiSender,
+		model.ModuleIdCdrs:        model.OcpiSender,
+		model.ModuleIdTariffs:     model.OcpiSender,
+		model.ModuleIdTokens:      model.OcpiReceiver,
+		model.ModuleIdCommands:    model.OcpiReceiver,
+	},
+	model.OcpiRoleEMSP: {
+		model.ModuleIdCredentials: model.OcpiSender,
+		model.ModuleIdLocations:   model.OcpiReceiver,
+		model.ModuleIdSessions:    model.OcpiReceiver,
+		model.ModuleIdCdrs:        model.OcpiReceiver,
+		model.ModuleIdTariffs:     model.OcpiReceiver,
+		model.ModuleIdTokens:      model.OcpiSender,
+		model.ModuleIdCommands:    model.OcpiSender,
+	},
+}
+
+// ModuleRoles returns roles of the module interface exposed by a platform with the given role
+// if the platform role isn't either CPO or EMSP, both roles are returned
+func ModuleRoles(module, platformRole string) []string {
+	if roles, ok := moduleRoles[platformRole]; ok {
+		if role, ok := roles[module]; ok {
+			return []string{role}
+		}
+		return nil
+	}
+	return []string{model.OcpiSender, model.OcpiReceiver}
+}
+
+// Modules returns modules supported by 2.1.1
+func Modules() []string {
+	var r []string
+	for m := range moduleRoles[model.OcpiRoleCPO] {
+		r = append(r, m)
+	}
+	sort.Strings(r)
+	return r
+}
+
+func VersionDetailsV211ToModel(vd *OcpiVersionDetails) *model.OcpiVersionDetails {
+	if vd == nil {
+		return nil
+	}
+	r := &model.OcpiVersionDetails{
+		Version: vd.Version,
+	}
+	for _, ep := range vd.Endpoints {
+		r.Endpoints = append(r.Endpoints, model.OcpiVersionModuleEndpoint{
+			Id:  ep.Id,
+			Url: ep.Url,
+		})
+	}
+	return r
+}
+
+// CredentialsModelToV211 flattens credentials taking the first role only, as 2.1.1 supports a single party per platform
+func CredentialsModelToV211(cred *model.OcpiCredentials) *OcpiCredentials {
+	if cred == nil {
+		return nil
+	}
+	r := &OcpiCredentials{
+		Token: cred.Token,
+		Url:   cred.Url,
+	}
+	if len(cred.Roles) > 0 && cred.Roles[0] != nil {
+		r.PartyId = cred.Roles[0].PartyId
+		r.CountryCode = cred.Roles[0].CountryCode
+		r.BusinessDetails = cred.Roles[0].BusinessDetails
+	}
+	return r
+}
+
+// CredentialsV211ToModel converts credentials to a single role, the role has to be taken from the platform as 2.1.1 doesn't pass it
+func CredentialsV211ToModel(cred *OcpiCredentials, role string) *model.OcpiCredentials {
+	if cred == nil {
+		return nil
+	}
+	r := &model.OcpiCredentials{
+		Token: cred.Token,
+		Url:   cred.Url,
+	}
+	if cred.PartyId != "" && cred.CountryCode != "" {
+		r.Roles = []*model.OcpiCredentialRole{
+			{
+				OcpiPartyId: model.OcpiPartyId{
+					PartyId:     cred.PartyId,
+					CountryCode: cred.CountryCode,
+				},
+				Role:            role,
+				BusinessDetails: cred.BusinessDetails,
+			},
+		}
+	}
+	return r
+}
+
+func locationTypeModelToV211(parkingType string) string {
+	switch parkingType {
+	case LocationTypeOnStreet, LocationTypeParkingGarage, LocationTypeUndergroundGarage, LocationTypeParkingLot:
+		return parkingType
+	case "":
+		return LocationTypeUnknown
+	default:
+		return LocationTypeOther
+	}
+}
+
+func locationTypeV211ToModel(locType string) string {
+	switch locType {
+	case LocationTypeOnStreet, LocationTypeParkingGarage, LocationTypeUndergroundGarage, LocationTypeParkingLot:
+		return locType
+	default:
+		return ""
+	}
+}
+
+func ConnectorModelToV211(con *model.OcpiConnector) *OcpiConnector {
+	if con == nil {
+		return nil
+	}
+	r := &OcpiConnector{
+		Id:                 con.Id,
+		Standard:           con.Standard,
+		Format:             con.Format,
+		PowerType:          con.PowerType,
+		Voltage:            int(math.Round(con.MaxVoltage)),
+		Amperage:           int(math.Round(con.MaxAmperage)),
+		TermsAndConditions: con.TermsAndConditions,
+		LastUpdated:        con.LastUpdated,
+	}
+	if len(con.TariffIds) > 0 {
+		r.TariffId = con.TariffIds[0]
+	}
+	return r
+}
+
+func ConnectorV211ToModel(con *OcpiConnector) *model.OcpiConnector {
+	if con == nil {
+		return nil
+	}
+	r := &model.OcpiConnector{
+		Id:                 con.Id,
+		Standard:           con.Standard,
+		Format:             con.Format,
+		PowerType:          con.PowerType,
+		MaxVoltage:         float64(con.Voltage),
+		MaxAmperage:        float64(con.Amperage),
+		TermsAndConditions: con.TermsAndConditions,
+		LastUpdated:        con.LastUpdated,
+	}
+	if con.TariffId != "" {
+		r.TariffIds = []string{con.TariffId}
+	}
+	return r
+}
+
+func EvseModelToV211(evse *model.OcpiEvse) *OcpiEvse {
+	if evse == nil {
+		return nil
+	}
+	r := &OcpiEvse{
+		Uid:                 evse.Uid,
+		EvseId:              evse.EvseId,
+		Status:              evse.Status,
+		StatusSchedule:      evse.StatusSchedule,
+		Capabilities:        evse.Capabilities,
+		FloorLevel:          evse.FloorLevel,
+		Coordinates:         evse.Coordinates,
+		PhysicalReference:   evse.PhysicalReference,
+		Directions:          evse.Directions,
+		ParkingRestrictions: evse.ParkingRestrictions,
+		Images:              evse.Images,
+		LastUpdated:         evse.LastUpdated,
+	}
+	for _, con := range evse.Connectors {
+		r.Connectors = append(r.Connectors, ConnectorModelToV211(con))
+	}
+	return r
+}
+
+func EvseV211ToModel(evse *OcpiEvse) *model.OcpiEvse {
+	if evse == nil {
+		return nil
+	}
+	r := &model.OcpiEvse{
+		Uid:                 evse.Uid,
+		EvseId:              evse.EvseId,
+		Status:              evse.Status,
+		StatusSchedule:      evse.StatusSchedule,
+		Capabilities:        evse.Capabilities,
+		FloorLevel:          evse.FloorLevel,
+		Coordinates:         evse.Coordinates,
+		PhysicalReference:   evse.PhysicalReference,
+		Directions:          evse.Directions,
+		ParkingRestrictions: evse.ParkingRestrictions,
+		Images:              evse.Images,
+		LastUpdated:         evse.LastUpdated,
+	}
+	for _, con := range evse.Connectors {
+		r.Connectors = append(r.Connectors, ConnectorV211ToModel(con))
+	}
+	return r
+}
+
+func LocationModelToV211(loc *model.OcpiLocation) *OcpiLocation {
+	if loc == nil {
+		return nil
+	}
+	r := &OcpiLocation{
+		Id:                 loc.Id,
+		Type:               locationTypeModelToV211(loc.ParkingType),
+		Name:               loc.Name,
+		Address:            loc.Address,
+		City:               loc.City,
+		PostalCode:         loc.PostalCode,
+		Country:            loc.Country,
+		Coordinates:        loc.Coordinates,
+		RelatedLocations:   loc.RelatedLocations,
+		Directions:         loc.Directions,
+		Operator:           loc.Operator,
+		SubOperator:        loc.SubOperator,
+		Owner:              loc.Owner,
+		Facilities:         loc.Facilities,
+		TimeZone:           loc.TimeZone,
+		OpeningTimes:       loc.OpeningTimes,
+		ChargingWhenClosed: loc.ChargingWhenClosed,
+		Images:             loc.Images,
+		EnergyMix:          loc.EnergyMix,
+		LastUpdated:        loc.LastUpdated,
+	}
+	for _, evse := range loc.Evses {
+		r.Evses = append(r.Evses, EvseModelToV211(evse))
+	}
+	return r
+}
+
+func LocationsModelToV211(locs []*model.OcpiLocation) []*OcpiLocation {
+	var r []*OcpiLocation
+	for _, loc := range locs {
+		r = append(r, LocationModelToV211(loc))
+	}
+	return r
+}
+
+// LocationV211ToModel converts location, party attributes aren't passed in 2.1.1 and must be populated by a caller
+// as 2.1.1 has no publish flag, a location is always published
+func LocationV211ToModel(loc *OcpiLocation) *model.OcpiLocation {
+	if loc == nil {
+		return nil
+	}
+	publish := true
+	r := &model.OcpiLocation{
+		Id:                 loc.Id,
+		Publish:            &publish,
+		Name:               loc.Name,
+		Address:            loc.Address,
+		City:               loc.City,
+		PostalCode:         loc.PostalCode,
+		Country:            loc.Country,
+		Coordinates:        loc.Coordinates,
+		RelatedLocations:   loc.RelatedLocations,
+		ParkingType:        locationTypeV211ToModel(loc.Type),
+		Directions:         loc.Directions,
+		Operator:           loc.Operator,
+		SubOperator:        loc.SubOperator,
+		Owner:              loc.Owner,
+		Facilities:         loc.Facilities,
+		TimeZone:           loc.TimeZone,
+		OpeningTimes:       loc.OpeningTimes,
+		ChargingWhenClosed: loc.ChargingWhenClosed,
+		Images:             loc.Images,
+		EnergyMix:          loc.EnergyMix,
+		LastUpdated:        loc.LastUpdated,
+	}
+	for _, evse := range loc.Evses {
+		r.Evses = append(r.Evses, EvseV211ToModel(evse))
+	}
+	return r
+}
+
+func LocationsV211ToModel(locs []*OcpiLocation) []*model.OcpiLocation {
+	var r []*model.OcpiLocation
+	for _, loc := range locs {
+		r = append(r, LocationV211ToModel(loc))
+	}
+	return r
+}
+
+// sessionLocation builds a location which contains only EVSE and connector as 2.1.1 requires the location object in sessions
+func sessionLocation(locId, evseId, conId string) *OcpiLocation {
+	r := &OcpiLocation{Id: locId}
+	if evseId != "" {
+		evse := &OcpiEvse{Uid: evseId}
+		if conId != "" {
+			evse.Connectors = []*OcpiConnector{{Id: conId}}
+		}
+		r.Evses = []*OcpiEvse{evse}
+	}
+	return r
+}
+
+// locationRef extracts location, EVSE and connector ids from the 2.1.1 location object
+func locationRef(loc *OcpiLocation) (locId, evseId, conId string) {
+	if loc == nil {
+		return
+	}
+	locId = loc.Id
+	if len(loc.Evses) > 0 && loc.Evses[0] != nil {
+		evseId = loc.Evses[0].Uid
+		if len(loc.Evses[0].Connectors) > 0 && loc.Evses[0].Connectors[0] != nil {
+			conId = loc.Evses[0].Connectors[0].Id
+		}
+	}
+	return
+}
+
+func authMethodModelToV211(m string) string {
+	// COMMAND auth method isn't supported by 2.1.1
+	if m == "COMMAND" {
+		return "AUTH_REQUEST"
+	}
+	return m
+}
+
+func sessionStatusModelToV211(st string) string {
+	// RESERVATION status isn't supported by 2.1.1
+	if st == "RESERVATION" {
+		return "PENDING"
+	}
+	return st
+}
+
+// cdrTokenV211ToModel 2.1.1 refers to a token by auth_id, which is supposed to be the same as the token uid
+func cdrTokenV211ToModel(authId string) *model.OcpiCdrToken {
+	if authId == "" {
+		return nil
+	}
+	return &model.OcpiCdrToken{
+		Id:         authId,
+		Type:       "OTHER",
+		ContractId: authId,
+	}
+}
+
+func cdrTokenModelToV211(tkn *model.OcpiCdrToken) string {
+	if tkn == nil {
+		return ""
+	}
+	if tkn.ContractId != "" {
+		return tkn.ContractId
+	}
+	return tkn.Id
+}
+
+func priceModelToV211(p *model.OcpiPrice) *float64 {
+	if p == nil {
+		return nil
+	}
+	v := p.ExclVat
+	return &v
+}
+
+func priceV211ToModel(p *float64) *model.OcpiPrice {
+	if p == nil {
+		return nil
+	}
+	return &model.OcpiPrice{ExclVat: *p}
+}
+
+func chargingPeriodsModelToV211(cps []*model.OcpiChargingPeriod) []*OcpiChargingPeriod {
+	var r []*OcpiChargingPeriod
+	for _, cp := range cps {
+		r = append(r, &OcpiChargingPeriod{
+			StartDateTime: cp.StartDateTime,
+			Dimensions:    cp.Dimensions,
+		})
+	}
+	return r
+}
+
+func chargingPeriodsV211ToModel(cps []*OcpiChargingPeriod) []*model.OcpiChargingPeriod {
+	var r []*model.OcpiChargingPeriod
+	for _, cp := range cps {
+		r = append(r, &model.OcpiChargingPeriod{
+			StartDateTime: cp.StartDateTime,
+			Dimensions:    cp.Dimensions,
+		})
+	}
+	return r
+}
+
+func SessionModelToV211(sess *model.OcpiSession) *OcpiSession {
+	if sess == nil {
+		return nil
+	}
+	return &OcpiSession{
+		Id:              sess.Id,
+		StartDateTime:   sess.StartDateTime,
+		EndDateTime:     sess.EndDateTime,
+		Kwh:             sess.Kwh,
+		AuthId:          cdrTokenModelToV211(sess.CdrToken),
+		AuthMethod:      authMethodModelToV211(sess.AuthMethod),
+		Location:        sessionLocation(sess.LocationId, sess.EvseId, sess.ConnectorId),
+		MeterId:         sess.MeterId,
+		Currency:        sess.Currency,
+		ChargingPeriods: chargingPeriodsModelToV211(sess.ChargingPeriods),
+		TotalCost:       priceModelToV211(sess.TotalCost),
+		Status:          sessionStatusModelToV211(sess.Status),
+		LastUpdated:     sess.LastUpdated,
+	}
+}
+
+func SessionsModelToV211(sessions []*model.OcpiSession) []*OcpiSession {
+	var r []*OcpiSession
+	for _, sess := range sessions {
+		r = append(r, SessionModelToV211(sess))
+	}
+	return r
+}
+
+// SessionV211ToModel converts session, party attributes aren't passed in 2.1.1 and must be populated by a caller
+func SessionV211ToModel(sess *OcpiSession) *model.OcpiSession {
+	if sess == nil {
+		return nil
+	}
+	r := &model.OcpiSession{
+		Id:              sess.Id,
+		StartDateTime:   sess.StartDateTime,
+		EndDateTime:     sess.EndDateTime,
+		Kwh:             sess.Kwh,
+		CdrToken:        cdrTokenV211ToModel(sess.AuthId),
+		AuthMethod:      sess.AuthMethod,
+		MeterId:         sess.MeterId,
+		Currency:        sess.Currency,
+		ChargingPeriods: chargingPeriodsV211ToModel(sess.ChargingPeriods),
+		TotalCost:       priceV211ToModel(sess.TotalCost),
+		Status:          sess.Status,
+		LastUpdated:     sess.LastUpdated,
+	}
+	r.LocationId, r.EvseId, r.ConnectorId = locationRef(sess.Location)
+	return r
+}
+
+func SessionsV211ToModel(sessions []*OcpiSession) []*model.OcpiSession {
+	var r []*model.OcpiSession
+	for _, sess := range sessions {
+		r = append(r, SessionV211ToModel(sess))
+	}
+	return r
+}
+
+func cdrLocationModelToV211(loc *model.OcpiCdrLocation) *OcpiLocation {
+	r := &OcpiLocation{
+		Id:          loc.Id,
+		Type:        LocationTypeUnknown,
+		Name:        loc.Name,
+		Address:     loc.Address,
+		City:        loc.City,
+		PostalCode:  loc.PostalCode,
+		Country:     loc.Country,
+		Coordinates: loc.Coordinates,
+	}
+	if loc.EvseUid != "" {
+		r.Evses = []*OcpiEvse{
+			{
+				Uid:    loc.EvseUid,
+				EvseId: loc.EvseId,
+				Connectors: []*OcpiConnector{
+					{
+						Id:        loc.ConnectorId,
+						Standard:  loc.ConnectorStandard,
+						Format:    loc.ConnectorFormat,
+						PowerType: loc.ConnectorPowerType,
+					},
+				},
+			},
+		}
+	}
+	return r
+}
+
+func cdrLocationV211ToModel(loc *OcpiLocation) model.OcpiCdrLocation {
+	if loc == nil {
+		return model.OcpiCdrLocation{}
+	}
+	r := model.OcpiCdrLocation{
+		Id:          loc.Id,
+		Name:        loc.Name,
+		Address:     loc.Address,
+		City:        loc.City,
+		PostalCode:  loc.PostalCode,
+		Country:     loc.Country,
+		Coordinates: loc.Coordinates,
+	}
+	if len(loc.Evses) > 0 && loc.Evses[0] != nil {
+		evse := loc.Evses[0]
+		r.EvseUid, r.EvseId = evse.Uid, evse.EvseId
+		if len(evse.Connectors) > 0 && evse.Connectors[0] != nil {
+			con := evse.Connectors[0]
+			r.ConnectorId, r.ConnectorStandard, r.ConnectorFormat, r.ConnectorPowerType = con.Id, con.Standard, con.Format, con.PowerType
+		}
+	}
+	return r
+}
+
+func CdrModelToV211(cdr *model.OcpiCdr) *OcpiCdr {
+	if cdr == nil {
+		return nil
+	}
+	return &OcpiCdr{
+		Id:               cdr.Id,
+		StartDateTime:    cdr.StartDateTime,
+		StopDateTime:     cdr.EndDateTime,
+		AuthId:           cdrTokenModelToV211(cdr.CdrToken),
+		AuthMethod:       authMethodModelToV211(cdr.AuthMethod),
+		Location:         cdrLocationModelToV211(&cdr.CdrLocation),
+		MeterId:          cdr.MeterId,
+		Currency:         cdr.Currency,
+		Tariffs:          TariffsModelToV211(cdr.Tariffs),
+		ChargingPeriods:  chargingPeriodsModelToV211(cdr.ChargingPeriods),
+		TotalCost:        cdr.TotalCost.ExclVat,
+		TotalEnergy:      cdr.TotalEnergy,
+		TotalTime:        cdr.TotalTime,
+		TotalParkingTime: cdr.TotalParkingTime,
+		Remark:           cdr.Remark,
+		LastUpdated:      cdr.LastUpdated,
+	}
+}
+
+func CdrsModelToV211(cdrs []*model.OcpiCdr) []*OcpiCdr {
+	var r []*OcpiCdr
+	for _, cdr := range cdrs {
+		r = append(r, CdrModelToV211(cdr))
+	}
+	return r
+}
+
+// CdrV211ToModel converts cdr, party attributes aren't passed in 2.1.1 and must be populated by a caller
+func CdrV211ToModel(cdr *OcpiCdr) *model.OcpiCdr {
+	if cdr == nil {
+		return nil
+	}
+	return &model.OcpiCdr{
+		Id:               cdr.Id,
+		StartDateTime:    cdr.StartDateTime,
+		EndDateTime:      cdr.StopDateTime,
+		CdrToken:         cdrTokenV211ToModel(cdr.AuthId),
+		AuthMethod:       cdr.AuthMethod,
+		CdrLocation:      cdrLocationV211ToModel(cdr.Location),
+		MeterId:          cdr.MeterId,
+		Currency:         cdr.Currency,
+		Tariffs:          TariffsV211ToModel(cdr.Tariffs),
+		ChargingPeriods:  chargingPeriodsV211ToModel(cdr.ChargingPeriods),
+		TotalCost:        model.OcpiPrice{ExclVat: cdr.TotalCost},
+		TotalEnergy:      cdr.TotalEnergy,
+		TotalTime:        cdr.TotalTime,
+		TotalParkingTime: cdr.TotalParkingTime,
+		Remark:           cdr.Remark,
+		LastUpdated:      cdr.LastUpdated,
+	}
+}
+
+func CdrsV211ToModel(cdrs []*OcpiCdr) []*model.OcpiCdr {
+	var r []*model.OcpiCdr
+	for _, cdr := range cdrs {
+		r = append(r, CdrV211ToModel(cdr))
+	}
+	return r
+}
+
+func TariffModelToV211(trf *model.OcpiTariff) *OcpiTariff {
+	if trf == nil {
+		return nil
+	}
+	r := &OcpiTariff{
+		Id:            trf.Id,
+		Currency:      trf.Currency,
+		TariffAltText: trf.TariffAltText,
+		TariffAltUrl:  trf.TariffAltUrl,
+		EnergyMix:     trf.EnergyMix,
+		LastUpdated:   trf.LastUpdated,
+	}
+	for _, el := range trf.Elements {
+		e := &OcpiTariffElement{}
+		for _, pc := range el.PriceComponents {
+			e.PriceComponents = append(e.PriceComponents, &OcpiPriceComponent{
+				Type:     pc.Type,
+				Price:    pc.Price,
+				StepSize: pc.StepSize,
+			})
+		}
+		if rs := el.Restrictions; rs != nil {
+			e.Restrictions = &OcpiTariffRestrictions{
+				StartTime:   rs.StartTime,
+				EndTime:     rs.EndTime,
+				StartDate:   rs.StartDate,
+				EndDate:     rs.EndDate,
+				MinKwh:      rs.MinKwh,
+				MaxKwh:      rs.MaxKwh,
+				MinPower:    rs.MinPower,
+				MaxPower:    rs.MaxPower,
+				MinDuration: rs.MinDuration,
+				MaxDuration: rs.MaxDuration,
+				DayOfWeek:   rs.DayOfWeek,
+			}
+		}
+		r.Elements = append(r.Elements, e)
+	}
+	return r
+}
+
+func TariffsModelToV211(trfs []*model.OcpiTariff) []*OcpiTariff {
+	var r []*OcpiTariff
+	for _, trf := range trfs {
+		r = append(r, TariffModelToV211(trf))
+	}
+	return r
+}
+
+// TariffV211ToModel converts tariff, party attributes aren't passed in 2.1.1 and must be populated by a caller
+func TariffV211ToModel(trf *OcpiTariff) *model.OcpiTariff {
+	if trf == nil {
+		return nil
+	}
+	r := &model.OcpiTariff{
+		Id:            trf.Id,
+		Currency:      trf.Currency,
+		TariffAltText: trf.TariffAltText,
+		TariffAltUrl:  trf.TariffAltUrl,
+		EnergyMix:     trf.EnergyMix,
+		LastUpdated:   trf.LastUpdated,
+	}
+	for _, el := range trf.Elements {
+		e := &model.OcpiTariffElement{}
+		for _, pc := range el.PriceComponents {
+			e.PriceComponents = append(e.PriceComponents, &model.OcpiPriceComponent{
+				Type:     pc.Type,
+				Price:    pc.Price,
+				StepSize: pc.StepSize,
+			})
+		}
+		if rs := el.Restrictions; rs != nil {
+			e.Restrictions = &model.OcpiTariffRestrictions{
+				StartTime:   rs.StartTime,
+				EndTime:     rs.EndTime,
+				StartDate:   rs.StartDate,
+				EndDate:     rs.EndDate,
+				MinKwh:      rs.MinKwh,
+				MaxKwh:      rs.MaxKwh,
+				MinPower:    rs.MinPower,
+				MaxPower:    rs.MaxPower,
+				MinDuration: rs.MinDuration,
+				MaxDuration: rs.MaxDuration,
+				DayOfWeek:   rs.DayOfWeek,
+			}
+		}
+		r.Elements = append(r.Elements, e)
+	}
+	return r
+}
+
+func TariffsV211ToModel(trfs []*OcpiTariff) []*model.OcpiTariff {
+	var r []*model.OcpiTariff
+	for _, trf := range trfs {
+		r = append(r, TariffV211ToModel(trf))
+	}
+	return r
+}
+
+func TokenModelToV211(tkn *model.OcpiToken) *OcpiToken {
+	if tkn == nil {
+		return nil
+	}
+	return &OcpiToken{
+		Id:           tkn.Id,
+		Type:         tkn.Type,
+		AuthId:       tkn.ContractId,
+		VisualNumber: tkn.VisualNumber,
+		Issuer:       tkn.Issuer,
+		Valid:        tkn.Valid,
+		WhiteList:    tkn.WhiteList,
+		Lang:         tkn.Lang,
+		LastUpdated:  tkn.LastUpdated,
+	}
+}
+
+func TokensModelToV211(tkns []*model.OcpiToken) []*OcpiToken {
+	var r []*OcpiToken
+	for _, tkn := range tkns {
+		r = append(r, TokenModelToV211(tkn))
+	}
+	return r
+}
+
+// TokenV211ToModel converts token, party attributes aren't passed in 2.1.1 and must be populated by a caller
+func TokenV211ToModel(tkn *OcpiToken) *model.OcpiToken {
+	if tkn == nil {
+		return nil
+	}
+	return &model.OcpiToken{
+		Id:           tkn.Id,
+		Type:         tkn.Type,
+		ContractId:   tkn.AuthId,
+		VisualNumber: tkn.VisualNumber,
+		Issuer:       tkn.Issuer,
+		Valid:        tkn.Valid,
+		WhiteList:    tkn.WhiteList,
+		Lang:         tkn.Lang,
+		LastUpdated:  tkn.LastUpdated,
+	}
+}
+
+func TokensV211ToModel(tkns []*OcpiToken) []*model.OcpiToken {
+	var r []*model.OcpiToken
+	for _, tkn := range tkns {
+		r = append(r, TokenV211ToModel(tkn))
+	}
+	return r
+}
+
+func AuthInfoModelToV211(ai *model.OcpiTokenAuthorizationInfo) *OcpiAuthorizationInfo {
+	if ai == nil {
+		return nil
+	}
+	return &OcpiAuthorizationInfo{
+		Allowed:  ai.Allowed,
+		Location: ai.Location,
+		Info:     ai.Info,
+	}
+}
+
+// CommandModelToV211 converts a command request, returns false if the command isn't supported by 2.1.1
+func CommandModelToV211(cmdType string, cmd any) (any, bool) {
+	switch cmdType {
+	case model.CommandStartSession:
+		c := cmd.(*model.OcpiStartSession)
+		return &OcpiStartSession{
+			ResponseUrl: c.ResponseUrl,
+			Token:       TokenModelToV211(c.Token),
+			LocationId:  c.LocationId,
+			EvseId:      c.EvseId,
+		}, true
+	case model.CommandStopSession:
+		c := cmd.(*model.OcpiStopSession)
+		return &OcpiStopSession{
+			ResponseUrl: c.ResponseUrl,
+			SessionId:   c.SessionId,
+		}, true
+	case model.CommandReserve:
+		c := cmd.(*model.OcpiReserveNow)
+		resId, _ := strconv.Atoi(c.ReservationId)
+		return &OcpiReserveNow{
+			ResponseUrl:   c.ResponseUrl,
+			Token:         TokenModelToV211(c.Token),
+			ExpiryDate:    c.ExpireDate,
+			ReservationId: resId,
+			LocationId:    c.LocationId,
+			EvseId:        c.EvseId,
+		}, true
+	case model.CommandUnlockConnector:
+		c := cmd.(*model.OcpiUnlockConnector)
+		return &OcpiUnlockConnector{
+			ResponseUrl: c.ResponseUrl,
+			LocationId:  c.LocationId,
+			EvseId:      c.EvseId,
+			ConnectorId: c.ConnectorId,
+		}, true
+	}
+	return nil, false
+}
+
+func StartSessionV211ToModel(cmd *OcpiStartSession) *model.OcpiStartSession {
+	if cmd == nil {
+		return nil
+	}
+	return &model.OcpiStartSession{
+		ResponseUrl: cmd.ResponseUrl,
+		Token:       TokenV211ToModel(cmd.Token),
+		LocationId:  cmd.LocationId,
+		EvseId:      cmd.EvseId,
+	}
+}
+
+func StopSessionV211ToModel(cmd *OcpiStopSession) *model.OcpiStopSession {
+	if cmd == nil {
+		return nil
+	}
+	return &model.OcpiStopSession{
+		ResponseUrl: cmd.ResponseUrl,
+		SessionId:   cmd.SessionId,
+	}
+}
+
+func ReserveNowV211ToModel(cmd *OcpiReserveNow) *model.OcpiReserveNow {
+	if cmd == nil {
+		return nil
+	}
+	return &model.OcpiReserveNow{
+		ResponseUrl:   cmd.ResponseUrl,
+		Token:         TokenV211ToModel(cmd.Token),
+		ExpireDate:    cmd.ExpiryDate,
+		ReservationId: strconv.Itoa(cmd.ReservationId),
+		LocationId:    cmd.LocationId,
+		EvseId:        cmd.EvseId,
+	}
+}
+
+func UnlockConnectorV211ToModel(cmd *OcpiUnlockConnector) *model.OcpiUnlockConnector {
+	if cmd == nil {
+		return nil
+	}
+	return &model.OcpiUnlockConnector{
+		ResponseUrl: cmd.ResponseUrl,
+		LocationId:  cmd.LocationId,
+		EvseId:      cmd.EvseId,
+		ConnectorId: cmd.ConnectorId,
+	}
+}
+
+// CommandResponseModelToV211 converts a synchronous command response, timeout isn't supported by 2.1.1
+func CommandResponseModelToV211(rs *model.OcpiCommandResponse) *OcpiCommandResponse {
+	if rs == nil {
+		return nil
+	}
+	return &OcpiCommandResponse{
+		Result:  rs.Result,
+		Message: rs.Message,
+	}
+}
+
+// CommandResultModelToV211 converts an asynchronous command result
+// results which aren't supported by 2.1.1 are reported as rejected
+func CommandResultModelToV211(rs *model.OcpiCommandResult) *OcpiCommandResponse {
+	if rs == nil {
+		return nil
+	}
+	r := &OcpiCommandResponse{
+		Result:  rs.Result,
+		Message: rs.Message,
+	}
+	switch rs.Result {
+	case CommandResponseAccepted, CommandResponseNotSupported, CommandResponseRejected, CommandResponseTimeout:
+	default:
+		r.Result = CommandResponseRejected
+	}
+	return r
+}
+
+// CommandResponseV211ToResult converts a 2.1.1 asynchronous command response to a command result
+func CommandResponseV211ToResult(rs *OcpiCommandResponse) *model.OcpiCommandResult {
+	if rs == nil {
+		return nil
+	}
+	r := &model.OcpiCommandResult{
+		Result:  rs.Result,
+		Message: rs.Message,
+	}
+	if rs.Result == CommandResponseUnknownSession {
+		r.Result = "FAILED"
+	}
+	return r
+}
@@ -0,0 +1,213 @@
+package v211
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type converterTestSuite struct {
+	kit.Suite
+}
+
+func (s *converterTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func TestConverterSuite(t *testing.T) {
+	suite.Run(t, new(converterTestSuite))
+}
+
+func (s *converterTestSuite) now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func (s *converterTestSuite) tariff() *model.OcpiTariff {
+	return &model.OcpiTariff{
+		Id:            kit.NewRandString(),
+		Currency:      "EUR",
+		TariffAltText: []*model.OcpiDisplayText{{Language: "en", Text: "tariff"}},
+		TariffAltUrl:  "https://tariff.com",
+		Elements: []*model.OcpiTariffElement{
+			{
+				PriceComponents: []*model.OcpiPriceComponent{
+					{Type: "ENERGY", Price: 0.25, StepSize: 1},
+					{Type: "TIME", Price: 2, StepSize: 60},
+				},
+				Restrictions: &model.OcpiTariffRestrictions{
+					StartTime: "08:00",
+					EndTime:   "20:00",
+					MinKwh:    kit.Float64Ptr(1),
+					MaxPower:  kit.Float64Ptr(50),
+					DayOfWeek: []string{"MONDAY", "FRIDAY"},
+				},
+			},
+		},
+		LastUpdated: s.now(),
+	}
+}
+
+func (s *converterTestSuite) periods() []*model.OcpiChargingPeriod {
+	return []*model.OcpiChargingPeriod{
+		{
+			StartDateTime: s.now().Add(-time.Hour),
+			Dimensions:    []*model.OcpiCdrDimension{{Type: "ENERGY", Volume: 10}},
+		},
+	}
+}
+
+func (s *converterTestSuite) Test_Location() {
+	loc := &model.OcpiLocation{
+		Id:          kit.NewRandString(),
+		Publish:     kit.BoolPtr(true),
+		Name:        "location",
+		Address:     "address",
+		City:        "city",
+		PostalCode:  "111111",
+		Country:     "DEU",
+		Coordinates: model.OcpiGeoLocation{Latitude: "52.52", Longitude: "13.40"},
+		ParkingType: LocationTypeParkingLot,
+		Facilities:  []string{"HOTEL"},
+		TimeZone:    "Europe/Berlin",
+		Evses: []*model.OcpiEvse{
+			{
+				Uid:          kit.NewRandString(),
+				EvseId:       "DE*ABC*E123",
+				Status:       "AVAILABLE",
+				Capabilities: []string{"RFID_READER"},
+				Connectors: []*model.OcpiConnector{
+					{
+						Id:          "1",
+						Standard:    "IEC_62196_T2",
+						Format:      "SOCKET",
+						PowerType:   "AC_3_PHASE",
+						MaxVoltage:  400,
+						MaxAmperage: 32,
+						TariffIds:   []string{"trf"},
+						LastUpdated: s.now(),
+					},
+				},
+				LastUpdated: s.now(),
+			},
+		},
+		LastUpdated: s.now(),
+	}
+	v := LocationModelToV211(loc)
+	s.Equal(LocationTypeParkingLot, v.Type)
+	s.Equal(400, v.Evses[0].Connectors[0].Voltage)
+	s.Equal("trf", v.Evses[0].Connectors[0].TariffId)
+	s.Equal(loc, LocationV211ToModel(v))
+}
+
+func (s *converterTestSuite) Test_Location_UnknownType() {
+	loc := &model.OcpiLocation{Id: kit.NewRandString(), Publish: kit.BoolPtr(true)}
+	v := LocationModelToV211(loc)
+	s.Equal(LocationTypeUnknown, v.Type)
+	s.Equal(loc, LocationV211ToModel(v))
+	s.Nil(LocationModelToV211(nil))
+	s.Nil(LocationV211ToModel(nil))
+}
+
+func (s *converterTestSuite) Test_Session() {
+	start := s.now().Add(-time.Hour)
+	sess := &model.OcpiSession{
+		Id:              kit.NewRandString(),
+		StartDateTime:   &start,
+		Kwh:             kit.Float64Ptr(10),
+		CdrToken:        &model.OcpiCdrToken{Id: "auth", Type: "OTHER", ContractId: "auth"},
+		AuthMethod:      "WHITELIST",
+		LocationId:      kit.NewRandString(),
+		EvseId:          kit.NewRandString(),
+		ConnectorId:     "1",
+		MeterId:         "meter",
+		Currency:        "EUR",
+		ChargingPeriods: s.periods(),
+		TotalCost:       &model.OcpiPrice{ExclVat: 2.5},
+		Status:          "ACTIVE",
+		LastUpdated:     s.now(),
+	}
+	v := SessionModelToV211(sess)
+	s.Equal("auth", v.AuthId)
+	s.Equal(sess.LocationId, v.Location.Id)
+	s.Equal(2.5, *v.TotalCost)
+	s.Equal(sess, SessionV211ToModel(v))
+}
+
+func (s *converterTestSuite) Test_Session_UnsupportedValues() {
+	sess := &model.OcpiSession{
+		Id:         kit.NewRandString(),
+		AuthMethod: "COMMAND",
+		Status:     "RESERVATION",
+	}
+	v := SessionModelToV211(sess)
+	s.Equal("AUTH_REQUEST", v.AuthMethod)
+	s.Equal("PENDING", v.Status)
+}
+
+func (s *converterTestSuite) Test_Cdr() {
+	cdr := &model.OcpiCdr{
+		Id:            kit.NewRandString(),
+		StartDateTime: s.now().Add(-time.Hour),
+		EndDateTime:   s.now(),
+		CdrToken:      &model.OcpiCdrToken{Id: "auth", Type: "OTHER", ContractId: "auth"},
+		AuthMethod:    "WHITELIST",
+		CdrLocation: model.OcpiCdrLocation{
+			Id:                 kit.NewRandString(),
+			Name:               "location",
+			Address:            "address",
+			City:               "city",
+			PostalCode:         "111111",
+			Country:            "DEU",
+			Coordinates:        model.OcpiGeoLocation{Latitude: "52.52", Longitude: "13.40"},
+			EvseUid:            kit.NewRandString(),
+			EvseId:             "DE*ABC*E123",
+			ConnectorId:        "1",
+			ConnectorStandard:  "IEC_62196_T2",
+			ConnectorFormat:    "SOCKET",
+			ConnectorPowerType: "AC_3_PHASE",
+		},
+		MeterId:          "meter",
+		Currency:         "EUR",
+		Tariffs:          []*model.OcpiTariff{s.tariff()},
+		ChargingPeriods:  s.periods(),
+		TotalCost:        model.OcpiPrice{ExclVat: 4.5},
+		TotalEnergy:      10,
+		TotalTime:        1,
+		TotalParkingTime: kit.Float64Ptr(0.5),
+		Remark:           "remark",
+		LastUpdated:      s.now(),
+	}
+	v := CdrModelToV211(cdr)
+	s.Equal(4.5, v.TotalCost)
+	s.Equal(cdr.EndDateTime, v.StopDateTime)
+	s.Equal(LocationTypeUnknown, v.Location.Type)
+	s.Equal(cdr, CdrV211ToModel(v))
+}
+
+func (s *converterTestSuite) Test_Tariff() {
+	trf := s.tariff()
+	v := TariffModelToV211(trf)
+	s.Len(v.Elements, 1)
+	s.Len(v.Elements[0].PriceComponents, 2)
+	s.Equal(trf, TariffV211ToModel(v))
+}
+
+func (s *converterTestSuite) Test_Token() {
+	tkn := &model.OcpiToken{
+		Id:           kit.NewRandString(),
+		Type:         "RFID",
+		ContractId:   kit.NewRandString(),
+		VisualNumber: "123",
+		Issuer:       "issuer",
+		Valid:        kit.BoolPtr(true),
+		WhiteList:    "ALLOWED",
+		Lang:         "en",
+		LastUpdated:  s.now(),
+	}
+	v := TokenModelToV211(tkn)
+	s.Equal(tkn.ContractId, v.AuthId)
+	s.Equal(tkn, TokenV211ToModel(v))
+}
@@ -0,0 +1,34 @@
+package v211
+
+import (
+	"github.com/mikhailbolshakov/ocpi/model"
+)
+
+type OcpiVersionModuleEndpoint struct {
+	Id  string `json:"identifier"`
+	Url string `json:"url"`
+}
+
+type OcpiVersionDetails struct {
+	Version   string                      `json:"version"`
+	Endpoints []OcpiVersionModuleEndpoint `json:"endpoints"`
+}
+
+// OcpiCredentials 2.1.1 credentials carry a single party with flat business details instead of roles
+type OcpiCredentials struct {
+	Token           string                     `json:"token"`
+	Url             string                     `json:"url"`
+	BusinessDetails *model.OcpiBusinessDetails `json:"business_details"`
+	PartyId         string                     `json:"party_id"`
+	CountryCode     string                     `json:"country_code"`
+}
+
+type OcpiCredentialsResponse struct {
+	model.OcpiResponse
+	Data *OcpiCredentials `json:"data"`
+}
+
+type OcpiVersionDetailsResponse struct {
+	model.OcpiResponse
+	Data *OcpiVersionDetails `json:"data"`
+}
@@ -0,0 +1,87 @@
+package v211
+
+import (
+	"github.com/mikhailbolshakov/ocpi/model"
+	"time"
+)
+
+const (
+	LocationTypeOnStreet          = "ON_STREET"
+	LocationTypeParkingGarage     = "PARKING_GARAGE"
+	LocationTypeUndergroundGarage = "UNDERGROUND_GARAGE"
+	LocationTypeParkingLot        = "PARKING_LOT"
+	LocationTypeOther             = "OTHER"
+	LocationTypeUnknown           = "UNKNOWN"
+)
+
+type OcpiConnector struct {
+	Id                 string    `json:"id"`                             // Id identifier of the Connector
+	Standard           string    `json:"standard"`                       // Standard of the installed connector
+	Format             string    `json:"format"`                         // Format socket/cable
+	PowerType          string    `json:"power_type"`                     // PowerType
+	Voltage            int       `json:"voltage"`                        // Voltage of the connector in V
+	Amperage           int       `json:"amperage"`                       // Amperage of the connector in A
+	TariffId           string    `json:"tariff_id,omitempty"`            // TariffId charging tariff
+	TermsAndConditions string    `json:"terms_and_conditions,omitempty"` // TermsAndConditions url of operator’s terms and conditions
+	LastUpdated        time.Time `json:"last_updated"`                   // LastUpdated when updated or created
+}
+
+type OcpiEvse struct {
+	Uid                 string                      `json:"uid"`                            // Uid identifies the EVSE within the CPOs platform
+	EvseId              string                      `json:"evse_id,omitempty"`              // EvseId following specification for EVSE ID from "eMI3 standard version V1.0"
+	Status              string                      `json:"status"`                         // Status current status of the EVSE
+	StatusSchedule      []*model.OcpiStatusSchedule `json:"status_schedule,omitempty"`      // StatusSchedule indicates a planned status update of the EVSE
+	Capabilities        []string                    `json:"capabilities,omitempty"`         // Capabilities list of functionalities that the EVSE is capable of
+	Connectors          []*OcpiConnector            `json:"connectors"`                     // Connectors list of available connectors on the EVSE
+	FloorLevel          string                      `json:"floor_level,omitempty"`          // FloorLevel level on which the Charge Point is located (in garage buildings)
+	Coordinates         *model.OcpiGeoLocation      `json:"coordinates,omitempty"`          // Coordinates of the EVSE
+	PhysicalReference   string                      `json:"physical_reference,omitempty"`   // PhysicalReference number/string printed on the outside of the EVSE
+	Directions          []*model.OcpiDisplayText    `json:"directions,omitempty"`           // Directions human-readable directions
+	ParkingRestrictions []string                    `json:"parking_restrictions,omitempty"` // ParkingRestrictions restrictions that apply to the parking spot
+	Images              []*model.OcpiImage          `json:"images,omitempty"`               // Images related to the EVSE
+	LastUpdated         time.Time                   `json:"last_updated"`                   // LastUpdated when updated or created
+}
+
+type OcpiLocation struct {
+	Id                 string                             `json:"id"`                             // Id uniquely identifies the location within the CPOs platform
+	Type               string                             `json:"type"`                           // Type of the location
+	Name               string                             `json:"name,omitempty"`                 // Name of the location
+	Address            string                             `json:"address"`                        // Address street/block name and house number
+	City               string                             `json:"city"`                           // City or town
+	PostalCode         string                             `json:"postal_code"`                    // PostalCode of the location
+	Country            string                             `json:"country"`                        // Country alpha-3 code for the country
+	Coordinates        model.OcpiGeoLocation              `json:"coordinates"`                    // Coordinates of the location
+	RelatedLocations   []*model.OcpiAdditionalGeoLocation `json:"related_locations,omitempty"`    // RelatedLocations related points relevant to the user
+	Evses              []*OcpiEvse                        `json:"evses,omitempty"`                // Evses list of EVSEs that belong to this Location
+	Directions         []*model.OcpiDisplayText           `json:"directions,omitempty"`           // Directions human-readable directions on how to reach the location
+	Operator           *model.OcpiBusinessDetails         `json:"operator,omitempty"`             // Operator of the location
+	SubOperator        *model.OcpiBusinessDetails         `json:"suboperator,omitempty"`          // SubOperator of the location
+	Owner              *model.OcpiBusinessDetails         `json:"owner,omitempty"`                // Owner of the location
+	Facilities         []string                           `json:"facilities,omitempty"`           // Facilities this charging location directly belongs
+	TimeZone           string                             `json:"time_zone,omitempty"`            // TimeZone IANA tzdata’s TZ-values
+	OpeningTimes       *model.OcpiHours                   `json:"opening_times,omitempty"`        // OpeningTimes times when the EVSEs at the location can be accessed
+	ChargingWhenClosed *bool                              `json:"charging_when_closed,omitempty"` // ChargingWhenClosed if the EVSEs are still charging outside the opening hours of the location
+	Images             []*model.OcpiImage                 `json:"images,omitempty"`               // Images links to images related to the location
+	EnergyMix          *model.OcpiEnergyMix               `json:"energy_mix,omitempty"`           // EnergyMix energy supplied at this location
+	LastUpdated        time.Time                          `json:"last_updated"`                   // LastUpdated when updated or created
+}
+
+type OcpiLocationsResponse struct {
+	model.OcpiResponse
+	Data []*OcpiLocation `json:"data"`
+}
+
+type OcpiLocationResponse struct {
+	model.OcpiResponse
+	Data *OcpiLocation `json:"data"`
+}
+
+type OcpiEvseResponse struct {
+	model.OcpiResponse
+	Data *OcpiEvse `json:"data"`
+}
+
+type OcpiConnectorResponse struct {
+	model.OcpiResponse
+	Data *OcpiConnector `json:"data"`
+}
@@ -0,0 +1,37 @@
+package v211
+
+import (
+	"github.com/mikhailbolshakov/ocpi/model"
+	"time"
+)
+
+type OcpiChargingPeriod struct {
+	StartDateTime time.Time                 `json:"start_date_time"` // StartDateTime start timestamp of the charging period
+	Dimensions    []*model.OcpiCdrDimension `json:"dimensions"`      // Dimensions list of relevant values for this charging period
+}
+
+type OcpiSession struct {
+	Id              string                `json:"id"`                         // Id unique id that identifies the charging session
+	StartDateTime   *time.Time            `json:"start_datetime"`             // StartDateTime timestamp when the session became ACTIVE
+	EndDateTime     *time.Time            `json:"end_datetime,omitempty"`     // EndDateTime timestamp when the session was completed/finished
+	Kwh             *float64              `json:"kwh"`                        // Kwh how many kWh were charged
+	AuthId          string                `json:"auth_id"`                    // AuthId reference to a token, identified by the auth_id field of the Token
+	AuthMethod      string                `json:"auth_method"`                // AuthMethod method used for authentication
+	Location        *OcpiLocation         `json:"location"`                   // Location where the session is taking place, contains only the EVSE and connector used
+	MeterId         string                `json:"meter_id,omitempty"`         // MeterId id of the kWh meter
+	Currency        string                `json:"currency"`                   // Currency ISO-4217 currency code
+	ChargingPeriods []*OcpiChargingPeriod `json:"charging_periods,omitempty"` // ChargingPeriods list of Charging Periods that can be used to calculate and verify the total cost
+	TotalCost       *float64              `json:"total_cost,omitempty"`       // TotalCost total cost (excluding VAT) of the session in the specified currency
+	Status          string                `json:"status"`                     // Status session status
+	LastUpdated     time.Time             `json:"last_updated"`               // LastUpdated when updated or created
+}
+
+type OcpiSessionsResponse struct {
+	model.OcpiResponse
+	Data []*OcpiSession `json:"data"`
+}
+
+type OcpiSessionResponse struct {
+	model.OcpiResponse
+	Data *OcpiSession `json:"data"`
+}
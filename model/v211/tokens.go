@@ -0,0 +1,34 @@
+package v211
+
+import (
+	"github.com/mikhailbolshakov/ocpi/model"
+	"time"
+)
+
+type OcpiToken struct {
+	Id           string    `json:"uid"`                     // Id unique ID by which this Token can be identified
+	Type         string    `json:"type"`                    // Type of the token
+	AuthId       string    `json:"auth_id"`                 // AuthId uniquely identifies the EV Driver contract token within the eMSP’s platform
+	VisualNumber string    `json:"visual_number,omitempty"` // VisualNumber number/identification as printed on the Token (RFID card)
+	Issuer       string    `json:"issuer"`                  // Issuer issuing company
+	Valid        *bool     `json:"valid"`                   // Valid is this Token valid
+	WhiteList    string    `json:"whitelist"`               // WhiteList indicates what type of white-listing is allowed
+	Lang         string    `json:"language,omitempty"`      // Lang code ISO 639-1
+	LastUpdated  time.Time `json:"last_updated"`            // LastUpdated timestamp when this Token was last updated
+}
+
+type OcpiAuthorizationInfo struct {
+	Allowed  string                 `json:"allowed"`            // Allowed status of the Token, and whether charging is allowed
+	Location *model.OcpiLocationRef `json:"location,omitempty"` // Location optional reference to the location
+	Info     *model.OcpiDisplayText `json:"info,omitempty"`     // Info display text
+}
+
+type OcpiTokensResponse struct {
+	model.OcpiResponse
+	Data []*OcpiToken `json:"data"`
+}
+
+type OcpiTokenResponse struct {
+	model.OcpiResponse
+	Data *OcpiToken `json:"data"`
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/model"
 	"github.com/mikhailbolshakov/ocpi/usecase"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type Adapter interface {
@@ -21,10 +22,13 @@ type Adapter interface {
 	usecase.RemoteSessionRepository
 	usecase.RemoteCommandRepository
 	usecase.RemoteCdrRepository
+	// Metrics returns collector of outgoing requests metrics
+	Metrics() prometheus.Collector
 }
 
 type adapterImpl struct {
-	ocpiRestClient
+	clients    map[string]ocpiRestClient // clients rest clients per OCPI version
+	pool       *platformPool             // pool http clients per remote platform shared by all versions
 	logService domain.OcpiLogService
 	cfg        *service.CfgOcpiConfig
 }
@@ -42,24 +46,73 @@ func (a *adapterImpl) l() kit.CLogger {
 func (a *adapterImpl) Init(ctx context.Context, config interface{}) error {
 	a.l().Mth("init").Dbg()
 	a.cfg = config.(*service.CfgOcpiConfig)
+	a.pool = newPlatformPool(a.cfg.Remote)
 	if a.cfg.Remote.Mock {
-		a.ocpiRestClient = newMockOcpiRestClient(a.logService)
+		mock := newMockOcpiRestClient(a.logService)
+		a.clients = map[string]ocpiRestClient{
+			model.OcpiVersion211: mock,
+			model.OcpiVersion221: mock,
+			model.OcpiVersion230: mock,
+		}
 	} else {
-		a.ocpiRestClient = newOcpiRestClient(a.logService)
+		a.clients = map[string]ocpiRestClient{
+			model.OcpiVersion211: newOcpiRestClientV211(a.logService, a.pool),
+			model.OcpiVersion221: newOcpiRestClient(a.logService, a.pool),
+			model.OcpiVersion230: newOcpiRestClientV230(a.logService, a.pool),
+		}
 	}
-	if err := a.ocpiRestClient.Init(ctx, a.cfg.Remote); err != nil {
-		return err
+	for _, cl := range a.clients {
+		if err := cl.Init(ctx, a.cfg.Remote); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func (a *adapterImpl) Close(ctx context.Context) error {
-	return a.ocpiRestClient.Close(ctx)
+	for _, cl := range a.clients {
+		if err := cl.Close(ctx); err != nil {
+			return err
+		}
+	}
+	a.pool.close()
+	return nil
+}
+
+// client picks a rest client by the version negotiated with the remote platform
+// if version isn't negotiated yet or isn't supported, 2.2.1 client is used
+// client settings of the remote platform are applied to its http client
+func (a *adapterImpl) client(rq *usecase.OcpiRepositoryBaseRequest) ocpiRestClient {
+	a.pool.configure(rq.ToPlatformId, rq.ClientSettings)
+	if cl, ok := a.clients[rq.Version]; ok {
+		return cl
+	}
+	return a.clients[model.OcpiVersion221]
+}
+
+// async sends a request in background, handler is called if the request fails
+// request context is detached, so that the push isn't cancelled with the incoming request while waiting for rate limiters
+func (a *adapterImpl) async(ctx context.Context, l kit.CLogger, handler usecase.ErrorHandler, f func(ctx context.Context) error) {
+	ctx = kit.Copy(ctx)
+	goroutine.New().WithLogger(l).Go(ctx, func() {
+		if err := f(ctx); err != nil {
+			handler(err)
+		}
+	})
+}
+
+func (a *adapterImpl) Metrics() prometheus.Collector {
+	return a.pool.metrics
+}
+
+func (a *adapterImpl) GetClientState(ctx context.Context, platformId string) *domain.ClientState {
+	a.l().C(ctx).Mth("get-client-state").Dbg()
+	return a.pool.state(platformId)
 }
 
 func (a *adapterImpl) GetVersions(ctx context.Context, rq *usecase.OcpiRepositoryBaseRequest) (domain.Versions, error) {
 	a.l().C(ctx).Mth("get-versions").Dbg()
-	rs, err := a.ocpiRestClient.GetVersions(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId)
+	rs, err := a.client(rq).GetVersions(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId)
 	if err != nil {
 		return nil, err
 	}
@@ -68,243 +121,243 @@ func (a *adapterImpl) GetVersions(ctx context.Context, rq *usecase.OcpiRepositor
 
 func (a *adapterImpl) GetVersionDetails(ctx context.Context, rq *usecase.OcpiRepositoryBaseRequest) (domain.ModuleEndpoints, error) {
 	a.l().C(ctx).Mth("get-ver-details").Dbg()
-	rs, err := a.ocpiRestClient.GetVersionDetails(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId)
+	rs, err := a.client(rq).GetVersionDetails(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId)
 	if err != nil {
 		return nil, err
 	}
-	return a.toVersionDetailsDomain(rs), nil
+	return a.toVersionDetailsDomain(rs, rq.Version, rq.ToPlatformRole), nil
 }
 
 func (a *adapterImpl) PostCredentials(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiCredentials]) (*model.OcpiCredentials, error) {
 	a.l().C(ctx).Mth("post-cred").Dbg()
-	return a.ocpiRestClient.PostCredentials(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
+	rs, err := a.client(&rq.OcpiRepositoryBaseRequest).PostCredentials(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
+	if err != nil {
+		return nil, err
+	}
+	return a.toCredentialsWithRole(rs, rq.ToPlatformRole), nil
 }
 
 func (a *adapterImpl) PutCredentials(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiCredentials]) (*model.OcpiCredentials, error) {
 	a.l().C(ctx).Mth("put-cred").Dbg()
-	return a.ocpiRestClient.PutCredentials(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
+	rs, err := a.client(&rq.OcpiRepositoryBaseRequest).PutCredentials(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
+	if err != nil {
+		return nil, err
+	}
+	return a.toCredentialsWithRole(rs, rq.ToPlatformRole), nil
 }
 
 func (a *adapterImpl) GetCredentials(ctx context.Context, rq *usecase.OcpiRepositoryBaseRequest) (*model.OcpiCredentials, error) {
 	a.l().C(ctx).Mth("get-cred").Dbg()
-	return a.ocpiRestClient.GetCredentials(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId)
+	rs, err := a.client(rq).GetCredentials(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId)
+	if err != nil {
+		return nil, err
+	}
+	return a.toCredentialsWithRole(rs, rq.ToPlatformRole), nil
 }
 
 func (a *adapterImpl) DeleteCredentials(ctx context.Context, rq *usecase.OcpiRepositoryBaseRequest) error {
 	a.l().C(ctx).Mth("del-cred").Dbg()
-	return a.ocpiRestClient.DeleteCredentials(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId)
+	return a.client(rq).DeleteCredentials(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId)
 }
 
 func (a *adapterImpl) PutClientInfoAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiClientInfo]) {
 	l := a.l().C(ctx).Mth("put-client-info").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PutClientInfo(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PutClientInfo(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) PutClientInfo(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiClientInfo]) error {
 	a.l().C(ctx).Mth("put-client-info").Dbg()
-	return a.ocpiRestClient.PutClientInfo(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
+	return a.client(&rq.OcpiRepositoryBaseRequest).PutClientInfo(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 }
 
 func (a *adapterImpl) GetClientInfos(ctx context.Context, rq *usecase.OcpiRepositoryPagingRequest) ([]*model.OcpiClientInfo, error) {
 	a.l().C(ctx).Mth("get-hub-client-page").Dbg()
-	return a.ocpiRestClient.GetHubClientInfo(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetHubClientInfo(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
 }
 
 func (a *adapterImpl) PutLocationAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiLocation]) {
 	l := a.l().C(ctx).Mth("put-location").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PutLocation(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PutLocation(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
+func (a *adapterImpl) PutLocation(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiLocation]) error {
+	a.l().C(ctx).Mth("put-loc").Dbg()
+	return a.client(&rq.OcpiRepositoryBaseRequest).PutLocation(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
+}
+
 func (a *adapterImpl) PatchLocationAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiLocation]) {
 	l := a.l().C(ctx).Mth("patch-location").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PatchLocation(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PatchLocation(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) GetLocations(ctx context.Context, rq *usecase.OcpiRepositoryPagingRequest) ([]*model.OcpiLocation, error) {
 	a.l().C(ctx).Mth("get-loc-page").Dbg()
-	return a.ocpiRestClient.GetLocationPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetLocationPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
 }
 
 func (a *adapterImpl) GetLocation(ctx context.Context, rq *usecase.OcpiRepositoryIdRequest) (*model.OcpiLocation, error) {
 	a.l().C(ctx).Mth("get-loc").Dbg()
-	return a.ocpiRestClient.GetLocation(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetLocation(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
 }
 
 func (a *adapterImpl) PutEvseAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) {
 	l := a.l().C(ctx).Mth("put-evse-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PutEvse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PutEvse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId)
 	})
 }
 
+func (a *adapterImpl) PutEvse(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) error {
+	a.l().C(ctx).Mth("put-evse").Dbg()
+	return a.client(&rq.OcpiRepositoryBaseRequest).PutEvse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId)
+}
+
 func (a *adapterImpl) PatchEvseAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) {
 	l := a.l().C(ctx).Mth("patch-evse-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PatchEvse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PatchEvse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId)
 	})
 }
 
+func (a *adapterImpl) PatchEvse(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) error {
+	a.l().C(ctx).Mth("patch-evse").Dbg()
+	return a.client(&rq.OcpiRepositoryBaseRequest).PatchEvse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId)
+}
+
 func (a *adapterImpl) GetEvse(ctx context.Context, rq *usecase.OcpiRepositoryBaseRequest, locId, evseId string) (*model.OcpiEvse, error) {
 	a.l().C(ctx).Mth("get-evse").Dbg()
-	return a.ocpiRestClient.GetEvse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, locId, evseId)
+	return a.client(rq).GetEvse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, locId, evseId)
 }
 
 func (a *adapterImpl) PutConnectorAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiConnector], party *model.OcpiPartyId, evseId, locId string) {
 	l := a.l().C(ctx).Mth("put-con-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PutCon(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId, evseId); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PutCon(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId, evseId)
 	})
 }
 
+func (a *adapterImpl) PutConnector(ctx context.Context, rq *usecase.OcpiRepositoryRequestG[*model.OcpiConnector], party *model.OcpiPartyId, locId, evseId string) error {
+	a.l().C(ctx).Mth("put-con").Dbg()
+	return a.client(&rq.OcpiRepositoryBaseRequest).PutCon(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId, evseId)
+}
+
 func (a *adapterImpl) PatchConnectorAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiConnector], party *model.OcpiPartyId, evseId, locId string) {
 	l := a.l().C(ctx).Mth("patch-con-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PatchCon(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId, evseId); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PatchCon(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request, party, locId, evseId)
 	})
 }
 
 func (a *adapterImpl) GetConnector(ctx context.Context, rq *usecase.OcpiRepositoryBaseRequest, locId, evseId, conId string) (*model.OcpiConnector, error) {
 	a.l().C(ctx).Mth("get-con").Dbg()
-	return a.ocpiRestClient.GetCon(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, locId, evseId, conId)
+	return a.client(rq).GetCon(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, locId, evseId, conId)
 }
 
 func (a *adapterImpl) PutTariffAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiTariff]) {
 	l := a.l().C(ctx).Mth("put-trf-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PutTariff(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PutTariff(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) PatchTariffAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiTariff]) {
 	l := a.l().C(ctx).Mth("patch-trf-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PatchTariff(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PatchTariff(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) GetTariffs(ctx context.Context, rq *usecase.OcpiRepositoryPagingRequest) ([]*model.OcpiTariff, error) {
 	a.l().C(ctx).Mth("get-trf-page").Dbg()
-	return a.ocpiRestClient.GetTariffPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetTariffPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
 }
 
 func (a *adapterImpl) GetTariff(ctx context.Context, rq *usecase.OcpiRepositoryIdRequest) (*model.OcpiTariff, error) {
 	a.l().C(ctx).Mth("get-trf").Dbg()
-	return a.ocpiRestClient.GetTariff(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetTariff(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
 }
 
 func (a *adapterImpl) PutTokenAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiToken]) {
 	l := a.l().C(ctx).Mth("put-tkn-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PutToken(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PutToken(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) PatchTokenAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiToken]) {
 	l := a.l().C(ctx).Mth("patch-tkn-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PatchToken(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PatchToken(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) GetTokens(ctx context.Context, rq *usecase.OcpiRepositoryPagingRequest) ([]*model.OcpiToken, error) {
 	a.l().C(ctx).Mth("get-tkn-page").Dbg()
-	return a.ocpiRestClient.GetTokenPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetTokenPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
 }
 
 func (a *adapterImpl) GetToken(ctx context.Context, rq *usecase.OcpiRepositoryIdRequest) (*model.OcpiToken, error) {
 	a.l().C(ctx).Mth("get-tkn").Dbg()
-	return a.ocpiRestClient.GetToken(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetToken(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
 }
 
 func (a *adapterImpl) PutSessionAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiSession]) {
 	l := a.l().C(ctx).Mth("put-sess-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PutSession(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PutSession(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) PatchSessionAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiSession]) {
 	l := a.l().C(ctx).Mth("patch-sess-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PatchSession(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PatchSession(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) GetSessions(ctx context.Context, rq *usecase.OcpiRepositoryPagingRequest) ([]*model.OcpiSession, error) {
 	a.l().C(ctx).Mth("get-sess-page").Dbg()
-	return a.ocpiRestClient.GetSessionPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetSessionPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
 }
 
 func (a *adapterImpl) GetSession(ctx context.Context, rq *usecase.OcpiRepositoryIdRequest) (*model.OcpiSession, error) {
 	a.l().C(ctx).Mth("get-sess").Dbg()
-	return a.ocpiRestClient.GetSession(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetSession(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
 }
 
 func (a *adapterImpl) PostCommandAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequest, cmdType string, cmd any) {
 	l := a.l().C(ctx).Mth("post-cmd-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PostCommand(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, cmdType, cmd); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PostCommand(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, cmdType, cmd)
 	})
 }
 
 func (a *adapterImpl) PostCommandResponseAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiCommandResult]) {
 	l := a.l().C(ctx).Mth("post-cmd-rs-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PostCommandResponse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PostCommandResponse(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) PostCdrAsync(ctx context.Context, rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiCdr]) {
 	l := a.l().C(ctx).Mth("post-cdr-async").Dbg()
-	goroutine.New().WithLogger(l).Go(ctx, func() {
-		if err := a.ocpiRestClient.PostCdr(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request); err != nil {
-			rq.Handler(err)
-		}
+	a.async(ctx, l, rq.Handler, func(ctx context.Context) error {
+		return a.client(&rq.OcpiRepositoryBaseRequest).PostCdr(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Request)
 	})
 }
 
 func (a *adapterImpl) GetCdrs(ctx context.Context, rq *usecase.OcpiRepositoryPagingRequest) ([]*model.OcpiCdr, error) {
 	a.l().C(ctx).Mth("get-cdrs-page").Dbg()
-	return a.ocpiRestClient.GetCdrsPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetCdrsPage(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, &rq.OcpiGetPageRequest)
 }
 
 func (a *adapterImpl) GetCdr(ctx context.Context, rq *usecase.OcpiRepositoryIdRequest) (*model.OcpiCdr, error) {
 	a.l().C(ctx).Mth("get-cdr").Dbg()
-	return a.ocpiRestClient.GetCdr(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
+	return a.client(&rq.OcpiRepositoryBaseRequest).GetCdr(ctx, string(rq.Endpoint), string(rq.Token), rq.FromPlatformId, rq.ToPlatformId, rq.Id)
 }
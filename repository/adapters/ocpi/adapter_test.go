@@ -0,0 +1,83 @@
+package ocpi
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type adapterTestSuite struct {
+	kit.Suite
+	adapter *adapterImpl
+}
+
+func (s *adapterTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *adapterTestSuite) SetupTest() {
+	s.adapter = NewAdapter(&mocks.OcpiLogService{}).(*adapterImpl)
+	s.NoError(s.adapter.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{Timeout: kit.IntPtr(5)}}))
+}
+
+func TestAdapterSuite(t *testing.T) {
+	suite.Run(t, new(adapterTestSuite))
+}
+
+func (s *adapterTestSuite) rq(version string) *usecase.OcpiRepositoryBaseRequest {
+	return &usecase.OcpiRepositoryBaseRequest{
+		ToPlatformId: kit.NewRandString(),
+		Version:      version,
+	}
+}
+
+func (s *adapterTestSuite) Test_Client_ByVersion() {
+	s.IsType(&clientV211Impl{}, s.adapter.client(s.rq(model.OcpiVersion211)))
+	s.IsType(&clientImpl{}, s.adapter.client(s.rq(model.OcpiVersion221)))
+	s.IsType(&clientV230Impl{}, s.adapter.client(s.rq(model.OcpiVersion230)))
+}
+
+func (s *adapterTestSuite) Test_Client_FallbackTo221() {
+	s.Equal(s.adapter.clients[model.OcpiVersion221], s.adapter.client(s.rq("")))
+	s.Equal(s.adapter.clients[model.OcpiVersion221], s.adapter.client(s.rq("2.0")))
+}
+
+func (s *adapterTestSuite) Test_Client_ConfiguresPool() {
+	rq := s.rq(model.OcpiVersion211)
+	s.NotNil(s.adapter.client(rq))
+	s.Contains(s.adapter.pool.clients, rq.ToPlatformId)
+}
+
+func (s *adapterTestSuite) Test_Async_ContextDetached() {
+	ctx, cancel := context.WithCancel(s.Ctx)
+	cancel()
+	errs := make(chan error, 1)
+	s.adapter.async(ctx, s.adapter.l(), func(err error) { errs <- err }, func(ctx context.Context) error {
+		return ctx.Err()
+	})
+	select {
+	case err := <-errs:
+		s.Fail("push is cancelled with the request", err.Error())
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+func (s *adapterTestSuite) Test_Async_HandlerCalled() {
+	errs := make(chan error, 1)
+	s.adapter.async(s.Ctx, s.adapter.l(), func(err error) { errs <- err }, func(ctx context.Context) error {
+		return errors.ErrPlatformNotAvailable(ctx)
+	})
+	select {
+	case err := <-errs:
+		s.AssertAppErr(err, errors.ErrCodePlatformNotAvailable)
+	case <-time.After(time.Second):
+		s.Fail("handler isn't called")
+	}
+}
@@ -73,12 +73,13 @@ type ocpiRestClient interface {
 type clientImpl struct {
 	cfg        *service.CfgOcpiRemote
 	logService domain.OcpiLogService
-	timeout    time.Duration
+	pool       *platformPool
 }
 
-func newOcpiRestClient(logService domain.OcpiLogService) ocpiRestClient {
+func newOcpiRestClient(logService domain.OcpiLogService, pool *platformPool) ocpiRestClient {
 	return &clientImpl{
 		logService: logService,
+		pool:       pool,
 	}
 }
 
@@ -89,11 +90,6 @@ func (s *clientImpl) l() kit.CLogger {
 func (s *clientImpl) Init(ctx context.Context, config *service.CfgOcpiRemote) error {
 	s.l().Mth("init").Dbg()
 	s.cfg = config
-	if s.cfg.Timeout != nil {
-		s.timeout = time.Duration(*s.cfg.Timeout) * time.Second
-	} else {
-		s.timeout = defaultTimeout
-	}
 	return nil
 }
 
@@ -185,8 +181,8 @@ func (s *clientImpl) GetCredentials(ctx context.Context, url, token, fromPlatfor
 
 func (s *clientImpl) DeleteCredentials(ctx context.Context, url, token, fromPlatform, toPlatform string) error {
 	s.l().Mth("del-cred").Dbg()
-	rq := s.prepareRq(ctx, url, token, domain.LogEventGetCredentials).
-		Verb(http.MethodGet).
+	rq := s.prepareRq(ctx, url, token, domain.LogEventDelCredentials).
+		Verb(http.MethodDelete).
 		B()
 	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
 }
@@ -632,7 +628,7 @@ func (s *clientImpl) prepareLogMsg(rq *restRequest, fromPlatform, toPlatform str
 }
 
 func (s *clientImpl) makeRequest(ctx context.Context, rq *restRequest, fromPlatform, toPlatform string) error {
-	s.l().C(ctx).Mth("make").F(kit.KV{"url": rq.Url, "verb": rq.Verb}).Dbg()
+	l := s.l().C(ctx).Mth("make").F(kit.KV{"url": rq.Url, "verb": rq.Verb}).Dbg()
 
 	start := kit.Now()
 
@@ -640,28 +636,99 @@ func (s *clientImpl) makeRequest(ctx context.Context, rq *restRequest, fromPlatf
 	log := s.prepareLogMsg(rq, fromPlatform, toPlatform)
 	defer s.logService.Log(ctx, log)
 
+	// payload
+	var body []byte
+	if rq.Body != nil {
+		body, _ = json.Marshal(rq.Body)
+		log.RequestBody = rq.Body
+	}
+
+	cl := s.pool.get(toPlatform)
+	module := logEventModules[rq.LogEvent]
+
+	// wait for rate limiters
+	if err := s.pool.wait(ctx, cl, toPlatform, module); err != nil {
+		err = errors.ErrOcpiRestSendRequest(ctx, err)
+		log.Err = err
+		return err
+	}
+
+	// limit concurrent requests to the remote platform
+	if cl.sem != nil {
+		select {
+		case cl.sem <- struct{}{}:
+			defer func() { <-cl.sem }()
+		case <-time.After(cl.timeout):
+			err := errors.ErrOcpiRestConcurrencyLimit(ctx, toPlatform)
+			log.Err = err
+			return err
+		}
+	}
+
+	// fail fast if the remote platform keeps failing
+	if !cl.breaker.allow() {
+		err := errors.ErrOcpiRestCircuitOpen(ctx, toPlatform)
+		log.Err = err
+		return err
+	}
+
+	// requests rejected with 429 haven't been processed, so they are always retried
+	// other failed requests are retried only if they are idempotent
+	idempotent := rq.Verb == http.MethodGet || rq.Verb == http.MethodPut
+
+	var err error
+	var transient, throttled bool
+	for attempt := 0; attempt <= cl.maxRetries; attempt++ {
+		if attempt > 0 {
+			l.F(kit.KV{"attempt": attempt}).E(err).Warn("retry")
+			if throttled {
+				if err = s.pool.wait(ctx, cl, toPlatform, module); err != nil {
+					err = errors.ErrOcpiRestSendRequest(ctx, err)
+					log.Err = err
+					break
+				}
+			} else {
+				time.Sleep(cl.backoff(attempt))
+			}
+		}
+		transient, throttled, err = s.do(ctx, cl, rq, body, toPlatform, log)
+		if err == nil || !(throttled || transient && idempotent) {
+			break
+		}
+	}
+	log.DurationMs = time.Since(start).Milliseconds()
+
+	// only failures of the remote platform itself are taken into account by the breaker
+	if err != nil && transient {
+		cl.breaker.failure()
+	} else {
+		cl.breaker.success()
+	}
+
+	return err
+}
+
+// do sends a single request
+// it returns transient = true if the remote platform failed, so that request can be retried
+// and throttled = true if the remote platform asked to slow down
+func (s *clientImpl) do(ctx context.Context, cl *platformClient, rq *restRequest, body []byte, toPlatform string, log *domain.LogMessage) (bool, bool, error) {
+
 	// setup timeout
-	ctxExec, cancelFn := context.WithTimeout(context.Background(), s.timeout)
+	ctxExec, cancelFn := context.WithTimeout(context.Background(), cl.timeout)
 	defer cancelFn()
 
-	// payload
 	var rqReader io.Reader
-	if rq.Body != nil {
-		bodyB, _ := json.Marshal(rq.Body)
-		rqReader = bytes.NewReader(bodyB)
-		log.RequestBody = rq.Body
+	if body != nil {
+		rqReader = bytes.NewReader(body)
 	}
 
 	// prepare request
 	req, err := http.NewRequestWithContext(ctxExec, rq.Verb, rq.Url, rqReader)
 	if err != nil {
 		log.Err = err
-		return errors.ErrOcpiRestSendRequest(ctx, err)
+		return false, false, errors.ErrOcpiRestSendRequest(ctx, err)
 	}
 
-	// setup separate connections for each call
-	req.Close = true
-
 	req.Header.Add("Content-Type", "application/json")
 
 	// headers
@@ -680,11 +747,10 @@ func (s *clientImpl) makeRequest(ctx context.Context, rq *restRequest, fromPlatf
 	}
 
 	// make request
-	resp, err := http.DefaultClient.Do(req)
-	log.DurationMs = time.Since(start).Milliseconds()
+	resp, err := cl.http.Do(req)
 	if err != nil {
 		log.Err = err
-		return errors.ErrOcpiRestSendRequest(ctx, err)
+		return true, false, errors.ErrOcpiRestSendRequest(ctx, err)
 	}
 
 	// parse body
@@ -694,21 +760,32 @@ func (s *clientImpl) makeRequest(ctx context.Context, rq *restRequest, fromPlatf
 	log.ResponseStatus = resp.StatusCode
 	if err != nil {
 		log.Err = err
-		return errors.ErrOcpiRestReadBody(ctx, err)
+		return true, false, errors.ErrOcpiRestReadBody(ctx, err)
+	}
+
+	// remote platform asks to slow down
+	if resp.StatusCode == http.StatusTooManyRequests {
+		s.pool.throttle(cl, toPlatform, logEventModules[rq.LogEvent], retryAfter(resp.Header.Get("Retry-After"), kit.Now()))
+		err = errors.ErrOcpiRestStatus(ctx, resp.StatusCode)
+		log.Err = err
+		return false, true, err
 	}
 
+	// remote platform is unavailable
+	transient := resp.StatusCode >= http.StatusInternalServerError
+
 	// check response
 	respObj := &model.OcpiResponse{}
 	err = json.Unmarshal(data, &respObj)
 	if err != nil {
 		err = errors.ErrOcpiRestParseResponse(ctx, err, resp.Status)
 		log.Err = err
-		return err
+		return transient, false, err
 	}
 	if respObj == nil {
 		err = errors.ErrOcpiRestEmptyResponse(ctx)
 		log.Err = err
-		return err
+		return transient, false, err
 	}
 	log.ResponseBody = respObj
 	log.OcpiStatus = respObj.StatusCode
@@ -717,14 +794,14 @@ func (s *clientImpl) makeRequest(ctx context.Context, rq *restRequest, fromPlatf
 	if resp.StatusCode > 300 {
 		err = errors.ErrOcpiRestStatus(ctx, resp.StatusCode)
 		log.Err = err
-		return err
+		return transient, false, err
 	}
 
 	// check ocpi status
 	if respObj.StatusCode != model.OcpiStatusCodeOk {
 		err = errors.ErrOcpiInvalidStatus(ctx, respObj.StatusCode, respObj.StatusMessage)
 		log.Err = err
-		return err
+		return false, false, err
 	}
 
 	// parse requested model
@@ -733,9 +810,10 @@ func (s *clientImpl) makeRequest(ctx context.Context, rq *restRequest, fromPlatf
 		if err != nil || rq.RespModel == nil {
 			err = errors.ErrOcpiRestParseModel(ctx)
 			log.Err = err
-			return err
+			return false, false, err
 		}
 	}
 
-	return nil
+	log.Err = nil
+	return false, false, nil
 }
@@ -26,11 +26,12 @@ func (s *clientTestSuite) SetupSuite() {
 func (s *clientTestSuite) SetupTest() {
 	s.logSvc = &mocks.OcpiLogService{}
 	s.logSvc.On("Log", mock.Anything, mock.Anything)
-	s.clSvc = newOcpiRestClient(s.logSvc).(*clientImpl)
-	s.clSvc.Init(s.Ctx, &ocpi.CfgOcpiRemote{
+	cfg := &ocpi.CfgOcpiRemote{
 		Mock:    false,
 		Timeout: kit.IntPtr(20),
-	})
+	}
+	s.clSvc = newOcpiRestClient(s.logSvc, newPlatformPool(cfg)).(*clientImpl)
+	s.clSvc.Init(s.Ctx, cfg)
 }
 
 func (s *clientTestSuite) TearDownSuite() {}
@@ -0,0 +1,444 @@
+package ocpi
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	service "github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/model/v211"
+	"net/http"
+)
+
+// clientV211Impl OCPI 2.1.1 rest client
+// it converts payloads to 2.1.1 models and reuses transport of 2.2.1 client for the rest
+type clientV211Impl struct {
+	*clientImpl
+}
+
+func newOcpiRestClientV211(logService domain.OcpiLogService, pool *platformPool) ocpiRestClient {
+	return &clientV211Impl{
+		clientImpl: &clientImpl{
+			logService: logService,
+			pool:       pool,
+		},
+	}
+}
+
+func (s *clientV211Impl) l() kit.CLogger {
+	return service.L().Cmp("ocpi-rest-v211")
+}
+
+func (s *clientV211Impl) GetVersionDetails(ctx context.Context, url, token, fromPlatform, toPlatform string) (*model.OcpiVersionDetails, error) {
+	s.l().Mth("get-version-det").Dbg()
+	rs := &v211.OcpiVersionDetailsResponse{}
+	rq := s.prepareRq(ctx, url, token, domain.LogEventGetVersionDetails).ResponseModel(&rs).B()
+	err := s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.VersionDetailsV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) credentials(ctx context.Context, url, token, fromPlatform, toPlatform, verb string, pl *model.OcpiCredentials) (*model.OcpiCredentials, error) {
+	rs := &v211.OcpiCredentialsResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventPostCredentials).
+		Verb(verb).
+		ResponseModel(&rs)
+	if pl != nil {
+		b.Body(v211.CredentialsModelToV211(pl))
+	}
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	// 2.1.1 credentials have no role, it's populated by a caller
+	return v211.CredentialsV211ToModel(rs.Data, ""), nil
+}
+
+func (s *clientV211Impl) PostCredentials(ctx context.Context, url, token, fromPlatform, toPlatform string, pl *model.OcpiCredentials) (*model.OcpiCredentials, error) {
+	s.l().Mth("post-cred").Dbg()
+	return s.credentials(ctx, url, token, fromPlatform, toPlatform, http.MethodPost, pl)
+}
+
+func (s *clientV211Impl) PutCredentials(ctx context.Context, url, token, fromPlatform, toPlatform string, pl *model.OcpiCredentials) (*model.OcpiCredentials, error) {
+	s.l().Mth("put-cred").Dbg()
+	return s.credentials(ctx, url, token, fromPlatform, toPlatform, http.MethodPut, pl)
+}
+
+func (s *clientV211Impl) GetCredentials(ctx context.Context, url, token, fromPlatform, toPlatform string) (*model.OcpiCredentials, error) {
+	s.l().Mth("get-cred").Dbg()
+	return s.credentials(ctx, url, token, fromPlatform, toPlatform, http.MethodGet, nil)
+}
+
+func (s *clientV211Impl) GetHubClientInfo(ctx context.Context, url, token, fromPlatform, toPlatform string, rq *model.OcpiGetPageRequest) ([]*model.OcpiClientInfo, error) {
+	return nil, errors.ErrVersionNotSupported(ctx, model.OcpiVersion211, model.ModuleIdHubClientInfo)
+}
+
+func (s *clientV211Impl) PutClientInfo(ctx context.Context, url, token, fromPlatform, toPlatform string, rq *model.OcpiClientInfo) error {
+	return errors.ErrVersionNotSupported(ctx, model.OcpiVersion211, model.ModuleIdHubClientInfo)
+}
+
+func (s *clientV211Impl) PutLocation(ctx context.Context, url, token, fromPlatform, toPlatform string, pl *model.OcpiLocation) error {
+	s.l().Mth("put-location").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPutLocation).
+		Verb(http.MethodPut).
+		Body(v211.LocationModelToV211(pl)).
+		UrlParams(pl.CountryCode, pl.PartyId, pl.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) PatchLocation(ctx context.Context, url, token, fromPlatform, toPlatform string, pl *model.OcpiLocation) error {
+	s.l().Mth("patch-location").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPatchLocation).
+		Verb(http.MethodPatch).
+		Body(v211.LocationModelToV211(pl)).
+		UrlParams(pl.CountryCode, pl.PartyId, pl.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) GetLocationPage(ctx context.Context, url, token, fromPlatform, toPlatform string, rq *model.OcpiGetPageRequest) ([]*model.OcpiLocation, error) {
+	s.l().Mth("get-loc-page").Dbg()
+	rs := &v211.OcpiLocationsResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetLocations).
+		Verb(http.MethodGet).
+		Page(rq).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.LocationsV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) GetLocation(ctx context.Context, url, token, fromPlatform, toPlatform string, locId string) (*model.OcpiLocation, error) {
+	s.l().Mth("get-loc").Dbg()
+	rs := &v211.OcpiLocationResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetLocation).
+		Verb(http.MethodGet).
+		UrlParams(locId).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.LocationV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) PutEvse(ctx context.Context, url, token, fromPlatform, toPlatform string, pl *model.OcpiEvse, party *model.OcpiPartyId, locId string) error {
+	s.l().Mth("put-evse").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPutEvse).
+		Verb(http.MethodPut).
+		Body(v211.EvseModelToV211(pl)).
+		UrlParams(party.CountryCode, party.PartyId, locId, pl.Uid).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) PatchEvse(ctx context.Context, url, token, fromPlatform, toPlatform string, pl *model.OcpiEvse, party *model.OcpiPartyId, locId string) error {
+	s.l().Mth("patch-evse").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPatchEvse).
+		Verb(http.MethodPatch).
+		Body(v211.EvseModelToV211(pl)).
+		UrlParams(party.CountryCode, party.PartyId, locId, pl.Uid).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) GetEvse(ctx context.Context, url, token, fromPlatform, toPlatform string, locId, evseId string) (*model.OcpiEvse, error) {
+	s.l().Mth("get-evse").Dbg()
+	rs := &v211.OcpiEvseResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetEvse).
+		Verb(http.MethodGet).
+		UrlParams(locId, evseId).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.EvseV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) PutCon(ctx context.Context, url, token, fromPlatform, toPlatform string, pl *model.OcpiConnector, party *model.OcpiPartyId, locId, evseId string) error {
+	s.l().Mth("put-con").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPutCon).
+		Verb(http.MethodPut).
+		Body(v211.ConnectorModelToV211(pl)).
+		UrlParams(party.CountryCode, party.PartyId, locId, evseId, pl.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) PatchCon(ctx context.Context, url, token, fromPlatform, toPlatform string, pl *model.OcpiConnector, party *model.OcpiPartyId, locId, evseId string) error {
+	s.l().Mth("patch-con").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPatchCon).
+		Verb(http.MethodPatch).
+		Body(v211.ConnectorModelToV211(pl)).
+		UrlParams(party.CountryCode, party.PartyId, locId, evseId, pl.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) GetCon(ctx context.Context, url, token, fromPlatform, toPlatform, locId, evseId, conId string) (*model.OcpiConnector, error) {
+	s.l().Mth("get-con").Dbg()
+	rs := &v211.OcpiConnectorResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetCon).
+		Verb(http.MethodGet).
+		UrlParams(locId, evseId, conId).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.ConnectorV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) PutTariff(ctx context.Context, url, token, fromPlatform, toPlatform string, trf *model.OcpiTariff) error {
+	s.l().Mth("put-trf").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPutTariff).
+		Verb(http.MethodPut).
+		Body(v211.TariffModelToV211(trf)).
+		UrlParams(trf.CountryCode, trf.PartyId, trf.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) PatchTariff(ctx context.Context, url, token, fromPlatform, toPlatform string, trf *model.OcpiTariff) error {
+	s.l().Mth("patch-trf").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPatchTariff).
+		Verb(http.MethodPatch).
+		Body(v211.TariffModelToV211(trf)).
+		UrlParams(trf.CountryCode, trf.PartyId, trf.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) GetTariffPage(ctx context.Context, url, token, fromPlatform, toPlatform string, rq *model.OcpiGetPageRequest) ([]*model.OcpiTariff, error) {
+	s.l().Mth("get-trf-page").Dbg()
+	rs := &v211.OcpiTariffsResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetTariffs).
+		Verb(http.MethodGet).
+		Page(rq).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.TariffsV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) GetTariff(ctx context.Context, url, token, fromPlatform, toPlatform string, trfId string) (*model.OcpiTariff, error) {
+	s.l().Mth("get-trf").Dbg()
+	rs := &v211.OcpiTariffResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetTariff).
+		Verb(http.MethodGet).
+		UrlParams(trfId).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.TariffV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) PutToken(ctx context.Context, url, token, fromPlatform, toPlatform string, tkn *model.OcpiToken) error {
+	s.l().Mth("put-tkn").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPutToken).
+		Verb(http.MethodPut).
+		Body(v211.TokenModelToV211(tkn)).
+		UrlParams(tkn.CountryCode, tkn.PartyId, tkn.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) PatchToken(ctx context.Context, url, token, fromPlatform, toPlatform string, tkn *model.OcpiToken) error {
+	s.l().Mth("patch-tkn").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPatchToken).
+		Verb(http.MethodPatch).
+		Body(v211.TokenModelToV211(tkn)).
+		UrlParams(tkn.CountryCode, tkn.PartyId, tkn.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) GetTokenPage(ctx context.Context, url, token, fromPlatform, toPlatform string, rq *model.OcpiGetPageRequest) ([]*model.OcpiToken, error) {
+	s.l().Mth("get-tkn-page").Dbg()
+	rs := &v211.OcpiTokensResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetTokens).
+		Verb(http.MethodGet).
+		Page(rq).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.TokensV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) GetToken(ctx context.Context, url, token, fromPlatform, toPlatform string, tknId string) (*model.OcpiToken, error) {
+	s.l().Mth("get-tkn").Dbg()
+	rs := &v211.OcpiTokenResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetToken).
+		Verb(http.MethodGet).
+		UrlParams(tknId).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.TokenV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) PutSession(ctx context.Context, url, token, fromPlatform, toPlatform string, sess *model.OcpiSession) error {
+	s.l().Mth("put-sess").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPutSession).
+		Verb(http.MethodPut).
+		Body(v211.SessionModelToV211(sess)).
+		UrlParams(sess.CountryCode, sess.PartyId, sess.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) PatchSession(ctx context.Context, url, token, fromPlatform, toPlatform string, sess *model.OcpiSession) error {
+	s.l().Mth("patch-sess").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPatchSession).
+		Verb(http.MethodPatch).
+		Body(v211.SessionModelToV211(sess)).
+		UrlParams(sess.CountryCode, sess.PartyId, sess.Id).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) GetSessionPage(ctx context.Context, url, token, fromPlatform, toPlatform string, rq *model.OcpiGetPageRequest) ([]*model.OcpiSession, error) {
+	s.l().Mth("get-sess-page").Dbg()
+	rs := &v211.OcpiSessionsResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetSessions).
+		Verb(http.MethodGet).
+		Page(rq).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.SessionsV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) GetSession(ctx context.Context, url, token, fromPlatform, toPlatform string, sessId string) (*model.OcpiSession, error) {
+	s.l().Mth("get-sess").Dbg()
+	rs := &v211.OcpiSessionResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetSession).
+		Verb(http.MethodGet).
+		UrlParams(sessId).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.SessionV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) PostCommand(ctx context.Context, url, token, fromPlatform, toPlatform, cmdType string, cmd any) error {
+	s.l().Mth("post-cmd").Dbg()
+	body, ok := v211.CommandModelToV211(cmdType, cmd)
+	if !ok {
+		return errors.ErrVersionNotSupported(ctx, model.OcpiVersion211, cmdType)
+	}
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPostCommand).
+		Verb(http.MethodPost).
+		Body(body).
+		UrlParams(cmdType).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) PostCommandResponse(ctx context.Context, url, token, fromPlatform, toPlatform string, rs *model.OcpiCommandResult) error {
+	s.l().Mth("post-cmd-rs").Dbg()
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPostCommandResponse).
+		Verb(http.MethodPost).
+		Body(v211.CommandResultModelToV211(rs)).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) PostCdr(ctx context.Context, url, token, fromPlatform, toPlatform string, cdr *model.OcpiCdr) error {
+	s.l().Mth("post-cdr").Dbg()
+	// 2.1.1 posts CDRs to the base url of the module
+	rq := s.prepareRq(ctx, url, token, domain.LogEventPostCdr).
+		Verb(http.MethodPost).
+		Body(v211.CdrModelToV211(cdr)).
+		B()
+	return s.makeRequest(ctx, rq, fromPlatform, toPlatform)
+}
+
+func (s *clientV211Impl) GetCdrsPage(ctx context.Context, url, token, fromPlatform, toPlatform string, rq *model.OcpiGetPageRequest) ([]*model.OcpiCdr, error) {
+	s.l().Mth("get-cdrs-page").Dbg()
+	rs := &v211.OcpiCdrsResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetCdrs).
+		Verb(http.MethodGet).
+		Page(rq).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.CdrsV211ToModel(rs.Data), nil
+}
+
+func (s *clientV211Impl) GetCdr(ctx context.Context, url, token, fromPlatform, toPlatform string, cdrId string) (*model.OcpiCdr, error) {
+	s.l().Mth("get-cdr").Dbg()
+	rs := &v211.OcpiCdrResponse{}
+	b := s.prepareRq(ctx, url, token, domain.LogEventGetCdr).
+		Verb(http.MethodGet).
+		UrlParams(cdrId).
+		ResponseModel(&rs)
+	err := s.makeRequest(ctx, b.B(), fromPlatform, toPlatform)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return v211.CdrV211ToModel(rs.Data), nil
+}
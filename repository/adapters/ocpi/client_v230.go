@@ -0,0 +1,39 @@
+package ocpi
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	service "github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/model"
+)
+
+// clientV230Impl OCPI 2.3.0 rest client
+// payloads of 2.3.0 are a superset of 2.2.1, so it reuses 2.2.1 client and adjusts the differences only
+type clientV230Impl struct {
+	*clientImpl
+}
+
+func newOcpiRestClientV230(logService domain.OcpiLogService, pool *platformPool) ocpiRestClient {
+	return &clientV230Impl{
+		clientImpl: &clientImpl{
+			logService: logService,
+			pool:       pool,
+		},
+	}
+}
+
+func (s *clientV230Impl) l() kit.CLogger {
+	return service.L().Cmp("ocpi-rest-v230")
+}
+
+func (s *clientV230Impl) PutTariff(ctx context.Context, url, token, fromPlatform, toPlatform string, trf *model.OcpiTariff) error {
+	s.l().Mth("put-trf").Dbg()
+	// tariff type is mandatory since 2.3.0
+	if trf.Type == "" {
+		t := *trf
+		t.Type = domain.TariffTypeReg
+		trf = &t
+	}
+	return s.clientImpl.PutTariff(ctx, url, token, fromPlatform, toPlatform, trf)
+}
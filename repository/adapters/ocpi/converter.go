@@ -3,6 +3,7 @@ package ocpi
 import (
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/model/v211"
 )
 
 func (a *adapterImpl) toVersionsDomain(versions []*model.OcpiVersion) domain.Versions {
@@ -13,7 +14,7 @@ func (a *adapterImpl) toVersionsDomain(versions []*model.OcpiVersion) domain.Ver
 	return res
 }
 
-func (a *adapterImpl) toVersionDetailsDomain(versionDetails *model.OcpiVersionDetails) domain.ModuleEndpoints {
+func (a *adapterImpl) toVersionDetailsDomain(versionDetails *model.OcpiVersionDetails, version, platformRole string) domain.ModuleEndpoints {
 	if versionDetails == nil {
 		return nil
 	}
@@ -22,7 +23,27 @@ func (a *adapterImpl) toVersionDetailsDomain(versionDetails *model.OcpiVersionDe
 		if r[vd.Id] == nil {
 			r[vd.Id] = make(map[string]domain.Endpoint)
 		}
+		// 2.1.1 doesn't pass interface roles, so they are inferred from the platform role
+		if version == model.OcpiVersion211 {
+			for _, role := range v211.ModuleRoles(vd.Id, platformRole) {
+				r[vd.Id][role] = domain.Endpoint(vd.Url)
+			}
+			continue
+		}
 		r[vd.Id][vd.Role] = domain.Endpoint(vd.Url)
 	}
 	return r
 }
+
+// toCredentialsWithRole populates credential roles which aren't passed in 2.1.1
+func (a *adapterImpl) toCredentialsWithRole(cred *model.OcpiCredentials, platformRole string) *model.OcpiCredentials {
+	if cred == nil {
+		return nil
+	}
+	for _, role := range cred.Roles {
+		if role.Role == "" {
+			role.Role = platformRole
+		}
+	}
+	return cred
+}
@@ -0,0 +1,147 @@
+package ocpi
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	maxRetryAfter = time.Minute * 5
+)
+
+// logEventModules maps log events of outgoing requests to OCPI modules
+var logEventModules = map[string]string{
+	domain.LogEventGetVersions:         domain.ModuleIdCredentials,
+	domain.LogEventGetVersionDetails:   domain.ModuleIdCredentials,
+	domain.LogEventPostCredentials:     domain.ModuleIdCredentials,
+	domain.LogEventPutCredentials:      domain.ModuleIdCredentials,
+	domain.LogEventGetCredentials:      domain.ModuleIdCredentials,
+	domain.LogEventDelCredentials:      domain.ModuleIdCredentials,
+	domain.LogEventGetHubClients:       domain.ModuleIdHubClientInfo,
+	domain.LogEventPutClientInfo:       domain.ModuleIdHubClientInfo,
+	domain.LogEventPutLocation:         domain.ModuleIdLocations,
+	domain.LogEventPatchLocation:       domain.ModuleIdLocations,
+	domain.LogEventGetLocations:        domain.ModuleIdLocations,
+	domain.LogEventGetLocation:         domain.ModuleIdLocations,
+	domain.LogEventPutEvse:             domain.ModuleIdLocations,
+	domain.LogEventPatchEvse:           domain.ModuleIdLocations,
+	domain.LogEventGetEvse:             domain.ModuleIdLocations,
+	domain.LogEventPutCon:              domain.ModuleIdLocations,
+	domain.LogEventPatchCon:            domain.ModuleIdLocations,
+	domain.LogEventGetCon:              domain.ModuleIdLocations,
+	domain.LogEventPutTariff:           domain.ModuleIdTariffs,
+	domain.LogEventPatchTariff:         domain.ModuleIdTariffs,
+	domain.LogEventGetTariffs:          domain.ModuleIdTariffs,
+	domain.LogEventGetTariff:           domain.ModuleIdTariffs,
+	domain.LogEventPutToken:            domain.ModuleIdTokens,
+	domain.LogEventPatchToken:          domain.ModuleIdTokens,
+	domain.LogEventGetTokens:           domain.ModuleIdTokens,
+	domain.LogEventGetToken:            domain.ModuleIdTokens,
+	domain.LogEventPutSession:          domain.ModuleIdSessions,
+	domain.LogEventPatchSession:        domain.ModuleIdSessions,
+	domain.LogEventGetSessions:         domain.ModuleIdSessions,
+	domain.LogEventGetSession:          domain.ModuleIdSessions,
+	domain.LogEventPostCommand:         domain.ModuleIdCommands,
+	domain.LogEventPostCommandResponse: domain.ModuleIdCommands,
+	domain.LogEventPostCdr:             domain.ModuleIdCdrs,
+	domain.LogEventGetCdrs:             domain.ModuleIdCdrs,
+	domain.LogEventGetCdr:              domain.ModuleIdCdrs,
+}
+
+// limiter token bucket rate limiter
+// requests aren't rejected when the bucket is empty, instead each request reserves a token in advance and waits its turn
+type limiter struct {
+	sync.Mutex
+	rate   float64   // rate tokens per second, unlimited if zero
+	burst  float64   // burst bucket capacity
+	tokens float64   // tokens available, negative value means there are requests waiting
+	last   time.Time // last when tokens were updated
+	until  time.Time // until requests are paused after the remote platform asked to retry later
+}
+
+func newLimiter(rl *domain.RateLimit) *limiter {
+	l := &limiter{}
+	if rl != nil && rl.Rate > 0 {
+		l.rate = rl.Rate
+		l.burst = float64(rl.Burst)
+		if l.burst < 1 {
+			l.burst = 1
+		}
+		l.tokens = l.burst
+	}
+	return l
+}
+
+// reserve reserves a token and returns delay before request can be sent
+func (l *limiter) reserve(now time.Time) time.Duration {
+	l.Lock()
+	defer l.Unlock()
+
+	var delay time.Duration
+	if l.rate > 0 {
+		// refill
+		if !l.last.IsZero() {
+			l.tokens += now.Sub(l.last).Seconds() * l.rate
+			if l.tokens > l.burst {
+				l.tokens = l.burst
+			}
+		}
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+	if pause := l.until.Sub(now); pause > delay {
+		delay = pause
+	}
+	return delay
+}
+
+// pause stops sending requests for the given period
+func (l *limiter) pause(now time.Time, d time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+	if until := now.Add(d); until.After(l.until) {
+		l.until = until
+	}
+}
+
+// wait waits until request can be sent according to the limiter
+func wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryAfter parses Retry-After header which is either delay in seconds or HTTP date
+func retryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	var d time.Duration
+	if secs, err := strconv.Atoi(header); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(header); err == nil {
+		d = t.Sub(now)
+	}
+	if d < 0 {
+		return 0
+	}
+	if d > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return d
+}
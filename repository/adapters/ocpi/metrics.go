@@ -0,0 +1,48 @@
+package ocpi
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics metrics of outgoing requests to remote platforms
+type metrics struct {
+	queueDepth *prometheus.GaugeVec
+	waitTime   *prometheus.HistogramVec
+	throttled  *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "ocpi",
+			Subsystem: "client",
+			Name:      "queue_depth",
+			Help:      "number of requests waiting for the rate limiter",
+		}, []string{"platform", "module"}),
+		waitTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "ocpi",
+			Subsystem: "client",
+			Name:      "rate_limit_wait_seconds",
+			Help:      "time requests spent waiting for the rate limiter",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+		}, []string{"platform", "module"}),
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ocpi",
+			Subsystem: "client",
+			Name:      "throttled_total",
+			Help:      "number of requests rejected by remote platforms with 429",
+		}, []string{"platform", "module"}),
+	}
+}
+
+func (m *metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.queueDepth.Describe(ch)
+	m.waitTime.Describe(ch)
+	m.throttled.Describe(ch)
+}
+
+func (m *metrics) Collect(ch chan<- prometheus.Metric) {
+	m.queueDepth.Collect(ch)
+	m.waitTime.Collect(ch)
+	m.throttled.Collect(ch)
+}
@@ -0,0 +1,284 @@
+package ocpi
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	service "github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultMaxRetries       = 2
+	defaultRetryBackoff     = time.Millisecond * 200
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = time.Second * 30
+	defaultMaxIdleConns     = 16
+	defaultIdleConnTimeout  = time.Second * 90
+)
+
+// breaker circuit breaker protecting from calling the remote platform which keeps failing
+type breaker struct {
+	sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     string
+	failures  int
+	openedAt  *time.Time
+	trial     bool // trial is true if a trial request is in progress in half-open state
+}
+
+// allow checks if a request can be sent
+func (b *breaker) allow() bool {
+	b.Lock()
+	defer b.Unlock()
+	switch b.state {
+	case domain.BreakerOpen:
+		if kit.Now().Before(b.openedAt.Add(b.cooldown)) {
+			return false
+		}
+		b.state = domain.BreakerHalfOpen
+		b.trial = true
+		return true
+	case domain.BreakerHalfOpen:
+		// only a single trial request is allowed
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	}
+	return true
+}
+
+// success registers a successful request
+func (b *breaker) success() {
+	b.Lock()
+	defer b.Unlock()
+	b.state = domain.BreakerClosed
+	b.failures = 0
+	b.openedAt = nil
+	b.trial = false
+}
+
+// failure registers a failed request
+func (b *breaker) failure() {
+	b.Lock()
+	defer b.Unlock()
+	b.failures++
+	b.trial = false
+	if b.state == domain.BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = domain.BreakerOpen
+		b.openedAt = kit.NowPtr()
+	}
+}
+
+// platformClient http client and its settings used to call the particular remote platform
+type platformClient struct {
+	settings     *domain.ClientSettings
+	http         *http.Client
+	breaker      *breaker
+	sem          chan struct{} // sem limits concurrent requests, nil if unlimited
+	timeout      time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+	limiter      *limiter            // limiter limits all requests to the platform
+	modLimiters  map[string]*limiter // modLimiters limit requests to the particular module
+	queued       int32               // queued number of requests waiting for limiters
+}
+
+// backoff calculates a delay before the retry attempt (starting from 1)
+// it's doubled with every attempt and jittered to avoid bursts of retries
+func (c *platformClient) backoff(attempt int) time.Duration {
+	d := c.retryBackoff << (attempt - 1)
+	return d + time.Duration(rand.Int63n(int64(c.retryBackoff)+1))
+}
+
+// state returns runtime state of the client
+func (c *platformClient) state() *domain.ClientState {
+	c.breaker.Lock()
+	defer c.breaker.Unlock()
+	return &domain.ClientState{
+		Breaker:  c.breaker.state,
+		Failures: c.breaker.failures,
+		OpenedAt: c.breaker.openedAt,
+		InFlight: len(c.sem),
+		Queued:   int(atomic.LoadInt32(&c.queued)),
+	}
+}
+
+// platformPool keeps clients to remote platforms, so that a slow or failing platform doesn't affect others
+type platformPool struct {
+	sync.RWMutex
+	timeout time.Duration
+	clients map[string]*platformClient
+	metrics *metrics
+}
+
+func newPlatformPool(cfg *service.CfgOcpiRemote) *platformPool {
+	p := &platformPool{
+		timeout: defaultTimeout,
+		clients: make(map[string]*platformClient),
+		metrics: newMetrics(),
+	}
+	if cfg != nil && cfg.Timeout != nil {
+		p.timeout = time.Duration(*cfg.Timeout) * time.Second
+	}
+	return p
+}
+
+// newClient creates a client with the given settings, empty settings are populated with defaults
+func (p *platformPool) newClient(settings *domain.ClientSettings, br *breaker) *platformClient {
+	s := settings
+	if s == nil {
+		s = &domain.ClientSettings{}
+	}
+	c := &platformClient{
+		settings:     settings,
+		timeout:      p.timeout,
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+	}
+	if s.Timeout > 0 {
+		c.timeout = time.Duration(s.Timeout) * time.Second
+	}
+	if s.MaxRetries != nil {
+		c.maxRetries = *s.MaxRetries
+	}
+	if s.RetryBackoff > 0 {
+		c.retryBackoff = time.Duration(s.RetryBackoff) * time.Millisecond
+	}
+	if br == nil {
+		br = &breaker{state: domain.BreakerClosed}
+	}
+	br.Lock()
+	br.threshold, br.cooldown = defaultBreakerThreshold, defaultBreakerCooldown
+	if s.BreakerThreshold > 0 {
+		br.threshold = s.BreakerThreshold
+	}
+	if s.BreakerCooldown > 0 {
+		br.cooldown = time.Duration(s.BreakerCooldown) * time.Second
+	}
+	br.Unlock()
+	c.breaker = br
+
+	// pooled connections are kept alive and reused by requests to the same platform
+	idleConns := defaultMaxIdleConns
+	if s.MaxConcurrency > 0 {
+		c.sem = make(chan struct{}, s.MaxConcurrency)
+		idleConns = s.MaxConcurrency
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = idleConns
+	transport.MaxIdleConnsPerHost = idleConns
+	transport.IdleConnTimeout = defaultIdleConnTimeout
+	c.http = &http.Client{Transport: transport}
+
+	// rate limiters
+	c.limiter = newLimiter(s.RateLimit)
+	c.modLimiters = make(map[string]*limiter)
+	for module, rl := range s.ModuleRateLimits {
+		c.modLimiters[module] = newLimiter(rl)
+	}
+
+	return c
+}
+
+// configure applies settings of the remote platform, client is recreated only if settings changed
+func (p *platformPool) configure(platformId string, settings *domain.ClientSettings) {
+	p.RLock()
+	c, ok := p.clients[platformId]
+	p.RUnlock()
+	if ok && reflect.DeepEqual(c.settings, settings) {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	c, ok = p.clients[platformId]
+	if ok && reflect.DeepEqual(c.settings, settings) {
+		return
+	}
+	var br *breaker
+	if ok {
+		// breaker state survives settings changes
+		br = c.breaker
+		c.http.CloseIdleConnections()
+	}
+	p.clients[platformId] = p.newClient(settings, br)
+}
+
+// get returns a client to the remote platform
+func (p *platformPool) get(platformId string) *platformClient {
+	p.RLock()
+	c, ok := p.clients[platformId]
+	p.RUnlock()
+	if ok {
+		return c
+	}
+	p.Lock()
+	defer p.Unlock()
+	if c, ok = p.clients[platformId]; !ok {
+		c = p.newClient(nil, nil)
+		p.clients[platformId] = c
+	}
+	return c
+}
+
+// wait waits until rate limiters of the platform and the module allow sending a request
+// requests are queued rather than rejected when limit is reached
+func (p *platformPool) wait(ctx context.Context, c *platformClient, platformId, module string) error {
+	now := kit.Now()
+	delay := c.limiter.reserve(now)
+	if ml, ok := c.modLimiters[module]; ok {
+		if d := ml.reserve(now); d > delay {
+			delay = d
+		}
+	}
+	if delay > 0 {
+		queueDepth := p.metrics.queueDepth.WithLabelValues(platformId, module)
+		queueDepth.Inc()
+		atomic.AddInt32(&c.queued, 1)
+		defer func() {
+			queueDepth.Dec()
+			atomic.AddInt32(&c.queued, -1)
+		}()
+	}
+	err := wait(ctx, delay)
+	p.metrics.waitTime.WithLabelValues(platformId, module).Observe(time.Since(now).Seconds())
+	return err
+}
+
+// throttle pauses requests to the platform when it responds with 429
+// if the platform doesn't specify when to retry, retry backoff is used
+func (p *platformPool) throttle(c *platformClient, platformId, module string, retryAfter time.Duration) {
+	p.metrics.throttled.WithLabelValues(platformId, module).Inc()
+	if retryAfter <= 0 {
+		retryAfter = c.retryBackoff
+	}
+	c.limiter.pause(kit.Now(), retryAfter)
+}
+
+// state returns runtime state of the client to the remote platform
+func (p *platformPool) state(platformId string) *domain.ClientState {
+	p.RLock()
+	c, ok := p.clients[platformId]
+	p.RUnlock()
+	if !ok {
+		return &domain.ClientState{Breaker: domain.BreakerClosed}
+	}
+	return c.state()
+}
+
+// close releases idle connections
+func (p *platformPool) close() {
+	p.Lock()
+	defer p.Unlock()
+	for _, c := range p.clients {
+		c.http.CloseIdleConnections()
+	}
+}
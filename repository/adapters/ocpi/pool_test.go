@@ -0,0 +1,232 @@
+package ocpi
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type poolTestSuite struct {
+	kit.Suite
+	logSvc *mocks.OcpiLogService
+	pool   *platformPool
+	cl     *clientImpl
+}
+
+func (s *poolTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *poolTestSuite) SetupTest() {
+	s.logSvc = &mocks.OcpiLogService{}
+	s.logSvc.On("Log", mock.Anything, mock.Anything)
+	cfg := &ocpi.CfgOcpiRemote{Timeout: kit.IntPtr(5)}
+	s.pool = newPlatformPool(cfg)
+	s.cl = newOcpiRestClient(s.logSvc, s.pool).(*clientImpl)
+	s.NoError(s.cl.Init(s.Ctx, cfg))
+}
+
+func TestPoolSuite(t *testing.T) {
+	suite.Run(t, new(poolTestSuite))
+}
+
+// server responds with 500 for the first failures requests and succeeds afterwards
+func (s *poolTestSuite) server(failures int32, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"status_code": 3000}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status_code": 1000, "data": []}`))
+	}))
+}
+
+func (s *poolTestSuite) settings(retries, threshold int) *domain.ClientSettings {
+	return &domain.ClientSettings{
+		MaxRetries:       &retries,
+		RetryBackoff:     1,
+		BreakerThreshold: threshold,
+		BreakerCooldown:  60,
+	}
+}
+
+func (s *poolTestSuite) Test_Get_Retried() {
+	var calls int32
+	srv := s.server(2, &calls)
+	defer srv.Close()
+	platformId := kit.NewRandString()
+	s.pool.configure(platformId, s.settings(2, 5))
+
+	_, err := s.cl.GetVersions(s.Ctx, srv.URL, "token", "local", platformId)
+	s.NoError(err)
+	s.Equal(int32(3), atomic.LoadInt32(&calls))
+	s.Equal(domain.BreakerClosed, s.pool.state(platformId).Breaker)
+}
+
+func (s *poolTestSuite) Test_Post_NotRetried() {
+	var calls int32
+	srv := s.server(1, &calls)
+	defer srv.Close()
+	platformId := kit.NewRandString()
+	s.pool.configure(platformId, s.settings(2, 5))
+
+	err := s.cl.PostCdr(s.Ctx, srv.URL, "token", "local", platformId, &model.OcpiCdr{})
+	s.AssertAppErr(err, errors.ErrCodeOcpiRestStatus)
+	s.Equal(int32(1), atomic.LoadInt32(&calls))
+}
+
+func (s *poolTestSuite) Test_Breaker_OpensAndRejects() {
+	var calls int32
+	srv := s.server(100, &calls)
+	defer srv.Close()
+	platformId := kit.NewRandString()
+	s.pool.configure(platformId, s.settings(0, 2))
+
+	for i := 0; i < 2; i++ {
+		_, err := s.cl.GetVersions(s.Ctx, srv.URL, "token", "local", platformId)
+		s.AssertAppErr(err, errors.ErrCodeOcpiRestStatus)
+	}
+	state := s.pool.state(platformId)
+	s.Equal(domain.BreakerOpen, state.Breaker)
+	s.Equal(2, state.Failures)
+	s.NotEmpty(state.OpenedAt)
+
+	// request is rejected without calling the remote platform
+	_, err := s.cl.GetVersions(s.Ctx, srv.URL, "token", "local", platformId)
+	s.AssertAppErr(err, errors.ErrCodeOcpiRestCircuitOpen)
+	s.Equal(int32(2), atomic.LoadInt32(&calls))
+}
+
+func (s *poolTestSuite) Test_Breaker_HalfOpen() {
+	br := &breaker{state: domain.BreakerClosed, threshold: 1}
+	br.failure()
+	s.Equal(domain.BreakerOpen, br.state)
+
+	// cooldown expired, a single trial request is allowed
+	br.openedAt = kit.TimePtr(kit.Now().Add(-defaultBreakerCooldown))
+	br.cooldown = defaultBreakerCooldown
+	s.True(br.allow())
+	s.Equal(domain.BreakerHalfOpen, br.state)
+	s.False(br.allow())
+
+	// trial failed
+	br.failure()
+	s.Equal(domain.BreakerOpen, br.state)
+	s.False(br.allow())
+
+	// trial succeeded
+	br.openedAt = kit.TimePtr(kit.Now().Add(-defaultBreakerCooldown))
+	s.True(br.allow())
+	br.success()
+	s.Equal(domain.BreakerClosed, br.state)
+	s.Equal(0, br.failures)
+	s.True(br.allow())
+}
+
+func (s *poolTestSuite) Test_OcpiError_NotCountedByBreaker() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status_code": 2001, "status_message": "invalid"}`))
+	}))
+	defer srv.Close()
+	platformId := kit.NewRandString()
+	s.pool.configure(platformId, s.settings(2, 1))
+
+	_, err := s.cl.GetVersions(s.Ctx, srv.URL, "token", "local", platformId)
+	s.AssertAppErr(err, errors.ErrCodeOcpiInvalidStatus)
+	s.Equal(domain.BreakerClosed, s.pool.state(platformId).Breaker)
+}
+
+func (s *poolTestSuite) Test_Configure_KeepsBreakerState() {
+	platformId := kit.NewRandString()
+	s.pool.configure(platformId, s.settings(0, 1))
+	s.pool.get(platformId).breaker.failure()
+	s.Equal(domain.BreakerOpen, s.pool.state(platformId).Breaker)
+
+	cl := s.pool.get(platformId)
+	s.pool.configure(platformId, s.settings(0, 1))
+	s.Same(cl, s.pool.get(platformId))
+
+	s.pool.configure(platformId, &domain.ClientSettings{MaxConcurrency: 2})
+	s.NotSame(cl, s.pool.get(platformId))
+	s.Equal(domain.BreakerOpen, s.pool.state(platformId).Breaker)
+	s.Equal(2, cap(s.pool.get(platformId).sem))
+}
+
+func (s *poolTestSuite) Test_Throttled_RetriedAndNotCountedByBreaker() {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status_code": 1000}`))
+	}))
+	defer srv.Close()
+	platformId := kit.NewRandString()
+	s.pool.configure(platformId, s.settings(1, 1))
+
+	start := kit.Now()
+	// not idempotent requests are retried as well, since they haven't been processed
+	err := s.cl.PostCdr(s.Ctx, srv.URL, "token", "local", platformId, &model.OcpiCdr{})
+	s.NoError(err)
+	s.Equal(int32(2), atomic.LoadInt32(&calls))
+	// Retry-After is honoured
+	s.GreaterOrEqual(kit.Now().Sub(start), time.Second)
+	s.Equal(domain.BreakerClosed, s.pool.state(platformId).Breaker)
+}
+
+func (s *poolTestSuite) Test_Limiter() {
+	now := kit.Now()
+	l := newLimiter(&domain.RateLimit{Rate: 10, Burst: 2})
+	// burst
+	s.Zero(l.reserve(now))
+	s.Zero(l.reserve(now))
+	// requests are queued
+	s.Equal(time.Millisecond*100, l.reserve(now))
+	s.Equal(time.Millisecond*200, l.reserve(now))
+	// tokens are refilled
+	s.Zero(l.reserve(now.Add(time.Second)))
+
+	// pause
+	l.pause(now, time.Minute)
+	s.Equal(time.Second*50, l.reserve(now.Add(time.Second*10)))
+
+	// unlimited
+	l = newLimiter(nil)
+	for i := 0; i < 100; i++ {
+		s.Zero(l.reserve(now))
+	}
+}
+
+func (s *poolTestSuite) Test_ModuleLimiter() {
+	platformId := kit.NewRandString()
+	s.pool.configure(platformId, &domain.ClientSettings{
+		ModuleRateLimits: map[string]*domain.RateLimit{domain.ModuleIdLocations: {Rate: 1000, Burst: 1}},
+	})
+	cl := s.pool.get(platformId)
+	s.NoError(s.pool.wait(s.Ctx, cl, platformId, domain.ModuleIdLocations))
+	s.Less(cl.modLimiters[domain.ModuleIdLocations].tokens, float64(1))
+	s.NoError(s.pool.wait(s.Ctx, cl, platformId, domain.ModuleIdSessions))
+	s.Equal(0, s.pool.state(platformId).Queued)
+}
+
+func (s *poolTestSuite) Test_RetryAfter() {
+	now := kit.Now()
+	s.Equal(time.Second*3, retryAfter("3", now))
+	s.Zero(retryAfter("", now))
+	s.Zero(retryAfter("invalid", now))
+	s.Equal(maxRetryAfter, retryAfter("3600", now))
+	s.InDelta(float64(time.Second*10), float64(retryAfter(now.Add(time.Second*10).UTC().Format(http.TimeFormat), now)), float64(time.Second))
+}
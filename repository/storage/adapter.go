@@ -8,6 +8,7 @@ import (
 	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/backend"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Adapter provides a contract to access a remote service
@@ -22,7 +23,13 @@ type Adapter interface {
 	domain.SessionStorage
 	domain.CommandStorage
 	domain.CdrStorage
+	domain.PaymentStorage
+	domain.ReservationStorage
+	domain.DisputeStorage
+	domain.CommandResultNotifier
 	backend.WebhookStorage
+	// Metrics returns collector of cache metrics
+	Metrics() prometheus.Collector
 }
 
 // adapterImpl implements storage adapter
@@ -37,7 +44,13 @@ type adapterImpl struct {
 	*sessionStorageImpl
 	*commandStorageImpl
 	*cdrStorageImpl
-	pg *pg.Storage
+	*paymentStorageImpl
+	*reservationStorageImpl
+	*disputeStorageImpl
+	*commandNotifier
+	pg        *pg.Storage
+	cacheSync *cacheSync
+	metrics   *cacheMetrics
 }
 
 // NewAdapter creates a new instance of the adapter
@@ -69,9 +82,25 @@ func (a *adapterImpl) Init(ctx context.Context, cfg interface{}) error {
 		}
 	}
 
+	// init tokens cipher
+	cipher, err := newTokenCipher(ctx, config.Crypto)
+	if err != nil {
+		return err
+	}
+
+	// init caches
+	a.metrics = newCacheMetrics()
+	a.cacheSync = newCacheSync(a.pg)
+	if config.Cache != nil && config.Cache.Enabled {
+		a.cacheSync.start(ctx)
+	}
+
 	// init storages
-	a.platformStorageImpl = newPlatformStorage(a.pg)
-	a.partyStorageImpl = newPartyStorage(a.pg)
+	a.platformStorageImpl = newPlatformStorage(a.pg, cipher, a.cacheSync, config.Cache, a.metrics)
+	if err := a.platformStorageImpl.init(ctx); err != nil {
+		return err
+	}
+	a.partyStorageImpl = newPartyStorage(a.pg, a.cacheSync, config.Cache, a.metrics)
 	a.logStorageImpl = newLogStorage(a.pg)
 	if err := a.logStorageImpl.init(ctx, 0, 0); err != nil {
 		return err
@@ -83,11 +112,24 @@ func (a *adapterImpl) Init(ctx context.Context, cfg interface{}) error {
 	a.sessionStorageImpl = newSessionStorage(a.pg)
 	a.commandStorageImpl = newCommandStorage(a.pg)
 	a.cdrStorageImpl = newCdrStorage(a.pg)
+	a.paymentStorageImpl = newPaymentStorage(a.pg)
+	a.reservationStorageImpl = newReservationStorage(a.pg)
+	a.disputeStorageImpl = newDisputeStorage(a.pg)
+
+	// init command notifier
+	a.commandNotifier = newCommandNotifier(a.pg)
+	a.commandNotifier.start(ctx)
 
 	return nil
 }
 
+func (a *adapterImpl) Metrics() prometheus.Collector {
+	return a.metrics
+}
+
 func (a *adapterImpl) Close(ctx context.Context) error {
+	a.cacheSync.close()
+	a.commandNotifier.close()
 	if a.pg != nil {
 		a.pg.Close()
 	}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+)
+
+const (
+	cacheTtlDefault = time.Minute
+
+	cachePlatforms = "platforms"
+	cacheParties   = "parties"
+)
+
+// cacheMetrics metrics of in-process caches
+type cacheMetrics struct {
+	hits          *prometheus.CounterVec
+	misses        *prometheus.CounterVec
+	invalidations *prometheus.CounterVec
+}
+
+func newCacheMetrics() *cacheMetrics {
+	return &cacheMetrics{
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ocpi",
+			Subsystem: "cache",
+			Name:      "hits_total",
+			Help:      "number of lookups served from cache",
+		}, []string{"cache"}),
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ocpi",
+			Subsystem: "cache",
+			Name:      "misses_total",
+			Help:      "number of lookups requested from database",
+		}, []string{"cache"}),
+		invalidations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ocpi",
+			Subsystem: "cache",
+			Name:      "invalidations_total",
+			Help:      "number of invalidations either by local changes or notified by other instances",
+		}, []string{"cache", "source"}),
+	}
+}
+
+func (m *cacheMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.hits.Describe(ch)
+	m.misses.Describe(ch)
+	m.invalidations.Describe(ch)
+}
+
+func (m *cacheMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.hits.Collect(ch)
+	m.misses.Collect(ch)
+	m.invalidations.Collect(ch)
+}
+
+type cacheEntry[V any] struct {
+	v   V
+	exp time.Time
+}
+
+// cache in-process cache with TTL
+// the same item can be cached by several keys (id, tokens etc.), so items are invalidated by id rather than by key
+// nil cache is a valid disabled cache
+type cache[V any] struct {
+	sync.RWMutex
+	name    string
+	ttl     time.Duration
+	id      func(V) string
+	items   map[string]*cacheEntry[V]
+	gen     uint64 // gen incremented with every invalidation
+	metrics *cacheMetrics
+}
+
+func newCache[V any](name string, cfg *ocpi.CfgCache, metrics *cacheMetrics, id func(V) string) *cache[V] {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+	c := &cache[V]{
+		name:    name,
+		ttl:     cacheTtlDefault,
+		id:      id,
+		items:   make(map[string]*cacheEntry[V]),
+		metrics: metrics,
+	}
+	if cfg.Ttl > 0 {
+		c.ttl = time.Duration(cfg.Ttl) * time.Second
+	}
+	return c
+}
+
+// get returns cached item and generation which must be passed to set if item isn't found
+func (c *cache[V]) get(key string) (V, uint64, bool) {
+	var v V
+	if c == nil {
+		return v, 0, false
+	}
+	c.RLock()
+	e, ok := c.items[key]
+	gen := c.gen
+	c.RUnlock()
+	if ok && kit.Now().Before(e.exp) {
+		c.metrics.hits.WithLabelValues(c.name).Inc()
+		return e.v, gen, true
+	}
+	c.metrics.misses.WithLabelValues(c.name).Inc()
+	return v, gen, false
+}
+
+// set puts item to cache
+// item isn't cached if there were invalidations since the generation was obtained, as the item read from database might be already outdated
+func (c *cache[V]) set(key string, v V, gen uint64) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.gen != gen {
+		return
+	}
+	c.items[key] = &cacheEntry[V]{v: v, exp: kit.Now().Add(c.ttl)}
+}
+
+// invalidate removes items with the given ids, all items are removed if no ids passed
+func (c *cache[V]) invalidate(source string, ids ...string) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.gen++
+	c.metrics.invalidations.WithLabelValues(c.name, source).Inc()
+	if len(ids) == 0 {
+		c.items = make(map[string]*cacheEntry[V])
+		return
+	}
+	idm := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		idm[id] = struct{}{}
+	}
+	for key, e := range c.items {
+		if _, ok := idm[c.id(e.v)]; ok {
+			delete(c.items, key)
+		}
+	}
+}
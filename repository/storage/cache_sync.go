@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/storages/pg"
+	"github.com/mikhailbolshakov/ocpi"
+	"strings"
+	"sync"
+)
+
+const (
+	// cacheChannel Postgres channel notifying all the instances about changed items
+	cacheChannel = "ocpi_cache"
+	// cachePayloadMaxLen notification payload must be shorter than 8000 bytes, otherwise the whole cache is invalidated
+	cachePayloadMaxLen = 7900
+
+	cacheSourceLocal  = "local"
+	cacheSourceRemote = "remote"
+)
+
+// cacheSync keeps caches of all the service instances consistent
+// a changed item is invalidated locally and all the instances are notified via Postgres LISTEN/NOTIFY
+// notifications might be missed while the listener reconnects, so caches are invalidated completely after reconnecting
+type cacheSync struct {
+	sync.RWMutex
+	handlers map[string]func(source string, ids ...string)
+	listener *listener
+}
+
+func newCacheSync(pg *pg.Storage) *cacheSync {
+	c := &cacheSync{
+		handlers: make(map[string]func(source string, ids ...string)),
+	}
+	// changes made while listening was interrupted are missed, so caches are invalidated every time listening starts
+	c.listener = newListener(pg, cacheChannel, func() { c.invalidateAll(cacheSourceRemote) }, c.notified)
+	return c
+}
+
+func (c *cacheSync) l() kit.CLogger {
+	return ocpi.L().Cmp("cache-sync")
+}
+
+// register registers invalidation handler of the cache
+func (c *cacheSync) register(name string, handler func(source string, ids ...string)) {
+	c.Lock()
+	defer c.Unlock()
+	c.handlers[name] = handler
+}
+
+// changed invalidates items locally and notifies other instances, the whole cache is invalidated if no ids passed
+// failed notification doesn't fail the change, other instances keep items until TTL expires
+func (c *cacheSync) changed(ctx context.Context, name string, ids ...string) {
+	if c == nil {
+		return
+	}
+	c.invalidate(cacheSourceLocal, name, ids...)
+	payload := fmt.Sprintf("%s:%s", name, strings.Join(ids, ","))
+	if len(payload) > cachePayloadMaxLen {
+		payload = name + ":"
+	}
+	if err := c.listener.notify(payload); err != nil {
+		c.l().C(ctx).Mth("notify").E(err).St().Err()
+	}
+}
+
+func (c *cacheSync) invalidate(source, name string, ids ...string) {
+	c.RLock()
+	handler, ok := c.handlers[name]
+	c.RUnlock()
+	if ok {
+		handler(source, ids...)
+	}
+}
+
+func (c *cacheSync) invalidateAll(source string) {
+	c.RLock()
+	defer c.RUnlock()
+	for _, handler := range c.handlers {
+		handler(source)
+	}
+}
+
+// notified handles notification of other instances
+func (c *cacheSync) notified(payload string) {
+	name, ids, _ := strings.Cut(payload, ":")
+	if ids == "" {
+		c.invalidate(cacheSourceRemote, name)
+		return
+	}
+	c.invalidate(cacheSourceRemote, name, strings.Split(ids, ",")...)
+}
+
+// start starts listening notifications
+func (c *cacheSync) start(ctx context.Context) {
+	c.listener.start(ctx)
+}
+
+// close stops listening
+func (c *cacheSync) close() {
+	if c == nil {
+		return
+	}
+	c.listener.close()
+}
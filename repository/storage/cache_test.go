@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type cacheTestSuite struct {
+	kit.Suite
+}
+
+func (s *cacheTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(cacheTestSuite))
+}
+
+type cacheItem struct {
+	id   string
+	name string
+}
+
+func (s *cacheTestSuite) cache(cfg *ocpi.CfgCache) *cache[*cacheItem] {
+	return newCache("test", cfg, newCacheMetrics(), func(v *cacheItem) string { return v.id })
+}
+
+func (s *cacheTestSuite) Test_GetSet() {
+	c := s.cache(&ocpi.CfgCache{Enabled: true})
+	_, gen, ok := c.get("id:1")
+	s.False(ok)
+	item := &cacheItem{id: "1", name: "name"}
+	c.set("id:1", item, gen)
+	c.set("name:name", item, gen)
+	v, _, ok := c.get("id:1")
+	s.True(ok)
+	s.Equal(item, v)
+	v, _, ok = c.get("name:name")
+	s.True(ok)
+	s.Equal(item, v)
+}
+
+func (s *cacheTestSuite) Test_Invalidate_ById() {
+	c := s.cache(&ocpi.CfgCache{Enabled: true})
+	_, gen, _ := c.get("id:1")
+	c.set("id:1", &cacheItem{id: "1"}, gen)
+	c.set("name:1", &cacheItem{id: "1"}, gen)
+	c.set("id:2", &cacheItem{id: "2"}, gen)
+	c.invalidate(cacheSourceLocal, "1")
+	_, _, ok := c.get("id:1")
+	s.False(ok)
+	_, _, ok = c.get("name:1")
+	s.False(ok)
+	_, _, ok = c.get("id:2")
+	s.True(ok)
+}
+
+func (s *cacheTestSuite) Test_Invalidate_All() {
+	c := s.cache(&ocpi.CfgCache{Enabled: true})
+	_, gen, _ := c.get("id:1")
+	c.set("id:1", &cacheItem{id: "1"}, gen)
+	c.set("id:2", &cacheItem{id: "2"}, gen)
+	c.invalidate(cacheSourceRemote)
+	_, _, ok := c.get("id:1")
+	s.False(ok)
+	_, _, ok = c.get("id:2")
+	s.False(ok)
+}
+
+func (s *cacheTestSuite) Test_Set_OutdatedGeneration() {
+	c := s.cache(&ocpi.CfgCache{Enabled: true})
+	_, gen, _ := c.get("id:1")
+	// item is changed while it's read from database
+	c.invalidate(cacheSourceLocal, "1")
+	c.set("id:1", &cacheItem{id: "1"}, gen)
+	_, _, ok := c.get("id:1")
+	s.False(ok)
+}
+
+func (s *cacheTestSuite) Test_Ttl() {
+	c := s.cache(&ocpi.CfgCache{Enabled: true, Ttl: 10})
+	s.Equal(int64(10), int64(c.ttl.Seconds()))
+	_, gen, _ := c.get("id:1")
+	c.set("id:1", &cacheItem{id: "1"}, gen)
+	// expire entry
+	c.items["id:1"].exp = kit.Now()
+	_, _, ok := c.get("id:1")
+	s.False(ok)
+}
+
+func (s *cacheTestSuite) Test_Disabled() {
+	for _, cfg := range []*ocpi.CfgCache{nil, {Enabled: false}} {
+		c := s.cache(cfg)
+		s.Nil(c)
+		_, gen, ok := c.get("id:1")
+		s.False(ok)
+		c.set("id:1", &cacheItem{id: "1"}, gen)
+		_, _, ok = c.get("id:1")
+		s.False(ok)
+		c.invalidate(cacheSourceLocal, "1")
+	}
+}
+
+func (s *cacheTestSuite) Test_Sync_Notified() {
+	sync := newCacheSync(nil)
+	platforms, parties := s.cache(&ocpi.CfgCache{Enabled: true}), s.cache(&ocpi.CfgCache{Enabled: true})
+	sync.register(cachePlatforms, platforms.invalidate)
+	sync.register(cacheParties, parties.invalidate)
+	for _, c := range []*cache[*cacheItem]{platforms, parties} {
+		_, gen, _ := c.get("id:1")
+		c.set("id:1", &cacheItem{id: "1"}, gen)
+		c.set("id:2", &cacheItem{id: "2"}, gen)
+		c.set("id:3", &cacheItem{id: "3"}, gen)
+	}
+
+	// invalidate items of one cache
+	sync.notified(cachePlatforms + ":1,2")
+	_, _, ok := platforms.get("id:1")
+	s.False(ok)
+	_, _, ok = platforms.get("id:2")
+	s.False(ok)
+	_, _, ok = platforms.get("id:3")
+	s.True(ok)
+	_, _, ok = parties.get("id:1")
+	s.True(ok)
+
+	// invalidate the whole cache
+	sync.notified(cacheParties + ":")
+	_, _, ok = parties.get("id:3")
+	s.False(ok)
+	_, _, ok = platforms.get("id:3")
+	s.True(ok)
+
+	// unknown cache is ignored
+	sync.notified("unknown:1")
+	_, _, ok = platforms.get("id:3")
+	s.True(ok)
+
+	// invalidate all the caches
+	sync.invalidateAll(cacheSourceRemote)
+	_, _, ok = platforms.get("id:3")
+	s.False(ok)
+}
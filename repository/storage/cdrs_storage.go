@@ -9,6 +9,7 @@ import (
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
@@ -68,6 +69,19 @@ func (s *cdrStorageImpl) MergeCdr(ctx context.Context, cdr *domain.Cdr) error {
 	return nil
 }
 
+func (s *cdrStorageImpl) MergeCdrs(ctx context.Context, cdrs []*domain.Cdr) error {
+	s.l().C(ctx).Mth("merge-cdrs").F(kit.KV{"count": len(cdrs)}).Dbg()
+	if len(cdrs) == 0 {
+		return nil
+	}
+	dtos := kit.Select(cdrs, s.toCdrDto)
+	dtos = latest(dtos, func(d *cdr) string { return d.Id }, func(d *cdr) time.Time { return d.LastUpdated })
+	if err := s.pg.Instance.Scopes(mergeLatest()).CreateInBatches(dtos, mergeBatchSize).Error; err != nil {
+		return errors.ErrCdrStorageMerge(ctx, err)
+	}
+	return nil
+}
+
 func (s *cdrStorageImpl) UpdateCdr(ctx context.Context, cdr *domain.Cdr) error {
 	s.l().C(ctx).Mth("update-cdr").F(kit.KV{"cdrId": cdr.Id}).Dbg()
 	if err := s.pg.Instance.Scopes(update()).Save(s.toCdrDto(cdr)).Error; err != nil {
@@ -76,6 +90,50 @@ func (s *cdrStorageImpl) UpdateCdr(ctx context.Context, cdr *domain.Cdr) error {
 	return nil
 }
 
+func (s *cdrStorageImpl) CreateCreditCdr(ctx context.Context, originalId, creditId string, build domain.CreditBuilder) (*domain.Cdr, *domain.Cdr, error) {
+	s.l().C(ctx).Mth("create-credit-cdr").F(kit.KV{"origId": originalId, "creditId": creditId}).Dbg()
+	var credit, original, existing *domain.Cdr
+	err := s.pg.Instance.Transaction(func(tx *gorm.DB) error {
+		// the original row is locked, so that concurrent credits don't exceed the creditable amount
+		dto := &cdr{}
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", originalId).Limit(1).Find(&dto)
+		if res.Error != nil {
+			return errors.ErrCdrStorageGet(ctx, res.Error)
+		}
+		if res.RowsAffected > 0 {
+			original = s.toCdrDomain(dto)
+		}
+		// a credit with the same id is looked up under the lock, so that a repeated credit is seen by the builder
+		creditDto := &cdr{}
+		res = tx.Where("id = ?", creditId).Limit(1).Find(&creditDto)
+		if res.Error != nil {
+			return errors.ErrCdrStorageGet(ctx, res.Error)
+		}
+		if res.RowsAffected > 0 {
+			existing = s.toCdrDomain(creditDto)
+		}
+		var err error
+		credit, err = build(original, existing)
+		if err != nil {
+			return err
+		}
+		if existing != nil && credit == existing {
+			return nil
+		}
+		if err := tx.Create(s.toCdrDto(credit)).Error; err != nil {
+			return errors.ErrCdrStorageMerge(ctx, err)
+		}
+		if err := tx.Scopes(update()).Save(s.toCdrDto(original)).Error; err != nil {
+			return errors.ErrCdrStorageMerge(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return credit, original, nil
+}
+
 func (s *cdrStorageImpl) DeleteCdrsByExtId(ctx context.Context, extId domain.PartyExtId) error {
 	s.l().C(ctx).Mth("delete-ext").F(kit.KV{"partyId": extId.PartyId, "country": extId.CountryCode}).Dbg()
 	if extId.PartyId == "" || extId.CountryCode == "" {
@@ -88,13 +146,29 @@ func (s *cdrStorageImpl) DeleteCdrsByExtId(ctx context.Context, extId domain.Par
 	return nil
 }
 
+func (s *cdrStorageImpl) DeleteCdrsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("delete-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return nil
+	}
+	if err := deleteByPlatform(s.pg.Instance, platformId, archive, &cdr{}); err != nil {
+		return errors.ErrCdrStorageDelete(ctx, err)
+	}
+	return nil
+}
+
 func (s *cdrStorageImpl) SearchCdrs(ctx context.Context, cr *domain.CdrSearchCriteria) (*domain.CdrSearchResponse, error) {
 	s.l().Mth("search-cdr").C(ctx).Dbg()
 
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	rs := &domain.CdrSearchResponse{
 		PageResponse: domain.PageResponse{
 			Limit: pagingLimit(cr.PageRequest.Limit),
-			Total: kit.IntPtr(0),
+			Total: pgn.total(0),
 		},
 	}
 
@@ -102,7 +176,7 @@ func (s *cdrStorageImpl) SearchCdrs(ctx context.Context, cr *domain.CdrSearchCri
 	var dtosRead []*cdrRead
 
 	if err := s.pg.Instance.
-		Scopes(s.buildSearchQuery(cr), paging(cr.PageRequest), orderByLastUpdated(true)).
+		Scopes(s.buildSearchQuery(cr), pgn.scope()).
 		Find(&dtosRead).Error; err != nil {
 		return nil, errors.ErrCdrStorageGet(ctx, err)
 	}
@@ -117,8 +191,9 @@ func (s *cdrStorageImpl) SearchCdrs(ctx context.Context, cr *domain.CdrSearchCri
 	}
 
 	rs.Items = s.toCdrsDomain(dtos)
-	rs.Total = &dtosRead[0].TotalCount.TotalCount
-	rs.NextPage = nextPage(cr.PageRequest, rs.Total)
+	last := dtosRead[len(dtosRead)-1].Cdr
+	rs.Total = pgn.total(dtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(dtosRead), last.LastUpdated, last.Id)
 
 	return rs, nil
 }
@@ -126,6 +201,7 @@ func (s *cdrStorageImpl) SearchCdrs(ctx context.Context, cr *domain.CdrSearchCri
 func (s *cdrStorageImpl) buildSearchQuery(criteria *domain.CdrSearchCriteria) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		query := db.Table("cdrs").Select("cdrs.*, count(*) over() total_count")
+		query = query.Where("deleted_at is null")
 		// populate conditions
 		if criteria.ExtId != nil {
 			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
@@ -148,6 +224,9 @@ func (s *cdrStorageImpl) buildSearchQuery(criteria *domain.CdrSearchCriteria) fu
 		if criteria.RefId != "" {
 			query = query.Where("ref_id = ?", criteria.RefId)
 		}
+		if criteria.WithIssues {
+			query = query.Where("jsonb_array_length(details -> 'issues') > 0")
+		}
 		return query
 	}
 }
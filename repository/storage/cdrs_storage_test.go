@@ -6,7 +6,9 @@ import (
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
 	"github.com/stretchr/testify/suite"
+	"sync"
 	"testing"
 	"time"
 )
@@ -74,6 +76,32 @@ func (s *cdrsTestSuite) Test_CRUD() {
 	s.Equal(act, cdr)
 }
 
+func (s *cdrsTestSuite) Test_MergeCdrs() {
+	cdrs := []*domain.Cdr{s.cdr(), s.cdr()}
+	s.NoError(s.storage.MergeCdrs(s.Ctx, cdrs))
+
+	for _, cdr := range cdrs {
+		act, err := s.storage.GetCdr(s.Ctx, cdr.Id)
+		s.NoError(err)
+		s.Equal(act, cdr)
+	}
+
+	// earlier changes aren't applied, later ones are
+	earlier, later := *cdrs[0], *cdrs[1]
+	earlier.LastUpdated = earlier.LastUpdated.Add(-time.Hour)
+	earlier.Details.Currency = "USD"
+	later.LastUpdated = later.LastUpdated.Add(time.Hour)
+	later.Details.Currency = "USD"
+	s.NoError(s.storage.MergeCdrs(s.Ctx, []*domain.Cdr{&earlier, &later}))
+
+	act, err := s.storage.GetCdr(s.Ctx, cdrs[0].Id)
+	s.NoError(err)
+	s.Equal(act, cdrs[0])
+	act, err = s.storage.GetCdr(s.Ctx, cdrs[1].Id)
+	s.NoError(err)
+	s.Equal(act, &later)
+}
+
 func (s *cdrsTestSuite) Test_Search() {
 	// create new
 	cdr := s.cdr()
@@ -119,6 +147,126 @@ func (s *cdrsTestSuite) Test_Search() {
 	s.Len(rs.Items, 1)
 }
 
+func (s *cdrsTestSuite) Test_SearchWithIssues() {
+	cdr := s.cdr()
+	s.NoError(s.storage.MergeCdr(s.Ctx, cdr))
+
+	cr := &domain.CdrSearchCriteria{
+		Ids:        []string{cdr.Id},
+		WithIssues: true,
+	}
+	rs, err := s.storage.SearchCdrs(s.Ctx, cr)
+	s.NoError(err)
+	s.Empty(rs.Items)
+
+	// store issues
+	cdr.Details.Issues = []*domain.CdrIssue{{Severity: domain.CdrIssueSeverityError, Code: domain.CdrIssueEnergy, Message: "energy"}}
+	s.NoError(s.storage.MergeCdr(s.Ctx, cdr))
+
+	rs, err = s.storage.SearchCdrs(s.Ctx, cr)
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+	s.Equal(cdr.Details.Issues, rs.Items[0].Details.Issues)
+}
+
+func (s *cdrsTestSuite) Test_CreateCreditCdr() {
+	orig := s.cdr()
+	s.NoError(s.storage.MergeCdr(s.Ctx, orig))
+
+	credit := s.cdr()
+	credit.Details.CreditReferenceId = orig.Id
+	credit.Details.TotalCost = domain.Price{ExclVat: -40}
+	orig.Details.Credited = &domain.Price{ExclVat: 40}
+	orig.Details.CreditCdrIds = []string{credit.Id}
+	actCredit, actOrig, err := s.storage.CreateCreditCdr(s.Ctx, orig.Id, credit.Id, func(stored, existing *domain.Cdr) (*domain.Cdr, error) {
+		s.Equal(orig.Id, stored.Id)
+		s.Nil(existing)
+		stored.Details = orig.Details
+		return credit, nil
+	})
+	s.NoError(err)
+	s.Equal(credit, actCredit)
+	s.Equal(orig, actOrig)
+
+	act, err := s.storage.GetCdr(s.Ctx, credit.Id)
+	s.NoError(err)
+	s.Equal(credit, act)
+
+	act, err = s.storage.GetCdr(s.Ctx, orig.Id)
+	s.NoError(err)
+	s.Equal(orig, act)
+}
+
+func (s *cdrsTestSuite) Test_CreateCreditCdr_Repeated() {
+	orig := s.cdr()
+	s.NoError(s.storage.MergeCdr(s.Ctx, orig))
+	credit := s.cdr()
+	credit.Details.CreditReferenceId = orig.Id
+	s.NoError(s.storage.MergeCdr(s.Ctx, credit))
+
+	// the existing credit is returned, nothing is stored
+	actCredit, actOrig, err := s.storage.CreateCreditCdr(s.Ctx, orig.Id, credit.Id, func(stored, existing *domain.Cdr) (*domain.Cdr, error) {
+		s.Equal(credit, existing)
+		stored.Details.CreditCdrIds = append(stored.Details.CreditCdrIds, credit.Id)
+		return existing, nil
+	})
+	s.NoError(err)
+	s.Equal(credit, actCredit)
+	s.Equal(orig.Id, actOrig.Id)
+
+	act, err := s.storage.GetCdr(s.Ctx, orig.Id)
+	s.NoError(err)
+	s.Equal(orig, act)
+
+	// a new credit with the existing id isn't upserted
+	_, _, err = s.storage.CreateCreditCdr(s.Ctx, orig.Id, credit.Id, func(stored, existing *domain.Cdr) (*domain.Cdr, error) {
+		another := s.cdr()
+		another.Id = existing.Id
+		return another, nil
+	})
+	s.AssertAppErr(err, errors.ErrCodeCdrStorageMerge)
+
+	act, err = s.storage.GetCdr(s.Ctx, credit.Id)
+	s.NoError(err)
+	s.Equal(credit, act)
+}
+
+func (s *cdrsTestSuite) Test_CreateCreditCdr_NotFound() {
+	_, _, err := s.storage.CreateCreditCdr(s.Ctx, kit.NewId(), kit.NewId(), func(stored, existing *domain.Cdr) (*domain.Cdr, error) {
+		s.Nil(stored)
+		return nil, errors.ErrCdrCreditNotFound(s.Ctx, "")
+	})
+	s.AssertAppErr(err, errors.ErrCodeCdrCreditNotFound)
+}
+
+func (s *cdrsTestSuite) Test_CreateCreditCdr_Concurrent() {
+	orig := s.cdr()
+	orig.Details.Credited = nil
+	orig.Details.CreditCdrIds = nil
+	s.NoError(s.storage.MergeCdr(s.Ctx, orig))
+
+	// every builder sees credits of the previous ones, so that nothing is lost
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			credit := s.cdr()
+			_, _, err := s.storage.CreateCreditCdr(s.Ctx, orig.Id, credit.Id, func(stored, existing *domain.Cdr) (*domain.Cdr, error) {
+				credit.Details.CreditReferenceId = stored.Id
+				stored.Details.CreditCdrIds = append(stored.Details.CreditCdrIds, credit.Id)
+				return credit, nil
+			})
+			s.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	act, err := s.storage.GetCdr(s.Ctx, orig.Id)
+	s.NoError(err)
+	s.Len(act.Details.CreditCdrIds, 5)
+}
+
 func (s *cdrsTestSuite) Test_DeleteByExt() {
 
 	sess := s.cdr()
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/storages/pg"
+	"github.com/mikhailbolshakov/ocpi"
+	"sync"
+)
+
+const (
+	// commandChannel Postgres channel notifying all the instances about received command results
+	commandChannel = "ocpi_commands"
+)
+
+// commandNotifier notifies waiters of command results
+// waiters of the same instance are notified in-process, other instances are notified via Postgres LISTEN/NOTIFY
+type commandNotifier struct {
+	sync.Mutex
+	waiters  map[string]map[chan struct{}]struct{}
+	listener *listener
+}
+
+func newCommandNotifier(pg *pg.Storage) *commandNotifier {
+	n := &commandNotifier{
+		waiters: make(map[string]map[chan struct{}]struct{}),
+	}
+	n.listener = newListener(pg, commandChannel, nil, n.signal)
+	return n
+}
+
+func (n *commandNotifier) l() kit.CLogger {
+	return ocpi.L().Cmp("cmd-notifier")
+}
+
+// NotifyCommandResult signals local waiters and notifies other instances
+// failed notification doesn't fail the command result, remote waiters get the result when waiting times out
+func (n *commandNotifier) NotifyCommandResult(ctx context.Context, cmdId string) {
+	n.signal(cmdId)
+	if err := n.listener.notify(cmdId); err != nil {
+		n.l().C(ctx).Mth("notify").F(kit.KV{"cmdId": cmdId}).E(err).St().Err()
+	}
+}
+
+func (n *commandNotifier) SubscribeCommandResult(ctx context.Context, cmdId string) (<-chan struct{}, func()) {
+	// buffered, so that signal never blocks
+	ch := make(chan struct{}, 1)
+
+	n.Lock()
+	defer n.Unlock()
+	if n.waiters[cmdId] == nil {
+		n.waiters[cmdId] = make(map[chan struct{}]struct{})
+	}
+	n.waiters[cmdId][ch] = struct{}{}
+
+	return ch, func() {
+		n.Lock()
+		defer n.Unlock()
+		delete(n.waiters[cmdId], ch)
+		if len(n.waiters[cmdId]) == 0 {
+			delete(n.waiters, cmdId)
+		}
+	}
+}
+
+// signal signals all the local waiters of the command
+func (n *commandNotifier) signal(cmdId string) {
+	n.Lock()
+	defer n.Unlock()
+	for ch := range n.waiters[cmdId] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// start starts listening notifications of other instances
+func (n *commandNotifier) start(ctx context.Context) {
+	n.listener.start(ctx)
+}
+
+// close stops listening
+func (n *commandNotifier) close() {
+	if n == nil {
+		return
+	}
+	n.listener.close()
+}
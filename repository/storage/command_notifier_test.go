@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type commandNotifierTestSuite struct {
+	kit.Suite
+}
+
+func (s *commandNotifierTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func TestCommandNotifierSuite(t *testing.T) {
+	suite.Run(t, new(commandNotifierTestSuite))
+}
+
+func (s *commandNotifierTestSuite) Test_Signal() {
+	n := newCommandNotifier(nil)
+
+	ch1, unsubscribe1 := n.SubscribeCommandResult(s.Ctx, "1")
+	ch2, unsubscribe2 := n.SubscribeCommandResult(s.Ctx, "1")
+	other, unsubscribeOther := n.SubscribeCommandResult(s.Ctx, "2")
+	defer unsubscribeOther()
+
+	// notification of other instance
+	n.signal("1")
+	s.Len(ch1, 1)
+	s.Len(ch2, 1)
+	s.Empty(other)
+
+	// repeated signal doesn't block
+	n.signal("1")
+	s.Len(ch1, 1)
+
+	unsubscribe1()
+	unsubscribe2()
+	s.NotContains(n.waiters, "1")
+	s.Contains(n.waiters, "2")
+
+	// no waiters
+	n.signal("1")
+}
@@ -1,10 +1,20 @@
 package storage
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"time"
+)
+
+const (
+	// mergeBatchSize number of rows upserted by a single statement
+	mergeBatchSize = 500
 )
 
 type totalCount struct {
@@ -42,12 +52,56 @@ func merge() func(*gorm.DB) *gorm.DB {
 	}
 }
 
+// mergeLatest upserts rows, the stored row is updated only if the incoming one isn't older
+// it protects later changes from being overwritten by concurrent merges
+func mergeLatest() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Clauses(clause.OnConflict{
+			UpdateAll: true,
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{
+					SQL:  "? <= excluded.last_updated",
+					Vars: []any{clause.Column{Table: clause.CurrentTable, Name: "last_updated"}},
+				},
+			}},
+		})
+	}
+}
+
+// latest leaves the latest version of rows having the same key
+// a single upsert statement cannot affect the same row twice
+func latest[T any](dtos []T, key func(T) string, lastUpdated func(T) time.Time) []T {
+	idx := make(map[string]int, len(dtos))
+	rs := make([]T, 0, len(dtos))
+	for _, dto := range dtos {
+		k := key(dto)
+		if i, ok := idx[k]; ok {
+			if !lastUpdated(dto).Before(lastUpdated(rs[i])) {
+				rs[i] = dto
+			}
+			continue
+		}
+		idx[k] = len(rs)
+		rs = append(rs, dto)
+	}
+	return rs
+}
+
 func update() func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		return db.Omit("created_at")
 	}
 }
 
+// deleteByPlatform deletes platform's items. If archive, items are marked as deleted but kept in storage
+func deleteByPlatform(db *gorm.DB, platformId string, archive bool, dto any) error {
+	query := db.Where("platform_id = ?", platformId)
+	if archive {
+		return query.Model(dto).Update("deleted_at", kit.Now()).Error
+	}
+	return query.Delete(dto).Error
+}
+
 func nextPage(rq domain.PageRequest, total *int) *domain.PageRequest {
 
 	if total == nil {
@@ -73,3 +127,83 @@ func nextPage(rq domain.PageRequest, total *int) *domain.PageRequest {
 	}
 
 }
+
+// pageCursor keyset position of the page, it points to the last row of the previous page
+type pageCursor struct {
+	LastUpdated time.Time `json:"u"`
+	Id          string    `json:"i"`
+	Skipped     int       `json:"s"` // Skipped number of rows on the previous pages
+}
+
+// page paging of the search query, rows are ordered by (last_updated, id)
+// keyset is applied if cursor is specified or the first page requested, otherwise offset is applied for compatibility
+type page struct {
+	rq     domain.PageRequest
+	limit  int
+	cursor *pageCursor // cursor position of the page, nil for the first page or offset paging
+	keyset bool
+}
+
+func newPage(ctx context.Context, rq domain.PageRequest) (*page, error) {
+	p := &page{
+		rq:     rq,
+		limit:  *pagingLimit(rq.Limit),
+		keyset: rq.Cursor != nil || rq.Offset == nil || *rq.Offset == 0,
+	}
+	if rq.Cursor != nil {
+		bytes, err := base64.RawURLEncoding.DecodeString(*rq.Cursor)
+		if err != nil {
+			return nil, errors.ErrPageCursorInvalid(ctx)
+		}
+		p.cursor = &pageCursor{}
+		if err := json.Unmarshal(bytes, p.cursor); err != nil || p.cursor.Id == "" || p.cursor.Skipped < 0 {
+			return nil, errors.ErrPageCursorInvalid(ctx)
+		}
+	}
+	return p, nil
+}
+
+// scope applies ordering and paging to the query
+func (p *page) scope() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Order("last_updated, id").Limit(p.limit)
+		if !p.keyset {
+			return db.Offset(*p.rq.Offset)
+		}
+		if p.cursor != nil {
+			db = db.Where("(last_updated, id) > (?, ?)", p.cursor.LastUpdated, p.cursor.Id)
+		}
+		return db
+	}
+}
+
+// total calculates total number of rows by total count of the query
+// with keyset, the query counts only rows after the cursor
+func (p *page) total(count int) *int {
+	if p.cursor != nil {
+		return kit.IntPtr(p.cursor.Skipped + count)
+	}
+	return kit.IntPtr(count)
+}
+
+// next builds the next page request, the next page starts after the last fetched row
+func (p *page) next(total *int, fetched int, lastUpdated time.Time, lastId string) *domain.PageRequest {
+	if !p.keyset {
+		return nextPage(p.rq, total)
+	}
+	skipped := fetched
+	if p.cursor != nil {
+		skipped += p.cursor.Skipped
+	}
+	if fetched < p.limit || skipped >= *total {
+		return nil
+	}
+	bytes, _ := json.Marshal(&pageCursor{LastUpdated: lastUpdated, Id: lastId, Skipped: skipped})
+	cursor := base64.RawURLEncoding.EncodeToString(bytes)
+	return &domain.PageRequest{
+		Limit:    kit.IntPtr(p.limit),
+		Cursor:   &cursor,
+		DateFrom: p.rq.DateFrom,
+		DateTo:   p.rq.DateTo,
+	}
+}
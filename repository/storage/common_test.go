@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type pageTestSuite struct {
+	kit.Suite
+}
+
+func (s *pageTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func TestPageSuite(t *testing.T) {
+	suite.Run(t, new(pageTestSuite))
+}
+
+func (s *pageTestSuite) Test_Keyset() {
+	dateFrom := kit.TimePtr(kit.Now().Add(-time.Hour))
+
+	// first page
+	p, err := newPage(s.Ctx, domain.PageRequest{Limit: kit.IntPtr(2), DateFrom: dateFrom})
+	s.NoError(err)
+	s.True(p.keyset)
+	s.Nil(p.cursor)
+	total := p.total(5)
+	s.Equal(5, *total)
+	lastUpdated := kit.Now()
+	next := p.next(total, 2, lastUpdated, "id-2")
+	s.NotEmpty(next)
+	s.NotEmpty(next.Cursor)
+	s.Nil(next.Offset)
+	s.Equal(2, *next.Limit)
+	s.Equal(dateFrom, next.DateFrom)
+
+	// second page
+	p, err = newPage(s.Ctx, *next)
+	s.NoError(err)
+	s.True(p.keyset)
+	s.Equal("id-2", p.cursor.Id)
+	s.True(lastUpdated.Equal(p.cursor.LastUpdated))
+	s.Equal(2, p.cursor.Skipped)
+	// query counts rows after the cursor
+	total = p.total(3)
+	s.Equal(5, *total)
+	next = p.next(total, 2, lastUpdated, "id-4")
+	s.NotEmpty(next)
+
+	// last page
+	p, err = newPage(s.Ctx, *next)
+	s.NoError(err)
+	total = p.total(1)
+	s.Equal(5, *total)
+	s.Nil(p.next(total, 1, lastUpdated, "id-5"))
+}
+
+func (s *pageTestSuite) Test_Offset() {
+	p, err := newPage(s.Ctx, domain.PageRequest{Limit: kit.IntPtr(2), Offset: kit.IntPtr(2)})
+	s.NoError(err)
+	s.False(p.keyset)
+	total := p.total(5)
+	s.Equal(5, *total)
+	next := p.next(total, 2, kit.Now(), "id-4")
+	s.NotEmpty(next)
+	s.Equal(4, *next.Offset)
+	s.Nil(next.Cursor)
+}
+
+func (s *pageTestSuite) Test_InvalidCursor() {
+	for _, cursor := range []string{"invalid cursor", "e30", "bm90LWpzb24"} {
+		_, err := newPage(s.Ctx, domain.PageRequest{Cursor: &cursor})
+		s.AssertAppErr(err, errors.ErrCodePageCursorInvalid)
+	}
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	cryptoKeySize   = 32
+	cryptoSeparator = ":"
+)
+
+var (
+	// separate keys are derived from the master key for encryption and hashing
+	cryptoEncSalt  = []byte("ocpi.token.enc")
+	cryptoHashSalt = []byte("ocpi.token.hash")
+)
+
+type cryptoKey struct {
+	aead    cipher.AEAD
+	hashKey []byte
+}
+
+// tokenCipher encrypts tokens with AES-GCM and calculates keyed hashes to look tokens up
+// every value is prefixed with a key id, so that the keys can be rotated
+type tokenCipher struct {
+	current string
+	keys    map[string]*cryptoKey
+}
+
+func newTokenCipher(ctx context.Context, cfg *ocpi.CfgCrypto) (*tokenCipher, error) {
+	if cfg == nil || cfg.Current == "" {
+		return nil, errors.ErrCryptoCurrentKeyNotFound(ctx, "")
+	}
+	c := &tokenCipher{
+		current: cfg.Current,
+		keys:    make(map[string]*cryptoKey),
+	}
+	for id, k := range cfg.Keys {
+		if k == nil || id == "" || strings.Contains(id, cryptoSeparator) {
+			return nil, errors.ErrCryptoKeyInvalid(ctx, id)
+		}
+		master, err := c.loadKey(k)
+		if err != nil || len(master) != cryptoKeySize {
+			// key isn't configured, it's allowed for not current keys
+			if err == nil && len(master) == 0 {
+				if id != cfg.Current {
+					continue
+				}
+				return nil, errors.ErrCryptoCurrentKeyNotFound(ctx, id)
+			}
+			return nil, errors.ErrCryptoKeyInvalid(ctx, id)
+		}
+		block, err := aes.NewCipher(c.derive(master, cryptoEncSalt))
+		if err != nil {
+			return nil, errors.ErrCryptoKeyInvalid(ctx, id)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errors.ErrCryptoKeyInvalid(ctx, id)
+		}
+		c.keys[id] = &cryptoKey{
+			aead:    aead,
+			hashKey: c.derive(master, cryptoHashSalt),
+		}
+	}
+	if _, ok := c.keys[cfg.Current]; !ok {
+		return nil, errors.ErrCryptoCurrentKeyNotFound(ctx, cfg.Current)
+	}
+	return c, nil
+}
+
+func (c *tokenCipher) loadKey(k *ocpi.CfgCryptoKey) ([]byte, error) {
+	val := k.Key
+	if val == "" && k.File != "" {
+		b, err := os.ReadFile(k.File)
+		if err != nil {
+			return nil, err
+		}
+		val = string(b)
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(val))
+}
+
+func (c *tokenCipher) derive(master, salt []byte) []byte {
+	m := hmac.New(sha256.New, master)
+	m.Write(salt)
+	return m.Sum(nil)
+}
+
+func (c *tokenCipher) hashWithKey(id string, token string) string {
+	m := hmac.New(sha256.New, c.keys[id].hashKey)
+	m.Write([]byte(token))
+	return id + cryptoSeparator + hex.EncodeToString(m.Sum(nil))
+}
+
+// hash calculates a keyed hash of the token with the current key
+func (c *tokenCipher) hash(token string) string {
+	if token == "" {
+		return ""
+	}
+	return c.hashWithKey(c.current, token)
+}
+
+// hashes calculates keyed hashes of the token with all available keys
+// it allows looking tokens up which haven't been re-hashed with the current key yet
+func (c *tokenCipher) hashes(token string) []string {
+	var r []string
+	for id := range c.keys {
+		r = append(r, c.hashWithKey(id, token))
+	}
+	return r
+}
+
+// encrypt encrypts the token with the current key
+func (c *tokenCipher) encrypt(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	aead := c.keys[c.current].aead
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.ErrCryptoEncrypt(ctx, err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(token), []byte(c.current))
+	return c.current + cryptoSeparator + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt decrypts the value encrypted with any of available keys
+func (c *tokenCipher) decrypt(ctx context.Context, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	id, data, _ := strings.Cut(value, cryptoSeparator)
+	key, ok := c.keys[id]
+	if !ok {
+		return "", errors.ErrCryptoKeyInvalid(ctx, id)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", errors.ErrCryptoDecrypt(ctx, err)
+	}
+	if len(sealed) < key.aead.NonceSize() {
+		return "", errors.ErrCryptoDecrypt(ctx, io.ErrUnexpectedEOF)
+	}
+	nonce, ct := sealed[:key.aead.NonceSize()], sealed[key.aead.NonceSize():]
+	token, err := key.aead.Open(nil, nonce, ct, []byte(id))
+	if err != nil {
+		return "", errors.ErrCryptoDecrypt(ctx, err)
+	}
+	return string(token), nil
+}
+
+// encrypted checks if the value is prefixed with id of one of available keys
+func (c *tokenCipher) encrypted(value string) bool {
+	id, _, ok := strings.Cut(value, cryptoSeparator)
+	if !ok {
+		return false
+	}
+	_, ok = c.keys[id]
+	return ok
+}
+
+// outdated checks if the value is encrypted or hashed not with the current key
+func (c *tokenCipher) outdated(value string) bool {
+	if value == "" {
+		return false
+	}
+	return !strings.HasPrefix(value, c.current+cryptoSeparator)
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type cryptoTestSuite struct {
+	kit.Suite
+}
+
+func (s *cryptoTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func TestCryptoSuite(t *testing.T) {
+	suite.Run(t, new(cryptoTestSuite))
+}
+
+func (s *cryptoTestSuite) key() string {
+	b := make([]byte, cryptoKeySize)
+	_, _ = rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func (s *cryptoTestSuite) Test_EncryptDecrypt() {
+	c, err := newTokenCipher(s.Ctx, &ocpi.CfgCrypto{Current: "k1", Keys: map[string]*ocpi.CfgCryptoKey{"k1": {Key: s.key()}}})
+	s.NoError(err)
+
+	token := kit.NewRandString()
+	enc, err := c.encrypt(s.Ctx, token)
+	s.NoError(err)
+	s.NotContains(enc, token)
+
+	// encryption isn't deterministic
+	enc2, err := c.encrypt(s.Ctx, token)
+	s.NoError(err)
+	s.NotEqual(enc, enc2)
+
+	dec, err := c.decrypt(s.Ctx, enc)
+	s.NoError(err)
+	s.Equal(token, dec)
+
+	// hash is deterministic
+	s.Equal(c.hash(token), c.hash(token))
+	s.NotContains(c.hash(token), token)
+	s.NotEqual(c.hash(token), c.hash(kit.NewRandString()))
+}
+
+func (s *cryptoTestSuite) Test_KeyRotation() {
+	k1, k2 := s.key(), s.key()
+	c1, err := newTokenCipher(s.Ctx, &ocpi.CfgCrypto{Current: "k1", Keys: map[string]*ocpi.CfgCryptoKey{"k1": {Key: k1}}})
+	s.NoError(err)
+	c2, err := newTokenCipher(s.Ctx, &ocpi.CfgCrypto{Current: "k2", Keys: map[string]*ocpi.CfgCryptoKey{"k1": {Key: k1}, "k2": {Key: k2}}})
+	s.NoError(err)
+
+	token := kit.NewRandString()
+	enc, err := c1.encrypt(s.Ctx, token)
+	s.NoError(err)
+	s.True(c2.outdated(enc))
+	s.False(c1.outdated(enc))
+
+	// value encrypted with the previous key can be decrypted
+	dec, err := c2.decrypt(s.Ctx, enc)
+	s.NoError(err)
+	s.Equal(token, dec)
+
+	// previous hash can be found
+	s.Contains(c2.hashes(token), c1.hash(token))
+
+	// unknown key
+	enc, err = c2.encrypt(s.Ctx, token)
+	s.NoError(err)
+	_, err = c1.decrypt(s.Ctx, enc)
+	s.AssertAppErr(err, errors.ErrCodeCryptoKeyInvalid)
+}
+
+func (s *cryptoTestSuite) Test_Encrypted() {
+	k1 := s.key()
+	c1, err := newTokenCipher(s.Ctx, &ocpi.CfgCrypto{Current: "k1", Keys: map[string]*ocpi.CfgCryptoKey{"k1": {Key: k1}}})
+	s.NoError(err)
+	c2, err := newTokenCipher(s.Ctx, &ocpi.CfgCrypto{Current: "k2", Keys: map[string]*ocpi.CfgCryptoKey{"k1": {Key: k1}, "k2": {Key: s.key()}}})
+	s.NoError(err)
+
+	enc, err := c2.encrypt(s.Ctx, kit.NewRandString())
+	s.NoError(err)
+	s.True(c2.encrypted(enc))
+	// key isn't available
+	s.False(c1.encrypted(enc))
+	// plain tokens
+	s.False(c1.encrypted(kit.NewRandString()))
+	s.False(c1.encrypted("k3:" + kit.NewRandString()))
+	s.False(c1.encrypted(""))
+}
+
+func (s *cryptoTestSuite) Test_KeyFile() {
+	file := filepath.Join(s.T().TempDir(), "ocpi.key")
+	s.NoError(os.WriteFile(file, []byte(s.key()+"\n"), 0600))
+	_, err := newTokenCipher(s.Ctx, &ocpi.CfgCrypto{Current: "k1", Keys: map[string]*ocpi.CfgCryptoKey{"k1": {File: file}}})
+	s.NoError(err)
+}
+
+func (s *cryptoTestSuite) Test_InvalidConfig() {
+	_, err := newTokenCipher(s.Ctx, nil)
+	s.AssertAppErr(err, errors.ErrCodeCryptoCurrentKeyNotFound)
+	// current key isn't configured (e.g. OCPI_CRYPTO_KEY isn't set)
+	_, err = newTokenCipher(s.Ctx, &ocpi.CfgCrypto{Current: "k1", Keys: map[string]*ocpi.CfgCryptoKey{"k1": {}}})
+	s.AssertAppErr(err, errors.ErrCodeCryptoCurrentKeyNotFound)
+	_, err = newTokenCipher(s.Ctx, &ocpi.CfgCrypto{Current: "k1", Keys: map[string]*ocpi.CfgCryptoKey{"k1": {Key: "short"}}})
+	s.AssertAppErr(err, errors.ErrCodeCryptoKeyInvalid)
+	_, err = newTokenCipher(s.Ctx, &ocpi.CfgCrypto{Current: "k2", Keys: map[string]*ocpi.CfgCryptoKey{"k1": {Key: s.key()}}})
+	s.AssertAppErr(err, errors.ErrCodeCryptoCurrentKeyNotFound)
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/storages/pg"
+	"github.com/mikhailbolshakov/ocpi/domain"
+)
+
+func (s *disputeStorageImpl) toDisputeDto(d *domain.Dispute) *dispute {
+	if d == nil {
+		return nil
+	}
+	dto := &dispute{
+		Id:          d.Id,
+		Status:      d.Status,
+		PartyId:     d.ExtId.PartyId,
+		CountryCode: d.ExtId.CountryCode,
+		PlatformId:  d.PlatformId,
+		RefId:       pg.StringToNull(d.RefId),
+		LastUpdated: d.LastUpdated,
+		LastSent:    d.LastSent,
+	}
+	dto.Details, _ = pg.ToJsonb(&d.Details)
+	return dto
+}
+
+func (s *disputeStorageImpl) toDisputeDomain(dto *dispute) *domain.Dispute {
+	if dto == nil {
+		return nil
+	}
+	d := &domain.Dispute{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     dto.PartyId,
+				CountryCode: dto.CountryCode,
+			},
+			PlatformId:  dto.PlatformId,
+			RefId:       pg.NullToString(dto.RefId),
+			LastUpdated: dto.LastUpdated,
+			LastSent:    dto.LastSent,
+		},
+		Id:     dto.Id,
+		Status: dto.Status,
+	}
+	det, _ := pg.FromJsonb[domain.DisputeDetails](dto.Details)
+	if det != nil {
+		d.Details = *det
+	}
+	return d
+}
+
+func (s *disputeStorageImpl) toDisputesDomain(dtos []*dispute) []*domain.Dispute {
+	return kit.Select(dtos, s.toDisputeDomain)
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"github.com/jackc/pgtype"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/storages/pg"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type dispute struct {
+	pg.GormDto
+	Id          string        `gorm:"column:id;primaryKey"`
+	Status      string        `gorm:"column:status"`
+	Details     *pgtype.JSONB `gorm:"column:details"`
+	PartyId     string        `gorm:"column:party_id"`
+	CountryCode string        `gorm:"column:country_code"`
+	PlatformId  string        `gorm:"column:platform_id"`
+	RefId       *string       `gorm:"column:ref_id"`
+	LastUpdated time.Time     `gorm:"column:last_updated"`
+	LastSent    *time.Time    `gorm:"column:last_sent"`
+}
+
+type disputeRead struct {
+	Dispute    dispute    `gorm:"embedded"`
+	TotalCount totalCount `gorm:"embedded"`
+}
+
+type disputeStorageImpl struct {
+	pg *pg.Storage
+}
+
+func (s *disputeStorageImpl) l() kit.CLogger {
+	return ocpi.L().Cmp("disp-storage")
+}
+
+func newDisputeStorage(pg *pg.Storage) *disputeStorageImpl {
+	return &disputeStorageImpl{
+		pg: pg,
+	}
+}
+
+func (s *disputeStorageImpl) GetDispute(ctx context.Context, id string) (*domain.Dispute, error) {
+	s.l().C(ctx).Mth("get-disp").F(kit.KV{"dispId": id}).Dbg()
+	if id == "" {
+		return nil, nil
+	}
+	dto := &dispute{}
+	res := s.pg.Instance.Where("id = ?", id).Limit(1).Find(&dto)
+	if res.Error != nil {
+		return nil, errors.ErrDisputeStorageGet(ctx, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return s.toDisputeDomain(dto), nil
+}
+
+func (s *disputeStorageImpl) MergeDispute(ctx context.Context, d *domain.Dispute) error {
+	s.l().C(ctx).Mth("merge-disp").F(kit.KV{"dispId": d.Id}).Dbg()
+	if err := s.pg.Instance.Scopes(merge()).Create(s.toDisputeDto(d)).Error; err != nil {
+		return errors.ErrDisputeStorageMerge(ctx, err)
+	}
+	return nil
+}
+
+func (s *disputeStorageImpl) UpdateDispute(ctx context.Context, d *domain.Dispute) error {
+	s.l().C(ctx).Mth("update-disp").F(kit.KV{"dispId": d.Id}).Dbg()
+	if err := s.pg.Instance.Scopes(update()).Save(s.toDisputeDto(d)).Error; err != nil {
+		return errors.ErrDisputeStorageUpdate(ctx, err)
+	}
+	return nil
+}
+
+func (s *disputeStorageImpl) SearchDisputes(ctx context.Context, cr *domain.DisputeSearchCriteria) (*domain.DisputeSearchResponse, error) {
+	s.l().Mth("search-disp").C(ctx).Dbg()
+
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &domain.DisputeSearchResponse{
+		PageResponse: domain.PageResponse{
+			Limit: pagingLimit(cr.PageRequest.Limit),
+			Total: pgn.total(0),
+		},
+	}
+
+	// make query
+	var dtosRead []*disputeRead
+
+	if err := s.pg.Instance.
+		Scopes(s.buildSearchQuery(cr), pgn.scope()).
+		Find(&dtosRead).Error; err != nil {
+		return nil, errors.ErrDisputeStorageGet(ctx, err)
+	}
+
+	if len(dtosRead) == 0 {
+		return rs, nil
+	}
+
+	dtos := make([]*dispute, 0, len(dtosRead))
+	for _, p := range dtosRead {
+		dtos = append(dtos, &p.Dispute)
+	}
+
+	rs.Items = s.toDisputesDomain(dtos)
+	last := dtosRead[len(dtosRead)-1].Dispute
+	rs.Total = pgn.total(dtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(dtosRead), last.LastUpdated, last.Id)
+
+	return rs, nil
+}
+
+func (s *disputeStorageImpl) buildSearchQuery(criteria *domain.DisputeSearchCriteria) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query := db.Table("disputes").Select("disputes.*, count(*) over() total_count")
+		// populate conditions
+		if criteria.ExtId != nil {
+			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
+		}
+		if criteria.DateTo != nil {
+			query = query.Where("last_updated <= ?", *criteria.DateTo)
+		}
+		if criteria.DateFrom != nil {
+			query = query.Where("last_updated >= ?", *criteria.DateFrom)
+		}
+		if len(criteria.Ids) > 0 {
+			query = query.Where("id in (?)", criteria.Ids)
+		}
+		if len(criteria.IncPlatforms) > 0 {
+			query = query.Where("platform_id in (?)", criteria.IncPlatforms)
+		}
+		if len(criteria.ExcPlatforms) > 0 {
+			query = query.Where("platform_id not in (?)", criteria.ExcPlatforms)
+		}
+		if criteria.RefId != "" {
+			query = query.Where("ref_id = ?", criteria.RefId)
+		}
+		if len(criteria.Statuses) > 0 {
+			query = query.Where("status in (?)", criteria.Statuses)
+		}
+		if criteria.CdrId != "" {
+			query = query.Where("cdr_id = ?", criteria.CdrId)
+		}
+		return query
+	}
+}
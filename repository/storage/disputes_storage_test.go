@@ -0,0 +1,110 @@
+//go:build integration
+
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type disputesTestSuite struct {
+	kit.Suite
+	storage domain.DisputeStorage
+	adapter Adapter
+}
+
+func (s *disputesTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+
+	// load config
+	cfg, err := ocpi.LoadConfig()
+	if err != nil {
+		s.Fatal(err)
+	}
+
+	s.adapter = NewAdapter()
+	s.NoError(s.adapter.Init(s.Ctx, cfg.Storages))
+
+	s.storage = s.adapter
+}
+
+func (s *disputesTestSuite) TearDownSuite() {
+	_ = s.adapter.Close(s.Ctx)
+}
+
+func TestDisputesSuite(t *testing.T) {
+	suite.Run(t, new(disputesTestSuite))
+}
+
+func (s *disputesTestSuite) Test_CRUD() {
+	// get when no exists
+	act, err := s.storage.GetDispute(s.Ctx, kit.NewId())
+	s.NoError(err)
+	s.Empty(act)
+
+	// create new
+	d := s.dispute()
+	s.NoError(s.storage.MergeDispute(s.Ctx, d))
+
+	// get
+	act, err = s.storage.GetDispute(s.Ctx, d.Id)
+	s.NoError(err)
+	s.Equal(act, d)
+
+	// update
+	d.Status = domain.DisputeStatusResolved
+	d.Details.CreditCdrId = kit.NewId()
+	d.Details.ClosedAt = kit.NowPtr()
+	s.NoError(s.storage.UpdateDispute(s.Ctx, d))
+
+	act, err = s.storage.GetDispute(s.Ctx, d.Id)
+	s.NoError(err)
+	s.Equal(act, d)
+}
+
+func (s *disputesTestSuite) Test_Search() {
+	d := s.dispute()
+	s.NoError(s.storage.MergeDispute(s.Ctx, d))
+
+	rs, err := s.storage.SearchDisputes(s.Ctx, &domain.DisputeSearchCriteria{
+		ExtId:        &d.ExtId,
+		IncPlatforms: []string{d.PlatformId},
+		Statuses:     []string{domain.DisputeStatusOpen, domain.DisputeStatusAcknowledged},
+		CdrId:        d.Details.CdrId,
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+	s.Equal(d.Id, rs.Items[0].Id)
+
+	rs, err = s.storage.SearchDisputes(s.Ctx, &domain.DisputeSearchCriteria{
+		CdrId:    d.Details.CdrId,
+		Statuses: []string{domain.DisputeStatusResolved},
+	})
+	s.NoError(err)
+	s.Empty(rs.Items)
+}
+
+func (s *disputesTestSuite) dispute() *domain.Dispute {
+	return &domain.Dispute{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     "TST",
+				CountryCode: "RS",
+			},
+			PlatformId:  kit.NewRandString(),
+			LastUpdated: kit.Now(),
+		},
+		Id:     kit.NewId(),
+		Status: domain.DisputeStatusOpen,
+		Details: domain.DisputeDetails{
+			CdrId:    kit.NewId(),
+			Reason:   "energy overcharged",
+			Amount:   domain.Price{ExclVat: 10, InclVat: kit.Float64Ptr(12)},
+			Currency: "EUR",
+			Evidence: []*domain.DisputeEvidence{{Type: domain.DisputeEvidenceDocument, RefId: "https://docs", AddedAt: kit.Now()}},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/goroutine"
+	"github.com/mikhailbolshakov/kit/storages/pg"
+	"github.com/mikhailbolshakov/ocpi"
+	"time"
+)
+
+const (
+	// listenerReconnectDelay delay before listening again after connection is lost
+	listenerReconnectDelay = time.Second * 5
+)
+
+// listener listens notifications of the Postgres channel on a dedicated connection and reconnects when the connection is lost
+type listener struct {
+	pg        *pg.Storage
+	channel   string
+	connected func()               // connected called every time listening (re)starts, notifications sent in between are missed
+	notified  func(payload string) // notified called on every notification
+	cancelFn  context.CancelFunc
+	done      chan struct{}
+}
+
+func newListener(pg *pg.Storage, channel string, connected func(), notified func(payload string)) *listener {
+	return &listener{
+		pg:        pg,
+		channel:   channel,
+		connected: connected,
+		notified:  notified,
+	}
+}
+
+func (c *listener) l() kit.CLogger {
+	return ocpi.L().Cmp("pg-listener")
+}
+
+// notify sends notification to the channel
+func (c *listener) notify(payload string) error {
+	return c.pg.Instance.Exec("select pg_notify(?, ?)", c.channel, payload).Error
+}
+
+// start starts listening notifications
+func (c *listener) start(ctx context.Context) {
+	l := c.l().C(ctx).Mth("listen").F(kit.KV{"channel": c.channel})
+	ctx, c.cancelFn = context.WithCancel(ctx)
+	c.done = make(chan struct{})
+	goroutine.New().WithLogger(l).Go(ctx, func() {
+		defer close(c.done)
+		for {
+			err := c.listen(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			l.E(err).St().Warn("listening interrupted")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(listenerReconnectDelay):
+			}
+		}
+	})
+}
+
+// listen holds a dedicated connection and waits for notifications until the connection fails or the context is cancelled
+func (c *listener) listen(ctx context.Context) error {
+	db, err := c.pg.Instance.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	return conn.Raw(func(driverConn any) error {
+		pgxConn := driverConn.(*stdlib.Conn).Conn()
+		if _, err := pgxConn.Exec(ctx, "listen "+c.channel); err != nil {
+			return err
+		}
+		defer func() { _, _ = pgxConn.Exec(context.Background(), "unlisten "+c.channel) }()
+
+		if c.connected != nil {
+			c.connected()
+		}
+
+		for {
+			n, err := pgxConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+			c.notified(n.Payload)
+		}
+	})
+}
+
+// close stops listening
+func (c *listener) close() {
+	if c == nil || c.cancelFn == nil {
+		return
+	}
+	c.cancelFn()
+	<-c.done
+}
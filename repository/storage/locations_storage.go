@@ -172,6 +172,42 @@ func (s *locationStorageImpl) MergeLocation(ctx context.Context, loc *domain.Loc
 	return nil
 }
 
+func (s *locationStorageImpl) MergeLocations(ctx context.Context, locs []*domain.Location) error {
+	s.l().C(ctx).Mth("merge-locs").F(kit.KV{"count": len(locs)}).Dbg()
+
+	if len(locs) == 0 {
+		return nil
+	}
+
+	locDtos := make([]*location, 0, len(locs))
+	var evseDtos []*evse
+	var conDtos []*connector
+	for _, loc := range locs {
+		locDtos = append(locDtos, s.toLocationDto(loc))
+		for _, evse := range loc.Evses {
+			evseDtos = append(evseDtos, s.toEvseDto(evse))
+			for _, con := range evse.Connectors {
+				conDtos = append(conDtos, s.toConnectorDto(con))
+			}
+		}
+	}
+
+	// locations, evses and connectors are upserted with multi-row statements in a single transaction
+	err := s.pg.Instance.Transaction(func(tx *gorm.DB) error {
+		if err := s.mergeLocationDtos(tx, locDtos); err != nil {
+			return err
+		}
+		if err := s.mergeEvseDtos(tx, evseDtos); err != nil {
+			return err
+		}
+		return s.mergeConnectorDtos(tx, conDtos)
+	})
+	if err != nil {
+		return errors.ErrLocStorageMerge(ctx, err)
+	}
+	return nil
+}
+
 func (s *locationStorageImpl) UpdateLocation(ctx context.Context, loc *domain.Location) error {
 	s.l().C(ctx).Mth("update-loc").F(kit.KV{"locId": loc.Id}).Dbg()
 	if err := s.pg.Instance.Scopes(update()).Save(s.toLocationDto(loc)).Error; err != nil {
@@ -220,13 +256,40 @@ func (s *locationStorageImpl) DeleteLocationsByExtId(ctx context.Context, extId
 	return nil
 }
 
+func (s *locationStorageImpl) DeleteLocationsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("delete-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return nil
+	}
+
+	tx := s.pg.Instance.Begin()
+	if tx.Error != nil {
+		return errors.ErrEvseStorageTx(ctx, tx.Error)
+	}
+
+	for _, dto := range []any{&location{}, &evse{}, &connector{}} {
+		if err := deleteByPlatform(tx, platformId, archive, dto); err != nil {
+			tx.Rollback()
+			return errors.ErrConStorageDelete(ctx, err)
+		}
+	}
+
+	tx.Commit()
+	return nil
+}
+
 func (s *locationStorageImpl) SearchLocations(ctx context.Context, cr *domain.LocationSearchCriteria) (*domain.LocationSearchResponse, error) {
 	l := s.l().Mth("search-loc").C(ctx).Dbg()
 
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	rs := &domain.LocationSearchResponse{
 		PageResponse: domain.PageResponse{
 			Limit: pagingLimit(cr.PageRequest.Limit),
-			Total: kit.IntPtr(0),
+			Total: pgn.total(0),
 		},
 	}
 
@@ -236,7 +299,7 @@ func (s *locationStorageImpl) SearchLocations(ctx context.Context, cr *domain.Lo
 	var conDtos []*connector
 
 	if err := s.pg.Instance.
-		Scopes(s.buildLocSearchQuery(cr), paging(cr.PageRequest), orderByLastUpdated(true)).
+		Scopes(s.buildLocSearchQuery(cr), pgn.scope()).
 		Find(&locDtosRead).Error; err != nil {
 		return nil, errors.ErrLocStorageGetDb(ctx, err)
 	}
@@ -280,8 +343,9 @@ func (s *locationStorageImpl) SearchLocations(ctx context.Context, cr *domain.Lo
 
 	// build response
 	rs.Items = s.toLocationsDomain(locDtos, evseDtos, conDtos)
-	rs.Total = &locDtosRead[0].TotalCount.TotalCount
-	rs.NextPage = nextPage(cr.PageRequest, rs.Total)
+	last := locDtosRead[len(locDtosRead)-1].Location
+	rs.Total = pgn.total(locDtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(locDtosRead), last.LastUpdated, last.Id)
 
 	return rs, nil
 }
@@ -329,10 +393,15 @@ func (s *locationStorageImpl) GetEvse(ctx context.Context, locId, evseId string,
 func (s *locationStorageImpl) SearchEvses(ctx context.Context, cr *domain.EvseSearchCriteria) (*domain.EvseSearchResponse, error) {
 	s.l().Mth("search-evse").C(ctx).Dbg()
 
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	rs := &domain.EvseSearchResponse{
 		PageResponse: domain.PageResponse{
 			Limit: pagingLimit(cr.PageRequest.Limit),
-			Total: kit.IntPtr(0),
+			Total: pgn.total(0),
 		},
 	}
 
@@ -341,7 +410,7 @@ func (s *locationStorageImpl) SearchEvses(ctx context.Context, cr *domain.EvseSe
 	var conDtos []*connector
 
 	if err := s.pg.Instance.
-		Scopes(s.buildEvseSearchQuery(cr), paging(cr.PageRequest), orderByLastUpdated(true)).
+		Scopes(s.buildEvseSearchQuery(cr), pgn.scope()).
 		Find(&evseDtosRead).Error; err != nil {
 		return nil, errors.ErrEvseStorageGetDb(ctx, err)
 	}
@@ -371,7 +440,9 @@ func (s *locationStorageImpl) SearchEvses(ctx context.Context, cr *domain.EvseSe
 
 	// build response
 	rs.Items = s.toEvsesDomain(evseDtos, conMap)
-	rs.Total = &evseDtosRead[0].TotalCount.TotalCount
+	last := evseDtosRead[len(evseDtosRead)-1].Evse
+	rs.Total = pgn.total(evseDtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(evseDtosRead), last.LastUpdated, last.Id)
 	return rs, nil
 }
 
@@ -452,6 +523,70 @@ func (s *locationStorageImpl) MergeEvse(ctx context.Context, evse *domain.Evse)
 	return nil
 }
 
+func (s *locationStorageImpl) MergeEvses(ctx context.Context, evses []*domain.Evse) error {
+	s.l().C(ctx).Mth("merge-evses").F(kit.KV{"count": len(evses)}).Dbg()
+
+	if len(evses) == 0 {
+		return nil
+	}
+
+	evseDtos := make([]*evse, 0, len(evses))
+	var conDtos []*connector
+	locUpdated := make(map[string]time.Time)
+	for _, evse := range evses {
+		evseDtos = append(evseDtos, s.toEvseDto(evse))
+		for _, con := range evse.Connectors {
+			conDtos = append(conDtos, s.toConnectorDto(con))
+		}
+		if evse.LastUpdated.After(locUpdated[evse.LocationId]) {
+			locUpdated[evse.LocationId] = evse.LastUpdated
+		}
+	}
+
+	err := s.pg.Instance.Transaction(func(tx *gorm.DB) error {
+		// update last_updated of locations
+		for locId, lastUpdated := range locUpdated {
+			if err := tx.Scopes(update()).Model(&location{}).
+				Where("id = ? and last_updated < ?", locId, lastUpdated).
+				Update("last_updated", lastUpdated).Error; err != nil {
+				return err
+			}
+		}
+		if err := s.mergeEvseDtos(tx, evseDtos); err != nil {
+			return err
+		}
+		return s.mergeConnectorDtos(tx, conDtos)
+	})
+	if err != nil {
+		return errors.ErrEvseStorageMerge(ctx, err)
+	}
+	return nil
+}
+
+func (s *locationStorageImpl) mergeLocationDtos(tx *gorm.DB, dtos []*location) error {
+	if len(dtos) == 0 {
+		return nil
+	}
+	dtos = latest(dtos, func(d *location) string { return d.Id }, func(d *location) time.Time { return d.LastUpdated })
+	return tx.Scopes(mergeLatest()).CreateInBatches(dtos, mergeBatchSize).Error
+}
+
+func (s *locationStorageImpl) mergeEvseDtos(tx *gorm.DB, dtos []*evse) error {
+	if len(dtos) == 0 {
+		return nil
+	}
+	dtos = latest(dtos, func(d *evse) string { return d.Id }, func(d *evse) time.Time { return d.LastUpdated })
+	return tx.Scopes(mergeLatest()).CreateInBatches(dtos, mergeBatchSize).Error
+}
+
+func (s *locationStorageImpl) mergeConnectorDtos(tx *gorm.DB, dtos []*connector) error {
+	if len(dtos) == 0 {
+		return nil
+	}
+	dtos = latest(dtos, func(d *connector) string { return d.Id }, func(d *connector) time.Time { return d.LastUpdated })
+	return tx.Scopes(mergeLatest()).CreateInBatches(dtos, mergeBatchSize).Error
+}
+
 func (s *locationStorageImpl) MergeConnector(ctx context.Context, con *domain.Connector) error {
 	l := s.l().C(ctx).Mth("merge-con").F(kit.KV{"conId": con.Id}).Dbg()
 	eg := goroutine.NewGroup(ctx).WithLogger(l)
@@ -532,10 +667,15 @@ func (s *locationStorageImpl) UpdateConnector(ctx context.Context, con *domain.C
 func (s *locationStorageImpl) SearchConnectors(ctx context.Context, cr *domain.ConnectorSearchCriteria) (*domain.ConnectorSearchResponse, error) {
 	s.l().Mth("search-con").C(ctx).Dbg()
 
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	rs := &domain.ConnectorSearchResponse{
 		PageResponse: domain.PageResponse{
 			Limit: pagingLimit(cr.PageRequest.Limit),
-			Total: kit.IntPtr(0),
+			Total: pgn.total(0),
 		},
 	}
 
@@ -543,7 +683,7 @@ func (s *locationStorageImpl) SearchConnectors(ctx context.Context, cr *domain.C
 	var conDtosRead []*connectorRead
 
 	if err := s.pg.Instance.
-		Scopes(s.buildConSearchQuery(cr), paging(cr.PageRequest), orderByLastUpdated(true)).
+		Scopes(s.buildConSearchQuery(cr), pgn.scope()).
 		Find(&conDtosRead).Error; err != nil {
 		return nil, errors.ErrConStorageGetDb(ctx, err)
 	}
@@ -559,7 +699,9 @@ func (s *locationStorageImpl) SearchConnectors(ctx context.Context, cr *domain.C
 
 	// build response
 	rs.Items = s.toConnectorsDomain(conDtos)
-	rs.Total = &conDtosRead[0].TotalCount.TotalCount
+	last := conDtosRead[len(conDtosRead)-1].Connector
+	rs.Total = pgn.total(conDtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(conDtosRead), last.LastUpdated, last.Id)
 	return rs, nil
 }
 
@@ -582,6 +724,7 @@ func (s *locationStorageImpl) GetConnector(ctx context.Context, locId, evseId, c
 func (s *locationStorageImpl) buildLocSearchQuery(criteria *domain.LocationSearchCriteria) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		query := db.Table("locations").Select("locations.*, count(*) over() total_count")
+		query = query.Where("deleted_at is null")
 		// populate conditions
 		if criteria.ExtId != nil {
 			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
@@ -611,6 +754,7 @@ func (s *locationStorageImpl) buildLocSearchQuery(criteria *domain.LocationSearc
 func (s *locationStorageImpl) buildConSearchQuery(criteria *domain.ConnectorSearchCriteria) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		query := db.Table("connectors").Select("connectors.*, count(*) over() total_count")
+		query = query.Where("deleted_at is null")
 		// populate conditions
 		if criteria.ExtId != nil {
 			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
@@ -631,6 +775,7 @@ func (s *locationStorageImpl) buildConSearchQuery(criteria *domain.ConnectorSear
 func (s *locationStorageImpl) buildEvseSearchQuery(criteria *domain.EvseSearchCriteria) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		query := db.Table("evses").Select("evses.*, count(*) over() total_count")
+		query = query.Where("deleted_at is null")
 		// populate conditions
 		if criteria.ExtId != nil {
 			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
@@ -96,6 +96,57 @@ func (s *locationsTestSuite) Test_Location_CRUD() {
 
 }
 
+func (s *locationsTestSuite) Test_MergeLocations() {
+	locs := []*domain.Location{s.location(), s.location()}
+	s.NoError(s.storage.MergeLocations(s.Ctx, locs))
+
+	for _, loc := range locs {
+		act, err := s.storage.GetLocation(s.Ctx, loc.Id, true)
+		s.NoError(err)
+		s.Equal(act, loc)
+	}
+
+	// merge when exists
+	locs[0].Details.Name = "another"
+	locs[1].Evses[0].Status = domain.EvseStatusCharging
+	s.NoError(s.storage.MergeLocations(s.Ctx, locs))
+
+	for _, loc := range locs {
+		act, err := s.storage.GetLocation(s.Ctx, loc.Id, true)
+		s.NoError(err)
+		s.Equal(act, loc)
+	}
+}
+
+func (s *locationsTestSuite) Test_MergeLocations_WhenStoredLater_NotOverwritten() {
+	loc := s.location()
+	s.NoError(s.storage.MergeLocations(s.Ctx, []*domain.Location{loc}))
+
+	// earlier changes aren't applied
+	earlier := s.location()
+	earlier.Id = loc.Id
+	earlier.Details.Name = "earlier"
+	earlier.LastUpdated = loc.LastUpdated.Add(-time.Hour)
+	earlier.Evses = nil
+	s.NoError(s.storage.MergeLocations(s.Ctx, []*domain.Location{earlier}))
+
+	act, err := s.storage.GetLocation(s.Ctx, loc.Id, true)
+	s.NoError(err)
+	s.Equal(act, loc)
+
+	// the latest version is applied if the same location is merged twice
+	later := s.location()
+	later.Id = loc.Id
+	later.Evses = loc.Evses
+	later.Details.Name = "later"
+	later.LastUpdated = loc.LastUpdated.Add(time.Hour)
+	s.NoError(s.storage.MergeLocations(s.Ctx, []*domain.Location{later, loc}))
+
+	act, err = s.storage.GetLocation(s.Ctx, loc.Id, false)
+	s.NoError(err)
+	s.Equal("later", act.Details.Name)
+}
+
 func (s *locationsTestSuite) Test_Location_Search() {
 	// merge when not exists
 	loc := s.location()
@@ -218,6 +269,31 @@ func (s *locationsTestSuite) Test_Evse_CRUD() {
 
 }
 
+func (s *locationsTestSuite) Test_MergeEvses() {
+	loc := s.location()
+	s.NoError(s.storage.MergeLocation(s.Ctx, loc))
+
+	evses := []*domain.Evse{s.evse(loc.ExtId.PartyId, loc.Id), s.evse(loc.ExtId.PartyId, loc.Id)}
+	s.NoError(s.storage.MergeEvses(s.Ctx, evses))
+
+	for _, evse := range evses {
+		act, err := s.storage.GetEvse(s.Ctx, evse.LocationId, evse.Id, true)
+		s.NoError(err)
+		s.Equal(act, evse)
+	}
+
+	// merge when exists
+	evses[0].Status = domain.EvseStatusCharging
+	evses[1].Status = domain.EvseStatusBlocked
+	s.NoError(s.storage.MergeEvses(s.Ctx, evses))
+
+	for _, evse := range evses {
+		act, err := s.storage.GetEvse(s.Ctx, evse.LocationId, evse.Id, true)
+		s.NoError(err)
+		s.Equal(act, evse)
+	}
+}
+
 func (s *locationsTestSuite) Test_Evse_Search() {
 	// merge when not exists
 	evse := s.evse(kit.NewId(), kit.NewId())
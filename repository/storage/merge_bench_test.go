@@ -0,0 +1,98 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"testing"
+)
+
+// benchPageSize number of objects merged per iteration, it corresponds to a page of remote pull
+const benchPageSize = domain.PageSizeMaxLimit
+
+func benchAdapter(b *testing.B) Adapter {
+	cfg, err := ocpi.LoadConfig()
+	if err != nil {
+		b.Fatal(err)
+	}
+	a := NewAdapter()
+	if err := a.Init(context.Background(), cfg.Storages); err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { _ = a.Close(context.Background()) })
+	return a
+}
+
+func benchItems[T any](n int, item func() T) []T {
+	items := make([]T, n)
+	for i := range items {
+		items[i] = item()
+	}
+	return items
+}
+
+// benchMerge compares merging objects one by one with merging them in batch
+func benchMerge[T any](b *testing.B, item func() T, mergeOne func(context.Context, T) error, mergeAll func(context.Context, []T) error) {
+	ctx := context.Background()
+	b.Run("one-by-one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			items := benchItems(benchPageSize, item)
+			b.StartTimer()
+			for _, it := range items {
+				if err := mergeOne(ctx, it); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			items := benchItems(benchPageSize, item)
+			b.StartTimer()
+			if err := mergeAll(ctx, items); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMergeLocations(b *testing.B) {
+	a := benchAdapter(b)
+	benchMerge(b, (&locationsTestSuite{}).location, a.MergeLocation, a.MergeLocations)
+}
+
+func BenchmarkMergeTariffs(b *testing.B) {
+	a := benchAdapter(b)
+	benchMerge(b, (&tariffsTestSuite{}).tariff, a.MergeTariff, a.MergeTariffs)
+}
+
+func BenchmarkMergeTokens(b *testing.B) {
+	a := benchAdapter(b)
+	benchMerge(b, (&tokensTestSuite{}).token, a.MergeToken, a.MergeTokens)
+}
+
+func BenchmarkMergeSessions(b *testing.B) {
+	a := benchAdapter(b)
+	s := &sessionsTestSuite{}
+	session := func() *domain.Session {
+		sess := s.session()
+		sess.ChargingPeriods = []*domain.ChargingPeriod{s.chargingPeriod()}
+		return sess
+	}
+	mergeOne := func(ctx context.Context, sess *domain.Session) error {
+		if err := a.MergeSession(ctx, sess); err != nil {
+			return err
+		}
+		return a.UpdateChargingPeriods(ctx, sess, sess.ChargingPeriods)
+	}
+	benchMerge(b, session, mergeOne, a.MergeSessions)
+}
+
+func BenchmarkMergeCdrs(b *testing.B) {
+	a := benchAdapter(b)
+	benchMerge(b, (&cdrsTestSuite{}).cdr, a.MergeCdr, a.MergeCdrs)
+}
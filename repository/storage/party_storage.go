@@ -39,17 +39,23 @@ type partyRead struct {
 }
 
 type partyStorageImpl struct {
-	pg *pg.Storage
+	pg    *pg.Storage
+	cache *cache[*party]
+	sync  *cacheSync
 }
 
 func (s *partyStorageImpl) l() kit.CLogger {
 	return ocpi.L().Cmp("party-storage")
 }
 
-func newPartyStorage(pg *pg.Storage) *partyStorageImpl {
-	return &partyStorageImpl{
-		pg: pg,
+func newPartyStorage(pg *pg.Storage, sync *cacheSync, cfg *ocpi.CfgCache, metrics *cacheMetrics) *partyStorageImpl {
+	s := &partyStorageImpl{
+		pg:    pg,
+		cache: newCache(cacheParties, cfg, metrics, func(dto *party) string { return dto.Id }),
+		sync:  sync,
 	}
+	sync.register(cacheParties, s.cache.invalidate)
+	return s
 }
 
 func (s *partyStorageImpl) CreateParty(ctx context.Context, p *domain.Party) error {
@@ -58,6 +64,7 @@ func (s *partyStorageImpl) CreateParty(ctx context.Context, p *domain.Party) err
 	if err != nil {
 		return errors.ErrPartyStorageCreate(ctx, err)
 	}
+	s.sync.changed(ctx, cacheParties, p.Id)
 	return nil
 }
 
@@ -67,6 +74,7 @@ func (s *partyStorageImpl) UpdateParty(ctx context.Context, p *domain.Party) err
 	if err != nil {
 		return errors.ErrPartyStorageUpdate(ctx, err)
 	}
+	s.sync.changed(ctx, cacheParties, p.Id)
 	return nil
 }
 
@@ -75,16 +83,11 @@ func (s *partyStorageImpl) GetPartyByExtId(ctx context.Context, extId domain.Par
 	if extId.PartyId == "" || extId.CountryCode == "" {
 		return nil, nil
 	}
-	dto := &party{}
-	res := s.pg.Instance.
-		Where("party_id = ?", extId.PartyId).
-		Where("country_code = ?", extId.CountryCode).
-		Limit(1).Find(&dto)
-	if res.Error != nil {
-		return nil, errors.ErrPartyStorageGetDb(ctx, res.Error)
-	}
-	if res.RowsAffected == 0 {
-		return nil, nil
+	dto, err := s.getCached(ctx, "ext:"+extId.CountryCode+"/"+extId.PartyId, func(db *gorm.DB) *gorm.DB {
+		return db.Where("party_id = ?", extId.PartyId).Where("country_code = ?", extId.CountryCode)
+	})
+	if err != nil {
+		return nil, err
 	}
 	return s.toPartyDomain(dto), nil
 }
@@ -107,13 +110,11 @@ func (s *partyStorageImpl) GetByRefId(ctx context.Context, refId string) (*domai
 	if refId == "" {
 		return nil, nil
 	}
-	dto := &party{}
-	res := s.pg.Instance.Where("ref_id = ?", refId).Limit(1).Find(&dto)
-	if res.Error != nil {
-		return nil, errors.ErrPartyStorageGetDb(ctx, res.Error)
-	}
-	if res.RowsAffected == 0 {
-		return nil, nil
+	dto, err := s.getCached(ctx, "ref:"+refId, func(db *gorm.DB) *gorm.DB {
+		return db.Where("ref_id = ?", refId)
+	})
+	if err != nil {
+		return nil, err
 	}
 	return s.toPartyDomain(dto), nil
 }
@@ -123,13 +124,11 @@ func (s *partyStorageImpl) GetParty(ctx context.Context, id string) (*domain.Par
 	if id == "" {
 		return nil, nil
 	}
-	dto := &party{}
-	res := s.pg.Instance.Where("id = ?", id).Limit(1).Find(&dto)
-	if res.Error != nil {
-		return nil, errors.ErrPartyStorageGetDb(ctx, res.Error)
-	}
-	if res.RowsAffected == 0 {
-		return nil, nil
+	dto, err := s.getCached(ctx, "id:"+id, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", id)
+	})
+	if err != nil {
+		return nil, err
 	}
 	return s.toPartyDomain(dto), nil
 }
@@ -140,16 +139,22 @@ func (s *partyStorageImpl) MarkSentParties(ctx context.Context, date time.Time,
 	if res.Error != nil {
 		return errors.ErrPartyStorageUpdate(ctx, res.Error)
 	}
+	s.sync.changed(ctx, cacheParties, partyIds...)
 	return nil
 }
 
 func (s *partyStorageImpl) Search(ctx context.Context, criteria *domain.PartySearchCriteria) (*domain.PartySearchResponse, error) {
 	s.l().Mth("search").C(ctx).Dbg()
 
+	pgn, err := newPage(ctx, criteria.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	rs := &domain.PartySearchResponse{
 		PageResponse: domain.PageResponse{
 			Limit: pagingLimit(criteria.PageRequest.Limit),
-			Total: kit.IntPtr(0),
+			Total: pgn.total(0),
 		},
 	}
 
@@ -157,7 +162,7 @@ func (s *partyStorageImpl) Search(ctx context.Context, criteria *domain.PartySea
 	var dtosRead []*partyRead
 
 	if err := s.pg.Instance.
-		Scopes(s.buildSearchQuery(criteria), paging(criteria.PageRequest), orderByLastUpdated(true)).
+		Scopes(s.buildSearchQuery(criteria), pgn.scope()).
 		Find(&dtosRead).Error; err != nil {
 		return nil, errors.ErrPartyStorageGetDb(ctx, err)
 	}
@@ -172,8 +177,9 @@ func (s *partyStorageImpl) Search(ctx context.Context, criteria *domain.PartySea
 	}
 
 	rs.Items = s.toPartiesDomain(dtos)
-	rs.Total = &dtosRead[0].TotalCount.TotalCount
-	rs.NextPage = nextPage(criteria.PageRequest, rs.Total)
+	last := dtosRead[len(dtosRead)-1].Party
+	rs.Total = pgn.total(dtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(dtosRead), last.LastUpdated, last.Id)
 
 	return rs, nil
 }
@@ -187,9 +193,30 @@ func (s *partyStorageImpl) DeletePartyByExtId(ctx context.Context, extId domain.
 		Delete(&party{}).Error; err != nil {
 		return errors.ErrPartyStorageDelete(ctx, err)
 	}
+	// ids of deleted parties are unknown, so the whole cache is invalidated
+	s.sync.changed(ctx, cacheParties)
 	return nil
 }
 
+// getCached retrieves party from cache by the key, if not found, the query is executed and the found party is cached
+// cached dto is shared, so it must not be modified
+func (s *partyStorageImpl) getCached(ctx context.Context, key string, query func(*gorm.DB) *gorm.DB) (*party, error) {
+	dto, gen, ok := s.cache.get(key)
+	if ok {
+		return dto, nil
+	}
+	dto = &party{}
+	res := query(s.pg.Instance).Limit(1).Find(&dto)
+	if res.Error != nil {
+		return nil, errors.ErrPartyStorageGetDb(ctx, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	s.cache.set(key, dto, gen)
+	return dto, nil
+}
+
 func (s *partyStorageImpl) buildSearchQuery(criteria *domain.PartySearchCriteria) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		query := db.Table("parties").Select("parties.*, count(*) over() total_count")
@@ -4,6 +4,7 @@ import (
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/kit/storages/pg"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"slices"
 )
 
 func (s *partyStorageImpl) toPartyDto(p *domain.Party) *party {
@@ -41,7 +42,7 @@ func (s *partyStorageImpl) toPartyDomain(dto *party) *domain.Party {
 			LastSent:    dto.LastSent,
 		},
 		Id:     dto.Id,
-		Roles:  dto.Roles,
+		Roles:  slices.Clone(dto.Roles),
 		Status: dto.Status,
 	}
 	det, _ := pg.FromJsonb[partyDetails](dto.Details)
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/storages/pg"
+	"github.com/mikhailbolshakov/ocpi/domain"
+)
+
+func (s *paymentStorageImpl) toTerminalDto(t *domain.Terminal) *terminal {
+	if t == nil {
+		return nil
+	}
+	dto := &terminal{
+		Id:          t.Id,
+		PartyId:     t.ExtId.PartyId,
+		CountryCode: t.ExtId.CountryCode,
+		PlatformId:  t.PlatformId,
+		RefId:       pg.StringToNull(t.RefId),
+		LastUpdated: t.LastUpdated,
+		LastSent:    t.LastSent,
+	}
+	dto.Details, _ = pg.ToJsonb(&t.Details)
+	return dto
+}
+
+func (s *paymentStorageImpl) toTerminalDomain(dto *terminal) *domain.Terminal {
+	if dto == nil {
+		return nil
+	}
+	t := &domain.Terminal{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     dto.PartyId,
+				CountryCode: dto.CountryCode,
+			},
+			PlatformId:  dto.PlatformId,
+			RefId:       pg.NullToString(dto.RefId),
+			LastUpdated: dto.LastUpdated,
+			LastSent:    dto.LastSent,
+		},
+		Id: dto.Id,
+	}
+	det, _ := pg.FromJsonb[domain.TerminalDetails](dto.Details)
+	if det != nil {
+		t.Details = *det
+	}
+	return t
+}
+
+func (s *paymentStorageImpl) toTerminalsDomain(dtos []*terminal) []*domain.Terminal {
+	return kit.Select(dtos, s.toTerminalDomain)
+}
+
+func (s *paymentStorageImpl) toFinancialAdviceDto(fa *domain.FinancialAdvice) *financialAdvice {
+	if fa == nil {
+		return nil
+	}
+	dto := &financialAdvice{
+		Id:          fa.Id,
+		PartyId:     fa.ExtId.PartyId,
+		CountryCode: fa.ExtId.CountryCode,
+		PlatformId:  fa.PlatformId,
+		RefId:       pg.StringToNull(fa.RefId),
+		LastUpdated: fa.LastUpdated,
+		LastSent:    fa.LastSent,
+	}
+	dto.Details, _ = pg.ToJsonb(&fa.Details)
+	return dto
+}
+
+func (s *paymentStorageImpl) toFinancialAdviceDomain(dto *financialAdvice) *domain.FinancialAdvice {
+	if dto == nil {
+		return nil
+	}
+	fa := &domain.FinancialAdvice{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     dto.PartyId,
+				CountryCode: dto.CountryCode,
+			},
+			PlatformId:  dto.PlatformId,
+			RefId:       pg.NullToString(dto.RefId),
+			LastUpdated: dto.LastUpdated,
+			LastSent:    dto.LastSent,
+		},
+		Id: dto.Id,
+	}
+	det, _ := pg.FromJsonb[domain.FinancialAdviceDetails](dto.Details)
+	if det != nil {
+		fa.Details = *det
+	}
+	return fa
+}
+
+func (s *paymentStorageImpl) toFinancialAdvicesDomain(dtos []*financialAdvice) []*domain.FinancialAdvice {
+	return kit.Select(dtos, s.toFinancialAdviceDomain)
+}
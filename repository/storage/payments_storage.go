@@ -0,0 +1,257 @@
+package storage
+
+import (
+	"context"
+	"github.com/jackc/pgtype"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/storages/pg"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type terminal struct {
+	pg.GormDto
+	Id          string        `gorm:"column:id;primaryKey"`
+	Details     *pgtype.JSONB `gorm:"column:details"`
+	PartyId     string        `gorm:"column:party_id"`
+	CountryCode string        `gorm:"column:country_code"`
+	PlatformId  string        `gorm:"column:platform_id"`
+	RefId       *string       `gorm:"column:ref_id"`
+	LastUpdated time.Time     `gorm:"column:last_updated"`
+	LastSent    *time.Time    `gorm:"column:last_sent"`
+}
+
+type terminalRead struct {
+	Terminal   terminal   `gorm:"embedded"`
+	TotalCount totalCount `gorm:"embedded"`
+}
+
+type financialAdvice struct {
+	pg.GormDto
+	Id          string        `gorm:"column:id;primaryKey"`
+	Details     *pgtype.JSONB `gorm:"column:details"`
+	PartyId     string        `gorm:"column:party_id"`
+	CountryCode string        `gorm:"column:country_code"`
+	PlatformId  string        `gorm:"column:platform_id"`
+	RefId       *string       `gorm:"column:ref_id"`
+	LastUpdated time.Time     `gorm:"column:last_updated"`
+	LastSent    *time.Time    `gorm:"column:last_sent"`
+}
+
+type financialAdviceRead struct {
+	FinancialAdvice financialAdvice `gorm:"embedded"`
+	TotalCount      totalCount      `gorm:"embedded"`
+}
+
+type paymentStorageImpl struct {
+	pg *pg.Storage
+}
+
+func (s *paymentStorageImpl) l() kit.CLogger {
+	return ocpi.L().Cmp("pay-storage")
+}
+
+func newPaymentStorage(pg *pg.Storage) *paymentStorageImpl {
+	return &paymentStorageImpl{
+		pg: pg,
+	}
+}
+
+func (s *paymentStorageImpl) GetTerminal(ctx context.Context, id string) (*domain.Terminal, error) {
+	s.l().C(ctx).Mth("get-terminal").F(kit.KV{"terminalId": id}).Dbg()
+	if id == "" {
+		return nil, nil
+	}
+	dto := &terminal{}
+	res := s.pg.Instance.Where("id = ?", id).Limit(1).Find(&dto)
+	if res.Error != nil {
+		return nil, errors.ErrPaymentStorageGet(ctx, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return s.toTerminalDomain(dto), nil
+}
+
+func (s *paymentStorageImpl) MergeTerminal(ctx context.Context, t *domain.Terminal) error {
+	s.l().C(ctx).Mth("merge-terminal").F(kit.KV{"terminalId": t.Id}).Dbg()
+	if err := s.pg.Instance.Scopes(merge()).Create(s.toTerminalDto(t)).Error; err != nil {
+		return errors.ErrPaymentStorageMerge(ctx, err)
+	}
+	return nil
+}
+
+func (s *paymentStorageImpl) UpdateTerminal(ctx context.Context, t *domain.Terminal) error {
+	s.l().C(ctx).Mth("update-terminal").F(kit.KV{"terminalId": t.Id}).Dbg()
+	if err := s.pg.Instance.Scopes(update()).Save(s.toTerminalDto(t)).Error; err != nil {
+		return errors.ErrPaymentStorageUpdate(ctx, err)
+	}
+	return nil
+}
+
+func (s *paymentStorageImpl) SearchTerminals(ctx context.Context, cr *domain.TerminalSearchCriteria) (*domain.TerminalSearchResponse, error) {
+	s.l().Mth("search-terminals").C(ctx).Dbg()
+
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &domain.TerminalSearchResponse{
+		PageResponse: domain.PageResponse{
+			Limit: pagingLimit(cr.PageRequest.Limit),
+			Total: pgn.total(0),
+		},
+	}
+
+	// make query
+	var dtosRead []*terminalRead
+
+	if err := s.pg.Instance.
+		Scopes(s.buildTerminalSearchQuery(cr), pgn.scope()).
+		Find(&dtosRead).Error; err != nil {
+		return nil, errors.ErrPaymentStorageGet(ctx, err)
+	}
+
+	if len(dtosRead) == 0 {
+		return rs, nil
+	}
+
+	dtos := make([]*terminal, 0, len(dtosRead))
+	for _, p := range dtosRead {
+		dtos = append(dtos, &p.Terminal)
+	}
+
+	rs.Items = s.toTerminalsDomain(dtos)
+	last := dtosRead[len(dtosRead)-1].Terminal
+	rs.Total = pgn.total(dtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(dtosRead), last.LastUpdated, last.Id)
+
+	return rs, nil
+}
+
+func (s *paymentStorageImpl) GetFinancialAdvice(ctx context.Context, id string) (*domain.FinancialAdvice, error) {
+	s.l().C(ctx).Mth("get-fin-advice").F(kit.KV{"faId": id}).Dbg()
+	if id == "" {
+		return nil, nil
+	}
+	dto := &financialAdvice{}
+	res := s.pg.Instance.Where("id = ?", id).Limit(1).Find(&dto)
+	if res.Error != nil {
+		return nil, errors.ErrPaymentStorageGet(ctx, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return s.toFinancialAdviceDomain(dto), nil
+}
+
+func (s *paymentStorageImpl) MergeFinancialAdvice(ctx context.Context, fa *domain.FinancialAdvice) error {
+	s.l().C(ctx).Mth("merge-fin-advice").F(kit.KV{"faId": fa.Id}).Dbg()
+	if err := s.pg.Instance.Scopes(merge()).Create(s.toFinancialAdviceDto(fa)).Error; err != nil {
+		return errors.ErrPaymentStorageMerge(ctx, err)
+	}
+	return nil
+}
+
+func (s *paymentStorageImpl) SearchFinancialAdvices(ctx context.Context, cr *domain.FinancialAdviceSearchCriteria) (*domain.FinancialAdviceSearchResponse, error) {
+	s.l().Mth("search-fin-advices").C(ctx).Dbg()
+
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &domain.FinancialAdviceSearchResponse{
+		PageResponse: domain.PageResponse{
+			Limit: pagingLimit(cr.PageRequest.Limit),
+			Total: pgn.total(0),
+		},
+	}
+
+	// make query
+	var dtosRead []*financialAdviceRead
+
+	if err := s.pg.Instance.
+		Scopes(s.buildFinancialAdviceSearchQuery(cr), pgn.scope()).
+		Find(&dtosRead).Error; err != nil {
+		return nil, errors.ErrPaymentStorageGet(ctx, err)
+	}
+
+	if len(dtosRead) == 0 {
+		return rs, nil
+	}
+
+	dtos := make([]*financialAdvice, 0, len(dtosRead))
+	for _, p := range dtosRead {
+		dtos = append(dtos, &p.FinancialAdvice)
+	}
+
+	rs.Items = s.toFinancialAdvicesDomain(dtos)
+	last := dtosRead[len(dtosRead)-1].FinancialAdvice
+	rs.Total = pgn.total(dtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(dtosRead), last.LastUpdated, last.Id)
+
+	return rs, nil
+}
+
+func (s *paymentStorageImpl) buildTerminalSearchQuery(criteria *domain.TerminalSearchCriteria) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query := db.Table("terminals").Select("terminals.*, count(*) over() total_count")
+		// populate conditions
+		if criteria.ExtId != nil {
+			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
+		}
+		if criteria.DateTo != nil {
+			query = query.Where("last_updated <= ?", *criteria.DateTo)
+		}
+		if criteria.DateFrom != nil {
+			query = query.Where("last_updated >= ?", *criteria.DateFrom)
+		}
+		if len(criteria.Ids) > 0 {
+			query = query.Where("id in (?)", criteria.Ids)
+		}
+		if len(criteria.IncPlatforms) > 0 {
+			query = query.Where("platform_id in (?)", criteria.IncPlatforms)
+		}
+		if len(criteria.ExcPlatforms) > 0 {
+			query = query.Where("platform_id not in (?)", criteria.ExcPlatforms)
+		}
+		if criteria.RefId != "" {
+			query = query.Where("ref_id = ?", criteria.RefId)
+		}
+		return query
+	}
+}
+
+func (s *paymentStorageImpl) buildFinancialAdviceSearchQuery(criteria *domain.FinancialAdviceSearchCriteria) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query := db.Table("financial_advices").Select("financial_advices.*, count(*) over() total_count")
+		// populate conditions
+		if criteria.ExtId != nil {
+			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
+		}
+		if criteria.DateTo != nil {
+			query = query.Where("last_updated <= ?", *criteria.DateTo)
+		}
+		if criteria.DateFrom != nil {
+			query = query.Where("last_updated >= ?", *criteria.DateFrom)
+		}
+		if len(criteria.Ids) > 0 {
+			query = query.Where("id in (?)", criteria.Ids)
+		}
+		if len(criteria.IncPlatforms) > 0 {
+			query = query.Where("platform_id in (?)", criteria.IncPlatforms)
+		}
+		if len(criteria.ExcPlatforms) > 0 {
+			query = query.Where("platform_id not in (?)", criteria.ExcPlatforms)
+		}
+		if criteria.AuthRef != "" {
+			query = query.Where("auth_ref = ?", criteria.AuthRef)
+		}
+		return query
+	}
+}
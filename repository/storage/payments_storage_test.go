@@ -0,0 +1,152 @@
+//go:build integration
+
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type paymentsTestSuite struct {
+	kit.Suite
+	storage domain.PaymentStorage
+	adapter Adapter
+}
+
+func (s *paymentsTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+
+	// load config
+	cfg, err := ocpi.LoadConfig()
+	if err != nil {
+		s.Fatal(err)
+	}
+
+	s.adapter = NewAdapter()
+	s.NoError(s.adapter.Init(s.Ctx, cfg.Storages))
+
+	s.storage = s.adapter
+}
+
+func (s *paymentsTestSuite) TearDownSuite() {
+	_ = s.adapter.Close(s.Ctx)
+}
+
+func TestPaymentsSuite(t *testing.T) {
+	suite.Run(t, new(paymentsTestSuite))
+}
+
+func (s *paymentsTestSuite) Test_Terminal_CRUD() {
+	// get when no exists
+	act, err := s.storage.GetTerminal(s.Ctx, kit.NewId())
+	s.NoError(err)
+	s.Empty(act)
+
+	// create new
+	t := s.terminal()
+	s.NoError(s.storage.MergeTerminal(s.Ctx, t))
+
+	// get
+	act, err = s.storage.GetTerminal(s.Ctx, t.Id)
+	s.NoError(err)
+	s.Equal(act, t)
+
+	// update
+	t.Details.Reference = "another"
+	s.NoError(s.storage.UpdateTerminal(s.Ctx, t))
+
+	// get
+	act, err = s.storage.GetTerminal(s.Ctx, t.Id)
+	s.NoError(err)
+	s.Equal(act, t)
+}
+
+func (s *paymentsTestSuite) Test_Terminal_Search() {
+	t := s.terminal()
+	s.NoError(s.storage.MergeTerminal(s.Ctx, t))
+
+	rs, err := s.storage.SearchTerminals(s.Ctx, &domain.TerminalSearchCriteria{
+		ExtId: &t.ExtId,
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+	s.Equal(*rs.Total, 1)
+
+	rs, err = s.storage.SearchTerminals(s.Ctx, &domain.TerminalSearchCriteria{
+		IncPlatforms: []string{t.PlatformId},
+		RefId:        t.RefId,
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+}
+
+func (s *paymentsTestSuite) Test_FinancialAdvice_CRUD() {
+	// get when no exists
+	act, err := s.storage.GetFinancialAdvice(s.Ctx, kit.NewId())
+	s.NoError(err)
+	s.Empty(act)
+
+	// create new
+	fa := s.financialAdvice()
+	s.NoError(s.storage.MergeFinancialAdvice(s.Ctx, fa))
+
+	// get
+	act, err = s.storage.GetFinancialAdvice(s.Ctx, fa.Id)
+	s.NoError(err)
+	s.Equal(act, fa)
+
+	// search by auth ref
+	rs, err := s.storage.SearchFinancialAdvices(s.Ctx, &domain.FinancialAdviceSearchCriteria{
+		AuthRef: fa.Details.AuthRef,
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+	s.Equal(rs.Items[0].Id, fa.Id)
+}
+
+func (s *paymentsTestSuite) terminal() *domain.Terminal {
+	return &domain.Terminal{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     kit.NewRandString(),
+				CountryCode: "RS",
+			},
+			PlatformId:  kit.NewRandString(),
+			RefId:       kit.NewRandString(),
+			LastUpdated: kit.Now(),
+		},
+		Id: kit.NewId(),
+		Details: domain.TerminalDetails{
+			CustomerReference: "customer",
+			Address:           "address",
+			City:              "Belgrade",
+			Country:           "SRB",
+			InvoiceCreator:    domain.InvoiceCreatorCpo,
+			LocationIds:       []string{kit.NewId()},
+		},
+	}
+}
+
+func (s *paymentsTestSuite) financialAdvice() *domain.FinancialAdvice {
+	return &domain.FinancialAdvice{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     kit.NewRandString(),
+				CountryCode: "RS",
+			},
+			PlatformId:  kit.NewRandString(),
+			RefId:       kit.NewRandString(),
+			LastUpdated: kit.Now(),
+		},
+		Id: kit.NewId(),
+		Details: domain.FinancialAdviceDetails{
+			AuthRef:           kit.NewId(),
+			TotalCosts:        domain.Price{ExclVat: 100.0},
+			Currency:          "EUR",
+			CaptureStatusCode: domain.CaptureStatusSuccess,
+		},
+	}
+}
@@ -9,57 +9,200 @@ import (
 	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
 )
 
 type platformDetails struct {
-	VersionInfo domain.VersionInfo      `json:"ver,omitempty"`
-	Endpoints   domain.ModuleEndpoints  `json:"eps,omitempty"`
-	Protocol    *domain.ProtocolDetails `json:"protocol,omitempty"`
-	TokenBase64 *bool                   `json:"tokenBase64,omitempty"`
+	VersionInfo domain.VersionInfo          `json:"ver,omitempty"`
+	Endpoints   domain.ModuleEndpoints      `json:"eps,omitempty"`
+	Protocol    *domain.ProtocolDetails     `json:"protocol,omitempty"`
+	TokenBase64 *bool                       `json:"tokenBase64,omitempty"`
+	Rotation    *domain.TokenRotationPolicy `json:"rotation,omitempty"`
+	IssuedAt    *time.Time                  `json:"issuedAt,omitempty"`
+	Onboarding  *domain.OnboardingToken     `json:"onboarding,omitempty"`
+	Health      *domain.PlatformHealth      `json:"health,omitempty"`
+	Client      *domain.ClientSettings      `json:"client,omitempty"`
+	Inbound     *domain.InboundLimits       `json:"inbound,omitempty"`
+	DataCleanup *domain.PlatformDataCleanup `json:"dataCleanup,omitempty"`
 }
 
 type platform struct {
 	pg.GormDto
-	Id      string        `gorm:"column:id"`
-	TokenA  string        `gorm:"column:token_a"`
-	TokenB  *string       `gorm:"column:token_b"`
-	TokenC  *string       `gorm:"column:token_c"`
-	Name    string        `gorm:"column:name"`
-	Role    string        `gorm:"column:role"`
-	Status  string        `gorm:"column:status"`
-	Remote  bool          `gorm:"column:remote"`
-	Details *pgtype.JSONB `gorm:"column:details"`
+	Id             string        `gorm:"column:id"`
+	TokenA         string        `gorm:"column:token_a"`
+	TokenAHash     *string       `gorm:"column:token_a_hash"`
+	TokenB         *string       `gorm:"column:token_b"`
+	TokenBHash     *string       `gorm:"column:token_b_hash"`
+	TokenC         *string       `gorm:"column:token_c"`
+	TokenCHash     *string       `gorm:"column:token_c_hash"`
+	TokenBPrev     *string       `gorm:"column:token_b_prev"`
+	TokenBPrevHash *string       `gorm:"column:token_b_prev_hash"`
+	TokenBPrevExp  *time.Time    `gorm:"column:token_b_prev_exp"`
+	Name           string        `gorm:"column:name"`
+	Role           string        `gorm:"column:role"`
+	Status         string        `gorm:"column:status"`
+	Remote         bool          `gorm:"column:remote"`
+	Details        *pgtype.JSONB `gorm:"column:details"`
+}
+
+type platformTokenRotation struct {
+	pg.GormDto
+	Id         string  `gorm:"column:id"`
+	PlatformId string  `gorm:"column:platform_id"`
+	Status     string  `gorm:"column:status"`
+	Error      *string `gorm:"column:error"`
 }
 
 type platformStorageImpl struct {
-	pg *pg.Storage
+	pg     *pg.Storage
+	cipher *tokenCipher
+	cache  *cache[*platform]
+	sync   *cacheSync
 }
 
 func (s *platformStorageImpl) l() kit.CLogger {
 	return ocpi.L().Cmp("platform-storage")
 }
 
-func newPlatformStorage(pg *pg.Storage) *platformStorageImpl {
-	return &platformStorageImpl{
-		pg: pg,
+func newPlatformStorage(pg *pg.Storage, cipher *tokenCipher, sync *cacheSync, cfg *ocpi.CfgCache, metrics *cacheMetrics) *platformStorageImpl {
+	s := &platformStorageImpl{
+		pg:     pg,
+		cipher: cipher,
+		cache:  newCache(cachePlatforms, cfg, metrics, func(dto *platform) string { return dto.Id }),
+		sync:   sync,
+	}
+	sync.register(cachePlatforms, s.cache.invalidate)
+	return s
+}
+
+// init encrypts plain tokens and re-encrypts tokens which aren't encrypted with the current key
+// a conversion failure doesn't prevent startup, legacy plain tokens are still accepted and the conversion is retried on the next start
+func (s *platformStorageImpl) init(ctx context.Context) error {
+	l := s.l().Mth("init").C(ctx).Dbg()
+	n, err := s.EncryptTokens(ctx)
+	if err != nil {
+		l.E(err).St().Err("tokens encryption")
+	}
+	if n > 0 {
+		l.F(kit.KV{"platforms": n}).Inf("tokens encrypted with the current key")
+	}
+	return nil
+}
+
+// byToken filters platforms by hashes of the token in the given hash columns
+// legacy rows (no token A hash) which haven't been encrypted yet are matched by the plain token
+func (s *platformStorageImpl) byToken(db *gorm.DB, token domain.PlatformToken, hashFields ...string) *gorm.DB {
+	hashes := s.cipher.hashes(string(token))
+	var hashConds, plainConds []string
+	var hashArgs, plainArgs []any
+	for _, f := range hashFields {
+		hashConds = append(hashConds, fmt.Sprintf("%s in (?)", f))
+		hashArgs = append(hashArgs, hashes)
+		plainConds = append(plainConds, fmt.Sprintf("%s = ?", strings.TrimSuffix(f, "_hash")))
+		plainArgs = append(plainArgs, string(token))
+	}
+	cond := fmt.Sprintf("(%s or (token_a_hash is null and (%s)))", strings.Join(hashConds, " or "), strings.Join(plainConds, " or "))
+	return db.Where(cond, append(hashArgs, plainArgs...)...)
+}
+
+// outdatedTokens filters platforms having plain tokens or tokens encrypted not with the current key
+func (s *platformStorageImpl) outdatedTokens(db *gorm.DB) *gorm.DB {
+	current := s.cipher.current + cryptoSeparator + "%"
+	return db.Where("token_a_hash is null or token_a_hash not like ? or token_b_hash not like ? or token_c_hash not like ? or token_b_prev_hash not like ?",
+		current, current, current, current)
+}
+
+func (s *platformStorageImpl) EncryptTokens(ctx context.Context) (int, error) {
+	l := s.l().Mth("encrypt-tokens").C(ctx).Dbg()
+	var ids []string
+	if err := s.outdatedTokens(s.pg.Instance.Model(&platform{})).Pluck("id", &ids).Error; err != nil {
+		return 0, errors.ErrPlatformStorageGetDb(ctx, err)
 	}
+	updated := 0
+	for _, id := range ids {
+		// the row is locked, so that concurrent updates of the platform aren't lost
+		var changed bool
+		err := s.pg.Instance.Transaction(func(tx *gorm.DB) error {
+			dto := &platform{}
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(dto).Error; err != nil {
+				return errors.ErrPlatformStorageGetDb(ctx, err)
+			}
+			if !s.tokensOutdated(dto) {
+				return nil
+			}
+			// only rows stored before encryption was introduced (no token A hash) may contain plain tokens
+			p, err := s.toPlatformDomainWithPlain(ctx, dto, dto.TokenAHash == nil)
+			if err != nil {
+				return err
+			}
+			upd, err := s.toPlatformDto(ctx, p)
+			if err != nil {
+				return err
+			}
+			if err := tx.Scopes(update()).Save(upd).Error; err != nil {
+				return errors.ErrPlatformStorageUpdate(ctx, err)
+			}
+			changed = true
+			return nil
+		})
+		if err != nil {
+			return updated, err
+		}
+		if changed {
+			s.sync.changed(ctx, cachePlatforms, id)
+			l.F(kit.KV{"platformId": id}).Inf("tokens encrypted")
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (s *platformStorageImpl) ConsumeOnboardingToken(ctx context.Context, platformId string, consumedAt time.Time) (bool, error) {
+	s.l().Mth("consume-onboarding-token").C(ctx).F(kit.KV{"platformId": platformId}).Dbg()
+	res := s.pg.Instance.Model(&platform{}).
+		Where("id = ? and details->'onboarding' is not null and details->'onboarding'->>'consumedAt' is null", platformId).
+		Updates(map[string]any{
+			"details":    gorm.Expr("jsonb_set(details, '{onboarding,consumedAt}', to_jsonb(?::text))", consumedAt.UTC().Format(time.RFC3339Nano)),
+			"updated_at": kit.Now(),
+		})
+	if res.Error != nil {
+		return false, errors.ErrPlatformStorageUpdate(ctx, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+	s.sync.changed(ctx, cachePlatforms, platformId)
+	return true, nil
 }
 
 func (s *platformStorageImpl) CreatePlatform(ctx context.Context, p *domain.Platform) error {
 	s.l().Mth("create").C(ctx).F(kit.KV{"platformId": p.Id}).Dbg()
-	err := s.pg.Instance.Create(s.toPlatformDto(p)).Error
+	dto, err := s.toPlatformDto(ctx, p)
+	if err != nil {
+		return err
+	}
+	err = s.pg.Instance.Create(dto).Error
 	if err != nil {
 		return errors.ErrPlatformStorageCreate(ctx, err)
 	}
+	s.sync.changed(ctx, cachePlatforms, p.Id)
 	return nil
 }
 
 func (s *platformStorageImpl) UpdatePlatform(ctx context.Context, p *domain.Platform) error {
 	s.l().Mth("update").C(ctx).F(kit.KV{"platformId": p.Id}).Dbg()
-	err := s.pg.Instance.Scopes(update()).Save(s.toPlatformDto(p)).Error
+	dto, err := s.toPlatformDto(ctx, p)
+	if err != nil {
+		return err
+	}
+	err = s.pg.Instance.Scopes(update()).Save(dto).Error
 	if err != nil {
 		return errors.ErrPlatformStorageUpdate(ctx, err)
 	}
+	s.sync.changed(ctx, cachePlatforms, p.Id)
 	return nil
 }
 
@@ -69,6 +212,7 @@ func (s *platformStorageImpl) DeletePlatform(ctx context.Context, p *domain.Plat
 	if err != nil {
 		return errors.ErrPlatformStorageDelete(ctx, err)
 	}
+	s.sync.changed(ctx, cachePlatforms, p.Id)
 	return nil
 }
 
@@ -77,30 +221,28 @@ func (s *platformStorageImpl) GetPlatform(ctx context.Context, id string) (*doma
 	if id == "" {
 		return nil, nil
 	}
-	dto := &platform{Id: id}
-	res := s.pg.Instance.Limit(1).Find(&dto)
-	if res.Error != nil {
-		return nil, errors.ErrPlatformStorageGetDb(ctx, res.Error)
-	}
-	if res.RowsAffected == 0 {
-		return nil, nil
+	dto, err := s.getCached(ctx, "id:"+id, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", id)
+	})
+	if err != nil || dto == nil {
+		return nil, err
 	}
-	return s.toPlatformDomain(dto), nil
+	return s.toPlatformDomain(ctx, dto)
 }
 
 func (s *platformStorageImpl) GetPlatformByTokenA(ctx context.Context, tokenA domain.PlatformToken) (*domain.Platform, error) {
 	s.l().Mth("get-by-token-a").C(ctx).Dbg()
-	return s.getPlatformByToken(ctx, "token_a", tokenA)
+	return s.getPlatformByToken(ctx, "token_a_hash", tokenA)
 }
 
 func (s *platformStorageImpl) GetPlatformByTokenB(ctx context.Context, token domain.PlatformToken) (*domain.Platform, error) {
 	s.l().Mth("get-by-token-b").C(ctx).Dbg()
-	return s.getPlatformByToken(ctx, "token_b", token)
+	return s.getPlatformByToken(ctx, "token_b_hash", token)
 }
 
 func (s *platformStorageImpl) GetPlatformByTokenC(ctx context.Context, token domain.PlatformToken) (*domain.Platform, error) {
 	s.l().Mth("get-by-token").C(ctx).Dbg()
-	return s.getPlatformByToken(ctx, "token_c", token)
+	return s.getPlatformByToken(ctx, "token_c_hash", token)
 }
 
 func (s *platformStorageImpl) GetPlatformByToken(ctx context.Context, token domain.PlatformToken) (*domain.Platform, error) {
@@ -108,15 +250,13 @@ func (s *platformStorageImpl) GetPlatformByToken(ctx context.Context, token doma
 	if token == "" {
 		return nil, nil
 	}
-	dto := &platform{}
-	res := s.pg.Instance.Where("? in (token_b, token_c)", token).Limit(1).Find(&dto)
-	if res.Error != nil {
-		return nil, errors.ErrPlatformStorageGetDb(ctx, res.Error)
-	}
-	if res.RowsAffected == 0 {
-		return nil, nil
+	dto, err := s.getCached(ctx, "token_bc_hash:"+s.cipher.hash(string(token)), func(db *gorm.DB) *gorm.DB {
+		return s.byToken(db, token, "token_b_hash", "token_c_hash")
+	})
+	if err != nil || dto == nil {
+		return nil, err
 	}
-	return s.toPlatformDomain(dto), nil
+	return s.toPlatformDomain(ctx, dto)
 }
 
 func (s *platformStorageImpl) SearchPlatforms(ctx context.Context, cr *domain.PlatformSearchCriteria) ([]*domain.Platform, error) {
@@ -144,24 +284,78 @@ func (s *platformStorageImpl) SearchPlatforms(ctx context.Context, cr *domain.Pl
 	if len(cr.ExcIds) > 0 {
 		q = q.Where("id not in (?)", cr.ExcIds)
 	}
+	if cr.Onboarding {
+		q = q.Where("details->'onboarding' is not null")
+	}
 	res := q.Find(&dtos)
 	if res.Error != nil {
 		return nil, errors.ErrPlatformStorageGetDb(ctx, res.Error)
 	}
-	return s.toPlatformsDomain(dtos), nil
+	return s.toPlatformsDomain(ctx, dtos)
+}
+
+func (s *platformStorageImpl) GetPlatformByPrevTokenB(ctx context.Context, token domain.PlatformToken) (*domain.Platform, error) {
+	s.l().Mth("get-by-prev-token-b").C(ctx).Dbg()
+	if token == "" {
+		return nil, nil
+	}
+	dto, err := s.getCached(ctx, "token_b_prev_hash:"+s.cipher.hash(string(token)), func(db *gorm.DB) *gorm.DB {
+		return s.byToken(db, token, "token_b_prev_hash").Where("token_b_prev_exp > ?", kit.Now())
+	})
+	// cached token might have expired
+	if err != nil || dto == nil || dto.TokenBPrevExp == nil || !dto.TokenBPrevExp.After(kit.Now()) {
+		return nil, err
+	}
+	return s.toPlatformDomain(ctx, dto)
+}
+
+func (s *platformStorageImpl) CreateTokenRotation(ctx context.Context, rotation *domain.TokenRotation) error {
+	s.l().Mth("create-token-rotation").C(ctx).F(kit.KV{"platformId": rotation.PlatformId}).Dbg()
+	err := s.pg.Instance.Create(s.toTokenRotationDto(rotation)).Error
+	if err != nil {
+		return errors.ErrPlatformStorageCreate(ctx, err)
+	}
+	return nil
+}
+
+func (s *platformStorageImpl) GetTokenRotations(ctx context.Context, platformId string) ([]*domain.TokenRotation, error) {
+	s.l().Mth("get-token-rotations").C(ctx).F(kit.KV{"platformId": platformId}).Dbg()
+	var dtos []*platformTokenRotation
+	res := s.pg.Instance.Where("platform_id = ?", platformId).Order("created_at desc").Find(&dtos)
+	if res.Error != nil {
+		return nil, errors.ErrPlatformStorageGetDb(ctx, res.Error)
+	}
+	return s.toTokenRotationsDomain(dtos), nil
 }
 
 func (s *platformStorageImpl) getPlatformByToken(ctx context.Context, field string, token domain.PlatformToken) (*domain.Platform, error) {
 	if token == "" {
 		return nil, nil
 	}
-	dto := &platform{}
-	res := s.pg.Instance.Where(fmt.Sprintf("%s = ?", field), token).Limit(1).Find(&dto)
+	dto, err := s.getCached(ctx, field+":"+s.cipher.hash(string(token)), func(db *gorm.DB) *gorm.DB {
+		return s.byToken(db, token, field)
+	})
+	if err != nil || dto == nil {
+		return nil, err
+	}
+	return s.toPlatformDomain(ctx, dto)
+}
+
+// getCached retrieves platform from cache by the key, if not found, the query is executed and the found platform is cached
+// cached dto is shared, so it must not be modified
+func (s *platformStorageImpl) getCached(ctx context.Context, key string, query func(*gorm.DB) *gorm.DB) (*platform, error) {
+	dto, gen, ok := s.cache.get(key)
+	if ok {
+		return dto, nil
+	}
+	dto = &platform{}
+	res := query(s.pg.Instance).Limit(1).Find(&dto)
 	if res.Error != nil {
 		return nil, errors.ErrPlatformStorageGetDb(ctx, res.Error)
 	}
 	if res.RowsAffected == 0 {
 		return nil, nil
 	}
-	return s.toPlatformDomain(dto), nil
+	s.cache.set(key, dto, gen)
+	return dto, nil
 }
@@ -1,47 +1,88 @@
 package storage
 
 import (
+	"context"
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/kit/storages/pg"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
 )
 
-func (s *platformStorageImpl) toPlatformDto(p *domain.Platform) *platform {
+func (s *platformStorageImpl) toPlatformDto(ctx context.Context, p *domain.Platform) (*platform, error) {
 	if p == nil {
-		return nil
+		return nil, nil
 	}
 	dto := &platform{
-		Id:     p.Id,
-		TokenA: string(p.TokenA),
-		TokenB: pg.StringToNull(string(p.TokenB)),
-		TokenC: pg.StringToNull(string(p.TokenC)),
-		Name:   p.Name,
-		Role:   p.Role,
-		Status: p.Status,
-		Remote: p.Remote,
+		Id:             p.Id,
+		TokenAHash:     pg.StringToNull(s.cipher.hash(string(p.TokenA))),
+		TokenBHash:     pg.StringToNull(s.cipher.hash(string(p.TokenB))),
+		TokenCHash:     pg.StringToNull(s.cipher.hash(string(p.TokenC))),
+		TokenBPrevHash: pg.StringToNull(s.cipher.hash(string(p.PrevTokenB))),
+		TokenBPrevExp:  p.PrevTokenBExp,
+		Name:           p.Name,
+		Role:           p.Role,
+		Status:         p.Status,
+		Remote:         p.Remote,
+	}
+	var err error
+	if dto.TokenA, err = s.cipher.encrypt(ctx, string(p.TokenA)); err != nil {
+		return nil, err
+	}
+	if dto.TokenB, err = s.encryptToNull(ctx, p.TokenB); err != nil {
+		return nil, err
+	}
+	if dto.TokenC, err = s.encryptToNull(ctx, p.TokenC); err != nil {
+		return nil, err
+	}
+	if dto.TokenBPrev, err = s.encryptToNull(ctx, p.PrevTokenB); err != nil {
+		return nil, err
 	}
 	dto.Details, _ = pg.ToJsonb(&platformDetails{
 		VersionInfo: p.VersionInfo,
 		Endpoints:   p.Endpoints,
 		Protocol:    p.Protocol,
 		TokenBase64: p.TokenBase64,
+		Rotation:    p.TokenRotation,
+		IssuedAt:    p.TokenIssuedAt,
+		Onboarding:  p.Onboarding,
+		Health:      p.Health,
+		Client:      p.Client,
+		Inbound:     p.Inbound,
+		DataCleanup: p.DataCleanup,
 	})
-	return dto
+	return dto, nil
 }
 
-func (s *platformStorageImpl) toPlatformDomain(dto *platform) *domain.Platform {
+// toPlatformDomain converts platform, plain tokens are accepted only in legacy rows which haven't been encrypted yet (no token A hash)
+func (s *platformStorageImpl) toPlatformDomain(ctx context.Context, dto *platform) (*domain.Platform, error) {
+	return s.toPlatformDomainWithPlain(ctx, dto, dto != nil && dto.TokenAHash == nil)
+}
+
+// toPlatformDomainWithPlain converts platform, if plainAllowed tokens without a key id prefix are taken as plain
+func (s *platformStorageImpl) toPlatformDomainWithPlain(ctx context.Context, dto *platform, plainAllowed bool) (*domain.Platform, error) {
 	if dto == nil {
-		return nil
+		return nil, nil
 	}
 	p := &domain.Platform{
-		Id:     dto.Id,
-		TokenA: domain.PlatformToken(dto.TokenA),
-		TokenB: domain.PlatformToken(pg.NullToString(dto.TokenB)),
-		TokenC: domain.PlatformToken(pg.NullToString(dto.TokenC)),
-		Name:   dto.Name,
-		Role:   dto.Role,
-		Status: dto.Status,
-		Remote: dto.Remote,
+		Id:            dto.Id,
+		PrevTokenBExp: dto.TokenBPrevExp,
+		Name:          dto.Name,
+		Role:          dto.Role,
+		Status:        dto.Status,
+		Remote:        dto.Remote,
+	}
+	var err error
+	if p.TokenA, err = s.decryptToken(ctx, dto.TokenA, plainAllowed); err != nil {
+		return nil, err
+	}
+	if p.TokenB, err = s.decryptToken(ctx, pg.NullToString(dto.TokenB), plainAllowed); err != nil {
+		return nil, err
+	}
+	if p.TokenC, err = s.decryptToken(ctx, pg.NullToString(dto.TokenC), plainAllowed); err != nil {
+		return nil, err
+	}
+	if p.PrevTokenB, err = s.decryptToken(ctx, pg.NullToString(dto.TokenBPrev), plainAllowed); err != nil {
+		return nil, err
 	}
 	det, _ := pg.FromJsonb[platformDetails](dto.Details)
 	if det != nil {
@@ -49,10 +90,95 @@ func (s *platformStorageImpl) toPlatformDomain(dto *platform) *domain.Platform {
 		p.VersionInfo = det.VersionInfo
 		p.Protocol = det.Protocol
 		p.TokenBase64 = det.TokenBase64
+		p.TokenRotation = det.Rotation
+		p.TokenIssuedAt = det.IssuedAt
+		p.Onboarding = det.Onboarding
+		p.Health = det.Health
+		p.Client = det.Client
+		p.Inbound = det.Inbound
+		p.DataCleanup = det.DataCleanup
+	}
+	return p, nil
+}
+
+func (s *platformStorageImpl) toPlatformsDomain(ctx context.Context, dtos []*platform) ([]*domain.Platform, error) {
+	var r []*domain.Platform
+	for _, dto := range dtos {
+		p, err := s.toPlatformDomain(ctx, dto)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, p)
+	}
+	return r, nil
+}
+
+func (s *platformStorageImpl) encryptToNull(ctx context.Context, token domain.PlatformToken) (*string, error) {
+	enc, err := s.cipher.encrypt(ctx, string(token))
+	if err != nil {
+		return nil, err
+	}
+	return pg.StringToNull(enc), nil
+}
+
+// decryptToken decrypts a token, ciphertext is recognized by the key id prefix
+// a value without a known key id prefix is rejected, unless plain tokens are allowed explicitly by tokens encryption
+func (s *platformStorageImpl) decryptToken(ctx context.Context, value string, plainAllowed bool) (domain.PlatformToken, error) {
+	if value == "" {
+		return "", nil
+	}
+	if !s.cipher.encrypted(value) {
+		if plainAllowed {
+			return domain.PlatformToken(value), nil
+		}
+		return "", errors.ErrCryptoTokenNotEncrypted(ctx)
+	}
+	dec, err := s.cipher.decrypt(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	return domain.PlatformToken(dec), nil
+}
+
+// tokensOutdated checks if tokens have to be re-encrypted with the current key
+func (s *platformStorageImpl) tokensOutdated(dto *platform) bool {
+	if dto.TokenAHash == nil {
+		return true
+	}
+	for _, v := range []*string{&dto.TokenA, dto.TokenAHash, dto.TokenB, dto.TokenBHash, dto.TokenC, dto.TokenCHash, dto.TokenBPrev, dto.TokenBPrevHash} {
+		if s.cipher.outdated(pg.NullToString(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *platformStorageImpl) toTokenRotationDto(r *domain.TokenRotation) *platformTokenRotation {
+	if r == nil {
+		return nil
+	}
+	return &platformTokenRotation{
+		GormDto:    pg.GormDto{CreatedAt: r.CreatedAt, UpdatedAt: r.CreatedAt},
+		Id:         r.Id,
+		PlatformId: r.PlatformId,
+		Status:     r.Status,
+		Error:      pg.StringToNull(r.Error),
+	}
+}
+
+func (s *platformStorageImpl) toTokenRotationDomain(dto *platformTokenRotation) *domain.TokenRotation {
+	if dto == nil {
+		return nil
+	}
+	return &domain.TokenRotation{
+		Id:         dto.Id,
+		PlatformId: dto.PlatformId,
+		Status:     dto.Status,
+		Error:      pg.NullToString(dto.Error),
+		CreatedAt:  dto.CreatedAt,
 	}
-	return p
 }
 
-func (s *platformStorageImpl) toPlatformsDomain(dtos []*platform) []*domain.Platform {
-	return kit.Select(dtos, s.toPlatformDomain)
+func (s *platformStorageImpl) toTokenRotationsDomain(dtos []*platformTokenRotation) []*domain.TokenRotation {
+	return kit.Select(dtos, s.toTokenRotationDomain)
 }
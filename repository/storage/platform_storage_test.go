@@ -7,7 +7,10 @@ import (
 	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/stretchr/testify/suite"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type platformStorageTestSuite struct {
@@ -140,3 +143,196 @@ func (s *platformStorageTestSuite) Test_Search() {
 	s.NoError(err)
 	s.Len(rs, 2)
 }
+
+func (s *platformStorageTestSuite) Test_PrevTokenB() {
+	p := s.platform()
+	p.PrevTokenB = domain.PlatformToken(kit.NewRandString())
+	p.PrevTokenBExp = kit.TimePtr(kit.Now().Add(time.Hour))
+	s.NoError(s.storage.CreatePlatform(s.Ctx, p))
+
+	// not expired token found
+	act, err := s.storage.GetPlatformByPrevTokenB(s.Ctx, p.PrevTokenB)
+	s.NoError(err)
+	s.NotEmpty(act)
+	s.Equal(p.Id, act.Id)
+
+	// expired token isn't found
+	p.PrevTokenBExp = kit.TimePtr(kit.Now().Add(-time.Hour))
+	s.NoError(s.storage.UpdatePlatform(s.Ctx, p))
+	act, err = s.storage.GetPlatformByPrevTokenB(s.Ctx, p.PrevTokenB)
+	s.NoError(err)
+	s.Empty(act)
+}
+
+func (s *platformStorageTestSuite) Test_TokensEncrypted() {
+	p := s.platform()
+	s.NoError(s.storage.CreatePlatform(s.Ctx, p))
+
+	// tokens aren't stored in clear
+	dto := &platform{}
+	s.NoError(s.adapter.(*adapterImpl).pg.Instance.Where("id = ?", p.Id).First(&dto).Error)
+	s.NotContains(dto.TokenA, string(p.TokenA))
+	s.NotContains(*dto.TokenB, string(p.TokenB))
+	s.NotContains(*dto.TokenC, string(p.TokenC))
+	s.NotContains(*dto.TokenBHash, string(p.TokenB))
+
+	// tokens decrypted
+	act, err := s.storage.GetPlatformByTokenC(s.Ctx, p.TokenC)
+	s.NoError(err)
+	s.NotEmpty(act)
+	s.Equal(p.TokenA, act.TokenA)
+	s.Equal(p.TokenB, act.TokenB)
+	s.Equal(p.TokenC, act.TokenC)
+}
+
+// legacyPlatform stores platform row as it was stored before encryption
+func (s *platformStorageTestSuite) legacyPlatform() *domain.Platform {
+	p := s.platform()
+	tokenB := string(p.TokenB)
+	s.NoError(s.adapter.(*adapterImpl).pg.Instance.Create(&platform{
+		Id:     p.Id,
+		TokenA: string(p.TokenA),
+		TokenB: &tokenB,
+		Name:   p.Name,
+		Role:   p.Role,
+		Status: p.Status,
+	}).Error)
+	return p
+}
+
+func (s *platformStorageTestSuite) Test_PlainTokens_AcceptedUntilEncrypted() {
+	p := s.legacyPlatform()
+
+	act, err := s.storage.GetPlatform(s.Ctx, p.Id)
+	s.NoError(err)
+	s.Equal(p.TokenB, act.TokenB)
+
+	act, err = s.storage.GetPlatformByTokenB(s.Ctx, p.TokenB)
+	s.NoError(err)
+	s.NotEmpty(act)
+	s.Equal(p.Id, act.Id)
+
+	act, err = s.adapter.(*adapterImpl).GetPlatformByToken(s.Ctx, p.TokenB)
+	s.NoError(err)
+	s.NotEmpty(act)
+	s.Equal(p.Id, act.Id)
+}
+
+func (s *platformStorageTestSuite) Test_PlainTokens_EncryptedOnStartup() {
+	p := s.legacyPlatform()
+	a := s.adapter.(*adapterImpl)
+
+	s.NoError(a.platformStorageImpl.init(s.Ctx))
+
+	act, err := s.storage.GetPlatformByTokenB(s.Ctx, p.TokenB)
+	s.NoError(err)
+	s.NotEmpty(act)
+	s.Equal(p.TokenA, act.TokenA)
+	s.Equal(p.TokenB, act.TokenB)
+
+	// stored encrypted, nothing to convert anymore
+	dto := &platform{}
+	s.NoError(a.pg.Instance.Where("id = ?", p.Id).First(&dto).Error)
+	s.NotNil(dto.TokenAHash)
+	s.NotEqual(string(p.TokenB), *dto.TokenB)
+	s.False(a.platformStorageImpl.tokensOutdated(dto))
+}
+
+func (s *platformStorageTestSuite) Test_ConsumeOnboardingToken() {
+	p := s.platform()
+	p.Onboarding = &domain.OnboardingToken{SingleUse: true, CreatedAt: kit.Now()}
+	s.NoError(s.storage.CreatePlatform(s.Ctx, p))
+
+	// concurrent handshakes, only one consumes the token
+	var consumed int32
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := s.storage.ConsumeOnboardingToken(s.Ctx, p.Id, kit.Now())
+			s.NoError(err)
+			if ok {
+				atomic.AddInt32(&consumed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	s.Equal(int32(1), consumed)
+
+	act, err := s.storage.GetPlatform(s.Ctx, p.Id)
+	s.NoError(err)
+	s.NotEmpty(act.Onboarding.ConsumedAt)
+	s.True(act.Onboarding.SingleUse)
+
+	// no onboarding record
+	p = s.platform()
+	s.NoError(s.storage.CreatePlatform(s.Ctx, p))
+	ok, err := s.storage.ConsumeOnboardingToken(s.Ctx, p.Id, kit.Now())
+	s.NoError(err)
+	s.False(ok)
+}
+
+func (s *platformStorageTestSuite) Test_TokenRotations() {
+	platformId := kit.NewRandString()
+	s.NoError(s.storage.CreateTokenRotation(s.Ctx, &domain.TokenRotation{
+		Id:         kit.NewId(),
+		PlatformId: platformId,
+		Status:     domain.TokenRotationSuccess,
+		CreatedAt:  kit.Now().Add(-time.Hour),
+	}))
+	s.NoError(s.storage.CreateTokenRotation(s.Ctx, &domain.TokenRotation{
+		Id:         kit.NewId(),
+		PlatformId: platformId,
+		Status:     domain.TokenRotationFailed,
+		Error:      "error",
+		CreatedAt:  kit.Now(),
+	}))
+
+	rs, err := s.storage.GetTokenRotations(s.Ctx, platformId)
+	s.NoError(err)
+	s.Len(rs, 2)
+	s.Equal(domain.TokenRotationFailed, rs[0].Status)
+	s.Equal("error", rs[0].Error)
+}
+
+func (s *platformStorageTestSuite) Test_CacheInvalidatedByOtherInstance() {
+	cfg, err := ocpi.LoadConfig()
+	s.NoError(err)
+	if cfg.Storages.Cache == nil || !cfg.Storages.Cache.Enabled {
+		s.T().Skip("cache disabled")
+	}
+
+	// another instance of the service
+	other := NewAdapter()
+	s.NoError(other.Init(s.Ctx, cfg.Storages))
+	defer func() { _ = other.Close(s.Ctx) }()
+
+	p := s.platform()
+	s.NoError(s.storage.CreatePlatform(s.Ctx, p))
+
+	// cache platform in the other instance
+	act, err := other.GetPlatformByTokenC(s.Ctx, p.TokenC)
+	s.NoError(err)
+	s.Equal(domain.ConnectionStatusSuspended, act.Status)
+
+	// local invalidation
+	p.Status = domain.ConnectionStatusConnected
+	s.NoError(s.storage.UpdatePlatform(s.Ctx, p))
+	act, err = s.storage.GetPlatformByTokenC(s.Ctx, p.TokenC)
+	s.NoError(err)
+	s.Equal(domain.ConnectionStatusConnected, act.Status)
+
+	// the other instance is notified
+	s.Eventually(func() bool {
+		act, err := other.GetPlatformByTokenC(s.Ctx, p.TokenC)
+		return err == nil && act.Status == domain.ConnectionStatusConnected
+	}, time.Second*5, time.Millisecond*100)
+
+	// deleted platform isn't found anymore
+	s.NoError(s.storage.DeletePlatform(s.Ctx, p))
+	s.Eventually(func() bool {
+		act, err := other.GetPlatform(s.Ctx, p.Id)
+		return err == nil && act == nil
+	}, time.Second*5, time.Millisecond*100)
+}
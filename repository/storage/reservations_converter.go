@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/storages/pg"
+	"github.com/mikhailbolshakov/ocpi/domain"
+)
+
+func (s *reservationStorageImpl) toReservationDto(r *domain.ChargeReservation) *reservation {
+	if r == nil {
+		return nil
+	}
+	dto := &reservation{
+		Id:            r.Id,
+		ReservationId: r.ReservationId,
+		Status:        r.Status,
+		ExpireDate:    r.Details.ExpireDate,
+		PartyId:       r.ExtId.PartyId,
+		CountryCode:   r.ExtId.CountryCode,
+		PlatformId:    r.PlatformId,
+		RefId:         pg.StringToNull(r.RefId),
+		LastUpdated:   r.LastUpdated,
+		LastSent:      r.LastSent,
+	}
+	dto.Details, _ = pg.ToJsonb(&r.Details)
+	return dto
+}
+
+func (s *reservationStorageImpl) toReservationDomain(dto *reservation) *domain.ChargeReservation {
+	if dto == nil {
+		return nil
+	}
+	r := &domain.ChargeReservation{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     dto.PartyId,
+				CountryCode: dto.CountryCode,
+			},
+			PlatformId:  dto.PlatformId,
+			RefId:       pg.NullToString(dto.RefId),
+			LastUpdated: dto.LastUpdated,
+			LastSent:    dto.LastSent,
+		},
+		Id:            dto.Id,
+		ReservationId: dto.ReservationId,
+		Status:        dto.Status,
+	}
+	det, _ := pg.FromJsonb[domain.ReservationDetails](dto.Details)
+	if det != nil {
+		r.Details = *det
+	}
+	return r
+}
+
+func (s *reservationStorageImpl) toReservationsDomain(dtos []*reservation) []*domain.ChargeReservation {
+	return kit.Select(dtos, s.toReservationDomain)
+}
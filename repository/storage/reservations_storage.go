@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"context"
+	"github.com/jackc/pgtype"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/storages/pg"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type reservation struct {
+	pg.GormDto
+	Id            string        `gorm:"column:id;primaryKey"`
+	ReservationId string        `gorm:"column:reservation_id"`
+	Status        string        `gorm:"column:status"`
+	ExpireDate    time.Time     `gorm:"column:expire_date"`
+	Details       *pgtype.JSONB `gorm:"column:details"`
+	PartyId       string        `gorm:"column:party_id"`
+	CountryCode   string        `gorm:"column:country_code"`
+	PlatformId    string        `gorm:"column:platform_id"`
+	RefId         *string       `gorm:"column:ref_id"`
+	LastUpdated   time.Time     `gorm:"column:last_updated"`
+	LastSent      *time.Time    `gorm:"column:last_sent"`
+}
+
+type reservationRead struct {
+	Reservation reservation `gorm:"embedded"`
+	TotalCount  totalCount  `gorm:"embedded"`
+}
+
+type reservationStorageImpl struct {
+	pg *pg.Storage
+}
+
+func (s *reservationStorageImpl) l() kit.CLogger {
+	return ocpi.L().Cmp("res-storage")
+}
+
+func newReservationStorage(pg *pg.Storage) *reservationStorageImpl {
+	return &reservationStorageImpl{
+		pg: pg,
+	}
+}
+
+func (s *reservationStorageImpl) GetReservation(ctx context.Context, id string) (*domain.ChargeReservation, error) {
+	s.l().C(ctx).Mth("get-res").F(kit.KV{"resId": id}).Dbg()
+	if id == "" {
+		return nil, nil
+	}
+	dto := &reservation{}
+	res := s.pg.Instance.Where("id = ?", id).Limit(1).Find(&dto)
+	if res.Error != nil {
+		return nil, errors.ErrReservationStorageGet(ctx, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return s.toReservationDomain(dto), nil
+}
+
+func (s *reservationStorageImpl) GetReservationByExtId(ctx context.Context, platformId string, extId domain.PartyExtId, reservationId string) (*domain.ChargeReservation, error) {
+	s.l().C(ctx).Mth("get-res-ext").F(kit.KV{"platformId": platformId, "resId": reservationId}).Dbg()
+	if reservationId == "" {
+		return nil, nil
+	}
+	dto := &reservation{}
+	res := s.pg.Instance.
+		Where("platform_id = ? and country_code = ? and party_id = ? and reservation_id = ?", platformId, extId.CountryCode, extId.PartyId, reservationId).
+		Limit(1).
+		Find(&dto)
+	if res.Error != nil {
+		return nil, errors.ErrReservationStorageGet(ctx, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return s.toReservationDomain(dto), nil
+}
+
+func (s *reservationStorageImpl) MergeReservation(ctx context.Context, r *domain.ChargeReservation) error {
+	s.l().C(ctx).Mth("merge-res").F(kit.KV{"resId": r.Id}).Dbg()
+	if err := s.pg.Instance.Scopes(merge()).Create(s.toReservationDto(r)).Error; err != nil {
+		return errors.ErrReservationStorageMerge(ctx, err)
+	}
+	return nil
+}
+
+func (s *reservationStorageImpl) UpdateReservation(ctx context.Context, r *domain.ChargeReservation) error {
+	s.l().C(ctx).Mth("update-res").F(kit.KV{"resId": r.Id}).Dbg()
+	if err := s.pg.Instance.Scopes(update()).Save(s.toReservationDto(r)).Error; err != nil {
+		return errors.ErrReservationStorageUpdate(ctx, err)
+	}
+	return nil
+}
+
+func (s *reservationStorageImpl) SearchReservations(ctx context.Context, cr *domain.ReservationSearchCriteria) (*domain.ReservationSearchResponse, error) {
+	s.l().Mth("search-res").C(ctx).Dbg()
+
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &domain.ReservationSearchResponse{
+		PageResponse: domain.PageResponse{
+			Limit: pagingLimit(cr.PageRequest.Limit),
+			Total: pgn.total(0),
+		},
+	}
+
+	// make query
+	var dtosRead []*reservationRead
+
+	if err := s.pg.Instance.
+		Scopes(s.buildSearchQuery(cr), pgn.scope()).
+		Find(&dtosRead).Error; err != nil {
+		return nil, errors.ErrReservationStorageGet(ctx, err)
+	}
+
+	if len(dtosRead) == 0 {
+		return rs, nil
+	}
+
+	dtos := make([]*reservation, 0, len(dtosRead))
+	for _, p := range dtosRead {
+		dtos = append(dtos, &p.Reservation)
+	}
+
+	rs.Items = s.toReservationsDomain(dtos)
+	last := dtosRead[len(dtosRead)-1].Reservation
+	rs.Total = pgn.total(dtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(dtosRead), last.LastUpdated, last.Id)
+
+	return rs, nil
+}
+
+func (s *reservationStorageImpl) buildSearchQuery(criteria *domain.ReservationSearchCriteria) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query := db.Table("reservations").Select("reservations.*, count(*) over() total_count")
+		// populate conditions
+		if criteria.ExtId != nil {
+			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
+		}
+		if criteria.DateTo != nil {
+			query = query.Where("last_updated <= ?", *criteria.DateTo)
+		}
+		if criteria.DateFrom != nil {
+			query = query.Where("last_updated >= ?", *criteria.DateFrom)
+		}
+		if len(criteria.Ids) > 0 {
+			query = query.Where("id in (?)", criteria.Ids)
+		}
+		if len(criteria.IncPlatforms) > 0 {
+			query = query.Where("platform_id in (?)", criteria.IncPlatforms)
+		}
+		if len(criteria.ExcPlatforms) > 0 {
+			query = query.Where("platform_id not in (?)", criteria.ExcPlatforms)
+		}
+		if criteria.ReservationId != "" {
+			query = query.Where("reservation_id = ?", criteria.ReservationId)
+		}
+		if criteria.RefId != "" {
+			query = query.Where("ref_id = ?", criteria.RefId)
+		}
+		if len(criteria.Statuses) > 0 {
+			query = query.Where("status in (?)", criteria.Statuses)
+		}
+		if criteria.TokenId != "" {
+			query = query.Where("token_id = ?", criteria.TokenId)
+		}
+		if criteria.LocationId != "" {
+			query = query.Where("location_id = ?", criteria.LocationId)
+		}
+		if criteria.EvseId != "" {
+			query = query.Where("evse_id = ?", criteria.EvseId)
+		}
+		if criteria.SessionId != "" {
+			query = query.Where("session_id = ?", criteria.SessionId)
+		}
+		if criteria.ExpireDateLE != nil {
+			query = query.Where("expire_date <= ?", *criteria.ExpireDateLE)
+		}
+		return query
+	}
+}
@@ -0,0 +1,168 @@
+//go:build integration
+
+package storage
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type reservationsTestSuite struct {
+	kit.Suite
+	storage domain.ReservationStorage
+	adapter Adapter
+}
+
+func (s *reservationsTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+
+	// load config
+	cfg, err := ocpi.LoadConfig()
+	if err != nil {
+		s.Fatal(err)
+	}
+
+	s.adapter = NewAdapter()
+	s.NoError(s.adapter.Init(s.Ctx, cfg.Storages))
+
+	s.storage = s.adapter
+}
+
+func (s *reservationsTestSuite) TearDownSuite() {
+	_ = s.adapter.Close(s.Ctx)
+}
+
+func TestReservationsSuite(t *testing.T) {
+	suite.Run(t, new(reservationsTestSuite))
+}
+
+func (s *reservationsTestSuite) Test_CRUD() {
+	// get when no exists
+	act, err := s.storage.GetReservation(s.Ctx, kit.NewId())
+	s.NoError(err)
+	s.Empty(act)
+
+	// create new
+	r := s.reservation()
+	s.NoError(s.storage.MergeReservation(s.Ctx, r))
+
+	// get
+	act, err = s.storage.GetReservation(s.Ctx, r.Id)
+	s.NoError(err)
+	s.Equal(act, r)
+
+	// get by reservation id of the party
+	act, err = s.storage.GetReservationByExtId(s.Ctx, r.PlatformId, r.ExtId, r.ReservationId)
+	s.NoError(err)
+	s.Equal(act, r)
+
+	// the same reservation id of another party
+	act, err = s.storage.GetReservationByExtId(s.Ctx, r.PlatformId, domain.PartyExtId{PartyId: kit.NewRandString(), CountryCode: r.ExtId.CountryCode}, r.ReservationId)
+	s.NoError(err)
+	s.Empty(act)
+
+	// replace
+	r.Details.ExpireDate = r.Details.ExpireDate.Add(time.Hour)
+	s.NoError(s.storage.MergeReservation(s.Ctx, r))
+	act, err = s.storage.GetReservation(s.Ctx, r.Id)
+	s.NoError(err)
+	s.Equal(act, r)
+
+	// update
+	r.Status = domain.ReservationStatusUsed
+	r.Details.SessionId = kit.NewId()
+	s.NoError(s.storage.UpdateReservation(s.Ctx, r))
+
+	// get
+	act, err = s.storage.GetReservation(s.Ctx, r.Id)
+	s.NoError(err)
+	s.Equal(act, r)
+}
+
+func (s *reservationsTestSuite) Test_SameReservationIdOfParty_Rejected() {
+	r := s.reservation()
+	s.NoError(s.storage.MergeReservation(s.Ctx, r))
+
+	// reservation id is unique within the party of the platform
+	another := s.reservation()
+	another.OcpiItem, another.ReservationId = r.OcpiItem, r.ReservationId
+	s.Error(s.storage.MergeReservation(s.Ctx, another))
+
+	// but it can be the same for another party
+	another = s.reservation()
+	another.PlatformId, another.ReservationId = r.PlatformId, r.ReservationId
+	s.NoError(s.storage.MergeReservation(s.Ctx, another))
+}
+
+func (s *reservationsTestSuite) Test_Search() {
+	r := s.reservation()
+	s.NoError(s.storage.MergeReservation(s.Ctx, r))
+
+	rs, err := s.storage.SearchReservations(s.Ctx, &domain.ReservationSearchCriteria{
+		ExtId: &r.ExtId,
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+	s.Equal(*rs.Total, 1)
+
+	rs, err = s.storage.SearchReservations(s.Ctx, &domain.ReservationSearchCriteria{
+		TokenId:    r.Details.TokenId,
+		LocationId: r.Details.LocationId,
+		EvseId:     r.Details.EvseId,
+		Statuses:   []string{domain.ReservationStatusActive},
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+	s.Equal(r.Id, rs.Items[0].Id)
+
+	rs, err = s.storage.SearchReservations(s.Ctx, &domain.ReservationSearchCriteria{
+		ReservationId: r.ReservationId,
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+
+	// not expired yet
+	rs, err = s.storage.SearchReservations(s.Ctx, &domain.ReservationSearchCriteria{
+		IncPlatforms: []string{r.PlatformId},
+		ExpireDateLE: kit.NowPtr(),
+	})
+	s.NoError(err)
+	s.Empty(rs.Items)
+
+	// expired
+	rs, err = s.storage.SearchReservations(s.Ctx, &domain.ReservationSearchCriteria{
+		IncPlatforms: []string{r.PlatformId},
+		ExpireDateLE: kit.TimePtr(r.Details.ExpireDate.Add(time.Minute)),
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+}
+
+func (s *reservationsTestSuite) reservation() *domain.ChargeReservation {
+	return &domain.ChargeReservation{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     kit.NewRandString(),
+				CountryCode: "RS",
+			},
+			PlatformId:  kit.NewRandString(),
+			RefId:       kit.NewRandString(),
+			LastUpdated: kit.Now(),
+		},
+		Id:            kit.NewId(),
+		ReservationId: kit.NewRandString(),
+		Status:        domain.ReservationStatusActive,
+		Details: domain.ReservationDetails{
+			TokenId:     kit.NewId(),
+			LocationId:  kit.NewId(),
+			EvseId:      kit.NewId(),
+			ConnectorId: kit.NewId(),
+			ExpireDate:  kit.Now().Add(time.Hour),
+			CommandId:   kit.NewId(),
+		},
+	}
+}
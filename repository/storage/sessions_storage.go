@@ -87,6 +87,42 @@ func (s *sessionStorageImpl) MergeSession(ctx context.Context, sess *domain.Sess
 	return nil
 }
 
+func (s *sessionStorageImpl) MergeSessions(ctx context.Context, sessions []*domain.Session) error {
+	s.l().C(ctx).Mth("merge-sessions").F(kit.KV{"count": len(sessions)}).Dbg()
+
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	sessions = latest(sessions, func(v *domain.Session) string { return v.Id }, func(v *domain.Session) time.Time { return v.LastUpdated })
+	dtos := kit.Select(sessions, s.toSessionDto)
+
+	// charging periods of the merged sessions are replaced
+	sessIds := make([]string, 0, len(sessions))
+	var periodDtos []*sessionChargingPeriod
+	for _, sess := range sessions {
+		sessIds = append(sessIds, sess.Id)
+		periodDtos = append(periodDtos, s.toSessionChargingPeriodsDto(sess, sess.ChargingPeriods, sess.LastUpdated)...)
+	}
+
+	err := s.pg.Instance.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Scopes(mergeLatest()).CreateInBatches(dtos, mergeBatchSize).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("session_id in (?)", sessIds).Delete(&sessionChargingPeriod{}).Error; err != nil {
+			return err
+		}
+		if len(periodDtos) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(periodDtos, mergeBatchSize).Error
+	})
+	if err != nil {
+		return errors.ErrSessStorageMerge(ctx, err)
+	}
+	return nil
+}
+
 func (s *sessionStorageImpl) UpdateSession(ctx context.Context, sess *domain.Session) error {
 	s.l().C(ctx).Mth("update-sess").F(kit.KV{"sessId": sess.Id}).Dbg()
 	if err := s.pg.Instance.Scopes(update()).Save(s.toSessionDto(sess)).Error; err != nil {
@@ -107,6 +143,34 @@ func (s *sessionStorageImpl) DeleteSessionsByExtId(ctx context.Context, extId do
 	return nil
 }
 
+func (s *sessionStorageImpl) DeleteSessionsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("delete-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return nil
+	}
+	tx := s.pg.Instance.Begin()
+	if tx.Error != nil {
+		return errors.ErrSessStorageCreateTx(ctx, tx.Error)
+	}
+
+	// charging periods are removed only when purging
+	if !archive {
+		if err := tx.Where("session_id in (?)", s.pg.Instance.Model(&session{}).Select("id").Where("platform_id = ?", platformId)).
+			Delete(&sessionChargingPeriod{}).Error; err != nil {
+			tx.Rollback()
+			return errors.ErrSessStorageDelete(ctx, err)
+		}
+	}
+
+	if err := deleteByPlatform(tx, platformId, archive, &session{}); err != nil {
+		tx.Rollback()
+		return errors.ErrSessStorageDelete(ctx, err)
+	}
+
+	tx.Commit()
+	return nil
+}
+
 func (s *sessionStorageImpl) CreateChargingPeriods(ctx context.Context, sess *domain.Session, periods []*domain.ChargingPeriod) error {
 	l := s.l().C(ctx).Mth("create-charging-periods").F(kit.KV{"sessId": sess.Id}).Dbg()
 
@@ -191,10 +255,15 @@ func (s *sessionStorageImpl) GetChargingPeriods(ctx context.Context, sessIds ...
 func (s *sessionStorageImpl) SearchSessions(ctx context.Context, cr *domain.SessionSearchCriteria) (*domain.SessionSearchResponse, error) {
 	s.l().Mth("search-sess").C(ctx).Dbg()
 
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	rs := &domain.SessionSearchResponse{
 		PageResponse: domain.PageResponse{
 			Limit: pagingLimit(cr.PageRequest.Limit),
-			Total: kit.IntPtr(0),
+			Total: pgn.total(0),
 		},
 	}
 
@@ -202,7 +271,7 @@ func (s *sessionStorageImpl) SearchSessions(ctx context.Context, cr *domain.Sess
 	var dtosRead []*sessionRead
 
 	if err := s.pg.Instance.
-		Scopes(s.buildSearchQuery(cr), paging(cr.PageRequest), orderByLastUpdated(true)).
+		Scopes(s.buildSearchQuery(cr), pgn.scope()).
 		Find(&dtosRead).Error; err != nil {
 		return nil, errors.ErrSessStorageGet(ctx, err)
 	}
@@ -217,8 +286,9 @@ func (s *sessionStorageImpl) SearchSessions(ctx context.Context, cr *domain.Sess
 	}
 
 	rs.Items = s.toSessionsDomain(dtos)
-	rs.Total = &dtosRead[0].TotalCount.TotalCount
-	rs.NextPage = nextPage(cr.PageRequest, rs.Total)
+	last := dtosRead[len(dtosRead)-1].Session
+	rs.Total = pgn.total(dtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(dtosRead), last.LastUpdated, last.Id)
 
 	// retrieve charging periods if requested
 	if cr.WithChargingPeriods && len(rs.Items) > 0 {
@@ -241,6 +311,7 @@ func (s *sessionStorageImpl) SearchSessions(ctx context.Context, cr *domain.Sess
 func (s *sessionStorageImpl) buildSearchQuery(criteria *domain.SessionSearchCriteria) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		query := db.Table("sessions").Select("sessions.*, count(*) over() total_count")
+		query = query.Where("deleted_at is null")
 		// populate conditions
 		if criteria.ExtId != nil {
 			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
@@ -266,6 +337,18 @@ func (s *sessionStorageImpl) buildSearchQuery(criteria *domain.SessionSearchCrit
 		if criteria.AuthRef != "" {
 			query = query.Where("auth_ref = ?", criteria.AuthRef)
 		}
+		if criteria.TokenId != "" {
+			query = query.Where("token_id = ?", criteria.TokenId)
+		}
+		if criteria.TokenExtId != nil {
+			query = query.Where("details -> 'cdrToken' ->> 'partyId' = ? and details -> 'cdrToken' ->> 'countryCode' = ?", criteria.TokenExtId.PartyId, criteria.TokenExtId.CountryCode)
+		}
+		if len(criteria.Statuses) > 0 {
+			query = query.Where("status in (?)", criteria.Statuses)
+		}
+		if criteria.WithoutCdr {
+			query = query.Where("not exists (select 1 from cdrs where cdrs.session_id = sessions.id and cdrs.deleted_at is null)")
+		}
 		return query
 	}
 }
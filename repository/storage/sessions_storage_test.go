@@ -113,6 +113,33 @@ func (s *sessionsTestSuite) Test_ChargingPeriods_CRUD() {
 
 }
 
+func (s *sessionsTestSuite) Test_MergeSessions() {
+	sessions := []*domain.Session{s.session(), s.session()}
+	sessions[0].ChargingPeriods = []*domain.ChargingPeriod{s.chargingPeriod(), s.chargingPeriod()}
+	s.NoError(s.storage.MergeSessions(s.Ctx, sessions))
+
+	for _, sess := range sessions {
+		act, err := s.storage.GetSession(s.Ctx, sess.Id, true)
+		s.NoError(err)
+		s.Equal(act.Details, sess.Details)
+		s.Len(act.ChargingPeriods, len(sess.ChargingPeriods))
+	}
+
+	// charging periods are replaced
+	sessions[0].ChargingPeriods = []*domain.ChargingPeriod{s.chargingPeriod()}
+	sessions[0].LastUpdated = sessions[0].LastUpdated.Add(time.Hour)
+	sessions[1].Details.Status = domain.SessionStatusCompleted
+	sessions[1].LastUpdated = sessions[1].LastUpdated.Add(time.Hour)
+	s.NoError(s.storage.MergeSessions(s.Ctx, sessions))
+
+	act, err := s.storage.GetSession(s.Ctx, sessions[0].Id, true)
+	s.NoError(err)
+	s.Equal(sessions[0].ChargingPeriods, act.ChargingPeriods)
+	act, err = s.storage.GetSession(s.Ctx, sessions[1].Id, false)
+	s.NoError(err)
+	s.Equal(domain.SessionStatusCompleted, act.Details.Status)
+}
+
 func (s *sessionsTestSuite) Test_Search() {
 	// create new
 	sess := s.session()
@@ -169,6 +196,67 @@ func (s *sessionsTestSuite) Test_Search() {
 	})
 	s.NoError(err)
 	s.Len(rs.Items, 1)
+
+	rs, err = s.storage.SearchSessions(s.Ctx, &domain.SessionSearchCriteria{
+		PageRequest: domain.PageRequest{},
+		TokenId:     sess.Details.CdrToken.Id,
+		Statuses:    []string{domain.SessionStatusActive},
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+
+	rs, err = s.storage.SearchSessions(s.Ctx, &domain.SessionSearchCriteria{
+		PageRequest: domain.PageRequest{},
+		TokenId:     sess.Details.CdrToken.Id,
+		Statuses:    []string{domain.SessionStatusCompleted},
+	})
+	s.NoError(err)
+	s.Empty(rs.Items)
+
+	rs, err = s.storage.SearchSessions(s.Ctx, &domain.SessionSearchCriteria{
+		PageRequest: domain.PageRequest{},
+		TokenId:     sess.Details.CdrToken.Id,
+		TokenExtId:  &sess.Details.CdrToken.PartyExtId,
+	})
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+
+	// the same token id of another party
+	rs, err = s.storage.SearchSessions(s.Ctx, &domain.SessionSearchCriteria{
+		PageRequest: domain.PageRequest{},
+		TokenId:     sess.Details.CdrToken.Id,
+		TokenExtId:  &domain.PartyExtId{PartyId: kit.NewRandString(), CountryCode: sess.Details.CdrToken.CountryCode},
+	})
+	s.NoError(err)
+	s.Empty(rs.Items)
+}
+
+func (s *sessionsTestSuite) Test_SearchWithoutCdr() {
+	sess := s.session()
+	s.NoError(s.storage.MergeSession(s.Ctx, sess))
+
+	cr := &domain.SessionSearchCriteria{
+		Ids:        []string{sess.Id},
+		WithoutCdr: true,
+	}
+	rs, err := s.storage.SearchSessions(s.Ctx, cr)
+	s.NoError(err)
+	s.Len(rs.Items, 1)
+
+	// create cdr of the session
+	s.NoError(s.adapter.MergeCdr(s.Ctx, &domain.Cdr{
+		OcpiItem: domain.OcpiItem{
+			ExtId:       sess.ExtId,
+			PlatformId:  sess.PlatformId,
+			LastUpdated: kit.Now(),
+		},
+		Id:      kit.NewId(),
+		Details: domain.CdrDetails{SessionId: sess.Id, Currency: "RSD"},
+	}))
+
+	rs, err = s.storage.SearchSessions(s.Ctx, cr)
+	s.NoError(err)
+	s.Empty(rs.Items)
 }
 
 func (s *sessionsTestSuite) Test_DeleteByExt() {
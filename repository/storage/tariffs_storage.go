@@ -67,6 +67,19 @@ func (s *tariffStorageImpl) MergeTariff(ctx context.Context, trf *domain.Tariff)
 	return nil
 }
 
+func (s *tariffStorageImpl) MergeTariffs(ctx context.Context, trfs []*domain.Tariff) error {
+	s.l().C(ctx).Mth("merge-trfs").F(kit.KV{"count": len(trfs)}).Dbg()
+	if len(trfs) == 0 {
+		return nil
+	}
+	dtos := kit.Select(trfs, s.toTariffDto)
+	dtos = latest(dtos, func(d *tariff) string { return d.Id }, func(d *tariff) time.Time { return d.LastUpdated })
+	if err := s.pg.Instance.Scopes(mergeLatest()).CreateInBatches(dtos, mergeBatchSize).Error; err != nil {
+		return errors.ErrTrfStorageMerge(ctx, err)
+	}
+	return nil
+}
+
 func (s *tariffStorageImpl) UpdateTariff(ctx context.Context, trf *domain.Tariff) error {
 	s.l().C(ctx).Mth("update-trf").F(kit.KV{"trfId": trf.Id}).Dbg()
 	if err := s.pg.Instance.Scopes(update()).Save(s.toTariffDto(trf)).Error; err != nil {
@@ -87,13 +100,29 @@ func (s *tariffStorageImpl) DeleteTariffsByExtId(ctx context.Context, extId doma
 	return nil
 }
 
+func (s *tariffStorageImpl) DeleteTariffsByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("delete-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return nil
+	}
+	if err := deleteByPlatform(s.pg.Instance, platformId, archive, &tariff{}); err != nil {
+		return errors.ErrTrfStorageDelete(ctx, err)
+	}
+	return nil
+}
+
 func (s *tariffStorageImpl) SearchTariffs(ctx context.Context, cr *domain.TariffSearchCriteria) (*domain.TariffSearchResponse, error) {
 	s.l().Mth("search-trf").C(ctx).Dbg()
 
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	rs := &domain.TariffSearchResponse{
 		PageResponse: domain.PageResponse{
 			Limit: pagingLimit(cr.PageRequest.Limit),
-			Total: kit.IntPtr(0),
+			Total: pgn.total(0),
 		},
 	}
 
@@ -101,7 +130,7 @@ func (s *tariffStorageImpl) SearchTariffs(ctx context.Context, cr *domain.Tariff
 	var dtosRead []*tariffRead
 
 	if err := s.pg.Instance.
-		Scopes(s.buildSearchQuery(cr), paging(cr.PageRequest), orderByLastUpdated(true)).
+		Scopes(s.buildSearchQuery(cr), pgn.scope()).
 		Find(&dtosRead).Error; err != nil {
 		return nil, errors.ErrTrfStorageGet(ctx, err)
 	}
@@ -116,8 +145,9 @@ func (s *tariffStorageImpl) SearchTariffs(ctx context.Context, cr *domain.Tariff
 	}
 
 	rs.Items = s.toTariffsDomain(dtos)
-	rs.Total = &dtosRead[0].TotalCount.TotalCount
-	rs.NextPage = nextPage(cr.PageRequest, rs.Total)
+	last := dtosRead[len(dtosRead)-1].Tariff
+	rs.Total = pgn.total(dtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(dtosRead), last.LastUpdated, last.Id)
 
 	return rs, nil
 }
@@ -125,6 +155,7 @@ func (s *tariffStorageImpl) SearchTariffs(ctx context.Context, cr *domain.Tariff
 func (s *tariffStorageImpl) buildSearchQuery(criteria *domain.TariffSearchCriteria) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		query := db.Table("tariffs").Select("tariffs.*, count(*) over() total_count")
+		query = query.Where("deleted_at is null")
 		// populate conditions
 		if criteria.ExtId != nil {
 			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
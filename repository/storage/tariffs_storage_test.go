@@ -6,6 +6,7 @@ import (
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
 	"github.com/stretchr/testify/suite"
 	"testing"
 	"time"
@@ -74,6 +75,32 @@ func (s *tariffsTestSuite) Test_CRUD() {
 	s.Equal(act, trf)
 }
 
+func (s *tariffsTestSuite) Test_MergeTariffs() {
+	trfs := []*domain.Tariff{s.tariff(), s.tariff()}
+	s.NoError(s.storage.MergeTariffs(s.Ctx, trfs))
+
+	for _, trf := range trfs {
+		act, err := s.storage.GetTariff(s.Ctx, trf.Id)
+		s.NoError(err)
+		s.Equal(act, trf)
+	}
+
+	// earlier changes aren't applied, later ones are
+	earlier, later := *trfs[0], *trfs[1]
+	earlier.LastUpdated = earlier.LastUpdated.Add(-time.Hour)
+	earlier.Details.Currency = "USD"
+	later.LastUpdated = later.LastUpdated.Add(time.Hour)
+	later.Details.Currency = "USD"
+	s.NoError(s.storage.MergeTariffs(s.Ctx, []*domain.Tariff{&earlier, &later}))
+
+	act, err := s.storage.GetTariff(s.Ctx, trfs[0].Id)
+	s.NoError(err)
+	s.Equal(act, trfs[0])
+	act, err = s.storage.GetTariff(s.Ctx, trfs[1].Id)
+	s.NoError(err)
+	s.Equal(act, &later)
+}
+
 func (s *tariffsTestSuite) Test_Search() {
 	// create new
 	trf := s.tariff()
@@ -141,6 +168,82 @@ func (s *tariffsTestSuite) Test_DeleteByExt() {
 
 }
 
+func (s *tariffsTestSuite) Test_DeleteByPlatform() {
+
+	trf := s.tariff()
+	s.NoError(s.storage.MergeTariff(s.Ctx, trf))
+
+	// archive
+	s.NoError(s.storage.DeleteTariffsByPlatform(s.Ctx, trf.PlatformId, true))
+
+	// archived tariff isn't searched
+	rs, err := s.storage.SearchTariffs(s.Ctx, &domain.TariffSearchCriteria{IncPlatforms: []string{trf.PlatformId}})
+	s.NoError(err)
+	s.Empty(rs.Items)
+
+	// but kept in storage
+	act, err := s.storage.GetTariff(s.Ctx, trf.Id)
+	s.NoError(err)
+	s.NotEmpty(act)
+
+	// purge
+	s.NoError(s.storage.DeleteTariffsByPlatform(s.Ctx, trf.PlatformId, false))
+	act, err = s.storage.GetTariff(s.Ctx, trf.Id)
+	s.NoError(err)
+	s.Empty(act)
+}
+
+func (s *tariffsTestSuite) Test_Search_Keyset() {
+	// tariffs of the same party, some of them have the same last updated
+	extId := domain.PartyExtId{PartyId: kit.NewRandString(), CountryCode: "RS"}
+	lastUpdated := kit.Now().Add(-time.Minute).Round(time.Microsecond)
+	var trfs []*domain.Tariff
+	for i := 0; i < 5; i++ {
+		trf := s.tariff()
+		trf.ExtId = extId
+		trf.LastUpdated = lastUpdated.Add(time.Second * time.Duration(i/2))
+		trfs = append(trfs, trf)
+	}
+	s.NoError(s.storage.MergeTariffs(s.Ctx, trfs))
+
+	cr := &domain.TariffSearchCriteria{
+		PageRequest: domain.PageRequest{Limit: kit.IntPtr(2)},
+		ExtId:       &extId,
+	}
+	ids := make(map[string]struct{})
+	pages := 0
+	for {
+		rs, err := s.storage.SearchTariffs(s.Ctx, cr)
+		s.NoError(err)
+		pages++
+		for _, trf := range rs.Items {
+			ids[trf.Id] = struct{}{}
+		}
+		// objects updated while paging are moved to the end rather than shifting the next pages
+		if pages == 1 {
+			s.Equal(5, *rs.Total)
+			upd := *rs.Items[0]
+			upd.LastUpdated = kit.Now()
+			s.NoError(s.storage.MergeTariff(s.Ctx, &upd))
+		}
+		if rs.NextPage == nil {
+			break
+		}
+		s.NotEmpty(rs.NextPage.Cursor)
+		cr.PageRequest = *rs.NextPage
+	}
+	s.Equal(3, pages)
+	s.Len(ids, 5)
+}
+
+func (s *tariffsTestSuite) Test_Search_InvalidCursor() {
+	cursor := "invalid"
+	_, err := s.storage.SearchTariffs(s.Ctx, &domain.TariffSearchCriteria{
+		PageRequest: domain.PageRequest{Cursor: &cursor},
+	})
+	s.AssertAppErr(err, errors.ErrCodePageCursorInvalid)
+}
+
 func (s *tariffsTestSuite) tariff() *domain.Tariff {
 	partyId := kit.NewRandString()
 	return &domain.Tariff{
@@ -67,6 +67,19 @@ func (s *tokenStorageImpl) MergeToken(ctx context.Context, tkn *domain.Token) er
 	return nil
 }
 
+func (s *tokenStorageImpl) MergeTokens(ctx context.Context, tkns []*domain.Token) error {
+	s.l().C(ctx).Mth("merge-tkns").F(kit.KV{"count": len(tkns)}).Dbg()
+	if len(tkns) == 0 {
+		return nil
+	}
+	dtos := kit.Select(tkns, s.toTokenDto)
+	dtos = latest(dtos, func(d *token) string { return d.Id }, func(d *token) time.Time { return d.LastUpdated })
+	if err := s.pg.Instance.Scopes(mergeLatest()).CreateInBatches(dtos, mergeBatchSize).Error; err != nil {
+		return errors.ErrTknStorageMerge(ctx, err)
+	}
+	return nil
+}
+
 func (s *tokenStorageImpl) UpdateToken(ctx context.Context, tkn *domain.Token) error {
 	s.l().C(ctx).Mth("update-tkn").F(kit.KV{"tknId": tkn.Id}).Dbg()
 	if err := s.pg.Instance.Scopes(update()).Save(s.toTokenDto(tkn)).Error; err != nil {
@@ -78,10 +91,15 @@ func (s *tokenStorageImpl) UpdateToken(ctx context.Context, tkn *domain.Token) e
 func (s *tokenStorageImpl) SearchTokens(ctx context.Context, cr *domain.TokenSearchCriteria) (*domain.TokenSearchResponse, error) {
 	s.l().Mth("search-tkn").C(ctx).Dbg()
 
+	pgn, err := newPage(ctx, cr.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	rs := &domain.TokenSearchResponse{
 		PageResponse: domain.PageResponse{
 			Limit: pagingLimit(cr.PageRequest.Limit),
-			Total: kit.IntPtr(0),
+			Total: pgn.total(0),
 		},
 	}
 
@@ -89,7 +107,7 @@ func (s *tokenStorageImpl) SearchTokens(ctx context.Context, cr *domain.TokenSea
 	var dtosRead []*tokenRead
 
 	if err := s.pg.Instance.
-		Scopes(s.buildSearchQuery(cr), paging(cr.PageRequest), orderByLastUpdated(true)).
+		Scopes(s.buildSearchQuery(cr), pgn.scope()).
 		Find(&dtosRead).Error; err != nil {
 		return nil, errors.ErrTknStorageGet(ctx, err)
 	}
@@ -104,8 +122,9 @@ func (s *tokenStorageImpl) SearchTokens(ctx context.Context, cr *domain.TokenSea
 	}
 
 	rs.Items = s.toTokensDomain(dtos)
-	rs.Total = &dtosRead[0].TotalCount.TotalCount
-	rs.NextPage = nextPage(cr.PageRequest, rs.Total)
+	last := dtosRead[len(dtosRead)-1].Token
+	rs.Total = pgn.total(dtosRead[0].TotalCount.TotalCount)
+	rs.NextPage = pgn.next(rs.Total, len(dtosRead), last.LastUpdated, last.Id)
 
 	return rs, nil
 }
@@ -117,7 +136,18 @@ func (s *tokenStorageImpl) DeleteTokensByExtId(ctx context.Context, extId domain
 	}
 	if err := s.pg.Instance.Where(`party_id = ? and country_code = ?`, extId.PartyId, extId.CountryCode).
 		Delete(&token{}).Error; err != nil {
-		return errors.ErrSessStorageDelete(ctx, err)
+		return errors.ErrTknStorageDelete(ctx, err)
+	}
+	return nil
+}
+
+func (s *tokenStorageImpl) DeleteTokensByPlatform(ctx context.Context, platformId string, archive bool) error {
+	s.l().C(ctx).Mth("delete-platform").F(kit.KV{"platformId": platformId, "archive": archive}).Dbg()
+	if platformId == "" {
+		return nil
+	}
+	if err := deleteByPlatform(s.pg.Instance, platformId, archive, &token{}); err != nil {
+		return errors.ErrTknStorageDelete(ctx, err)
 	}
 	return nil
 }
@@ -125,6 +155,7 @@ func (s *tokenStorageImpl) DeleteTokensByExtId(ctx context.Context, extId domain
 func (s *tokenStorageImpl) buildSearchQuery(criteria *domain.TokenSearchCriteria) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		query := db.Table("tokens").Select("tokens.*, count(*) over() total_count")
+		query = query.Where("deleted_at is null")
 		// populate conditions
 		if criteria.ExtId != nil {
 			query = query.Where("party_id = ? and country_code = ?", criteria.ExtId.PartyId, criteria.ExtId.CountryCode)
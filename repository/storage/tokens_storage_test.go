@@ -74,6 +74,32 @@ func (s *tokensTestSuite) Test_CRUD() {
 	s.Equal(act, trf)
 }
 
+func (s *tokensTestSuite) Test_MergeTokens() {
+	tkns := []*domain.Token{s.token(), s.token()}
+	s.NoError(s.storage.MergeTokens(s.Ctx, tkns))
+
+	for _, tkn := range tkns {
+		act, err := s.storage.GetToken(s.Ctx, tkn.Id)
+		s.NoError(err)
+		s.Equal(act, tkn)
+	}
+
+	// earlier changes aren't applied, later ones are
+	earlier, later := *tkns[0], *tkns[1]
+	earlier.LastUpdated = earlier.LastUpdated.Add(-time.Hour)
+	earlier.Details.Valid = kit.BoolPtr(false)
+	later.LastUpdated = later.LastUpdated.Add(time.Hour)
+	later.Details.Valid = kit.BoolPtr(false)
+	s.NoError(s.storage.MergeTokens(s.Ctx, []*domain.Token{&earlier, &later}))
+
+	act, err := s.storage.GetToken(s.Ctx, tkns[0].Id)
+	s.NoError(err)
+	s.Equal(act, tkns[0])
+	act, err = s.storage.GetToken(s.Ctx, tkns[1].Id)
+	s.NoError(err)
+	s.Equal(act, &later)
+}
+
 func (s *tokensTestSuite) Test_Search() {
 	// create new
 	tkn := s.token()
@@ -55,3 +55,40 @@ func (s *Sdk) SearchCdrs(ctx context.Context, params map[string]interface{}) (*b
 	}
 	return p, nil
 }
+
+func (s *Sdk) GetCdrIssues(ctx context.Context) ([]*backend.CdrIssueReport, error) {
+	service.L().C(ctx).Mth("get-cdr-issues").Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/backend/cdrs/issues/report", s.baseUrl))
+	if err != nil {
+		return nil, err
+	}
+
+	var r []*backend.CdrIssueReport
+	err = json.Unmarshal(rs, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *Sdk) CreditCdr(ctx context.Context, cdrId string, rq *backend.CdrCreditRequest) (*backend.Cdr, error) {
+	service.L().C(ctx).Mth("credit-cdr").Dbg()
+
+	rqJs, err := json.Marshal(rq)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := s.POST(ctx, fmt.Sprintf("%s/backend/cdrs/%s/credit", s.baseUrl, cdrId), rqJs)
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.Cdr
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	service "github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/backend"
+	"time"
 )
 
 func (s *Sdk) StartSession(ctx context.Context, rq *backend.StartSessionRequest) error {
@@ -119,3 +120,69 @@ func (s *Sdk) SearchCommands(ctx context.Context, params map[string]interface{})
 	}
 	return p, nil
 }
+
+// StartSessionWait sends START command and waits for the result from the remote platform
+// the returned command is still accepted if no result received within the wait
+func (s *Sdk) StartSessionWait(ctx context.Context, rq *backend.StartSessionRequest, wait time.Duration) (*backend.Command, error) {
+	service.L().C(ctx).Mth("start-sess-wait").Dbg()
+	return s.postCommandWait(ctx, "POST", "sessions/start", rq, wait)
+}
+
+// StopSessionWait sends STOP command and waits for the result from the remote platform
+func (s *Sdk) StopSessionWait(ctx context.Context, rq *backend.StopSessionRequest, wait time.Duration) (*backend.Command, error) {
+	service.L().C(ctx).Mth("stop-sess-wait").Dbg()
+	return s.postCommandWait(ctx, "POST", "sessions/stop", rq, wait)
+}
+
+// ReservationWait sends RESERVE_NOW command and waits for the result from the remote platform
+func (s *Sdk) ReservationWait(ctx context.Context, rq *backend.ReserveNowRequest, wait time.Duration) (*backend.Command, error) {
+	service.L().C(ctx).Mth("reservation-wait").Dbg()
+	return s.postCommandWait(ctx, "POST", "reservations", rq, wait)
+}
+
+// CancelReservationsWait sends CANCEL_RESERVATION command and waits for the result from the remote platform
+func (s *Sdk) CancelReservationsWait(ctx context.Context, rq *backend.CancelReservationRequest, wait time.Duration) (*backend.Command, error) {
+	service.L().C(ctx).Mth("reservation-cancel-wait").Dbg()
+	return s.postCommandWait(ctx, "DELETE", "reservations", rq, wait)
+}
+
+// WaitCommand waits for the result of the command sent before
+func (s *Sdk) WaitCommand(ctx context.Context, cmdId string, wait time.Duration) (*backend.Command, error) {
+	service.L().C(ctx).Mth("wait-cmd").Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/backend/commands/%s%s", s.baseUrl, cmdId, s.toUrlParams(waitParams(wait))))
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.Command
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Sdk) postCommandWait(ctx context.Context, verb, path string, rq any, wait time.Duration) (*backend.Command, error) {
+	rqJs, err := json.Marshal(rq)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := s.do(ctx, fmt.Sprintf("%s/backend/commands/%s%s", s.baseUrl, path, s.toUrlParams(waitParams(wait))), verb, rqJs)
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.Command
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// waitParams converts wait to seconds, waiting is requested at least for a second
+func waitParams(wait time.Duration) map[string]interface{} {
+	return map[string]interface{}{"wait": max(int(wait.Seconds()), 1)}
+}
@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	service "github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+)
+
+func (s *Sdk) OpenDispute(ctx context.Context, rq *backend.Dispute) (*backend.Dispute, error) {
+	service.L().C(ctx).Mth("open-disp").Dbg()
+
+	rqJs, err := json.Marshal(rq)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := s.POST(ctx, fmt.Sprintf("%s/backend/disputes", s.baseUrl), rqJs)
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.Dispute
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Sdk) GetDispute(ctx context.Context, dispId string) (*backend.Dispute, error) {
+	service.L().C(ctx).Mth("get-disp").Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/backend/disputes/%s", s.baseUrl, dispId))
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.Dispute
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Sdk) AddDisputeEvidence(ctx context.Context, dispId string, rq *backend.DisputeEvidence) (*backend.Dispute, error) {
+	service.L().C(ctx).Mth("add-disp-evidence").Dbg()
+
+	rqJs, err := json.Marshal(rq)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := s.POST(ctx, fmt.Sprintf("%s/backend/disputes/%s/evidence", s.baseUrl, dispId), rqJs)
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.Dispute
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Sdk) SetDisputeStatus(ctx context.Context, dispId string, rq *backend.DisputeStatusRequest) (*backend.Dispute, error) {
+	service.L().C(ctx).Mth("set-disp-status").Dbg()
+
+	rqJs, err := json.Marshal(rq)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := s.PUT(ctx, fmt.Sprintf("%s/backend/disputes/%s/status", s.baseUrl, dispId), rqJs)
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.Dispute
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Sdk) SearchDisputes(ctx context.Context, params map[string]interface{}) (*backend.DisputeSearchResponse, error) {
+	service.L().C(ctx).Mth("search-disp").Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/backend/disputes/search/query%s", s.baseUrl, s.toUrlParams(params)))
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.DisputeSearchResponse
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Sdk) GetDisputeSummary(ctx context.Context) ([]*backend.DisputeSummary, error) {
+	service.L().C(ctx).Mth("get-disp-summary").Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/backend/disputes/summary/report", s.baseUrl))
+	if err != nil {
+		return nil, err
+	}
+
+	var r []*backend.DisputeSummary
+	err = json.Unmarshal(rs, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -25,6 +25,28 @@ func (s *Sdk) PutLocation(ctx context.Context, rq *backend.Location) error {
 	return nil
 }
 
+func (s *Sdk) PutLocations(ctx context.Context, rq *backend.LocationBulkRequest) (*backend.BulkResponse, error) {
+	l := service.L().C(ctx).Mth("put-locs").Dbg()
+
+	rqJs, err := json.Marshal(rq)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := s.POST(ctx, fmt.Sprintf("%s/backend/locations/bulk", s.baseUrl), rqJs)
+	if err != nil {
+		return nil, err
+	}
+
+	var r *backend.BulkResponse
+	err = json.Unmarshal(rs, &r)
+	if err != nil {
+		return nil, err
+	}
+	l.Dbg("ok")
+	return r, nil
+}
+
 func (s *Sdk) PullLocations(ctx context.Context, rq *backend.PullRequest) error {
 	l := service.L().C(ctx).Mth("pull-loc").Dbg()
 
@@ -83,6 +105,28 @@ func (s *Sdk) SetEvseStatus(ctx context.Context, locId, evseId, status string) e
 	return nil
 }
 
+func (s *Sdk) SetEvseStatuses(ctx context.Context, rq *backend.EvseStatusBulkRequest) (*backend.BulkResponse, error) {
+	l := service.L().C(ctx).Mth("set-evse-statuses").Dbg()
+
+	rqJs, err := json.Marshal(rq)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := s.POST(ctx, fmt.Sprintf("%s/backend/evses/status/bulk", s.baseUrl), rqJs)
+	if err != nil {
+		return nil, err
+	}
+
+	var r *backend.BulkResponse
+	err = json.Unmarshal(rs, &r)
+	if err != nil {
+		return nil, err
+	}
+	l.Dbg("ok")
+	return r, nil
+}
+
 func (s *Sdk) GetEvse(ctx context.Context, locId, evseId string) (*backend.Evse, error) {
 	service.L().C(ctx).Mth("get-evse").Dbg()
 
@@ -98,3 +98,110 @@ func (s *Sdk) UpdateConnectionPlatform(ctx context.Context, platformId string) (
 	l.Dbg("ok")
 	return p, nil
 }
+
+func (s *Sdk) DisconnectPlatform(ctx context.Context, platformId, dataPolicy string) (*backend.Platform, error) {
+	l := service.L().C(ctx).Mth("disconnect-platform").F(kit.KV{"platformId": platformId}).Dbg()
+
+	rs, err := s.DELETE(ctx, fmt.Sprintf("%s/platforms/%s/connections?dataPolicy=%s", s.baseUrl, platformId, dataPolicy), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.Platform
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	l.Dbg("ok")
+	return p, nil
+}
+
+func (s *Sdk) RotatePlatformToken(ctx context.Context, platformId string) (*backend.Platform, error) {
+	l := service.L().C(ctx).Mth("rotate-platform-token").F(kit.KV{"platformId": platformId}).Dbg()
+
+	rs, err := s.POST(ctx, fmt.Sprintf("%s/platforms/%s/token-rotations", s.baseUrl, platformId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.Platform
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	l.Dbg("ok")
+	return p, nil
+}
+
+func (s *Sdk) GetPlatformTokenRotations(ctx context.Context, platformId string) ([]*backend.TokenRotation, error) {
+	l := service.L().C(ctx).Mth("get-platform-token-rotations").F(kit.KV{"platformId": platformId}).Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/platforms/%s/token-rotations", s.baseUrl, platformId))
+	if err != nil {
+		return nil, err
+	}
+
+	var r []*backend.TokenRotation
+	err = json.Unmarshal(rs, &r)
+	if err != nil {
+		return nil, err
+	}
+	l.Dbg("ok")
+	return r, nil
+}
+
+func (s *Sdk) GetOnboardingTokens(ctx context.Context, status string) ([]*backend.OnboardingToken, error) {
+	l := service.L().C(ctx).Mth("get-onboarding-tokens").Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/platforms/onboarding-tokens?status=%s", s.baseUrl, status))
+	if err != nil {
+		return nil, err
+	}
+
+	var r []*backend.OnboardingToken
+	err = json.Unmarshal(rs, &r)
+	if err != nil {
+		return nil, err
+	}
+	l.Dbg("ok")
+	return r, nil
+}
+
+func (s *Sdk) IssueOnboardingToken(ctx context.Context, platformId string, rq *backend.OnboardingTokenRequest) (*backend.OnboardingToken, error) {
+	l := service.L().C(ctx).Mth("issue-onboarding-token").F(kit.KV{"platformId": platformId}).Dbg()
+
+	rqJs, err := json.Marshal(rq)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := s.POST(ctx, fmt.Sprintf("%s/platforms/%s/onboarding-tokens", s.baseUrl, platformId), rqJs)
+	if err != nil {
+		return nil, err
+	}
+
+	var r *backend.OnboardingToken
+	err = json.Unmarshal(rs, &r)
+	if err != nil {
+		return nil, err
+	}
+	l.Dbg("ok")
+	return r, nil
+}
+
+func (s *Sdk) RevokeOnboardingToken(ctx context.Context, platformId string) (*backend.OnboardingToken, error) {
+	l := service.L().C(ctx).Mth("revoke-onboarding-token").F(kit.KV{"platformId": platformId}).Dbg()
+
+	rs, err := s.DELETE(ctx, fmt.Sprintf("%s/platforms/%s/onboarding-tokens", s.baseUrl, platformId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var r *backend.OnboardingToken
+	err = json.Unmarshal(rs, &r)
+	if err != nil {
+		return nil, err
+	}
+	l.Dbg("ok")
+	return r, nil
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	service "github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+)
+
+func (s *Sdk) GetReservation(ctx context.Context, resId string) (*backend.ChargeReservation, error) {
+	service.L().C(ctx).Mth("get-res").Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/backend/reservations/%s", s.baseUrl, resId))
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.ChargeReservation
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Sdk) SearchReservations(ctx context.Context, params map[string]interface{}) (*backend.ReservationSearchResponse, error) {
+	service.L().C(ctx).Mth("search-res").Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/backend/reservations/search/query%s", s.baseUrl, s.toUrlParams(params)))
+	if err != nil {
+		return nil, err
+	}
+
+	var p *backend.ReservationSearchResponse
+	err = json.Unmarshal(rs, &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -71,3 +71,19 @@ func (s *Sdk) SearchSessions(ctx context.Context, params map[string]interface{})
 	}
 	return p, nil
 }
+
+func (s *Sdk) GetSessionIssues(ctx context.Context) ([]*backend.SessionIssueReport, error) {
+	service.L().C(ctx).Mth("get-sess-issues").Dbg()
+
+	rs, err := s.GET(ctx, fmt.Sprintf("%s/backend/sessions/issues/report", s.baseUrl))
+	if err != nil {
+		return nil, err
+	}
+
+	var r []*backend.SessionIssueReport
+	err = json.Unmarshal(rs, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
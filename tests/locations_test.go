@@ -110,6 +110,44 @@ func (s *locationsTestSuite) Test_Location_PutLocEvseConnectorBySeparateCallsInL
 
 }
 
+func (s *locationsTestSuite) Test_Location_BulkInLocal_PushedToEmulator() {
+
+	// create and send locations in local system by a single request
+	party := party()
+	locs := []*backend.Location{location(party.PartyId), location(party.PartyId)}
+	rs, err := s.localSdk.PutLocations(s.Ctx, &backend.LocationBulkRequest{Items: locs})
+	s.NoError(err)
+	s.Len(rs.Items, len(locs))
+	for _, item := range rs.Items {
+		s.True(item.Applied)
+	}
+
+	// await
+	for _, loc := range locs {
+		s.NotEmpty(s.awaitLocation(s.emulatorSdk, loc.Id, nil))
+	}
+
+	// update statuses by a single request
+	rq := &backend.EvseStatusBulkRequest{}
+	for _, loc := range locs {
+		rq.Items = append(rq.Items, &backend.EvseStatus{LocationId: loc.Id, EvseId: loc.Evses[0].Id, Status: "CHARGING"})
+	}
+	rq.Items = append(rq.Items, &backend.EvseStatus{LocationId: locs[0].Id, EvseId: kit.NewId(), Status: "CHARGING"})
+	rs, err = s.localSdk.SetEvseStatuses(s.Ctx, rq)
+	s.NoError(err)
+	s.Len(rs.Items, len(rq.Items))
+	s.True(rs.Items[0].Applied)
+	s.True(rs.Items[1].Applied)
+	s.NotEmpty(rs.Items[2].Error)
+
+	// await
+	for _, loc := range locs {
+		s.NotEmpty(s.awaitLocation(s.emulatorSdk, loc.Id, func(location *backend.Location) bool {
+			return len(location.Evses) > 0 && location.Evses[0].Status == "CHARGING"
+		}))
+	}
+}
+
 func (s *locationsTestSuite) Test_Location_PutLocLocal_Webhook() {
 
 	var receivedEvent string
@@ -15,6 +15,8 @@ type Controller interface {
 	PostCdr(http.ResponseWriter, *http.Request)
 	GetCdr(http.ResponseWriter, *http.Request)
 	SearchCdrs(http.ResponseWriter, *http.Request)
+	GetCdrIssues(http.ResponseWriter, *http.Request)
+	CreditCdr(http.ResponseWriter, *http.Request)
 }
 
 type ctrlImpl struct {
@@ -93,6 +95,7 @@ func (c *ctrlImpl) GetCdr(w http.ResponseWriter, r *http.Request) {
 // @Summary retrieves cdr objects by criteria
 // @Accept json
 // @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
 // @Param limit query string false "number of items to retrieve"
 // @Param dateFrom query string false "items updated after the given date"
 // @Param dateTo query string false "items updated before the given date"
@@ -118,6 +121,15 @@ func (c *ctrlImpl) SearchCdrs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
 	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
 	if err != nil {
 		c.RespondError(w, err)
@@ -195,9 +207,62 @@ func (c *ctrlImpl) SearchCdrs(w http.ResponseWriter, r *http.Request) {
 
 	c.RespondOK(w, &backend.CdrSearchResponse{
 		PageInfo: &backend.PageResponse{
-			Total: rs.Total,
-			Limit: rs.Limit,
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
 		},
 		Items: c.converter.CdrsDomainToBackend(rs.Items),
 	})
 }
+
+// GetCdrIssues godoc
+// @Summary retrieves discrepancies of CDRs received from remote platforms grouped by partner
+// @Accept json
+// @Success 200 {array} backend.CdrIssueReport
+// @Failure 500 {object} http.Error
+// @Router /backend/cdrs/issues/report [get]
+// @tags cdrs
+func (c *ctrlImpl) GetCdrIssues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rs, err := c.cdrUc.GetCdrIssues(ctx)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.CdrIssueReportsDomainToBackend(rs))
+}
+
+// CreditCdr godoc
+// @Summary issues a credit cdr against the local cdr and pushes it to the eMSP
+// @Accept json
+// @Param cdrId path string true "OCPI cdr ID to be credited"
+// @Param request body backend.CdrCreditRequest true "credit request, the whole remaining amount is credited if amount isn't specified"
+// @Success 200 {object} backend.Cdr
+// @Failure 500 {object} http.Error
+// @Router /backend/cdrs/{cdrId}/credit [post]
+// @tags cdrs
+func (c *ctrlImpl) CreditCdr(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cdrId, err := c.Var(ctx, r, "cdrId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rq, err := kitHttp.DecodeRequest[backend.CdrCreditRequest](ctx, r)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	credit, err := c.cdrUc.CreditCdr(ctx, cdrId, c.converter.CdrCreditBackendToDomain(rq))
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.CdrDomainToBackend(credit))
+}
@@ -8,6 +8,8 @@ func GetRoutes(c Controller) []*http.Route {
 	return []*http.Route{
 		http.R("/backend/cdrs", c.PostCdr).POST().ApiKey(),
 		http.R("/backend/cdrs/{cdrId}", c.GetCdr).GET().ApiKey(),
+		http.R("/backend/cdrs/{cdrId}/credit", c.CreditCdr).POST().ApiKey(),
 		http.R("/backend/cdrs/search/query", c.SearchCdrs).GET().ApiKey(),
+		http.R("/backend/cdrs/issues/report", c.GetCdrIssues).GET().ApiKey(),
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/usecase"
 	"net/http"
+	"time"
 )
 
 type Controller interface {
@@ -44,7 +45,8 @@ func NewController(cmdUc usecase.CommandUc, converter usecase.CommandConverter,
 // @Summary sends "START" command to the remote platform
 // @Accept json
 // @Param request body backend.StartSessionRequest true "start command request"
-// @Success 200
+// @Param wait query string false "seconds to wait for the command result, empty response is returned immediately if not specified"
+// @Success 200 {object} backend.Command
 // @Failure 500 {object} http.Error
 // @Router /backend/commands/sessions/start [post]
 // @tags commands
@@ -57,20 +59,28 @@ func (c *ctrlImpl) StartSession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.cmdUc.OnLocalStartSession(ctx, c.converter.StartSessionCommandBackendToDomain(rq, c.localPlatform.GetPlatformId(ctx)))
+	wait, err := c.FormValInt(ctx, r, "wait", true)
 	if err != nil {
 		c.RespondError(w, err)
 		return
 	}
 
-	c.RespondOK(w, kitHttp.EmptyOkResponse)
+	cmd := c.converter.StartSessionCommandBackendToDomain(rq, c.localPlatform.GetPlatformId(ctx))
+	err = c.cmdUc.OnLocalStartSession(ctx, cmd)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.respondCommand(w, r, cmd.Id, wait)
 }
 
 // StopSession godoc
 // @Summary sends "STOP" command to the remote platform
 // @Accept json
 // @Param request body backend.StopSessionRequest true "stop command request"
-// @Success 200
+// @Param wait query string false "seconds to wait for the command result, empty response is returned immediately if not specified"
+// @Success 200 {object} backend.Command
 // @Failure 500 {object} http.Error
 // @Router /backend/commands/sessions/stop [post]
 // @tags commands
@@ -83,20 +93,28 @@ func (c *ctrlImpl) StopSession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.cmdUc.OnLocalStopSession(ctx, c.converter.StopSessionCommandBackendToDomain(rq, c.localPlatform.GetPlatformId(ctx)))
+	wait, err := c.FormValInt(ctx, r, "wait", true)
 	if err != nil {
 		c.RespondError(w, err)
 		return
 	}
 
-	c.RespondOK(w, kitHttp.EmptyOkResponse)
+	cmd := c.converter.StopSessionCommandBackendToDomain(rq, c.localPlatform.GetPlatformId(ctx))
+	err = c.cmdUc.OnLocalStopSession(ctx, cmd)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.respondCommand(w, r, cmd.Id, wait)
 }
 
 // Reservation godoc
 // @Summary sends "RESERVE_NOW" command to the remote platform
 // @Accept json
 // @Param request body backend.ReserveNowRequest true "reservation request"
-// @Success 200
+// @Param wait query string false "seconds to wait for the command result, empty response is returned immediately if not specified"
+// @Success 200 {object} backend.Command
 // @Failure 500 {object} http.Error
 // @Router /backend/commands/reservations [post]
 // @tags commands
@@ -109,13 +127,20 @@ func (c *ctrlImpl) Reservation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.cmdUc.OnLocalReserve(ctx, c.converter.ReserveNowCommandBackendToDomain(rq, c.localPlatform.GetPlatformId(ctx)))
+	wait, err := c.FormValInt(ctx, r, "wait", true)
 	if err != nil {
 		c.RespondError(w, err)
 		return
 	}
 
-	c.RespondOK(w, kitHttp.EmptyOkResponse)
+	cmd := c.converter.ReserveNowCommandBackendToDomain(rq, c.localPlatform.GetPlatformId(ctx))
+	err = c.cmdUc.OnLocalReserve(ctx, cmd)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.respondCommand(w, r, cmd.Id, wait)
 }
 
 // CancelReservation godoc
@@ -123,7 +148,8 @@ func (c *ctrlImpl) Reservation(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Param resId path string true "reservation ID to cancel"
 // @Param request body backend.CancelReservationRequest true "reservation cancellation request"
-// @Success 200
+// @Param wait query string false "seconds to wait for the command result, empty response is returned immediately if not specified"
+// @Success 200 {object} backend.Command
 // @Failure 500 {object} http.Error
 // @Router /backend/commands/reservations [delete]
 // @tags commands
@@ -136,13 +162,20 @@ func (c *ctrlImpl) CancelReservation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.cmdUc.OnLocalCancelReservation(ctx, c.converter.CancelReservationCommandBackendToDomain(rq, c.localPlatform.GetPlatformId(ctx)))
+	wait, err := c.FormValInt(ctx, r, "wait", true)
 	if err != nil {
 		c.RespondError(w, err)
 		return
 	}
 
-	c.RespondOK(w, kitHttp.EmptyOkResponse)
+	cmd := c.converter.CancelReservationCommandBackendToDomain(rq, c.localPlatform.GetPlatformId(ctx))
+	err = c.cmdUc.OnLocalCancelReservation(ctx, cmd)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.respondCommand(w, r, cmd.Id, wait)
 }
 
 func (c *ctrlImpl) UnLockConnector(writer http.ResponseWriter, request *http.Request) {
@@ -187,6 +220,7 @@ func (c *ctrlImpl) PutCommandResponse(w http.ResponseWriter, r *http.Request) {
 // @Summary retrieves a command object by id
 // @Accept json
 // @Param cmdId path string true "OCPI command ID"
+// @Param wait query string false "seconds to wait for the command result if the command is still processed"
 // @Success 200 {object} backend.Command
 // @Failure 500 {object} http.Error
 // @Router /backend/commands/{cmdId} [get]
@@ -200,6 +234,17 @@ func (c *ctrlImpl) GetCommand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	wait, err := c.FormValInt(ctx, r, "wait", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	if wait != nil && *wait > 0 {
+		c.respondCommand(w, r, cmdId, wait)
+		return
+	}
+
 	cmd, err := c.cmdService.Get(ctx, cmdId)
 	if err != nil {
 		c.RespondError(w, err)
@@ -209,6 +254,24 @@ func (c *ctrlImpl) GetCommand(w http.ResponseWriter, r *http.Request) {
 	c.RespondOK(w, c.converter.CommandDomainToBackend(cmd))
 }
 
+// respondCommand responds with the command once its result is received if waiting requested, otherwise responds immediately
+func (c *ctrlImpl) respondCommand(w http.ResponseWriter, r *http.Request, cmdId string, wait *int) {
+	ctx := r.Context()
+
+	if wait == nil || *wait <= 0 {
+		c.RespondOK(w, kitHttp.EmptyOkResponse)
+		return
+	}
+
+	cmd, err := c.cmdUc.WaitCommandResult(ctx, cmdId, time.Duration(*wait)*time.Second)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.CommandDomainToBackend(cmd))
+}
+
 // SearchCommands godoc
 // @Summary retrieves commands objects by criteria
 // @Accept json
@@ -0,0 +1,315 @@
+package disputes
+
+import (
+	kitHttp "github.com/mikhailbolshakov/kit/http"
+	service "github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"net/http"
+	"strings"
+)
+
+type Controller interface {
+	kitHttp.Controller
+	OpenDispute(http.ResponseWriter, *http.Request)
+	GetDispute(http.ResponseWriter, *http.Request)
+	AddEvidence(http.ResponseWriter, *http.Request)
+	SetStatus(http.ResponseWriter, *http.Request)
+	SearchDisputes(http.ResponseWriter, *http.Request)
+	GetDisputeSummary(http.ResponseWriter, *http.Request)
+}
+
+type ctrlImpl struct {
+	kitHttp.BaseController
+	disputeUc      usecase.DisputeUc
+	converter      usecase.DisputeConverter
+	disputeService domain.DisputeService
+}
+
+func NewController(disputeUc usecase.DisputeUc, converter usecase.DisputeConverter, disputeService domain.DisputeService) Controller {
+	return &ctrlImpl{
+		BaseController: kitHttp.BaseController{Logger: service.LF()},
+		disputeUc:      disputeUc,
+		converter:      converter,
+		disputeService: disputeService,
+	}
+}
+
+// OpenDispute godoc
+// @Summary opens a dispute against CDR received from a remote platform
+// @Accept json
+// @Param request body backend.Dispute true "dispute object"
+// @Success 200 {object} backend.Dispute
+// @Failure 500 {object} http.Error
+// @Router /backend/disputes [post]
+// @tags disputes
+func (c *ctrlImpl) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rq, err := kitHttp.DecodeRequest[backend.Dispute](ctx, r)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	d, err := c.disputeUc.OpenDispute(ctx, c.converter.DisputeBackendToDomain(rq))
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.DisputeDomainToBackend(d))
+}
+
+// GetDispute godoc
+// @Summary retrieves a dispute object by id
+// @Accept json
+// @Param dispId path string true "dispute ID"
+// @Success 200 {object} backend.Dispute
+// @Failure 500 {object} http.Error
+// @Router /backend/disputes/{dispId} [get]
+// @tags disputes
+func (c *ctrlImpl) GetDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dispId, err := c.Var(ctx, r, "dispId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	d, err := c.disputeService.Get(ctx, dispId)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.DisputeDomainToBackend(d))
+}
+
+// AddEvidence godoc
+// @Summary attaches evidence to the dispute
+// @Accept json
+// @Param dispId path string true "dispute ID"
+// @Param request body backend.DisputeEvidence true "evidence object"
+// @Success 200 {object} backend.Dispute
+// @Failure 500 {object} http.Error
+// @Router /backend/disputes/{dispId}/evidence [post]
+// @tags disputes
+func (c *ctrlImpl) AddEvidence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dispId, err := c.Var(ctx, r, "dispId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rq, err := kitHttp.DecodeRequest[backend.DisputeEvidence](ctx, r)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	d, err := c.disputeUc.AddEvidence(ctx, dispId, c.converter.DisputeEvidenceBackendToDomain(rq))
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.DisputeDomainToBackend(d))
+}
+
+// SetStatus godoc
+// @Summary changes status of the dispute
+// @Accept json
+// @Param dispId path string true "dispute ID"
+// @Param request body backend.DisputeStatusRequest true "status request"
+// @Success 200 {object} backend.Dispute
+// @Failure 500 {object} http.Error
+// @Router /backend/disputes/{dispId}/status [put]
+// @tags disputes
+func (c *ctrlImpl) SetStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	dispId, err := c.Var(ctx, r, "dispId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rq, err := kitHttp.DecodeRequest[backend.DisputeStatusRequest](ctx, r)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	d, err := c.disputeUc.SetStatus(ctx, dispId, rq.Status, rq.Comment, rq.CreditCdrId)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.DisputeDomainToBackend(d))
+}
+
+// SearchDisputes godoc
+// @Summary retrieves dispute objects by criteria
+// @Accept json
+// @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
+// @Param limit query string false "number of items to retrieve"
+// @Param dateFrom query string false "items updated after the given date"
+// @Param dateTo query string false "items updated before the given date"
+// @Param refId query string false "reference id"
+// @Param partyId query string false "OCPI party id"
+// @Param countryCode query string false "OCPI country code"
+// @Param incPlatforms query string false "comma separated platforms to include"
+// @Param excPlatforms query string false "comma separated platforms to exclude"
+// @Param ids query string false "comma separated list of ids"
+// @Param statuses query string false "comma separated list of statuses"
+// @Param cdrId query string false "disputed cdr id"
+// @Success 200 {object} backend.DisputeSearchResponse
+// @Failure 500 {object} http.Error
+// @Router /backend/disputes/search/query [get]
+// @tags disputes
+func (c *ctrlImpl) SearchDisputes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+	cr := &domain.DisputeSearchCriteria{}
+
+	cr.Offset, err = c.FormValInt(ctx, r, "offset", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
+	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	cr.DateFrom, err = c.FormValTime(ctx, r, "dateFrom", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	cr.DateTo, err = c.FormValTime(ctx, r, "dateTo", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	cr.RefId, err = c.FormVal(ctx, r, "refId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	partyId, err := c.FormVal(ctx, r, "partyId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	countryCode, err := c.FormVal(ctx, r, "countryCode", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	if partyId != "" && countryCode != "" {
+		cr.ExtId = &domain.PartyExtId{
+			PartyId:     partyId,
+			CountryCode: countryCode,
+		}
+	}
+
+	incPlatforms, err := c.FormVal(ctx, r, "incPlatforms", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if incPlatforms != "" {
+		cr.IncPlatforms = strings.Split(incPlatforms, ",")
+	}
+
+	excPlatforms, err := c.FormVal(ctx, r, "excPlatforms", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if excPlatforms != "" {
+		cr.ExcPlatforms = strings.Split(excPlatforms, ",")
+	}
+
+	ids, err := c.FormVal(ctx, r, "ids", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if ids != "" {
+		cr.Ids = strings.Split(ids, ",")
+	}
+
+	statuses, err := c.FormVal(ctx, r, "statuses", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if statuses != "" {
+		cr.Statuses = strings.Split(statuses, ",")
+	}
+
+	cr.CdrId, err = c.FormVal(ctx, r, "cdrId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rs, err := c.disputeService.Search(ctx, cr)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, &backend.DisputeSearchResponse{
+		PageInfo: &backend.PageResponse{
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
+		},
+		Items: c.converter.DisputesDomainToBackend(rs.Items),
+	})
+}
+
+// GetDisputeSummary godoc
+// @Summary retrieves disputes grouped by partner
+// @Accept json
+// @Success 200 {array} backend.DisputeSummary
+// @Failure 500 {object} http.Error
+// @Router /backend/disputes/summary/report [get]
+// @tags disputes
+func (c *ctrlImpl) GetDisputeSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rs, err := c.disputeUc.GetDisputeSummary(ctx)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.DisputeSummariesDomainToBackend(rs))
+}
@@ -0,0 +1,16 @@
+package disputes
+
+import (
+	"github.com/mikhailbolshakov/ocpi/transport/http"
+)
+
+func GetRoutes(c Controller) []*http.Route {
+	return []*http.Route{
+		http.R("/backend/disputes", c.OpenDispute).POST().ApiKey(),
+		http.R("/backend/disputes/{dispId}", c.GetDispute).GET().ApiKey(),
+		http.R("/backend/disputes/{dispId}/evidence", c.AddEvidence).POST().ApiKey(),
+		http.R("/backend/disputes/{dispId}/status", c.SetStatus).PUT().ApiKey(),
+		http.R("/backend/disputes/search/query", c.SearchDisputes).GET().ApiKey(),
+		http.R("/backend/disputes/summary/report", c.GetDisputeSummary).GET().ApiKey(),
+	}
+}
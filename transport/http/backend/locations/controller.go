@@ -13,11 +13,13 @@ import (
 type Controller interface {
 	kitHttp.Controller
 	PutLocation(http.ResponseWriter, *http.Request)
+	PutLocations(http.ResponseWriter, *http.Request)
 	PullLocations(http.ResponseWriter, *http.Request)
 	GetLocation(http.ResponseWriter, *http.Request)
 	SearchLocations(http.ResponseWriter, *http.Request)
 	PutEvse(http.ResponseWriter, *http.Request)
 	SetEvseStatus(http.ResponseWriter, *http.Request)
+	SetEvseStatuses(http.ResponseWriter, *http.Request)
 	GetEvse(http.ResponseWriter, *http.Request)
 	SearchEvses(http.ResponseWriter, *http.Request)
 	PutConnector(http.ResponseWriter, *http.Request)
@@ -70,6 +72,32 @@ func (c *ctrlImpl) PutLocation(w http.ResponseWriter, r *http.Request) {
 	c.RespondOK(w, kitHttp.EmptyOkResponse)
 }
 
+// PutLocations godoc
+// @Summary updates batch of location objects in OCPI. Locations may contain evses and connectors as well
+// @Accept json
+// @Param request body backend.LocationBulkRequest true "locations"
+// @Success 200 {object} backend.BulkResponse
+// @Failure 500 {object} http.Error
+// @Router /backend/locations/bulk [post]
+// @tags locations
+func (c *ctrlImpl) PutLocations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rq, err := kitHttp.DecodeRequest[backend.LocationBulkRequest](ctx, r)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rs, err := c.locationUc.OnLocalLocationsChanged(ctx, c.converter.LocationsBackendToDomain(rq.Items, c.localPlatform.GetPlatformId(ctx)))
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.BulkResultsDomainToBackend(rs))
+}
+
 // PullLocations godoc
 // @Summary triggers pulling locations from the remote platforms
 // @Accept json
@@ -126,6 +154,7 @@ func (c *ctrlImpl) GetLocation(w http.ResponseWriter, r *http.Request) {
 // @Summary retrieves location objects by criteria
 // @Accept json
 // @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
 // @Param limit query string false "number of items to retrieve"
 // @Param dateFrom query string false "items updated after the given date"
 // @Param dateTo query string false "items updated before the given date"
@@ -149,6 +178,15 @@ func (c *ctrlImpl) SearchLocations(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
 	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
 	if err != nil {
 		c.RespondError(w, err)
@@ -227,8 +265,9 @@ func (c *ctrlImpl) SearchLocations(w http.ResponseWriter, r *http.Request) {
 
 	c.RespondOK(w, &backend.LocationSearchResponse{
 		PageInfo: &backend.PageResponse{
-			Total: rs.Total,
-			Limit: rs.Limit,
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
 		},
 		Items: c.converter.LocationsDomainToBackend(rs.Items),
 	})
@@ -305,6 +344,32 @@ func (c *ctrlImpl) SetEvseStatus(w http.ResponseWriter, r *http.Request) {
 	c.RespondOK(w, kitHttp.EmptyOkResponse)
 }
 
+// SetEvseStatuses godoc
+// @Summary updates statuses of batch of evses in OCPI
+// @Accept json
+// @Param request body backend.EvseStatusBulkRequest true "evse statuses"
+// @Success 200 {object} backend.BulkResponse
+// @Failure 500 {object} http.Error
+// @Router /backend/evses/status/bulk [post]
+// @tags locations
+func (c *ctrlImpl) SetEvseStatuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rq, err := kitHttp.DecodeRequest[backend.EvseStatusBulkRequest](ctx, r)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rs, err := c.locationUc.OnLocalEvseStatusesChanged(ctx, c.converter.EvseStatusesBackendToDomain(rq.Items))
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.BulkResultsDomainToBackend(rs))
+}
+
 // GetEvse godoc
 // @Summary retrieves an evse object by id
 // @Accept json
@@ -342,6 +407,7 @@ func (c *ctrlImpl) GetEvse(w http.ResponseWriter, r *http.Request) {
 // @Summary retrieves evse objects by criteria
 // @Accept json
 // @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
 // @Param limit query string false "number of items to retrieve"
 // @Param dateFrom query string false "items updated after the given date"
 // @Param dateTo query string false "items updated before the given date"
@@ -361,6 +427,15 @@ func (c *ctrlImpl) SearchEvses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
 	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
 	if err != nil {
 		c.RespondError(w, err)
@@ -409,8 +484,9 @@ func (c *ctrlImpl) SearchEvses(w http.ResponseWriter, r *http.Request) {
 
 	c.RespondOK(w, &backend.EvseSearchResponse{
 		PageInfo: &backend.PageResponse{
-			Total: rs.Total,
-			Limit: rs.Limit,
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
 		},
 		Items: c.converter.EvsesDomainToBackend(rs.Items),
 	})
@@ -499,6 +575,7 @@ func (c *ctrlImpl) GetConnector(w http.ResponseWriter, r *http.Request) {
 // @Summary retrieves connector objects by criteria
 // @Accept json
 // @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
 // @Param limit query string false "number of items to retrieve"
 // @Param dateFrom query string false "items updated after the given date"
 // @Param dateTo query string false "items updated before the given date"
@@ -518,6 +595,15 @@ func (c *ctrlImpl) SearchConnectors(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
 	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
 	if err != nil {
 		c.RespondError(w, err)
@@ -568,8 +654,9 @@ func (c *ctrlImpl) SearchConnectors(w http.ResponseWriter, r *http.Request) {
 
 	c.RespondOK(w, &backend.ConnectorSearchResponse{
 		PageInfo: &backend.PageResponse{
-			Total: rs.Total,
-			Limit: rs.Limit,
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
 		},
 		Items: c.converter.ConnectorsDomainToBackend(rs.Items),
 	})
@@ -21,7 +21,7 @@ func (c *ctrlImpl) toLogMessage(m *domain.LogMessage, short *bool) *LogMessage {
 		r.RequestBody = m.RequestBody
 		r.ResponseBody = m.ResponseBody
 		r.Headers = m.Headers
-		r.Token = m.Token[:5] + "****"
+		r.Token = m.Token
 	}
 	if m.Err != nil {
 		r.Err = m.Err.Error()
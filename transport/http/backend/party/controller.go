@@ -95,6 +95,7 @@ func (c *ctrlImpl) GetParty(w http.ResponseWriter, r *http.Request) {
 // @Summary retrieves parties objects by criteria
 // @Accept json
 // @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
 // @Param limit query string false "number of items to retrieve"
 // @Param refId query string false "party reference id"
 // @Param dateFrom query string false "items updated after the given date"
@@ -120,6 +121,15 @@ func (c *ctrlImpl) SearchParties(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
 	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
 	if err != nil {
 		c.RespondError(w, err)
@@ -197,8 +207,9 @@ func (c *ctrlImpl) SearchParties(w http.ResponseWriter, r *http.Request) {
 
 	c.RespondOK(w, &backend.PartySearchResponse{
 		PageInfo: &backend.PageResponse{
-			Total: rs.Total,
-			Limit: rs.Limit,
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
 		},
 		Items: c.credentialsConverter.PartiesDomainToBackend(rs.Items),
 	})
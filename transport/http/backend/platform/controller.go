@@ -16,7 +16,13 @@ type Controller interface {
 	GetPlatform(http.ResponseWriter, *http.Request)
 	EstablishConnection(http.ResponseWriter, *http.Request)
 	UpdateConnection(http.ResponseWriter, *http.Request)
+	DisconnectPlatform(http.ResponseWriter, *http.Request)
+	RotateToken(http.ResponseWriter, *http.Request)
+	GetTokenRotations(http.ResponseWriter, *http.Request)
 	GenToken(http.ResponseWriter, *http.Request)
+	GetOnboardingTokens(http.ResponseWriter, *http.Request)
+	IssueOnboardingToken(http.ResponseWriter, *http.Request)
+	RevokeOnboardingToken(http.ResponseWriter, *http.Request)
 }
 
 type ctrlImpl struct {
@@ -113,7 +119,7 @@ func (c *ctrlImpl) GetPlatform(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	platform, err := c.platformService.Get(ctx, platformId)
+	platform, err := c.credentialsUc.GetPlatform(ctx, platformId)
 	if err != nil {
 		c.RespondError(w, err)
 		return
@@ -174,6 +180,91 @@ func (c *ctrlImpl) UpdateConnection(w http.ResponseWriter, r *http.Request) {
 	c.RespondOK(w, c.converter.PlatformDomainToBackend(platform))
 }
 
+// DisconnectPlatform godoc
+// @Summary deletes credentials on the remote platform and tears down connection, repeat on the suspended platform to retry failed data cleanup
+// @Param platformId path string true "platform ID to disconnect"
+// @Param dataPolicy query string false "what to do with platform's data: keep (default), archive, purge"
+// @Accept json
+// @Success 200 {object} backend.Platform
+// @Failure 500 {object} http.Error
+// @Router /platforms/{platformId}/connections [delete]
+// @tags platform
+func (c *ctrlImpl) DisconnectPlatform(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	platformId, err := c.Var(ctx, r, "platformId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	dataPolicy, err := c.FormVal(ctx, r, "dataPolicy", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	platform, err := c.credentialsUc.DisconnectPlatform(ctx, platformId, dataPolicy)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.PlatformDomainToBackend(platform))
+}
+
+// RotateToken godoc
+// @Summary rotates credentials tokens of the connected platform
+// @Param platformId path string true "platform ID"
+// @Accept json
+// @Success 200 {object} backend.Platform
+// @Failure 500 {object} http.Error
+// @Router /platforms/{platformId}/token-rotations [post]
+// @tags platform
+func (c *ctrlImpl) RotateToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	platformId, err := c.Var(ctx, r, "platformId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	platform, err := c.credentialsUc.RotateToken(ctx, platformId)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.PlatformDomainToBackend(platform))
+}
+
+// GetTokenRotations godoc
+// @Summary retrieves token rotation history of the platform
+// @Param platformId path string true "platform ID"
+// @Accept json
+// @Success 200 {array} backend.TokenRotation
+// @Failure 500 {object} http.Error
+// @Router /platforms/{platformId}/token-rotations [get]
+// @tags platform
+func (c *ctrlImpl) GetTokenRotations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	platformId, err := c.Var(ctx, r, "platformId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rotations, err := c.platformService.GetTokenRotations(ctx, platformId)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.TokenRotationsDomainToBackend(rotations))
+}
+
 // GenToken godoc
 // @Summary generates a new platform token
 // @Accept json
@@ -192,3 +283,101 @@ func (c *ctrlImpl) GenToken(w http.ResponseWriter, r *http.Request) {
 
 	c.RespondOK(w, token)
 }
+
+// GetOnboardingTokens godoc
+// @Summary retrieves onboarding tokens (tokens A) issued for platforms
+// @Param status query string false "filter by status: active, expired, consumed, revoked"
+// @Accept json
+// @Success 200 {array} backend.OnboardingToken
+// @Failure 500 {object} http.Error
+// @Router /platforms/onboarding-tokens [get]
+// @tags platform
+func (c *ctrlImpl) GetOnboardingTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status, err := c.FormVal(ctx, r, "status", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	platforms, err := c.platformService.Search(ctx, &domain.PlatformSearchCriteria{Onboarding: true})
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rs := make([]*backend.OnboardingToken, 0, len(platforms))
+	for _, p := range platforms {
+		tokenStatus := c.platformService.OnboardingTokenStatus(p.Onboarding)
+		if status != "" && status != tokenStatus {
+			continue
+		}
+		rs = append(rs, c.converter.OnboardingTokenDomainToBackend(p, tokenStatus))
+	}
+
+	c.RespondOK(w, rs)
+}
+
+// IssueOnboardingToken godoc
+// @Summary issues a new onboarding token (token A) for the platform, the previous token A stops working
+// @Param platformId path string true "platform ID"
+// @Param request body backend.OnboardingTokenRequest true "onboarding token request"
+// @Accept json
+// @Success 200 {object} backend.OnboardingToken
+// @Failure 500 {object} http.Error
+// @Router /platforms/{platformId}/onboarding-tokens [post]
+// @tags platform
+func (c *ctrlImpl) IssueOnboardingToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	platformId, err := c.Var(ctx, r, "platformId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rq, err := kitHttp.DecodeRequest[backend.OnboardingTokenRequest](ctx, r)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	platform, err := c.platformService.IssueOnboardingToken(ctx, platformId, c.converter.OnboardingTokenBackendToDomain(rq))
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	// token value is returned only once when issued
+	rs := c.converter.OnboardingTokenDomainToBackend(platform, c.platformService.OnboardingTokenStatus(platform.Onboarding))
+	rs.Token = string(platform.TokenA)
+
+	c.RespondOK(w, rs)
+}
+
+// RevokeOnboardingToken godoc
+// @Summary revokes onboarding token (token A) of the platform
+// @Param platformId path string true "platform ID"
+// @Accept json
+// @Success 200 {object} backend.OnboardingToken
+// @Failure 500 {object} http.Error
+// @Router /platforms/{platformId}/onboarding-tokens [delete]
+// @tags platform
+func (c *ctrlImpl) RevokeOnboardingToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	platformId, err := c.Var(ctx, r, "platformId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	platform, err := c.platformService.RevokeOnboardingToken(ctx, platformId)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.OnboardingTokenDomainToBackend(platform, c.platformService.OnboardingTokenStatus(platform.Onboarding)))
+}
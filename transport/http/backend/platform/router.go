@@ -8,9 +8,15 @@ func GetRoutes(c Controller) []*http.Route {
 	return []*http.Route{
 		http.R("/platforms", c.PostPlatform).POST().ApiKey(),
 		http.R("/platforms/{platformId}/status", c.UpdatePlatformStatus).POST().ApiKey(),
+		http.R("/platforms/onboarding-tokens", c.GetOnboardingTokens).GET().ApiKey(),
 		http.R("/platforms/{platformId}", c.GetPlatform).GET().ApiKey(),
 		http.R("/platforms/{platformId}/connections", c.EstablishConnection).POST().ApiKey(),
 		http.R("/platforms/{platformId}/connections", c.UpdateConnection).PUT().ApiKey(),
+		http.R("/platforms/{platformId}/connections", c.DisconnectPlatform).DELETE().ApiKey(),
+		http.R("/platforms/{platformId}/token-rotations", c.RotateToken).POST().ApiKey(),
+		http.R("/platforms/{platformId}/token-rotations", c.GetTokenRotations).GET().ApiKey(),
 		http.R("/platforms/tokens/generate", c.GenToken).GET().ApiKey(),
+		http.R("/platforms/{platformId}/onboarding-tokens", c.IssueOnboardingToken).POST().ApiKey(),
+		http.R("/platforms/{platformId}/onboarding-tokens", c.RevokeOnboardingToken).DELETE().ApiKey(),
 	}
 }
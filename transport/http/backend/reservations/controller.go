@@ -0,0 +1,226 @@
+package reservations
+
+import (
+	kitHttp "github.com/mikhailbolshakov/kit/http"
+	service "github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"net/http"
+	"strings"
+)
+
+type Controller interface {
+	kitHttp.Controller
+	GetReservation(http.ResponseWriter, *http.Request)
+	SearchReservations(http.ResponseWriter, *http.Request)
+}
+
+type ctrlImpl struct {
+	kitHttp.BaseController
+	converter  usecase.ReservationConverter
+	resService domain.ReservationService
+}
+
+func NewController(converter usecase.ReservationConverter, resService domain.ReservationService) Controller {
+	return &ctrlImpl{
+		BaseController: kitHttp.BaseController{Logger: service.LF()},
+		converter:      converter,
+		resService:     resService,
+	}
+}
+
+// GetReservation godoc
+// @Summary retrieves a reservation object by id
+// @Accept json
+// @Param resId path string true "reservation ID"
+// @Success 200 {object} backend.ChargeReservation
+// @Failure 500 {object} http.Error
+// @Router /backend/reservations/{resId} [get]
+// @tags reservations
+func (c *ctrlImpl) GetReservation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resId, err := c.Var(ctx, r, "resId", false)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	res, err := c.resService.Get(ctx, resId)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.ReservationDomainToBackend(res))
+}
+
+// SearchReservations godoc
+// @Summary retrieves reservation objects by criteria
+// @Accept json
+// @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
+// @Param limit query string false "number of items to retrieve"
+// @Param dateFrom query string false "items updated after the given date"
+// @Param dateTo query string false "items updated before the given date"
+// @Param refId query string false "reference id"
+// @Param partyId query string false "OCPI party id"
+// @Param countryCode query string false "OCPI country code"
+// @Param incPlatforms query string false "comma separated platforms to include"
+// @Param excPlatforms query string false "comma separated platforms to exclude"
+// @Param ids query string false "comma separated list of ids"
+// @Param reservationId query string false "reservation id given by eMSP"
+// @Param statuses query string false "comma separated list of statuses"
+// @Param tokenId query string false "token id"
+// @Param locationId query string false "location id"
+// @Param evseId query string false "evse id"
+// @Param sessionId query string false "session id"
+// @Success 200 {object} backend.ReservationSearchResponse
+// @Failure 500 {object} http.Error
+// @Router /backend/reservations/search/query [get]
+// @tags reservations
+func (c *ctrlImpl) SearchReservations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+	cr := &domain.ReservationSearchCriteria{}
+
+	cr.Offset, err = c.FormValInt(ctx, r, "offset", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
+	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	cr.DateFrom, err = c.FormValTime(ctx, r, "dateFrom", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	cr.DateTo, err = c.FormValTime(ctx, r, "dateTo", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	cr.RefId, err = c.FormVal(ctx, r, "refId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	partyId, err := c.FormVal(ctx, r, "partyId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	countryCode, err := c.FormVal(ctx, r, "countryCode", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	if partyId != "" && countryCode != "" {
+		cr.ExtId = &domain.PartyExtId{
+			PartyId:     partyId,
+			CountryCode: countryCode,
+		}
+	}
+
+	incPlatforms, err := c.FormVal(ctx, r, "incPlatforms", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if incPlatforms != "" {
+		cr.IncPlatforms = strings.Split(incPlatforms, ",")
+	}
+
+	excPlatforms, err := c.FormVal(ctx, r, "excPlatforms", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if excPlatforms != "" {
+		cr.ExcPlatforms = strings.Split(excPlatforms, ",")
+	}
+
+	ids, err := c.FormVal(ctx, r, "ids", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if ids != "" {
+		cr.Ids = strings.Split(ids, ",")
+	}
+
+	cr.ReservationId, err = c.FormVal(ctx, r, "reservationId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	statuses, err := c.FormVal(ctx, r, "statuses", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if statuses != "" {
+		cr.Statuses = strings.Split(statuses, ",")
+	}
+
+	cr.TokenId, err = c.FormVal(ctx, r, "tokenId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	cr.LocationId, err = c.FormVal(ctx, r, "locationId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	cr.EvseId, err = c.FormVal(ctx, r, "evseId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	cr.SessionId, err = c.FormVal(ctx, r, "sessionId", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	rs, err := c.resService.Search(ctx, cr)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, &backend.ReservationSearchResponse{
+		PageInfo: &backend.PageResponse{
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
+		},
+		Items: c.converter.ReservationsDomainToBackend(rs.Items),
+	})
+}
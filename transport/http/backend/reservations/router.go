@@ -0,0 +1,12 @@
+package reservations
+
+import (
+	"github.com/mikhailbolshakov/ocpi/transport/http"
+)
+
+func GetRoutes(c Controller) []*http.Route {
+	return []*http.Route{
+		http.R("/backend/reservations/{resId}", c.GetReservation).GET().ApiKey(),
+		http.R("/backend/reservations/search/query", c.SearchReservations).GET().ApiKey(),
+	}
+}
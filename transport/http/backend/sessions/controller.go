@@ -17,6 +17,7 @@ type Controller interface {
 	PatchSession(http.ResponseWriter, *http.Request)
 	GetSession(http.ResponseWriter, *http.Request)
 	SearchSessions(http.ResponseWriter, *http.Request)
+	GetSessionIssues(http.ResponseWriter, *http.Request)
 }
 
 type ctrlImpl struct {
@@ -135,6 +136,7 @@ func (c *ctrlImpl) GetSession(w http.ResponseWriter, r *http.Request) {
 // @Summary retrieves session objects by criteria
 // @Accept json
 // @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
 // @Param limit query string false "number of items to retrieve"
 // @Param dateFrom query string false "items updated after the given date"
 // @Param dateTo query string false "items updated before the given date"
@@ -162,6 +164,15 @@ func (c *ctrlImpl) SearchSessions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
 	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
 	if err != nil {
 		c.RespondError(w, err)
@@ -254,9 +265,29 @@ func (c *ctrlImpl) SearchSessions(w http.ResponseWriter, r *http.Request) {
 
 	c.RespondOK(w, &backend.SessionSearchResponse{
 		PageInfo: &backend.PageResponse{
-			Total: rs.Total,
-			Limit: rs.Limit,
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
 		},
 		Items: c.converter.SessionsDomainToBackend(rs.Items),
 	})
 }
+
+// GetSessionIssues godoc
+// @Summary retrieves stale sessions and sessions with missing CDR grouped by partner
+// @Accept json
+// @Success 200 {array} backend.SessionIssueReport
+// @Failure 500 {object} http.Error
+// @Router /backend/sessions/issues/report [get]
+// @tags sessions
+func (c *ctrlImpl) GetSessionIssues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rs, err := c.sessUc.GetSessionIssues(ctx)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+
+	c.RespondOK(w, c.converter.SessionIssueReportsDomainToBackend(rs))
+}
@@ -10,5 +10,6 @@ func GetRoutes(c Controller) []*http.Route {
 		http.R("/backend/sessions/{sessId}", c.PatchSession).PATCH().ApiKey(),
 		http.R("/backend/sessions/{sessId}", c.GetSession).GET().ApiKey(),
 		http.R("/backend/sessions/search/query", c.SearchSessions).GET().ApiKey(),
+		http.R("/backend/sessions/issues/report", c.GetSessionIssues).GET().ApiKey(),
 	}
 }
@@ -120,6 +120,7 @@ func (c *ctrlImpl) GetTariff(w http.ResponseWriter, r *http.Request) {
 // @Summary retrieves tariff objects by criteria
 // @Accept json
 // @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
 // @Param limit query string false "number of items to retrieve"
 // @Param dateFrom query string false "items updated after the given date"
 // @Param dateTo query string false "items updated before the given date"
@@ -143,6 +144,15 @@ func (c *ctrlImpl) SearchTariffs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
 	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
 	if err != nil {
 		c.RespondError(w, err)
@@ -226,8 +236,9 @@ func (c *ctrlImpl) SearchTariffs(w http.ResponseWriter, r *http.Request) {
 
 	c.RespondOK(w, &backend.TariffSearchResponse{
 		PageInfo: &backend.PageResponse{
-			Total: rs.Total,
-			Limit: rs.Limit,
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
 		},
 		Items: c.converter.TariffsDomainToBackend(rs.Items),
 	})
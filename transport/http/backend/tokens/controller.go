@@ -119,6 +119,7 @@ func (c *ctrlImpl) GetToken(w http.ResponseWriter, r *http.Request) {
 // @Summary retrieves token objects by criteria
 // @Accept json
 // @Param offset query string false "number of items to offset from the beginning"
+// @Param cursor query string false "cursor of the page returned with the previous page, offset is ignored if specified"
 // @Param limit query string false "number of items to retrieve"
 // @Param dateFrom query string false "items updated after the given date"
 // @Param dateTo query string false "items updated before the given date"
@@ -142,6 +143,15 @@ func (c *ctrlImpl) SearchTokens(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor, err := c.FormVal(ctx, r, "cursor", true)
+	if err != nil {
+		c.RespondError(w, err)
+		return
+	}
+	if cursor != "" {
+		cr.Cursor = &cursor
+	}
+
 	cr.Limit, err = c.FormValInt(ctx, r, "limit", true)
 	if err != nil {
 		c.RespondError(w, err)
@@ -200,8 +210,9 @@ func (c *ctrlImpl) SearchTokens(w http.ResponseWriter, r *http.Request) {
 
 	c.RespondOK(w, &backend.TokenSearchResponse{
 		PageInfo: &backend.PageResponse{
-			Total: rs.Total,
-			Limit: rs.Limit,
+			Total:  rs.Total,
+			Limit:  rs.Limit,
+			Cursor: rs.NextCursor(),
 		},
 		Items: c.converter.TokensDomainToBackend(rs.Items),
 	})
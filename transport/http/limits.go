@@ -0,0 +1,210 @@
+package http
+
+import (
+	"fmt"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	HeaderRateLimit          = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	HeaderQuotaLimit         = "X-Quota-Limit"
+	HeaderQuotaRemaining     = "X-Quota-Remaining"
+	HeaderQuotaReset         = "X-Quota-Reset"
+	HeaderRetryAfter         = "Retry-After"
+
+	limitExceededRate  = "rate"
+	limitExceededQuota = "quota"
+
+	// platformScope scope of limits applied to all the requests of the platform
+	platformScope = ""
+)
+
+// bucket token bucket
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// counter counts requests per day
+type counter struct {
+	day   string
+	count int
+}
+
+// platformUsage usage of the limits by the remote platform
+type platformUsage struct {
+	sync.Mutex
+	buckets  map[string]*bucket
+	counters map[string]*counter
+}
+
+// limitStatus result of the limits check
+type limitStatus struct {
+	exceeded       string        // exceeded kind of exceeded limit, empty if request is allowed
+	scope          string        // scope either route or empty if platform limit
+	retryAfter     time.Duration // retryAfter when request can be retried
+	rateLimit      int           // rateLimit burst of the most restrictive rate limit
+	rateRemaining  int           // rateRemaining number of requests available immediately
+	rateReset      time.Duration // rateReset when bucket is full again
+	quotaLimit     int           // quotaLimit the most restrictive daily quota
+	quotaRemaining int           // quotaRemaining number of requests left for today
+	quotaReset     time.Duration // quotaReset when quota is reset
+}
+
+// inboundLimiter applies rate limits and daily quotas to requests of remote platforms
+// usage is kept in memory, so limits are applied per service instance
+type inboundLimiter struct {
+	sync.RWMutex
+	usage map[string]*platformUsage
+}
+
+func newInboundLimiter() *inboundLimiter {
+	return &inboundLimiter{
+		usage: make(map[string]*platformUsage),
+	}
+}
+
+func (l *inboundLimiter) platformUsage(platformId string) *platformUsage {
+	l.RLock()
+	u, ok := l.usage[platformId]
+	l.RUnlock()
+	if ok {
+		return u
+	}
+	l.Lock()
+	defer l.Unlock()
+	if u, ok = l.usage[platformId]; !ok {
+		u = &platformUsage{
+			buckets:  make(map[string]*bucket),
+			counters: make(map[string]*counter),
+		}
+		l.usage[platformId] = u
+	}
+	return u
+}
+
+// allow checks limits of the platform and the route and registers the request if it's allowed
+func (l *inboundLimiter) allow(platformId, route string, limits *domain.InboundLimits, now time.Time) *limitStatus {
+	st := &limitStatus{}
+	if limits == nil {
+		return st
+	}
+
+	scopes := map[string]*domain.RouteLimits{
+		platformScope: {RateLimit: limits.RateLimit, DailyQuota: limits.DailyQuota},
+	}
+	if rl, ok := limits.Routes[route]; ok && rl != nil {
+		scopes[route] = rl
+	}
+
+	u := l.platformUsage(platformId)
+	u.Lock()
+	defer u.Unlock()
+
+	now = now.UTC()
+	day := now.Format(time.DateOnly)
+	midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	// refill buckets and roll counters over, check limits without consuming
+	for scope, rl := range scopes {
+		if rl.DailyQuota > 0 {
+			c, ok := u.counters[scope]
+			if !ok || c.day != day {
+				c = &counter{day: day}
+				u.counters[scope] = c
+			}
+			if c.count >= rl.DailyQuota && st.exceeded == "" {
+				st.exceeded, st.scope, st.retryAfter = limitExceededQuota, scope, midnight.Sub(now)
+			}
+		}
+		if rl.RateLimit != nil && rl.RateLimit.Rate > 0 {
+			b := u.bucket(scope, rl.RateLimit, now)
+			if b.tokens < 1 && st.exceeded == "" {
+				st.exceeded, st.scope = limitExceededRate, scope
+				st.retryAfter = time.Duration((1 - b.tokens) / rl.RateLimit.Rate * float64(time.Second))
+			}
+		}
+	}
+
+	// consume
+	if st.exceeded == "" {
+		for scope, rl := range scopes {
+			if rl.DailyQuota > 0 {
+				u.counters[scope].count++
+			}
+			if rl.RateLimit != nil && rl.RateLimit.Rate > 0 {
+				u.buckets[scope].tokens--
+			}
+		}
+	}
+
+	// populate the most restrictive limits
+	st.rateRemaining, st.quotaRemaining = math.MaxInt, math.MaxInt
+	for scope, rl := range scopes {
+		if rl.DailyQuota > 0 {
+			if remaining := rl.DailyQuota - u.counters[scope].count; remaining < st.quotaRemaining {
+				st.quotaLimit, st.quotaRemaining, st.quotaReset = rl.DailyQuota, max(remaining, 0), midnight.Sub(now)
+			}
+		}
+		if rl.RateLimit != nil && rl.RateLimit.Rate > 0 {
+			b := u.buckets[scope]
+			burst := bucketCapacity(rl.RateLimit)
+			if remaining := int(math.Max(b.tokens, 0)); remaining < st.rateRemaining {
+				st.rateLimit, st.rateRemaining = int(burst), remaining
+				st.rateReset = time.Duration((burst - b.tokens) / rl.RateLimit.Rate * float64(time.Second))
+			}
+		}
+	}
+	return st
+}
+
+// bucket returns a refilled token bucket of the scope
+func (u *platformUsage) bucket(scope string, rl *domain.RateLimit, now time.Time) *bucket {
+	burst := bucketCapacity(rl)
+	b, ok := u.buckets[scope]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		u.buckets[scope] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * rl.Rate
+	if b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+	return b
+}
+
+func bucketCapacity(rl *domain.RateLimit) float64 {
+	if rl.Burst < 1 {
+		return 1
+	}
+	return float64(rl.Burst)
+}
+
+// setHeaders sets limit headers to the response
+func (st *limitStatus) setHeaders(w http.ResponseWriter) {
+	if st.rateLimit > 0 {
+		w.Header().Set(HeaderRateLimit, fmt.Sprintf("%d", st.rateLimit))
+		w.Header().Set(HeaderRateLimitRemaining, fmt.Sprintf("%d", st.rateRemaining))
+		w.Header().Set(HeaderRateLimitReset, fmt.Sprintf("%d", seconds(st.rateReset)))
+	}
+	if st.quotaLimit > 0 {
+		w.Header().Set(HeaderQuotaLimit, fmt.Sprintf("%d", st.quotaLimit))
+		w.Header().Set(HeaderQuotaRemaining, fmt.Sprintf("%d", st.quotaRemaining))
+		w.Header().Set(HeaderQuotaReset, fmt.Sprintf("%d", seconds(st.quotaReset)))
+	}
+	if st.exceeded != "" {
+		w.Header().Set(HeaderRetryAfter, fmt.Sprintf("%d", seconds(st.retryAfter)))
+	}
+}
+
+// seconds rounds duration up to seconds
+func seconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/stretchr/testify/suite"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type limitsTestSuite struct {
+	kit.Suite
+	limiter *inboundLimiter
+}
+
+func (s *limitsTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *limitsTestSuite) SetupTest() {
+	s.limiter = newInboundLimiter()
+}
+
+func TestLimitsSuite(t *testing.T) {
+	suite.Run(t, new(limitsTestSuite))
+}
+
+func (s *limitsTestSuite) Test_NoLimits() {
+	for i := 0; i < 100; i++ {
+		st := s.limiter.allow("p", "locations.get", nil, kit.Now())
+		s.Empty(st.exceeded)
+	}
+	st := s.limiter.allow("p", "locations.get", &domain.InboundLimits{}, kit.Now())
+	s.Empty(st.exceeded)
+	s.Zero(st.rateLimit)
+	s.Zero(st.quotaLimit)
+}
+
+func (s *limitsTestSuite) Test_RateLimit() {
+	now := kit.Now()
+	limits := &domain.InboundLimits{RateLimit: &domain.RateLimit{Rate: 1, Burst: 2}}
+
+	st := s.limiter.allow("p", "locations.get", limits, now)
+	s.Empty(st.exceeded)
+	s.Equal(2, st.rateLimit)
+	s.Equal(1, st.rateRemaining)
+	st = s.limiter.allow("p", "locations.get", limits, now)
+	s.Empty(st.exceeded)
+	s.Equal(0, st.rateRemaining)
+
+	// exceeded
+	st = s.limiter.allow("p", "sessions.get", limits, now)
+	s.Equal(limitExceededRate, st.exceeded)
+	s.Equal(platformScope, st.scope)
+	s.Equal(time.Second, st.retryAfter)
+
+	// other platforms aren't affected
+	st = s.limiter.allow("another", "locations.get", limits, now)
+	s.Empty(st.exceeded)
+
+	// refilled
+	st = s.limiter.allow("p", "locations.get", limits, now.Add(time.Second))
+	s.Empty(st.exceeded)
+}
+
+func (s *limitsTestSuite) Test_RouteRateLimit() {
+	now := kit.Now()
+	limits := &domain.InboundLimits{
+		Routes: map[string]*domain.RouteLimits{
+			"locations.get": {RateLimit: &domain.RateLimit{Rate: 1, Burst: 1}},
+		},
+	}
+	st := s.limiter.allow("p", "locations.get", limits, now)
+	s.Empty(st.exceeded)
+	st = s.limiter.allow("p", "locations.get", limits, now)
+	s.Equal(limitExceededRate, st.exceeded)
+	s.Equal("locations.get", st.scope)
+
+	// other routes aren't affected
+	st = s.limiter.allow("p", "sessions.get", limits, now)
+	s.Empty(st.exceeded)
+}
+
+func (s *limitsTestSuite) Test_DailyQuota() {
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	limits := &domain.InboundLimits{
+		DailyQuota: 3,
+		Routes: map[string]*domain.RouteLimits{
+			"locations.get": {DailyQuota: 1},
+		},
+	}
+	st := s.limiter.allow("p", "locations.get", limits, now)
+	s.Empty(st.exceeded)
+	s.Equal(1, st.quotaLimit)
+	s.Equal(0, st.quotaRemaining)
+	s.Equal(time.Hour, st.quotaReset)
+
+	// route quota exceeded
+	st = s.limiter.allow("p", "locations.get", limits, now)
+	s.Equal(limitExceededQuota, st.exceeded)
+	s.Equal(time.Hour, st.retryAfter)
+
+	// rejected requests aren't counted
+	st = s.limiter.allow("p", "sessions.get", limits, now)
+	s.Empty(st.exceeded)
+	s.Equal(3, st.quotaLimit)
+	s.Equal(1, st.quotaRemaining)
+	st = s.limiter.allow("p", "sessions.get", limits, now)
+	s.Empty(st.exceeded)
+
+	// platform quota exceeded
+	st = s.limiter.allow("p", "sessions.get", limits, now)
+	s.Equal(limitExceededQuota, st.exceeded)
+	s.Equal(platformScope, st.scope)
+
+	// next day
+	st = s.limiter.allow("p", "locations.get", limits, now.Add(time.Hour))
+	s.Empty(st.exceeded)
+}
+
+func (s *limitsTestSuite) Test_Headers() {
+	now := kit.Now()
+	limits := &domain.InboundLimits{RateLimit: &domain.RateLimit{Rate: 1, Burst: 1}, DailyQuota: 10}
+
+	w := httptest.NewRecorder()
+	s.limiter.allow("p", "locations.get", limits, now).setHeaders(w)
+	s.Equal("1", w.Header().Get(HeaderRateLimit))
+	s.Equal("0", w.Header().Get(HeaderRateLimitRemaining))
+	s.Equal("1", w.Header().Get(HeaderRateLimitReset))
+	s.Equal("10", w.Header().Get(HeaderQuotaLimit))
+	s.Equal("9", w.Header().Get(HeaderQuotaRemaining))
+	s.NotEmpty(w.Header().Get(HeaderQuotaReset))
+	s.Empty(w.Header().Get(HeaderRetryAfter))
+
+	w = httptest.NewRecorder()
+	s.limiter.allow("p", "locations.get", limits, now).setHeaders(w)
+	s.Equal("1", w.Header().Get(HeaderRetryAfter))
+}
@@ -27,6 +27,7 @@ type Middleware struct {
 	platformService domain.PlatformService
 	ocpiLogging     domain.OcpiLogService
 	cfg             *ocpiCfg.CfgOcpiConfig
+	limiter         *inboundLimiter
 }
 
 func NewMiddleware(platformService domain.PlatformService, ocpiLogging domain.OcpiLogService, cfg *ocpiCfg.CfgOcpiConfig) *Middleware {
@@ -35,6 +36,7 @@ func NewMiddleware(platformService domain.PlatformService, ocpiLogging domain.Oc
 		platformService: platformService,
 		ocpiLogging:     ocpiLogging,
 		cfg:             cfg,
+		limiter:         newInboundLimiter(),
 	}
 }
 
@@ -59,6 +61,7 @@ func (m *Middleware) AuthAccessTokenMiddleware(next http.HandlerFunc, tokenTypes
 
 		// try to find platform by the token
 		var platform *domain.Platform
+		var matchedType string
 		for _, tokenType := range tokenTypes {
 			switch tokenType {
 			case TokenA:
@@ -73,6 +76,7 @@ func (m *Middleware) AuthAccessTokenMiddleware(next http.HandlerFunc, tokenTypes
 				return
 			}
 			if platform != nil {
+				matchedType = tokenType
 				break
 			}
 		}
@@ -83,6 +87,12 @@ func (m *Middleware) AuthAccessTokenMiddleware(next http.HandlerFunc, tokenTypes
 			return
 		}
 
+		// token A is accepted only for onboarding
+		if matchedType == TokenA && !m.tokenAAccepted(platform) {
+			m.OcpiRespondError(r, w, errors.ErrAuthFailed(ctx))
+			return
+		}
+
 		if platform.Status == domain.ConnectionStatusSuspended {
 			m.OcpiRespondError(r, w, errors.ErrPlatformNotAvailable(ctx))
 			return
@@ -91,12 +101,50 @@ func (m *Middleware) AuthAccessTokenMiddleware(next http.HandlerFunc, tokenTypes
 		// populate context
 		r = r.WithContext(ctxRq.WithKv(model.OcpiCtxPlatform, platform.Id).ToContext(r.Context()))
 
+		// apply inbound limits
+		if err := m.applyLimits(w, r, platform); err != nil {
+			m.OcpiRespondError(r, w, err)
+			return
+		}
+
 		next.ServeHTTP(w, r)
 	}
 
 	return f
 }
 
+// tokenAAccepted checks if token A can be used by the platform
+// expired, consumed or revoked onboarding token isn't accepted
+// token A without onboarding record (e.g. set by platform request) is accepted only until credentials are exchanged
+func (m *Middleware) tokenAAccepted(platform *domain.Platform) bool {
+	if platform.Onboarding == nil {
+		return platform.TokenB == ""
+	}
+	return m.platformService.OnboardingTokenStatus(platform.Onboarding) == domain.OnboardingTokenActive
+}
+
+// applyLimits checks rate limits and daily quotas of the platform and sets limit headers
+func (m *Middleware) applyLimits(w http.ResponseWriter, r *http.Request, platform *domain.Platform) error {
+	ctx := r.Context()
+	route := routeKey(r)
+	st := m.limiter.allow(platform.Id, route, platform.Inbound, kit.Now())
+	st.setHeaders(w)
+	if st.exceeded == "" {
+		return nil
+	}
+	var err error
+	if st.exceeded == limitExceededQuota {
+		err = errors.ErrDailyQuotaExceeded(ctx, route)
+	} else {
+		err = errors.ErrRateLimitExceeded(ctx, route)
+	}
+	// throttling is recorded in OCPI log
+	if rqCtx, ok := kit.Request(ctx); ok {
+		rqCtx.WithKv(model.OcpiCtxThrottled, err)
+	}
+	return err
+}
+
 func (m *Middleware) SetContextMiddleware(next http.Handler) http.Handler {
 
 	f := func(w http.ResponseWriter, r *http.Request) {
@@ -214,6 +262,16 @@ var (
 	}
 )
 
+// routeKey returns a key of the route the request is sent to (e.g. locations.get)
+func routeKey(r *http.Request) string {
+	for _, mod := range modules {
+		if strings.Index(r.RequestURI, mod) > 0 {
+			return domain.RouteKey(mod, r.Method)
+		}
+	}
+	return domain.LogEventGetVersionDetails
+}
+
 func (m *Middleware) OcpiLoggingMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	f := func(w http.ResponseWriter, r *http.Request) {
 
@@ -232,15 +290,7 @@ func (m *Middleware) OcpiLoggingMiddleware(next http.HandlerFunc) http.HandlerFu
 		}
 
 		// get event
-		for _, mod := range modules {
-			if strings.Index(r.RequestURI, mod) > 0 {
-				msg.Event = strings.ToLower(fmt.Sprintf("%s.%s", mod, r.Method))
-				break
-			}
-		}
-		if msg.Event == "" {
-			msg.Event = "version-details.get"
-		}
+		msg.Event = routeKey(r)
 
 		// token
 		msg.Token, _ = m.ExtractToken(ctx, r)
@@ -286,6 +336,9 @@ func (m *Middleware) OcpiLoggingMiddleware(next http.HandlerFunc) http.HandlerFu
 			if v, ok := rqCtx.Kv[model.OcpiCtxPlatform]; ok && v != nil {
 				msg.FromPlatform = v.(string)
 			}
+			if v, ok := rqCtx.Kv[model.OcpiCtxThrottled]; ok && v != nil {
+				msg.Err = v.(error)
+			}
 		}
 	}
 
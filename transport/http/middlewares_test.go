@@ -0,0 +1,49 @@
+package http
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type middlewareTestSuite struct {
+	kit.Suite
+	platformService *mocks.PlatformService
+	m               *Middleware
+}
+
+func (s *middlewareTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *middlewareTestSuite) SetupTest() {
+	s.platformService = &mocks.PlatformService{}
+	s.m = NewMiddleware(s.platformService, &mocks.OcpiLogService{}, &ocpi.CfgOcpiConfig{})
+}
+
+func TestMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(middlewareTestSuite))
+}
+
+func (s *middlewareTestSuite) Test_TokenA_NoOnboarding() {
+	// credentials haven't been exchanged yet
+	p := &domain.Platform{Id: kit.NewRandString(), TokenA: domain.PlatformToken(kit.NewRandString())}
+	s.True(s.m.tokenAAccepted(p))
+
+	// credentials exchanged
+	p.TokenB = domain.PlatformToken(kit.NewRandString())
+	s.False(s.m.tokenAAccepted(p))
+}
+
+func (s *middlewareTestSuite) Test_TokenA_Onboarding() {
+	p := &domain.Platform{Id: kit.NewRandString(), Onboarding: &domain.OnboardingToken{SingleUse: true, CreatedAt: kit.Now()}}
+
+	s.platformService.On("OnboardingTokenStatus", p.Onboarding).Return(domain.OnboardingTokenActive).Once()
+	s.True(s.m.tokenAAccepted(p))
+
+	s.platformService.On("OnboardingTokenStatus", p.Onboarding).Return(domain.OnboardingTokenConsumed).Once()
+	s.False(s.m.tokenAAccepted(p))
+}
@@ -149,6 +149,15 @@ func (c *Controller) OcpiRespondNotFoundError(r *http.Request, w http.ResponseWr
 	})
 }
 
+// FormValCursor retrieves page cursor, nil if not specified
+func (c *Controller) FormValCursor(ctx context.Context, r *http.Request) (*string, error) {
+	cursor, err := c.FormVal(ctx, r, model.OcpiQueryParamCursor, true)
+	if err != nil || cursor == "" {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
 func (c *Controller) SetResponseCtx(ctx context.Context, rs domain.PageResponse) context.Context {
 	appCtx, ok := kit.Request(ctx)
 	if !ok {
@@ -196,6 +205,9 @@ func (c *Controller) buildNextPageUrl(nextPage *domain.PageRequest, prefixUrl st
 	if nextPage.Offset != nil {
 		v.Add(model.OcpiQueryParamOffset, strconv.Itoa(*nextPage.Offset))
 	}
+	if nextPage.Cursor != nil {
+		v.Add(model.OcpiQueryParamCursor, *nextPage.Cursor)
+	}
 	values := v.Encode()
 	if values == "" {
 		return prefixUrl
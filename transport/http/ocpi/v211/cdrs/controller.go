@@ -0,0 +1,149 @@
+package cdrs
+
+import (
+	"fmt"
+	kitHttp "github.com/mikhailbolshakov/kit/http"
+	cfg "github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/model/v211"
+	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi"
+	ocpiV211 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v211"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"net/http"
+)
+
+type Controller interface {
+	kitHttp.Controller
+	SenderGetCdrs(http.ResponseWriter, *http.Request)
+	ReceiverGetCdr(http.ResponseWriter, *http.Request)
+	ReceiverPostCdr(http.ResponseWriter, *http.Request)
+}
+
+type ctrlImpl struct {
+	ocpi.Controller
+	cdrService      domain.CdrService
+	partyService    domain.PartyService
+	localPlatform   domain.LocalPlatformService
+	converter       usecase.CdrConverter
+	cdrUc           usecase.CdrUc
+	senderSearchUrl string
+}
+
+func NewController(cdrService domain.CdrService, partyService domain.PartyService, localPlatform domain.LocalPlatformService, converter usecase.CdrConverter,
+	cdrUc usecase.CdrUc, cfg *cfg.CfgOcpiConfig) Controller {
+	return &ctrlImpl{
+		Controller:      ocpi.NewController(),
+		cdrService:      cdrService,
+		partyService:    partyService,
+		localPlatform:   localPlatform,
+		converter:       converter,
+		cdrUc:           cdrUc,
+		senderSearchUrl: fmt.Sprintf("%s/%s", cfg.Local.Url, "ocpi/2.1.1/sender/cdrs"),
+	}
+}
+
+func (c *ctrlImpl) SenderGetCdrs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// retrieve cdrs by the local platform
+	rq := &domain.CdrSearchCriteria{
+		IncPlatforms: []string{c.localPlatform.GetPlatformId(ctx)},
+	}
+
+	var err error
+	rq.DateFrom, err = c.FormValTime(ctx, r, model.OcpiQueryParamDateFrom, true)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq.DateTo, err = c.FormValTime(ctx, r, model.OcpiQueryParamDateTo, true)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq.Offset, err = c.FormValInt(ctx, r, model.OcpiQueryParamOffset, true)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq.Cursor, err = c.FormValCursor(ctx, r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq.Limit, err = c.FormValInt(ctx, r, model.OcpiQueryParamLimit, true)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rs, err := c.cdrService.SearchCdrs(ctx, rq)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	r = r.WithContext(c.SetResponseWithNextPageCtx(ctx, rs.PageResponse, c.senderSearchUrl))
+
+	c.OcpiRespondOK(r, w, v211.CdrsModelToV211(c.converter.CdrsDomainToModel(rs.Items)))
+}
+
+func (c *ctrlImpl) ReceiverGetCdr(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cdrId, err := c.Var(ctx, r, "cdr_id", false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	cdr, err := c.cdrService.GetCdr(ctx, cdrId)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	if cdr == nil {
+		c.OcpiRespondNotFoundError(r, w)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, v211.CdrModelToV211(c.converter.CdrDomainToModel(cdr)))
+}
+
+func (c *ctrlImpl) ReceiverPostCdr(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rqV211, err := kitHttp.DecodeRequest[v211.OcpiCdr](ctx, r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq := v211.CdrV211ToModel(rqV211)
+
+	platformId, err := c.PlatformId(ctx)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	// 2.1.1 posts cdrs to the module url, so party is taken from the remote platform
+	party, err := ocpiV211.PlatformParty(ctx, c.partyService, platformId)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq.PartyId, rq.CountryCode = party.ExtId.PartyId, party.ExtId.CountryCode
+	if rq.CdrToken != nil {
+		rq.CdrToken.OcpiPartyId = rq.OcpiPartyId
+	}
+
+	err = c.cdrUc.OnRemoteCdrPut(ctx, platformId, rq)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, nil)
+}
@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"context"
+	kitHttp "github.com/mikhailbolshakov/kit/http"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/model/v211"
+	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi"
+	ocpiV211 "github.com/mikhailbolshakov/ocpi/transport/http/ocpi/v211"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"net/http"
+	"sync"
+)
+
+type commandDetails struct {
+	RqFunc  func() any
+	CmdFunc func(context.Context, string, any) (*model.OcpiCommandResponse, error)
+}
+
+type Controller interface {
+	kitHttp.Controller
+	SenderSetCommandResponse(http.ResponseWriter, *http.Request)
+	ReceiverExecCommand(http.ResponseWriter, *http.Request)
+}
+
+type ctrlImpl struct {
+	ocpi.Controller
+	once         sync.Once
+	commandMap   map[string]commandDetails
+	commandUc    usecase.CommandUc
+	partyService domain.PartyService
+}
+
+func NewController(commandUc usecase.CommandUc, partyService domain.PartyService) Controller {
+	return &ctrlImpl{
+		Controller:   ocpi.NewController(),
+		commandUc:    commandUc,
+		partyService: partyService,
+	}
+}
+
+// getCommandsCfg 2.1.1 commands, CANCEL_RESERVATION isn't supported
+func (c *ctrlImpl) getCommandsCfg() map[string]commandDetails {
+	c.once.Do(
+		func() {
+			c.commandMap = map[string]commandDetails{
+				model.CommandStartSession: {
+					RqFunc: func() any { return &v211.OcpiStartSession{} },
+					CmdFunc: func(ctx context.Context, platformId string, rq any) (*model.OcpiCommandResponse, error) {
+						return c.commandUc.OnRemoteStartSession(ctx, platformId, v211.StartSessionV211ToModel(rq.(*v211.OcpiStartSession)))
+					},
+				},
+				model.CommandStopSession: {
+					RqFunc: func() any { return &v211.OcpiStopSession{} },
+					CmdFunc: func(ctx context.Context, platformId string, rq any) (*model.OcpiCommandResponse, error) {
+						return c.commandUc.OnRemoteStopSession(ctx, platformId, v211.StopSessionV211ToModel(rq.(*v211.OcpiStopSession)))
+					},
+				},
+				model.CommandReserve: {
+					RqFunc: func() any { return &v211.OcpiReserveNow{} },
+					CmdFunc: func(ctx context.Context, platformId string, rq any) (*model.OcpiCommandResponse, error) {
+						return c.commandUc.OnRemoteReserve(ctx, platformId, v211.ReserveNowV211ToModel(rq.(*v211.OcpiReserveNow)))
+					},
+				},
+				model.CommandUnlockConnector: {
+					RqFunc: func() any { return &v211.OcpiUnlockConnector{} },
+					CmdFunc: func(ctx context.Context, platformId string, rq any) (*model.OcpiCommandResponse, error) {
+						return c.commandUc.OnRemoteUnlockConnector(ctx, platformId, v211.UnlockConnectorV211ToModel(rq.(*v211.OcpiUnlockConnector)))
+					},
+				},
+			}
+		})
+	return c.commandMap
+}
+
+func (c *ctrlImpl) SenderSetCommandResponse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := c.Var(ctx, r, model.OcpiQueryParamCommand, false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	// check command
+	if _, ok := c.getCommandsCfg()[command]; !ok {
+		c.OcpiRespondError(r, w, errors.ErrCmdNotSupported(ctx))
+		return
+	}
+
+	uid, err := c.Var(ctx, r, model.OcpiQueryParamUid, false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rq, err := kitHttp.DecodeRequest[v211.OcpiCommandResponse](ctx, r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	platformId, err := c.PlatformId(ctx)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	err = c.commandUc.OnRemoteSetResponse(ctx, platformId, uid, v211.CommandResponseV211ToResult(rq))
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, nil)
+
+}
+
+func (c *ctrlImpl) ReceiverExecCommand(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	command, err := c.Var(ctx, r, model.OcpiQueryParamCommand, false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	platformId, err := c.PlatformId(ctx)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	// 2.1.1 doesn't pass party headers, so the sender party is taken from the remote platform
+	party, err := ocpiV211.PlatformParty(ctx, c.partyService, platformId)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	ctx = ocpiV211.WithFromPartyCtx(ctx, party)
+
+	// check command
+	cmdDet, ok := c.getCommandsCfg()[command]
+	if !ok {
+		c.OcpiRespondError(r, w, errors.ErrCmdNotSupported(ctx))
+		return
+	}
+	rq := cmdDet.RqFunc()
+	if err := c.DecodeRequest(ctx, r, rq); err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rs, err := cmdDet.CmdFunc(ctx, platformId, rq)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, v211.CommandResponseModelToV211(rs))
+}
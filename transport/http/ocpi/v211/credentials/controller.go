@@ -0,0 +1,138 @@
+package credentials
+
+import (
+	kitHttp "github.com/mikhailbolshakov/kit/http"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/model/v211"
+	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"net/http"
+)
+
+type Controller interface {
+	kitHttp.Controller
+	GetVersionDetails(http.ResponseWriter, *http.Request)
+	PostCredentials(http.ResponseWriter, *http.Request)
+	PutCredentials(http.ResponseWriter, *http.Request)
+	DeleteCredentials(http.ResponseWriter, *http.Request)
+	GetCredentials(http.ResponseWriter, *http.Request)
+}
+
+type ctrlImpl struct {
+	ocpi.Controller
+	credentialUc    usecase.CredentialsUc
+	localPlatform   domain.LocalPlatformService
+	platformService domain.PlatformService
+}
+
+func NewController(credentialUc usecase.CredentialsUc, platformService domain.PlatformService, localPlatform domain.LocalPlatformService) Controller {
+	return &ctrlImpl{
+		Controller:      ocpi.NewController(),
+		credentialUc:    credentialUc,
+		platformService: platformService,
+		localPlatform:   localPlatform,
+	}
+}
+
+func (c *ctrlImpl) getPlatform(r *http.Request) (*domain.Platform, error) {
+	ctx := r.Context()
+	platformId, err := c.PlatformId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	platform, err := c.platformService.Get(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+	if platform == nil {
+		return nil, errors.ErrPlatformNotFound(ctx, platformId)
+	}
+	return platform, nil
+}
+
+func (c *ctrlImpl) GetVersionDetails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 2.1.1 exposes a single url per module, so the interface is chosen by the role of the calling platform
+	platform, err := c.getPlatform(r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rs := &v211.OcpiVersionDetails{
+		Version:   model.OcpiVersion211,
+		Endpoints: c.toVersionEndpointsApi(c.localPlatform.GetEndpoints(ctx, model.OcpiVersion211), platform.Role),
+	}
+	c.OcpiRespondOK(r, w, rs)
+}
+
+func (c *ctrlImpl) acceptConnection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	platform, err := c.getPlatform(r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rq, err := kitHttp.DecodeRequest[v211.OcpiCredentials](ctx, r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rs, err := c.credentialUc.AcceptConnection(ctx, platform.Id, v211.CredentialsV211ToModel(rq, platform.Role))
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, v211.CredentialsModelToV211(rs))
+}
+
+func (c *ctrlImpl) PostCredentials(w http.ResponseWriter, r *http.Request) {
+	c.acceptConnection(w, r)
+}
+
+func (c *ctrlImpl) PutCredentials(w http.ResponseWriter, r *http.Request) {
+	c.acceptConnection(w, r)
+}
+
+func (c *ctrlImpl) DeleteCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	platformId, err := c.PlatformId(ctx)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	err = c.credentialUc.OnRemoteDeleteCredentials(ctx, platformId)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, nil)
+}
+
+func (c *ctrlImpl) GetCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	platformId, err := c.PlatformId(ctx)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rs, err := c.credentialUc.OnRemoteGetCredentials(ctx, platformId)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, v211.CredentialsModelToV211(rs))
+}
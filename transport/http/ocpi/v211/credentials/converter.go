@@ -0,0 +1,35 @@
+package credentials
+
+import (
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/model/v211"
+)
+
+// toVersionEndpointsApi builds 2.1.1 endpoints exposing the interface opposite to the one the remote platform exposes
+// if the remote role is unknown, sender interfaces are exposed
+func (c *ctrlImpl) toVersionEndpointsApi(endpoints domain.ModuleEndpoints, platformRole string) []v211.OcpiVersionModuleEndpoint {
+	var r []v211.OcpiVersionModuleEndpoint
+	for _, moduleId := range v211.Modules() {
+		roles, ok := endpoints[moduleId]
+		if !ok {
+			continue
+		}
+		localRole := model.OcpiSender
+		if remoteRoles := v211.ModuleRoles(moduleId, platformRole); len(remoteRoles) == 1 && remoteRoles[0] == model.OcpiSender {
+			localRole = model.OcpiReceiver
+		}
+		ep, ok := roles[localRole]
+		if !ok {
+			// module with a single interface (e.g. credentials)
+			for _, e := range roles {
+				ep = e
+			}
+		}
+		r = append(r, v211.OcpiVersionModuleEndpoint{
+			Id:  moduleId,
+			Url: string(ep),
+		})
+	}
+	return r
+}
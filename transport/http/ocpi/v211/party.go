@@ -0,0 +1,35 @@
+package v211
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/model"
+)
+
+// PlatformParty retrieves a party of the remote platform
+// 2.1.1 supports a single party per platform and doesn't pass party headers, so the party is taken from the credentials exchange
+func PlatformParty(ctx context.Context, partyService domain.PartyService, platformId string) (*domain.Party, error) {
+	rs, err := partyService.Search(ctx, &domain.PartySearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(1)},
+		IncPlatforms: []string{platformId},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rs.Items) == 0 {
+		return nil, errors.ErrPlatformPartyNotFound(ctx, platformId)
+	}
+	return rs.Items[0], nil
+}
+
+// WithFromPartyCtx populates context with the party of the remote platform as OCPI headers would do in 2.2.1
+func WithFromPartyCtx(ctx context.Context, party *domain.Party) context.Context {
+	if rqCtx, ok := kit.Request(ctx); ok {
+		rqCtx.WithKv(model.OcpiCtxFromParty, party.ExtId.PartyId)
+		rqCtx.WithKv(model.OcpiCtxFromCountryCode, party.ExtId.CountryCode)
+		return rqCtx.ToContext(ctx)
+	}
+	return ctx
+}
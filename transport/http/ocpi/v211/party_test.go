@@ -0,0 +1,46 @@
+package v211
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type partyTestSuite struct {
+	kit.Suite
+	partyService *mocks.PartyService
+}
+
+func (s *partyTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *partyTestSuite) SetupTest() {
+	s.partyService = &mocks.PartyService{}
+}
+
+func TestPartySuite(t *testing.T) {
+	suite.Run(t, new(partyTestSuite))
+}
+
+func (s *partyTestSuite) Test_PlatformParty() {
+	platformId := kit.NewRandString()
+	party := &domain.Party{Id: kit.NewRandString()}
+	s.partyService.On("Search", mock.Anything, mock.MatchedBy(func(rq *domain.PartySearchCriteria) bool {
+		return len(rq.IncPlatforms) == 1 && rq.IncPlatforms[0] == platformId
+	})).Return(&domain.PartySearchResponse{Items: []*domain.Party{party}}, nil)
+	rs, err := PlatformParty(s.Ctx, s.partyService, platformId)
+	s.NoError(err)
+	s.Equal(party, rs)
+}
+
+func (s *partyTestSuite) Test_PlatformParty_NotFound() {
+	s.partyService.On("Search", mock.Anything, mock.Anything).Return(&domain.PartySearchResponse{}, nil)
+	_, err := PlatformParty(s.Ctx, s.partyService, kit.NewRandString())
+	s.AssertAppErr(err, errors.ErrCodePlatformPartyNotFound)
+}
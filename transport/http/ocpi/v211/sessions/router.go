@@ -0,0 +1,14 @@
+package sessions
+
+import "github.com/mikhailbolshakov/ocpi/transport/http"
+
+func GetRoutes(c Controller) []*http.Route {
+	return []*http.Route{
+		// sender
+		http.R("/ocpi/2.1.1/sender/sessions", c.SenderGetSessions).GET().Auth(http.TokenB).OcpiLogging(),
+		// receiver
+		http.R("/ocpi/2.1.1/receiver/sessions/{country_code}/{party_id}/{session_id}", c.ReceiverGetSession).GET().Auth(http.TokenB).OcpiLogging(),
+		http.R("/ocpi/2.1.1/receiver/sessions/{country_code}/{party_id}/{session_id}", c.ReceiverPostSession).PUT().Auth(http.TokenB).OcpiLogging(),
+		http.R("/ocpi/2.1.1/receiver/sessions/{country_code}/{party_id}/{session_id}", c.ReceiverPatchSession).PATCH().Auth(http.TokenB).OcpiLogging(),
+	}
+}
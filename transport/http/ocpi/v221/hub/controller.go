@@ -59,6 +59,11 @@ func (c *ctrlImpl) GetHubClientInfo(w http.ResponseWriter, r *http.Request) {
 		c.OcpiRespondError(r, w, err)
 		return
 	}
+	rq.Cursor, err = c.FormValCursor(ctx, r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
 	rq.Limit, err = c.FormValInt(ctx, r, model.OcpiQueryParamLimit, true)
 	if err != nil {
 		c.OcpiRespondError(r, w, err)
@@ -74,6 +74,11 @@ func (c *ctrlImpl) SenderGetLocations(w http.ResponseWriter, r *http.Request) {
 		c.OcpiRespondError(r, w, err)
 		return
 	}
+	rq.Cursor, err = c.FormValCursor(ctx, r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
 	rq.Limit, err = c.FormValInt(ctx, r, model.OcpiQueryParamLimit, true)
 	if err != nil {
 		c.OcpiRespondError(r, w, err)
@@ -0,0 +1,266 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	kitHttp "github.com/mikhailbolshakov/kit/http"
+	cfg "github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/transport/http/ocpi"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"net/http"
+)
+
+type Controller interface {
+	kitHttp.Controller
+	SenderGetTerminals(http.ResponseWriter, *http.Request)
+	SenderGetTerminal(http.ResponseWriter, *http.Request)
+	SenderGetFinancialAdvices(http.ResponseWriter, *http.Request)
+
+	ReceiverGetTerminal(http.ResponseWriter, *http.Request)
+	ReceiverPutTerminal(http.ResponseWriter, *http.Request)
+	ReceiverPatchTerminal(http.ResponseWriter, *http.Request)
+	ReceiverPostFinancialAdvice(http.ResponseWriter, *http.Request)
+}
+
+type ctrlImpl struct {
+	ocpi.Controller
+	paymentService           domain.PaymentService
+	localPlatform            domain.LocalPlatformService
+	converter                usecase.PaymentConverter
+	paymentUc                usecase.PaymentUc
+	senderTerminalsSearchUrl string
+	senderFaSearchUrl        string
+}
+
+func NewController(paymentService domain.PaymentService, localPlatform domain.LocalPlatformService, converter usecase.PaymentConverter,
+	paymentUc usecase.PaymentUc, cfg *cfg.CfgOcpiConfig) Controller {
+	return &ctrlImpl{
+		Controller:               ocpi.NewController(),
+		paymentService:           paymentService,
+		localPlatform:            localPlatform,
+		converter:                converter,
+		paymentUc:                paymentUc,
+		senderTerminalsSearchUrl: fmt.Sprintf("%s/%s", cfg.Local.Url, "ocpi/2.3.0/sender/payments/terminals"),
+		senderFaSearchUrl:        fmt.Sprintf("%s/%s", cfg.Local.Url, "ocpi/2.3.0/sender/payments/financial-advice-confirmations"),
+	}
+}
+
+func (c *ctrlImpl) SenderGetTerminals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// retrieve terminals by the local platform
+	rq := &domain.TerminalSearchCriteria{
+		IncPlatforms: []string{c.localPlatform.GetPlatformId(ctx)},
+	}
+
+	err := c.pageRequest(r, &rq.PageRequest)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rs, err := c.paymentService.SearchTerminals(ctx, rq)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	r = r.WithContext(c.SetResponseWithNextPageCtx(ctx, rs.PageResponse, c.senderTerminalsSearchUrl))
+
+	c.OcpiRespondOK(r, w, c.converter.TerminalsDomainToModel(rs.Items))
+}
+
+func (c *ctrlImpl) SenderGetTerminal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	terminalId, err := c.Var(ctx, r, "terminal_id", false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	t, err := c.paymentService.GetTerminal(ctx, terminalId)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	// only terminals of the local platform are exposed
+	if t == nil || t.PlatformId != c.localPlatform.GetPlatformId(ctx) {
+		c.OcpiRespondNotFoundError(r, w)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, c.converter.TerminalDomainToModel(t))
+}
+
+func (c *ctrlImpl) SenderGetFinancialAdvices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// retrieve confirmations by the local platform
+	rq := &domain.FinancialAdviceSearchCriteria{
+		IncPlatforms: []string{c.localPlatform.GetPlatformId(ctx)},
+	}
+
+	err := c.pageRequest(r, &rq.PageRequest)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rs, err := c.paymentService.SearchFinancialAdvices(ctx, rq)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	r = r.WithContext(c.SetResponseWithNextPageCtx(ctx, rs.PageResponse, c.senderFaSearchUrl))
+
+	c.OcpiRespondOK(r, w, c.converter.FinancialAdvicesDomainToModel(rs.Items))
+}
+
+func (c *ctrlImpl) ReceiverGetTerminal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, err := c.Var(ctx, r, model.OcpiQueryParamPartyId, false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	_, err = c.Var(ctx, r, model.OcpiQueryParamCountryCode, false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	terminalId, err := c.Var(ctx, r, "terminal_id", false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	t, err := c.paymentService.GetTerminal(ctx, terminalId)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	if t == nil {
+		c.OcpiRespondNotFoundError(r, w)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, c.converter.TerminalDomainToModel(t))
+}
+
+func (c *ctrlImpl) ReceiverPutTerminal(w http.ResponseWriter, r *http.Request) {
+	c.modifyTerminal(w, r, c.paymentUc.OnRemoteTerminalPut)
+}
+
+func (c *ctrlImpl) ReceiverPatchTerminal(w http.ResponseWriter, r *http.Request) {
+	c.modifyTerminal(w, r, c.paymentUc.OnRemoteTerminalPatch)
+}
+
+func (c *ctrlImpl) ReceiverPostFinancialAdvice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rq, err := kitHttp.DecodeRequest[model.OcpiFinancialAdviceConfirmation](ctx, r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rq.PartyId, err = c.Var(ctx, r, model.OcpiQueryParamPartyId, false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq.CountryCode, err = c.Var(ctx, r, model.OcpiQueryParamCountryCode, false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq.Id, err = c.Var(ctx, r, "financial_advice_confirmation_id", false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	platformId, err := c.PlatformId(ctx)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	err = c.paymentUc.OnRemoteFinancialAdvicePost(ctx, platformId, rq)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, nil)
+}
+
+func (c *ctrlImpl) modifyTerminal(w http.ResponseWriter, r *http.Request, modifyFn func(context.Context, string, *model.OcpiTerminal) error) {
+	ctx := r.Context()
+
+	rq, err := kitHttp.DecodeRequest[model.OcpiTerminal](ctx, r)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	rq.PartyId, err = c.Var(ctx, r, model.OcpiQueryParamPartyId, false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq.CountryCode, err = c.Var(ctx, r, model.OcpiQueryParamCountryCode, false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+	rq.Id, err = c.Var(ctx, r, "terminal_id", false)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	platformId, err := c.PlatformId(ctx)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	err = modifyFn(ctx, platformId, rq)
+	if err != nil {
+		c.OcpiRespondError(r, w, err)
+		return
+	}
+
+	c.OcpiRespondOK(r, w, nil)
+}
+
+func (c *ctrlImpl) pageRequest(r *http.Request, rq *domain.PageRequest) error {
+	ctx := r.Context()
+	var err error
+	rq.DateFrom, err = c.FormValTime(ctx, r, model.OcpiQueryParamDateFrom, true)
+	if err != nil {
+		return err
+	}
+	rq.DateTo, err = c.FormValTime(ctx, r, model.OcpiQueryParamDateTo, true)
+	if err != nil {
+		return err
+	}
+	rq.Offset, err = c.FormValInt(ctx, r, model.OcpiQueryParamOffset, true)
+	if err != nil {
+		return err
+	}
+	rq.Cursor, err = c.FormValCursor(ctx, r)
+	if err != nil {
+		return err
+	}
+	rq.Limit, err = c.FormValInt(ctx, r, model.OcpiQueryParamLimit, true)
+	return err
+}
@@ -0,0 +1,17 @@
+package payments
+
+import "github.com/mikhailbolshakov/ocpi/transport/http"
+
+func GetRoutes(c Controller) []*http.Route {
+	return []*http.Route{
+		// sender
+		http.R("/ocpi/2.3.0/sender/payments/terminals", c.SenderGetTerminals).GET().Auth(http.TokenB).OcpiLogging(),
+		http.R("/ocpi/2.3.0/sender/payments/terminals/{terminal_id}", c.SenderGetTerminal).GET().Auth(http.TokenB).OcpiLogging(),
+		http.R("/ocpi/2.3.0/sender/payments/financial-advice-confirmations", c.SenderGetFinancialAdvices).GET().Auth(http.TokenB).OcpiLogging(),
+		// receiver
+		http.R("/ocpi/2.3.0/receiver/payments/terminals/{country_code}/{party_id}/{terminal_id}", c.ReceiverGetTerminal).GET().Auth(http.TokenB).OcpiLogging(),
+		http.R("/ocpi/2.3.0/receiver/payments/terminals/{country_code}/{party_id}/{terminal_id}", c.ReceiverPutTerminal).PUT().Auth(http.TokenB).OcpiLogging(),
+		http.R("/ocpi/2.3.0/receiver/payments/terminals/{country_code}/{party_id}/{terminal_id}", c.ReceiverPatchTerminal).PATCH().Auth(http.TokenB).OcpiLogging(),
+		http.R("/ocpi/2.3.0/receiver/payments/financial-advice-confirmations/{country_code}/{party_id}/{financial_advice_confirmation_id}", c.ReceiverPostFinancialAdvice).POST().Auth(http.TokenB).OcpiLogging(),
+	}
+}
@@ -0,0 +1,36 @@
+package sessions
+
+import (
+	"encoding/json"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type controllerTestSuite struct {
+	kit.Suite
+}
+
+func (s *controllerTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func TestControllerSuite(t *testing.T) {
+	suite.Run(t, new(controllerTestSuite))
+}
+
+func (s *controllerTestSuite) Test_SenderPutChargingPreferences_NotSupported() {
+	c := NewController(&mocks.SessionService{}, &mocks.LocalPlatformService{}, nil, &mocks.SessionUc{}, &ocpi.CfgOcpiConfig{Local: &ocpi.CfgOcpiLocal{}})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPut, "/ocpi/2.3.0/sender/sessions/sess/preferences", nil).WithContext(s.Ctx)
+	s.NotPanics(func() { c.SenderPutChargingPreferences(w, r) })
+	s.Equal(http.StatusOK, w.Code)
+	rs := &model.OcpiResponse{}
+	s.NoError(json.Unmarshal(w.Body.Bytes(), rs))
+	s.Equal(model.OcpiStatusGenClientError, rs.StatusCode)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/backend"
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/model"
@@ -19,8 +20,12 @@ type CdrConverter interface {
 	CdrDomainToBackend(cdr *domain.Cdr) *backend.Cdr
 	// CdrsDomainToBackend converts cdr domain to backend
 	CdrsDomainToBackend(ts []*domain.Cdr) []*backend.Cdr
+	// CdrIssueReportsDomainToBackend converts cdr issue reports domain to backend
+	CdrIssueReportsDomainToBackend(rs []*domain.CdrIssueReport) []*backend.CdrIssueReport
 	// CdrBackendToDomain converts cdr backend to domain
 	CdrBackendToDomain(cdr *backend.Cdr, sess *domain.Session, loc *domain.Location, evse *domain.Evse, con *domain.Connector) *domain.Cdr
+	// CdrCreditBackendToDomain converts credit request backend to domain
+	CdrCreditBackendToDomain(rq *backend.CdrCreditRequest) *domain.CdrCredit
 }
 
 type CdrUc interface {
@@ -28,10 +33,18 @@ type CdrUc interface {
 	OnLocalCdrChanged(ctx context.Context, cdr *backend.Cdr) error
 	// OnRemoteCdrsPull handles request to pull cdrs from remote platforms (fired by cron)
 	OnRemoteCdrsPull(ctx context.Context, from, to *time.Time) error
+	// OnRemotePlatformPull pulls cdrs from the particular remote platform (catch-up after platform is back online)
+	OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error
 	// OnRemoteCdrsPullWhenPushNotSupported handles request to pull cdrs from remote platforms which don't support push (fired by cron)
 	OnRemoteCdrsPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error
 	// OnRemoteCdrPut handles put cdr in remote platform
 	OnRemoteCdrPut(ctx context.Context, platformId string, cdr *model.OcpiCdr) error
+	// Init initializes use case with config
+	Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error
+	// GetCdrIssues retrieves CDR discrepancies grouped by partner
+	GetCdrIssues(ctx context.Context) ([]*domain.CdrIssueReport, error)
+	// CreditCdr issues a credit CDR against the local CDR and pushes it to the eMSP
+	CreditCdr(ctx context.Context, cdrId string, rq *domain.CdrCredit) (*domain.Cdr, error)
 }
 
 type RemoteCdrRepository interface {
@@ -2,9 +2,11 @@ package usecase
 
 import (
 	"context"
+	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/backend"
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/model"
+	"time"
 )
 
 type CommandConverter interface {
@@ -42,6 +44,8 @@ type CommandConverter interface {
 }
 
 type CommandUc interface {
+	// Init initializes command usecase
+	Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error
 	// OnRemoteStartSession fires when a remote platform requests starting session
 	OnRemoteStartSession(ctx context.Context, platformId string, rq *model.OcpiStartSession) (*model.OcpiCommandResponse, error)
 	// OnRemoteStopSession fires when a remote platform requests stopping session
@@ -56,6 +60,9 @@ type CommandUc interface {
 	OnRemoteSetResponse(ctx context.Context, platformId, uid string, rq *model.OcpiCommandResult) error
 	// RemoteCommandsDeadlineCronHandler handles all hanging local commands
 	RemoteCommandsDeadlineCronHandler(ctx context.Context)
+	// WaitCommandResult waits until the command result is received from the remote platform or timeout passes
+	// it returns the command in the actual status, which is still accepted if no result received
+	WaitCommandResult(ctx context.Context, cmdId string, timeout time.Duration) (*domain.Command, error)
 
 	// OnLocalStartSession fires when a local platform requests starting session
 	OnLocalStartSession(ctx context.Context, rq *domain.Command) error
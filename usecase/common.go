@@ -1,17 +1,28 @@
 package usecase
 
 import (
+	"context"
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/model"
+	"time"
 )
 
 type ErrorHandler func(err error)
 
+// PlatformPuller pulls module objects from the particular remote platform
+type PlatformPuller interface {
+	// OnRemotePlatformPull pulls objects updated within the given period from the remote platform
+	OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error
+}
+
 type OcpiRepositoryBaseRequest struct {
 	Endpoint       domain.Endpoint
 	Token          domain.PlatformToken
 	FromPlatformId string
 	ToPlatformId   string
+	Version        string                 // Version OCPI version negotiated with the remote platform
+	ToPlatformRole string                 // ToPlatformRole role of the remote platform
+	ClientSettings *domain.ClientSettings // ClientSettings settings of the client to the remote platform
 }
 
 type OcpiRepositoryRequestG[T any] struct {
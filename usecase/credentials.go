@@ -23,6 +23,14 @@ type CredentialsConverter interface {
 	PlatformBackendToDomain(rq *backend.PlatformRequest) *domain.Platform
 	// PlatformDomainToBackend converts platform domain to Backend
 	PlatformDomainToBackend(p *domain.Platform) *backend.Platform
+	// TokenRotationDomainToBackend converts token rotation domain to backend
+	TokenRotationDomainToBackend(r *domain.TokenRotation) *backend.TokenRotation
+	// TokenRotationsDomainToBackend converts token rotations domain to backend
+	TokenRotationsDomainToBackend(rs []*domain.TokenRotation) []*backend.TokenRotation
+	// OnboardingTokenBackendToDomain converts onboarding token request to domain
+	OnboardingTokenBackendToDomain(rq *backend.OnboardingTokenRequest) *domain.OnboardingToken
+	// OnboardingTokenDomainToBackend converts platform's onboarding token to backend
+	OnboardingTokenDomainToBackend(p *domain.Platform, status string) *backend.OnboardingToken
 }
 
 type CredentialsUc interface {
@@ -32,17 +40,28 @@ type CredentialsUc interface {
 	UpdateConnection(ctx context.Context, receiverPlatformId string) (*domain.Platform, error)
 	// AcceptConnection accepts connection from the remote platform
 	AcceptConnection(ctx context.Context, senderPlatformId string, rq *model.OcpiCredentials) (*model.OcpiCredentials, error)
+	// DisconnectPlatform deletes credentials on the remote platform, wipes connection tokens and handles platform's data according to the policy
+	// data cleanup failure is reported in the platform's DataCleanup, disconnecting the suspended platform again retries cleanup
+	DisconnectPlatform(ctx context.Context, platformId, dataPolicy string) (*domain.Platform, error)
 	// OnRemoteGetCredentials remote platform requests local credentials
 	OnRemoteGetCredentials(ctx context.Context, platformId string) (*model.OcpiCredentials, error)
 	// OnRemoteDeleteCredentials remote platform requests deletes credentials
 	OnRemoteDeleteCredentials(ctx context.Context, platformId string) error
 	// OnRemotePartyPull initiates by cron, goes through all the connected platforms, retrieves and updates current list of parties
 	OnRemotePartyPull(ctx context.Context) error
+	// GetPlatform retrieves platform with runtime state of the client
+	GetPlatform(ctx context.Context, platformId string) (*domain.Platform, error)
+	// RotateToken rotates credentials tokens of the connected platform keeping the previous token B valid during grace period
+	RotateToken(ctx context.Context, platformId string) (*domain.Platform, error)
+	// TokenRotationCronHandler rotates tokens of platforms whose tokens are about to expire according to rotation policy
+	TokenRotationCronHandler(ctx context.Context)
 	// OnLocalPartyChanged party changed in local platform
 	OnLocalPartyChanged(ctx context.Context, party *domain.Party) error
 }
 
 type RemotePlatformRepository interface {
+	// GetClientState retrieves runtime state of the client to the remote platform
+	GetClientState(ctx context.Context, platformId string) *domain.ClientState
 	// GetVersions requests versions of the remote platform
 	GetVersions(ctx context.Context, rq *OcpiRepositoryBaseRequest) (domain.Versions, error)
 	// GetVersionDetails requests version details  of the remote platform
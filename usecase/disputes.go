@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+)
+
+type DisputeConverter interface {
+	// DisputeDomainToBackend converts dispute domain to backend
+	DisputeDomainToBackend(d *domain.Dispute) *backend.Dispute
+	// DisputesDomainToBackend converts disputes domain to backend
+	DisputesDomainToBackend(ds []*domain.Dispute) []*backend.Dispute
+	// DisputeBackendToDomain converts dispute backend to domain
+	DisputeBackendToDomain(d *backend.Dispute) *domain.Dispute
+	// DisputeEvidenceBackendToDomain converts dispute evidence backend to domain
+	DisputeEvidenceBackendToDomain(ev *backend.DisputeEvidence) *domain.DisputeEvidence
+	// DisputeSummariesDomainToBackend converts dispute summaries domain to backend
+	DisputeSummariesDomainToBackend(rs []*domain.DisputeSummary) []*backend.DisputeSummary
+}
+
+type DisputeUc interface {
+	// OpenDispute opens a dispute against CDR received from a remote platform
+	OpenDispute(ctx context.Context, d *domain.Dispute) (*domain.Dispute, error)
+	// AddEvidence attaches evidence to the dispute, snapshot of session, charging periods or logs is taken at the moment
+	AddEvidence(ctx context.Context, id string, ev *domain.DisputeEvidence) (*domain.Dispute, error)
+	// SetStatus changes status of the dispute
+	SetStatus(ctx context.Context, id, status, comment, creditCdrId string) (*domain.Dispute, error)
+	// OnCdrChanged resolves disputes of the credited CDR when a credit CDR is received
+	OnCdrChanged(ctx context.Context, cdr *domain.Cdr) error
+	// GetDisputeSummary retrieves disputes grouped by partner
+	GetDisputeSummary(ctx context.Context) ([]*domain.DisputeSummary, error)
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+)
+
+type HealthUc interface {
+	// Init initializes health checks
+	Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error
+	// CheckPlatform checks availability of the remote platform and moves it OFFLINE and back to CONNECTED if thresholds are reached
+	CheckPlatform(ctx context.Context, platformId string) (*domain.Platform, error)
+	// HealthCheckCronHandler checks availability of all connected remote platforms (fired by cron)
+	HealthCheckCronHandler(ctx context.Context)
+}
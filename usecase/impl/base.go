@@ -51,6 +51,17 @@ func (u *ucBase) getConnectedPlatform(ctx context.Context, platformId string) (*
 	return platform, nil
 }
 
+// validateBulk checks size of the bulk request before items are processed one by one
+func (u *ucBase) validateBulk(ctx context.Context, size int) error {
+	if size == 0 {
+		return errors.ErrBulkEmpty(ctx)
+	}
+	if size > domain.BulkMaxSize {
+		return errors.ErrBulkTooLarge(ctx, domain.BulkMaxSize)
+	}
+	return nil
+}
+
 func (u *ucBase) getCreateParty(ctx context.Context, platformId, partyId, countryCode string) (*domain.Party, error) {
 	party, err := u.partyService.GetByExtId(ctx, domain.PartyExtId{PartyId: partyId, CountryCode: countryCode})
 	if err != nil {
@@ -76,6 +87,19 @@ func (u *ucBase) getCreateParty(ctx context.Context, platformId, partyId, countr
 	return party, nil
 }
 
+// getCreateParties gets or creates parties of items put in a batch, so that each party is requested once
+// returns errors by party ext id
+func (u *ucBase) getCreateParties(ctx context.Context, platformId string, extIds []domain.PartyExtId) map[domain.PartyExtId]error {
+	rs := make(map[domain.PartyExtId]error)
+	for _, extId := range extIds {
+		if _, ok := rs[extId]; ok {
+			continue
+		}
+		_, rs[extId] = u.getCreateParty(ctx, platformId, extId.PartyId, extId.CountryCode)
+	}
+	return rs
+}
+
 func (u *ucBase) getLocalParties(ctx context.Context, localPlatform *domain.Platform) ([]*domain.Party, error) {
 	searchRq := &domain.PartySearchCriteria{
 		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(999)},
@@ -88,6 +112,28 @@ func (u *ucBase) getLocalParties(ctx context.Context, localPlatform *domain.Plat
 	return rs.Items, nil
 }
 
+// getVersionParty returns a party of the remote platform if the negotiated version doesn't pass party attributes in objects (2.1.1)
+// returns nil if party attributes are passed
+func (u *ucBase) getVersionParty(ctx context.Context, platform *domain.Platform) (*model.OcpiPartyId, error) {
+	if platform.VersionInfo.Current != model.OcpiVersion211 {
+		return nil, nil
+	}
+	rs, err := u.partyService.Search(ctx, &domain.PartySearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(1)},
+		IncPlatforms: []string{platform.Id},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rs.Items) == 0 {
+		return nil, errors.ErrPlatformPartyNotFound(ctx, platform.Id)
+	}
+	return &model.OcpiPartyId{
+		PartyId:     rs.Items[0].ExtId.PartyId,
+		CountryCode: rs.Items[0].ExtId.CountryCode,
+	}, nil
+}
+
 func (u *ucBase) setToPartyCtx(ctx context.Context, extId domain.PartyExtId) context.Context {
 	if rqCtx, ok := kit.Request(ctx); ok {
 		rqCtx.WithKv(model.OcpiCtxToParty, extId.PartyId)
@@ -572,6 +618,9 @@ func buildOcpiRepositoryErrHandlerRequestG[T any](ep domain.Endpoint, tkn domain
 				Token:          tkn,
 				FromPlatformId: fromPlatform.Id,
 				ToPlatformId:   toPlatform.Id,
+				Version:        toPlatform.VersionInfo.Current,
+				ToPlatformRole: toPlatform.Role,
+				ClientSettings: toPlatform.Client,
 			},
 			Handler: func(err error) { l.F(kit.KV{"platform": toPlatform.Id}).E(err).St().Err() },
 		},
@@ -586,6 +635,9 @@ func buildOcpiRepositoryErrHandlerRequest(ep domain.Endpoint, tkn domain.Platfor
 			Token:          tkn,
 			FromPlatformId: fromPlatform.Id,
 			ToPlatformId:   toPlatform.Id,
+			Version:        toPlatform.VersionInfo.Current,
+			ToPlatformRole: toPlatform.Role,
+			ClientSettings: toPlatform.Client,
 		},
 		Handler: func(err error) { l.F(kit.KV{"platform": toPlatform.Id}).E(err).St().Err() },
 	}
@@ -597,6 +649,9 @@ func buildOcpiRepositoryRequest(ep domain.Endpoint, tkn domain.PlatformToken, fr
 		Token:          tkn,
 		FromPlatformId: fromPlatform.Id,
 		ToPlatformId:   toPlatform.Id,
+		Version:        toPlatform.VersionInfo.Current,
+		ToPlatformRole: toPlatform.Role,
+		ClientSettings: toPlatform.Client,
 	}
 }
 
@@ -607,6 +662,9 @@ func buildOcpiRepositoryRequestG[T any](ep domain.Endpoint, tkn domain.PlatformT
 			Token:          tkn,
 			FromPlatformId: fromPlatform.Id,
 			ToPlatformId:   toPlatform.Id,
+			Version:        toPlatform.VersionInfo.Current,
+			ToPlatformRole: toPlatform.Role,
+			ClientSettings: toPlatform.Client,
 		},
 		Request: rq,
 	}
@@ -619,7 +677,21 @@ func buildOcpiRepositoryIdRequest(ep domain.Endpoint, tkn domain.PlatformToken,
 			Token:          tkn,
 			FromPlatformId: fromPlatform.Id,
 			ToPlatformId:   toPlatform.Id,
+			Version:        toPlatform.VersionInfo.Current,
+			ToPlatformRole: toPlatform.Role,
+			ClientSettings: toPlatform.Client,
 		},
 		Id: id,
 	}
 }
+
+// chunks splits items into chunks of the given size
+func chunks[T any](items []T, size int) [][]T {
+	var rs [][]T
+	for len(items) > 0 {
+		n := min(len(items), size)
+		rs = append(rs, items[:n])
+		items = items[n:]
+	}
+	return rs
+}
@@ -30,11 +30,13 @@ type cdrUc struct {
 	tariffService        domain.TariffService
 	localPlatformService domain.LocalPlatformService
 	tokenService         domain.TokenService
+	disputeUc            usecase.DisputeUc
+	validationCfg        *ocpi.CfgCdrValidation
 }
 
 func NewCdrUc(platformService domain.PlatformService, cdrService domain.CdrService, remoteCdrRep usecase.RemoteCdrRepository,
 	partyService domain.PartyService, webhook backend.WebhookCallService, sessService domain.SessionService, localPlatformService domain.LocalPlatformService,
-	locService domain.LocationService, tariffService domain.TariffService, tokenService domain.TokenService, tokenGen domain.TokenGenerator) usecase.CdrUc {
+	locService domain.LocationService, tariffService domain.TariffService, tokenService domain.TokenService, disputeUc usecase.DisputeUc, tokenGen domain.TokenGenerator) usecase.CdrUc {
 	return &cdrUc{
 		ucBase:               newBase(platformService, partyService, tokenGen),
 		cdrService:           cdrService,
@@ -46,7 +48,9 @@ func NewCdrUc(platformService domain.PlatformService, cdrService domain.CdrServi
 		locService:           locService,
 		tariffService:        tariffService,
 		tokenService:         tokenService,
+		disputeUc:            disputeUc,
 		converter:            NewCdrConverter(NewTariffConverter()),
+		validationCfg:        &ocpi.CfgCdrValidation{},
 	}
 }
 
@@ -54,6 +58,13 @@ func (s *cdrUc) l() kit.CLogger {
 	return ocpi.L().Cmp("cdr-uc")
 }
 
+func (s *cdrUc) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
+	if cfg != nil && cfg.Remote != nil && cfg.Remote.CdrValidation != nil {
+		s.validationCfg = cfg.Remote.CdrValidation
+	}
+	return nil
+}
+
 func (s *cdrUc) OnLocalCdrChanged(ctx context.Context, cdr *backend.Cdr) error {
 	l := s.l().C(ctx).Mth("on-cdr-changed-loc").F(kit.KV{"cdrId": cdr.Id}).Dbg()
 
@@ -105,20 +116,77 @@ func (s *cdrUc) OnLocalCdrChanged(ctx context.Context, cdr *backend.Cdr) error {
 		return nil
 	}
 
+	// push cdr to the eMSP
+	s.pushLocalCdr(ctx, localPlatform, platform, sess.ExtId, tkn.ExtId, cdrDom, l)
+
+	return nil
+}
+
+func (s *cdrUc) CreditCdr(ctx context.Context, cdrId string, rq *domain.CdrCredit) (*domain.Cdr, error) {
+	l := s.l().C(ctx).Mth("credit").F(kit.KV{"cdrId": cdrId}).Dbg()
+
+	// only cdrs of the local platform can be credited
+	stored, err := s.cdrService.GetCdr(ctx, cdrId)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, errors.ErrCdrCreditNotFound(ctx, cdrId)
+	}
+	if stored.PlatformId != s.localPlatformService.GetPlatformId(ctx) {
+		return nil, errors.ErrCdrCreditInvalidPlatform(ctx)
+	}
+
+	// local platform
+	localPlatform, err := s.localPlatformService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// credit cdr is sent to the eMSP of the original cdr token
+	if stored.Details.CdrToken == nil {
+		return nil, errors.ErrCdrTokenEmpty(ctx)
+	}
+	tkn, err := s.mustGetToken(ctx, stored.Details.CdrToken.Id)
+	if err != nil {
+		return nil, err
+	}
+	platform, err := s.getConnectedPlatform(ctx, tkn.PlatformId)
+	if err != nil {
+		return nil, err
+	}
+
+	// issue credit cdr
+	credit, orig, err := s.cdrService.Credit(ctx, cdrId, rq)
+	if err != nil {
+		return nil, err
+	}
+
+	// push credit cdr to the eMSP
+	s.pushLocalCdr(ctx, localPlatform, platform, orig.ExtId, tkn.ExtId, credit, l)
+
+	// call webhooks for the original cdr with the updated credited balance and the credit one
+	if err := s.webhook.OnCdrChanged(ctx, s.converter.CdrDomainToBackend(orig)); err != nil {
+		return nil, err
+	}
+	return credit, s.webhook.OnCdrChanged(ctx, s.converter.CdrDomainToBackend(credit))
+}
+
+// pushLocalCdr pushes local cdr to the eMSP platform if it supports push
+func (s *cdrUc) pushLocalCdr(ctx context.Context, localPlatform, platform *domain.Platform, from, to domain.PartyExtId, cdr *domain.Cdr, l kit.CLogger) {
+
 	// set header to route message
-	s.setFromPartyCtx(ctx, sess.ExtId)
-	s.setToPartyCtx(ctx, tkn.ExtId)
+	s.setFromPartyCtx(ctx, from)
+	s.setToPartyCtx(ctx, to)
 
 	ep := s.platformService.RoleEndpoint(ctx, platform, model.ModuleIdCdrs, model.OcpiReceiver)
 	if ep != "" && (platform.Protocol == nil || platform.Protocol.PushSupport.Cdrs) {
 		// push cdr to a remote platform
-		ocpiRq := buildOcpiRepositoryErrHandlerRequestG(ep, s.tokenC(platform), localPlatform, platform, s.converter.CdrDomainToModel(cdrDom), l)
+		ocpiRq := buildOcpiRepositoryErrHandlerRequestG(ep, s.tokenC(platform), localPlatform, platform, s.converter.CdrDomainToModel(cdr), l)
 		s.remoteCdrRep.PostCdrAsync(ctx, ocpiRq)
 	} else {
 		l.F(kit.KV{"platform": platform.Id}).Dbg("push not supported")
 	}
-
-	return nil
 }
 
 func (s *cdrUc) OnRemoteCdrsPull(ctx context.Context, from, to *time.Time) error {
@@ -132,6 +200,19 @@ func (s *cdrUc) OnRemoteCdrsPull(ctx context.Context, from, to *time.Time) error
 	return s.remoteCdrsPull(ctx, from, to, platforms)
 }
 
+func (s *cdrUc) OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error {
+	// get platforms to pull from
+	platforms, err := s.getPlatformsToPull(ctx)
+	if err != nil {
+		return err
+	}
+
+	// retrieve only the requested platform
+	platforms = kit.Filter(platforms, func(p *domain.Platform) bool { return p.Id == platformId })
+
+	return s.remoteCdrsPull(ctx, from, to, platforms)
+}
+
 func (s *cdrUc) OnRemoteCdrsPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error {
 	// get platforms to pull from
 	platforms, err := s.getPlatformsToPull(ctx)
@@ -164,13 +245,19 @@ func (s *cdrUc) OnRemoteCdrPut(ctx context.Context, platformId string, cdr *mode
 	}
 
 	// get session
-	_, err = s.mustGetRemoteSession(ctx, cdr.SessionId)
+	sess, err := s.mustGetRemoteSession(ctx, cdr.SessionId)
 	if err != nil {
 		return err
 	}
 
+	// verify consistency
+	cdrDom := s.converter.CdrModelToDomain(cdr, platformId)
+	if err := s.verify(ctx, cdrDom, sess); err != nil {
+		return err
+	}
+
 	// merge evse to the local platform
-	cdrDom, err := s.cdrService.PutCdr(ctx, s.converter.CdrModelToDomain(cdr, platformId))
+	cdrDom, err = s.cdrService.PutCdr(ctx, cdrDom)
 	if err != nil {
 		return err
 	}
@@ -181,10 +268,21 @@ func (s *cdrUc) OnRemoteCdrPut(ctx context.Context, platformId string, cdr *mode
 		return nil
 	}
 
+	// resolve disputes by credit cdr
+	s.resolveDisputes(ctx, cdrDom)
+
 	// call webhook
 	return s.webhook.OnCdrChanged(ctx, s.converter.CdrDomainToBackend(cdrDom))
 }
 
+// resolveDisputes resolves disputes of the CDR credited by the given one
+// failure doesn't prevent the CDR from being accepted, disputes can be resolved manually
+func (s *cdrUc) resolveDisputes(ctx context.Context, cdr *domain.Cdr) {
+	if err := s.disputeUc.OnCdrChanged(ctx, cdr); err != nil {
+		s.l().C(ctx).Mth("resolve-disputes").E(err).St().Err()
+	}
+}
+
 func (s *cdrUc) getPlatformsToPull(ctx context.Context) ([]*domain.Platform, error) {
 	platforms, err := s.platformService.Search(ctx, &domain.PlatformSearchCriteria{
 		Statuses: []string{domain.ConnectionStatusConnected}, // connected platforms
@@ -334,6 +432,110 @@ func (s *cdrUc) mustGetToken(ctx context.Context, id string) (*domain.Token, err
 	return tkn, nil
 }
 
+// putRemoteCdrs puts cdrs pulled from the remote platform in a single batch
+// items which cannot be put are logged and skipped
+func (s *cdrUc) putRemoteCdrs(ctx context.Context, platformId string, cdrs []*model.OcpiCdr) error {
+	l := s.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "count": len(cdrs)}).Dbg()
+
+	// get and check platform
+	_, err := s.getConnectedPlatform(ctx, platformId)
+	if err != nil {
+		return err
+	}
+
+	localPlatformId := s.localPlatformService.GetPlatformId(ctx)
+
+	// check cdrs are of the remote platform
+	stored, err := s.cdrService.SearchCdrs(ctx, &domain.CdrSearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(len(cdrs))},
+		Ids:          kit.Select(cdrs, func(cdr *model.OcpiCdr) string { return cdr.Id }),
+		IncPlatforms: []string{localPlatformId},
+	})
+	if err != nil {
+		return err
+	}
+	local := make(map[string]struct{}, len(stored.Items))
+	for _, cdr := range stored.Items {
+		local[cdr.Id] = struct{}{}
+	}
+
+	// get sessions
+	sessIds := kit.Filter(kit.Select(cdrs, func(cdr *model.OcpiCdr) string { return cdr.SessionId }), func(id string) bool { return id != "" })
+	sessions := make(map[string]*domain.Session, len(sessIds))
+	if len(sessIds) > 0 {
+		rs, err := s.sessService.SearchSessions(ctx, &domain.SessionSearchCriteria{
+			PageRequest: domain.PageRequest{Limit: kit.IntPtr(len(sessIds))},
+			Ids:         sessIds,
+		})
+		if err != nil {
+			return err
+		}
+		for _, sess := range rs.Items {
+			sessions[sess.Id] = sess
+		}
+	}
+
+	logErr := func(id string, err error) {
+		s.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "cdrId": id}).E(err).St().Err()
+	}
+
+	cdrDoms := make([]*domain.Cdr, 0, len(cdrs))
+	for _, cdr := range cdrs {
+		if _, ok := local[cdr.Id]; ok {
+			logErr(cdr.Id, errors.ErrCdrInvalidPlatform(ctx))
+			continue
+		}
+		if cdr.SessionId == "" {
+			logErr(cdr.Id, errors.ErrCdrSessionIdEmpty(ctx))
+			continue
+		}
+		sess, ok := sessions[cdr.SessionId]
+		if !ok {
+			logErr(cdr.Id, errors.ErrSessNotFound(ctx))
+			continue
+		}
+		if sess.PlatformId == localPlatformId {
+			logErr(cdr.Id, errors.ErrCdrSessInvalidPlatform(ctx))
+			continue
+		}
+		cdrDom := s.converter.CdrModelToDomain(cdr, platformId)
+		if err := s.verify(ctx, cdrDom, sess); err != nil {
+			logErr(cdr.Id, err)
+			continue
+		}
+		cdrDoms = append(cdrDoms, cdrDom)
+	}
+	if len(cdrDoms) == 0 {
+		return nil
+	}
+
+	// put cdrs to the local platform
+	applied, results, err := s.cdrService.PutCdrs(ctx, cdrDoms)
+	if err != nil {
+		return err
+	}
+	for _, rs := range results {
+		if rs.Err != nil {
+			logErr(rs.Id, rs.Err)
+		}
+	}
+
+	// no changes
+	if len(applied) == 0 {
+		l.Warn("no changes applied")
+		return nil
+	}
+
+	// call webhook
+	for _, cdr := range applied {
+		s.resolveDisputes(ctx, cdr)
+		if err := s.webhook.OnCdrChanged(ctx, s.converter.CdrDomainToBackend(cdr)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *cdrUc) remoteCdrsPull(ctx context.Context, from, to *time.Time, platforms []*domain.Platform) error {
 	l := s.l().C(ctx).Mth("remote-pull").Dbg()
 
@@ -350,11 +552,11 @@ func (s *cdrUc) remoteCdrsPull(ctx context.Context, from, to *time.Time, platfor
 	for i := 0; i < cdrWorkersNum; i++ {
 		goroutine.New().WithLogger(l).Go(ctx, func() {
 			for v := range ch {
-				cdr := v.data.(*model.OcpiCdr)
-				err := s.OnRemoteCdrPut(ctx, v.platformId, cdr)
+				cdrs := v.data.([]*model.OcpiCdr)
+				err := s.putRemoteCdrs(ctx, v.platformId, cdrs)
 				if err != nil {
 					s.l().C(ctx).Mth("remote-put").
-						F(kit.KV{"platformId": v.platformId, "cdrId": cdr.Id}).
+						F(kit.KV{"platformId": v.platformId, "count": len(cdrs)}).
 						E(err).St().Err()
 				}
 			}
@@ -372,9 +574,23 @@ func (s *cdrUc) remoteCdrsPull(ctx context.Context, from, to *time.Time, platfor
 			continue
 		}
 		eg.Go(func() error {
+			// objects of some versions come without party attributes
+			party, err := s.getVersionParty(ctx, platform)
+			if err != nil {
+				return err
+			}
 			for cdrs := range pgReader.GetPage(ctx, buildOcpiRepositoryRequest(ep, s.tokenC(platform), localPlatform, platform), cdrPageSize, from, to) {
 				for _, cdr := range cdrs {
-					ch <- channelData{platformId: platform.Id, data: cdr}
+					if party != nil {
+						cdr.OcpiPartyId = *party
+						if cdr.CdrToken != nil && cdr.CdrToken.PartyId == "" {
+							cdr.CdrToken.OcpiPartyId = *party
+						}
+					}
+				}
+				// pages are put in batches by workers
+				for _, batch := range chunks(cdrs, domain.PageSizeMaxLimit) {
+					ch <- channelData{platformId: platform.Id, data: batch}
 				}
 			}
 			return nil
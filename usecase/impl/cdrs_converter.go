@@ -70,6 +70,7 @@ func (t *cdrConverter) CdrDomainToModel(cdr *domain.Cdr) *model.OcpiCdr {
 		Credit:                   cdr.Details.Credit,
 		CreditReferenceId:        cdr.Details.CreditReferenceId,
 		HomeChargingCompensation: cdr.Details.HomeChargingCompensation,
+		TaxAmounts:               t.taxAmountsDomainToModel(cdr.Details.TaxAmounts),
 		LastUpdated:              cdr.LastUpdated,
 	}
 }
@@ -134,6 +135,7 @@ func (t *cdrConverter) CdrModelToDomain(cdr *model.OcpiCdr, platformId string) *
 			Credit:                   cdr.Credit,
 			CreditReferenceId:        cdr.CreditReferenceId,
 			HomeChargingCompensation: cdr.HomeChargingCompensation,
+			TaxAmounts:               t.taxAmountsModelToDomain(cdr.TaxAmounts),
 		},
 	}
 }
@@ -165,6 +167,10 @@ func (t *cdrConverter) CdrDomainToBackend(cdr *domain.Cdr) *backend.Cdr {
 		Credit:                   cdr.Details.Credit,
 		CreditReferenceId:        cdr.Details.CreditReferenceId,
 		HomeChargingCompensation: cdr.Details.HomeChargingCompensation,
+		TaxAmounts:               t.taxAmountsDomainToBackend(cdr.Details.TaxAmounts),
+		Issues:                   t.issuesDomainToBackend(cdr.Details.Issues),
+		Credited:                 t.priceDomainToBackend(cdr.Details.Credited),
+		CreditCdrIds:             cdr.Details.CreditCdrIds,
 		LastUpdated:              cdr.LastUpdated,
 		PlatformId:               cdr.PlatformId,
 		RefId:                    cdr.RefId,
@@ -181,6 +187,21 @@ func (t *cdrConverter) CdrsDomainToBackend(ts []*domain.Cdr) []*backend.Cdr {
 	return r
 }
 
+func (t *cdrConverter) CdrIssueReportsDomainToBackend(rs []*domain.CdrIssueReport) []*backend.CdrIssueReport {
+	r := make([]*backend.CdrIssueReport, 0, len(rs))
+	for _, i := range rs {
+		r = append(r, &backend.CdrIssueReport{
+			PlatformId:  i.PlatformId,
+			PartyId:     i.PartyId,
+			CountryCode: i.CountryCode,
+			Warnings:    i.Warnings,
+			Errors:      i.Errors,
+			Cdrs:        i.Cdrs,
+		})
+	}
+	return r
+}
+
 func (t *cdrConverter) CdrBackendToDomain(cdr *backend.Cdr, sess *domain.Session, loc *domain.Location, evse *domain.Evse, con *domain.Connector) *domain.Cdr {
 	if cdr == nil {
 		return nil
@@ -238,6 +259,7 @@ func (t *cdrConverter) CdrBackendToDomain(cdr *backend.Cdr, sess *domain.Session
 			Credit:                   cdr.Credit,
 			CreditReferenceId:        cdr.CreditReferenceId,
 			HomeChargingCompensation: cdr.HomeChargingCompensation,
+			TaxAmounts:               t.taxAmountsBackendToDomain(cdr.TaxAmounts),
 		},
 	}
 }
@@ -267,3 +289,78 @@ func (t *cdrConverter) SignedDataModelToDomain(sd *model.OcpiSignedData) *domain
 		Url:                   sd.Url,
 	}
 }
+
+func (t *cdrConverter) taxAmountsDomainToModel(tas []*domain.TaxAmount) []*model.OcpiTaxAmount {
+	var r []*model.OcpiTaxAmount
+	for _, ta := range tas {
+		r = append(r, &model.OcpiTaxAmount{
+			Name:          ta.Name,
+			AccountNumber: ta.AccountNumber,
+			Percentage:    ta.Percentage,
+			Amount:        ta.Amount,
+		})
+	}
+	return r
+}
+
+func (t *cdrConverter) taxAmountsModelToDomain(tas []*model.OcpiTaxAmount) []*domain.TaxAmount {
+	var r []*domain.TaxAmount
+	for _, ta := range tas {
+		r = append(r, &domain.TaxAmount{
+			Name:          ta.Name,
+			AccountNumber: ta.AccountNumber,
+			Percentage:    ta.Percentage,
+			Amount:        ta.Amount,
+		})
+	}
+	return r
+}
+
+func (t *cdrConverter) taxAmountsDomainToBackend(tas []*domain.TaxAmount) []*backend.TaxAmount {
+	var r []*backend.TaxAmount
+	for _, ta := range tas {
+		r = append(r, &backend.TaxAmount{
+			Name:          ta.Name,
+			AccountNumber: ta.AccountNumber,
+			Percentage:    ta.Percentage,
+			Amount:        ta.Amount,
+		})
+	}
+	return r
+}
+
+func (t *cdrConverter) taxAmountsBackendToDomain(tas []*backend.TaxAmount) []*domain.TaxAmount {
+	var r []*domain.TaxAmount
+	for _, ta := range tas {
+		r = append(r, &domain.TaxAmount{
+			Name:          ta.Name,
+			AccountNumber: ta.AccountNumber,
+			Percentage:    ta.Percentage,
+			Amount:        ta.Amount,
+		})
+	}
+	return r
+}
+
+func (t *cdrConverter) issuesDomainToBackend(issues []*domain.CdrIssue) []*backend.CdrIssue {
+	var r []*backend.CdrIssue
+	for _, i := range issues {
+		r = append(r, &backend.CdrIssue{
+			Severity: i.Severity,
+			Code:     i.Code,
+			Message:  i.Message,
+		})
+	}
+	return r
+}
+
+func (t *cdrConverter) CdrCreditBackendToDomain(rq *backend.CdrCreditRequest) *domain.CdrCredit {
+	if rq == nil {
+		return nil
+	}
+	return &domain.CdrCredit{
+		Id:     rq.Id,
+		Amount: t.priceBackendToDomain(rq.Amount),
+		Remark: rq.Remark,
+	}
+}
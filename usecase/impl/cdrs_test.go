@@ -3,12 +3,28 @@ package impl
 import (
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
 
 type cdrUcTestSuite struct {
 	kit.Suite
+	platformService      *mocks.PlatformService
+	cdrService           *mocks.CdrService
+	sessService          *mocks.SessionService
+	localPlatformService *mocks.LocalPlatformService
+	webhook              *mocks.WebhookCallService
+	disputeUc            *mocks.DisputeUc
+	remoteCdrRep         *mocks.RemoteCdrRepository
+	tokenService         *mocks.TokenService
+	uc                   *cdrUc
 }
 
 func (s *cdrUcTestSuite) SetupSuite() {
@@ -16,6 +32,18 @@ func (s *cdrUcTestSuite) SetupSuite() {
 }
 
 func (s *cdrUcTestSuite) SetupTest() {
+	s.platformService = &mocks.PlatformService{}
+	s.cdrService = &mocks.CdrService{}
+	s.sessService = &mocks.SessionService{}
+	s.localPlatformService = &mocks.LocalPlatformService{}
+	s.webhook = &mocks.WebhookCallService{}
+	s.disputeUc = &mocks.DisputeUc{}
+	s.remoteCdrRep = &mocks.RemoteCdrRepository{}
+	s.tokenService = &mocks.TokenService{}
+	s.uc = NewCdrUc(s.platformService, s.cdrService, s.remoteCdrRep, &mocks.PartyService{}, s.webhook, s.sessService,
+		s.localPlatformService, &mocks.LocationService{}, &mocks.TariffService{}, s.tokenService, s.disputeUc, nil).(*cdrUc)
+	s.localPlatformService.On("GetPlatformId", mock.Anything).Return("local")
+	s.disputeUc.On("OnCdrChanged", mock.Anything, mock.Anything).Return(nil)
 }
 
 func (s *cdrUcTestSuite) TearDownSuite() {}
@@ -23,3 +51,119 @@ func (s *cdrUcTestSuite) TearDownSuite() {}
 func TestCdrUcSuite(t *testing.T) {
 	suite.Run(t, new(cdrUcTestSuite))
 }
+
+func (s *cdrUcTestSuite) remoteCdrPut(issues []*domain.CdrIssue) (*model.OcpiCdr, *domain.Session) {
+	s.platformService.On("Get", s.Ctx, "remote").Return(&domain.Platform{Id: "remote", Status: domain.ConnectionStatusConnected}, nil)
+	cdr := &model.OcpiCdr{Id: kit.NewId(), SessionId: kit.NewId(), LastUpdated: kit.Now()}
+	sess := &domain.Session{Id: cdr.SessionId, OcpiItem: domain.OcpiItem{PlatformId: "remote"}}
+	s.cdrService.On("GetCdr", s.Ctx, cdr.Id).Return(nil, nil)
+	s.sessService.On("GetSession", s.Ctx, sess.Id).Return(sess, nil)
+	s.cdrService.On("Verify", s.Ctx, mock.Anything, sess).Return(issues)
+	return cdr, sess
+}
+
+func (s *cdrUcTestSuite) Test_OnRemoteCdrPut_IssuesStored() {
+	issues := []*domain.CdrIssue{{Severity: domain.CdrIssueSeverityError, Code: domain.CdrIssueEnergy, Message: "energy"}}
+	cdr, _ := s.remoteCdrPut(issues)
+	s.cdrService.On("PutCdr", s.Ctx, mock.MatchedBy(func(c *domain.Cdr) bool { return c.Id == cdr.Id && len(c.Details.Issues) == 1 })).
+		Return(&domain.Cdr{Id: cdr.Id, Details: domain.CdrDetails{Issues: issues}}, nil)
+	s.webhook.On("OnCdrChanged", s.Ctx, mock.Anything).Return(nil)
+
+	s.NoError(s.uc.OnRemoteCdrPut(s.Ctx, "remote", cdr))
+	s.webhook.AssertCalled(s.T(), "OnCdrChanged", s.Ctx, mock.MatchedBy(func(c *backend.Cdr) bool {
+		return len(c.Issues) == 1 && c.Issues[0].Code == domain.CdrIssueEnergy
+	}))
+}
+
+func (s *cdrUcTestSuite) Test_OnRemoteCdrPut_StrictRejected() {
+	s.NoError(s.uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{CdrValidation: &ocpi.CfgCdrValidation{Strict: true}}}))
+	issues := []*domain.CdrIssue{{Severity: domain.CdrIssueSeverityError, Code: domain.CdrIssueEnergy, Message: "energy"}}
+	cdr, _ := s.remoteCdrPut(issues)
+
+	s.AssertAppErr(s.uc.OnRemoteCdrPut(s.Ctx, "remote", cdr), "OCPI-247")
+	s.cdrService.AssertNotCalled(s.T(), "PutCdr", mock.Anything, mock.Anything)
+}
+
+func (s *cdrUcTestSuite) Test_OnRemoteCdrPut_StrictWarningAccepted() {
+	s.NoError(s.uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{CdrValidation: &ocpi.CfgCdrValidation{Strict: true}}}))
+	issues := []*domain.CdrIssue{{Severity: domain.CdrIssueSeverityWarning, Code: domain.CdrIssueEnergy, Message: "energy"}}
+	cdr, _ := s.remoteCdrPut(issues)
+	s.cdrService.On("PutCdr", s.Ctx, mock.Anything).Return(&domain.Cdr{Id: cdr.Id, Details: domain.CdrDetails{Issues: issues}}, nil)
+	s.webhook.On("OnCdrChanged", s.Ctx, mock.Anything).Return(nil)
+
+	s.NoError(s.uc.OnRemoteCdrPut(s.Ctx, "remote", cdr))
+	s.cdrService.AssertNumberOfCalls(s.T(), "PutCdr", 1)
+}
+
+func (s *cdrUcTestSuite) Test_GetCdrIssues_GroupedByPartner() {
+	p1 := domain.OcpiItem{PlatformId: "p1", ExtId: domain.PartyExtId{PartyId: "AAA", CountryCode: "RS"}}
+	p2 := domain.OcpiItem{PlatformId: "p2", ExtId: domain.PartyExtId{PartyId: "BBB", CountryCode: "RS"}}
+	warn := []*domain.CdrIssue{{Severity: domain.CdrIssueSeverityWarning}}
+	errs := []*domain.CdrIssue{{Severity: domain.CdrIssueSeverityWarning}, {Severity: domain.CdrIssueSeverityError}}
+	s.cdrService.On("SearchCdrs", s.Ctx, mock.MatchedBy(func(cr *domain.CdrSearchCriteria) bool {
+		return cr.WithIssues && len(cr.ExcPlatforms) == 1 && cr.ExcPlatforms[0] == "local"
+	})).Return(&domain.CdrSearchResponse{Items: []*domain.Cdr{
+		{Id: "c1", OcpiItem: p2, Details: domain.CdrDetails{Issues: warn}},
+		{Id: "c2", OcpiItem: p1, Details: domain.CdrDetails{Issues: errs}},
+		{Id: "c3", OcpiItem: p1, Details: domain.CdrDetails{Issues: warn}},
+	}}, nil)
+
+	rs, err := s.uc.GetCdrIssues(s.Ctx)
+	s.NoError(err)
+	s.Len(rs, 2)
+	s.Equal("p1", rs[0].PlatformId)
+	s.Equal(1, rs[0].Errors)
+	s.Equal(1, rs[0].Warnings)
+	s.Equal([]string{"c2", "c3"}, rs[0].Cdrs)
+	s.Equal("p2", rs[1].PlatformId)
+	s.Equal(0, rs[1].Errors)
+	s.Equal(1, rs[1].Warnings)
+}
+
+func (s *cdrUcTestSuite) Test_OnRemoteCdrPut_CreditResolvesDisputes() {
+	cdr, _ := s.remoteCdrPut(nil)
+	credit := &domain.Cdr{Id: cdr.Id, Details: domain.CdrDetails{Credit: true, CreditReferenceId: kit.NewId()}}
+	s.cdrService.On("PutCdr", s.Ctx, mock.Anything).Return(credit, nil)
+	s.webhook.On("OnCdrChanged", s.Ctx, mock.Anything).Return(nil)
+
+	s.NoError(s.uc.OnRemoteCdrPut(s.Ctx, "remote", cdr))
+	s.disputeUc.AssertCalled(s.T(), "OnCdrChanged", s.Ctx, credit)
+}
+
+func (s *cdrUcTestSuite) Test_CreditCdr_Pushed() {
+	orig := &domain.Cdr{
+		OcpiItem: domain.OcpiItem{ExtId: domain.PartyExtId{PartyId: "TST", CountryCode: "RS"}, PlatformId: "local"},
+		Id:       kit.NewId(),
+		Details:  domain.CdrDetails{CdrToken: &domain.CdrToken{Id: kit.NewId()}},
+	}
+	tkn := &domain.Token{OcpiItem: domain.OcpiItem{ExtId: domain.PartyExtId{PartyId: "EMS", CountryCode: "RS"}, PlatformId: "remote"}, Id: orig.Details.CdrToken.Id}
+	platform := &domain.Platform{Id: "remote", Status: domain.ConnectionStatusConnected}
+	credit := &domain.Cdr{OcpiItem: orig.OcpiItem, Id: kit.NewId(), Details: domain.CdrDetails{Credit: true, CreditReferenceId: orig.Id}}
+	rq := &domain.CdrCredit{Amount: &domain.Price{ExclVat: 5}}
+
+	s.cdrService.On("GetCdr", s.Ctx, orig.Id).Return(orig, nil)
+	s.localPlatformService.On("Get", s.Ctx).Return(&domain.Platform{Id: "local"}, nil)
+	s.tokenService.On("GetToken", s.Ctx, tkn.Id).Return(tkn, nil)
+	s.platformService.On("Get", s.Ctx, platform.Id).Return(platform, nil)
+	s.platformService.On("RoleEndpoint", s.Ctx, platform, model.ModuleIdCdrs, model.OcpiReceiver).Return(domain.Endpoint("https://emsp/cdrs"))
+	s.cdrService.On("Credit", s.Ctx, orig.Id, rq).Return(credit, orig, nil)
+	s.remoteCdrRep.On("PostCdrAsync", s.Ctx, mock.Anything).Return()
+	s.webhook.On("OnCdrChanged", s.Ctx, mock.Anything).Return(nil)
+
+	act, err := s.uc.CreditCdr(s.Ctx, orig.Id, rq)
+	s.NoError(err)
+	s.Equal(credit, act)
+	s.remoteCdrRep.AssertCalled(s.T(), "PostCdrAsync", s.Ctx, mock.MatchedBy(func(rq *usecase.OcpiRepositoryErrHandlerRequestG[*model.OcpiCdr]) bool {
+		return rq.Request.Id == credit.Id && rq.Request.Credit && rq.Request.CreditReferenceId == orig.Id
+	}))
+	s.webhook.AssertNumberOfCalls(s.T(), "OnCdrChanged", 2)
+}
+
+func (s *cdrUcTestSuite) Test_CreditCdr_RemoteCdr() {
+	orig := &domain.Cdr{OcpiItem: domain.OcpiItem{PlatformId: "remote"}, Id: kit.NewId()}
+	s.cdrService.On("GetCdr", s.Ctx, orig.Id).Return(orig, nil)
+
+	_, err := s.uc.CreditCdr(s.Ctx, orig.Id, &domain.CdrCredit{})
+	s.AssertAppErr(err, errors.ErrCodeCdrCreditInvalidPlatform)
+	s.cdrService.AssertNotCalled(s.T(), "Credit", mock.Anything, mock.Anything, mock.Anything)
+}
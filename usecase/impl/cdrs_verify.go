@@ -0,0 +1,63 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"slices"
+)
+
+// verify cross-checks the cdr and stores the findings with it
+// in strict mode cdr with errors is rejected
+func (s *cdrUc) verify(ctx context.Context, cdr *domain.Cdr, sess *domain.Session) error {
+	cdr.Details.Issues = s.cdrService.Verify(ctx, cdr, sess)
+	if len(cdr.Details.Issues) == 0 {
+		return nil
+	}
+
+	s.l().C(ctx).Mth("verify").F(kit.KV{"cdrId": cdr.Id, "platformId": cdr.PlatformId, "issues": len(cdr.Details.Issues)}).Warn("cdr inconsistent")
+
+	if !s.validationCfg.Strict {
+		return nil
+	}
+	for _, issue := range cdr.Details.Issues {
+		if issue.Severity == domain.CdrIssueSeverityError {
+			return errors.ErrCdrInconsistent(ctx, issue.Message)
+		}
+	}
+	return nil
+}
+
+func (s *cdrUc) GetCdrIssues(ctx context.Context) ([]*domain.CdrIssueReport, error) {
+	s.l().C(ctx).Mth("cdr-issues").Dbg()
+
+	reports := newPartnerReports(func(platformId string, extId domain.PartyExtId) *domain.CdrIssueReport {
+		return &domain.CdrIssueReport{PartyExtId: extId, PlatformId: platformId}
+	})
+	cr := &domain.CdrSearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(domain.PageSizeMaxLimit)},
+		ExcPlatforms: []string{s.localPlatformService.GetPlatformId(ctx)},
+		WithIssues:   true,
+	}
+	err := scanPages(&cr.PageRequest, func() ([]*domain.Cdr, *domain.PageRequest, error) {
+		rs, err := s.cdrService.SearchCdrs(ctx, cr)
+		if err != nil {
+			return nil, nil, err
+		}
+		return rs.Items, rs.NextPage, nil
+	}, func(cdr *domain.Cdr) {
+		r := reports.get(cdr.PlatformId, cdr.ExtId)
+		r.Cdrs = append(r.Cdrs, cdr.Id)
+		if slices.ContainsFunc(cdr.Details.Issues, func(i *domain.CdrIssue) bool { return i.Severity == domain.CdrIssueSeverityError }) {
+			r.Errors++
+		} else {
+			r.Warnings++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports.sorted(), nil
+}
@@ -17,6 +17,7 @@ import (
 const (
 	cmdTimeout       = time.Minute * 10
 	cmdTimeoutErrMsg = "command timed out"
+	cmdWaitMax       = time.Minute * 2
 )
 
 type commandUc struct {
@@ -31,11 +32,14 @@ type commandUc struct {
 	tokenUc              usecase.TokenUc
 	tokenService         domain.TokenService
 	sessionService       domain.SessionService
+	reservationUc        usecase.ReservationUc
+	resultNotifier       domain.CommandResultNotifier
+	selector             *connectorSelector
 }
 
 func NewCommandUc(platformService domain.PlatformService, commandService domain.CommandService, remoteCommandRep usecase.RemoteCommandRepository,
 	partyService domain.PartyService, locService domain.LocationService, webhook backend.WebhookCallService,
-	localPlatform domain.LocalPlatformService, tokenUc usecase.TokenUc, tokenService domain.TokenService, sessionService domain.SessionService, tokenGen domain.TokenGenerator) usecase.CommandUc {
+	localPlatform domain.LocalPlatformService, tokenUc usecase.TokenUc, tokenService domain.TokenService, sessionService domain.SessionService, reservationUc usecase.ReservationUc, resultNotifier domain.CommandResultNotifier, tokenGen domain.TokenGenerator) usecase.CommandUc {
 	return &commandUc{
 		ucBase:               newBase(platformService, partyService, tokenGen),
 		commandService:       commandService,
@@ -47,6 +51,9 @@ func NewCommandUc(platformService domain.PlatformService, commandService domain.
 		tokenUc:              tokenUc,
 		tokenService:         tokenService,
 		sessionService:       sessionService,
+		reservationUc:        reservationUc,
+		resultNotifier:       resultNotifier,
+		selector:             newConnectorSelector(),
 		converter:            NewCommandConverter(NewTokenConverter()),
 	}
 }
@@ -55,6 +62,13 @@ func (t *commandUc) l() kit.CLogger {
 	return ocpi.L().Cmp("cmd-uc")
 }
 
+func (t *commandUc) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
+	if cfg != nil && cfg.Remote != nil && cfg.Remote.Commands != nil {
+		return t.selector.init(ctx, cfg.Remote.Commands.ConnectorSelection)
+	}
+	return nil
+}
+
 func (t *commandUc) OnRemoteStartSession(ctx context.Context, platformId string, rq *model.OcpiStartSession) (*model.OcpiCommandResponse, error) {
 	t.l().C(ctx).Mth("on-start-sess-rem").F(kit.KV{"locId": rq.LocationId}).Dbg()
 
@@ -301,10 +315,54 @@ func (t *commandUc) OnRemoteSetResponse(ctx context.Context, platformId, uid str
 		return err
 	}
 
+	// track reservation
+	t.trackReservation(ctx, cmd)
+
+	// notify waiters of the command result
+	t.resultNotifier.NotifyCommandResult(ctx, cmd.Id)
+
 	// call webhook to local platform
 	return t.webhook.OnCommandResponse(ctx, t.converter.CommandDomainToBackend(cmd))
 }
 
+func (t *commandUc) WaitCommandResult(ctx context.Context, cmdId string, timeout time.Duration) (*domain.Command, error) {
+	l := t.l().C(ctx).Mth("wait-result").F(kit.KV{"cmdId": cmdId, "timeout": timeout}).Dbg()
+
+	// subscribe before checking the command, so that the result received in between isn't missed
+	notified, unsubscribe := t.resultNotifier.SubscribeCommandResult(ctx, cmdId)
+	defer unsubscribe()
+
+	cmd, err := t.commandService.Get(ctx, cmdId)
+	if err != nil {
+		return nil, err
+	}
+	if cmd == nil {
+		return nil, errors.ErrCmdCommandNotFound(ctx, cmdId)
+	}
+	if cmd.Status != domain.CmdStatusRequestAccepted {
+		return cmd, nil
+	}
+
+	// no reason to wait after the deadline, the command is expired by cron
+	timeout = min(timeout, cmdWaitMax, cmd.Deadline.Sub(kit.Now()))
+	if timeout <= 0 {
+		return cmd, nil
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-notified:
+	case <-timer.C:
+		// the result might be stored without notification (e.g. received by another instance), so it's retrieved anyway
+		l.Dbg("timed out")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return t.commandService.Get(ctx, cmdId)
+}
+
 func (t *commandUc) RemoteCommandsDeadlineCronHandler(ctx context.Context) {
 	l := t.l().C(ctx).Mth("remote-cmd-deadline").Dbg()
 
@@ -341,17 +399,17 @@ func (t *commandUc) OnLocalStartSession(ctx context.Context, rq *domain.Command)
 		return err
 	}
 
-	// get connector
-	con, err := t.locService.GetConnector(ctx, rq.Details.StartSession.LocationId, rq.Details.StartSession.EvseId, rq.Details.StartSession.ConnectorId)
+	// get location
+	loc, err := t.locService.GetLocation(ctx, rq.Details.StartSession.LocationId, true)
 	if err != nil {
 		return err
 	}
-	if con == nil {
-		return errors.ErrCmdConNotFound(ctx)
+	if loc == nil {
+		return errors.ErrLocationNotFound(ctx)
 	}
 
 	// platform
-	platform, err := t.getConnectedPlatform(ctx, con.PlatformId)
+	platform, err := t.getConnectedPlatform(ctx, loc.PlatformId)
 	if err != nil {
 		return err
 	}
@@ -361,6 +419,12 @@ func (t *commandUc) OnLocalStartSession(ctx context.Context, rq *domain.Command)
 		return errors.ErrLocNotBelongRemotePlatform(ctx)
 	}
 
+	// check EVSE or select it if not specified
+	err = t.selectStartSessionConnector(ctx, loc, rq.Details.StartSession)
+	if err != nil {
+		return err
+	}
+
 	// pre-populate
 	if rq.Id == "" {
 		rq.Id = kit.NewId()
@@ -371,7 +435,7 @@ func (t *commandUc) OnLocalStartSession(ctx context.Context, rq *domain.Command)
 	rq.Deadline = kit.Now().Add(cmdTimeout)
 
 	// set response url
-	rq.Details.ResponseUrl, err = t.getLocalResponseUrl(ctx, rq.Cmd, rq.Id)
+	rq.Details.ResponseUrl, err = t.getLocalResponseUrl(ctx, platform, rq.Cmd, rq.Id)
 	if err != nil {
 		return err
 	}
@@ -391,7 +455,7 @@ func (t *commandUc) OnLocalStartSession(ctx context.Context, rq *domain.Command)
 
 	// set header to route message
 	ctx = t.setFromPartyCtx(ctx, cmd.ExtId)
-	ctx = t.setToPartyCtx(ctx, con.ExtId)
+	ctx = t.setToPartyCtx(ctx, loc.ExtId)
 
 	ep := t.platformService.RoleEndpoint(ctx, platform, model.ModuleIdCommands, model.OcpiReceiver)
 	if ep != "" && (platform.Protocol == nil || platform.Protocol.PushSupport.Commands) {
@@ -438,6 +502,17 @@ func (t *commandUc) OnLocalStopSession(ctx context.Context, rq *domain.Command)
 		return errors.ErrLocNotBelongRemotePlatform(ctx)
 	}
 
+	// check if EVSE supports remote stop, the session is stopped anyway if EVSE is unknown
+	if sess.Details.EvseId != "" {
+		evse, err := t.locService.GetEvse(ctx, sess.Details.LocationId, sess.Details.EvseId, false)
+		if err != nil {
+			return err
+		}
+		if evse != nil && !t.selector.capable(evse, domain.CapabilityRemoteStartStop) {
+			return errors.ErrCmdEvseNotCapable(ctx, evse.Id, domain.CapabilityRemoteStartStop)
+		}
+	}
+
 	// pre-populate
 	if rq.Id == "" {
 		rq.Id = kit.NewId()
@@ -449,7 +524,7 @@ func (t *commandUc) OnLocalStopSession(ctx context.Context, rq *domain.Command)
 	rq.Deadline = kit.Now().Add(cmdTimeout)
 
 	// set response url
-	rq.Details.ResponseUrl, err = t.getLocalResponseUrl(ctx, rq.Cmd, rq.Id)
+	rq.Details.ResponseUrl, err = t.getLocalResponseUrl(ctx, platform, rq.Cmd, rq.Id)
 	if err != nil {
 		return err
 	}
@@ -486,7 +561,7 @@ func (t *commandUc) OnLocalReserve(ctx context.Context, rq *domain.Command) erro
 	}
 
 	// get location
-	loc, err := t.locService.GetLocation(ctx, rq.Details.Reserve.LocationId, false)
+	loc, err := t.locService.GetLocation(ctx, rq.Details.Reserve.LocationId, true)
 	if err != nil {
 		return err
 	}
@@ -505,6 +580,12 @@ func (t *commandUc) OnLocalReserve(ctx context.Context, rq *domain.Command) erro
 		return errors.ErrLocNotBelongRemotePlatform(ctx)
 	}
 
+	// check EVSE
+	err = t.validateReserveEvse(ctx, loc, rq.Details.Reserve)
+	if err != nil {
+		return err
+	}
+
 	// pre-populate
 	if rq.Id == "" {
 		rq.Id = kit.NewId()
@@ -515,7 +596,7 @@ func (t *commandUc) OnLocalReserve(ctx context.Context, rq *domain.Command) erro
 	rq.Deadline = kit.Now().Add(cmdTimeout)
 
 	// set response url
-	rq.Details.ResponseUrl, err = t.getLocalResponseUrl(ctx, rq.Cmd, rq.Id)
+	rq.Details.ResponseUrl, err = t.getLocalResponseUrl(ctx, platform, rq.Cmd, rq.Id)
 	if err != nil {
 		return err
 	}
@@ -615,7 +696,7 @@ func (t *commandUc) OnLocalCancelReservation(ctx context.Context, rq *domain.Com
 	rq.Deadline = kit.Now().Add(cmdTimeout)
 
 	// set response url
-	rq.Details.ResponseUrl, err = t.getLocalResponseUrl(ctx, rq.Cmd, rq.Id)
+	rq.Details.ResponseUrl, err = t.getLocalResponseUrl(ctx, platform, rq.Cmd, rq.Id)
 	if err != nil {
 		return err
 	}
@@ -676,6 +757,9 @@ func (t *commandUc) OnLocalCommandSetResponse(ctx context.Context, uid, status,
 		return err
 	}
 
+	// track reservation
+	t.trackReservation(ctx, cmd)
+
 	// get target platform
 	platform, err := t.getConnectedPlatform(ctx, cmd.PlatformId)
 	if err != nil {
@@ -737,6 +821,72 @@ func (t *commandUc) LocalCommandsDeadlineCronHandler(ctx context.Context) {
 	}
 }
 
+// selectStartSessionConnector checks the requested EVSE and connector against EVSE capabilities and status
+// if EVSE isn't specified, the best available one is selected according to the configured strategy
+func (t *commandUc) selectStartSessionConnector(ctx context.Context, loc *domain.Location, rq *domain.StartSession) error {
+
+	if rq.EvseId == "" {
+		if rq.ConnectorId != "" {
+			return errors.ErrCmdEmptyAttr(ctx, "start_session", "evse_id")
+		}
+		evse, con := t.selector.selectConnector(loc, domain.CapabilityRemoteStartStop)
+		if evse == nil {
+			return errors.ErrCmdNoAvailableEvse(ctx, loc.Id)
+		}
+		t.l().C(ctx).Mth("select-con").F(kit.KV{"locId": loc.Id, "evseId": evse.Id, "conId": con.Id}).Dbg("selected")
+		rq.EvseId = evse.Id
+		if t.selector.connectorRequired(evse) {
+			rq.ConnectorId = con.Id
+		}
+		return nil
+	}
+
+	evse := t.selector.findEvse(loc, rq.EvseId)
+	if evse == nil {
+		return errors.ErrCmdEvseNotFound(ctx, rq.EvseId)
+	}
+	if err := t.selector.validateEvse(ctx, evse, domain.CapabilityRemoteStartStop); err != nil {
+		return err
+	}
+
+	if rq.ConnectorId == "" {
+		if t.selector.connectorRequired(evse) {
+			return errors.ErrCmdConnectorRequired(ctx, evse.Id)
+		}
+		return nil
+	}
+	if t.selector.findConnector(evse, rq.ConnectorId) == nil {
+		return errors.ErrCmdConNotFound(ctx)
+	}
+	return nil
+}
+
+// validateReserveEvse checks the requested EVSE against EVSE capabilities and status
+// reservation of the whole location is checked by the CPO
+func (t *commandUc) validateReserveEvse(ctx context.Context, loc *domain.Location, rq *domain.ReserveNow) error {
+	if rq.EvseId == "" {
+		return nil
+	}
+	evse := t.selector.findEvse(loc, rq.EvseId)
+	if evse == nil {
+		return errors.ErrCmdEvseNotFound(ctx, rq.EvseId)
+	}
+	if err := t.selector.validateEvse(ctx, evse, domain.CapabilityReservable); err != nil {
+		return err
+	}
+	if rq.ConnectorId != "" && t.selector.findConnector(evse, rq.ConnectorId) == nil {
+		return errors.ErrCmdConNotFound(ctx)
+	}
+	return nil
+}
+
+// trackReservation tracks reservation by the command response, failure doesn't break the command response
+func (t *commandUc) trackReservation(ctx context.Context, cmd *domain.Command) {
+	if err := t.reservationUc.OnCommandResponse(ctx, cmd); err != nil {
+		t.l().C(ctx).Mth("track-res").F(kit.KV{"cmdId": cmd.Id}).E(err).St().Err()
+	}
+}
+
 func (t *commandUc) getOrCreateRemoteToken(ctx context.Context, platformId string, tkn *model.OcpiToken) (*domain.Token, error) {
 	t.l().C(ctx).Mth("get-create-tkn-rem").F(kit.KV{"tknId": tkn.Id}).Dbg()
 
@@ -760,10 +910,15 @@ func (t *commandUc) getOrCreateRemoteToken(ctx context.Context, platformId strin
 	return stored, nil
 }
 
-func (t *commandUc) getLocalResponseUrl(ctx context.Context, cmd, cmdId string) (domain.Endpoint, error) {
+func (t *commandUc) getLocalResponseUrl(ctx context.Context, platform *domain.Platform, cmd, cmdId string) (domain.Endpoint, error) {
 	locPl, err := t.localPlatformService.Get(ctx)
 	if err != nil {
 		return "", err
 	}
-	return domain.Endpoint(fmt.Sprintf("%s/%s/%s", locPl.Endpoints[model.ModuleIdCommands][model.OcpiSender], cmd, cmdId)), nil
+	endpoints := locPl.Endpoints
+	// response url must be exposed in the version negotiated with the remote platform
+	if platform.VersionInfo.Current != "" && platform.VersionInfo.Current != locPl.VersionInfo.Current {
+		endpoints = t.localPlatformService.GetEndpoints(ctx, platform.VersionInfo.Current)
+	}
+	return domain.Endpoint(fmt.Sprintf("%s/%s/%s", endpoints[model.ModuleIdCommands][model.OcpiSender], cmd, cmdId)), nil
 }
@@ -75,11 +75,13 @@ func (c *commandConverter) StartSessionCommandBackendToDomain(cmd *backend.Start
 		Cmd: domain.CmdStartSession,
 		Details: domain.CommandDetails{
 			StartSession: &domain.StartSession{
-				Token:       c.tokenConverter.TokenBackendToDomain(&cmd.Token, platformId),
-				LocationId:  cmd.LocationId,
-				EvseId:      cmd.EvseId,
-				ConnectorId: cmd.ConnectorId,
-				KwhLimit:    cmd.KwhLimit,
+				Token:             c.tokenConverter.TokenBackendToDomain(&cmd.Token, platformId),
+				LocationId:        cmd.LocationId,
+				EvseId:            cmd.EvseId,
+				ConnectorId:       cmd.ConnectorId,
+				KwhLimit:          cmd.KwhLimit,
+				CostLimit:         cmd.CostLimit,
+				CostLimitCurrency: cmd.CostLimitCurrency,
 			},
 		},
 		AuthRef: cmd.AuthorizationReference,
@@ -184,6 +186,8 @@ func (c *commandConverter) detailsDomainToBackend(cmd *domain.Command, det *doma
 			ConnectorId:            det.StartSession.ConnectorId,
 			AuthorizationReference: cmd.AuthRef,
 			KwhLimit:               det.StartSession.KwhLimit,
+			CostLimit:              det.StartSession.CostLimit,
+			CostLimitCurrency:      det.StartSession.CostLimitCurrency,
 		}
 	}
 	if det.StopSession != nil {
@@ -0,0 +1,141 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"slices"
+	"strings"
+)
+
+const (
+	ConnectorSelectionPower    = "power"    // ConnectorSelectionPower the most powerful connector is selected
+	ConnectorSelectionStandard = "standard" // ConnectorSelectionStandard connector of the most preferred standard is selected, then the most powerful one
+)
+
+var (
+	// evseUnavailableStatusMap EVSE in these statuses cannot accept commands
+	evseUnavailableStatusMap = map[string]struct{}{
+		domain.EvseStatusBlocked:     {},
+		domain.EvseStatusCharging:    {},
+		domain.EvseStatusInOperative: {},
+		domain.EvseStatusOutOfOrder:  {},
+		domain.EvseStatusPlanned:     {},
+		domain.EvseStatusRemoved:     {},
+	}
+)
+
+// connectorSelector validates EVSEs against commands and selects a connector when EVSE isn't specified
+type connectorSelector struct {
+	strategy  string
+	standards []string
+}
+
+func newConnectorSelector() *connectorSelector {
+	return &connectorSelector{strategy: ConnectorSelectionPower}
+}
+
+func (s *connectorSelector) init(ctx context.Context, cfg *ocpi.CfgConnectorSelection) error {
+	if cfg == nil {
+		return nil
+	}
+	switch cfg.Strategy {
+	case "":
+	case ConnectorSelectionPower, ConnectorSelectionStandard:
+		s.strategy = cfg.Strategy
+	default:
+		return errors.ErrCmdSelectionStrategyInvalid(ctx, cfg.Strategy)
+	}
+	s.standards = nil
+	for _, std := range strings.Split(cfg.Standards, ",") {
+		if std = strings.TrimSpace(std); std != "" {
+			s.standards = append(s.standards, std)
+		}
+	}
+	return nil
+}
+
+// capable checks if EVSE supports the capability
+// capabilities aren't mandatory, so EVSE without capabilities is considered capable of everything
+func (s *connectorSelector) capable(evse *domain.Evse, capability string) bool {
+	return len(evse.Details.Capabilities) == 0 || slices.Contains(evse.Details.Capabilities, capability)
+}
+
+// connectorRequired checks if CPO requires connector to start session on the EVSE
+func (s *connectorSelector) connectorRequired(evse *domain.Evse) bool {
+	return slices.Contains(evse.Details.Capabilities, domain.CapabilityStartSessionConReq)
+}
+
+// validateEvse checks if command with the given capability can be sent to the EVSE
+func (s *connectorSelector) validateEvse(ctx context.Context, evse *domain.Evse, capability string) error {
+	if !s.capable(evse, capability) {
+		return errors.ErrCmdEvseNotCapable(ctx, evse.Id, capability)
+	}
+	if _, ok := evseUnavailableStatusMap[evse.Status]; ok {
+		return errors.ErrCmdEvseNotAvailable(ctx, evse.Id, evse.Status)
+	}
+	return nil
+}
+
+// selectConnector selects the best connector among available EVSEs of the location capable of the command
+func (s *connectorSelector) selectConnector(loc *domain.Location, capability string) (*domain.Evse, *domain.Connector) {
+	var bestEvse *domain.Evse
+	var best *domain.Connector
+	for _, evse := range loc.Evses {
+		if evse.Status != domain.EvseStatusAvailable || !s.capable(evse, capability) {
+			continue
+		}
+		for _, con := range evse.Connectors {
+			if best == nil || s.better(con, best) {
+				bestEvse, best = evse, con
+			}
+		}
+	}
+	return bestEvse, best
+}
+
+// better checks if the connector is better than the other one according to the strategy
+func (s *connectorSelector) better(con, other *domain.Connector) bool {
+	if s.strategy == ConnectorSelectionStandard {
+		rank, otherRank := s.rank(con), s.rank(other)
+		if rank != otherRank {
+			return rank < otherRank
+		}
+	}
+	return s.power(con) > s.power(other)
+}
+
+// rank returns position of the connector standard in the preferred list, not preferred standards go last
+func (s *connectorSelector) rank(con *domain.Connector) int {
+	if i := slices.Index(s.standards, con.Details.Standard); i >= 0 {
+		return i
+	}
+	return len(s.standards)
+}
+
+// power returns max power of the connector in W
+func (s *connectorSelector) power(con *domain.Connector) float64 {
+	if con.Details.MaxElectricPower != nil {
+		return *con.Details.MaxElectricPower
+	}
+	return con.Details.MaxVoltage * con.Details.MaxAmperage
+}
+
+func (s *connectorSelector) findEvse(loc *domain.Location, evseId string) *domain.Evse {
+	for _, evse := range loc.Evses {
+		if evse.Id == evseId {
+			return evse
+		}
+	}
+	return nil
+}
+
+func (s *connectorSelector) findConnector(evse *domain.Evse, conId string) *domain.Connector {
+	for _, con := range evse.Connectors {
+		if con.Id == conId {
+			return con
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type connectorSelectorTestSuite struct {
+	kit.Suite
+}
+
+func (s *connectorSelectorTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func TestConnectorSelectorSuite(t *testing.T) {
+	suite.Run(t, new(connectorSelectorTestSuite))
+}
+
+func (s *connectorSelectorTestSuite) connector(id, standard string, power float64) *domain.Connector {
+	return &domain.Connector{Id: id, Details: domain.ConnectorDetails{Standard: standard, MaxElectricPower: kit.Float64Ptr(power)}}
+}
+
+func (s *connectorSelectorTestSuite) location() *domain.Location {
+	return &domain.Location{
+		Id: "loc",
+		Evses: []*domain.Evse{
+			{
+				Id:         "charging",
+				Status:     domain.EvseStatusCharging,
+				Connectors: []*domain.Connector{s.connector("1", domain.ConnectorTypeT2Combo, 350000)},
+			},
+			{
+				Id:         "ac",
+				Status:     domain.EvseStatusAvailable,
+				Connectors: []*domain.Connector{s.connector("1", "IEC_62196_T2", 22000)},
+			},
+			{
+				Id:         "dc",
+				Status:     domain.EvseStatusAvailable,
+				Details:    domain.EvseDetails{Capabilities: []string{domain.CapabilityRemoteStartStop}},
+				Connectors: []*domain.Connector{s.connector("1", "CHADEMO", 50000), s.connector("2", domain.ConnectorTypeT2Combo, 150000)},
+			},
+			{
+				Id:         "not-capable",
+				Status:     domain.EvseStatusAvailable,
+				Details:    domain.EvseDetails{Capabilities: []string{domain.CapabilityRfid}},
+				Connectors: []*domain.Connector{s.connector("1", domain.ConnectorTypeT2Combo, 300000)},
+			},
+		},
+	}
+}
+
+func (s *connectorSelectorTestSuite) Test_Init() {
+	sel := newConnectorSelector()
+	s.Nil(sel.init(s.Ctx, nil))
+	s.Equal(ConnectorSelectionPower, sel.strategy)
+
+	s.Nil(sel.init(s.Ctx, &ocpi.CfgConnectorSelection{Strategy: ConnectorSelectionStandard, Standards: " CHADEMO, IEC_62196_T2 ,"}))
+	s.Equal(ConnectorSelectionStandard, sel.strategy)
+	s.Equal([]string{"CHADEMO", "IEC_62196_T2"}, sel.standards)
+
+	s.AssertAppErr(sel.init(s.Ctx, &ocpi.CfgConnectorSelection{Strategy: "cheapest"}), errors.ErrCodeCmdSelectionStrategyInvalid)
+}
+
+func (s *connectorSelectorTestSuite) Test_SelectConnector_Power() {
+	sel := newConnectorSelector()
+	evse, con := sel.selectConnector(s.location(), domain.CapabilityRemoteStartStop)
+	s.Equal("dc", evse.Id)
+	s.Equal("2", con.Id)
+}
+
+func (s *connectorSelectorTestSuite) Test_SelectConnector_Standard() {
+	sel := newConnectorSelector()
+	s.Nil(sel.init(s.Ctx, &ocpi.CfgConnectorSelection{Strategy: ConnectorSelectionStandard, Standards: "IEC_62196_T2,CHADEMO"}))
+	evse, con := sel.selectConnector(s.location(), domain.CapabilityRemoteStartStop)
+	s.Equal("ac", evse.Id)
+	s.Equal("1", con.Id)
+
+	// none of preferred standards, the most powerful is selected
+	s.Nil(sel.init(s.Ctx, &ocpi.CfgConnectorSelection{Strategy: ConnectorSelectionStandard, Standards: "DOMESTIC_F"}))
+	evse, con = sel.selectConnector(s.location(), domain.CapabilityRemoteStartStop)
+	s.Equal("dc", evse.Id)
+	s.Equal("2", con.Id)
+}
+
+func (s *connectorSelectorTestSuite) Test_SelectConnector_PowerByVoltage() {
+	sel := newConnectorSelector()
+	loc := &domain.Location{Evses: []*domain.Evse{
+		{Id: "1", Status: domain.EvseStatusAvailable, Connectors: []*domain.Connector{{Id: "1", Details: domain.ConnectorDetails{MaxVoltage: 230, MaxAmperage: 16}}}},
+		{Id: "2", Status: domain.EvseStatusAvailable, Connectors: []*domain.Connector{{Id: "1", Details: domain.ConnectorDetails{MaxVoltage: 400, MaxAmperage: 32}}}},
+	}}
+	evse, _ := sel.selectConnector(loc, domain.CapabilityRemoteStartStop)
+	s.Equal("2", evse.Id)
+}
+
+func (s *connectorSelectorTestSuite) Test_SelectConnector_NoAvailable() {
+	sel := newConnectorSelector()
+	loc := s.location()
+	loc.Evses = loc.Evses[:1]
+	evse, con := sel.selectConnector(loc, domain.CapabilityRemoteStartStop)
+	s.Nil(evse)
+	s.Nil(con)
+}
+
+func (s *connectorSelectorTestSuite) Test_ValidateEvse() {
+	sel := newConnectorSelector()
+	loc := s.location()
+	s.AssertAppErr(sel.validateEvse(s.Ctx, loc.Evses[0], domain.CapabilityRemoteStartStop), errors.ErrCodeCmdEvseNotAvailable)
+	s.Nil(sel.validateEvse(s.Ctx, loc.Evses[1], domain.CapabilityRemoteStartStop))
+	s.Nil(sel.validateEvse(s.Ctx, loc.Evses[2], domain.CapabilityRemoteStartStop))
+	s.AssertAppErr(sel.validateEvse(s.Ctx, loc.Evses[2], domain.CapabilityReservable), errors.ErrCodeCmdEvseNotCapable)
+	s.AssertAppErr(sel.validateEvse(s.Ctx, loc.Evses[3], domain.CapabilityRemoteStartStop), errors.ErrCodeCmdEvseNotCapable)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type commandUcTestSuite struct {
@@ -26,6 +27,8 @@ type commandUcTestSuite struct {
 	tokenUc              *mocks.TokenUc
 	tokenService         *mocks.TokenService
 	sessionService       *mocks.SessionService
+	reservationUc        *mocks.ReservationUc
+	resultNotifier       *mocks.CommandResultNotifier
 	converter            *mocks.CommandConverter
 	tokenGen             *mocks.TokenGenerator
 }
@@ -44,6 +47,8 @@ func (s *commandUcTestSuite) SetupTest() {
 	s.tokenUc = &mocks.TokenUc{}
 	s.tokenService = &mocks.TokenService{}
 	s.sessionService = &mocks.SessionService{}
+	s.reservationUc = &mocks.ReservationUc{}
+	s.resultNotifier = &mocks.CommandResultNotifier{}
 	s.converter = &mocks.CommandConverter{}
 	s.platformService = &mocks.PlatformService{}
 	s.tokenGen = &mocks.TokenGenerator{}
@@ -59,6 +64,8 @@ func (s *commandUcTestSuite) SetupTest() {
 		s.tokenUc,
 		s.tokenService,
 		s.sessionService,
+		s.reservationUc,
+		s.resultNotifier,
 		s.tokenGen,
 	)
 }
@@ -138,3 +145,114 @@ func (s *commandUcTestSuite) Test_OnRemoteStartSession_TokenError() {
 	_, err := s.uc.OnRemoteStartSession(s.Ctx, "platform123", rq)
 	s.AssertAppErr(err, errors.ErrCodeTknNotValid)
 }
+
+func (s *commandUcTestSuite) Test_WaitCommandResult_AlreadyProcessed() {
+	cmd := &domain.Command{Id: "cmd1", Status: domain.CmdStatusRequestProcessedOk}
+	s.resultNotifier.On("SubscribeCommandResult", s.Ctx, cmd.Id).Return(make(<-chan struct{}), func() {})
+	s.commandService.On("Get", s.Ctx, cmd.Id).Return(cmd, nil)
+
+	rs, err := s.uc.WaitCommandResult(s.Ctx, cmd.Id, time.Minute)
+	s.NoError(err)
+	s.Equal(domain.CmdStatusRequestProcessedOk, rs.Status)
+	s.commandService.AssertNumberOfCalls(s.T(), "Get", 1)
+}
+
+func (s *commandUcTestSuite) Test_WaitCommandResult_Notified() {
+	notified := make(chan struct{}, 1)
+	notified <- struct{}{}
+	unsubscribed := false
+	s.resultNotifier.On("SubscribeCommandResult", s.Ctx, "cmd1").Return((<-chan struct{})(notified), func() { unsubscribed = true })
+	s.commandService.On("Get", s.Ctx, "cmd1").Return(&domain.Command{Id: "cmd1", Status: domain.CmdStatusRequestAccepted, Deadline: kit.Now().Add(time.Minute)}, nil).Once()
+	s.commandService.On("Get", s.Ctx, "cmd1").Return(&domain.Command{Id: "cmd1", Status: domain.CmdStatusRequestProcessedFailed}, nil).Once()
+
+	rs, err := s.uc.WaitCommandResult(s.Ctx, "cmd1", time.Minute)
+	s.NoError(err)
+	s.Equal(domain.CmdStatusRequestProcessedFailed, rs.Status)
+	s.True(unsubscribed)
+}
+
+func (s *commandUcTestSuite) Test_WaitCommandResult_Timeout() {
+	s.resultNotifier.On("SubscribeCommandResult", s.Ctx, "cmd1").Return(make(<-chan struct{}), func() {})
+	s.commandService.On("Get", s.Ctx, "cmd1").Return(&domain.Command{Id: "cmd1", Status: domain.CmdStatusRequestAccepted, Deadline: kit.Now().Add(time.Minute)}, nil)
+
+	rs, err := s.uc.WaitCommandResult(s.Ctx, "cmd1", time.Millisecond*10)
+	s.NoError(err)
+	s.Equal(domain.CmdStatusRequestAccepted, rs.Status)
+	s.commandService.AssertNumberOfCalls(s.T(), "Get", 2)
+}
+
+func (s *commandUcTestSuite) Test_WaitCommandResult_Timeout_ResultStoredWithoutNotification() {
+	// notification never arrives
+	s.resultNotifier.On("SubscribeCommandResult", s.Ctx, "cmd1").Return(make(<-chan struct{}), func() {})
+	s.commandService.On("Get", s.Ctx, "cmd1").Return(&domain.Command{Id: "cmd1", Status: domain.CmdStatusRequestAccepted, Deadline: kit.Now().Add(time.Minute)}, nil).Once()
+	s.commandService.On("Get", s.Ctx, "cmd1").Return(&domain.Command{Id: "cmd1", Status: domain.CmdStatusRequestProcessedOk}, nil).Once()
+
+	rs, err := s.uc.WaitCommandResult(s.Ctx, "cmd1", time.Millisecond*10)
+	s.NoError(err)
+	s.Equal(domain.CmdStatusRequestProcessedOk, rs.Status)
+}
+
+func (s *commandUcTestSuite) Test_WaitCommandResult_NotFound() {
+	s.resultNotifier.On("SubscribeCommandResult", s.Ctx, "cmd1").Return(make(<-chan struct{}), func() {})
+	s.commandService.On("Get", s.Ctx, "cmd1").Return(nil, nil)
+
+	_, err := s.uc.WaitCommandResult(s.Ctx, "cmd1", time.Minute)
+	s.AssertAppErr(err, errors.ErrCodeCmdCommandNotFound)
+}
+
+func (s *commandUcTestSuite) startSessionLocation() *domain.Location {
+	return &domain.Location{
+		OcpiItem: domain.OcpiItem{PlatformId: "remote"},
+		Id:       "loc1",
+		Evses: []*domain.Evse{
+			{
+				Id:         "evse1",
+				Status:     domain.EvseStatusAvailable,
+				Details:    domain.EvseDetails{Capabilities: []string{domain.CapabilityRemoteStartStop, domain.CapabilityStartSessionConReq}},
+				Connectors: []*domain.Connector{{Id: "con1"}},
+			},
+		},
+	}
+}
+
+func (s *commandUcTestSuite) Test_OnLocalStartSession_ConnectorRequired() {
+	s.localPlatformService.On("Get", s.Ctx).Return(&domain.Platform{}, nil)
+	s.locationService.On("GetLocation", s.Ctx, "loc1", true).Return(s.startSessionLocation(), nil)
+	s.platformService.On("Get", s.Ctx, "remote").Return(&domain.Platform{Id: "remote", Remote: true, Status: domain.ConnectionStatusConnected}, nil)
+
+	err := s.uc.OnLocalStartSession(s.Ctx, &domain.Command{
+		Cmd:     domain.CmdStartSession,
+		Details: domain.CommandDetails{StartSession: &domain.StartSession{LocationId: "loc1", EvseId: "evse1"}},
+	})
+	s.AssertAppErr(err, errors.ErrCodeCmdConnectorRequired)
+}
+
+func (s *commandUcTestSuite) Test_OnLocalStartSession_NoAvailableEvse() {
+	loc := s.startSessionLocation()
+	loc.Evses[0].Status = domain.EvseStatusCharging
+	s.localPlatformService.On("Get", s.Ctx).Return(&domain.Platform{}, nil)
+	s.locationService.On("GetLocation", s.Ctx, "loc1", true).Return(loc, nil)
+	s.platformService.On("Get", s.Ctx, "remote").Return(&domain.Platform{Id: "remote", Remote: true, Status: domain.ConnectionStatusConnected}, nil)
+
+	err := s.uc.OnLocalStartSession(s.Ctx, &domain.Command{
+		Cmd:     domain.CmdStartSession,
+		Details: domain.CommandDetails{StartSession: &domain.StartSession{LocationId: "loc1"}},
+	})
+	s.AssertAppErr(err, errors.ErrCodeCmdNoAvailableEvse)
+}
+
+func (s *commandUcTestSuite) Test_OnLocalStartSession_EvseSelected() {
+	s.localPlatformService.On("Get", s.Ctx).Return(&domain.Platform{}, nil)
+	s.locationService.On("GetLocation", s.Ctx, "loc1", true).Return(s.startSessionLocation(), nil)
+	s.platformService.On("Get", s.Ctx, "remote").Return(&domain.Platform{Id: "remote", Remote: true, Status: domain.ConnectionStatusConnected}, nil)
+	s.tokenUc.On("OnLocalTokenChanged", s.Ctx, mock.Anything).Return(errors.ErrTknNotValid(s.Ctx))
+
+	rq := &domain.Command{
+		Cmd:     domain.CmdStartSession,
+		Details: domain.CommandDetails{StartSession: &domain.StartSession{LocationId: "loc1"}},
+	}
+	err := s.uc.OnLocalStartSession(s.Ctx, rq)
+	s.AssertAppErr(err, errors.ErrCodeTknNotValid)
+	s.Equal("evse1", rq.Details.StartSession.EvseId)
+	s.Equal("con1", rq.Details.StartSession.ConnectorId)
+}
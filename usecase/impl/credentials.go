@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -29,10 +30,16 @@ type credentialsUc struct {
 	converter            usecase.CredentialsConverter
 	webhook              backend.WebhookCallService
 	hubUc                usecase.HubUc
+	locService           domain.LocationService
+	trfService           domain.TariffService
+	tknService           domain.TokenService
+	sessService          domain.SessionService
+	cdrService           domain.CdrService
 }
 
 func NewCredentialsUc(platformService domain.PlatformService, localPlatformService domain.LocalPlatformService, tokenGen domain.TokenGenerator, remotePlatformRep usecase.RemotePlatformRepository, partyService domain.PartyService,
-	webhook backend.WebhookCallService, hubUc usecase.HubUc) usecase.CredentialsUc {
+	webhook backend.WebhookCallService, hubUc usecase.HubUc, locService domain.LocationService, trfService domain.TariffService, tknService domain.TokenService,
+	sessService domain.SessionService, cdrService domain.CdrService) usecase.CredentialsUc {
 	return &credentialsUc{
 		ucBase:               newBase(platformService, partyService, tokenGen),
 		platformService:      platformService,
@@ -42,6 +49,11 @@ func NewCredentialsUc(platformService domain.PlatformService, localPlatformServi
 		converter:            NewCredentialsConverter(),
 		hubUc:                hubUc,
 		webhook:              webhook,
+		locService:           locService,
+		trfService:           trfService,
+		tknService:           tknService,
+		sessService:          sessService,
+		cdrService:           cdrService,
 	}
 }
 
@@ -119,6 +131,18 @@ func (c *credentialsUc) AcceptConnection(ctx context.Context, senderPlatformId s
 		return nil, errors.ErrPlatformNotAvailable(ctx)
 	}
 
+	// onboarding token is issued for the particular role
+	if senderPlatform.Onboarding != nil && senderPlatform.Onboarding.Role != "" && !c.hasRole(rq.Roles, senderPlatform.Onboarding.Role) {
+		return nil, errors.ErrOnboardingTokenRoleMismatch(ctx, senderPlatform.Onboarding.Role)
+	}
+
+	// onboarding token is consumed before handshake, so that concurrent handshakes with a single use token fail
+	// if handshake fails afterwards, a new token has to be issued
+	senderPlatform, err = c.platformService.ConsumeOnboardingToken(ctx, senderPlatform.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	// if base64 used we have to populate auth header with encoded tokens
 	// at the same time incoming payload brings not encoded tokens (OCPI 7.3.1)
 	senderToken := domain.PlatformToken(rq.Token)
@@ -142,6 +166,9 @@ func (c *credentialsUc) AcceptConnection(ctx context.Context, senderPlatformId s
 		return nil, errors.ErrNoCompatibleVersionFound(ctx)
 	}
 
+	// version details are requested according to the negotiated version
+	senderPlatform.VersionInfo.Current = version
+
 	// get sender endpoints
 	senderEndpoints, err := c.remotePlatformRep.GetVersionDetails(ctx, buildOcpiRepositoryRequest(senderVersions[version], senderToken, localPlatform, senderPlatform))
 	if err != nil {
@@ -164,6 +191,7 @@ func (c *credentialsUc) AcceptConnection(ctx context.Context, senderPlatformId s
 	// create connection per each role received
 	senderPlatform.Endpoints = senderEndpoints
 	senderPlatform.TokenB = localToken
+	senderPlatform.TokenIssuedAt = kit.NowPtr()
 	senderPlatform.TokenC = domain.PlatformToken(rq.Token)
 	senderPlatform.VersionInfo.Available = senderVersions
 	senderPlatform.VersionInfo.Current = version
@@ -314,6 +342,228 @@ func (c *credentialsUc) OnRemoteDeleteCredentials(ctx context.Context, platformI
 	return err
 }
 
+func (c *credentialsUc) DisconnectPlatform(ctx context.Context, platformId, dataPolicy string) (*domain.Platform, error) {
+	l := c.l().C(ctx).Mth("disconnect").F(kit.KV{"platformId": platformId, "dataPolicy": dataPolicy}).Dbg()
+
+	// check data policy
+	if dataPolicy == "" {
+		dataPolicy = domain.PlatformDataKeep
+	}
+	if dataPolicy != domain.PlatformDataKeep && dataPolicy != domain.PlatformDataArchive && dataPolicy != domain.PlatformDataPurge {
+		return nil, errors.ErrPlatformDataPolicyInvalid(ctx, dataPolicy)
+	}
+
+	// get platform
+	if platformId == "" {
+		return nil, errors.ErrPlatformIdEmpty(ctx)
+	}
+	platform, err := c.platformService.Get(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+	if platform == nil || !platform.Remote {
+		return nil, errors.ErrPlatformNotFound(ctx, platformId)
+	}
+
+	// request credentials deletion on the remote side if there is a connection
+	if platform.TokenC != "" {
+
+		// get local platform
+		localPlatform, err := c.localPlatformService.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// credential role is symmetric, so it should have either RECEIVER or SENDER role
+		ep := c.roleEndpoint(ctx, platform.Endpoints, model.ModuleIdCredentials, model.OcpiSender)
+		if ep == "" {
+			ep = c.roleEndpoint(ctx, platform.Endpoints, model.ModuleIdCredentials, model.OcpiReceiver)
+		}
+
+		// remote platform might be unavailable, it mustn't prevent disconnection on our side
+		if ep != "" {
+			err = c.remotePlatformRep.DeleteCredentials(ctx, buildOcpiRepositoryRequest(ep, c.tokenC(platform), localPlatform, platform))
+			if err != nil {
+				l.E(err).St().Warn("remote credentials deletion")
+			}
+		}
+	}
+
+	// wipe tokens and suspend platform
+	platform, err = c.platformService.Disconnect(ctx, platform.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// archive or purge platform's data
+	// the platform is already disconnected at this point, so a cleanup failure doesn't fail the call
+	// instead it's recorded on the platform and the cleanup can be retried by disconnecting the suspended platform again
+	if dataPolicy != domain.PlatformDataKeep {
+		cleanup := &domain.PlatformDataCleanup{
+			Policy:    dataPolicy,
+			Status:    domain.DataCleanupSuccess,
+			UpdatedAt: kit.Now(),
+		}
+		if err := c.cleanupPlatformData(ctx, platform.Id, dataPolicy == domain.PlatformDataArchive); err != nil {
+			l.E(err).St().Err("data cleanup")
+			cleanup.Status, cleanup.Error = domain.DataCleanupFailed, err.Error()
+		}
+		if p, err := c.platformService.SetDataCleanup(ctx, platform.Id, cleanup); err != nil {
+			l.E(err).St().Err("set data cleanup")
+			platform.DataCleanup = cleanup
+		} else {
+			platform = p
+		}
+	}
+
+	// call webhook
+	// the platform is already disconnected, so a webhook failure doesn't fail the call, otherwise the backend retries the completed disconnection
+	if err := c.webhook.OnPlatformDisconnected(ctx, c.converter.PlatformDomainToBackend(platform)); err != nil {
+		l.E(err).St().Err("webhook")
+	}
+
+	return platform, nil
+}
+
+// cleanupPlatformData archives or purges all the data of the platform
+func (c *credentialsUc) cleanupPlatformData(ctx context.Context, platformId string, archive bool) error {
+	eg := goroutine.NewGroup(ctx).WithLogger(c.l().C(ctx).Mth("cleanup-data"))
+	eg.Go(func() error {
+		return c.locService.DeleteLocationsByPlatform(ctx, platformId, archive)
+	})
+	eg.Go(func() error {
+		return c.trfService.DeleteTariffsByPlatform(ctx, platformId, archive)
+	})
+	eg.Go(func() error {
+		return c.tknService.DeleteTokensByPlatform(ctx, platformId, archive)
+	})
+	eg.Go(func() error {
+		return c.sessService.DeleteSessionsByPlatform(ctx, platformId, archive)
+	})
+	eg.Go(func() error {
+		return c.cdrService.DeleteCdrsByPlatform(ctx, platformId, archive)
+	})
+	return eg.Wait()
+}
+
+func (c *credentialsUc) RotateToken(ctx context.Context, platformId string) (*domain.Platform, error) {
+	l := c.l().C(ctx).Mth("rotate-token").F(kit.KV{"platformId": platformId}).Dbg()
+
+	// get connected platform
+	platform, err := c.getConnectedPlatform(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+	prevTokenB, prevTokenC, prevIssuedAt := platform.TokenB, platform.TokenC, platform.TokenIssuedAt
+
+	// the current token B remains accepted during grace period, so that the remote platform isn't cut off while switching
+	if platform.TokenRotation != nil && platform.TokenRotation.GracePeriod > 0 {
+		platform.PrevTokenB = prevTokenB
+		platform.PrevTokenBExp = kit.TimePtr(kit.Now().Add(time.Duration(platform.TokenRotation.GracePeriod) * time.Hour))
+		platform, err = c.platformService.Merge(ctx, platform)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// exchange credentials with a newly generated token
+	rotated, err := c.UpdateConnection(ctx, platform.Id)
+	if err != nil {
+		l.E(err).St().Err("rotation failed")
+
+		// if credentials haven't been exchanged, the remote platform still uses the previous token, so restore it
+		stored, getErr := c.platformService.Get(ctx, platform.Id)
+		if getErr == nil && stored != nil && stored.TokenC == prevTokenC && stored.TokenB != prevTokenB {
+			stored.TokenB = prevTokenB
+			stored.TokenIssuedAt = prevIssuedAt
+			if _, mergeErr := c.platformService.Merge(ctx, stored); mergeErr != nil {
+				l.E(mergeErr).St().Err("restore token")
+			}
+		}
+
+		c.addTokenRotation(ctx, platform.Id, err)
+		return nil, err
+	}
+
+	c.addTokenRotation(ctx, platform.Id, nil)
+
+	return rotated, nil
+}
+
+func (c *credentialsUc) GetPlatform(ctx context.Context, platformId string) (*domain.Platform, error) {
+	c.l().C(ctx).Mth("get-platform").F(kit.KV{"platformId": platformId}).Dbg()
+
+	platform, err := c.platformService.Get(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+	if platform != nil && platform.Remote {
+		platform.ClientState = c.remotePlatformRep.GetClientState(ctx, platformId)
+	}
+	return platform, nil
+}
+
+func (c *credentialsUc) TokenRotationCronHandler(ctx context.Context) {
+	l := c.l().C(ctx).Mth("token-rotation-cron").Dbg()
+
+	// get connected remote platforms
+	platforms, err := c.platformService.Search(ctx, &domain.PlatformSearchCriteria{
+		Statuses: []string{domain.ConnectionStatusConnected},
+		Remote:   kit.BoolPtr(true),
+	})
+	if err != nil {
+		l.E(err).St().Err()
+		return
+	}
+
+	now := kit.Now()
+	for _, platform := range platforms {
+		if !c.tokenRotationDue(platform, now) {
+			continue
+		}
+		// failures are tracked in the rotation history and notified via webhook
+		_, _ = c.RotateToken(ctx, platform.Id)
+	}
+}
+
+// tokenRotationDue checks if token must be rotated according to the platform's rotation policy
+// rotation is requested ahead of expiry, so that the previous token is never accepted longer than the rotation interval
+func (c *credentialsUc) tokenRotationDue(platform *domain.Platform, now time.Time) bool {
+	if platform.TokenRotation == nil || platform.TokenRotation.Interval <= 0 {
+		return false
+	}
+	if platform.TokenIssuedAt == nil {
+		return true
+	}
+	expiresAt := platform.TokenIssuedAt.Add(time.Duration(platform.TokenRotation.Interval) * time.Hour * 24)
+	return !now.Before(expiresAt.Add(-time.Duration(platform.TokenRotation.GracePeriod) * time.Hour))
+}
+
+func (c *credentialsUc) addTokenRotation(ctx context.Context, platformId string, rotationErr error) {
+	l := c.l().C(ctx).Mth("add-token-rotation").F(kit.KV{"platformId": platformId})
+
+	rotation := &domain.TokenRotation{
+		PlatformId: platformId,
+		Status:     domain.TokenRotationSuccess,
+	}
+	if rotationErr != nil {
+		rotation.Status = domain.TokenRotationFailed
+		rotation.Error = rotationErr.Error()
+	}
+
+	// store history
+	if err := c.platformService.AddTokenRotation(ctx, rotation); err != nil {
+		l.E(err).St().Err()
+	}
+
+	// notify about failure
+	if rotationErr != nil {
+		if err := c.webhook.OnTokenRotationFailed(ctx, c.converter.TokenRotationDomainToBackend(rotation)); err != nil {
+			l.E(err).St().Err()
+		}
+	}
+}
+
 func (c *credentialsUc) OnLocalPartyChanged(ctx context.Context, party *domain.Party) error {
 	l := c.l().C(ctx).Mth("on-party-changed-loc").F(kit.KV{"partyId": party.Id}).Dbg()
 
@@ -386,6 +636,9 @@ func (c *credentialsUc) reconnect(ctx context.Context, receiverPlatform *domain.
 		return nil, errors.ErrNoCompatibleVersionFound(ctx)
 	}
 
+	// version details are requested according to the negotiated version
+	receiverPlatform.VersionInfo.Current = version
+
 	// get receiver endpoints
 	receiverEndpoints, err := c.remotePlatformRep.GetVersionDetails(ctx, buildOcpiRepositoryRequest(receiverVersions[version], encodedTokenA, localPlatform, receiverPlatform))
 	if err != nil {
@@ -400,6 +653,7 @@ func (c *credentialsUc) reconnect(ctx context.Context, receiverPlatform *domain.
 
 	// update receiver platform
 	receiverPlatform.TokenB = localToken
+	receiverPlatform.TokenIssuedAt = kit.NowPtr()
 	receiverPlatform.VersionInfo.Available = receiverVersions
 	receiverPlatform.VersionInfo.Current = version
 	receiverPlatform.Endpoints = receiverEndpoints
@@ -464,6 +718,15 @@ func (c *credentialsUc) reconnect(ctx context.Context, receiverPlatform *domain.
 	return receiverPlatform, nil
 }
 
+func (c *credentialsUc) hasRole(roles []*model.OcpiCredentialRole, role string) bool {
+	for _, r := range roles {
+		if r != nil && r.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *credentialsUc) verToInt(v string) int {
 	a := strings.Replace(v, ".", "", -1)
 	if len(a) == 2 {
@@ -471,18 +734,26 @@ func (c *credentialsUc) verToInt(v string) int {
 	} else if len(a) != 3 {
 		return 0
 	}
-	vInt, _ := strconv.ParseInt(a, 10, 8)
+	vInt, err := strconv.ParseInt(a, 10, 32)
+	if err != nil {
+		return 0
+	}
 	return int(vInt)
 }
 
 func (c *credentialsUc) findProperVersion(remoteVersions, localVersions domain.Versions) string {
 	var remVerInt []int
 	var locVer = map[int]string{}
+	// unparsable versions aren't negotiated
 	for v := range remoteVersions {
-		remVerInt = append(remVerInt, c.verToInt(v))
+		if vInt := c.verToInt(v); vInt > 0 {
+			remVerInt = append(remVerInt, vInt)
+		}
 	}
 	for v := range localVersions {
-		locVer[c.verToInt(v)] = v
+		if vInt := c.verToInt(v); vInt > 0 {
+			locVer[vInt] = v
+		}
 	}
 	sort.Sort(sort.Reverse(sort.IntSlice(remVerInt)))
 	for _, r := range remVerInt {
@@ -6,6 +6,7 @@ import (
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/model"
 	"github.com/mikhailbolshakov/ocpi/usecase"
+	"time"
 )
 
 type credentialsConverter struct {
@@ -146,6 +147,30 @@ func (c *credentialsConverter) PlatformBackendToDomain(rq *backend.PlatformReque
 		},
 		Remote: true,
 	}
+	if rq.TokenRotation != nil {
+		r.TokenRotation = &domain.TokenRotationPolicy{
+			Interval:    rq.TokenRotation.Interval,
+			GracePeriod: rq.TokenRotation.GracePeriod,
+		}
+	}
+	if rq.Client != nil {
+		r.Client = &domain.ClientSettings{
+			Timeout:          rq.Client.Timeout,
+			MaxRetries:       rq.Client.MaxRetries,
+			RetryBackoff:     rq.Client.RetryBackoff,
+			BreakerThreshold: rq.Client.BreakerThreshold,
+			BreakerCooldown:  rq.Client.BreakerCooldown,
+			MaxConcurrency:   rq.Client.MaxConcurrency,
+			RateLimit:        c.rateLimitBackendToDomain(rq.Client.RateLimit),
+		}
+		if len(rq.Client.ModuleRateLimits) > 0 {
+			r.Client.ModuleRateLimits = make(map[string]*domain.RateLimit)
+			for module, rl := range rq.Client.ModuleRateLimits {
+				r.Client.ModuleRateLimits[module] = c.rateLimitBackendToDomain(rl)
+			}
+		}
+	}
+	r.Inbound = c.inboundLimitsBackendToDomain(rq.Inbound)
 	if rq.Protocol != nil {
 		r.Protocol = &domain.ProtocolDetails{
 			PushSupport: domain.PushSupport{
@@ -158,6 +183,7 @@ func (c *credentialsConverter) PlatformBackendToDomain(rq *backend.PlatformReque
 				Tariffs:       rq.Protocol.PushSupport.Tariffs,
 				Tokens:        rq.Protocol.PushSupport.Tokens,
 			},
+			Validation: rq.Protocol.Validation,
 		}
 	}
 	return r
@@ -183,6 +209,72 @@ func (c *credentialsConverter) versionsDomainToBackend(eps domain.Versions) map[
 	return r
 }
 
+func (c *credentialsConverter) rateLimitBackendToDomain(rl *backend.RateLimit) *domain.RateLimit {
+	if rl == nil {
+		return nil
+	}
+	return &domain.RateLimit{
+		Rate:  rl.Rate,
+		Burst: rl.Burst,
+	}
+}
+
+func (c *credentialsConverter) rateLimitDomainToBackend(rl *domain.RateLimit) *backend.RateLimit {
+	if rl == nil {
+		return nil
+	}
+	return &backend.RateLimit{
+		Rate:  rl.Rate,
+		Burst: rl.Burst,
+	}
+}
+
+func (c *credentialsConverter) inboundLimitsBackendToDomain(in *backend.InboundLimits) *domain.InboundLimits {
+	if in == nil {
+		return nil
+	}
+	r := &domain.InboundLimits{
+		RateLimit:  c.rateLimitBackendToDomain(in.RateLimit),
+		DailyQuota: in.DailyQuota,
+	}
+	if len(in.Routes) > 0 {
+		r.Routes = make(map[string]*domain.RouteLimits)
+		for route, rl := range in.Routes {
+			if rl == nil {
+				continue
+			}
+			r.Routes[route] = &domain.RouteLimits{
+				RateLimit:  c.rateLimitBackendToDomain(rl.RateLimit),
+				DailyQuota: rl.DailyQuota,
+			}
+		}
+	}
+	return r
+}
+
+func (c *credentialsConverter) inboundLimitsDomainToBackend(in *domain.InboundLimits) *backend.InboundLimits {
+	if in == nil {
+		return nil
+	}
+	r := &backend.InboundLimits{
+		RateLimit:  c.rateLimitDomainToBackend(in.RateLimit),
+		DailyQuota: in.DailyQuota,
+	}
+	if len(in.Routes) > 0 {
+		r.Routes = make(map[string]*backend.RouteLimits)
+		for route, rl := range in.Routes {
+			if rl == nil {
+				continue
+			}
+			r.Routes[route] = &backend.RouteLimits{
+				RateLimit:  c.rateLimitDomainToBackend(rl.RateLimit),
+				DailyQuota: rl.DailyQuota,
+			}
+		}
+	}
+	return r
+}
+
 func (c *credentialsConverter) PlatformDomainToBackend(p *domain.Platform) *backend.Platform {
 	if p == nil {
 		return nil
@@ -200,9 +292,62 @@ func (c *credentialsConverter) PlatformDomainToBackend(p *domain.Platform) *back
 			Available:    c.versionsDomainToBackend(p.VersionInfo.Available),
 			GetVersionEp: string(p.VersionInfo.VersionEp),
 		},
-		Endpoints: c.endpointsDomainToBackend(p.Endpoints),
-		Status:    p.Status,
-		Remote:    p.Remote,
+		Endpoints:     c.endpointsDomainToBackend(p.Endpoints),
+		Status:        p.Status,
+		Remote:        p.Remote,
+		TokenIssuedAt: p.TokenIssuedAt,
+		PrevTokenBExp: p.PrevTokenBExp,
+	}
+	if p.Health != nil {
+		r.Health = &backend.PlatformHealth{
+			CheckedAt:    p.Health.CheckedAt,
+			LatencyMs:    p.Health.LatencyMs,
+			Failures:     p.Health.Failures,
+			Successes:    p.Health.Successes,
+			LastError:    p.Health.LastError,
+			OfflineSince: p.Health.OfflineSince,
+		}
+	}
+	if p.Client != nil {
+		r.Client = &backend.ClientSettings{
+			Timeout:          p.Client.Timeout,
+			MaxRetries:       p.Client.MaxRetries,
+			RetryBackoff:     p.Client.RetryBackoff,
+			BreakerThreshold: p.Client.BreakerThreshold,
+			BreakerCooldown:  p.Client.BreakerCooldown,
+			MaxConcurrency:   p.Client.MaxConcurrency,
+			RateLimit:        c.rateLimitDomainToBackend(p.Client.RateLimit),
+		}
+		if len(p.Client.ModuleRateLimits) > 0 {
+			r.Client.ModuleRateLimits = make(map[string]*backend.RateLimit)
+			for module, rl := range p.Client.ModuleRateLimits {
+				r.Client.ModuleRateLimits[module] = c.rateLimitDomainToBackend(rl)
+			}
+		}
+	}
+	r.Inbound = c.inboundLimitsDomainToBackend(p.Inbound)
+	if p.DataCleanup != nil {
+		r.DataCleanup = &backend.PlatformDataCleanup{
+			Policy:    p.DataCleanup.Policy,
+			Status:    p.DataCleanup.Status,
+			Error:     p.DataCleanup.Error,
+			UpdatedAt: p.DataCleanup.UpdatedAt,
+		}
+	}
+	if p.ClientState != nil {
+		r.ClientState = &backend.ClientState{
+			Breaker:  p.ClientState.Breaker,
+			Failures: p.ClientState.Failures,
+			OpenedAt: p.ClientState.OpenedAt,
+			InFlight: p.ClientState.InFlight,
+			Queued:   p.ClientState.Queued,
+		}
+	}
+	if p.TokenRotation != nil {
+		r.TokenRotation = &backend.TokenRotationPolicy{
+			Interval:    p.TokenRotation.Interval,
+			GracePeriod: p.TokenRotation.GracePeriod,
+		}
 	}
 	if p.Protocol != nil {
 		r.Protocol = &backend.ProtocolDetails{
@@ -216,7 +361,55 @@ func (c *credentialsConverter) PlatformDomainToBackend(p *domain.Platform) *back
 				Tariffs:       p.Protocol.PushSupport.Tariffs,
 				Tokens:        p.Protocol.PushSupport.Tokens,
 			},
+			Validation: p.Protocol.Validation,
 		}
 	}
 	return r
 }
+
+func (c *credentialsConverter) TokenRotationDomainToBackend(r *domain.TokenRotation) *backend.TokenRotation {
+	if r == nil {
+		return nil
+	}
+	return &backend.TokenRotation{
+		Id:         r.Id,
+		PlatformId: r.PlatformId,
+		Status:     r.Status,
+		Error:      r.Error,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func (c *credentialsConverter) TokenRotationsDomainToBackend(rs []*domain.TokenRotation) []*backend.TokenRotation {
+	return kit.Select(rs, c.TokenRotationDomainToBackend)
+}
+
+func (c *credentialsConverter) OnboardingTokenBackendToDomain(rq *backend.OnboardingTokenRequest) *domain.OnboardingToken {
+	if rq == nil {
+		return nil
+	}
+	r := &domain.OnboardingToken{
+		Role:      rq.Role,
+		SingleUse: rq.SingleUse,
+	}
+	if rq.Ttl > 0 {
+		r.ExpiresAt = kit.TimePtr(kit.Now().Add(time.Duration(rq.Ttl) * time.Hour))
+	}
+	return r
+}
+
+func (c *credentialsConverter) OnboardingTokenDomainToBackend(p *domain.Platform, status string) *backend.OnboardingToken {
+	if p == nil || p.Onboarding == nil {
+		return nil
+	}
+	return &backend.OnboardingToken{
+		PlatformId: p.Id,
+		Role:       p.Onboarding.Role,
+		SingleUse:  p.Onboarding.SingleUse,
+		Status:     status,
+		CreatedAt:  p.Onboarding.CreatedAt,
+		ExpiresAt:  p.Onboarding.ExpiresAt,
+		ConsumedAt: p.Onboarding.ConsumedAt,
+		RevokedAt:  p.Onboarding.RevokedAt,
+	}
+}
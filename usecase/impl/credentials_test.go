@@ -1,10 +1,12 @@
 package impl
 
 import (
+	"context"
 	"encoding/base64"
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
 	"github.com/mikhailbolshakov/ocpi/mocks"
 	"github.com/mikhailbolshakov/ocpi/model"
 	"github.com/mikhailbolshakov/ocpi/usecase"
@@ -25,6 +27,11 @@ type credentialsUcTestSuite struct {
 	partyService      *mocks.PartyService
 	webhook           *mocks.WebhookCallService
 	hubUc             *mocks.HubUc
+	locService        *mocks.LocationService
+	trfService        *mocks.TariffService
+	tknService        *mocks.TokenService
+	sessService       *mocks.SessionService
+	cdrService        *mocks.CdrService
 }
 
 func (s *credentialsUcTestSuite) SetupSuite() {
@@ -40,7 +47,13 @@ func (s *credentialsUcTestSuite) SetupTest() {
 	s.partyService = &mocks.PartyService{}
 	s.webhook = &mocks.WebhookCallService{}
 	s.hubUc = &mocks.HubUc{}
-	s.uc = NewCredentialsUc(s.platformSvc, s.localPlatformSvc, s.tokenGen, s.remotePlatformRep, s.partyService, s.webhook, s.hubUc).(*credentialsUc)
+	s.locService = &mocks.LocationService{}
+	s.trfService = &mocks.TariffService{}
+	s.tknService = &mocks.TokenService{}
+	s.sessService = &mocks.SessionService{}
+	s.cdrService = &mocks.CdrService{}
+	s.uc = NewCredentialsUc(s.platformSvc, s.localPlatformSvc, s.tokenGen, s.remotePlatformRep, s.partyService, s.webhook, s.hubUc,
+		s.locService, s.trfService, s.tknService, s.sessService, s.cdrService).(*credentialsUc)
 }
 
 func (s *credentialsUcTestSuite) TearDownSuite() {}
@@ -175,6 +188,36 @@ func (s *credentialsUcTestSuite) Test_FindProperVersion() {
 			rem: domain.Versions{"1.5": "", "1.2": "", "2.0": ""},
 			res: "1.2",
 		},
+		{
+			loc: domain.Versions{"2.1.1": "", "2.2.1": "", "2.3.0": ""},
+			rem: domain.Versions{"2.1.1": "", "2.2.1": "", "2.3.0": ""},
+			res: "2.3.0",
+		},
+		{
+			loc: domain.Versions{"2.1.1": "", "2.2.1": "", "2.3.0": ""},
+			rem: domain.Versions{"2.1.1": "", "2.2.1": ""},
+			res: "2.2.1",
+		},
+		{
+			loc: domain.Versions{"2.1.1": "", "2.2.1": ""},
+			rem: domain.Versions{"2.2.1": "", "2.3.0": ""},
+			res: "2.2.1",
+		},
+		{
+			loc: domain.Versions{"2.1.1": "", "2.2.1": ""},
+			rem: domain.Versions{"2.1.1": ""},
+			res: "2.1.1",
+		},
+		{
+			loc: domain.Versions{"2.1.1": ""},
+			rem: domain.Versions{"2.1.1": "", "2.2.1": ""},
+			res: "2.1.1",
+		},
+		{
+			loc: domain.Versions{"2.1.1": "", "2.2.1": ""},
+			rem: domain.Versions{"2.x.1": "", "a.b": ""},
+			res: "",
+		},
 	} {
 		s.Equal(cs.res, s.uc.findProperVersion(cs.rem, cs.loc))
 	}
@@ -225,6 +268,7 @@ func (s *credentialsUcTestSuite) Test_AcceptConnection() {
 		Return(nil)
 	s.platformSvc.On("Merge", s.Ctx, remPlatform).Return(remPlatform, nil)
 	s.platformSvc.On("SetStatus", s.Ctx, remPlatform.Id, domain.ConnectionStatusConnected).Return(remPlatform, nil)
+	s.platformSvc.On("ConsumeOnboardingToken", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
 	s.webhook.On("OnPartiesChanged", s.Ctx, mock.Anything).Return(nil)
 
 	_, err := s.uc.AcceptConnection(s.Ctx, remPlatform.Id, recCred)
@@ -232,7 +276,64 @@ func (s *credentialsUcTestSuite) Test_AcceptConnection() {
 
 	s.NotEmpty(mergedParty)
 	s.Equal(mergedParty.ExtId.PartyId, recCred.Roles[0].PartyId)
+	s.platformSvc.AssertCalled(s.T(), "ConsumeOnboardingToken", s.Ctx, remPlatform.Id)
+}
 
+func (s *credentialsUcTestSuite) Test_AcceptConnection_OnboardingRoleMismatch() {
+	remPlatform := s.platform(domain.RoleCPO)
+	remPlatform.Onboarding = &domain.OnboardingToken{Role: domain.RoleEMSP, CreatedAt: kit.Now()}
+	locPlatform := s.platform(domain.RoleHUB)
+	recCred := &model.OcpiCredentials{
+		Token: kit.NewRandString(),
+		Url:   kit.NewRandString(),
+		Roles: []*model.OcpiCredentialRole{
+			{
+				OcpiPartyId: model.OcpiPartyId{
+					PartyId:     kit.NewRandString(),
+					CountryCode: "RS",
+				},
+				Role: domain.RoleCPO,
+			},
+		},
+	}
+	locParty := &domain.Party{Roles: []string{domain.RoleCPO}, OcpiItem: domain.OcpiItem{ExtId: domain.PartyExtId{PartyId: kit.NewId()}}}
+	s.localPlatformSvc.On("Get", mock.Anything).Return(locPlatform, nil)
+	s.platformSvc.On("Get", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	s.partyService.On("Search", s.Ctx, mock.AnythingOfType("*domain.PartySearchCriteria")).Return(&domain.PartySearchResponse{Items: []*domain.Party{locParty}}, nil)
+
+	_, err := s.uc.AcceptConnection(s.Ctx, remPlatform.Id, recCred)
+	s.AssertAppErr(err, errors.ErrCodeOnboardingTokenRoleMismatch)
+	s.remotePlatformRep.AssertNotCalled(s.T(), "GetVersions", mock.Anything, mock.Anything)
+}
+
+func (s *credentialsUcTestSuite) Test_AcceptConnection_OnboardingTokenConsumed() {
+	remPlatform := s.platform(domain.RoleCPO)
+	remPlatform.Onboarding = &domain.OnboardingToken{SingleUse: true, CreatedAt: kit.Now()}
+	locPlatform := s.platform(domain.RoleHUB)
+	recCred := &model.OcpiCredentials{
+		Token: kit.NewRandString(),
+		Url:   kit.NewRandString(),
+		Roles: []*model.OcpiCredentialRole{
+			{
+				OcpiPartyId: model.OcpiPartyId{
+					PartyId:     kit.NewRandString(),
+					CountryCode: "RS",
+				},
+				Role: domain.RoleCPO,
+			},
+		},
+	}
+	locParty := &domain.Party{Roles: []string{domain.RoleCPO}, OcpiItem: domain.OcpiItem{ExtId: domain.PartyExtId{PartyId: kit.NewId()}}}
+	s.localPlatformSvc.On("Get", mock.Anything).Return(locPlatform, nil)
+	s.platformSvc.On("Get", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	s.partyService.On("Search", s.Ctx, mock.AnythingOfType("*domain.PartySearchCriteria")).Return(&domain.PartySearchResponse{Items: []*domain.Party{locParty}}, nil)
+	// concurrent handshake has consumed the token
+	s.platformSvc.On("ConsumeOnboardingToken", s.Ctx, remPlatform.Id).Return(nil, errors.ErrOnboardingTokenConsumed(s.Ctx))
+
+	_, err := s.uc.AcceptConnection(s.Ctx, remPlatform.Id, recCred)
+	s.AssertAppErr(err, errors.ErrCodeOnboardingTokenConsumed)
+	s.remotePlatformRep.AssertNotCalled(s.T(), "GetVersions", mock.Anything, mock.Anything)
+	s.platformSvc.AssertNotCalled(s.T(), "Merge", mock.Anything, mock.Anything)
 }
 
 func (s *credentialsUcTestSuite) Test_OnRemotePartyPull() {
@@ -352,6 +453,198 @@ func (s *credentialsUcTestSuite) Test_OnRemotePartyPull_WhenNotFound() {
 	}, time.Millisecond*300, time.Second))
 }
 
+func (s *credentialsUcTestSuite) Test_DisconnectPlatform_Purge() {
+
+	locPlatform := s.platform(domain.RoleHUB)
+	s.localPlatformSvc.On("Get", s.Ctx).Return(locPlatform, nil)
+
+	remPlatform := s.platform(domain.RoleCPO)
+	remPlatform.Remote = true
+	remPlatform.TokenB = domain.PlatformToken(kit.NewRandString())
+	remPlatform.TokenC = domain.PlatformToken(kit.NewRandString())
+	remPlatform.Status = domain.ConnectionStatusConnected
+	remPlatform.Endpoints = domain.ModuleEndpoints{
+		model.ModuleIdCredentials: {model.OcpiSender: domain.Endpoint("http://cred")},
+	}
+	disconnected := *remPlatform
+	disconnected.TokenB, disconnected.TokenC, disconnected.Status = "", "", domain.ConnectionStatusSuspended
+
+	s.platformSvc.On("Get", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	s.remotePlatformRep.On("DeleteCredentials", s.Ctx, mock.MatchedBy(func(r *usecase.OcpiRepositoryBaseRequest) bool {
+		return r.Endpoint == "http://cred" && r.Token == remPlatform.TokenC
+	})).Return(nil)
+	s.platformSvc.On("Disconnect", s.Ctx, remPlatform.Id).Return(&disconnected, nil)
+	s.locService.On("DeleteLocationsByPlatform", s.Ctx, remPlatform.Id, false).Return(nil)
+	s.trfService.On("DeleteTariffsByPlatform", s.Ctx, remPlatform.Id, false).Return(nil)
+	s.tknService.On("DeleteTokensByPlatform", s.Ctx, remPlatform.Id, false).Return(nil)
+	s.sessService.On("DeleteSessionsByPlatform", s.Ctx, remPlatform.Id, false).Return(nil)
+	s.cdrService.On("DeleteCdrsByPlatform", s.Ctx, remPlatform.Id, false).Return(nil)
+	s.platformSvc.On("SetDataCleanup", s.Ctx, remPlatform.Id, mock.Anything).
+		Return(func(ctx context.Context, platformId string, cleanup *domain.PlatformDataCleanup) *domain.Platform {
+			disconnected.DataCleanup = cleanup
+			return &disconnected
+		}, nil)
+	s.webhook.On("OnPlatformDisconnected", s.Ctx, mock.Anything).Return(nil)
+
+	rs, err := s.uc.DisconnectPlatform(s.Ctx, remPlatform.Id, domain.PlatformDataPurge)
+	s.NoError(err)
+	s.Empty(rs.TokenB)
+	s.Empty(rs.TokenC)
+	s.Equal(domain.ConnectionStatusSuspended, rs.Status)
+	s.Equal(domain.PlatformDataPurge, rs.DataCleanup.Policy)
+	s.Equal(domain.DataCleanupSuccess, rs.DataCleanup.Status)
+
+	s.remotePlatformRep.AssertExpectations(s.T())
+	s.locService.AssertExpectations(s.T())
+	s.trfService.AssertExpectations(s.T())
+	s.tknService.AssertExpectations(s.T())
+	s.sessService.AssertExpectations(s.T())
+	s.cdrService.AssertExpectations(s.T())
+	s.webhook.AssertExpectations(s.T())
+}
+
+func (s *credentialsUcTestSuite) Test_DisconnectPlatform_KeepWhenRemoteFails() {
+
+	locPlatform := s.platform(domain.RoleHUB)
+	s.localPlatformSvc.On("Get", s.Ctx).Return(locPlatform, nil)
+
+	remPlatform := s.platform(domain.RoleCPO)
+	remPlatform.Remote = true
+	remPlatform.TokenC = domain.PlatformToken(kit.NewRandString())
+	remPlatform.Endpoints = domain.ModuleEndpoints{
+		model.ModuleIdCredentials: {model.OcpiReceiver: domain.Endpoint("http://cred")},
+	}
+
+	s.platformSvc.On("Get", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	s.remotePlatformRep.On("DeleteCredentials", s.Ctx, mock.Anything).Return(errors.ErrPlatformNotAvailable(s.Ctx))
+	s.platformSvc.On("Disconnect", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	s.webhook.On("OnPlatformDisconnected", s.Ctx, mock.Anything).Return(nil)
+
+	_, err := s.uc.DisconnectPlatform(s.Ctx, remPlatform.Id, "")
+	s.NoError(err)
+
+	s.platformSvc.AssertCalled(s.T(), "Disconnect", s.Ctx, remPlatform.Id)
+	s.locService.AssertNotCalled(s.T(), "DeleteLocationsByPlatform", mock.Anything, mock.Anything, mock.Anything)
+	s.cdrService.AssertNotCalled(s.T(), "DeleteCdrsByPlatform", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *credentialsUcTestSuite) Test_DisconnectPlatform_CleanupFailed_PlatformDisconnected() {
+
+	remPlatform := s.platform(domain.RoleCPO)
+	remPlatform.Remote = true
+	remPlatform.Status = domain.ConnectionStatusSuspended
+
+	s.platformSvc.On("Get", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	s.platformSvc.On("Disconnect", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	s.locService.On("DeleteLocationsByPlatform", s.Ctx, remPlatform.Id, true).Return(errors.ErrPlatformNotAvailable(s.Ctx))
+	s.trfService.On("DeleteTariffsByPlatform", s.Ctx, remPlatform.Id, true).Return(nil)
+	s.tknService.On("DeleteTokensByPlatform", s.Ctx, remPlatform.Id, true).Return(nil)
+	s.sessService.On("DeleteSessionsByPlatform", s.Ctx, remPlatform.Id, true).Return(nil)
+	s.cdrService.On("DeleteCdrsByPlatform", s.Ctx, remPlatform.Id, true).Return(nil)
+	s.platformSvc.On("SetDataCleanup", s.Ctx, remPlatform.Id, mock.MatchedBy(func(c *domain.PlatformDataCleanup) bool {
+		return c.Policy == domain.PlatformDataArchive && c.Status == domain.DataCleanupFailed && c.Error != ""
+	})).Return(func(ctx context.Context, platformId string, cleanup *domain.PlatformDataCleanup) *domain.Platform {
+		remPlatform.DataCleanup = cleanup
+		return remPlatform
+	}, nil)
+	s.webhook.On("OnPlatformDisconnected", s.Ctx, mock.Anything).Return(nil)
+
+	rs, err := s.uc.DisconnectPlatform(s.Ctx, remPlatform.Id, domain.PlatformDataArchive)
+	s.NoError(err)
+	s.Equal(domain.ConnectionStatusSuspended, rs.Status)
+	s.Equal(domain.DataCleanupFailed, rs.DataCleanup.Status)
+
+	// no remote call for the suspended platform
+	s.remotePlatformRep.AssertNotCalled(s.T(), "DeleteCredentials", mock.Anything, mock.Anything)
+	s.webhook.AssertExpectations(s.T())
+}
+
+func (s *credentialsUcTestSuite) Test_DisconnectPlatform_WebhookFailed_PlatformReturned() {
+
+	remPlatform := s.platform(domain.RoleCPO)
+	remPlatform.Remote = true
+	remPlatform.Status = domain.ConnectionStatusSuspended
+
+	s.platformSvc.On("Get", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	s.platformSvc.On("Disconnect", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	s.webhook.On("OnPlatformDisconnected", s.Ctx, mock.Anything).Return(errors.ErrPlatformNotAvailable(s.Ctx))
+
+	rs, err := s.uc.DisconnectPlatform(s.Ctx, remPlatform.Id, domain.PlatformDataKeep)
+	s.NoError(err)
+	s.Equal(remPlatform.Id, rs.Id)
+	s.webhook.AssertExpectations(s.T())
+}
+
+func (s *credentialsUcTestSuite) Test_DisconnectPlatform_InvalidPolicy() {
+	_, err := s.uc.DisconnectPlatform(s.Ctx, kit.NewRandString(), "drop")
+	s.AssertAppErr(err, errors.ErrCodePlatformDataPolicyInvalid)
+}
+
+func (s *credentialsUcTestSuite) Test_TokenRotationDue() {
+	now := kit.Now()
+	p := s.platform(domain.RoleCPO)
+
+	// no policy
+	s.False(s.uc.tokenRotationDue(p, now))
+
+	// never issued
+	p.TokenRotation = &domain.TokenRotationPolicy{Interval: 90, GracePeriod: 48}
+	s.True(s.uc.tokenRotationDue(p, now))
+
+	// issued recently
+	p.TokenIssuedAt = kit.TimePtr(now.Add(-time.Hour * 24))
+	s.False(s.uc.tokenRotationDue(p, now))
+
+	// within grace period before expiry
+	p.TokenIssuedAt = kit.TimePtr(now.Add(-time.Hour * 24 * 89))
+	s.True(s.uc.tokenRotationDue(p, now))
+}
+
+func (s *credentialsUcTestSuite) Test_RotateToken_WhenFailed_TokenRestored() {
+
+	locPlatform := s.platform(domain.RoleHUB)
+	s.localPlatformSvc.On("Get", s.Ctx).Return(locPlatform, nil)
+	s.partyService.On("Search", s.Ctx, mock.Anything).Return(&domain.PartySearchResponse{}, nil)
+
+	remPlatform := s.platform(domain.RoleCPO)
+	remPlatform.Remote = true
+	remPlatform.Status = domain.ConnectionStatusConnected
+	remPlatform.TokenB = domain.PlatformToken(kit.NewRandString())
+	remPlatform.TokenC = domain.PlatformToken(kit.NewRandString())
+	remPlatform.TokenRotation = &domain.TokenRotationPolicy{Interval: 90, GracePeriod: 24}
+	prevTokenB := remPlatform.TokenB
+
+	s.platformSvc.On("Get", s.Ctx, remPlatform.Id).Return(remPlatform, nil)
+	var merged []domain.Platform
+	s.platformSvc.On("Merge", s.Ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			merged = append(merged, *args.Get(1).(*domain.Platform))
+		}).
+		Return(remPlatform, nil)
+	s.remotePlatformRep.On("GetVersions", s.Ctx, mock.Anything).Return(nil, errors.ErrPlatformNotAvailable(s.Ctx))
+	var rotation *domain.TokenRotation
+	s.platformSvc.On("AddTokenRotation", s.Ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			rotation = args.Get(1).(*domain.TokenRotation)
+		}).
+		Return(nil)
+	s.webhook.On("OnTokenRotationFailed", s.Ctx, mock.Anything).Return(nil)
+
+	_, err := s.uc.RotateToken(s.Ctx, remPlatform.Id)
+	s.Error(err)
+
+	// previous token registered for grace period
+	s.NotEmpty(merged)
+	s.Equal(prevTokenB, merged[0].PrevTokenB)
+	s.NotEmpty(merged[0].PrevTokenBExp)
+
+	// failure tracked
+	s.NotEmpty(rotation)
+	s.Equal(domain.TokenRotationFailed, rotation.Status)
+	s.NotEmpty(rotation.Error)
+	s.webhook.AssertCalled(s.T(), "OnTokenRotationFailed", s.Ctx, mock.Anything)
+}
+
 func (s *credentialsUcTestSuite) base64Encode(tkn string) string {
 	return base64.StdEncoding.EncodeToString([]byte(tkn))
 }
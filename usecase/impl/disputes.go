@@ -0,0 +1,287 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"math"
+	"slices"
+)
+
+const (
+	disputeCreditComment   = "resolved by credit CDR"
+	disputeAmountTolerance = 0.01 // disputeAmountTolerance tolerance of amount in currency units
+)
+
+var (
+	// disputeActiveStatuses statuses of disputes which aren't closed yet
+	disputeActiveStatuses = []string{domain.DisputeStatusOpen, domain.DisputeStatusAcknowledged}
+)
+
+type disputeUc struct {
+	disputeService       domain.DisputeService
+	cdrService           domain.CdrService
+	sessService          domain.SessionService
+	logService           domain.OcpiLogService
+	localPlatformService domain.LocalPlatformService
+	webhook              backend.WebhookCallService
+	converter            usecase.DisputeConverter
+	sessConverter        usecase.SessionConverter
+	cdrConverter         usecase.CdrConverter
+}
+
+func NewDisputeUc(disputeService domain.DisputeService, cdrService domain.CdrService, sessService domain.SessionService, logService domain.OcpiLogService,
+	localPlatformService domain.LocalPlatformService, webhook backend.WebhookCallService) usecase.DisputeUc {
+	return &disputeUc{
+		disputeService:       disputeService,
+		cdrService:           cdrService,
+		sessService:          sessService,
+		logService:           logService,
+		localPlatformService: localPlatformService,
+		webhook:              webhook,
+		converter:            NewDisputeConverter(),
+		sessConverter:        NewSessionConverter(),
+		cdrConverter:         NewCdrConverter(NewTariffConverter()),
+	}
+}
+
+func (s *disputeUc) l() kit.CLogger {
+	return ocpi.L().Cmp("disp-uc")
+}
+
+func (s *disputeUc) OpenDispute(ctx context.Context, d *domain.Dispute) (*domain.Dispute, error) {
+	s.l().C(ctx).Mth("open").F(kit.KV{"cdrId": d.Details.CdrId}).Dbg()
+
+	cdr, err := s.mustGetCdr(ctx, d.Details.CdrId)
+	if err != nil {
+		return nil, err
+	}
+
+	// only CDRs received from partners are disputed
+	if cdr.PlatformId == s.localPlatformService.GetPlatformId(ctx) {
+		return nil, errors.ErrDisputeCdrInvalidPlatform(ctx)
+	}
+
+	// a single active dispute per CDR
+	rs, err := s.disputeService.Search(ctx, &domain.DisputeSearchCriteria{
+		PageRequest: domain.PageRequest{Limit: kit.IntPtr(1)},
+		CdrId:       cdr.Id,
+		Statuses:    disputeActiveStatuses,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rs.Items) > 0 {
+		return nil, errors.ErrDisputeAlreadyOpen(ctx, cdr.Id)
+	}
+
+	// dispute is of the partner owning the CDR
+	if d.Id == "" {
+		d.Id = kit.NewId()
+	}
+	d.ExtId = cdr.ExtId
+	d.PlatformId = cdr.PlatformId
+	d.LastUpdated = kit.Now()
+	d.Details.SessionId = cdr.Details.SessionId
+	if d.Details.Currency == "" {
+		d.Details.Currency = cdr.Details.Currency
+	}
+
+	// take snapshots of evidence
+	for _, ev := range d.Details.Evidence {
+		if err := s.snapshot(ctx, cdr, ev); err != nil {
+			return nil, err
+		}
+	}
+
+	d, err = s.disputeService.Create(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// call webhook
+	return d, s.webhook.OnDisputeChanged(ctx, s.converter.DisputeDomainToBackend(d))
+}
+
+func (s *disputeUc) AddEvidence(ctx context.Context, id string, ev *domain.DisputeEvidence) (*domain.Dispute, error) {
+	s.l().C(ctx).Mth("add-evidence").F(kit.KV{"dispId": id}).Dbg()
+
+	d, err := s.disputeService.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.ErrDisputeNotFound(ctx)
+	}
+
+	cdr, err := s.mustGetCdr(ctx, d.Details.CdrId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.snapshot(ctx, cdr, ev); err != nil {
+		return nil, err
+	}
+
+	d, err = s.disputeService.AddEvidence(ctx, id, ev)
+	if err != nil {
+		return nil, err
+	}
+
+	// call webhook
+	return d, s.webhook.OnDisputeChanged(ctx, s.converter.DisputeDomainToBackend(d))
+}
+
+func (s *disputeUc) SetStatus(ctx context.Context, id, status, comment, creditCdrId string) (*domain.Dispute, error) {
+	s.l().C(ctx).Mth("set-status").F(kit.KV{"dispId": id, "status": status}).Dbg()
+
+	d, err := s.disputeService.SetStatus(ctx, id, status, comment, creditCdrId)
+	if err != nil {
+		return nil, err
+	}
+
+	// call webhook
+	return d, s.webhook.OnDisputeChanged(ctx, s.converter.DisputeDomainToBackend(d))
+}
+
+func (s *disputeUc) OnCdrChanged(ctx context.Context, cdr *domain.Cdr) error {
+	if !cdr.Details.Credit || cdr.Details.CreditReferenceId == "" {
+		return nil
+	}
+
+	l := s.l().C(ctx).Mth("on-cdr").F(kit.KV{"cdrId": cdr.Id, "creditRef": cdr.Details.CreditReferenceId}).Dbg()
+
+	// active disputes of the credited CDR, CDR id is unique only within the platform, so the credit CDR must be of the same platform
+	rs, err := s.disputeService.Search(ctx, &domain.DisputeSearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(domain.PageSizeMaxLimit)},
+		CdrId:        cdr.Details.CreditReferenceId,
+		IncPlatforms: []string{cdr.PlatformId},
+		Statuses:     disputeActiveStatuses,
+	})
+	if err != nil {
+		return err
+	}
+
+	// credit CDR holds negative costs, the credited amount is positive
+	amount := &domain.Price{ExclVat: math.Abs(cdr.Details.TotalCost.ExclVat)}
+	if cdr.Details.TotalCost.InclVat != nil {
+		amount.InclVat = kit.Float64Ptr(math.Abs(*cdr.Details.TotalCost.InclVat))
+	}
+
+	for _, d := range rs.Items {
+		// amounts in different currencies can't be summed up
+		if d.Details.Currency != cdr.Details.Currency {
+			l.F(kit.KV{"dispId": d.Id, "currency": cdr.Details.Currency, "dispCurrency": d.Details.Currency}).Warn("credit currency differs from dispute, skipped")
+			continue
+		}
+
+		d, err := s.disputeService.AddCredit(ctx, d.Id, cdr.Id, amount)
+		if err != nil {
+			return err
+		}
+
+		// dispute is resolved only when the disputed amount is credited in full
+		if d.Details.Credited.ExclVat+disputeAmountTolerance >= d.Details.Amount.ExclVat {
+			if _, err := s.SetStatus(ctx, d.Id, domain.DisputeStatusResolved, disputeCreditComment, cdr.Id); err != nil {
+				return err
+			}
+			l.F(kit.KV{"dispId": d.Id}).Inf("resolved by credit cdr")
+			continue
+		}
+
+		// partial credit is linked, dispute remains open
+		if err := s.webhook.OnDisputeChanged(ctx, s.converter.DisputeDomainToBackend(d)); err != nil {
+			return err
+		}
+		l.F(kit.KV{"dispId": d.Id, "credited": d.Details.Credited.ExclVat}).Inf("partially credited")
+	}
+	return nil
+}
+
+func (s *disputeUc) GetDisputeSummary(ctx context.Context) ([]*domain.DisputeSummary, error) {
+	s.l().C(ctx).Mth("summary").Dbg()
+
+	summaries := newPartnerReports(func(platformId string, extId domain.PartyExtId) *domain.DisputeSummary {
+		return &domain.DisputeSummary{PartyExtId: extId, PlatformId: platformId, Amounts: make(map[string]float64)}
+	})
+	cr := &domain.DisputeSearchCriteria{
+		PageRequest: domain.PageRequest{Limit: kit.IntPtr(domain.PageSizeMaxLimit)},
+	}
+	err := scanPages(&cr.PageRequest, func() ([]*domain.Dispute, *domain.PageRequest, error) {
+		rs, err := s.disputeService.Search(ctx, cr)
+		if err != nil {
+			return nil, nil, err
+		}
+		return rs.Items, rs.NextPage, nil
+	}, func(d *domain.Dispute) {
+		sm := summaries.get(d.PlatformId, d.ExtId)
+		switch d.Status {
+		case domain.DisputeStatusOpen:
+			sm.Open++
+		case domain.DisputeStatusAcknowledged:
+			sm.Acknowledged++
+		case domain.DisputeStatusResolved:
+			sm.Resolved++
+		case domain.DisputeStatusRejected:
+			sm.Rejected++
+		}
+		if slices.Contains(disputeActiveStatuses, d.Status) {
+			sm.Amounts[d.Details.Currency] += d.Details.Amount.ExclVat
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summaries.sorted(), nil
+}
+
+func (s *disputeUc) mustGetCdr(ctx context.Context, cdrId string) (*domain.Cdr, error) {
+	if cdrId == "" {
+		return nil, errors.ErrDisputeEmptyAttr(ctx, "dispute", "cdr_id")
+	}
+	cdr, err := s.cdrService.GetCdr(ctx, cdrId)
+	if err != nil {
+		return nil, err
+	}
+	if cdr == nil {
+		return nil, errors.ErrDisputeCdrNotFound(ctx, cdrId)
+	}
+	return cdr, nil
+}
+
+// snapshot populates evidence with the current state of the referenced object
+// so that the evidence isn't affected by later changes
+func (s *disputeUc) snapshot(ctx context.Context, cdr *domain.Cdr, ev *domain.DisputeEvidence) error {
+	switch ev.Type {
+	case domain.DisputeEvidenceSession:
+		if ev.RefId == "" {
+			ev.RefId = cdr.Details.SessionId
+		}
+		sess, err := s.sessService.GetSessionWithPeriods(ctx, ev.RefId)
+		if err != nil {
+			return err
+		}
+		if sess == nil {
+			return errors.ErrSessNotFound(ctx)
+		}
+		ev.Data = s.sessConverter.SessionDomainToBackend(sess)
+	case domain.DisputeEvidenceChargingPeriods:
+		ev.RefId = cdr.Id
+		ev.Data = s.cdrConverter.CdrDomainToBackend(cdr).ChargingPeriods
+	case domain.DisputeEvidenceLog:
+		if ev.RefId == "" {
+			return errors.ErrDisputeEmptyAttr(ctx, "evidence", "ref_id")
+		}
+		msgs, err := s.logService.Search(ctx, &domain.SearchLogCriteria{RequestId: ev.RefId})
+		if err != nil {
+			return err
+		}
+		ev.Data = msgs
+	}
+	return nil
+}
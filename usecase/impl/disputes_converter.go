@@ -0,0 +1,104 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+)
+
+type disputeConverter struct {
+	baseConverter
+}
+
+func NewDisputeConverter() usecase.DisputeConverter {
+	return &disputeConverter{}
+}
+
+func (c *disputeConverter) DisputeDomainToBackend(d *domain.Dispute) *backend.Dispute {
+	if d == nil {
+		return nil
+	}
+	return &backend.Dispute{
+		Id:           d.Id,
+		Status:       d.Status,
+		CdrId:        d.Details.CdrId,
+		SessionId:    d.Details.SessionId,
+		Reason:       d.Details.Reason,
+		Amount:       *c.priceDomainToBackend(&d.Details.Amount),
+		Currency:     d.Details.Currency,
+		Evidence:     kit.Select(d.Details.Evidence, c.evidenceDomainToBackend),
+		Comment:      d.Details.Comment,
+		CreditCdrId:  d.Details.CreditCdrId,
+		Credited:     c.priceDomainToBackend(d.Details.Credited),
+		CreditCdrIds: d.Details.CreditCdrIds,
+		ClosedAt:     d.Details.ClosedAt,
+		LastUpdated:  d.LastUpdated,
+		PlatformId:   d.PlatformId,
+		RefId:        d.RefId,
+		PartyId:      d.ExtId.PartyId,
+		CountryCode:  d.ExtId.CountryCode,
+	}
+}
+
+func (c *disputeConverter) DisputesDomainToBackend(ds []*domain.Dispute) []*backend.Dispute {
+	return kit.Select(ds, c.DisputeDomainToBackend)
+}
+
+func (c *disputeConverter) DisputeBackendToDomain(d *backend.Dispute) *domain.Dispute {
+	if d == nil {
+		return nil
+	}
+	return &domain.Dispute{
+		OcpiItem: domain.OcpiItem{
+			RefId: d.RefId,
+		},
+		Id: d.Id,
+		Details: domain.DisputeDetails{
+			CdrId:    d.CdrId,
+			Reason:   d.Reason,
+			Amount:   *c.priceBackendToDomain(&d.Amount),
+			Currency: d.Currency,
+			Evidence: kit.Select(d.Evidence, c.DisputeEvidenceBackendToDomain),
+		},
+	}
+}
+
+func (c *disputeConverter) DisputeEvidenceBackendToDomain(ev *backend.DisputeEvidence) *domain.DisputeEvidence {
+	if ev == nil {
+		return nil
+	}
+	return &domain.DisputeEvidence{
+		Type:        ev.Type,
+		RefId:       ev.RefId,
+		Description: ev.Description,
+		Data:        ev.Data,
+	}
+}
+
+func (c *disputeConverter) DisputeSummariesDomainToBackend(rs []*domain.DisputeSummary) []*backend.DisputeSummary {
+	r := make([]*backend.DisputeSummary, 0, len(rs))
+	for _, i := range rs {
+		r = append(r, &backend.DisputeSummary{
+			PlatformId:   i.PlatformId,
+			PartyId:      i.PartyId,
+			CountryCode:  i.CountryCode,
+			Open:         i.Open,
+			Acknowledged: i.Acknowledged,
+			Resolved:     i.Resolved,
+			Rejected:     i.Rejected,
+			Amounts:      i.Amounts,
+		})
+	}
+	return r
+}
+
+func (c *disputeConverter) evidenceDomainToBackend(ev *domain.DisputeEvidence) *backend.DisputeEvidence {
+	return &backend.DisputeEvidence{
+		Type:        ev.Type,
+		RefId:       ev.RefId,
+		Description: ev.Description,
+		Data:        ev.Data,
+		AddedAt:     ev.AddedAt,
+	}
+}
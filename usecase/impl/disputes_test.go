@@ -0,0 +1,190 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type disputeUcTestSuite struct {
+	kit.Suite
+	uc                   *disputeUc
+	disputeService       *mocks.DisputeService
+	cdrService           *mocks.CdrService
+	sessService          *mocks.SessionService
+	logService           *mocks.OcpiLogService
+	localPlatformService *mocks.LocalPlatformService
+	webhook              *mocks.WebhookCallService
+}
+
+func (s *disputeUcTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *disputeUcTestSuite) SetupTest() {
+	s.disputeService = &mocks.DisputeService{}
+	s.cdrService = &mocks.CdrService{}
+	s.sessService = &mocks.SessionService{}
+	s.logService = &mocks.OcpiLogService{}
+	s.localPlatformService = &mocks.LocalPlatformService{}
+	s.webhook = &mocks.WebhookCallService{}
+	s.uc = NewDisputeUc(s.disputeService, s.cdrService, s.sessService, s.logService, s.localPlatformService, s.webhook).(*disputeUc)
+	s.localPlatformService.On("GetPlatformId", s.Ctx).Return("local")
+	s.webhook.On("OnDisputeChanged", s.Ctx, mock.Anything).Return(nil)
+}
+
+func TestDisputeUcSuite(t *testing.T) {
+	suite.Run(t, new(disputeUcTestSuite))
+}
+
+func (s *disputeUcTestSuite) cdr(platformId string) *domain.Cdr {
+	cdr := &domain.Cdr{
+		OcpiItem: domain.OcpiItem{
+			ExtId:      domain.PartyExtId{PartyId: "TST", CountryCode: "RS"},
+			PlatformId: platformId,
+		},
+		Id: kit.NewId(),
+		Details: domain.CdrDetails{
+			SessionId: kit.NewId(),
+			Currency:  "EUR",
+			ChargingPeriods: []*domain.ChargingPeriod{
+				{StartDateTime: kit.Now(), Dimensions: []*domain.CdrDimension{{Type: domain.DimensionTypeEnergy, Volume: 10}}},
+			},
+		},
+	}
+	s.cdrService.On("GetCdr", s.Ctx, cdr.Id).Return(cdr, nil)
+	return cdr
+}
+
+func (s *disputeUcTestSuite) Test_OpenDispute_Ok() {
+	cdr := s.cdr("remote")
+	sess := &domain.Session{Id: cdr.Details.SessionId}
+	s.sessService.On("GetSessionWithPeriods", s.Ctx, sess.Id).Return(sess, nil)
+	s.disputeService.On("Search", s.Ctx, mock.Anything).Return(&domain.DisputeSearchResponse{}, nil)
+	in := &domain.Dispute{
+		Details: domain.DisputeDetails{
+			CdrId:  cdr.Id,
+			Reason: "overcharged",
+			Amount: domain.Price{ExclVat: 10},
+			Evidence: []*domain.DisputeEvidence{
+				{Type: domain.DisputeEvidenceSession},
+				{Type: domain.DisputeEvidenceChargingPeriods},
+			},
+		},
+	}
+	s.disputeService.On("Create", s.Ctx, in).Return(in, nil)
+
+	d, err := s.uc.OpenDispute(s.Ctx, in)
+	s.NoError(err)
+	s.NotEmpty(d.Id)
+	s.Equal(cdr.PlatformId, d.PlatformId)
+	s.Equal(cdr.ExtId, d.ExtId)
+	s.Equal(cdr.Details.SessionId, d.Details.SessionId)
+	s.Equal("EUR", d.Details.Currency)
+	s.Equal(sess.Id, d.Details.Evidence[0].RefId)
+	s.IsType(&backend.Session{}, d.Details.Evidence[0].Data)
+	s.Equal(cdr.Id, d.Details.Evidence[1].RefId)
+	s.Len(d.Details.Evidence[1].Data, 1)
+	s.webhook.AssertCalled(s.T(), "OnDisputeChanged", s.Ctx, mock.Anything)
+}
+
+func (s *disputeUcTestSuite) Test_OpenDispute_LocalCdr() {
+	cdr := s.cdr("local")
+	_, err := s.uc.OpenDispute(s.Ctx, &domain.Dispute{Details: domain.DisputeDetails{CdrId: cdr.Id, Reason: "overcharged"}})
+	s.AssertAppErr(err, errors.ErrCodeDisputeCdrInvalidPlatform)
+}
+
+func (s *disputeUcTestSuite) Test_OpenDispute_CdrNotFound() {
+	cdrId := kit.NewId()
+	s.cdrService.On("GetCdr", s.Ctx, cdrId).Return(nil, nil)
+	_, err := s.uc.OpenDispute(s.Ctx, &domain.Dispute{Details: domain.DisputeDetails{CdrId: cdrId, Reason: "overcharged"}})
+	s.AssertAppErr(err, errors.ErrCodeDisputeCdrNotFound)
+}
+
+func (s *disputeUcTestSuite) Test_OpenDispute_AlreadyOpen() {
+	cdr := s.cdr("remote")
+	s.disputeService.On("Search", s.Ctx, mock.MatchedBy(func(cr *domain.DisputeSearchCriteria) bool { return cr.CdrId == cdr.Id })).
+		Return(&domain.DisputeSearchResponse{Items: []*domain.Dispute{{Id: kit.NewId(), Status: domain.DisputeStatusOpen}}}, nil)
+	_, err := s.uc.OpenDispute(s.Ctx, &domain.Dispute{Details: domain.DisputeDetails{CdrId: cdr.Id, Reason: "overcharged"}})
+	s.AssertAppErr(err, errors.ErrCodeDisputeAlreadyOpen)
+	s.disputeService.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *disputeUcTestSuite) Test_OnCdrChanged_CreditResolves() {
+	creditedId := kit.NewId()
+	disp := &domain.Dispute{OcpiItem: domain.OcpiItem{PlatformId: "remote"}, Id: kit.NewId(), Status: domain.DisputeStatusAcknowledged, Details: domain.DisputeDetails{Amount: domain.Price{ExclVat: 10}, Currency: "EUR"}}
+	credit := &domain.Cdr{OcpiItem: domain.OcpiItem{PlatformId: "remote"}, Id: kit.NewId(), Details: domain.CdrDetails{Credit: true, CreditReferenceId: creditedId, TotalCost: domain.Price{ExclVat: -10}, Currency: "EUR"}}
+	s.disputeService.On("Search", s.Ctx, mock.MatchedBy(func(cr *domain.DisputeSearchCriteria) bool {
+		return cr.CdrId == creditedId && len(cr.Statuses) == 2 && len(cr.IncPlatforms) == 1 && cr.IncPlatforms[0] == credit.PlatformId
+	})).Return(&domain.DisputeSearchResponse{Items: []*domain.Dispute{disp}}, nil)
+	s.disputeService.On("AddCredit", s.Ctx, disp.Id, credit.Id, &domain.Price{ExclVat: 10}).
+		Return(&domain.Dispute{Id: disp.Id, Status: disp.Status, Details: domain.DisputeDetails{Amount: disp.Details.Amount, Credited: &domain.Price{ExclVat: 10}, CreditCdrIds: []string{credit.Id}}}, nil)
+	s.disputeService.On("SetStatus", s.Ctx, disp.Id, domain.DisputeStatusResolved, mock.Anything, credit.Id).
+		Return(&domain.Dispute{Id: disp.Id, Status: domain.DisputeStatusResolved}, nil)
+
+	s.NoError(s.uc.OnCdrChanged(s.Ctx, credit))
+	s.disputeService.AssertCalled(s.T(), "SetStatus", s.Ctx, disp.Id, domain.DisputeStatusResolved, mock.Anything, credit.Id)
+	s.webhook.AssertCalled(s.T(), "OnDisputeChanged", s.Ctx, mock.MatchedBy(func(d *backend.Dispute) bool {
+		return d.Status == backend.DisputeStatusResolved
+	}))
+}
+
+func (s *disputeUcTestSuite) Test_OnCdrChanged_PartialCredit_RemainsOpen() {
+	creditedId := kit.NewId()
+	disp := &domain.Dispute{Id: kit.NewId(), Status: domain.DisputeStatusOpen, Details: domain.DisputeDetails{Amount: domain.Price{ExclVat: 10}, Currency: "EUR"}}
+	credit := &domain.Cdr{Id: kit.NewId(), Details: domain.CdrDetails{Credit: true, CreditReferenceId: creditedId, TotalCost: domain.Price{ExclVat: -4, InclVat: kit.Float64Ptr(-4.8)}, Currency: "EUR"}}
+	s.disputeService.On("Search", s.Ctx, mock.Anything).Return(&domain.DisputeSearchResponse{Items: []*domain.Dispute{disp}}, nil)
+	s.disputeService.On("AddCredit", s.Ctx, disp.Id, credit.Id, &domain.Price{ExclVat: 4, InclVat: kit.Float64Ptr(4.8)}).
+		Return(&domain.Dispute{Id: disp.Id, Status: disp.Status, Details: domain.DisputeDetails{Amount: disp.Details.Amount, Credited: &domain.Price{ExclVat: 4}, CreditCdrIds: []string{credit.Id}}}, nil)
+
+	s.NoError(s.uc.OnCdrChanged(s.Ctx, credit))
+	s.disputeService.AssertNotCalled(s.T(), "SetStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.webhook.AssertCalled(s.T(), "OnDisputeChanged", s.Ctx, mock.MatchedBy(func(d *backend.Dispute) bool {
+		return d.Status == backend.DisputeStatusOpen && d.Credited.ExclVat == 4 && d.CreditCdrIds[0] == credit.Id
+	}))
+}
+
+func (s *disputeUcTestSuite) Test_OnCdrChanged_AnotherCurrency_Skipped() {
+	creditedId := kit.NewId()
+	disp := &domain.Dispute{Id: kit.NewId(), Status: domain.DisputeStatusOpen, Details: domain.DisputeDetails{Amount: domain.Price{ExclVat: 10}, Currency: "EUR"}}
+	credit := &domain.Cdr{Id: kit.NewId(), Details: domain.CdrDetails{Credit: true, CreditReferenceId: creditedId, TotalCost: domain.Price{ExclVat: -10}, Currency: "RSD"}}
+	s.disputeService.On("Search", s.Ctx, mock.Anything).Return(&domain.DisputeSearchResponse{Items: []*domain.Dispute{disp}}, nil)
+
+	s.NoError(s.uc.OnCdrChanged(s.Ctx, credit))
+	s.disputeService.AssertNotCalled(s.T(), "AddCredit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.webhook.AssertNotCalled(s.T(), "OnDisputeChanged", mock.Anything, mock.Anything)
+}
+
+func (s *disputeUcTestSuite) Test_OnCdrChanged_NotCredit() {
+	s.NoError(s.uc.OnCdrChanged(s.Ctx, &domain.Cdr{Id: kit.NewId()}))
+	s.disputeService.AssertNotCalled(s.T(), "Search", mock.Anything, mock.Anything)
+}
+
+func (s *disputeUcTestSuite) Test_GetDisputeSummary_GroupedByPartner() {
+	p1 := domain.OcpiItem{ExtId: domain.PartyExtId{PartyId: "AAA", CountryCode: "RS"}, PlatformId: "p1"}
+	p2 := domain.OcpiItem{ExtId: domain.PartyExtId{PartyId: "BBB", CountryCode: "RS"}, PlatformId: "p2"}
+	s.disputeService.On("Search", s.Ctx, mock.Anything).Return(&domain.DisputeSearchResponse{Items: []*domain.Dispute{
+		{OcpiItem: p2, Id: kit.NewId(), Status: domain.DisputeStatusRejected, Details: domain.DisputeDetails{Amount: domain.Price{ExclVat: 5}, Currency: "EUR"}},
+		{OcpiItem: p1, Id: kit.NewId(), Status: domain.DisputeStatusOpen, Details: domain.DisputeDetails{Amount: domain.Price{ExclVat: 10}, Currency: "EUR"}},
+		{OcpiItem: p1, Id: kit.NewId(), Status: domain.DisputeStatusAcknowledged, Details: domain.DisputeDetails{Amount: domain.Price{ExclVat: 2.5}, Currency: "EUR"}},
+		{OcpiItem: p1, Id: kit.NewId(), Status: domain.DisputeStatusResolved, Details: domain.DisputeDetails{Amount: domain.Price{ExclVat: 7}, Currency: "EUR"}},
+	}}, nil)
+
+	rs, err := s.uc.GetDisputeSummary(s.Ctx)
+	s.NoError(err)
+	s.Len(rs, 2)
+	s.Equal("p1", rs[0].PlatformId)
+	s.Equal(1, rs[0].Open)
+	s.Equal(1, rs[0].Acknowledged)
+	s.Equal(1, rs[0].Resolved)
+	s.Equal(12.5, rs[0].Amounts["EUR"])
+	s.Equal("p2", rs[1].PlatformId)
+	s.Equal(1, rs[1].Rejected)
+	s.Empty(rs[1].Amounts)
+}
@@ -0,0 +1,146 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"sync"
+	"time"
+)
+
+// evseStatusFn pushes statuses of evses to remote platforms, it mustn't wait until pushes are done
+type evseStatusFn func(ctx context.Context, evses []*domain.Evse)
+
+// evseStatusBuffer coalesces rapid status changes of evses, so that only the latest status within the window is pushed
+// statuses collected within the window are pushed as a single batch
+// a batch collects statuses of different requests, so it's pushed with its own request context
+type evseStatusBuffer struct {
+	sync.Mutex
+	window    time.Duration
+	push      evseStatusFn
+	evses     map[string]*domain.Evse // evses the latest statuses to push
+	timer     *time.Timer             // timer fires when window is over
+	scheduled bool                    // scheduled if flush is scheduled
+	wg        sync.WaitGroup
+	closed    bool
+}
+
+func newEvseStatusBuffer(window time.Duration, push evseStatusFn) *evseStatusBuffer {
+	return &evseStatusBuffer{
+		window: window,
+		push:   push,
+		evses:  make(map[string]*domain.Evse),
+	}
+}
+
+func evseKey(evse *domain.Evse) string {
+	return evse.LocationId + "/" + evse.Id
+}
+
+// add puts evse statuses to the buffer, the previous statuses waiting to be pushed are replaced
+func (b *evseStatusBuffer) add(evses ...*domain.Evse) {
+	b.Lock()
+	defer b.Unlock()
+	for _, evse := range evses {
+		b.evses[evseKey(evse)] = evse
+	}
+	b.schedule()
+}
+
+// schedule schedules flush when window is over, must be called under lock
+func (b *evseStatusBuffer) schedule() {
+	if b.scheduled {
+		return
+	}
+	b.scheduled = true
+	b.wg.Add(1)
+	if b.closed || b.window <= 0 {
+		go b.flush()
+		return
+	}
+	b.timer = time.AfterFunc(b.window, b.flush)
+}
+
+// flush pushes the latest statuses of evses
+func (b *evseStatusBuffer) flush() {
+	defer b.wg.Done()
+
+	b.Lock()
+	b.timer, b.scheduled = nil, false
+	evses := make([]*domain.Evse, 0, len(b.evses))
+	for _, evse := range b.evses {
+		evses = append(evses, evse)
+	}
+	b.evses = make(map[string]*domain.Evse)
+	b.Unlock()
+
+	if len(evses) == 0 {
+		return
+	}
+
+	b.push(kit.NewRequestCtx().Empty().WithNewRequestId().ToContext(context.Background()), evses)
+}
+
+// close pushes all the statuses without waiting for window is over
+// statuses added after closing are pushed immediately
+func (b *evseStatusBuffer) close(ctx context.Context) {
+	b.Lock()
+	b.closed = true
+	if b.timer != nil && b.timer.Stop() {
+		b.timer = nil
+		go b.flush()
+	}
+	b.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// pendingEvses statuses of evses waiting in the platform queues
+// while a push to a platform is waiting in the queue, new statuses are merged into it, so that a slow platform gets only the latest statuses
+// once the push is started, new statuses wait for the next one, so that the platform receives statuses in order
+type pendingEvses struct {
+	sync.Mutex
+	platforms map[string]map[string]*domain.Evse
+}
+
+func newPendingEvses() *pendingEvses {
+	return &pendingEvses{
+		platforms: make(map[string]map[string]*domain.Evse),
+	}
+}
+
+// add merges statuses to the pending ones of the platform, returns true if the platform has no pending push yet
+func (p *pendingEvses) add(platformId string, evses []*domain.Evse) bool {
+	p.Lock()
+	defer p.Unlock()
+	pending, ok := p.platforms[platformId]
+	if !ok {
+		pending = make(map[string]*domain.Evse)
+		p.platforms[platformId] = pending
+	}
+	for _, evse := range evses {
+		pending[evseKey(evse)] = evse
+	}
+	return !ok
+}
+
+// take retrieves pending statuses of the platform when its push is started
+func (p *pendingEvses) take(platformId string) []*domain.Evse {
+	p.Lock()
+	defer p.Unlock()
+	pending := p.platforms[platformId]
+	delete(p.platforms, platformId)
+	evses := make([]*domain.Evse, 0, len(pending))
+	for _, evse := range pending {
+		evses = append(evses, evse)
+	}
+	return evses
+}
@@ -0,0 +1,131 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+	"time"
+)
+
+type evseBufferTestSuite struct {
+	kit.Suite
+	sync.Mutex
+	pushed  []string
+	batches int
+	delay   time.Duration
+}
+
+func (s *evseBufferTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *evseBufferTestSuite) SetupTest() {
+	s.pushed, s.batches, s.delay = nil, 0, 0
+}
+
+func TestEvseBufferSuite(t *testing.T) {
+	suite.Run(t, new(evseBufferTestSuite))
+}
+
+func (s *evseBufferTestSuite) push(ctx context.Context, evses []*domain.Evse) {
+	time.Sleep(s.delay)
+	s.Lock()
+	s.batches++
+	for _, evse := range evses {
+		s.pushed = append(s.pushed, evse.Status)
+	}
+	s.Unlock()
+}
+
+func (s *evseBufferTestSuite) evse(status string) *domain.Evse {
+	return &domain.Evse{Id: "evse", LocationId: "loc", Status: status}
+}
+
+func (s *evseBufferTestSuite) statuses() []string {
+	s.Lock()
+	defer s.Unlock()
+	return append([]string{}, s.pushed...)
+}
+
+func (s *evseBufferTestSuite) Test_Coalesced() {
+	b := newEvseStatusBuffer(time.Millisecond*50, s.push)
+	b.add(s.evse(domain.EvseStatusCharging))
+	b.add(s.evse(domain.EvseStatusInOperative))
+	b.add(s.evse(domain.EvseStatusAvailable))
+	s.Empty(s.statuses())
+
+	s.Eventually(func() bool { return len(s.statuses()) > 0 }, time.Second, time.Millisecond*10)
+	time.Sleep(time.Millisecond * 100)
+	s.Equal([]string{domain.EvseStatusAvailable}, s.statuses())
+	b.Lock()
+	s.Empty(b.evses)
+	b.Unlock()
+}
+
+func (s *evseBufferTestSuite) Test_DifferentEvses_NotCoalesced() {
+	b := newEvseStatusBuffer(time.Millisecond*10, s.push)
+	b.add(s.evse(domain.EvseStatusCharging))
+	b.add(&domain.Evse{Id: "another", LocationId: "loc", Status: domain.EvseStatusAvailable})
+	s.Eventually(func() bool { return len(s.statuses()) == 2 }, time.Second, time.Millisecond*10)
+	b.close(s.Ctx)
+	s.ElementsMatch([]string{domain.EvseStatusCharging, domain.EvseStatusAvailable}, s.statuses())
+	// pushed as a single batch
+	s.Equal(1, s.batches)
+}
+
+func (s *evseBufferTestSuite) Test_Batch() {
+	b := newEvseStatusBuffer(time.Hour, s.push)
+	var evses []*domain.Evse
+	for i := 0; i < 10; i++ {
+		evses = append(evses, &domain.Evse{Id: kit.NewRandString(), LocationId: "loc", Status: domain.EvseStatusAvailable})
+	}
+	b.add(evses...)
+	b.close(s.Ctx)
+	s.Len(s.statuses(), 10)
+	s.Equal(1, s.batches)
+}
+
+func (s *evseBufferTestSuite) Test_Flush_NotBlockedByPush() {
+	s.delay = time.Millisecond * 200
+	b := newEvseStatusBuffer(0, func(ctx context.Context, evses []*domain.Evse) {
+		// push is done in background
+		go s.push(ctx, evses)
+	})
+	b.add(s.evse(domain.EvseStatusCharging))
+	start := time.Now()
+	b.close(s.Ctx)
+	s.Less(time.Since(start), s.delay)
+	s.Eventually(func() bool { return len(s.statuses()) == 1 }, time.Second, time.Millisecond*10)
+}
+
+func (s *evseBufferTestSuite) Test_Pending_Merged() {
+	p := newPendingEvses()
+	s.True(p.add("platform", []*domain.Evse{s.evse(domain.EvseStatusCharging)}))
+	// push is waiting in the queue, the status is replaced
+	s.False(p.add("platform", []*domain.Evse{s.evse(domain.EvseStatusAvailable), {Id: "another", LocationId: "loc", Status: domain.EvseStatusBlocked}}))
+	// another platform has its own push
+	s.True(p.add("another", []*domain.Evse{s.evse(domain.EvseStatusCharging)}))
+
+	evses := p.take("platform")
+	s.Len(evses, 2)
+	s.ElementsMatch([]string{domain.EvseStatusAvailable, domain.EvseStatusBlocked}, []string{evses[0].Status, evses[1].Status})
+
+	// push is started, new statuses need a new push
+	s.True(p.add("platform", []*domain.Evse{s.evse(domain.EvseStatusCharging)}))
+	s.Len(p.take("another"), 1)
+}
+
+func (s *evseBufferTestSuite) Test_Close_Flushes() {
+	b := newEvseStatusBuffer(time.Hour, s.push)
+	b.add(s.evse(domain.EvseStatusCharging))
+	b.close(s.Ctx)
+	s.Equal([]string{domain.EvseStatusCharging}, s.statuses())
+
+	// pushed immediately after closing
+	b.add(s.evse(domain.EvseStatusAvailable))
+	s.Eventually(func() bool { return len(s.statuses()) == 2 }, time.Second, time.Millisecond*10)
+}
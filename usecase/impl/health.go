@@ -0,0 +1,206 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/goroutine"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+	"time"
+)
+
+const (
+	healthFailureThresholdDefault  = 3
+	healthRecoveryThresholdDefault = 2
+)
+
+type healthUc struct {
+	ucBase
+	platformService      domain.PlatformService
+	localPlatformService domain.LocalPlatformService
+	remotePlatformRep    usecase.RemotePlatformRepository
+	webhook              backend.WebhookCallService
+	converter            usecase.CredentialsConverter
+	pullers              []usecase.PlatformPuller
+	cfg                  *ocpi.CfgHealth
+}
+
+func NewHealthUc(platformService domain.PlatformService, localPlatformService domain.LocalPlatformService, remotePlatformRep usecase.RemotePlatformRepository,
+	partyService domain.PartyService, webhook backend.WebhookCallService, tokenGen domain.TokenGenerator, pullers ...usecase.PlatformPuller) usecase.HealthUc {
+	return &healthUc{
+		ucBase:               newBase(platformService, partyService, tokenGen),
+		platformService:      platformService,
+		localPlatformService: localPlatformService,
+		remotePlatformRep:    remotePlatformRep,
+		webhook:              webhook,
+		converter:            NewCredentialsConverter(),
+		pullers:              pullers,
+		cfg:                  &ocpi.CfgHealth{},
+	}
+}
+
+func (h *healthUc) l() kit.CLogger {
+	return ocpi.L().Cmp("health-uc")
+}
+
+func (h *healthUc) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
+	if cfg != nil && cfg.Remote != nil && cfg.Remote.Health != nil {
+		h.cfg = cfg.Remote.Health
+	}
+	return nil
+}
+
+func (h *healthUc) failureThreshold() int {
+	if h.cfg.FailureThreshold > 0 {
+		return h.cfg.FailureThreshold
+	}
+	return healthFailureThresholdDefault
+}
+
+func (h *healthUc) recoveryThreshold() int {
+	if h.cfg.RecoveryThreshold > 0 {
+		return h.cfg.RecoveryThreshold
+	}
+	return healthRecoveryThresholdDefault
+}
+
+func (h *healthUc) CheckPlatform(ctx context.Context, platformId string) (*domain.Platform, error) {
+	l := h.l().C(ctx).Mth("check-platform").F(kit.KV{"platformId": platformId}).Dbg()
+
+	platform, err := h.platformService.Get(ctx, platformId)
+	if err != nil {
+		return nil, err
+	}
+	if platform == nil {
+		return nil, errors.ErrPlatformNotFound(ctx, platformId)
+	}
+	if !h.checkable(platform) {
+		return platform, nil
+	}
+
+	localPlatform, err := h.localPlatformService.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	health := platform.Health
+	if health == nil {
+		health = &domain.PlatformHealth{}
+	}
+
+	// request versions of the remote platform
+	startedAt := kit.Now()
+	_, checkErr := h.remotePlatformRep.GetVersions(ctx, buildOcpiRepositoryRequest(platform.VersionInfo.VersionEp, h.tokenC(platform), localPlatform, platform))
+	health.CheckedAt = kit.NowPtr()
+	health.LatencyMs = health.CheckedAt.Sub(startedAt).Milliseconds()
+	if checkErr != nil {
+		l.E(checkErr).Warn("check failed")
+		health.Failures++
+		health.Successes = 0
+		health.LastError = checkErr.Error()
+	} else {
+		health.Successes++
+		health.Failures = 0
+		health.LastError = ""
+	}
+
+	// hysteresis: status is changed only when the number of consecutive results reaches the threshold
+	status := platform.Status
+	var offlineSince *time.Time
+	switch {
+	case platform.Status == domain.ConnectionStatusConnected && health.Failures >= h.failureThreshold():
+		status = domain.ConnectionStatusOffLine
+		health.OfflineSince = health.CheckedAt
+	case platform.Status == domain.ConnectionStatusConnected:
+		// platform might be brought back outside of health checks
+		health.OfflineSince = nil
+	case platform.Status == domain.ConnectionStatusOffLine && health.Successes >= h.recoveryThreshold():
+		status = domain.ConnectionStatusConnected
+		offlineSince = health.OfflineSince
+		health.OfflineSince = nil
+	}
+
+	platform, err = h.platformService.SetHealth(ctx, platform.Id, health)
+	if err != nil {
+		return nil, err
+	}
+	if status == platform.Status {
+		return platform, nil
+	}
+
+	// change status
+	l.F(kit.KV{"status": status}).Inf("status changed")
+	platform, err = h.platformService.SetStatus(ctx, platform.Id, status)
+	if err != nil {
+		return nil, err
+	}
+
+	// notify
+	if err := h.webhook.OnPlatformStatusChanged(ctx, h.converter.PlatformDomainToBackend(platform)); err != nil {
+		l.E(err).St().Err()
+	}
+
+	// catch up with changes missed while the platform was offline
+	if status == domain.ConnectionStatusConnected {
+		h.catchUp(ctx, platform.Id, offlineSince)
+	}
+
+	return platform, nil
+}
+
+// checkable checks if the platform is subject to health checks
+// platforms moved OFFLINE manually aren't checked, so that they aren't brought back automatically
+func (h *healthUc) checkable(platform *domain.Platform) bool {
+	if !platform.Remote || platform.VersionInfo.VersionEp == "" {
+		return false
+	}
+	switch platform.Status {
+	case domain.ConnectionStatusConnected:
+		return true
+	case domain.ConnectionStatusOffLine:
+		return platform.Health != nil && platform.Health.OfflineSince != nil
+	}
+	return false
+}
+
+func (h *healthUc) catchUp(ctx context.Context, platformId string, from *time.Time) {
+	for _, puller := range h.pullers {
+		p := puller
+		goroutine.New().
+			WithLogger(h.l().C(ctx).Mth("catch-up").F(kit.KV{"platformId": platformId})).
+			Go(ctx, func() {
+				if err := p.OnRemotePlatformPull(ctx, platformId, from, nil); err != nil {
+					h.l().C(ctx).Mth("catch-up").F(kit.KV{"platformId": platformId}).E(err).St().Err()
+				}
+			})
+	}
+}
+
+func (h *healthUc) HealthCheckCronHandler(ctx context.Context) {
+	l := h.l().C(ctx).Mth("health-check-cron").Dbg()
+
+	if !h.cfg.Enabled {
+		return
+	}
+
+	platforms, err := h.platformService.Search(ctx, &domain.PlatformSearchCriteria{
+		Statuses: []string{domain.ConnectionStatusConnected, domain.ConnectionStatusOffLine},
+		Remote:   kit.BoolPtr(true),
+	})
+	if err != nil {
+		l.E(err).St().Err()
+		return
+	}
+
+	for _, platform := range platforms {
+		if !h.checkable(platform) {
+			continue
+		}
+		if _, err := h.CheckPlatform(ctx, platform.Id); err != nil {
+			l.E(err).St().Err()
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package impl
+
+import (
+	"fmt"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type healthUcTestSuite struct {
+	kit.Suite
+	uc                *healthUc
+	platformSvc       *mocks.PlatformService
+	localPlatformSvc  *mocks.LocalPlatformService
+	tokenGen          *mocks.TokenGenerator
+	remotePlatformRep *mocks.RemotePlatformRepository
+	partyService      *mocks.PartyService
+	webhook           *mocks.WebhookCallService
+	locUc             *mocks.LocationUc
+}
+
+func (s *healthUcTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *healthUcTestSuite) SetupTest() {
+	s.platformSvc = &mocks.PlatformService{}
+	s.localPlatformSvc = &mocks.LocalPlatformService{}
+	s.localPlatformSvc.On("Get", s.Ctx).Return(&domain.Platform{Id: kit.NewRandString()}, nil)
+	s.tokenGen = &mocks.TokenGenerator{}
+	s.remotePlatformRep = &mocks.RemotePlatformRepository{}
+	s.partyService = &mocks.PartyService{}
+	s.webhook = &mocks.WebhookCallService{}
+	s.webhook.On("OnPlatformStatusChanged", s.Ctx, mock.Anything).Return(nil)
+	s.locUc = &mocks.LocationUc{}
+	s.uc = NewHealthUc(s.platformSvc, s.localPlatformSvc, s.remotePlatformRep, s.partyService, s.webhook, s.tokenGen, s.locUc).(*healthUc)
+	s.NoError(s.uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{Health: &ocpi.CfgHealth{
+		Enabled:           true,
+		FailureThreshold:  3,
+		RecoveryThreshold: 2,
+	}}}))
+}
+
+func TestHealthUcSuite(t *testing.T) {
+	suite.Run(t, new(healthUcTestSuite))
+}
+
+func (s *healthUcTestSuite) platform(status string, health *domain.PlatformHealth) *domain.Platform {
+	return &domain.Platform{
+		Id:     kit.NewRandString(),
+		Role:   domain.RoleCPO,
+		Remote: true,
+		VersionInfo: domain.VersionInfo{
+			Current:   "2.2.1",
+			VersionEp: domain.Endpoint("http://versions/" + kit.NewRandString()),
+		},
+		Status: status,
+		Health: health,
+	}
+}
+
+func (s *healthUcTestSuite) mockPlatform(p *domain.Platform) {
+	s.platformSvc.On("Get", s.Ctx, p.Id).Return(p, nil)
+	s.platformSvc.On("SetHealth", s.Ctx, p.Id, mock.Anything).
+		Run(func(args mock.Arguments) { p.Health = args.Get(2).(*domain.PlatformHealth) }).
+		Return(p, nil)
+	s.platformSvc.On("SetStatus", s.Ctx, p.Id, mock.Anything).
+		Run(func(args mock.Arguments) { p.Status = args.String(2) }).
+		Return(p, nil)
+}
+
+func (s *healthUcTestSuite) Test_BelowThreshold_StaysConnected() {
+	p := s.platform(domain.ConnectionStatusConnected, &domain.PlatformHealth{Failures: 1})
+	s.mockPlatform(p)
+	s.remotePlatformRep.On("GetVersions", s.Ctx, mock.Anything).Return(nil, fmt.Errorf("unavailable"))
+
+	r, err := s.uc.CheckPlatform(s.Ctx, p.Id)
+	s.NoError(err)
+	s.Equal(domain.ConnectionStatusConnected, r.Status)
+	s.Equal(2, r.Health.Failures)
+	s.Equal("unavailable", r.Health.LastError)
+	s.NotEmpty(r.Health.CheckedAt)
+	s.Nil(r.Health.OfflineSince)
+	s.platformSvc.AssertNotCalled(s.T(), "SetStatus", s.Ctx, p.Id, mock.Anything)
+	s.webhook.AssertNotCalled(s.T(), "OnPlatformStatusChanged", s.Ctx, mock.Anything)
+}
+
+func (s *healthUcTestSuite) Test_FailureThreshold_GoesOffline() {
+	p := s.platform(domain.ConnectionStatusConnected, &domain.PlatformHealth{Failures: 2})
+	s.mockPlatform(p)
+	s.remotePlatformRep.On("GetVersions", s.Ctx, mock.Anything).Return(nil, fmt.Errorf("unavailable"))
+
+	r, err := s.uc.CheckPlatform(s.Ctx, p.Id)
+	s.NoError(err)
+	s.Equal(domain.ConnectionStatusOffLine, r.Status)
+	s.Equal(3, r.Health.Failures)
+	s.NotEmpty(r.Health.OfflineSince)
+	s.platformSvc.AssertCalled(s.T(), "SetStatus", s.Ctx, p.Id, domain.ConnectionStatusOffLine)
+	s.webhook.AssertCalled(s.T(), "OnPlatformStatusChanged", s.Ctx, mock.Anything)
+	s.locUc.AssertNotCalled(s.T(), "OnRemotePlatformPull", s.Ctx, p.Id, mock.Anything, mock.Anything)
+}
+
+func (s *healthUcTestSuite) Test_Success_ResetsFailures() {
+	p := s.platform(domain.ConnectionStatusConnected, &domain.PlatformHealth{Failures: 2, LastError: "unavailable"})
+	s.mockPlatform(p)
+	s.remotePlatformRep.On("GetVersions", s.Ctx, mock.Anything).Return(domain.Versions{}, nil)
+
+	r, err := s.uc.CheckPlatform(s.Ctx, p.Id)
+	s.NoError(err)
+	s.Equal(domain.ConnectionStatusConnected, r.Status)
+	s.Equal(0, r.Health.Failures)
+	s.Equal(1, r.Health.Successes)
+	s.Empty(r.Health.LastError)
+}
+
+func (s *healthUcTestSuite) Test_RecoveryThreshold_ReconnectsAndCatchesUp() {
+	offlineSince := kit.Now().Add(-time.Hour)
+	p := s.platform(domain.ConnectionStatusOffLine, &domain.PlatformHealth{Successes: 1, OfflineSince: &offlineSince})
+	s.mockPlatform(p)
+	s.remotePlatformRep.On("GetVersions", s.Ctx, mock.Anything).Return(domain.Versions{}, nil)
+	pulled := make(chan *time.Time, 1)
+	s.locUc.On("OnRemotePlatformPull", s.Ctx, p.Id, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { pulled <- args.Get(2).(*time.Time) }).
+		Return(nil)
+
+	r, err := s.uc.CheckPlatform(s.Ctx, p.Id)
+	s.NoError(err)
+	s.Equal(domain.ConnectionStatusConnected, r.Status)
+	s.Nil(r.Health.OfflineSince)
+	s.webhook.AssertCalled(s.T(), "OnPlatformStatusChanged", s.Ctx, mock.Anything)
+
+	select {
+	case from := <-pulled:
+		s.Equal(offlineSince, *from)
+	case <-time.After(time.Second):
+		s.Fail("catch-up pull isn't triggered")
+	}
+}
+
+func (s *healthUcTestSuite) Test_BelowRecoveryThreshold_StaysOffline() {
+	offlineSince := kit.Now().Add(-time.Hour)
+	p := s.platform(domain.ConnectionStatusOffLine, &domain.PlatformHealth{Failures: 5, OfflineSince: &offlineSince})
+	s.mockPlatform(p)
+	s.remotePlatformRep.On("GetVersions", s.Ctx, mock.Anything).Return(domain.Versions{}, nil)
+
+	r, err := s.uc.CheckPlatform(s.Ctx, p.Id)
+	s.NoError(err)
+	s.Equal(domain.ConnectionStatusOffLine, r.Status)
+	s.Equal(1, r.Health.Successes)
+	s.NotEmpty(r.Health.OfflineSince)
+	s.platformSvc.AssertNotCalled(s.T(), "SetStatus", s.Ctx, p.Id, mock.Anything)
+}
+
+func (s *healthUcTestSuite) Test_ManuallyOffline_NotChecked() {
+	p := s.platform(domain.ConnectionStatusOffLine, nil)
+	s.mockPlatform(p)
+
+	r, err := s.uc.CheckPlatform(s.Ctx, p.Id)
+	s.NoError(err)
+	s.Equal(domain.ConnectionStatusOffLine, r.Status)
+	s.remotePlatformRep.AssertNotCalled(s.T(), "GetVersions", s.Ctx, mock.Anything)
+	s.platformSvc.AssertNotCalled(s.T(), "SetHealth", s.Ctx, p.Id, mock.Anything)
+}
+
+func (s *healthUcTestSuite) Test_CronHandler_Disabled() {
+	s.NoError(s.uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{Health: &ocpi.CfgHealth{}}}))
+	s.uc.HealthCheckCronHandler(s.Ctx)
+	s.platformSvc.AssertNotCalled(s.T(), "Search", s.Ctx, mock.Anything)
+}
@@ -16,6 +16,8 @@ import (
 const (
 	locWorkersNum = 16
 	locPageSize   = 100
+
+	evseStatusWindowDefault = time.Second
 )
 
 type locationUc struct {
@@ -26,12 +28,15 @@ type locationUc struct {
 	partyService      domain.PartyService
 	webhook           backend.WebhookCallService
 	converter         usecase.LocationConverter
+	evseBuffer        *evseStatusBuffer
+	queue             *platformQueue
+	pendingEvses      *pendingEvses
 }
 
 func NewLocationUc(platformService domain.PlatformService, locationService domain.LocationService,
 	remoteLocationRep usecase.RemoteLocationRepository, partyService domain.PartyService,
 	webhook backend.WebhookCallService, localPlatform domain.LocalPlatformService, tokenGen domain.TokenGenerator) usecase.LocationUc {
-	return &locationUc{
+	uc := &locationUc{
 		ucBase:            newBase(platformService, partyService, tokenGen),
 		locationService:   locationService,
 		remoteLocationRep: remoteLocationRep,
@@ -39,13 +44,31 @@ func NewLocationUc(platformService domain.PlatformService, locationService domai
 		webhook:           webhook,
 		localPlatform:     localPlatform,
 		converter:         NewLocationConverter(),
+		queue:             newPlatformQueue(),
+		pendingEvses:      newPendingEvses(),
 	}
+	uc.evseBuffer = newEvseStatusBuffer(evseStatusWindowDefault, uc.pushEvseStatuses)
+	return uc
 }
 
 func (l *locationUc) l() kit.CLogger {
 	return ocpi.L().Cmp("loc-uc")
 }
 
+func (l *locationUc) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
+	if cfg != nil && cfg.Remote != nil && cfg.Remote.Push != nil {
+		l.evseBuffer.Lock()
+		l.evseBuffer.window = time.Duration(cfg.Remote.Push.EvseStatusWindowMs) * time.Millisecond
+		l.evseBuffer.Unlock()
+	}
+	return nil
+}
+
+func (l *locationUc) Close(ctx context.Context) {
+	l.evseBuffer.close(ctx)
+	l.queue.close(ctx)
+}
+
 func (l *locationUc) OnLocalLocationChanged(ctx context.Context, loc *domain.Location) error {
 	lg := l.l().C(ctx).Mth("on-loc-changed-loc").F(kit.KV{"locId": loc.Id}).Dbg()
 
@@ -85,14 +108,20 @@ func (l *locationUc) OnLocalLocationChanged(ctx context.Context, loc *domain.Loc
 
 	// for each platform
 	ocpiLoc := l.converter.LocationDomainToModel(loc)
+	// pushes outlive the request
+	pushCtx := kit.Copy(ctx)
 	for _, platform := range platforms {
 		platform := platform
 		// check if location receiver is supported by the remote platform
 		ep := l.platformService.RoleEndpoint(ctx, platform, model.ModuleIdLocations, model.OcpiReceiver)
 		if ep != "" && (platform.Protocol == nil || platform.Protocol.PushSupport.Locations) {
-			// push location to a remote platform
-			rq := buildOcpiRepositoryErrHandlerRequestG(ep, l.tokenC(platform), localPlatform, platform, ocpiLoc, lg)
-			l.remoteLocationRep.PutLocationAsync(ctx, rq)
+			// push location to a remote platform, pushes are queued per platform, so that it receives changes in order
+			l.queue.enqueue(ctx, lg, platform.Id, func() {
+				rq := buildOcpiRepositoryRequestG(ep, l.tokenC(platform), localPlatform, platform, ocpiLoc)
+				if err := l.remoteLocationRep.PutLocation(pushCtx, rq); err != nil {
+					lg.F(kit.KV{"platform": platform.Id}).E(err).St().Err()
+				}
+			})
 		} else {
 			lg.F(kit.KV{"platform": platform.Id}).Dbg("push not supported")
 		}
@@ -101,6 +130,99 @@ func (l *locationUc) OnLocalLocationChanged(ctx context.Context, loc *domain.Loc
 	return nil
 }
 
+func (l *locationUc) OnLocalLocationsChanged(ctx context.Context, locs []*domain.Location) ([]*domain.BulkItemResult, error) {
+	lg := l.l().C(ctx).Mth("on-locs-changed-loc").F(kit.KV{"count": len(locs)}).Dbg()
+
+	if err := l.validateBulk(ctx, len(locs)); err != nil {
+		return nil, err
+	}
+
+	// check locations are of the local platform
+	results := make([]*domain.BulkItemResult, len(locs))
+	var accepted []*domain.Location
+	var positions []int
+	for i, loc := range locs {
+		if loc == nil {
+			results[i] = &domain.BulkItemResult{Err: errors.ErrLocIdEmpty(ctx)}
+			continue
+		}
+		stored, err := l.locationService.GetLocation(ctx, loc.Id, false)
+		if err == nil && stored != nil && stored.PlatformId != l.localPlatform.GetPlatformId(ctx) {
+			err = errors.ErrLocNotBelongLocalPlatform(ctx)
+		}
+		if err != nil {
+			results[i] = &domain.BulkItemResult{Id: loc.Id, Err: err}
+			continue
+		}
+		accepted = append(accepted, loc)
+		positions = append(positions, i)
+	}
+	if len(accepted) == 0 {
+		return results, nil
+	}
+
+	// put locations to local platform
+	applied, rs, err := l.locationService.PutLocations(ctx, accepted)
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range rs {
+		results[positions[i]] = r
+	}
+
+	// no changes applied
+	if len(applied) == 0 {
+		lg.Warn("no changes applied")
+		return results, nil
+	}
+
+	// push is queued per platform
+	l.pushLocations(kit.Copy(ctx), applied)
+
+	return results, nil
+}
+
+// pushLocations pushes locations to remote platforms
+// OCPI doesn't support batches, so locations are sent one by one
+// pushes are queued per platform, so that the following evse statuses aren't received before the locations
+func (l *locationUc) pushLocations(ctx context.Context, locs []*domain.Location) {
+	lg := l.l().C(ctx).Mth("push-locs").F(kit.KV{"count": len(locs)}).Dbg()
+
+	// get local platform
+	localPlatform, err := l.localPlatform.Get(ctx)
+	if err != nil {
+		lg.E(err).St().Err()
+		return
+	}
+
+	// get platforms to push locations
+	platforms, err := l.getPlatformsToPush(ctx, localPlatform.Id)
+	if err != nil {
+		lg.E(err).St().Err()
+		return
+	}
+	lg.DbgF("%d platforms to push", len(platforms))
+
+	ocpiLocs := l.converter.LocationsDomainToModel(locs)
+	for _, platform := range platforms {
+		platform := platform
+		// check if location receiver is supported by the remote platform
+		ep := l.platformService.RoleEndpoint(ctx, platform, model.ModuleIdLocations, model.OcpiReceiver)
+		if ep != "" && (platform.Protocol == nil || platform.Protocol.PushSupport.Locations) {
+			l.queue.enqueue(ctx, lg, platform.Id, func() {
+				for _, loc := range ocpiLocs {
+					rq := buildOcpiRepositoryRequestG(ep, l.tokenC(platform), localPlatform, platform, loc)
+					if err := l.remoteLocationRep.PutLocation(ctx, rq); err != nil {
+						lg.F(kit.KV{"platform": platform.Id, "locId": loc.Id}).E(err).St().Err()
+					}
+				}
+			})
+		} else {
+			lg.F(kit.KV{"platform": platform.Id}).Dbg("push not supported")
+		}
+	}
+}
+
 func (l *locationUc) OnRemoteLocationPut(ctx context.Context, platformId string, loc *model.OcpiLocation) error {
 	l.l().C(ctx).Mth("on-loc-put-rem").F(kit.KV{"platformId": platformId, "locId": loc.Id}).Dbg()
 	return l.modifyLocation(ctx, platformId, loc, l.locationService.PutLocation)
@@ -122,6 +244,19 @@ func (l *locationUc) OnRemoteLocationsPull(ctx context.Context, from, to *time.T
 	return l.remoteLocationsPull(ctx, from, to, platforms)
 }
 
+func (l *locationUc) OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error {
+	// get platforms to pull from
+	platforms, err := l.getPlatformsToPull(ctx)
+	if err != nil {
+		return err
+	}
+
+	// retrieve only the requested platform
+	platforms = kit.Filter(platforms, func(p *domain.Platform) bool { return p.Id == platformId })
+
+	return l.remoteLocationsPull(ctx, from, to, platforms)
+}
+
 func (l *locationUc) OnRemoteLocationsPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error {
 
 	// get platforms to pull from
@@ -174,6 +309,8 @@ func (l *locationUc) OnLocalEvseChanged(ctx context.Context, evse *domain.Evse)
 
 	// for each platform
 	ocpiEvse := l.converter.EvseDomainToModel(evse)
+	// pushes outlive the request
+	pushCtx := kit.Copy(ctx)
 	ocpiParty := &model.OcpiPartyId{
 		PartyId:     evse.ExtId.PartyId,
 		CountryCode: evse.ExtId.CountryCode,
@@ -183,9 +320,13 @@ func (l *locationUc) OnLocalEvseChanged(ctx context.Context, evse *domain.Evse)
 		// check if location receiver is supported by the remote platform
 		ep := l.platformService.RoleEndpoint(ctx, platform, model.ModuleIdLocations, model.OcpiReceiver)
 		if ep != "" && (platform.Protocol == nil || platform.Protocol.PushSupport.Locations) {
-			// push evse to a remote platform
-			rq := buildOcpiRepositoryErrHandlerRequestG(ep, l.tokenC(platform), localPlatform, platform, ocpiEvse, lg)
-			l.remoteLocationRep.PutEvseAsync(ctx, rq, ocpiParty, evse.LocationId)
+			// push evse to a remote platform, pushes are queued per platform, so that it receives changes in order
+			l.queue.enqueue(ctx, lg, platform.Id, func() {
+				rq := buildOcpiRepositoryRequestG(ep, l.tokenC(platform), localPlatform, platform, ocpiEvse)
+				if err := l.remoteLocationRep.PutEvse(pushCtx, rq, ocpiParty, evse.LocationId); err != nil {
+					lg.F(kit.KV{"platform": platform.Id}).E(err).St().Err()
+				}
+			})
 		} else {
 			lg.F(kit.KV{"platform": platform.Id}).Dbg("push not supported")
 		}
@@ -197,12 +338,6 @@ func (l *locationUc) OnLocalEvseChanged(ctx context.Context, evse *domain.Evse)
 func (l *locationUc) OnLocalEvseStatusChanged(ctx context.Context, locId, evseId, status string) error {
 	lg := l.l().C(ctx).Mth("on-evse-changed-loc").F(kit.KV{"evseId": evseId, "status": status}).Dbg()
 
-	// get local platform
-	localPlatform, err := l.localPlatform.Get(ctx)
-	if err != nil {
-		return err
-	}
-
 	// check location is of the local platform
 	evse, err := l.locationService.GetEvse(ctx, locId, evseId, false)
 	if err != nil {
@@ -229,37 +364,123 @@ func (l *locationUc) OnLocalEvseStatusChanged(ctx context.Context, locId, evseId
 		return nil
 	}
 
-	// get platforms to push evse
-	platforms, err := l.getPlatformsToPush(ctx, evse.PlatformId)
+	// push is postponed, so that rapid changes are coalesced
+	l.evseBuffer.add(evse)
+
+	return nil
+}
+
+func (l *locationUc) OnLocalEvseStatusesChanged(ctx context.Context, evses []*domain.Evse) ([]*domain.BulkItemResult, error) {
+	lg := l.l().C(ctx).Mth("on-evse-statuses-changed-loc").F(kit.KV{"count": len(evses)}).Dbg()
+
+	if err := l.validateBulk(ctx, len(evses)); err != nil {
+		return nil, err
+	}
+
+	// check evses are of the local platform
+	results := make([]*domain.BulkItemResult, len(evses))
+	var accepted []*domain.Evse
+	var positions []int
+	now := kit.Now()
+	for i, evse := range evses {
+		if evse == nil {
+			results[i] = &domain.BulkItemResult{Err: errors.ErrEvseIdEmpty(ctx)}
+			continue
+		}
+		stored, err := l.locationService.GetEvse(ctx, evse.LocationId, evse.Id, false)
+		if err == nil && stored == nil {
+			err = errors.ErrEvseNotFound(ctx)
+		}
+		if err == nil && stored.PlatformId != l.localPlatform.GetPlatformId(ctx) {
+			err = errors.ErrLocNotBelongLocalPlatform(ctx)
+		}
+		if err != nil {
+			results[i] = &domain.BulkItemResult{Id: evse.Id, Err: err}
+			continue
+		}
+		accepted = append(accepted, &domain.Evse{
+			OcpiItem:   domain.OcpiItem{LastUpdated: now},
+			Id:         evse.Id,
+			LocationId: evse.LocationId,
+			Status:     evse.Status,
+		})
+		positions = append(positions, i)
+	}
+	if len(accepted) == 0 {
+		return results, nil
+	}
+
+	// merge evses
+	applied, rs, err := l.locationService.MergeEvses(ctx, accepted)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	for i, r := range rs {
+		results[positions[i]] = r
 	}
 
-	// for each platform
-	ocpiEvse := &model.OcpiEvse{
-		Uid:         evse.Id,
-		Status:      evse.Status,
-		LastUpdated: evse.LastUpdated,
+	// no changes applied
+	if len(applied) == 0 {
+		lg.Warn("no changes applied")
+		return results, nil
 	}
-	ocpiParty := &model.OcpiPartyId{
-		PartyId:     evse.ExtId.PartyId,
-		CountryCode: evse.ExtId.CountryCode,
+
+	// statuses are pushed along with other changes collected within the window
+	l.evseBuffer.add(applied...)
+
+	return results, nil
+}
+
+// pushEvseStatuses pushes statuses of evses to remote platforms, it doesn't wait until pushes are done
+// OCPI doesn't support batches, so evses are sent one by one
+// pushes are queued per platform along with locations pushes, so that remote platforms receive changes in order
+func (l *locationUc) pushEvseStatuses(ctx context.Context, evses []*domain.Evse) {
+	lg := l.l().C(ctx).Mth("push-evse-statuses").F(kit.KV{"count": len(evses)}).Dbg()
+
+	// get local platform
+	localPlatform, err := l.localPlatform.Get(ctx)
+	if err != nil {
+		lg.E(err).St().Err()
+		return
+	}
+
+	// get platforms to push evses
+	platforms, err := l.getPlatformsToPush(ctx, localPlatform.Id)
+	if err != nil {
+		lg.E(err).St().Err()
+		return
 	}
+
 	for _, platform := range platforms {
 		platform := platform
 		// check if location receiver is supported by the remote platform
 		ep := l.platformService.RoleEndpoint(ctx, platform, model.ModuleIdLocations, model.OcpiReceiver)
 		if ep != "" && (platform.Protocol == nil || platform.Protocol.PushSupport.Locations) {
-			// push evse to a remote platform
-			rq := buildOcpiRepositoryErrHandlerRequestG(ep, l.tokenC(platform), localPlatform, platform, ocpiEvse, lg)
-			l.remoteLocationRep.PatchEvseAsync(ctx, rq, ocpiParty, evse.LocationId)
-
+			// push evses to a remote platform, statuses are merged into the push waiting in the queue if any
+			if !l.pendingEvses.add(platform.Id, evses) {
+				continue
+			}
+			l.queue.enqueue(ctx, lg, platform.Id, func() {
+				for _, evse := range l.pendingEvses.take(platform.Id) {
+					ocpiEvse := &model.OcpiEvse{
+						Uid:         evse.Id,
+						Status:      evse.Status,
+						LastUpdated: evse.LastUpdated,
+					}
+					ocpiParty := &model.OcpiPartyId{
+						PartyId:     evse.ExtId.PartyId,
+						CountryCode: evse.ExtId.CountryCode,
+					}
+					rq := buildOcpiRepositoryRequestG(ep, l.tokenC(platform), localPlatform, platform, ocpiEvse)
+					if err := l.remoteLocationRep.PatchEvse(ctx, rq, ocpiParty, evse.LocationId); err != nil {
+						lg.F(kit.KV{"platform": platform.Id, "evseId": evse.Id}).E(err).St().Err()
+					}
+				}
+			})
 		} else {
 			lg.F(kit.KV{"platform": platform.Id}).Dbg("push not supported")
 		}
 	}
-
-	return nil
 }
 
 func (l *locationUc) OnRemoteEvsePut(ctx context.Context, platformId, locId, countryCode, partyId string, evse *model.OcpiEvse) error {
@@ -310,6 +531,8 @@ func (l *locationUc) OnLocalConnectorChanged(ctx context.Context, con *domain.Co
 
 	// for each platform
 	ocpiCon := l.converter.ConnectorDomainToModel(con)
+	// pushes outlive the request
+	pushCtx := kit.Copy(ctx)
 	ocpiParty := &model.OcpiPartyId{
 		PartyId:     con.ExtId.PartyId,
 		CountryCode: con.ExtId.CountryCode,
@@ -319,9 +542,13 @@ func (l *locationUc) OnLocalConnectorChanged(ctx context.Context, con *domain.Co
 		// check if location receiver is supported by the remote platform
 		ep := l.platformService.RoleEndpoint(ctx, platform, model.ModuleIdLocations, model.OcpiReceiver)
 		if ep != "" && (platform.Protocol == nil || platform.Protocol.PushSupport.Locations) {
-			// push connector to a remote platform
-			rq := buildOcpiRepositoryErrHandlerRequestG(ep, l.tokenC(platform), localPlatform, platform, ocpiCon, lg)
-			l.remoteLocationRep.PutConnectorAsync(ctx, rq, ocpiParty, con.LocationId, con.EvseId)
+			// push connector to a remote platform, pushes are queued per platform, so that it receives changes in order
+			l.queue.enqueue(ctx, lg, platform.Id, func() {
+				rq := buildOcpiRepositoryRequestG(ep, l.tokenC(platform), localPlatform, platform, ocpiCon)
+				if err := l.remoteLocationRep.PutConnector(pushCtx, rq, ocpiParty, con.LocationId, con.EvseId); err != nil {
+					lg.F(kit.KV{"platform": platform.Id}).E(err).St().Err()
+				}
+			})
 		} else {
 			lg.F(kit.KV{"platform": platform.Id}).Dbg("push not supported")
 		}
@@ -485,6 +712,77 @@ func (l *locationUc) modifyConnector(ctx context.Context, platformId, locId, evs
 	return l.webhook.OnConnectorChanged(ctx, l.converter.ConnectorDomainToBackend(conDom))
 }
 
+// putRemoteLocations puts locations pulled from the remote platform in a single batch
+// items which cannot be put are logged and skipped
+func (l *locationUc) putRemoteLocations(ctx context.Context, platformId string, locs []*model.OcpiLocation) error {
+	lg := l.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "count": len(locs)}).Dbg()
+
+	// get and check platform
+	_, err := l.getConnectedPlatform(ctx, platformId)
+	if err != nil {
+		return err
+	}
+
+	// check locations are of the remote platform
+	stored, err := l.locationService.SearchLocations(ctx, &domain.LocationSearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(len(locs))},
+		Ids:          kit.Select(locs, func(loc *model.OcpiLocation) string { return loc.Id }),
+		IncPlatforms: []string{l.localPlatform.GetPlatformId(ctx)},
+	})
+	if err != nil {
+		return err
+	}
+	local := make(map[string]struct{}, len(stored.Items))
+	for _, loc := range stored.Items {
+		local[loc.Id] = struct{}{}
+	}
+
+	// get or create parties
+	parties := l.getCreateParties(ctx, platformId, kit.Select(locs, func(loc *model.OcpiLocation) domain.PartyExtId {
+		return domain.PartyExtId{PartyId: loc.PartyId, CountryCode: loc.CountryCode}
+	}))
+
+	logErr := func(locId string, err error) {
+		l.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "locId": locId}).E(err).St().Err()
+	}
+
+	locDoms := make([]*domain.Location, 0, len(locs))
+	for _, loc := range locs {
+		if _, ok := local[loc.Id]; ok {
+			logErr(loc.Id, errors.ErrLocNotBelongRemotePlatform(ctx))
+			continue
+		}
+		if err := parties[domain.PartyExtId{PartyId: loc.PartyId, CountryCode: loc.CountryCode}]; err != nil {
+			logErr(loc.Id, err)
+			continue
+		}
+		locDoms = append(locDoms, l.converter.LocationModelToDomain(loc, platformId))
+	}
+	if len(locDoms) == 0 {
+		return nil
+	}
+
+	// put locations to local platform
+	applied, results, err := l.locationService.PutLocations(ctx, locDoms)
+	if err != nil {
+		return err
+	}
+	for _, rs := range results {
+		if rs.Err != nil {
+			logErr(rs.Id, rs.Err)
+		}
+	}
+
+	// no changes applied
+	if len(applied) == 0 {
+		lg.Warn("no changes applied")
+		return nil
+	}
+
+	// call webhook
+	return l.webhook.OnLocationsChanged(ctx, l.converter.LocationsDomainToBackend(applied)...)
+}
+
 func (l *locationUc) remoteLocationsPull(ctx context.Context, from, to *time.Time, platforms []*domain.Platform) error {
 	lg := l.l().C(ctx).Mth("remote-pull").Dbg()
 
@@ -505,11 +803,11 @@ func (l *locationUc) remoteLocationsPull(ctx context.Context, from, to *time.Tim
 	for i := 0; i < locWorkersNum; i++ {
 		goroutine.New().WithLogger(lg).Go(ctx, func() {
 			for v := range ch {
-				loc := v.data.(*model.OcpiLocation)
-				err := l.OnRemoteLocationPut(ctx, v.platformId, loc)
+				locs := v.data.([]*model.OcpiLocation)
+				err := l.putRemoteLocations(ctx, v.platformId, locs)
 				if err != nil {
 					l.l().C(ctx).Mth("remote-put").
-						F(kit.KV{"platformId": v.platformId, "locId": loc.Id}).
+						F(kit.KV{"platformId": v.platformId, "count": len(locs)}).
 						E(err).St().Err()
 				}
 			}
@@ -527,9 +825,20 @@ func (l *locationUc) remoteLocationsPull(ctx context.Context, from, to *time.Tim
 			continue
 		}
 		eg.Go(func() error {
+			// objects of some versions come without party attributes
+			party, err := l.getVersionParty(ctx, platform)
+			if err != nil {
+				return err
+			}
 			for locs := range pgReader.GetPage(ctx, buildOcpiRepositoryRequest(ep, l.tokenC(platform), localPlatform, platform), locPageSize, from, to) {
 				for _, loc := range locs {
-					ch <- channelData{platformId: platform.Id, data: loc}
+					if party != nil {
+						loc.OcpiPartyId = *party
+					}
+				}
+				// pages are put in batches by workers
+				for _, batch := range chunks(locs, domain.PageSizeMaxLimit) {
+					ch <- channelData{platformId: platform.Id, data: batch}
 				}
 			}
 			return nil
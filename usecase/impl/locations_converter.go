@@ -1,6 +1,7 @@
 package impl
 
 import (
+	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi/backend"
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/model"
@@ -40,6 +41,7 @@ func (l *locationConverter) ConnectorModelToDomain(con *model.OcpiConnector, cc,
 			MaxElectricPower:   con.MaxElectricPower,
 			TariffIds:          con.TariffIds,
 			TermsAndConditions: con.TermsAndConditions,
+			Capabilities:       con.Capabilities,
 		},
 	}
 }
@@ -105,6 +107,7 @@ func (l *locationConverter) EvseModelToDomain(e *model.OcpiEvse, cc, partyId, pl
 			Directions:          l.displayTextsModelToDomain(e.Directions),
 			ParkingRestrictions: e.ParkingRestrictions,
 			Images:              l.imagesModelToDomain(e.Images),
+			Parking:             l.evseParkingModelToDomain(e.Parking),
 		},
 		Connectors: l.ConnectorsModelToDomain(e.Connectors, cc, partyId, platformId, locId, e.Uid),
 	}
@@ -200,6 +203,44 @@ func (l *locationConverter) energyMixModelToDomain(em *model.OcpiEnergyMix) *dom
 	return r
 }
 
+func (l *locationConverter) parkingPlacesModelToDomain(pp []*model.OcpiParking) []*domain.Parking {
+	var r []*domain.Parking
+	for _, p := range pp {
+		r = append(r, &domain.Parking{
+			Id:                    p.Id,
+			PhysicalReference:     p.PhysicalReference,
+			VehicleTypes:          p.VehicleTypes,
+			MaxVehicleWeight:      p.MaxVehicleWeight,
+			MaxVehicleHeight:      p.MaxVehicleHeight,
+			MaxVehicleLength:      p.MaxVehicleLength,
+			MaxVehicleWidth:       p.MaxVehicleWidth,
+			ParkingSpaceLength:    p.ParkingSpaceLength,
+			ParkingSpaceWidth:     p.ParkingSpaceWidth,
+			DangerousGoodsAllowed: p.DangerousGoodsAllowed,
+			Direction:             p.Direction,
+			DriveThrough:          p.DriveThrough,
+			RestrictedToType:      p.RestrictedToType,
+			ReservationRequired:   p.ReservationRequired,
+			TimeLimit:             p.TimeLimit,
+			Roofed:                p.Roofed,
+			Lighting:              p.Lighting,
+			RefrigerationOutlet:   p.RefrigerationOutlet,
+		})
+	}
+	return r
+}
+
+func (l *locationConverter) evseParkingModelToDomain(pp []*model.OcpiEvseParking) []*domain.EvseParking {
+	var r []*domain.EvseParking
+	for _, p := range pp {
+		r = append(r, &domain.EvseParking{
+			ParkingId:    p.ParkingId,
+			EvsePosition: p.EvsePosition,
+		})
+	}
+	return r
+}
+
 func (l *locationConverter) LocationModelToDomain(loc *model.OcpiLocation, platformId string) *domain.Location {
 	if loc == nil {
 		return nil
@@ -236,6 +277,8 @@ func (l *locationConverter) LocationModelToDomain(loc *model.OcpiLocation, platf
 			ChargingWhenClosed: loc.ChargingWhenClosed,
 			Images:             l.imagesModelToDomain(loc.Images),
 			EnergyMix:          l.energyMixModelToDomain(loc.EnergyMix),
+			HelpPhone:          loc.HelpPhone,
+			ParkingPlaces:      l.parkingPlacesModelToDomain(loc.ParkingPlaces),
 		},
 		Evses: l.EvsesModelToDomain(loc.Evses, loc.CountryCode, loc.PartyId, platformId, loc.Id),
 	}
@@ -352,6 +395,44 @@ func (l *locationConverter) energyMixDomainToModel(em *domain.EnergyMix) *model.
 	return r
 }
 
+func (l *locationConverter) parkingPlacesDomainToModel(pp []*domain.Parking) []*model.OcpiParking {
+	var r []*model.OcpiParking
+	for _, p := range pp {
+		r = append(r, &model.OcpiParking{
+			Id:                    p.Id,
+			PhysicalReference:     p.PhysicalReference,
+			VehicleTypes:          p.VehicleTypes,
+			MaxVehicleWeight:      p.MaxVehicleWeight,
+			MaxVehicleHeight:      p.MaxVehicleHeight,
+			MaxVehicleLength:      p.MaxVehicleLength,
+			MaxVehicleWidth:       p.MaxVehicleWidth,
+			ParkingSpaceLength:    p.ParkingSpaceLength,
+			ParkingSpaceWidth:     p.ParkingSpaceWidth,
+			DangerousGoodsAllowed: p.DangerousGoodsAllowed,
+			Direction:             p.Direction,
+			DriveThrough:          p.DriveThrough,
+			RestrictedToType:      p.RestrictedToType,
+			ReservationRequired:   p.ReservationRequired,
+			TimeLimit:             p.TimeLimit,
+			Roofed:                p.Roofed,
+			Lighting:              p.Lighting,
+			RefrigerationOutlet:   p.RefrigerationOutlet,
+		})
+	}
+	return r
+}
+
+func (l *locationConverter) evseParkingDomainToModel(pp []*domain.EvseParking) []*model.OcpiEvseParking {
+	var r []*model.OcpiEvseParking
+	for _, p := range pp {
+		r = append(r, &model.OcpiEvseParking{
+			ParkingId:    p.ParkingId,
+			EvsePosition: p.EvsePosition,
+		})
+	}
+	return r
+}
+
 func (l *locationConverter) LocationDomainToModel(loc *domain.Location) *model.OcpiLocation {
 	if loc == nil {
 		return nil
@@ -383,6 +464,8 @@ func (l *locationConverter) LocationDomainToModel(loc *domain.Location) *model.O
 		ChargingWhenClosed: loc.Details.ChargingWhenClosed,
 		Images:             l.imagesDomainToModelModel(loc.Details.Images),
 		EnergyMix:          l.energyMixDomainToModel(loc.Details.EnergyMix),
+		HelpPhone:          loc.Details.HelpPhone,
+		ParkingPlaces:      l.parkingPlacesDomainToModel(loc.Details.ParkingPlaces),
 		Evses:              l.EvsesDomainToModel(loc.Evses),
 		LastUpdated:        loc.LastUpdated,
 	}
@@ -412,6 +495,7 @@ func (l *locationConverter) EvseDomainToModel(evse *domain.Evse) *model.OcpiEvse
 		Directions:          l.displayTextsDomainToModel(evse.Details.Directions),
 		ParkingRestrictions: evse.Details.ParkingRestrictions,
 		Images:              l.imagesDomainToModelModel(evse.Details.Images),
+		Parking:             l.evseParkingDomainToModel(evse.Details.Parking),
 		Connectors:          l.ConnectorsDomainToModel(evse.Connectors),
 		LastUpdated:         evse.LastUpdated,
 	}
@@ -439,6 +523,7 @@ func (l *locationConverter) ConnectorDomainToModel(con *domain.Connector) *model
 		MaxElectricPower:   con.Details.MaxElectricPower,
 		TariffIds:          con.Details.TariffIds,
 		TermsAndConditions: con.Details.TermsAndConditions,
+		Capabilities:       con.Details.Capabilities,
 		LastUpdated:        con.LastUpdated,
 	}
 }
@@ -599,6 +684,44 @@ func (l *locationConverter) businessDetailsDomainToBackend(bd *domain.BusinessDe
 	return r
 }
 
+func (l *locationConverter) parkingPlacesDomainToBackend(pp []*domain.Parking) []*backend.Parking {
+	var r []*backend.Parking
+	for _, p := range pp {
+		r = append(r, &backend.Parking{
+			Id:                    p.Id,
+			PhysicalReference:     p.PhysicalReference,
+			VehicleTypes:          p.VehicleTypes,
+			MaxVehicleWeight:      p.MaxVehicleWeight,
+			MaxVehicleHeight:      p.MaxVehicleHeight,
+			MaxVehicleLength:      p.MaxVehicleLength,
+			MaxVehicleWidth:       p.MaxVehicleWidth,
+			ParkingSpaceLength:    p.ParkingSpaceLength,
+			ParkingSpaceWidth:     p.ParkingSpaceWidth,
+			DangerousGoodsAllowed: p.DangerousGoodsAllowed,
+			Direction:             p.Direction,
+			DriveThrough:          p.DriveThrough,
+			RestrictedToType:      p.RestrictedToType,
+			ReservationRequired:   p.ReservationRequired,
+			TimeLimit:             p.TimeLimit,
+			Roofed:                p.Roofed,
+			Lighting:              p.Lighting,
+			RefrigerationOutlet:   p.RefrigerationOutlet,
+		})
+	}
+	return r
+}
+
+func (l *locationConverter) evseParkingDomainToBackend(pp []*domain.EvseParking) []*backend.EvseParking {
+	var r []*backend.EvseParking
+	for _, p := range pp {
+		r = append(r, &backend.EvseParking{
+			ParkingId:    p.ParkingId,
+			EvsePosition: p.EvsePosition,
+		})
+	}
+	return r
+}
+
 func (l *locationConverter) LocationDomainToBackend(loc *domain.Location) *backend.Location {
 	if loc == nil {
 		return nil
@@ -628,6 +751,8 @@ func (l *locationConverter) LocationDomainToBackend(loc *domain.Location) *backe
 		ChargingWhenClosed: loc.Details.ChargingWhenClosed,
 		Images:             l.imagesDomainToBackend(loc.Details.Images),
 		EnergyMix:          l.energyMixDomainToBackend(loc.Details.EnergyMix),
+		HelpPhone:          loc.Details.HelpPhone,
+		ParkingPlaces:      l.parkingPlacesDomainToBackend(loc.Details.ParkingPlaces),
 		Evses:              l.EvsesDomainToBackend(loc.Evses),
 		RefId:              loc.RefId,
 		LastUpdated:        loc.LastUpdated,
@@ -658,6 +783,7 @@ func (l *locationConverter) EvseDomainToBackend(evse *domain.Evse) *backend.Evse
 		Directions:          l.displayTextsDomainToBackend(evse.Details.Directions),
 		ParkingRestrictions: evse.Details.ParkingRestrictions,
 		Images:              l.imagesDomainToBackend(evse.Details.Images),
+		Parking:             l.evseParkingDomainToBackend(evse.Details.Parking),
 		Connectors:          l.ConnectorsDomainToBackend(evse.Connectors),
 		RefId:               evse.RefId,
 		LocationId:          evse.LocationId,
@@ -691,6 +817,7 @@ func (l *locationConverter) ConnectorDomainToBackend(con *domain.Connector) *bac
 		MaxElectricPower:   con.Details.MaxElectricPower,
 		TariffIds:          con.Details.TariffIds,
 		TermsAndConditions: con.Details.TermsAndConditions,
+		Capabilities:       con.Details.Capabilities,
 		PartyId:            con.ExtId.PartyId,
 		CountryCode:        con.ExtId.CountryCode,
 		RefId:              con.RefId,
@@ -732,6 +859,7 @@ func (l *locationConverter) ConnectorBackendToDomain(con *backend.Connector, pla
 			MaxElectricPower:   con.MaxElectricPower,
 			TariffIds:          con.TariffIds,
 			TermsAndConditions: con.TermsAndConditions,
+			Capabilities:       con.Capabilities,
 		},
 	}
 }
@@ -816,6 +944,7 @@ func (l *locationConverter) EvseBackendToDomain(e *backend.Evse, platformId, loc
 			Directions:          l.displayTextsBackendToDomain(e.Directions),
 			ParkingRestrictions: e.ParkingRestrictions,
 			Images:              l.imagesBackendToDomain(e.Images),
+			Parking:             l.evseParkingBackendToDomain(e.Parking),
 		},
 		Connectors: l.ConnectorsBackendToDomain(e.Connectors, platformId, locId, e.Id),
 	}
@@ -911,6 +1040,44 @@ func (l *locationConverter) energyMixBackendToDomain(em *backend.EnergyMix) *dom
 	return r
 }
 
+func (l *locationConverter) parkingPlacesBackendToDomain(pp []*backend.Parking) []*domain.Parking {
+	var r []*domain.Parking
+	for _, p := range pp {
+		r = append(r, &domain.Parking{
+			Id:                    p.Id,
+			PhysicalReference:     p.PhysicalReference,
+			VehicleTypes:          p.VehicleTypes,
+			MaxVehicleWeight:      p.MaxVehicleWeight,
+			MaxVehicleHeight:      p.MaxVehicleHeight,
+			MaxVehicleLength:      p.MaxVehicleLength,
+			MaxVehicleWidth:       p.MaxVehicleWidth,
+			ParkingSpaceLength:    p.ParkingSpaceLength,
+			ParkingSpaceWidth:     p.ParkingSpaceWidth,
+			DangerousGoodsAllowed: p.DangerousGoodsAllowed,
+			Direction:             p.Direction,
+			DriveThrough:          p.DriveThrough,
+			RestrictedToType:      p.RestrictedToType,
+			ReservationRequired:   p.ReservationRequired,
+			TimeLimit:             p.TimeLimit,
+			Roofed:                p.Roofed,
+			Lighting:              p.Lighting,
+			RefrigerationOutlet:   p.RefrigerationOutlet,
+		})
+	}
+	return r
+}
+
+func (l *locationConverter) evseParkingBackendToDomain(pp []*backend.EvseParking) []*domain.EvseParking {
+	var r []*domain.EvseParking
+	for _, p := range pp {
+		r = append(r, &domain.EvseParking{
+			ParkingId:    p.ParkingId,
+			EvsePosition: p.EvsePosition,
+		})
+	}
+	return r
+}
+
 func (l *locationConverter) LocationBackendToDomain(loc *backend.Location, platformId string) *domain.Location {
 	if loc == nil {
 		return nil
@@ -948,7 +1115,51 @@ func (l *locationConverter) LocationBackendToDomain(loc *backend.Location, platf
 			ChargingWhenClosed: loc.ChargingWhenClosed,
 			Images:             l.imagesBackendToDomain(loc.Images),
 			EnergyMix:          l.energyMixBackendToDomain(loc.EnergyMix),
+			HelpPhone:          loc.HelpPhone,
+			ParkingPlaces:      l.parkingPlacesBackendToDomain(loc.ParkingPlaces),
 		},
 		Evses: l.EvsesBackendToDomain(loc.Evses, platformId, loc.Id),
 	}
 }
+
+func (l *locationConverter) LocationsBackendToDomain(locs []*backend.Location, platformId string) []*domain.Location {
+	var r []*domain.Location
+	for _, loc := range locs {
+		r = append(r, l.LocationBackendToDomain(loc, platformId))
+	}
+	return r
+}
+
+func (l *locationConverter) EvseStatusesBackendToDomain(statuses []*backend.EvseStatus) []*domain.Evse {
+	var r []*domain.Evse
+	for _, st := range statuses {
+		if st == nil {
+			r = append(r, nil)
+			continue
+		}
+		r = append(r, &domain.Evse{
+			Id:         st.EvseId,
+			LocationId: st.LocationId,
+			Status:     st.Status,
+		})
+	}
+	return r
+}
+
+func (l *locationConverter) BulkResultsDomainToBackend(rs []*domain.BulkItemResult) *backend.BulkResponse {
+	r := &backend.BulkResponse{}
+	for _, item := range rs {
+		res := &backend.BulkItemResult{
+			Id:      item.Id,
+			Applied: item.Applied,
+		}
+		if item.Err != nil {
+			res.Error = &backend.BulkItemError{Message: item.Err.Error()}
+			if appErr, ok := kit.IsAppErr(item.Err); ok {
+				res.Error.Code, res.Error.Message = appErr.Code(), appErr.Message()
+			}
+		}
+		r.Items = append(r.Items, res)
+	}
+	return r
+}
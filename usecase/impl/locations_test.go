@@ -1,6 +1,7 @@
 package impl
 
 import (
+	"context"
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/domain"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/atomic"
+	"sync"
 	"testing"
 	"time"
 )
@@ -73,14 +75,104 @@ func (s *locationUcTestSuite) Test_OnLocalLocationChanged_UpdateAndRemotePut() {
 	s.locationService.On("PutLocation", s.Ctx, loc).Return(loc, nil)
 	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
 	platforms := []*domain.Platform{
-		{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
-		{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
 	}
 	s.platformService.On("Search", s.Ctx, mock.Anything).Return(platforms, nil)
 	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
-	s.remoteLocationRep.On("PutLocationAsync", s.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.remoteLocationRep.On("PutLocation", mock.Anything, mock.Anything).Return(nil)
 	s.NoError(s.uc.OnLocalLocationChanged(s.Ctx, loc))
-	s.AssertNumberOfCalls(&s.remoteLocationRep.Mock, "PutLocationAsync", 2)
+	s.uc.Close(s.Ctx)
+	s.AssertNumberOfCalls(&s.remoteLocationRep.Mock, "PutLocation", 2)
+}
+
+func (s *locationUcTestSuite) Test_OnLocalLocationsChanged_ResultsAndRemotePut() {
+	s.localPlatform.On("Get", mock.Anything).Return(&domain.Platform{Id: "local"}, nil)
+	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
+	local, remote, created := &domain.Location{Id: kit.NewId()}, &domain.Location{Id: kit.NewId()}, &domain.Location{Id: kit.NewId()}
+	local.PlatformId, remote.PlatformId = "local", "remote"
+	s.locationService.On("GetLocation", s.Ctx, local.Id, false).Return(local, nil)
+	s.locationService.On("GetLocation", s.Ctx, remote.Id, false).Return(remote, nil)
+	s.locationService.On("GetLocation", s.Ctx, created.Id, false).Return(nil, nil)
+	s.locationService.On("PutLocations", s.Ctx, []*domain.Location{local, created}).
+		Return([]*domain.Location{local, created}, []*domain.BulkItemResult{{Id: local.Id, Applied: true}, {Id: created.Id, Applied: true}}, nil)
+	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
+	platforms := []*domain.Platform{
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+	}
+	s.platformService.On("Search", mock.Anything, mock.Anything).Return(platforms, nil)
+	cnt := atomic.NewInt32(0)
+	s.remoteLocationRep.On("PutLocation", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			cnt.Inc()
+		}).
+		Return(nil)
+
+	rs, err := s.uc.OnLocalLocationsChanged(s.Ctx, []*domain.Location{local, remote, created})
+	s.NoError(err)
+	s.Len(rs, 3)
+	s.True(rs[0].Applied)
+	s.False(rs[1].Applied)
+	s.AssertAppErr(rs[1].Err, errors.ErrCodeLocNotBelongLocalPlatform)
+	s.True(rs[2].Applied)
+
+	// every applied location is pushed to every platform
+	s.Eventually(func() bool { return cnt.Load() == 4 }, time.Second, time.Millisecond*10)
+}
+
+func (s *locationUcTestSuite) Test_OnLocalLocationsChanged_EvseStatusPushedAfterLocation() {
+	s.localPlatform.On("Get", mock.Anything).Return(&domain.Platform{Id: "local"}, nil)
+	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
+	loc := &domain.Location{Id: kit.NewId()}
+	loc.PlatformId = "local"
+	evse := &domain.Evse{Id: kit.NewId(), LocationId: loc.Id}
+	evse.PlatformId = "local"
+	s.locationService.On("GetLocation", s.Ctx, loc.Id, false).Return(loc, nil)
+	s.locationService.On("PutLocations", s.Ctx, mock.Anything).
+		Return([]*domain.Location{loc}, []*domain.BulkItemResult{{Id: loc.Id, Applied: true}}, nil)
+	s.locationService.On("GetEvse", s.Ctx, evse.LocationId, evse.Id, false).Return(evse, nil)
+	s.locationService.On("MergeEvse", s.Ctx, evse).Return(evse, nil)
+	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
+	platforms := []*domain.Platform{
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+	}
+	s.platformService.On("Search", mock.Anything, mock.Anything).Return(platforms, nil)
+	var mu sync.Mutex
+	var pushed []string
+	s.remoteLocationRep.On("PutLocation", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			// the location push is slow, the evse status must wait for it
+			time.Sleep(time.Millisecond * 100)
+			mu.Lock()
+			pushed = append(pushed, "location")
+			mu.Unlock()
+		}).
+		Return(nil)
+	s.remoteLocationRep.On("PatchEvse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			mu.Lock()
+			pushed = append(pushed, "evse")
+			mu.Unlock()
+		}).
+		Return(nil)
+	s.NoError(s.uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{Push: &ocpi.CfgPush{EvseStatusWindowMs: 0}}}))
+
+	_, err := s.uc.OnLocalLocationsChanged(s.Ctx, []*domain.Location{loc})
+	s.NoError(err)
+	s.NoError(s.uc.OnLocalEvseStatusChanged(s.Ctx, evse.LocationId, evse.Id, domain.EvseStatusAvailable))
+	s.uc.Close(s.Ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	s.Equal([]string{"location", "evse"}, pushed)
+}
+
+func (s *locationUcTestSuite) Test_OnLocalLocationsChanged_WhenTooLarge() {
+	_, err := s.uc.OnLocalLocationsChanged(s.Ctx, make([]*domain.Location, domain.BulkMaxSize+1))
+	s.AssertAppErr(err, errors.ErrCodeBulkTooLarge)
+	_, err = s.uc.OnLocalLocationsChanged(s.Ctx, nil)
+	s.AssertAppErr(err, errors.ErrCodeBulkEmpty)
 }
 
 func (s *locationUcTestSuite) Test_OnRemoteLocationPut_WhenNotOfRemotePlatform() {
@@ -120,10 +212,12 @@ func (s *locationUcTestSuite) Test_OnRemoteLocationPull() {
 	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
 	s.platformService.On("Get", mock.Anything, mock.Anything).Return(&domain.Platform{Status: domain.ConnectionStatusConnected}, nil)
 	s.partyService.On("GetByExtId", mock.Anything, mock.Anything).Return(&domain.Party{}, nil)
-	s.locationService.On("PutLocation", mock.Anything, mock.Anything).Return(&domain.Location{}, nil)
-	s.locationService.On("GetLocation", mock.Anything, mock.Anything, false).Return(nil, nil)
+	s.localPlatform.On("GetPlatformId", mock.Anything).Return("local")
+	s.locationService.On("SearchLocations", mock.Anything, mock.Anything).Return(&domain.LocationSearchResponse{}, nil)
+	s.locationService.On("PutLocations", mock.Anything, mock.Anything).
+		Return([]*domain.Location{{}, {}}, []*domain.BulkItemResult{{Applied: true}, {Applied: true}}, nil)
 	cnt := atomic.NewInt32(0)
-	s.webhook.On("OnLocationsChanged", mock.Anything, mock.Anything).
+	s.webhook.On("OnLocationsChanged", mock.Anything, mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			cnt.Inc()
 		}).
@@ -148,11 +242,43 @@ func (s *locationUcTestSuite) Test_OnRemoteLocationPull() {
 	}
 
 	s.NoError(s.uc.OnRemoteLocationsPull(s.Ctx, nil, nil))
+	// each page is put with a single batch
 	if err := <-kit.Await(func() (bool, error) {
-		return cnt.Load() == 20, nil
+		return cnt.Load() == 10, nil
 	}, time.Millisecond*50, time.Second*5); err != nil {
 		s.Fatal(err)
 	}
+	s.locationService.AssertNotCalled(s.T(), "PutLocation", mock.Anything, mock.Anything)
+}
+
+func (s *locationUcTestSuite) Test_PutRemoteLocations() {
+	platformId := kit.NewId()
+	s.platformService.On("Get", s.Ctx, platformId).Return(&domain.Platform{Id: platformId, Status: domain.ConnectionStatusConnected}, nil)
+	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
+	locs := []*model.OcpiLocation{
+		{Id: kit.NewId(), OcpiPartyId: model.OcpiPartyId{PartyId: "P1", CountryCode: "RS"}},
+		{Id: kit.NewId(), OcpiPartyId: model.OcpiPartyId{PartyId: "P1", CountryCode: "RS"}},
+		{Id: kit.NewId(), OcpiPartyId: model.OcpiPartyId{PartyId: "P2", CountryCode: "RS"}},
+		{Id: kit.NewId(), OcpiPartyId: model.OcpiPartyId{PartyId: "P1", CountryCode: "RS"}},
+	}
+	// the last location belongs to the local platform
+	s.locationService.On("SearchLocations", s.Ctx, &domain.LocationSearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(4)},
+		Ids:          []string{locs[0].Id, locs[1].Id, locs[2].Id, locs[3].Id},
+		IncPlatforms: []string{"local"},
+	}).Return(&domain.LocationSearchResponse{Items: []*domain.Location{{Id: locs[3].Id}}}, nil)
+	s.partyService.On("GetByExtId", s.Ctx, domain.PartyExtId{PartyId: "P1", CountryCode: "RS"}).Return(&domain.Party{}, nil)
+	s.partyService.On("GetByExtId", s.Ctx, domain.PartyExtId{PartyId: "P2", CountryCode: "RS"}).Return(nil, errors.ErrPartyIdLen(s.Ctx))
+	applied := []*domain.Location{{Id: locs[0].Id}}
+	s.locationService.On("PutLocations", s.Ctx, mock.MatchedBy(func(ls []*domain.Location) bool {
+		return len(ls) == 2 && ls[0].Id == locs[0].Id && ls[1].Id == locs[1].Id
+	})).Return(applied, []*domain.BulkItemResult{{Id: locs[0].Id, Applied: true}, {Id: locs[1].Id}}, nil)
+	s.webhook.On("OnLocationsChanged", s.Ctx, mock.Anything).Return(nil)
+
+	s.NoError(s.uc.(*locationUc).putRemoteLocations(s.Ctx, platformId, locs))
+	// party is requested once
+	s.AssertNumberOfCalls(&s.partyService.Mock, "GetByExtId", 2)
+	s.AssertNumberOfCalls(&s.webhook.Mock, "OnLocationsChanged", 1)
 }
 
 func (s *locationUcTestSuite) Test_OnRemoteLocationPull_WhenNoLocations() {
@@ -227,14 +353,54 @@ func (s *locationUcTestSuite) Test_OnLocalEvseChanged_UpdateAndRemotePut() {
 	s.locationService.On("PutEvse", s.Ctx, evse).Return(evse, nil)
 	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
 	platforms := []*domain.Platform{
-		{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
-		{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
 	}
 	s.platformService.On("Search", s.Ctx, mock.Anything).Return(platforms, nil)
 	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
-	s.remoteLocationRep.On("PutEvseAsync", s.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.remoteLocationRep.On("PutEvse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	s.NoError(s.uc.OnLocalEvseChanged(s.Ctx, evse))
-	s.AssertNumberOfCalls(&s.remoteLocationRep.Mock, "PutEvseAsync", 2)
+	s.uc.Close(s.Ctx)
+	s.AssertNumberOfCalls(&s.remoteLocationRep.Mock, "PutEvse", 2)
+}
+
+func (s *locationUcTestSuite) Test_OnLocalEvseChanged_PutAndStatusInOrder() {
+	s.localPlatform.On("Get", mock.Anything).Return(&domain.Platform{Id: "local"}, nil)
+	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
+	evse := &domain.Evse{Id: kit.NewId(), LocationId: kit.NewId()}
+	evse.PlatformId = "local"
+	s.locationService.On("GetEvse", s.Ctx, evse.LocationId, evse.Id, false).Return(evse, nil)
+	s.locationService.On("PutEvse", s.Ctx, evse).Return(evse, nil)
+	s.locationService.On("MergeEvse", s.Ctx, evse).Return(evse, nil)
+	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
+	s.platformService.On("Search", mock.Anything, mock.Anything).Return([]*domain.Platform{{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString())}}, nil)
+	var mu sync.Mutex
+	var pushed []string
+	s.remoteLocationRep.On("PutEvse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			// the evse push is slow, the status must wait for it
+			time.Sleep(time.Millisecond * 100)
+			mu.Lock()
+			pushed = append(pushed, "put")
+			mu.Unlock()
+		}).
+		Return(nil)
+	s.remoteLocationRep.On("PatchEvse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			mu.Lock()
+			pushed = append(pushed, "status")
+			mu.Unlock()
+		}).
+		Return(nil)
+	s.NoError(s.uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{Push: &ocpi.CfgPush{EvseStatusWindowMs: 0}}}))
+
+	s.NoError(s.uc.OnLocalEvseChanged(s.Ctx, evse))
+	s.NoError(s.uc.OnLocalEvseStatusChanged(s.Ctx, evse.LocationId, evse.Id, domain.EvseStatusAvailable))
+	s.uc.Close(s.Ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	s.Equal([]string{"put", "status"}, pushed)
 }
 
 func (s *locationUcTestSuite) Test_OnLocalEvseStatusChanged_UpdateAndRemotePut() {
@@ -245,14 +411,97 @@ func (s *locationUcTestSuite) Test_OnLocalEvseStatusChanged_UpdateAndRemotePut()
 	s.locationService.On("MergeEvse", s.Ctx, evse).Return(evse, nil)
 	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
 	platforms := []*domain.Platform{
-		{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
-		{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
 	}
-	s.platformService.On("Search", s.Ctx, mock.Anything).Return(platforms, nil)
+	s.platformService.On("Search", mock.Anything, mock.Anything).Return(platforms, nil)
 	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
-	s.remoteLocationRep.On("PatchEvseAsync", s.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.remoteLocationRep.On("PatchEvse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.NoError(s.uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{Push: &ocpi.CfgPush{EvseStatusWindowMs: 3600000}}}))
 	s.NoError(s.uc.OnLocalEvseStatusChanged(s.Ctx, evse.LocationId, evse.Id, domain.EvseStatusAvailable))
-	s.AssertNumberOfCalls(&s.remoteLocationRep.Mock, "PatchEvseAsync", 2)
+	s.remoteLocationRep.AssertNotCalled(s.T(), "PatchEvse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	// pending statuses are pushed on close
+	s.uc.Close(s.Ctx)
+	s.AssertNumberOfCalls(&s.remoteLocationRep.Mock, "PatchEvse", 2)
+}
+
+func (s *locationUcTestSuite) Test_OnLocalEvseStatusChanged_SlowPlatformDoesntBlockOthers() {
+	s.localPlatform.On("Get", mock.Anything).Return(&domain.Platform{Id: "local"}, nil)
+	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
+	evse := &domain.Evse{Id: kit.NewId(), LocationId: kit.NewId()}
+	evse.PlatformId = "local"
+	// evse is retrieved anew on each change, as statuses of the previous one are being pushed
+	s.locationService.On("GetEvse", s.Ctx, evse.LocationId, evse.Id, false).Return(func(context.Context, string, string, bool) (*domain.Evse, error) {
+		e := *evse
+		return &e, nil
+	}, nil)
+	s.locationService.On("MergeEvse", s.Ctx, mock.Anything).Return(func(_ context.Context, e *domain.Evse) (*domain.Evse, error) { return e, nil }, nil)
+	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
+	slow, fast := &domain.Platform{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString())}, &domain.Platform{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString())}
+	s.platformService.On("Search", mock.Anything, mock.Anything).Return([]*domain.Platform{slow, fast}, nil)
+	release, pushed := make(chan struct{}), make(chan struct{}, 1)
+	s.remoteLocationRep.On("PatchEvse", mock.Anything, mock.MatchedBy(func(rq *usecase.OcpiRepositoryRequestG[*model.OcpiEvse]) bool {
+		return rq.ToPlatformId == slow.Id
+	}), mock.Anything, mock.Anything).Run(func(mock.Arguments) { <-release }).Return(nil)
+	s.remoteLocationRep.On("PatchEvse", mock.Anything, mock.MatchedBy(func(rq *usecase.OcpiRepositoryRequestG[*model.OcpiEvse]) bool {
+		return rq.ToPlatformId == fast.Id
+	}), mock.Anything, mock.Anything).Run(func(mock.Arguments) { pushed <- struct{}{} }).Return(nil)
+	s.NoError(s.uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{Push: &ocpi.CfgPush{EvseStatusWindowMs: 0}}}))
+
+	s.NoError(s.uc.OnLocalEvseStatusChanged(s.Ctx, evse.LocationId, evse.Id, domain.EvseStatusCharging))
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		s.Fail("status isn't pushed while another platform is slow")
+	}
+
+	// statuses changed while the slow platform is busy are delivered to the fast one
+	s.NoError(s.uc.OnLocalEvseStatusChanged(s.Ctx, evse.LocationId, evse.Id, domain.EvseStatusAvailable))
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		s.Fail("status isn't pushed while another platform is slow")
+	}
+	close(release)
+	s.uc.Close(s.Ctx)
+}
+
+func (s *locationUcTestSuite) Test_OnLocalEvseStatusesChanged_ResultsAndRemotePatch() {
+	s.localPlatform.On("Get", mock.Anything).Return(&domain.Platform{Id: "local"}, nil)
+	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
+	locId := kit.NewId()
+	evse1, evse2, notFound := &domain.Evse{Id: kit.NewId(), LocationId: locId}, &domain.Evse{Id: kit.NewId(), LocationId: locId}, &domain.Evse{Id: kit.NewId(), LocationId: locId}
+	evse1.PlatformId, evse2.PlatformId = "local", "local"
+	s.locationService.On("GetEvse", s.Ctx, locId, evse1.Id, false).Return(evse1, nil)
+	s.locationService.On("GetEvse", s.Ctx, locId, evse2.Id, false).Return(evse2, nil)
+	s.locationService.On("GetEvse", s.Ctx, locId, notFound.Id, false).Return(nil, nil)
+	s.locationService.On("MergeEvses", s.Ctx, mock.MatchedBy(func(evses []*domain.Evse) bool {
+		return len(evses) == 2 && evses[0].Status == domain.EvseStatusCharging && evses[1].Status == domain.EvseStatusAvailable
+	})).Return([]*domain.Evse{evse1, evse2}, []*domain.BulkItemResult{{Id: evse1.Id, Applied: true}, {Id: evse2.Id, Applied: true}}, nil)
+	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
+	platforms := []*domain.Platform{
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+	}
+	s.platformService.On("Search", mock.Anything, mock.Anything).Return(platforms, nil)
+	s.remoteLocationRep.On("PatchEvse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.NoError(s.uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Remote: &ocpi.CfgOcpiRemote{Push: &ocpi.CfgPush{EvseStatusWindowMs: 3600000}}}))
+
+	rs, err := s.uc.OnLocalEvseStatusesChanged(s.Ctx, []*domain.Evse{
+		{Id: evse1.Id, LocationId: locId, Status: domain.EvseStatusCharging},
+		{Id: notFound.Id, LocationId: locId, Status: domain.EvseStatusCharging},
+		{Id: evse2.Id, LocationId: locId, Status: domain.EvseStatusAvailable},
+	})
+	s.NoError(err)
+	s.Len(rs, 3)
+	s.True(rs[0].Applied)
+	s.AssertAppErr(rs[1].Err, errors.ErrCodeEvseNotFound)
+	s.True(rs[2].Applied)
+
+	// pending statuses are pushed on close
+	s.uc.Close(s.Ctx)
+	s.AssertNumberOfCalls(&s.remoteLocationRep.Mock, "PatchEvse", 4)
+	s.AssertNumberOfCalls(&s.platformService.Mock, "Search", 1)
 }
 
 func (s *locationUcTestSuite) Test_OnRemoteEvsePut_WhenNotOfRemotePlatform() {
@@ -325,14 +574,15 @@ func (s *locationUcTestSuite) Test_OnLocalConChanged_UpdateAndRemotePut() {
 	s.locationService.On("PutConnector", s.Ctx, con).Return(con, nil)
 	s.platformService.On("RoleEndpoint", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Endpoint("url"))
 	platforms := []*domain.Platform{
-		{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
-		{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
+		{Id: kit.NewId(), TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Locations: true}}},
 	}
 	s.platformService.On("Search", s.Ctx, mock.Anything).Return(platforms, nil)
 	s.localPlatform.On("GetPlatformId", s.Ctx).Return("local")
-	s.remoteLocationRep.On("PutConnectorAsync", s.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.remoteLocationRep.On("PutConnector", mock.Anything, mock.Anything, mock.Anything, con.LocationId, con.EvseId).Return(nil)
 	s.NoError(s.uc.OnLocalConnectorChanged(s.Ctx, con))
-	s.AssertNumberOfCalls(&s.remoteLocationRep.Mock, "PutConnectorAsync", 2)
+	s.uc.Close(s.Ctx)
+	s.AssertNumberOfCalls(&s.remoteLocationRep.Mock, "PutConnector", 2)
 }
 
 func (s *locationUcTestSuite) Test_OnRemoteConPut_WhenNotOfRemotePlatform() {
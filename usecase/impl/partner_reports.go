@@ -0,0 +1,81 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"slices"
+	"strings"
+)
+
+// partnerKey identifies a partner, i.e. a party of the platform
+type partnerKey struct {
+	platformId  string
+	partyId     string
+	countryCode string
+}
+
+func (k partnerKey) compare(o partnerKey) int {
+	if c := strings.Compare(k.platformId, o.platformId); c != 0 {
+		return c
+	}
+	if c := strings.Compare(k.partyId, o.partyId); c != 0 {
+		return c
+	}
+	return strings.Compare(k.countryCode, o.countryCode)
+}
+
+// partnerReports groups reports per partner
+type partnerReports[R any] struct {
+	reports map[partnerKey]R
+	create  func(platformId string, extId domain.PartyExtId) R
+}
+
+// newPartnerReports creates a grouping, create makes an empty report of the partner
+func newPartnerReports[R any](create func(platformId string, extId domain.PartyExtId) R) *partnerReports[R] {
+	return &partnerReports[R]{
+		reports: make(map[partnerKey]R),
+		create:  create,
+	}
+}
+
+// get retrieves report of the partner, the report is created if it's absent
+func (p *partnerReports[R]) get(platformId string, extId domain.PartyExtId) R {
+	key := partnerKey{platformId: platformId, partyId: extId.PartyId, countryCode: extId.CountryCode}
+	r, ok := p.reports[key]
+	if !ok {
+		r = p.create(platformId, extId)
+		p.reports[key] = r
+	}
+	return r
+}
+
+// sorted returns reports ordered by platform and party
+func (p *partnerReports[R]) sorted() []R {
+	keys := make([]partnerKey, 0, len(p.reports))
+	for k := range p.reports {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, partnerKey.compare)
+	rs := make([]R, 0, len(keys))
+	for _, k := range keys {
+		rs = append(rs, p.reports[k])
+	}
+	return rs
+}
+
+// scanPages calls fn for items of all the pages
+// search retrieves items of the current page and the next page request, page is moved to the next one until there are no pages left
+func scanPages[T any](page *domain.PageRequest, search func() ([]T, *domain.PageRequest, error), fn func(item T)) error {
+	for {
+		items, next, err := search()
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			fn(item)
+		}
+		if next == nil {
+			return nil
+		}
+		*page = *next
+	}
+}
@@ -0,0 +1,64 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type partnerReportsTestSuite struct {
+	kit.Suite
+}
+
+func (s *partnerReportsTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func TestPartnerReportsSuite(t *testing.T) {
+	suite.Run(t, new(partnerReportsTestSuite))
+}
+
+type partnerReportTest struct {
+	platformId string
+	extId      domain.PartyExtId
+	items      int
+}
+
+func (s *partnerReportsTestSuite) Test_GroupedAndSorted() {
+	reports := newPartnerReports(func(platformId string, extId domain.PartyExtId) *partnerReportTest {
+		return &partnerReportTest{platformId: platformId, extId: extId}
+	})
+	// concatenated ids of these partners are ordered the other way round
+	reports.get("ab", domain.PartyExtId{PartyId: "A", CountryCode: "RS"}).items++
+	reports.get("a", domain.PartyExtId{PartyId: "BX", CountryCode: "RS"}).items++
+	reports.get("ab", domain.PartyExtId{PartyId: "A", CountryCode: "RS"}).items++
+	reports.get("a", domain.PartyExtId{PartyId: "B", CountryCode: "XRS"}).items++
+
+	rs := reports.sorted()
+	s.Len(rs, 3)
+	s.Equal("a", rs[0].platformId)
+	s.Equal("B", rs[0].extId.PartyId)
+	s.Equal("a", rs[1].platformId)
+	s.Equal("BX", rs[1].extId.PartyId)
+	s.Equal("ab", rs[2].platformId)
+	s.Equal(2, rs[2].items)
+}
+
+func (s *partnerReportsTestSuite) Test_ScanPages() {
+	cursor := kit.NewRandString()
+	page := &domain.PageRequest{}
+	var searched []*domain.PageRequest
+	var items []int
+	s.NoError(scanPages(page, func() ([]int, *domain.PageRequest, error) {
+		searched = append(searched, &domain.PageRequest{Cursor: page.Cursor})
+		if page.Cursor == nil {
+			return []int{1, 2}, &domain.PageRequest{Cursor: &cursor}, nil
+		}
+		return []int{3}, nil, nil
+	}, func(item int) { items = append(items, item) }))
+	s.Equal([]int{1, 2, 3}, items)
+	s.Len(searched, 2)
+	s.Equal(cursor, *searched[1].Cursor)
+}
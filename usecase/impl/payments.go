@@ -0,0 +1,115 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+)
+
+type paymentUc struct {
+	ucBase
+	localPlatform  domain.LocalPlatformService
+	paymentService domain.PaymentService
+	webhook        backend.WebhookCallService
+	converter      usecase.PaymentConverter
+}
+
+func NewPaymentUc(platformService domain.PlatformService, paymentService domain.PaymentService, partyService domain.PartyService,
+	webhook backend.WebhookCallService, localPlatform domain.LocalPlatformService, tokenGen domain.TokenGenerator) usecase.PaymentUc {
+	return &paymentUc{
+		ucBase:         newBase(platformService, partyService, tokenGen),
+		paymentService: paymentService,
+		localPlatform:  localPlatform,
+		webhook:        webhook,
+		converter:      NewPaymentConverter(),
+	}
+}
+
+func (p *paymentUc) l() kit.CLogger {
+	return ocpi.L().Cmp("pay-uc")
+}
+
+func (p *paymentUc) OnRemoteTerminalPut(ctx context.Context, platformId string, t *model.OcpiTerminal) error {
+	p.l().C(ctx).Mth("on-terminal-put-rem").F(kit.KV{"platformId": platformId, "terminalId": t.Id}).Dbg()
+	return p.modifyTerminal(ctx, platformId, t, p.paymentService.PutTerminal)
+}
+
+func (p *paymentUc) OnRemoteTerminalPatch(ctx context.Context, platformId string, t *model.OcpiTerminal) error {
+	p.l().C(ctx).Mth("on-terminal-patch-rem").F(kit.KV{"platformId": platformId, "terminalId": t.Id}).Dbg()
+	return p.modifyTerminal(ctx, platformId, t, p.paymentService.MergeTerminal)
+}
+
+func (p *paymentUc) OnRemoteFinancialAdvicePost(ctx context.Context, platformId string, fa *model.OcpiFinancialAdviceConfirmation) error {
+	l := p.l().C(ctx).Mth("on-fin-advice-post-rem").F(kit.KV{"platformId": platformId, "faId": fa.Id}).Dbg()
+
+	// get and check platform
+	_, err := p.getConnectedPlatform(ctx, platformId)
+	if err != nil {
+		return err
+	}
+
+	// get or create party
+	_, err = p.getCreateParty(ctx, platformId, fa.PartyId, fa.CountryCode)
+	if err != nil {
+		return err
+	}
+
+	faDom, err := p.paymentService.PutFinancialAdvice(ctx, p.converter.FinancialAdviceModelToDomain(fa, platformId))
+	if err != nil {
+		return err
+	}
+
+	// no changes applied
+	if faDom == nil {
+		l.Warn("no changes applied")
+		return nil
+	}
+
+	// call webhook
+	return p.webhook.OnFinancialAdviceChanged(ctx, p.converter.FinancialAdviceDomainToBackend(faDom))
+}
+
+func (p *paymentUc) modifyTerminal(ctx context.Context, platformId string, t *model.OcpiTerminal, modifyFunc func(context.Context, *domain.Terminal) (*domain.Terminal, error)) error {
+	l := p.l().C(ctx).Mth("terminal-modify").F(kit.KV{"platformId": platformId, "terminalId": t.Id}).Dbg()
+
+	// get and check platform
+	_, err := p.getConnectedPlatform(ctx, platformId)
+	if err != nil {
+		return err
+	}
+
+	// check terminal is of the remote platform
+	stored, err := p.paymentService.GetTerminal(ctx, t.Id)
+	if err != nil {
+		return err
+	}
+	if stored != nil && stored.PlatformId == p.localPlatform.GetPlatformId(ctx) {
+		return errors.ErrTerminalNotBelongRemotePlatform(ctx)
+	}
+
+	// get or create party
+	_, err = p.getCreateParty(ctx, platformId, t.PartyId, t.CountryCode)
+	if err != nil {
+		return err
+	}
+
+	// merge terminal to local platform
+	tDom, err := modifyFunc(ctx, p.converter.TerminalModelToDomain(t, platformId))
+	if err != nil {
+		return err
+	}
+
+	// no changes applied
+	if tDom == nil {
+		l.Warn("no changes applied")
+		return nil
+	}
+
+	// call webhook
+	return p.webhook.OnTerminalChanged(ctx, p.converter.TerminalDomainToBackend(tDom))
+}
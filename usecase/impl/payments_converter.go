@@ -0,0 +1,199 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+)
+
+type paymentConverter struct {
+}
+
+func NewPaymentConverter() usecase.PaymentConverter {
+	return &paymentConverter{}
+}
+
+func (c *paymentConverter) TerminalDomainToModel(t *domain.Terminal) *model.OcpiTerminal {
+	if t == nil {
+		return nil
+	}
+	r := &model.OcpiTerminal{
+		OcpiPartyId: model.OcpiPartyId{
+			PartyId:     t.ExtId.PartyId,
+			CountryCode: t.ExtId.CountryCode,
+		},
+		Id:                t.Id,
+		CustomerReference: t.Details.CustomerReference,
+		Address:           t.Details.Address,
+		City:              t.Details.City,
+		PostalCode:        t.Details.PostalCode,
+		State:             t.Details.State,
+		Country:           t.Details.Country,
+		InvoiceBaseUrl:    t.Details.InvoiceBaseUrl,
+		InvoiceCreator:    t.Details.InvoiceCreator,
+		Reference:         t.Details.Reference,
+		LocationIds:       t.Details.LocationIds,
+		EvseUids:          t.Details.EvseUids,
+		LastUpdated:       t.LastUpdated,
+	}
+	if t.Details.Coordinates != nil {
+		r.Coordinates = &model.OcpiGeoLocation{
+			Latitude:  t.Details.Coordinates.Latitude,
+			Longitude: t.Details.Coordinates.Longitude,
+		}
+	}
+	return r
+}
+
+func (c *paymentConverter) TerminalsDomainToModel(ts []*domain.Terminal) []*model.OcpiTerminal {
+	return kit.Select(ts, c.TerminalDomainToModel)
+}
+
+func (c *paymentConverter) TerminalModelToDomain(t *model.OcpiTerminal, platformId string) *domain.Terminal {
+	if t == nil {
+		return nil
+	}
+	r := &domain.Terminal{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     t.PartyId,
+				CountryCode: t.CountryCode,
+			},
+			PlatformId:  platformId,
+			LastUpdated: t.LastUpdated,
+		},
+		Id: t.Id,
+		Details: domain.TerminalDetails{
+			CustomerReference: t.CustomerReference,
+			Address:           t.Address,
+			City:              t.City,
+			PostalCode:        t.PostalCode,
+			State:             t.State,
+			Country:           t.Country,
+			InvoiceBaseUrl:    t.InvoiceBaseUrl,
+			InvoiceCreator:    t.InvoiceCreator,
+			Reference:         t.Reference,
+			LocationIds:       t.LocationIds,
+			EvseUids:          t.EvseUids,
+		},
+	}
+	if t.Coordinates != nil {
+		r.Details.Coordinates = &domain.GeoLocation{
+			Latitude:  t.Coordinates.Latitude,
+			Longitude: t.Coordinates.Longitude,
+		}
+	}
+	return r
+}
+
+func (c *paymentConverter) TerminalDomainToBackend(t *domain.Terminal) *backend.Terminal {
+	if t == nil {
+		return nil
+	}
+	r := &backend.Terminal{
+		Id:                t.Id,
+		CustomerReference: t.Details.CustomerReference,
+		Address:           t.Details.Address,
+		City:              t.Details.City,
+		PostalCode:        t.Details.PostalCode,
+		State:             t.Details.State,
+		Country:           t.Details.Country,
+		InvoiceBaseUrl:    t.Details.InvoiceBaseUrl,
+		InvoiceCreator:    t.Details.InvoiceCreator,
+		Reference:         t.Details.Reference,
+		LocationIds:       t.Details.LocationIds,
+		EvseUids:          t.Details.EvseUids,
+		LastUpdated:       t.LastUpdated,
+		PlatformId:        t.PlatformId,
+		RefId:             t.RefId,
+		PartyId:           t.ExtId.PartyId,
+		CountryCode:       t.ExtId.CountryCode,
+	}
+	if t.Details.Coordinates != nil {
+		r.Coordinates = &backend.GeoLocation{
+			Latitude:  t.Details.Coordinates.Latitude,
+			Longitude: t.Details.Coordinates.Longitude,
+		}
+	}
+	return r
+}
+
+func (c *paymentConverter) FinancialAdviceDomainToModel(fa *domain.FinancialAdvice) *model.OcpiFinancialAdviceConfirmation {
+	if fa == nil {
+		return nil
+	}
+	return &model.OcpiFinancialAdviceConfirmation{
+		OcpiPartyId: model.OcpiPartyId{
+			PartyId:     fa.ExtId.PartyId,
+			CountryCode: fa.ExtId.CountryCode,
+		},
+		Id:      fa.Id,
+		AuthRef: fa.Details.AuthRef,
+		TotalCosts: model.OcpiPrice{
+			ExclVat: fa.Details.TotalCosts.ExclVat,
+			InclVat: fa.Details.TotalCosts.InclVat,
+		},
+		Currency:             fa.Details.Currency,
+		EftData:              fa.Details.EftData,
+		CaptureStatusCode:    fa.Details.CaptureStatusCode,
+		CaptureStatusMessage: fa.Details.CaptureStatusMessage,
+		LastUpdated:          fa.LastUpdated,
+	}
+}
+
+func (c *paymentConverter) FinancialAdvicesDomainToModel(fas []*domain.FinancialAdvice) []*model.OcpiFinancialAdviceConfirmation {
+	return kit.Select(fas, c.FinancialAdviceDomainToModel)
+}
+
+func (c *paymentConverter) FinancialAdviceModelToDomain(fa *model.OcpiFinancialAdviceConfirmation, platformId string) *domain.FinancialAdvice {
+	if fa == nil {
+		return nil
+	}
+	return &domain.FinancialAdvice{
+		OcpiItem: domain.OcpiItem{
+			ExtId: domain.PartyExtId{
+				PartyId:     fa.PartyId,
+				CountryCode: fa.CountryCode,
+			},
+			PlatformId:  platformId,
+			LastUpdated: fa.LastUpdated,
+		},
+		Id: fa.Id,
+		Details: domain.FinancialAdviceDetails{
+			AuthRef: fa.AuthRef,
+			TotalCosts: domain.Price{
+				ExclVat: fa.TotalCosts.ExclVat,
+				InclVat: fa.TotalCosts.InclVat,
+			},
+			Currency:             fa.Currency,
+			EftData:              fa.EftData,
+			CaptureStatusCode:    fa.CaptureStatusCode,
+			CaptureStatusMessage: fa.CaptureStatusMessage,
+		},
+	}
+}
+
+func (c *paymentConverter) FinancialAdviceDomainToBackend(fa *domain.FinancialAdvice) *backend.FinancialAdvice {
+	if fa == nil {
+		return nil
+	}
+	return &backend.FinancialAdvice{
+		Id:      fa.Id,
+		AuthRef: fa.Details.AuthRef,
+		TotalCosts: backend.Price{
+			ExclVat: fa.Details.TotalCosts.ExclVat,
+			InclVat: fa.Details.TotalCosts.InclVat,
+		},
+		Currency:             fa.Details.Currency,
+		EftData:              fa.Details.EftData,
+		CaptureStatusCode:    fa.Details.CaptureStatusCode,
+		CaptureStatusMessage: fa.Details.CaptureStatusMessage,
+		LastUpdated:          fa.LastUpdated,
+		PlatformId:           fa.PlatformId,
+		RefId:                fa.RefId,
+		PartyId:              fa.ExtId.PartyId,
+		CountryCode:          fa.ExtId.CountryCode,
+	}
+}
@@ -0,0 +1,82 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/kit/goroutine"
+	"github.com/mikhailbolshakov/ocpi"
+	"sync"
+)
+
+// platformQueue runs pushes to a remote platform one by one in order they are enqueued
+// pushes to different platforms run concurrently
+type platformQueue struct {
+	sync.Mutex
+	jobs map[string][]func() // jobs waiting per platform, the platform is present while its worker is running
+	wg   sync.WaitGroup
+}
+
+func (q *platformQueue) l() kit.CLogger {
+	return ocpi.L().Cmp("platform-queue")
+}
+
+func newPlatformQueue() *platformQueue {
+	return &platformQueue{
+		jobs: make(map[string][]func()),
+	}
+}
+
+// enqueue puts a job to the platform queue, a worker is started if the platform has no running worker
+func (q *platformQueue) enqueue(ctx context.Context, l kit.CLogger, platformId string, job func()) {
+	q.Lock()
+	defer q.Unlock()
+	jobs, running := q.jobs[platformId]
+	q.jobs[platformId] = append(jobs, job)
+	if running {
+		return
+	}
+	q.wg.Add(1)
+	goroutine.New().WithLogger(l).Go(ctx, func() { q.run(platformId) })
+}
+
+// run executes jobs of the platform until the queue is empty
+func (q *platformQueue) run(platformId string) {
+	defer q.wg.Done()
+	for {
+		q.Lock()
+		jobs := q.jobs[platformId]
+		if len(jobs) == 0 {
+			delete(q.jobs, platformId)
+			q.Unlock()
+			return
+		}
+		job := jobs[0]
+		q.jobs[platformId] = jobs[1:]
+		q.Unlock()
+		q.do(platformId, job)
+	}
+}
+
+// do executes a job, a panicking job doesn't stop the rest of the queue
+func (q *platformQueue) do(platformId string, job func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			q.l().F(kit.KV{"platformId": platformId}).E(fmt.Errorf("%v", r)).St().Err("job panicked")
+		}
+	}()
+	job()
+}
+
+// close waits until all the enqueued jobs are done or ctx is cancelled
+func (q *platformQueue) close(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
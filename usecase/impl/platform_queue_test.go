@@ -0,0 +1,87 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+	"time"
+)
+
+type platformQueueTestSuite struct {
+	kit.Suite
+}
+
+func (s *platformQueueTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func TestPlatformQueueSuite(t *testing.T) {
+	suite.Run(t, new(platformQueueTestSuite))
+}
+
+func (s *platformQueueTestSuite) Test_InOrderPerPlatform() {
+	q := newPlatformQueue()
+	var mu sync.Mutex
+	var done []int
+	for i := 0; i < 10; i++ {
+		i := i
+		q.enqueue(s.Ctx, ocpi.L(), "platform", func() {
+			// the earlier jobs are slower, so they'd be overtaken if run concurrently
+			time.Sleep(time.Millisecond * time.Duration(10-i))
+			mu.Lock()
+			done = append(done, i)
+			mu.Unlock()
+		})
+	}
+	q.close(s.Ctx)
+	s.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, done)
+	s.Empty(q.jobs)
+}
+
+func (s *platformQueueTestSuite) Test_PlatformsConcurrently() {
+	q := newPlatformQueue()
+	release := make(chan struct{})
+	q.enqueue(s.Ctx, ocpi.L(), "blocked", func() { <-release })
+	another := make(chan struct{})
+	q.enqueue(s.Ctx, ocpi.L(), "another", func() { close(another) })
+	select {
+	case <-another:
+	case <-time.After(time.Second):
+		s.Fail("platform is blocked by another one")
+	}
+	close(release)
+	q.close(s.Ctx)
+	s.Empty(q.jobs)
+}
+
+func (s *platformQueueTestSuite) Test_NewWorkerAfterIdle() {
+	q := newPlatformQueue()
+	q.enqueue(s.Ctx, ocpi.L(), "platform", func() {})
+	q.close(s.Ctx)
+	done := make(chan struct{})
+	q.enqueue(s.Ctx, ocpi.L(), "platform", func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("job isn't run")
+	}
+}
+
+func (s *platformQueueTestSuite) Test_Panic_RestRun() {
+	q := newPlatformQueue()
+	release := make(chan struct{})
+	q.enqueue(s.Ctx, ocpi.L(), "platform", func() { <-release })
+	q.enqueue(s.Ctx, ocpi.L(), "platform", func() { panic("push failed") })
+	done := make(chan struct{})
+	q.enqueue(s.Ctx, ocpi.L(), "platform", func() { close(done) })
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("jobs after panic aren't run")
+	}
+	q.close(s.Ctx)
+	s.Empty(q.jobs)
+}
@@ -0,0 +1,217 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+)
+
+type reservationUc struct {
+	reservationService   domain.ReservationService
+	locService           domain.LocationService
+	locationUc           usecase.LocationUc
+	localPlatformService domain.LocalPlatformService
+	webhook              backend.WebhookCallService
+	converter            usecase.ReservationConverter
+}
+
+func NewReservationUc(reservationService domain.ReservationService, locService domain.LocationService, locationUc usecase.LocationUc,
+	localPlatform domain.LocalPlatformService, webhook backend.WebhookCallService) usecase.ReservationUc {
+	return &reservationUc{
+		reservationService:   reservationService,
+		locService:           locService,
+		locationUc:           locationUc,
+		localPlatformService: localPlatform,
+		webhook:              webhook,
+		converter:            NewReservationConverter(),
+	}
+}
+
+func (u *reservationUc) l() kit.CLogger {
+	return ocpi.L().Cmp("res-uc")
+}
+
+func (u *reservationUc) OnCommandResponse(ctx context.Context, cmd *domain.Command) error {
+	u.l().C(ctx).Mth("on-cmd-rs").F(kit.KV{"cmdId": cmd.Id, "cmd": cmd.Cmd, "status": cmd.Details.Processing.Status}).Dbg()
+
+	// only accepted commands change reservations
+	if cmd.Details.Processing.Status != domain.CmdResultTypeAccepted {
+		return nil
+	}
+
+	switch cmd.Cmd {
+	case domain.CmdReserve:
+		if cmd.Details.Reserve == nil {
+			return nil
+		}
+		return u.activate(ctx, cmd)
+	case domain.CmdCancelReservation:
+		if cmd.Details.CancelReservation == nil {
+			return nil
+		}
+		r, err := u.reservationService.GetByReservationId(ctx, cmd.PlatformId, cmd.ExtId, cmd.Details.CancelReservation.ReservationId)
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			return errors.ErrReservationNotFound(ctx)
+		}
+		return u.finish(ctx, r, domain.ReservationStatusCancelled, "")
+	}
+
+	return nil
+}
+
+func (u *reservationUc) OnSessionChanged(ctx context.Context, sess *domain.Session) error {
+	l := u.l().C(ctx).Mth("on-sess-changed").F(kit.KV{"sessId": sess.Id}).Dbg()
+
+	if sess.Details.CdrToken == nil || sess.Details.CdrToken.Id == "" || sess.Details.LocationId == "" {
+		return nil
+	}
+
+	// session in RESERVATION status represents the reservation itself, it isn't started yet
+	if sess.Details.Status == domain.SessionStatusReservation {
+		return nil
+	}
+
+	// search active reservations of the token on the location
+	rs, err := u.reservationService.Search(ctx, &domain.ReservationSearchCriteria{
+		TokenId:    sess.Details.CdrToken.Id,
+		LocationId: sess.Details.LocationId,
+		Statuses:   []string{domain.ReservationStatusActive},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rs.Items {
+		// reservation of the whole location can be used on any EVSE
+		if r.Details.EvseId != "" && r.Details.EvseId != sess.Details.EvseId {
+			continue
+		}
+		l.F(kit.KV{"resId": r.Id}).Dbg("used")
+		return u.finish(ctx, r, domain.ReservationStatusUsed, sess.Id)
+	}
+
+	return nil
+}
+
+func (u *reservationUc) ExpireReservationsCronHandler(ctx context.Context) {
+	l := u.l().C(ctx).Mth("expire-cron").Dbg()
+
+	cr := &domain.ReservationSearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(domain.PageSizeMaxLimit)},
+		Statuses:     []string{domain.ReservationStatusActive},
+		ExpireDateLE: kit.NowPtr(),
+	}
+	for {
+		rs, err := u.reservationService.Search(ctx, cr)
+		if err != nil {
+			l.E(err).St().Err("retrieve reservations")
+			return
+		}
+		l.DbgF("found: %d", len(rs.Items))
+
+		for _, r := range rs.Items {
+			if err := u.finish(ctx, r, domain.ReservationStatusExpired, ""); err != nil {
+				u.l().C(ctx).Mth("expire-cron").F(kit.KV{"resId": r.Id}).E(err).St().Err()
+			}
+		}
+
+		if rs.NextPage == nil {
+			return
+		}
+		cr.PageRequest = *rs.NextPage
+	}
+}
+
+// activate creates an active reservation by the accepted RESERVE_NOW command
+func (u *reservationUc) activate(ctx context.Context, cmd *domain.Command) error {
+	rq := cmd.Details.Reserve
+
+	// reservation with the same id of the party replaces the previous one, so that the previously reserved EVSE is released
+	stored, err := u.reservationService.GetByReservationId(ctx, cmd.PlatformId, cmd.ExtId, rq.ReservationId)
+	if err != nil {
+		return err
+	}
+
+	r := &domain.ChargeReservation{
+		OcpiItem: domain.OcpiItem{
+			ExtId:       cmd.ExtId,
+			PlatformId:  cmd.PlatformId,
+			RefId:       cmd.RefId,
+			LastUpdated: kit.Now(),
+		},
+		ReservationId: rq.ReservationId,
+		Details: domain.ReservationDetails{
+			LocationId:  rq.LocationId,
+			EvseId:      rq.EvseId,
+			ConnectorId: rq.ConnectorId,
+			ExpireDate:  rq.ExpireDate,
+			AuthRef:     cmd.AuthRef,
+			CommandId:   cmd.Id,
+		},
+	}
+	if rq.Token != nil {
+		r.Details.TokenId = rq.Token.Id
+	}
+
+	r, err = u.reservationService.Create(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	if stored != nil && stored.Status == domain.ReservationStatusActive && stored.Details.EvseId != r.Details.EvseId {
+		u.setEvseStatus(ctx, stored, domain.EvseStatusAvailable)
+	}
+	u.setEvseStatus(ctx, r, domain.EvseStatusReserved)
+
+	return u.webhook.OnReservationChanged(ctx, u.converter.ReservationDomainToBackend(r))
+}
+
+// finish moves the active reservation to the final status and releases the reserved EVSE
+func (u *reservationUc) finish(ctx context.Context, r *domain.ChargeReservation, status, sessionId string) error {
+	r, err := u.reservationService.SetStatus(ctx, r.Id, status, sessionId)
+	if err != nil {
+		return err
+	}
+
+	// status of the used EVSE is set by the charge point
+	if status != domain.ReservationStatusUsed {
+		u.setEvseStatus(ctx, r, domain.EvseStatusAvailable)
+	}
+
+	return u.webhook.OnReservationChanged(ctx, u.converter.ReservationDomainToBackend(r))
+}
+
+// setEvseStatus sets status of the reserved EVSE if it's of the local platform
+// EVSE of the remote platform is maintained by the remote CPO, failure doesn't break the reservation lifecycle
+func (u *reservationUc) setEvseStatus(ctx context.Context, r *domain.ChargeReservation, status string) {
+	l := u.l().C(ctx).Mth("set-evse-status").F(kit.KV{"resId": r.Id, "evseId": r.Details.EvseId, "status": status})
+
+	if r.Details.EvseId == "" {
+		return
+	}
+
+	evse, err := u.locService.GetEvse(ctx, r.Details.LocationId, r.Details.EvseId, false)
+	if err != nil {
+		l.E(err).St().Err()
+		return
+	}
+	if evse == nil || evse.PlatformId != u.localPlatformService.GetPlatformId(ctx) || evse.Status == status {
+		return
+	}
+
+	// EVSE is released only if it's still reserved
+	if status == domain.EvseStatusAvailable && evse.Status != domain.EvseStatusReserved {
+		return
+	}
+
+	if err := u.locationUc.OnLocalEvseStatusChanged(ctx, r.Details.LocationId, r.Details.EvseId, status); err != nil {
+		l.E(err).St().Err()
+	}
+}
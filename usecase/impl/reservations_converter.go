@@ -0,0 +1,43 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/usecase"
+)
+
+type reservationConverter struct {
+}
+
+func NewReservationConverter() usecase.ReservationConverter {
+	return &reservationConverter{}
+}
+
+func (c *reservationConverter) ReservationDomainToBackend(r *domain.ChargeReservation) *backend.ChargeReservation {
+	if r == nil {
+		return nil
+	}
+	return &backend.ChargeReservation{
+		Id:            r.Id,
+		ReservationId: r.ReservationId,
+		Status:        r.Status,
+		TokenId:       r.Details.TokenId,
+		LocationId:    r.Details.LocationId,
+		EvseId:        r.Details.EvseId,
+		ConnectorId:   r.Details.ConnectorId,
+		ExpireDate:    r.Details.ExpireDate,
+		AuthRef:       r.Details.AuthRef,
+		CommandId:     r.Details.CommandId,
+		SessionId:     r.Details.SessionId,
+		LastUpdated:   r.LastUpdated,
+		PlatformId:    r.PlatformId,
+		RefId:         r.RefId,
+		PartyId:       r.ExtId.PartyId,
+		CountryCode:   r.ExtId.CountryCode,
+	}
+}
+
+func (c *reservationConverter) ReservationsDomainToBackend(rs []*domain.ChargeReservation) []*backend.ChargeReservation {
+	return kit.Select(rs, c.ReservationDomainToBackend)
+}
@@ -0,0 +1,177 @@
+package impl
+
+import (
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type reservationUcTestSuite struct {
+	kit.Suite
+	uc                   *reservationUc
+	reservationService   *mocks.ReservationService
+	locationService      *mocks.LocationService
+	locationUc           *mocks.LocationUc
+	localPlatformService *mocks.LocalPlatformService
+	webhook              *mocks.WebhookCallService
+}
+
+func (s *reservationUcTestSuite) SetupSuite() {
+	s.Suite.Init(ocpi.LF())
+}
+
+func (s *reservationUcTestSuite) SetupTest() {
+	s.reservationService = &mocks.ReservationService{}
+	s.locationService = &mocks.LocationService{}
+	s.locationUc = &mocks.LocationUc{}
+	s.localPlatformService = &mocks.LocalPlatformService{}
+	s.webhook = &mocks.WebhookCallService{}
+	s.uc = NewReservationUc(s.reservationService, s.locationService, s.locationUc, s.localPlatformService, s.webhook).(*reservationUc)
+	s.localPlatformService.On("GetPlatformId", s.Ctx).Return("local")
+	s.webhook.On("OnReservationChanged", s.Ctx, mock.Anything).Return(nil)
+}
+
+func TestReservationUcSuite(t *testing.T) {
+	suite.Run(t, new(reservationUcTestSuite))
+}
+
+func (s *reservationUcTestSuite) reserveCmd() *domain.Command {
+	return &domain.Command{
+		OcpiItem: domain.OcpiItem{
+			ExtId:      domain.PartyExtId{PartyId: "TST", CountryCode: "RS"},
+			PlatformId: "remote",
+		},
+		Id:  kit.NewId(),
+		Cmd: domain.CmdReserve,
+		Details: domain.CommandDetails{
+			Reserve: &domain.ReserveNow{
+				Token:         &domain.Token{Id: kit.NewId()},
+				ExpireDate:    kit.Now().Add(time.Hour),
+				ReservationId: kit.NewId(),
+				LocationId:    kit.NewId(),
+				EvseId:        kit.NewId(),
+			},
+			Processing: domain.Processing{Status: domain.CmdResultTypeAccepted},
+		},
+	}
+}
+
+func (s *reservationUcTestSuite) reservation(cmd *domain.Command, status string) *domain.ChargeReservation {
+	return &domain.ChargeReservation{
+		OcpiItem:      cmd.OcpiItem,
+		Id:            kit.NewId(),
+		ReservationId: cmd.Details.Reserve.ReservationId,
+		Status:        status,
+		Details: domain.ReservationDetails{
+			TokenId:    cmd.Details.Reserve.Token.Id,
+			LocationId: cmd.Details.Reserve.LocationId,
+			EvseId:     cmd.Details.Reserve.EvseId,
+			ExpireDate: cmd.Details.Reserve.ExpireDate,
+			CommandId:  cmd.Id,
+		},
+	}
+}
+
+func (s *reservationUcTestSuite) evse(r *domain.ChargeReservation, platformId, status string) *domain.Evse {
+	return &domain.Evse{OcpiItem: domain.OcpiItem{PlatformId: platformId}, Id: r.Details.EvseId, LocationId: r.Details.LocationId, Status: status}
+}
+
+func (s *reservationUcTestSuite) Test_ReserveAccepted_LocalEvseReserved() {
+	cmd := s.reserveCmd()
+	r := s.reservation(cmd, domain.ReservationStatusActive)
+	s.reservationService.On("GetByReservationId", s.Ctx, cmd.PlatformId, cmd.ExtId, r.ReservationId).Return(nil, nil)
+	s.reservationService.On("Create", s.Ctx, mock.MatchedBy(func(res *domain.ChargeReservation) bool {
+		return res.ReservationId == r.ReservationId && res.ExtId == cmd.ExtId && res.Details.TokenId == r.Details.TokenId && res.Details.CommandId == cmd.Id && res.PlatformId == cmd.PlatformId
+	})).Return(r, nil)
+	s.locationService.On("GetEvse", s.Ctx, r.Details.LocationId, r.Details.EvseId, false).Return(s.evse(r, "local", domain.EvseStatusAvailable), nil)
+	s.locationUc.On("OnLocalEvseStatusChanged", s.Ctx, r.Details.LocationId, r.Details.EvseId, domain.EvseStatusReserved).Return(nil)
+	s.NoError(s.uc.OnCommandResponse(s.Ctx, cmd))
+	s.locationUc.AssertNumberOfCalls(s.T(), "OnLocalEvseStatusChanged", 1)
+	s.webhook.AssertNumberOfCalls(s.T(), "OnReservationChanged", 1)
+}
+
+func (s *reservationUcTestSuite) Test_ReserveAccepted_RemoteEvseNotChanged() {
+	cmd := s.reserveCmd()
+	r := s.reservation(cmd, domain.ReservationStatusActive)
+	s.reservationService.On("GetByReservationId", s.Ctx, cmd.PlatformId, cmd.ExtId, r.ReservationId).Return(nil, nil)
+	s.reservationService.On("Create", s.Ctx, mock.Anything).Return(r, nil)
+	s.locationService.On("GetEvse", s.Ctx, r.Details.LocationId, r.Details.EvseId, false).Return(s.evse(r, "remote", domain.EvseStatusAvailable), nil)
+	s.NoError(s.uc.OnCommandResponse(s.Ctx, cmd))
+	s.locationUc.AssertNotCalled(s.T(), "OnLocalEvseStatusChanged", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.webhook.AssertNumberOfCalls(s.T(), "OnReservationChanged", 1)
+}
+
+func (s *reservationUcTestSuite) Test_ReserveRejected() {
+	cmd := s.reserveCmd()
+	cmd.Details.Processing.Status = domain.CmdResultTypeRejected
+	s.NoError(s.uc.OnCommandResponse(s.Ctx, cmd))
+	s.reservationService.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	s.webhook.AssertNotCalled(s.T(), "OnReservationChanged", mock.Anything, mock.Anything)
+}
+
+func (s *reservationUcTestSuite) Test_CancelAccepted_EvseReleased() {
+	r := s.reservation(s.reserveCmd(), domain.ReservationStatusActive)
+	cancelled := s.reservation(s.reserveCmd(), domain.ReservationStatusCancelled)
+	cancelled.Id, cancelled.Details = r.Id, r.Details
+	cmd := &domain.Command{
+		OcpiItem: r.OcpiItem,
+		Id:       kit.NewId(),
+		Cmd:      domain.CmdCancelReservation,
+		Details: domain.CommandDetails{
+			CancelReservation: &domain.CancelReservation{ReservationId: r.ReservationId},
+			Processing:        domain.Processing{Status: domain.CmdResultTypeAccepted},
+		},
+	}
+	s.reservationService.On("GetByReservationId", s.Ctx, r.PlatformId, r.ExtId, r.ReservationId).Return(r, nil)
+	s.reservationService.On("SetStatus", s.Ctx, r.Id, domain.ReservationStatusCancelled, "").Return(cancelled, nil)
+	s.locationService.On("GetEvse", s.Ctx, r.Details.LocationId, r.Details.EvseId, false).Return(s.evse(r, "local", domain.EvseStatusReserved), nil)
+	s.locationUc.On("OnLocalEvseStatusChanged", s.Ctx, r.Details.LocationId, r.Details.EvseId, domain.EvseStatusAvailable).Return(nil)
+	s.NoError(s.uc.OnCommandResponse(s.Ctx, cmd))
+	s.locationUc.AssertNumberOfCalls(s.T(), "OnLocalEvseStatusChanged", 1)
+	s.webhook.AssertNumberOfCalls(s.T(), "OnReservationChanged", 1)
+}
+
+func (s *reservationUcTestSuite) Test_SessionStarted_ReservationUsed() {
+	r := s.reservation(s.reserveCmd(), domain.ReservationStatusActive)
+	other := s.reservation(s.reserveCmd(), domain.ReservationStatusActive)
+	sess := &domain.Session{
+		Id: kit.NewId(),
+		Details: domain.SessionDetails{
+			CdrToken:   &domain.CdrToken{Id: r.Details.TokenId},
+			LocationId: r.Details.LocationId,
+			EvseId:     r.Details.EvseId,
+			Status:     domain.SessionStatusActive,
+		},
+	}
+	used := s.reservation(s.reserveCmd(), domain.ReservationStatusUsed)
+	s.reservationService.On("Search", s.Ctx, mock.Anything).Return(&domain.ReservationSearchResponse{Items: []*domain.ChargeReservation{other, r}}, nil)
+	s.reservationService.On("SetStatus", s.Ctx, r.Id, domain.ReservationStatusUsed, sess.Id).Return(used, nil)
+	s.NoError(s.uc.OnSessionChanged(s.Ctx, sess))
+	s.reservationService.AssertNumberOfCalls(s.T(), "SetStatus", 1)
+	s.locationService.AssertNotCalled(s.T(), "GetEvse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.webhook.AssertNumberOfCalls(s.T(), "OnReservationChanged", 1)
+}
+
+func (s *reservationUcTestSuite) Test_ExpireCron() {
+	r1 := s.reservation(s.reserveCmd(), domain.ReservationStatusActive)
+	r2 := s.reservation(s.reserveCmd(), domain.ReservationStatusActive)
+	r2.Details.EvseId = ""
+	cursor := kit.NewRandString()
+	s.reservationService.On("Search", s.Ctx, mock.MatchedBy(func(cr *domain.ReservationSearchCriteria) bool { return cr.Cursor == nil })).
+		Return(&domain.ReservationSearchResponse{Items: []*domain.ChargeReservation{r1}, PageResponse: domain.PageResponse{NextPage: &domain.PageRequest{Cursor: &cursor}}}, nil)
+	s.reservationService.On("Search", s.Ctx, mock.MatchedBy(func(cr *domain.ReservationSearchCriteria) bool { return cr.Cursor != nil })).
+		Return(&domain.ReservationSearchResponse{Items: []*domain.ChargeReservation{r2}}, nil)
+	s.reservationService.On("SetStatus", s.Ctx, r1.Id, domain.ReservationStatusExpired, "").Return(r1, nil)
+	s.reservationService.On("SetStatus", s.Ctx, r2.Id, domain.ReservationStatusExpired, "").Return(r2, nil)
+	s.locationService.On("GetEvse", s.Ctx, r1.Details.LocationId, r1.Details.EvseId, false).Return(s.evse(r1, "local", domain.EvseStatusCharging), nil)
+	s.uc.ExpireReservationsCronHandler(s.Ctx)
+	s.reservationService.AssertNumberOfCalls(s.T(), "SetStatus", 2)
+	// EVSE which isn't reserved anymore isn't released
+	s.locationUc.AssertNotCalled(s.T(), "OnLocalEvseStatusChanged", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.webhook.AssertNumberOfCalls(s.T(), "OnReservationChanged", 2)
+}
@@ -28,11 +28,16 @@ type sessionUc struct {
 	cmdService           domain.CommandService
 	localPlatformService domain.LocalPlatformService
 	tokenService         domain.TokenService
+	reservationUc        usecase.ReservationUc
+	commandUc            usecase.CommandUc
+	tokenConverter       usecase.TokenConverter
+	tokenBlockPolicy     string
+	monitorCfg           *ocpi.CfgSessionMonitor
 }
 
 func NewSessionUc(platformService domain.PlatformService, sessionService domain.SessionService, remoteSessionRep usecase.RemoteSessionRepository,
 	partyService domain.PartyService, webhook backend.WebhookCallService, cmdService domain.CommandService, localPlatformService domain.LocalPlatformService,
-	tokenService domain.TokenService, tokenGen domain.TokenGenerator) usecase.SessionUc {
+	tokenService domain.TokenService, reservationUc usecase.ReservationUc, commandUc usecase.CommandUc, tokenGen domain.TokenGenerator) usecase.SessionUc {
 	return &sessionUc{
 		ucBase:               newBase(platformService, partyService, tokenGen),
 		sessionService:       sessionService,
@@ -42,7 +47,12 @@ func NewSessionUc(platformService domain.PlatformService, sessionService domain.
 		cmdService:           cmdService,
 		localPlatformService: localPlatformService,
 		tokenService:         tokenService,
+		reservationUc:        reservationUc,
+		commandUc:            commandUc,
 		converter:            NewSessionConverter(),
+		tokenConverter:       NewTokenConverter(),
+		tokenBlockPolicy:     TokenBlockPolicyNotify,
+		monitorCfg:           &ocpi.CfgSessionMonitor{},
 	}
 }
 
@@ -50,6 +60,25 @@ func (s *sessionUc) l() kit.CLogger {
 	return ocpi.L().Cmp("sess-uc")
 }
 
+func (s *sessionUc) Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Remote != nil && cfg.Remote.SessionMonitor != nil {
+		s.monitorCfg = cfg.Remote.SessionMonitor
+	}
+	if cfg.Local != nil && cfg.Local.TokenBlock != nil {
+		switch cfg.Local.TokenBlock.Policy {
+		case "":
+		case TokenBlockPolicyNone, TokenBlockPolicyNotify, TokenBlockPolicyStop:
+			s.tokenBlockPolicy = cfg.Local.TokenBlock.Policy
+		default:
+			return errors.ErrSessTokenBlockPolicyInvalid(ctx, cfg.Local.TokenBlock.Policy)
+		}
+	}
+	return nil
+}
+
 func (s *sessionUc) OnLocalSessionChanged(ctx context.Context, sess *domain.Session) error {
 	l := s.l().C(ctx).Mth("on-sess-changed-loc").F(kit.KV{"sessId": sess.Id}).Dbg()
 
@@ -85,6 +114,12 @@ func (s *sessionUc) OnLocalSessionChanged(ctx context.Context, sess *domain.Sess
 		return nil
 	}
 
+	// link reservation
+	s.trackReservations(ctx, sess)
+
+	// check limits
+	s.watchLimits(ctx, sess)
+
 	// get platform
 	platform, err := s.getConnectedPlatform(ctx, tkn.PlatformId)
 	if err != nil {
@@ -145,6 +180,12 @@ func (s *sessionUc) OnLocalSessionPatched(ctx context.Context, sess *domain.Sess
 		return nil
 	}
 
+	// link reservation
+	s.trackReservations(ctx, sess)
+
+	// check limits
+	s.watchLimits(ctx, sess)
+
 	// get platform to push session
 	platform, err := s.getConnectedPlatform(ctx, tkn.PlatformId)
 	if err != nil {
@@ -178,6 +219,19 @@ func (s *sessionUc) OnRemoteSessionsPull(ctx context.Context, from, to *time.Tim
 	return s.remoteSessionsPull(ctx, from, to, platforms)
 }
 
+func (s *sessionUc) OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error {
+	// get platforms to pull from
+	platforms, err := s.getPlatformsToPull(ctx)
+	if err != nil {
+		return err
+	}
+
+	// retrieve only the requested platform
+	platforms = kit.Filter(platforms, func(p *domain.Platform) bool { return p.Id == platformId })
+
+	return s.remoteSessionsPull(ctx, from, to, platforms)
+}
+
 func (s *sessionUc) OnRemoteSessionsPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error {
 	// get platforms to pull from
 	platforms, err := s.getPlatformsToPull(ctx)
@@ -243,10 +297,25 @@ func (s *sessionUc) modifySession(ctx context.Context, platformId string, sess *
 		return nil
 	}
 
+	// link reservation
+	s.trackReservations(ctx, sessDom)
+
+	// check limits
+	s.watchLimits(ctx, sessDom)
+
 	// call webhook
 	return s.webhook.OnSessionsChanged(ctx, s.converter.SessionDomainToBackend(sessDom))
 }
 
+// trackReservations marks reservations used by the sessions, failure doesn't break processing of the sessions
+func (s *sessionUc) trackReservations(ctx context.Context, sessions ...*domain.Session) {
+	for _, sess := range sessions {
+		if err := s.reservationUc.OnSessionChanged(ctx, sess); err != nil {
+			s.l().C(ctx).Mth("track-res").F(kit.KV{"sessId": sess.Id}).E(err).St().Err()
+		}
+	}
+}
+
 func (s *sessionUc) mustGetToken(ctx context.Context, id string) (*domain.Token, error) {
 	if id == "" {
 		return nil, errors.ErrTknIdEmpty(ctx)
@@ -275,6 +344,74 @@ func (s *sessionUc) mustGetTokenId(ctx context.Context, sess *domain.Session) (s
 	return fullSess.Details.CdrToken.Id, nil
 }
 
+// putRemoteSessions puts sessions pulled from the remote platform in a single batch
+// items which cannot be put are logged and skipped
+func (s *sessionUc) putRemoteSessions(ctx context.Context, platformId string, sessions []*model.OcpiSession) error {
+	l := s.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "count": len(sessions)}).Dbg()
+
+	// get and check platform
+	_, err := s.getConnectedPlatform(ctx, platformId)
+	if err != nil {
+		return err
+	}
+
+	// check sessions are of the remote platform
+	stored, err := s.sessionService.SearchSessions(ctx, &domain.SessionSearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(len(sessions))},
+		Ids:          kit.Select(sessions, func(sess *model.OcpiSession) string { return sess.Id }),
+		IncPlatforms: []string{s.localPlatformService.GetPlatformId(ctx)},
+	})
+	if err != nil {
+		return err
+	}
+	local := make(map[string]struct{}, len(stored.Items))
+	for _, sess := range stored.Items {
+		local[sess.Id] = struct{}{}
+	}
+
+	logErr := func(id string, err error) {
+		s.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "sessId": id}).E(err).St().Err()
+	}
+
+	sessDoms := make([]*domain.Session, 0, len(sessions))
+	for _, sess := range sessions {
+		if _, ok := local[sess.Id]; ok {
+			logErr(sess.Id, errors.ErrSessCmdInvalidPlatform(ctx))
+			continue
+		}
+		sessDoms = append(sessDoms, s.converter.SessionModelToDomain(sess, platformId))
+	}
+	if len(sessDoms) == 0 {
+		return nil
+	}
+
+	// put sessions to the local platform
+	applied, results, err := s.sessionService.PutSessions(ctx, sessDoms)
+	if err != nil {
+		return err
+	}
+	for _, rs := range results {
+		if rs.Err != nil {
+			logErr(rs.Id, rs.Err)
+		}
+	}
+
+	// no changes
+	if len(applied) == 0 {
+		l.Warn("no changes applied")
+		return nil
+	}
+
+	// link reservations
+	s.trackReservations(ctx, applied...)
+
+	// check limits
+	s.watchLimits(ctx, applied...)
+
+	// call webhook
+	return s.webhook.OnSessionsChanged(ctx, s.converter.SessionsDomainToBackend(applied)...)
+}
+
 func (s *sessionUc) remoteSessionsPull(ctx context.Context, from, to *time.Time, platforms []*domain.Platform) error {
 	l := s.l().C(ctx).Mth("remote-pull").Dbg()
 
@@ -295,11 +432,11 @@ func (s *sessionUc) remoteSessionsPull(ctx context.Context, from, to *time.Time,
 	for i := 0; i < sessWorkersNum; i++ {
 		goroutine.New().WithLogger(l).Go(ctx, func() {
 			for v := range ch {
-				sess := v.data.(*model.OcpiSession)
-				err := s.OnRemoteSessionPut(ctx, v.platformId, sess)
+				sessions := v.data.([]*model.OcpiSession)
+				err := s.putRemoteSessions(ctx, v.platformId, sessions)
 				if err != nil {
 					s.l().C(ctx).Mth("remote-put").
-						F(kit.KV{"platformId": v.platformId, "sessId": sess.Id}).
+						F(kit.KV{"platformId": v.platformId, "count": len(sessions)}).
 						E(err).St().Err()
 				}
 			}
@@ -317,9 +454,23 @@ func (s *sessionUc) remoteSessionsPull(ctx context.Context, from, to *time.Time,
 			continue
 		}
 		eg.Go(func() error {
+			// objects of some versions come without party attributes
+			party, err := s.getVersionParty(ctx, platform)
+			if err != nil {
+				return err
+			}
 			for sessions := range pgReader.GetPage(ctx, buildOcpiRepositoryRequest(ep, s.tokenC(platform), localPlatform, platform), sessPageSize, from, to) {
 				for _, sess := range sessions {
-					ch <- channelData{platformId: platform.Id, data: sess}
+					if party != nil {
+						sess.OcpiPartyId = *party
+						if sess.CdrToken != nil && sess.CdrToken.PartyId == "" {
+							sess.CdrToken.OcpiPartyId = *party
+						}
+					}
+				}
+				// pages are put in batches by workers
+				for _, batch := range chunks(sessions, domain.PageSizeMaxLimit) {
+					ch <- channelData{platformId: platform.Id, data: batch}
 				}
 			}
 			return nil
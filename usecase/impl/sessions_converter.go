@@ -116,6 +116,7 @@ func (t *sessionConverter) SessionDomainToBackend(sess *domain.Session) *backend
 		ChargingPeriods: t.chargingPeriodDomainToBackend(sess.ChargingPeriods),
 		TotalCost:       t.priceDomainToBackend(sess.Details.TotalCost),
 		Status:          sess.Details.Status,
+		StopReason:      t.stopReasonDomainToBackend(sess.Details.StopReason),
 		LastUpdated:     sess.LastUpdated,
 		PlatformId:      sess.PlatformId,
 		RefId:           sess.RefId,
@@ -124,6 +125,33 @@ func (t *sessionConverter) SessionDomainToBackend(sess *domain.Session) *backend
 	}
 }
 
+func (t *sessionConverter) stopReasonDomainToBackend(r *domain.SessionStopReason) *backend.SessionStopReason {
+	if r == nil {
+		return nil
+	}
+	return &backend.SessionStopReason{
+		Limit:     r.Limit,
+		Value:     r.Value,
+		Actual:    r.Actual,
+		CommandId: r.CommandId,
+		At:        r.At,
+	}
+}
+
+func (t *sessionConverter) SessionIssueReportsDomainToBackend(rs []*domain.SessionIssueReport) []*backend.SessionIssueReport {
+	r := make([]*backend.SessionIssueReport, 0, len(rs))
+	for _, i := range rs {
+		r = append(r, &backend.SessionIssueReport{
+			PlatformId:  i.PlatformId,
+			PartyId:     i.PartyId,
+			CountryCode: i.CountryCode,
+			Stale:       i.Stale,
+			MissingCdr:  i.MissingCdr,
+		})
+	}
+	return r
+}
+
 func (t *sessionConverter) SessionsDomainToBackend(ts []*domain.Session) []*backend.Session {
 	var r []*backend.Session
 	for _, sess := range ts {
@@ -0,0 +1,158 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/domain"
+)
+
+// watchLimits stops sessions exceeded their limits, failure doesn't break processing of the sessions
+func (s *sessionUc) watchLimits(ctx context.Context, sessions ...*domain.Session) {
+	for _, sess := range sessions {
+		if err := s.checkLimits(ctx, sess); err != nil {
+			s.l().C(ctx).Mth("watch-limits").F(kit.KV{"sessId": sess.Id}).E(err).St().Err()
+		}
+	}
+}
+
+// checkLimits checks the session against limits of the originating command and the token
+// if any of them is exceeded, the session is requested to be stopped and the reason is recorded on the session
+func (s *sessionUc) checkLimits(ctx context.Context, sess *domain.Session) error {
+	// only active sessions which haven't been stopped yet are checked
+	if sess.Details.Status != domain.SessionStatusActive || sess.Details.StopReason != nil {
+		return nil
+	}
+
+	limits, err := s.getLimits(ctx, sess)
+	if err != nil {
+		return err
+	}
+
+	reason := s.exceededLimit(sess, limits)
+	if reason == nil {
+		return nil
+	}
+
+	s.l().C(ctx).Mth("limit-exceeded").F(kit.KV{"sessId": sess.Id, "limit": reason.Limit, "value": reason.Value, "actual": reason.Actual}).Inf("session limit exceeded")
+
+	// request stopping session of a remote platform, the local platform stops its sessions itself being notified by webhook
+	if sess.PlatformId != s.localPlatformService.GetPlatformId(ctx) {
+		cmd := &domain.Command{
+			Id: kit.NewId(),
+			OcpiItem: domain.OcpiItem{
+				PlatformId:  s.localPlatformService.GetPlatformId(ctx),
+				LastUpdated: kit.Now(),
+			},
+			Cmd: domain.CmdStopSession,
+			Details: domain.CommandDetails{
+				StopSession: &domain.StopSession{SessionId: sess.Id},
+			},
+		}
+		if sess.Details.CdrToken != nil {
+			cmd.ExtId = sess.Details.CdrToken.PartyExtId
+		}
+		if err := s.commandUc.OnLocalStopSession(ctx, cmd); err != nil {
+			return err
+		}
+		reason.CommandId = cmd.Id
+	}
+
+	// record the reason
+	sess, err = s.sessionService.SetStopReason(ctx, sess.Id, reason)
+	if err != nil {
+		return err
+	}
+
+	// call webhook
+	return s.webhook.OnSessionLimitExceeded(ctx, s.converter.SessionDomainToBackend(sess))
+}
+
+// getLimits retrieves the strictest limits among the originating START_SESSION command and the token
+func (s *sessionUc) getLimits(ctx context.Context, sess *domain.Session) (*domain.SessionLimits, error) {
+	limits := &domain.SessionLimits{}
+
+	// command limits
+	if sess.Details.AuthMethod == domain.AuthMethodCommand && sess.Details.AuthRef != "" {
+		rs, err := s.cmdService.SearchCommands(ctx, &domain.CommandSearchCriteria{
+			PageRequest: domain.PageRequest{Limit: kit.IntPtr(1)},
+			AuthRef:     sess.Details.AuthRef,
+			Cmd:         domain.CmdStartSession,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(rs.Items) > 0 && rs.Items[0].Details.StartSession != nil {
+			start := rs.Items[0].Details.StartSession
+			limits.Kwh = minLimit(limits.Kwh, start.KwhLimit)
+			limits.Cost = s.minCostLimit(ctx, sess, limits.Cost, start.CostLimit, start.CostLimitCurrency)
+		}
+	}
+
+	// token limits
+	if sess.Details.CdrToken != nil && sess.Details.CdrToken.Id != "" {
+		tkn, err := s.tokenService.GetToken(ctx, sess.Details.CdrToken.Id)
+		if err != nil {
+			return nil, err
+		}
+		if tkn != nil && tkn.Details.SessionLimits != nil {
+			limits.Kwh = minLimit(limits.Kwh, tkn.Details.SessionLimits.Kwh)
+			limits.Cost = s.minCostLimit(ctx, sess, limits.Cost, tkn.Details.SessionLimits.Cost, tkn.Details.SessionLimits.Currency)
+		}
+	}
+	if limits.Cost != nil {
+		limits.Currency = sess.Details.Currency
+	}
+
+	return limits, nil
+}
+
+// minCostLimit returns the strictest of two cost limits
+// the cost limit in currency other than the session one can't be compared with the session cost, so it's skipped
+func (s *sessionUc) minCostLimit(ctx context.Context, sess *domain.Session, limit, cost *float64, currency string) *float64 {
+	if cost == nil {
+		return limit
+	}
+	if currency != sess.Details.Currency {
+		s.l().C(ctx).Mth("cost-limit").F(kit.KV{"sessId": sess.Id, "currency": currency, "sessCurrency": sess.Details.Currency}).Warn("cost limit currency differs from session, skipped")
+		return limit
+	}
+	return minLimit(limit, cost)
+}
+
+// exceededLimit returns stop reason if the session reached any of the limits
+func (s *sessionUc) exceededLimit(sess *domain.Session, limits *domain.SessionLimits) *domain.SessionStopReason {
+	if limits.Kwh != nil && sess.Details.Kwh != nil && *sess.Details.Kwh >= *limits.Kwh {
+		return &domain.SessionStopReason{
+			Limit:  domain.SessionLimitKwh,
+			Value:  *limits.Kwh,
+			Actual: *sess.Details.Kwh,
+			At:     kit.Now(),
+		}
+	}
+	if limits.Cost != nil && sess.Details.TotalCost != nil && limits.Currency == sess.Details.Currency {
+		cost := sess.Details.TotalCost.ExclVat
+		if sess.Details.TotalCost.InclVat != nil {
+			cost = *sess.Details.TotalCost.InclVat
+		}
+		if cost >= *limits.Cost {
+			return &domain.SessionStopReason{
+				Limit:  domain.SessionLimitCost,
+				Value:  *limits.Cost,
+				Actual: cost,
+				At:     kit.Now(),
+			}
+		}
+	}
+	return nil
+}
+
+// minLimit returns the strictest of two limits, nil means no limit
+func minLimit(a, b *float64) *float64 {
+	if a == nil {
+		return b
+	}
+	if b == nil || *a <= *b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,202 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"time"
+)
+
+const (
+	// sessMonitorPeriod how often the monitor is run by cron
+	// only sessions which have become stale (or missing CDR) within the last period are notified, so that webhooks aren't repeated every run
+	sessMonitorPeriod = time.Hour
+
+	sessMonitorStaleHoursDefault = 24
+	sessMonitorCdrHoursDefault   = 48
+)
+
+func (s *sessionUc) staleAfter() time.Duration {
+	if s.monitorCfg.StaleHours > 0 {
+		return time.Duration(s.monitorCfg.StaleHours) * time.Hour
+	}
+	return sessMonitorStaleHoursDefault * time.Hour
+}
+
+func (s *sessionUc) cdrAfter() time.Duration {
+	if s.monitorCfg.CdrHours > 0 {
+		return time.Duration(s.monitorCfg.CdrHours) * time.Hour
+	}
+	return sessMonitorCdrHoursDefault * time.Hour
+}
+
+// staleCriteria ACTIVE/PENDING sessions without updates since the given time
+func (s *sessionUc) staleCriteria(before time.Time) *domain.SessionSearchCriteria {
+	return &domain.SessionSearchCriteria{
+		PageRequest: domain.PageRequest{Limit: kit.IntPtr(domain.PageSizeMaxLimit), DateTo: kit.TimePtr(before)},
+		Statuses:    []string{domain.SessionStatusActive, domain.SessionStatusPending},
+	}
+}
+
+// missingCdrCriteria COMPLETED sessions without CDR since the given time
+func (s *sessionUc) missingCdrCriteria(before time.Time) *domain.SessionSearchCriteria {
+	return &domain.SessionSearchCriteria{
+		PageRequest: domain.PageRequest{Limit: kit.IntPtr(domain.PageSizeMaxLimit), DateTo: kit.TimePtr(before)},
+		Statuses:    []string{domain.SessionStatusCompleted},
+		WithoutCdr:  true,
+	}
+}
+
+func (s *sessionUc) SessionMonitorCronHandler(ctx context.Context) {
+	l := s.l().C(ctx).Mth("sess-monitor-cron").Dbg()
+
+	if !s.monitorCfg.Enabled {
+		return
+	}
+
+	now := kit.Now()
+
+	// stale sessions
+	cr := s.staleCriteria(now.Add(-s.staleAfter()))
+	cr.DateFrom = kit.TimePtr(cr.DateTo.Add(-sessMonitorPeriod))
+	err := s.scanSessions(ctx, cr, func(sess *domain.Session) error {
+		if s.pullSession(ctx, sess) {
+			return nil
+		}
+		return s.webhook.OnSessionStale(ctx, s.converter.SessionDomainToBackend(sess))
+	})
+	if err != nil {
+		l.E(err).St().Err("stale sessions")
+	}
+
+	// missing CDRs
+	cr = s.missingCdrCriteria(now.Add(-s.cdrAfter()))
+	cr.DateFrom = kit.TimePtr(cr.DateTo.Add(-sessMonitorPeriod))
+	err = s.scanSessions(ctx, cr, func(sess *domain.Session) error {
+		if s.pullSession(ctx, sess) {
+			return nil
+		}
+		return s.webhook.OnCdrMissing(ctx, s.converter.SessionDomainToBackend(sess))
+	})
+	if err != nil {
+		l.E(err).St().Err("missing cdrs")
+	}
+}
+
+func (s *sessionUc) GetSessionIssues(ctx context.Context) ([]*domain.SessionIssueReport, error) {
+	s.l().C(ctx).Mth("sess-issues").Dbg()
+
+	now := kit.Now()
+	reports := newPartnerReports(func(platformId string, extId domain.PartyExtId) *domain.SessionIssueReport {
+		return &domain.SessionIssueReport{PartyExtId: extId, PlatformId: platformId}
+	})
+
+	// stale sessions
+	err := s.scanSessions(ctx, s.staleCriteria(now.Add(-s.staleAfter())), func(sess *domain.Session) error {
+		r := reports.get(sess.PlatformId, sess.ExtId)
+		r.Stale = append(r.Stale, sess.Id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// missing CDRs
+	err = s.scanSessions(ctx, s.missingCdrCriteria(now.Add(-s.cdrAfter())), func(sess *domain.Session) error {
+		r := reports.get(sess.PlatformId, sess.ExtId)
+		r.MissingCdr = append(r.MissingCdr, sess.Id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reports.sorted(), nil
+}
+
+// scanSessions calls fn for all the sessions found by criteria, failure of a single session is logged and doesn't break scanning
+func (s *sessionUc) scanSessions(ctx context.Context, cr *domain.SessionSearchCriteria, fn func(sess *domain.Session) error) error {
+	return scanPages(&cr.PageRequest, func() ([]*domain.Session, *domain.PageRequest, error) {
+		rs, err := s.sessionService.SearchSessions(ctx, cr)
+		if err != nil {
+			return nil, nil, err
+		}
+		return rs.Items, rs.NextPage, nil
+	}, func(sess *domain.Session) {
+		if err := fn(sess); err != nil {
+			s.l().C(ctx).Mth("scan").F(kit.KV{"sessId": sess.Id}).E(err).St().Err()
+		}
+	})
+}
+
+// pullSession requests the session from the owning remote platform
+// returns true if the session has been updated by the pulled one, so it doesn't need attention anymore
+func (s *sessionUc) pullSession(ctx context.Context, sess *domain.Session) bool {
+	l := s.l().C(ctx).Mth("pull-sess").F(kit.KV{"sessId": sess.Id, "platformId": sess.PlatformId})
+
+	// sessions of the local platform cannot be pulled
+	if sess.PlatformId == s.localPlatformService.GetPlatformId(ctx) {
+		return false
+	}
+
+	localPlatform, err := s.localPlatformService.Get(ctx)
+	if err != nil {
+		l.E(err).St().Err()
+		return false
+	}
+
+	platform, err := s.getConnectedPlatform(ctx, sess.PlatformId)
+	if err != nil {
+		l.E(err).St().Warn("platform isn't available")
+		return false
+	}
+
+	// check if sender is supported by the remote platform
+	ep := s.platformService.RoleEndpoint(ctx, platform, model.ModuleIdSessions, model.OcpiSender)
+	if ep == "" {
+		return false
+	}
+
+	// set header to route message
+	if sess.Details.CdrToken != nil {
+		s.setFromPartyCtx(ctx, sess.Details.CdrToken.PartyExtId)
+	}
+	s.setToPartyCtx(ctx, sess.ExtId)
+
+	pulled, err := s.remoteSessionRep.GetSession(ctx, buildOcpiRepositoryIdRequest(ep, s.tokenC(platform), localPlatform, platform, sess.Id))
+	if err != nil {
+		l.E(err).St().Warn("pull failed")
+		return false
+	}
+	if pulled == nil {
+		return false
+	}
+
+	// objects of some versions come without party attributes
+	party, err := s.getVersionParty(ctx, platform)
+	if err != nil {
+		l.E(err).St().Err()
+		return false
+	}
+	if party != nil {
+		pulled.OcpiPartyId = *party
+		if pulled.CdrToken != nil && pulled.CdrToken.PartyId == "" {
+			pulled.CdrToken.OcpiPartyId = *party
+		}
+	}
+
+	// put pulled session
+	if err := s.putRemoteSessions(ctx, platform.Id, []*model.OcpiSession{pulled}); err != nil {
+		l.E(err).St().Err()
+		return false
+	}
+
+	// check if the session has been updated
+	stored, err := s.sessionService.GetSession(ctx, sess.Id)
+	if err != nil {
+		l.E(err).St().Err()
+		return false
+	}
+	return stored != nil && stored.LastUpdated.After(sess.LastUpdated)
+}
@@ -3,11 +3,16 @@ package impl
 import (
 	"github.com/mikhailbolshakov/kit"
 	"github.com/mikhailbolshakov/ocpi"
+	"github.com/mikhailbolshakov/ocpi/backend"
 	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/errors"
 	"github.com/mikhailbolshakov/ocpi/mocks"
+	"github.com/mikhailbolshakov/ocpi/model"
+	"github.com/mikhailbolshakov/ocpi/usecase"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type sessionUcTestSuite struct {
@@ -21,6 +26,8 @@ type sessionUcTestSuite struct {
 	cmdService           *mocks.CommandService
 	localPlatformService *mocks.LocalPlatformService
 	tokenService         *mocks.TokenService
+	reservationUc        *mocks.ReservationUc
+	commandUc            *mocks.CommandUc
 }
 
 func (s *sessionUcTestSuite) SetupSuite() {
@@ -33,7 +40,9 @@ func (s *sessionUcTestSuite) SetupSuite() {
 	s.cmdService = &mocks.CommandService{}
 	s.localPlatformService = &mocks.LocalPlatformService{}
 	s.tokenService = &mocks.TokenService{}
-	s.uc = NewSessionUc(s.platformService, s.sessionService, s.remoteSessionRep, s.partyService, s.webhook, s.cmdService, s.localPlatformService, s.tokenService, nil).(*sessionUc)
+	s.reservationUc = &mocks.ReservationUc{}
+	s.commandUc = &mocks.CommandUc{}
+	s.uc = NewSessionUc(s.platformService, s.sessionService, s.remoteSessionRep, s.partyService, s.webhook, s.cmdService, s.localPlatformService, s.tokenService, s.reservationUc, s.commandUc, nil).(*sessionUc)
 }
 
 func (s *sessionUcTestSuite) SetupTest() {
@@ -55,6 +64,7 @@ func (s *sessionUcTestSuite) Test_OnLocalSessionChanged_UpdateAndRemotePut() {
 	platform := &domain.Platform{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Sessions: true}}, Status: domain.ConnectionStatusConnected}
 	s.platformService.On("Get", s.Ctx, tkn.PlatformId).Return(platform, nil)
 	s.remoteSessionRep.On("PutSessionAsync", s.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.reservationUc.On("OnSessionChanged", s.Ctx, sess).Return(nil)
 	s.NoError(s.uc.OnLocalSessionChanged(s.Ctx, sess))
 	s.AssertNumberOfCalls(&s.remoteSessionRep.Mock, "PutSessionAsync", 1)
 }
@@ -69,6 +79,287 @@ func (s *sessionUcTestSuite) Test_OnLocalSessionPatched_UpdateAndRemotePatch() {
 	platform := &domain.Platform{TokenC: domain.PlatformToken(kit.NewRandString()), Protocol: &domain.ProtocolDetails{PushSupport: domain.PushSupport{Sessions: true}}, Status: domain.ConnectionStatusConnected}
 	s.platformService.On("Get", s.Ctx, tkn.PlatformId).Return(platform, nil)
 	s.remoteSessionRep.On("PatchSessionAsync", s.Ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.reservationUc.On("OnSessionChanged", s.Ctx, sess).Return(nil)
 	s.NoError(s.uc.OnLocalSessionPatched(s.Ctx, sess))
 	s.AssertNumberOfCalls(&s.remoteSessionRep.Mock, "PatchSessionAsync", 1)
 }
+
+func (s *sessionUcTestSuite) Test_CheckLimits_CommandKwhExceeded_StopRequested() {
+	s.localPlatformService.On("GetPlatformId", mock.Anything).Return("local")
+	sess := &domain.Session{
+		Id:       kit.NewId(),
+		OcpiItem: domain.OcpiItem{PlatformId: "remote"},
+		Details: domain.SessionDetails{
+			Status:     domain.SessionStatusActive,
+			AuthMethod: domain.AuthMethodCommand,
+			AuthRef:    kit.NewId(),
+			Kwh:        kit.Float64Ptr(10),
+			CdrToken:   &domain.CdrToken{Id: kit.NewId(), PartyExtId: domain.PartyExtId{PartyId: "AAA", CountryCode: "RS"}},
+		},
+	}
+	cmd := &domain.Command{Details: domain.CommandDetails{StartSession: &domain.StartSession{KwhLimit: kit.Float64Ptr(8)}}}
+	s.cmdService.On("SearchCommands", s.Ctx, mock.MatchedBy(func(cr *domain.CommandSearchCriteria) bool {
+		return cr.AuthRef == sess.Details.AuthRef && cr.Cmd == domain.CmdStartSession
+	})).Return(&domain.CommandSearchResponse{Items: []*domain.Command{cmd}}, nil)
+	s.tokenService.On("GetToken", s.Ctx, sess.Details.CdrToken.Id).Return(&domain.Token{}, nil)
+	s.commandUc.On("OnLocalStopSession", s.Ctx, mock.MatchedBy(func(c *domain.Command) bool {
+		return c.Details.StopSession.SessionId == sess.Id
+	})).Return(nil)
+	var reason *domain.SessionStopReason
+	s.sessionService.On("SetStopReason", s.Ctx, sess.Id, mock.Anything).
+		Run(func(args mock.Arguments) { reason = args.Get(2).(*domain.SessionStopReason) }).
+		Return(sess, nil)
+	s.webhook.On("OnSessionLimitExceeded", s.Ctx, mock.Anything).Return(nil)
+	s.NoError(s.uc.checkLimits(s.Ctx, sess))
+	s.NotEmpty(reason)
+	s.Equal(domain.SessionLimitKwh, reason.Limit)
+	s.Equal(8.0, reason.Value)
+	s.Equal(10.0, reason.Actual)
+	s.NotEmpty(reason.CommandId)
+}
+
+func (s *sessionUcTestSuite) Test_CheckLimits_TokenCostExceeded_LocalSessionNotStopped() {
+	s.localPlatformService.On("GetPlatformId", mock.Anything).Return("local")
+	sess := &domain.Session{
+		Id:       kit.NewId(),
+		OcpiItem: domain.OcpiItem{PlatformId: "local"},
+		Details: domain.SessionDetails{
+			Status:     domain.SessionStatusActive,
+			AuthMethod: domain.AuthMethodWhitelist,
+			Kwh:        kit.Float64Ptr(10),
+			Currency:   "EUR",
+			TotalCost:  &domain.Price{ExclVat: 5, InclVat: kit.Float64Ptr(6)},
+			CdrToken:   &domain.CdrToken{Id: kit.NewId()},
+		},
+	}
+	tkn := &domain.Token{Details: domain.TokenDetails{SessionLimits: &domain.SessionLimits{Kwh: kit.Float64Ptr(20), Cost: kit.Float64Ptr(5.5), Currency: "EUR"}}}
+	s.tokenService.On("GetToken", s.Ctx, sess.Details.CdrToken.Id).Return(tkn, nil)
+	var reason *domain.SessionStopReason
+	s.sessionService.On("SetStopReason", s.Ctx, sess.Id, mock.Anything).
+		Run(func(args mock.Arguments) { reason = args.Get(2).(*domain.SessionStopReason) }).
+		Return(sess, nil)
+	s.webhook.On("OnSessionLimitExceeded", s.Ctx, mock.Anything).Return(nil)
+	s.NoError(s.uc.checkLimits(s.Ctx, sess))
+	s.NotEmpty(reason)
+	s.Equal(domain.SessionLimitCost, reason.Limit)
+	s.Equal(6.0, reason.Actual)
+	s.Empty(reason.CommandId)
+	s.commandUc.AssertNotCalled(s.T(), "OnLocalStopSession", s.Ctx, mock.MatchedBy(func(c *domain.Command) bool {
+		return c.Details.StopSession.SessionId == sess.Id
+	}))
+}
+
+func (s *sessionUcTestSuite) Test_CheckLimits_CostInAnotherCurrency_Skipped() {
+	sess := &domain.Session{
+		Id: kit.NewId(),
+		Details: domain.SessionDetails{
+			Status:     domain.SessionStatusActive,
+			AuthMethod: domain.AuthMethodCommand,
+			AuthRef:    kit.NewId(),
+			Currency:   "RSD",
+			TotalCost:  &domain.Price{ExclVat: 500},
+			CdrToken:   &domain.CdrToken{Id: kit.NewId()},
+		},
+	}
+	cmd := &domain.Command{Details: domain.CommandDetails{StartSession: &domain.StartSession{CostLimit: kit.Float64Ptr(10), CostLimitCurrency: "EUR"}}}
+	s.cmdService.On("SearchCommands", s.Ctx, mock.Anything).Return(&domain.CommandSearchResponse{Items: []*domain.Command{cmd}}, nil)
+	tkn := &domain.Token{Details: domain.TokenDetails{SessionLimits: &domain.SessionLimits{Cost: kit.Float64Ptr(20), Currency: "EUR"}}}
+	s.tokenService.On("GetToken", s.Ctx, sess.Details.CdrToken.Id).Return(tkn, nil)
+	s.NoError(s.uc.checkLimits(s.Ctx, sess))
+	s.sessionService.AssertNotCalled(s.T(), "SetStopReason", s.Ctx, sess.Id, mock.Anything)
+}
+
+func (s *sessionUcTestSuite) Test_CheckLimits_NotExceeded() {
+	sess := &domain.Session{
+		Id: kit.NewId(),
+		Details: domain.SessionDetails{
+			Status:   domain.SessionStatusActive,
+			Kwh:      kit.Float64Ptr(10),
+			CdrToken: &domain.CdrToken{Id: kit.NewId()},
+		},
+	}
+	tkn := &domain.Token{Details: domain.TokenDetails{SessionLimits: &domain.SessionLimits{Kwh: kit.Float64Ptr(20)}}}
+	s.tokenService.On("GetToken", s.Ctx, sess.Details.CdrToken.Id).Return(tkn, nil)
+	s.NoError(s.uc.checkLimits(s.Ctx, sess))
+	s.sessionService.AssertNotCalled(s.T(), "SetStopReason", s.Ctx, sess.Id, mock.Anything)
+}
+
+func (s *sessionUcTestSuite) Test_CheckLimits_AlreadyStopped_Skipped() {
+	sess := &domain.Session{
+		Id: kit.NewId(),
+		Details: domain.SessionDetails{
+			Status:     domain.SessionStatusActive,
+			Kwh:        kit.Float64Ptr(10),
+			CdrToken:   &domain.CdrToken{Id: kit.NewId()},
+			StopReason: &domain.SessionStopReason{Limit: domain.SessionLimitKwh},
+		},
+	}
+	s.NoError(s.uc.checkLimits(s.Ctx, sess))
+	s.tokenService.AssertNotCalled(s.T(), "GetToken", s.Ctx, sess.Details.CdrToken.Id)
+}
+
+func (s *sessionUcTestSuite) Test_MinLimit() {
+	s.Nil(minLimit(nil, nil))
+	s.Equal(1.0, *minLimit(kit.Float64Ptr(1), nil))
+	s.Equal(1.0, *minLimit(nil, kit.Float64Ptr(1)))
+	s.Equal(1.0, *minLimit(kit.Float64Ptr(2), kit.Float64Ptr(1)))
+}
+
+func (s *sessionUcTestSuite) Test_TokenBlocked() {
+	s.False(tokenBlocked(nil))
+	s.False(tokenBlocked(&domain.Token{Details: domain.TokenDetails{Valid: kit.BoolPtr(true), WhiteList: domain.TokenWLTypeAllowed}}))
+	s.True(tokenBlocked(&domain.Token{Details: domain.TokenDetails{Valid: kit.BoolPtr(false), WhiteList: domain.TokenWLTypeAllowed}}))
+	s.True(tokenBlocked(&domain.Token{Details: domain.TokenDetails{Valid: kit.BoolPtr(true), WhiteList: domain.TokenWLTypeNever}}))
+}
+
+func (s *sessionUcTestSuite) Test_Init_InvalidTokenBlockPolicy() {
+	uc := &sessionUc{tokenBlockPolicy: TokenBlockPolicyNotify}
+	s.AssertAppErr(uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Local: &ocpi.CfgOcpiLocal{TokenBlock: &ocpi.CfgTokenBlock{Policy: "invalid"}}}), errors.ErrCodeSessTokenBlockPolicyInvalid)
+	s.NoError(uc.Init(s.Ctx, &ocpi.CfgOcpiConfig{Local: &ocpi.CfgOcpiLocal{TokenBlock: &ocpi.CfgTokenBlock{Policy: TokenBlockPolicyStop}}}))
+	s.Equal(TokenBlockPolicyStop, uc.tokenBlockPolicy)
+}
+
+func (s *sessionUcTestSuite) Test_OnTokenBlocked_RemoteToken_BackendNotified() {
+	s.localPlatformService.On("GetPlatformId", mock.Anything).Return("local")
+	tkn := &domain.Token{Id: kit.NewId(), OcpiItem: domain.OcpiItem{PlatformId: "remote", ExtId: domain.PartyExtId{PartyId: "AAA", CountryCode: "RS"}}}
+	sess, next := &domain.Session{Id: kit.NewId(), OcpiItem: domain.OcpiItem{PlatformId: "local"}}, &domain.Session{Id: kit.NewId(), OcpiItem: domain.OcpiItem{PlatformId: "local"}}
+	cursor := kit.NewRandString()
+	s.sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool {
+		return cr.TokenId == tkn.Id && *cr.TokenExtId == tkn.ExtId && cr.Cursor == nil
+	})).Return(&domain.SessionSearchResponse{Items: []*domain.Session{sess}, PageResponse: domain.PageResponse{NextPage: &domain.PageRequest{Cursor: &cursor}}}, nil)
+	s.sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool {
+		return cr.TokenId == tkn.Id && *cr.TokenExtId == tkn.ExtId && cr.Cursor != nil
+	})).Return(&domain.SessionSearchResponse{Items: []*domain.Session{next}}, nil)
+	var tb *backend.TokenBlocked
+	s.webhook.On("OnTokenBlocked", s.Ctx, mock.MatchedBy(func(b *backend.TokenBlocked) bool { return b.Token.Id == tkn.Id })).
+		Run(func(args mock.Arguments) { tb = args.Get(1).(*backend.TokenBlocked) }).
+		Return(nil)
+	s.uc.tokenBlockPolicy = TokenBlockPolicyStop
+	defer func() { s.uc.tokenBlockPolicy = TokenBlockPolicyNotify }()
+	s.NoError(s.uc.OnTokenBlocked(s.Ctx, tkn))
+	s.NotEmpty(tb)
+	s.True(tb.Stop)
+	// sessions of all pages are notified
+	s.Len(tb.Sessions, 2)
+	s.Equal(sess.Id, tb.Sessions[0].Id)
+	s.Equal(next.Id, tb.Sessions[1].Id)
+}
+
+func (s *sessionUcTestSuite) Test_OnTokenBlocked_RemoteToken_PolicyNone() {
+	s.localPlatformService.On("GetPlatformId", mock.Anything).Return("local")
+	tkn := &domain.Token{Id: kit.NewId(), OcpiItem: domain.OcpiItem{PlatformId: "remote"}}
+	s.uc.tokenBlockPolicy = TokenBlockPolicyNone
+	defer func() { s.uc.tokenBlockPolicy = TokenBlockPolicyNotify }()
+	s.NoError(s.uc.OnTokenBlocked(s.Ctx, tkn))
+	s.sessionService.AssertNotCalled(s.T(), "SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool {
+		return cr.TokenId == tkn.Id
+	}))
+}
+
+func (s *sessionUcTestSuite) Test_OnTokenBlocked_LocalToken_StopRequested() {
+	s.localPlatformService.On("GetPlatformId", mock.Anything).Return("local")
+	tkn := &domain.Token{Id: kit.NewId(), OcpiItem: domain.OcpiItem{PlatformId: "local"}}
+	sess := &domain.Session{Id: kit.NewId(), OcpiItem: domain.OcpiItem{PlatformId: "remote"}}
+	s.sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool {
+		return cr.TokenId == tkn.Id && len(cr.ExcPlatforms) > 0
+	})).Return(&domain.SessionSearchResponse{Items: []*domain.Session{sess}}, nil)
+	s.commandUc.On("OnLocalStopSession", s.Ctx, mock.MatchedBy(func(c *domain.Command) bool {
+		return c.Details.StopSession.SessionId == sess.Id
+	})).Return(nil)
+	s.NoError(s.uc.OnTokenBlocked(s.Ctx, tkn))
+	s.commandUc.AssertCalled(s.T(), "OnLocalStopSession", s.Ctx, mock.MatchedBy(func(c *domain.Command) bool {
+		return c.Details.StopSession.SessionId == sess.Id && c.Cmd == domain.CmdStopSession
+	}))
+}
+
+func (s *sessionUcTestSuite) Test_SessionMonitorCronHandler_Disabled() {
+	sessionService := &mocks.SessionService{}
+	uc := NewSessionUc(s.platformService, sessionService, s.remoteSessionRep, s.partyService, s.webhook, s.cmdService, s.localPlatformService, s.tokenService, s.reservationUc, s.commandUc, nil).(*sessionUc)
+	uc.SessionMonitorCronHandler(s.Ctx)
+	sessionService.AssertNotCalled(s.T(), "SearchSessions", mock.Anything, mock.Anything)
+}
+
+func (s *sessionUcTestSuite) Test_SessionMonitorCronHandler_LocalSessionsNotified() {
+	sessionService := &mocks.SessionService{}
+	webhook := &mocks.WebhookCallService{}
+	localPlatformService := &mocks.LocalPlatformService{}
+	uc := NewSessionUc(s.platformService, sessionService, s.remoteSessionRep, s.partyService, webhook, s.cmdService, localPlatformService, s.tokenService, s.reservationUc, s.commandUc, nil).(*sessionUc)
+	uc.monitorCfg = &ocpi.CfgSessionMonitor{Enabled: true, StaleHours: 1, CdrHours: 2}
+
+	localPlatformService.On("GetPlatformId", mock.Anything).Return("local")
+	stale := &domain.Session{Id: kit.NewId(), OcpiItem: domain.OcpiItem{PlatformId: "local"}, Details: domain.SessionDetails{Status: domain.SessionStatusActive}}
+	noCdr := &domain.Session{Id: kit.NewId(), OcpiItem: domain.OcpiItem{PlatformId: "local"}, Details: domain.SessionDetails{Status: domain.SessionStatusCompleted}}
+	sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool {
+		return !cr.WithoutCdr && cr.DateFrom != nil && cr.DateTo != nil && cr.DateTo.Sub(*cr.DateFrom) == sessMonitorPeriod
+	})).Return(&domain.SessionSearchResponse{Items: []*domain.Session{stale}}, nil)
+	sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool {
+		return cr.WithoutCdr && cr.DateFrom != nil
+	})).Return(&domain.SessionSearchResponse{Items: []*domain.Session{noCdr}}, nil)
+	webhook.On("OnSessionStale", s.Ctx, mock.Anything).Return(nil)
+	webhook.On("OnCdrMissing", s.Ctx, mock.Anything).Return(nil)
+
+	uc.SessionMonitorCronHandler(s.Ctx)
+	webhook.AssertCalled(s.T(), "OnSessionStale", s.Ctx, mock.MatchedBy(func(sess *backend.Session) bool { return sess.Id == stale.Id }))
+	webhook.AssertCalled(s.T(), "OnCdrMissing", s.Ctx, mock.MatchedBy(func(sess *backend.Session) bool { return sess.Id == noCdr.Id }))
+}
+
+func (s *sessionUcTestSuite) Test_SessionMonitorCronHandler_RemoteSessionPulled() {
+	sessionService := &mocks.SessionService{}
+	webhook := &mocks.WebhookCallService{}
+	localPlatformService := &mocks.LocalPlatformService{}
+	platformService := &mocks.PlatformService{}
+	remoteSessionRep := &mocks.RemoteSessionRepository{}
+	reservationUc := &mocks.ReservationUc{}
+	uc := NewSessionUc(platformService, sessionService, remoteSessionRep, s.partyService, webhook, s.cmdService, localPlatformService, s.tokenService, reservationUc, s.commandUc, nil).(*sessionUc)
+	uc.monitorCfg = &ocpi.CfgSessionMonitor{Enabled: true}
+
+	localPlatformService.On("GetPlatformId", mock.Anything).Return("local")
+	localPlatformService.On("Get", mock.Anything).Return(&domain.Platform{Id: "local"}, nil)
+	platform := &domain.Platform{Id: "remote", Remote: true, Status: domain.ConnectionStatusConnected, VersionInfo: domain.VersionInfo{Current: "2.2.1"}}
+	platformService.On("Get", s.Ctx, platform.Id).Return(platform, nil)
+	platformService.On("RoleEndpoint", s.Ctx, platform, model.ModuleIdSessions, model.OcpiSender).Return(domain.Endpoint("url"))
+
+	lastUpdated := kit.Now().Add(-time.Hour * 25)
+	stale := &domain.Session{Id: kit.NewId(), OcpiItem: domain.OcpiItem{PlatformId: platform.Id, LastUpdated: lastUpdated}, Details: domain.SessionDetails{Status: domain.SessionStatusActive}}
+	sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool { return !cr.WithoutCdr && cr.Ids == nil })).
+		Return(&domain.SessionSearchResponse{Items: []*domain.Session{stale}}, nil)
+	sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool { return cr.WithoutCdr })).
+		Return(&domain.SessionSearchResponse{}, nil)
+
+	// pulled session is completed
+	remoteSessionRep.On("GetSession", s.Ctx, mock.MatchedBy(func(rq *usecase.OcpiRepositoryIdRequest) bool { return rq.Id == stale.Id })).
+		Return(&model.OcpiSession{Id: stale.Id, Status: domain.SessionStatusCompleted, LastUpdated: kit.Now()}, nil)
+	sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool { return len(cr.Ids) > 0 })).
+		Return(&domain.SessionSearchResponse{}, nil)
+	updated := &domain.Session{Id: stale.Id, OcpiItem: domain.OcpiItem{PlatformId: platform.Id, LastUpdated: kit.Now()}, Details: domain.SessionDetails{Status: domain.SessionStatusCompleted}}
+	sessionService.On("PutSessions", s.Ctx, mock.Anything).Return([]*domain.Session{updated}, nil, nil)
+	sessionService.On("GetSession", s.Ctx, stale.Id).Return(updated, nil)
+	reservationUc.On("OnSessionChanged", s.Ctx, updated).Return(nil)
+	webhook.On("OnSessionsChanged", s.Ctx, mock.Anything).Return(nil)
+
+	uc.SessionMonitorCronHandler(s.Ctx)
+	remoteSessionRep.AssertNumberOfCalls(s.T(), "GetSession", 1)
+	webhook.AssertNotCalled(s.T(), "OnSessionStale", mock.Anything, mock.Anything)
+}
+
+func (s *sessionUcTestSuite) Test_GetSessionIssues_GroupedByPartner() {
+	sessionService := &mocks.SessionService{}
+	uc := NewSessionUc(s.platformService, sessionService, s.remoteSessionRep, s.partyService, s.webhook, s.cmdService, s.localPlatformService, s.tokenService, s.reservationUc, s.commandUc, nil).(*sessionUc)
+
+	p1 := domain.OcpiItem{PlatformId: "p1", ExtId: domain.PartyExtId{PartyId: "AAA", CountryCode: "RS"}}
+	p2 := domain.OcpiItem{PlatformId: "p2", ExtId: domain.PartyExtId{PartyId: "BBB", CountryCode: "RS"}}
+	sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool { return !cr.WithoutCdr && cr.DateFrom == nil })).
+		Return(&domain.SessionSearchResponse{Items: []*domain.Session{{Id: "s1", OcpiItem: p1}, {Id: "s2", OcpiItem: p2}}}, nil)
+	sessionService.On("SearchSessions", s.Ctx, mock.MatchedBy(func(cr *domain.SessionSearchCriteria) bool { return cr.WithoutCdr && cr.DateFrom == nil })).
+		Return(&domain.SessionSearchResponse{Items: []*domain.Session{{Id: "s3", OcpiItem: p1}}}, nil)
+
+	rs, err := uc.GetSessionIssues(s.Ctx)
+	s.NoError(err)
+	s.Len(rs, 2)
+	s.Equal("p1", rs[0].PlatformId)
+	s.Equal([]string{"s1"}, rs[0].Stale)
+	s.Equal([]string{"s3"}, rs[0].MissingCdr)
+	s.Equal("p2", rs[1].PlatformId)
+	s.Equal([]string{"s2"}, rs[1].Stale)
+	s.Empty(rs[1].MissingCdr)
+}
@@ -0,0 +1,94 @@
+package impl
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/kit"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+)
+
+const (
+	TokenBlockPolicyNone   = "none"   // TokenBlockPolicyNone active sessions of a blocked token are kept as is
+	TokenBlockPolicyNotify = "notify" // TokenBlockPolicyNotify backend is notified about active sessions of a blocked token
+	TokenBlockPolicyStop   = "stop"   // TokenBlockPolicyStop backend is notified and requested to stop active sessions of a blocked token
+)
+
+// tokenBlocked checks if token isn't allowed to charge anymore
+func tokenBlocked(tkn *domain.Token) bool {
+	if tkn == nil {
+		return false
+	}
+	return (tkn.Details.Valid != nil && !*tkn.Details.Valid) || tkn.Details.WhiteList == domain.TokenWLTypeNever
+}
+
+func (s *sessionUc) OnTokenBlocked(ctx context.Context, tkn *domain.Token) error {
+	l := s.l().C(ctx).Mth("on-tkn-blocked").F(kit.KV{"tknId": tkn.Id}).Dbg()
+
+	localPlatformId := s.localPlatformService.GetPlatformId(ctx)
+
+	// token of the local platform is blocked by eMSP, sessions of remote CPOs are requested to stop
+	if tkn.PlatformId == localPlatformId {
+		sessions, err := s.searchActiveSessions(ctx, tkn, &domain.SessionSearchCriteria{ExcPlatforms: []string{localPlatformId}})
+		if err != nil {
+			return err
+		}
+		for _, sess := range sessions {
+			cmd := &domain.Command{
+				Id: kit.NewId(),
+				OcpiItem: domain.OcpiItem{
+					ExtId:       tkn.ExtId,
+					PlatformId:  localPlatformId,
+					LastUpdated: kit.Now(),
+				},
+				Cmd: domain.CmdStopSession,
+				Details: domain.CommandDetails{
+					StopSession: &domain.StopSession{SessionId: sess.Id},
+				},
+			}
+			if err := s.commandUc.OnLocalStopSession(ctx, cmd); err != nil {
+				l.F(kit.KV{"sessId": sess.Id}).E(err).St().Err("stop session")
+			}
+		}
+		return nil
+	}
+
+	// token of a remote eMSP is blocked, local sessions are handled by backend according to the policy
+	if s.tokenBlockPolicy == TokenBlockPolicyNone {
+		return nil
+	}
+	sessions, err := s.searchActiveSessions(ctx, tkn, &domain.SessionSearchCriteria{IncPlatforms: []string{localPlatformId}})
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	// call webhook
+	return s.webhook.OnTokenBlocked(ctx, &backend.TokenBlocked{
+		Token:    s.tokenConverter.TokenDomainToBackend(tkn),
+		Sessions: s.converter.SessionsDomainToBackend(sessions),
+		Stop:     s.tokenBlockPolicy == TokenBlockPolicyStop,
+	})
+}
+
+// searchActiveSessions retrieves all active sessions started with the token
+// token id is unique only within the party, so sessions are searched by the token party as well
+func (s *sessionUc) searchActiveSessions(ctx context.Context, tkn *domain.Token, cr *domain.SessionSearchCriteria) ([]*domain.Session, error) {
+	cr.PageRequest = domain.PageRequest{Limit: kit.IntPtr(domain.PageSizeMaxLimit)}
+	cr.TokenId = tkn.Id
+	cr.TokenExtId = &tkn.ExtId
+	cr.Statuses = []string{domain.SessionStatusActive}
+	var sessions []*domain.Session
+	for {
+		rs, err := s.sessionService.SearchSessions(ctx, cr)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, rs.Items...)
+		if rs.NextPage == nil {
+			return sessions, nil
+		}
+		cr.PageRequest = *rs.NextPage
+	}
+}
@@ -120,6 +120,19 @@ func (t *tariffUc) OnRemoteTariffsPull(ctx context.Context, from, to *time.Time)
 	return t.remoteTariffsPull(ctx, from, to, platforms)
 }
 
+func (t *tariffUc) OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error {
+	// get platforms to pull from
+	platforms, err := t.getPlatformsToPull(ctx)
+	if err != nil {
+		return err
+	}
+
+	// retrieve only the requested platform
+	platforms = kit.Filter(platforms, func(p *domain.Platform) bool { return p.Id == platformId })
+
+	return t.remoteTariffsPull(ctx, from, to, platforms)
+}
+
 func (t *tariffUc) OnRemoteTariffsPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error {
 	// get platforms to pull from
 	platforms, err := t.getPlatformsToPull(ctx)
@@ -199,6 +212,77 @@ func (t *tariffUc) modifyTariff(ctx context.Context, platformId string, trf *mod
 	return err
 }
 
+// putRemoteTariffs puts tariffs pulled from the remote platform in a single batch
+// items which cannot be put are logged and skipped
+func (t *tariffUc) putRemoteTariffs(ctx context.Context, platformId string, trfs []*model.OcpiTariff) error {
+	l := t.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "count": len(trfs)}).Dbg()
+
+	// get and check platform
+	_, err := t.getConnectedPlatform(ctx, platformId)
+	if err != nil {
+		return err
+	}
+
+	// check tariffs are of the remote platform
+	stored, err := t.tariffService.SearchTariffs(ctx, &domain.TariffSearchCriteria{
+		PageRequest:  domain.PageRequest{Limit: kit.IntPtr(len(trfs))},
+		Ids:          kit.Select(trfs, func(trf *model.OcpiTariff) string { return trf.Id }),
+		IncPlatforms: []string{t.localPlatform.GetPlatformId(ctx)},
+	})
+	if err != nil {
+		return err
+	}
+	local := make(map[string]struct{}, len(stored.Items))
+	for _, trf := range stored.Items {
+		local[trf.Id] = struct{}{}
+	}
+
+	// get or create parties
+	parties := t.getCreateParties(ctx, platformId, kit.Select(trfs, func(trf *model.OcpiTariff) domain.PartyExtId {
+		return domain.PartyExtId{PartyId: trf.PartyId, CountryCode: trf.CountryCode}
+	}))
+
+	logErr := func(id string, err error) {
+		t.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "trfId": id}).E(err).St().Err()
+	}
+
+	trfDoms := make([]*domain.Tariff, 0, len(trfs))
+	for _, trf := range trfs {
+		if _, ok := local[trf.Id]; ok {
+			logErr(trf.Id, errors.ErrTrfNotBelongRemotePlatform(ctx))
+			continue
+		}
+		if err := parties[domain.PartyExtId{PartyId: trf.PartyId, CountryCode: trf.CountryCode}]; err != nil {
+			logErr(trf.Id, err)
+			continue
+		}
+		trfDoms = append(trfDoms, t.converter.TariffModelToDomain(trf, platformId))
+	}
+	if len(trfDoms) == 0 {
+		return nil
+	}
+
+	// put tariffs to local platform
+	applied, results, err := t.tariffService.PutTariffs(ctx, trfDoms)
+	if err != nil {
+		return err
+	}
+	for _, rs := range results {
+		if rs.Err != nil {
+			logErr(rs.Id, rs.Err)
+		}
+	}
+
+	// no changes applied
+	if len(applied) == 0 {
+		l.Warn("no changes applied")
+		return nil
+	}
+
+	// call webhook
+	return t.webhook.OnTariffsChanged(ctx, t.converter.TariffsDomainToBackend(applied)...)
+}
+
 func (t *tariffUc) remoteTariffsPull(ctx context.Context, from, to *time.Time, platforms []*domain.Platform) error {
 	l := t.l().C(ctx).Mth("remote-pull").Dbg()
 
@@ -218,11 +302,11 @@ func (t *tariffUc) remoteTariffsPull(ctx context.Context, from, to *time.Time, p
 	for i := 0; i < trfWorkersNum; i++ {
 		goroutine.New().WithLogger(l).Go(ctx, func() {
 			for v := range ch {
-				trf := v.data.(*model.OcpiTariff)
-				err := t.OnRemoteTariffPut(ctx, v.platformId, trf)
+				trfs := v.data.([]*model.OcpiTariff)
+				err := t.putRemoteTariffs(ctx, v.platformId, trfs)
 				if err != nil {
 					t.l().C(ctx).Mth("remote-put").
-						F(kit.KV{"platformId": v.platformId, "trfId": trf.Id}).
+						F(kit.KV{"platformId": v.platformId, "count": len(trfs)}).
 						E(err).St().Err()
 				}
 			}
@@ -240,9 +324,20 @@ func (t *tariffUc) remoteTariffsPull(ctx context.Context, from, to *time.Time, p
 			continue
 		}
 		eg.Go(func() error {
+			// objects of some versions come without party attributes
+			party, err := t.getVersionParty(ctx, platform)
+			if err != nil {
+				return err
+			}
 			for tariffs := range pgReader.GetPage(ctx, buildOcpiRepositoryRequest(ep, t.tokenC(platform), localPlatform, platform), trfPageSize, from, to) {
 				for _, trf := range tariffs {
-					ch <- channelData{platformId: platform.Id, data: trf}
+					if party != nil {
+						trf.OcpiPartyId = *party
+					}
+				}
+				// pages are put in batches by workers
+				for _, batch := range chunks(tariffs, domain.PageSizeMaxLimit) {
+					ch <- channelData{platformId: platform.Id, data: batch}
 				}
 			}
 			return nil
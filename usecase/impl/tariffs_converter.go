@@ -25,18 +25,20 @@ func (t *tariffConverter) TariffDomainToModel(trf *domain.Tariff) *model.OcpiTar
 			PartyId:     trf.OcpiItem.ExtId.PartyId,
 			CountryCode: trf.OcpiItem.ExtId.CountryCode,
 		},
-		Id:            trf.Id,
-		Currency:      trf.Details.Currency,
-		Type:          trf.Details.Type,
-		TariffAltText: t.displayTextsDomainToModel(trf.Details.TariffAltText),
-		TariffAltUrl:  trf.Details.TariffAltUrl,
-		MinPrice:      t.priceDomainToModel(trf.Details.MinPrice),
-		MaxPrice:      t.priceDomainToModel(trf.Details.MaxPrice),
-		Elements:      t.elementsDomainToModel(trf.Details.Elements),
-		StartDateTime: trf.Details.StartDateTime,
-		EndDateTime:   trf.Details.EndDateTime,
-		EnergyMix:     t.energyMixDomainToModel(trf.Details.EnergyMix),
-		LastUpdated:   trf.LastUpdated,
+		Id:                 trf.Id,
+		Currency:           trf.Details.Currency,
+		Type:               trf.Details.Type,
+		TariffAltText:      t.displayTextsDomainToModel(trf.Details.TariffAltText),
+		TariffAltUrl:       trf.Details.TariffAltUrl,
+		MinPrice:           t.priceDomainToModel(trf.Details.MinPrice),
+		MaxPrice:           t.priceDomainToModel(trf.Details.MaxPrice),
+		Elements:           t.elementsDomainToModel(trf.Details.Elements),
+		StartDateTime:      trf.Details.StartDateTime,
+		EndDateTime:        trf.Details.EndDateTime,
+		EnergyMix:          t.energyMixDomainToModel(trf.Details.EnergyMix),
+		PreauthorizeAmount: t.priceDomainToModel(trf.Details.PreauthorizeAmount),
+		TaxIncluded:        trf.Details.TaxIncluded,
+		LastUpdated:        trf.LastUpdated,
 	}
 }
 
@@ -59,16 +61,18 @@ func (t *tariffConverter) TariffModelToDomain(trf *model.OcpiTariff, platformId
 		},
 		Id: trf.Id,
 		Details: domain.TariffDetails{
-			Currency:      trf.Currency,
-			Type:          trf.Type,
-			TariffAltText: t.displayTextsModelToDomain(trf.TariffAltText),
-			TariffAltUrl:  trf.TariffAltUrl,
-			MinPrice:      t.priceModelToDomain(trf.MinPrice),
-			MaxPrice:      t.priceModelToDomain(trf.MaxPrice),
-			Elements:      t.elementsModelToDomain(trf.Elements),
-			StartDateTime: trf.StartDateTime,
-			EndDateTime:   trf.EndDateTime,
-			EnergyMix:     t.energyMixModelToDomain(trf.EnergyMix),
+			Currency:           trf.Currency,
+			Type:               trf.Type,
+			TariffAltText:      t.displayTextsModelToDomain(trf.TariffAltText),
+			TariffAltUrl:       trf.TariffAltUrl,
+			MinPrice:           t.priceModelToDomain(trf.MinPrice),
+			MaxPrice:           t.priceModelToDomain(trf.MaxPrice),
+			Elements:           t.elementsModelToDomain(trf.Elements),
+			StartDateTime:      trf.StartDateTime,
+			EndDateTime:        trf.EndDateTime,
+			EnergyMix:          t.energyMixModelToDomain(trf.EnergyMix),
+			PreauthorizeAmount: t.priceModelToDomain(trf.PreauthorizeAmount),
+			TaxIncluded:        trf.TaxIncluded,
 		},
 	}
 }
@@ -86,22 +90,24 @@ func (t *tariffConverter) TariffDomainToBackend(trf *domain.Tariff) *backend.Tar
 		return nil
 	}
 	return &backend.Tariff{
-		Id:            trf.Id,
-		Currency:      trf.Details.Currency,
-		Type:          trf.Details.Type,
-		TariffAltText: t.displayTextsDomainToBackend(trf.Details.TariffAltText),
-		TariffAltUrl:  trf.Details.TariffAltUrl,
-		MinPrice:      t.priceDomainToBackend(trf.Details.MinPrice),
-		MaxPrice:      t.priceDomainToBackend(trf.Details.MaxPrice),
-		Elements:      t.elementsDomainToBackend(trf.Details.Elements),
-		StartDateTime: trf.Details.StartDateTime,
-		EndDateTime:   trf.Details.EndDateTime,
-		EnergyMix:     t.energyMixDomainToBackend(trf.Details.EnergyMix),
-		LastUpdated:   trf.LastUpdated,
-		PlatformId:    trf.PlatformId,
-		RefId:         trf.RefId,
-		PartyId:       trf.OcpiItem.ExtId.PartyId,
-		CountryCode:   trf.OcpiItem.ExtId.CountryCode,
+		Id:                 trf.Id,
+		Currency:           trf.Details.Currency,
+		Type:               trf.Details.Type,
+		TariffAltText:      t.displayTextsDomainToBackend(trf.Details.TariffAltText),
+		TariffAltUrl:       trf.Details.TariffAltUrl,
+		MinPrice:           t.priceDomainToBackend(trf.Details.MinPrice),
+		MaxPrice:           t.priceDomainToBackend(trf.Details.MaxPrice),
+		Elements:           t.elementsDomainToBackend(trf.Details.Elements),
+		StartDateTime:      trf.Details.StartDateTime,
+		EndDateTime:        trf.Details.EndDateTime,
+		EnergyMix:          t.energyMixDomainToBackend(trf.Details.EnergyMix),
+		PreauthorizeAmount: t.priceDomainToBackend(trf.Details.PreauthorizeAmount),
+		TaxIncluded:        trf.Details.TaxIncluded,
+		LastUpdated:        trf.LastUpdated,
+		PlatformId:         trf.PlatformId,
+		RefId:              trf.RefId,
+		PartyId:            trf.OcpiItem.ExtId.PartyId,
+		CountryCode:        trf.OcpiItem.ExtId.CountryCode,
 	}
 }
 
@@ -125,16 +131,18 @@ func (t *tariffConverter) TariffBackendToDomain(trf *backend.Tariff) *domain.Tar
 		},
 		Id: trf.Id,
 		Details: domain.TariffDetails{
-			Currency:      trf.Currency,
-			Type:          trf.Type,
-			TariffAltText: t.displayTextsBackendToDomain(trf.TariffAltText),
-			TariffAltUrl:  trf.TariffAltUrl,
-			MinPrice:      t.priceBackendToDomain(trf.MinPrice),
-			MaxPrice:      t.priceBackendToDomain(trf.MaxPrice),
-			Elements:      t.elementsBackendToDomain(trf.Elements),
-			StartDateTime: trf.StartDateTime,
-			EndDateTime:   trf.EndDateTime,
-			EnergyMix:     t.energyMixBackendToDomain(trf.EnergyMix),
+			Currency:           trf.Currency,
+			Type:               trf.Type,
+			TariffAltText:      t.displayTextsBackendToDomain(trf.TariffAltText),
+			TariffAltUrl:       trf.TariffAltUrl,
+			MinPrice:           t.priceBackendToDomain(trf.MinPrice),
+			MaxPrice:           t.priceBackendToDomain(trf.MaxPrice),
+			Elements:           t.elementsBackendToDomain(trf.Elements),
+			StartDateTime:      trf.StartDateTime,
+			EndDateTime:        trf.EndDateTime,
+			EnergyMix:          t.energyMixBackendToDomain(trf.EnergyMix),
+			PreauthorizeAmount: t.priceBackendToDomain(trf.PreauthorizeAmount),
+			TaxIncluded:        trf.TaxIncluded,
 		},
 	}
 }
@@ -26,6 +26,7 @@ type tokenUc struct {
 	partyService   domain.PartyService
 	webhook        backend.WebhookCallService
 	converter      usecase.TokenConverter
+	sessionUc      usecase.SessionUc
 }
 
 func NewTokenUc(platformService domain.PlatformService, tokenService domain.TokenService, remoteTokenRep usecase.RemoteTokenRepository,
@@ -45,6 +46,10 @@ func (t *tokenUc) l() kit.CLogger {
 	return ocpi.L().Cmp("tkn-uc")
 }
 
+func (t *tokenUc) SetSessionUc(sessionUc usecase.SessionUc) {
+	t.sessionUc = sessionUc
+}
+
 func (t *tokenUc) OnLocalTokenChanged(ctx context.Context, tkn *domain.Token) error {
 	l := t.l().C(ctx).Mth("on-tkn-changed-loc").F(kit.KV{"tknId": tkn.Id}).Dbg()
 
@@ -74,6 +79,9 @@ func (t *tokenUc) OnLocalTokenChanged(ctx context.Context, tkn *domain.Token) er
 		return nil
 	}
 
+	// stop active sessions of the blocked token
+	t.onBlocked(ctx, stored, tkn)
+
 	// get platforms to push token
 	platforms, err := t.getPlatformsToPush(ctx, tkn.PlatformId)
 	if err != nil {
@@ -111,6 +119,19 @@ func (t *tokenUc) OnRemoteTokensPull(ctx context.Context, from, to *time.Time) e
 	return t.remoteTokensPull(ctx, from, to, platforms)
 }
 
+func (t *tokenUc) OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error {
+	// get platforms to pull from
+	platforms, err := t.getPlatformsToPull(ctx)
+	if err != nil {
+		return err
+	}
+
+	// retrieve only the requested platform
+	platforms = kit.Filter(platforms, func(p *domain.Platform) bool { return p.Id == platformId })
+
+	return t.remoteTokensPull(ctx, from, to, platforms)
+}
+
 func (t *tokenUc) OnRemoteTokensPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error {
 	// get platforms to pull from
 	platforms, err := t.getPlatformsToPull(ctx)
@@ -166,6 +187,16 @@ func (t *tokenUc) GetOrCreateLocalToken(ctx context.Context, tkn *domain.Token)
 	})
 }
 
+// onBlocked handles active sessions if the token has just been blocked, failure doesn't break processing of the token
+func (t *tokenUc) onBlocked(ctx context.Context, stored, tkn *domain.Token) {
+	if t.sessionUc == nil || !tokenBlocked(tkn) || tokenBlocked(stored) {
+		return
+	}
+	if err := t.sessionUc.OnTokenBlocked(ctx, tkn); err != nil {
+		t.l().C(ctx).Mth("on-blocked").F(kit.KV{"tknId": tkn.Id}).E(err).St().Err()
+	}
+}
+
 func (t *tokenUc) getPlatformsToPush(ctx context.Context, originalPlatformId string) ([]*domain.Platform, error) {
 	platforms, err := t.platformService.Search(ctx, &domain.PlatformSearchCriteria{
 		Statuses: []string{domain.ConnectionStatusConnected}, // connected platforms
@@ -227,11 +258,94 @@ func (t *tokenUc) modifyToken(ctx context.Context, platformId string, tkn *model
 		return nil
 	}
 
+	// handle active sessions of the blocked token
+	t.onBlocked(ctx, stored, tknDom)
+
 	// call webhook
 	err = t.webhook.OnTokensChanged(ctx, t.converter.TokenDomainToBackend(tknDom))
 	return err
 }
 
+// putRemoteTokens puts tokens pulled from the remote platform in a single batch
+// items which cannot be put are logged and skipped
+func (t *tokenUc) putRemoteTokens(ctx context.Context, platformId string, tkns []*model.OcpiToken) error {
+	l := t.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "count": len(tkns)}).Dbg()
+
+	// get and check platform
+	_, err := t.getConnectedPlatform(ctx, platformId)
+	if err != nil {
+		return err
+	}
+
+	// check tokens are of the remote platform
+	stored, err := t.tokenService.SearchTokens(ctx, &domain.TokenSearchCriteria{
+		PageRequest: domain.PageRequest{Limit: kit.IntPtr(len(tkns))},
+		Ids:         kit.Select(tkns, func(tkn *model.OcpiToken) string { return tkn.Id }),
+	})
+	if err != nil {
+		return err
+	}
+	localPlatformId := t.localPlatform.GetPlatformId(ctx)
+	local := make(map[string]struct{}, len(stored.Items))
+	storedMap := make(map[string]*domain.Token, len(stored.Items))
+	for _, tkn := range stored.Items {
+		if tkn.PlatformId == localPlatformId {
+			local[tkn.Id] = struct{}{}
+		}
+		storedMap[tkn.Id] = tkn
+	}
+
+	// get or create parties
+	parties := t.getCreateParties(ctx, platformId, kit.Select(tkns, func(tkn *model.OcpiToken) domain.PartyExtId {
+		return domain.PartyExtId{PartyId: tkn.PartyId, CountryCode: tkn.CountryCode}
+	}))
+
+	logErr := func(id string, err error) {
+		t.l().C(ctx).Mth("remote-put").F(kit.KV{"platformId": platformId, "tknId": id}).E(err).St().Err()
+	}
+
+	tknDoms := make([]*domain.Token, 0, len(tkns))
+	for _, tkn := range tkns {
+		if _, ok := local[tkn.Id]; ok {
+			logErr(tkn.Id, errors.ErrTrfNotBelongRemotePlatform(ctx))
+			continue
+		}
+		if err := parties[domain.PartyExtId{PartyId: tkn.PartyId, CountryCode: tkn.CountryCode}]; err != nil {
+			logErr(tkn.Id, err)
+			continue
+		}
+		tknDoms = append(tknDoms, t.converter.TokenModelToDomain(tkn, platformId))
+	}
+	if len(tknDoms) == 0 {
+		return nil
+	}
+
+	// put tokens to local platform
+	applied, results, err := t.tokenService.PutTokens(ctx, tknDoms)
+	if err != nil {
+		return err
+	}
+	for _, rs := range results {
+		if rs.Err != nil {
+			logErr(rs.Id, rs.Err)
+		}
+	}
+
+	// no changes applied
+	if len(applied) == 0 {
+		l.Warn("no changes applied")
+		return nil
+	}
+
+	// handle active sessions of the blocked tokens
+	for _, tkn := range applied {
+		t.onBlocked(ctx, storedMap[tkn.Id], tkn)
+	}
+
+	// call webhook
+	return t.webhook.OnTokensChanged(ctx, t.converter.TokensDomainToBackend(applied)...)
+}
+
 func (t *tokenUc) remoteTokensPull(ctx context.Context, from, to *time.Time, platforms []*domain.Platform) error {
 	l := t.l().C(ctx).Mth("remote-pull").Dbg()
 
@@ -251,11 +365,11 @@ func (t *tokenUc) remoteTokensPull(ctx context.Context, from, to *time.Time, pla
 	for i := 0; i < tknWorkersNum; i++ {
 		goroutine.New().WithLogger(l).Go(ctx, func() {
 			for v := range ch {
-				tkn := v.data.(*model.OcpiToken)
-				err := t.OnRemoteTokenPut(ctx, v.platformId, tkn)
+				tkns := v.data.([]*model.OcpiToken)
+				err := t.putRemoteTokens(ctx, v.platformId, tkns)
 				if err != nil {
 					t.l().C(ctx).Mth("remote-put").
-						F(kit.KV{"platformId": v.platformId, "tknId": tkn.Id}).
+						F(kit.KV{"platformId": v.platformId, "count": len(tkns)}).
 						E(err).St().Err()
 				}
 			}
@@ -273,9 +387,20 @@ func (t *tokenUc) remoteTokensPull(ctx context.Context, from, to *time.Time, pla
 			continue
 		}
 		eg.Go(func() error {
+			// objects of some versions come without party attributes
+			party, err := t.getVersionParty(ctx, platform)
+			if err != nil {
+				return err
+			}
 			for tokens := range pgReader.GetPage(ctx, buildOcpiRepositoryRequest(ep, t.tokenC(platform), localPlatform, platform), tknPageSize, from, to) {
 				for _, tkn := range tokens {
-					ch <- channelData{platformId: platform.Id, data: tkn}
+					if party != nil {
+						tkn.OcpiPartyId = *party
+					}
+				}
+				// pages are put in batches by workers
+				for _, batch := range chunks(tokens, domain.PageSizeMaxLimit) {
+					ch <- channelData{platformId: platform.Id, data: batch}
 				}
 			}
 			return nil
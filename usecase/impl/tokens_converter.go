@@ -114,6 +114,13 @@ func (t *tokenConverter) TokenDomainToBackend(tkn *domain.Token) *backend.Token
 			ContractId:   tkn.Details.EnergyContract.ContractId,
 		}
 	}
+	if tkn.Details.SessionLimits != nil {
+		r.SessionLimits = &backend.SessionLimits{
+			Kwh:      tkn.Details.SessionLimits.Kwh,
+			Cost:     tkn.Details.SessionLimits.Cost,
+			Currency: tkn.Details.SessionLimits.Currency,
+		}
+	}
 	return r
 }
 
@@ -158,5 +165,12 @@ func (t *tokenConverter) TokenBackendToDomain(tkn *backend.Token, platformId str
 			ContractId:   tkn.EnergyContract.ContractId,
 		}
 	}
+	if tkn.SessionLimits != nil {
+		r.Details.SessionLimits = &domain.SessionLimits{
+			Kwh:      tkn.SessionLimits.Kwh,
+			Cost:     tkn.SessionLimits.Cost,
+			Currency: tkn.SessionLimits.Currency,
+		}
+	}
 	return r
 }
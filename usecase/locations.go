@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/backend"
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/model"
@@ -21,6 +22,8 @@ type LocationConverter interface {
 	LocationsDomainToBackend(locs []*domain.Location) []*backend.Location
 	// LocationBackendToDomain converts location backend to domain
 	LocationBackendToDomain(loc *backend.Location, platformId string) *domain.Location
+	// LocationsBackendToDomain converts locations backend to domain
+	LocationsBackendToDomain(locs []*backend.Location, platformId string) []*domain.Location
 
 	// EvseDomainToModel converts evse domain to ocpi model
 	EvseDomainToModel(evse *domain.Evse) *model.OcpiEvse
@@ -34,6 +37,10 @@ type LocationConverter interface {
 	EvseBackendToDomain(e *backend.Evse, platformId, locId string) *domain.Evse
 	// EvsesDomainToBackend converts evses domain to backend
 	EvsesDomainToBackend(evses []*domain.Evse) []*backend.Evse
+	// EvseStatusesBackendToDomain converts evse statuses backend to domain
+	EvseStatusesBackendToDomain(statuses []*backend.EvseStatus) []*domain.Evse
+	// BulkResultsDomainToBackend converts bulk results domain to backend
+	BulkResultsDomainToBackend(rs []*domain.BulkItemResult) *backend.BulkResponse
 
 	// ConnectorDomainToModel converts connector domain to ocpi model
 	ConnectorDomainToModel(con *domain.Connector) *model.OcpiConnector
@@ -50,10 +57,18 @@ type LocationConverter interface {
 }
 
 type LocationUc interface {
+	// Init initializes location usecase
+	Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error
+	// Close flushes evse status changes waiting to be pushed to remote platforms
+	Close(ctx context.Context)
 	// OnLocalLocationChanged handles changing location in local platform
 	OnLocalLocationChanged(ctx context.Context, loc *domain.Location) error
+	// OnLocalLocationsChanged handles changing batch of locations in local platform, returns results in order of request
+	OnLocalLocationsChanged(ctx context.Context, locs []*domain.Location) ([]*domain.BulkItemResult, error)
 	// OnRemoteLocationsPull handles request to pull locations from remote platforms (fired by cron)
 	OnRemoteLocationsPull(ctx context.Context, from, to *time.Time) error
+	// OnRemotePlatformPull pulls locations from the particular remote platform (catch-up after platform is back online)
+	OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error
 	// OnRemoteLocationsPullWhenPushNotSupported handles request to pull locations from remote platforms which don't support push (fired by cron)
 	OnRemoteLocationsPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error
 	// OnRemoteLocationPut handles put location in remote platform
@@ -65,6 +80,9 @@ type LocationUc interface {
 	OnLocalEvseChanged(ctx context.Context, evse *domain.Evse) error
 	// OnLocalEvseStatusChanged handles changing status of evse in local platform
 	OnLocalEvseStatusChanged(ctx context.Context, locId, evseId, status string) error
+	// OnLocalEvseStatusesChanged handles changing statuses of batch of evses in local platform, returns results in order of request
+	// evses must be populated with location id, evse id and status
+	OnLocalEvseStatusesChanged(ctx context.Context, evses []*domain.Evse) ([]*domain.BulkItemResult, error)
 	// OnRemoteEvsePut handles put location in remote platform
 	OnRemoteEvsePut(ctx context.Context, platformId, locId, countryCode, partyId string, evse *model.OcpiEvse) error
 	// OnRemoteEvsePatch handles patch location in remote platform
@@ -81,6 +99,8 @@ type LocationUc interface {
 type RemoteLocationRepository interface {
 	// PutLocationAsync puts location
 	PutLocationAsync(ctx context.Context, rq *OcpiRepositoryErrHandlerRequestG[*model.OcpiLocation])
+	// PutLocation puts location
+	PutLocation(ctx context.Context, rq *OcpiRepositoryRequestG[*model.OcpiLocation]) error
 	// PatchLocationAsync patches location
 	PatchLocationAsync(ctx context.Context, rq *OcpiRepositoryErrHandlerRequestG[*model.OcpiLocation])
 	// GetLocations retrieves locations
@@ -89,12 +109,18 @@ type RemoteLocationRepository interface {
 	GetLocation(ctx context.Context, rq *OcpiRepositoryIdRequest) (*model.OcpiLocation, error)
 	// PutEvseAsync puts evse
 	PutEvseAsync(ctx context.Context, rq *OcpiRepositoryErrHandlerRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string)
+	// PutEvse puts evse
+	PutEvse(ctx context.Context, rq *OcpiRepositoryRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) error
 	// PatchEvseAsync patches evse
 	PatchEvseAsync(ctx context.Context, rq *OcpiRepositoryErrHandlerRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string)
+	// PatchEvse patches evse
+	PatchEvse(ctx context.Context, rq *OcpiRepositoryRequestG[*model.OcpiEvse], party *model.OcpiPartyId, locId string) error
 	// GetEvse retrieves evse
 	GetEvse(ctx context.Context, rq *OcpiRepositoryBaseRequest, locId, evseId string) (*model.OcpiEvse, error)
 	// PutConnectorAsync puts connector
 	PutConnectorAsync(ctx context.Context, rq *OcpiRepositoryErrHandlerRequestG[*model.OcpiConnector], party *model.OcpiPartyId, evseId, locId string)
+	// PutConnector puts connector
+	PutConnector(ctx context.Context, rq *OcpiRepositoryRequestG[*model.OcpiConnector], party *model.OcpiPartyId, locId, evseId string) error
 	// PatchConnectorAsync patches connector
 	PatchConnectorAsync(ctx context.Context, rq *OcpiRepositoryErrHandlerRequestG[*model.OcpiConnector], party *model.OcpiPartyId, evseId, locId string)
 	// GetConnector retrieves connector
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+	"github.com/mikhailbolshakov/ocpi/model"
+)
+
+type PaymentConverter interface {
+	// TerminalDomainToModel converts terminal domain to ocpi model
+	TerminalDomainToModel(t *domain.Terminal) *model.OcpiTerminal
+	// TerminalsDomainToModel converts terminals domain to ocpi model
+	TerminalsDomainToModel(ts []*domain.Terminal) []*model.OcpiTerminal
+	// TerminalModelToDomain converts terminal model to domain
+	TerminalModelToDomain(t *model.OcpiTerminal, platformId string) *domain.Terminal
+	// TerminalDomainToBackend converts terminal domain to backend
+	TerminalDomainToBackend(t *domain.Terminal) *backend.Terminal
+	// FinancialAdviceDomainToModel converts financial advice confirmation domain to ocpi model
+	FinancialAdviceDomainToModel(fa *domain.FinancialAdvice) *model.OcpiFinancialAdviceConfirmation
+	// FinancialAdvicesDomainToModel converts financial advice confirmations domain to ocpi model
+	FinancialAdvicesDomainToModel(fas []*domain.FinancialAdvice) []*model.OcpiFinancialAdviceConfirmation
+	// FinancialAdviceModelToDomain converts financial advice confirmation model to domain
+	FinancialAdviceModelToDomain(fa *model.OcpiFinancialAdviceConfirmation, platformId string) *domain.FinancialAdvice
+	// FinancialAdviceDomainToBackend converts financial advice confirmation domain to backend
+	FinancialAdviceDomainToBackend(fa *domain.FinancialAdvice) *backend.FinancialAdvice
+}
+
+type PaymentUc interface {
+	// OnRemoteTerminalPut handles put terminal in remote platform
+	OnRemoteTerminalPut(ctx context.Context, platformId string, t *model.OcpiTerminal) error
+	// OnRemoteTerminalPatch handles patch terminal in remote platform
+	OnRemoteTerminalPatch(ctx context.Context, platformId string, t *model.OcpiTerminal) error
+	// OnRemoteFinancialAdvicePost handles financial advice confirmation posted by remote platform
+	OnRemoteFinancialAdvicePost(ctx context.Context, platformId string, fa *model.OcpiFinancialAdviceConfirmation) error
+}
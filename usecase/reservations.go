@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"github.com/mikhailbolshakov/ocpi/backend"
+	"github.com/mikhailbolshakov/ocpi/domain"
+)
+
+type ReservationConverter interface {
+	// ReservationDomainToBackend converts reservation domain to backend
+	ReservationDomainToBackend(r *domain.ChargeReservation) *backend.ChargeReservation
+	// ReservationsDomainToBackend converts reservations domain to backend
+	ReservationsDomainToBackend(rs []*domain.ChargeReservation) []*backend.ChargeReservation
+}
+
+type ReservationUc interface {
+	// OnCommandResponse tracks reservation by the response to a reservation command
+	// reservation is activated when RESERVE_NOW is accepted and cancelled when CANCEL_RESERVATION is accepted
+	OnCommandResponse(ctx context.Context, cmd *domain.Command) error
+	// OnSessionChanged marks the active reservation as used when a session starts with the reserved token
+	OnSessionChanged(ctx context.Context, sess *domain.Session) error
+	// ExpireReservationsCronHandler expires active reservations
+	ExpireReservationsCronHandler(ctx context.Context)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"github.com/mikhailbolshakov/ocpi"
 	"github.com/mikhailbolshakov/ocpi/backend"
 	"github.com/mikhailbolshakov/ocpi/domain"
 	"github.com/mikhailbolshakov/ocpi/model"
@@ -21,23 +22,37 @@ type SessionConverter interface {
 	SessionsDomainToBackend(ts []*domain.Session) []*backend.Session
 	// SessionBackendToDomain converts session backend to domain
 	SessionBackendToDomain(sess *backend.Session, platformId string) *domain.Session
+	// SessionIssueReportsDomainToBackend converts session issue reports to backend
+	SessionIssueReportsDomainToBackend(rs []*domain.SessionIssueReport) []*backend.SessionIssueReport
 	// TokenToCdrTokenDomain converts token object to cdr token
 	TokenToCdrTokenDomain(tkn *domain.Token) *domain.CdrToken
 }
 
 type SessionUc interface {
+	// Init initializes session usecase
+	Init(ctx context.Context, cfg *ocpi.CfgOcpiConfig) error
 	// OnLocalSessionChanged handles changing session in local platform
 	OnLocalSessionChanged(ctx context.Context, sess *domain.Session) error
 	// OnLocalSessionPatched handles patching session
 	OnLocalSessionPatched(ctx context.Context, sess *domain.Session) error
 	// OnRemoteSessionsPull handles request to pull sessions from remote platforms (fired by cron)
 	OnRemoteSessionsPull(ctx context.Context, from, to *time.Time) error
+	// OnRemotePlatformPull pulls sessions from the particular remote platform (catch-up after platform is back online)
+	OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error
 	// OnRemoteSessionsPullWhenPushNotSupported handles request to pull sessions from remote platforms which don't support push (fired by cron)
 	OnRemoteSessionsPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error
 	// OnRemoteSessionPut handles put session in remote platform
 	OnRemoteSessionPut(ctx context.Context, platformId string, sess *model.OcpiSession) error
 	// OnRemoteSessionPatch handles patch session in remote platform
 	OnRemoteSessionPatch(ctx context.Context, platformId string, sess *model.OcpiSession) error
+	// OnTokenBlocked handles active sessions of the blocked token
+	// for a token of the local platform, sessions are requested to stop on remote platforms
+	// for a token of a remote platform, local sessions are handled by backend according to the configured policy
+	OnTokenBlocked(ctx context.Context, tkn *domain.Token) error
+	// SessionMonitorCronHandler detects stale sessions and sessions with missing CDR, tries to pull them and notifies backend (fired by cron)
+	SessionMonitorCronHandler(ctx context.Context)
+	// GetSessionIssues retrieves stale sessions and sessions with missing CDR grouped by partner
+	GetSessionIssues(ctx context.Context) ([]*domain.SessionIssueReport, error)
 }
 
 type RemoteSessionRepository interface {
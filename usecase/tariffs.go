@@ -32,6 +32,8 @@ type TariffUc interface {
 	OnLocalTariffChanged(ctx context.Context, trf *domain.Tariff) error
 	// OnRemoteTariffsPull handles request to pull tariffs from remote platforms (fired by cron)
 	OnRemoteTariffsPull(ctx context.Context, from, to *time.Time) error
+	// OnRemotePlatformPull pulls tariffs from the particular remote platform (catch-up after platform is back online)
+	OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error
 	// OnRemoteTariffsPullWhenPushNotSupported handles request to pull tariffs from remote platforms which don't support push (fired by cron)
 	OnRemoteTariffsPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error
 	// OnRemoteTariffPut handles put tariff in remote platform
@@ -28,6 +28,8 @@ type TokenUc interface {
 	OnLocalTokenChanged(ctx context.Context, tkn *domain.Token) error
 	// OnRemoteTokensPull handles request to pull tokens from remote platforms (fired by cron)
 	OnRemoteTokensPull(ctx context.Context, from, to *time.Time) error
+	// OnRemotePlatformPull pulls tokens from the particular remote platform (catch-up after platform is back online)
+	OnRemotePlatformPull(ctx context.Context, platformId string, from, to *time.Time) error
 	// OnRemoteTokensPullWhenPushNotSupported handles request to pull tokens from remote platforms which don't support push (fired by cron)
 	OnRemoteTokensPullWhenPushNotSupported(ctx context.Context, from, to *time.Time) error
 	// OnRemoteTokenPut handles put token in remote platform
@@ -37,6 +39,9 @@ type TokenUc interface {
 
 	// GetOrCreateLocalToken first tries to find token by id, is not exists, create a new one local token with default attr
 	GetOrCreateLocalToken(ctx context.Context, tkn *domain.Token) (*domain.Token, error)
+	// SetSessionUc sets session usecase handling sessions of blocked tokens
+	// it's set after construction as the session usecase depends on the token usecase through commands
+	SetSessionUc(sessionUc SessionUc)
 }
 
 type RemoteTokenRepository interface {